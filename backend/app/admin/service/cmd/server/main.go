@@ -14,6 +14,9 @@ import (
 	_ "github.com/tx7do/kratos-bootstrap/registry/etcd"
 	_ "github.com/tx7do/kratos-bootstrap/tracer"
 
+	storageV1 "go-wind-cms/api/gen/go/storage/service/v1"
+
+	"go-wind-cms/pkg/notification"
 	"go-wind-cms/pkg/serviceid"
 )
 
@@ -26,11 +29,13 @@ func newApp(
 	hs *http.Server,
 	gs *grpc.Server,
 	ss *sse.Server,
+	nr *notification.Relay,
 ) *kratos.App {
 	return bootstrap.NewApp(ctx,
 		hs,
 		gs,
 		ss,
+		nr,
 	)
 }
 
@@ -43,6 +48,9 @@ func runApp() error {
 			Version: version,
 		},
 	)
+
+	ctx.RegisterCustomConfig("Storage", &storageV1.StorageOptionWrapper{})
+
 	return bootstrap.RunApp(ctx, initApp)
 }
 
@@ -71,11 +71,17 @@ func initApp(context *bootstrap.Context) (*kratos.App, func(), error) {
 	languageService := service.NewLanguageService(context, languageServiceClient)
 	fileServiceClient := data.NewFileServiceClient(context, discovery)
 	fileService := service.NewFileService(context, fileServiceClient)
-	minIOClient := data.NewMinIoClient(context)
+	storageOption := data.NewStorageOption(context)
+	storageRouter, err := data.NewStorageRouter(context, storageOption)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	uploadGuard := data.NewUploadGuard(context, storageOption)
 	mediaAssetServiceClient := data.NewMediaAssetServiceClient(context, discovery)
-	fileTransferService := service.NewFileTransferService(context, minIOClient, fileServiceClient, mediaAssetServiceClient)
-	translator := data.NewTranslator(context)
-	translatorService := service.NewTranslatorService(context, translator)
+	fileTransferService := service.NewFileTransferService(context, storageRouter, uploadGuard, fileServiceClient, mediaAssetServiceClient)
+	translatorServiceClient := data.NewTranslatorServiceClient(context, discovery)
+	translatorService := service.NewTranslatorService(context, translatorServiceClient)
 	internalMessageServiceClient := data.NewInternalMessageServiceClient(context, discovery)
 	internalMessageCategoryServiceClient := data.NewInternalMessageCategoryServiceClient(context, discovery)
 	internalMessageRecipientServiceClient := data.NewInternalMessageRecipientServiceClient(context, discovery)
@@ -96,6 +102,8 @@ func initApp(context *bootstrap.Context) (*kratos.App, func(), error) {
 	commentService := service.NewCommentService(context, commentServiceClient)
 	interactionAdminServiceClient := data.NewInteractionAdminServiceClient(context, discovery)
 	interactionAdminService := service.NewInteractionAdminService(context, interactionAdminServiceClient)
+	commentModerationServiceClient := data.NewCommentModerationServiceClient(context, discovery)
+	commentModerationService := service.NewCommentModerationService(context, commentModerationServiceClient)
 	postServiceClient := data.NewPostServiceClient(context, discovery)
 	postService := service.NewPostService(context, postServiceClient)
 	categoryServiceClient := data.NewCategoryServiceClient(context, discovery)
@@ -106,6 +114,40 @@ func initApp(context *bootstrap.Context) (*kratos.App, func(), error) {
 	pageService := service.NewPageService(context, pageServiceClient)
 	sectionServiceClient := data.NewSectionServiceClient(context, discovery)
 	sectionService := service.NewSectionService(context, sectionServiceClient)
+	redirectServiceClient := data.NewRedirectServiceClient(context, discovery)
+	redirectService := service.NewRedirectService(context, redirectServiceClient)
+	fieldGroupServiceClient := data.NewFieldGroupServiceClient(context, discovery)
+	fieldGroupService := service.NewFieldGroupService(context, fieldGroupServiceClient)
+	contentModelServiceClient := data.NewContentModelServiceClient(context, discovery)
+	contentModelService := service.NewContentModelService(context, contentModelServiceClient)
+	contentEntryServiceClient := data.NewContentEntryServiceClient(context, discovery)
+	contentEntryService := service.NewContentEntryService(context, contentEntryServiceClient)
+	workflowServiceClient := data.NewWorkflowServiceClient(context, discovery)
+	workflowService := service.NewWorkflowService(context, workflowServiceClient)
+	editorialServiceClient := data.NewEditorialServiceClient(context, discovery)
+	editorialService := service.NewEditorialService(context, editorialServiceClient)
+	trashServiceClient := data.NewTrashServiceClient(context, discovery)
+	trashService := service.NewTrashService(context, trashServiceClient)
+	previewServiceClient := data.NewPreviewServiceClient(context, discovery)
+	previewService := service.NewPreviewService(context, previewServiceClient)
+	releaseServiceClient := data.NewReleaseServiceClient(context, discovery)
+	releaseService := service.NewReleaseService(context, releaseServiceClient)
+	formServiceClient := data.NewFormServiceClient(context, discovery)
+	formService := service.NewFormService(context, formServiceClient)
+	translationJobServiceClient := data.NewTranslationJobServiceClient(context, discovery)
+	translationJobService := service.NewTranslationJobService(context, translationJobServiceClient)
+	translationMemoryServiceClient := data.NewTranslationMemoryServiceClient(context, discovery)
+	translationMemoryService := service.NewTranslationMemoryService(context, translationMemoryServiceClient)
+	glossaryServiceClient := data.NewGlossaryServiceClient(context, discovery)
+	glossaryService := service.NewGlossaryService(context, glossaryServiceClient)
+	translationDashboardServiceClient := data.NewTranslationDashboardServiceClient(context, discovery)
+	translationDashboardService := service.NewTranslationDashboardService(context, translationDashboardServiceClient)
+	aiAssistServiceClient := data.NewAiAssistServiceClient(context, discovery)
+	aiAssistService := service.NewAiAssistService(context, aiAssistServiceClient)
+	seoServiceClient := data.NewSeoServiceClient(context, discovery)
+	seoService := service.NewSeoService(context, seoServiceClient)
+	importJobServiceClient := data.NewImportJobServiceClient(context, discovery)
+	importJobService := service.NewImportJobService(context, importJobServiceClient)
 	siteServiceClient := data.NewSiteServiceClient(context, discovery)
 	siteService := service.NewSiteService(context, siteServiceClient)
 	siteSettingServiceClient := data.NewSiteSettingServiceClient(context, discovery)
@@ -115,7 +157,7 @@ func initApp(context *bootstrap.Context) (*kratos.App, func(), error) {
 	navigationItemServiceClient := data.NewNavigationItemServiceClient(context, discovery)
 	navigationItemService := service.NewNavigationItemService(context, navigationItemServiceClient)
 	mediaAssetService := service.NewMediaAssetService(context, mediaAssetServiceClient)
-	httpServer := server.NewRestServer(context, v, userService, userProfileService, roleService, tenantService, orgUnitService, positionService, menuService, apiService, permissionGroupService, permissionService, adminPortalService, taskService, authenticationService, loginPolicyService, dictTypeService, dictEntryService, languageService, fileService, fileTransferService, translatorService, internalMessageService, internalMessageCategoryService, internalMessageRecipientService, apiAuditLogService, dataAccessAuditLogService, loginAuditLogService, policyEvaluationLogService, operationAuditLogService, permissionAuditLogService, commentService, interactionAdminService, postService, categoryService, tagService, pageService, sectionService, siteService, siteSettingService, navigationService, navigationItemService, mediaAssetService)
+	httpServer := server.NewRestServer(context, v, userService, userProfileService, roleService, tenantService, orgUnitService, positionService, menuService, apiService, permissionGroupService, permissionService, adminPortalService, taskService, authenticationService, loginPolicyService, dictTypeService, dictEntryService, languageService, fileService, fileTransferService, storageRouter, translatorService, internalMessageService, internalMessageCategoryService, internalMessageRecipientService, apiAuditLogService, dataAccessAuditLogService, loginAuditLogService, policyEvaluationLogService, operationAuditLogService, permissionAuditLogService, commentService, interactionAdminService, commentModerationService, postService, categoryService, tagService, pageService, sectionService, redirectService, fieldGroupService, contentModelService, contentEntryService, workflowService, editorialService, trashService, previewService, releaseService, formService, translationJobService, translationMemoryService, glossaryService, translationDashboardService, aiAssistService, seoService, importJobService, siteService, siteSettingService, navigationService, navigationItemService, mediaAssetService)
 	grpcMiddlewares := server.NewGrpcMiddleware(context)
 	grpcServer, err := server.NewGrpcServer(context, grpcMiddlewares)
 	if err != nil {
@@ -123,7 +165,8 @@ func initApp(context *bootstrap.Context) (*kratos.App, func(), error) {
 		return nil, nil, err
 	}
 	sseServer := server.NewSseServer(context, internalMessageService)
-	app := newApp(context, httpServer, grpcServer, sseServer)
+	relay := server.NewNotificationRelay(context, client, sseServer, authenticationServiceClient)
+	app := newApp(context, httpServer, grpcServer, sseServer, relay)
 	return app, func() {
 		cleanup()
 	}, nil
@@ -16,8 +16,6 @@ import (
 	"github.com/tx7do/kratos-bootstrap/rpc"
 
 	"github.com/tx7do/go-utils/captcha"
-	"github.com/tx7do/go-utils/translator"
-	"github.com/tx7do/go-utils/translator/google"
 
 	auditV1 "go-wind-cms/api/gen/go/audit/service/v1"
 	authenticationV1 "go-wind-cms/api/gen/go/authentication/service/v1"
@@ -32,8 +30,10 @@ import (
 	siteV1 "go-wind-cms/api/gen/go/site/service/v1"
 	storageV1 "go-wind-cms/api/gen/go/storage/service/v1"
 	taskV1 "go-wind-cms/api/gen/go/task/service/v1"
+	translatorV1 "go-wind-cms/api/gen/go/translator/service/v1"
 
 	"go-wind-cms/pkg/oss"
+	"go-wind-cms/pkg/scanner"
 	"go-wind-cms/pkg/serviceid"
 )
 
@@ -87,15 +87,82 @@ func NewDiscovery(ctx *bootstrap.Context) registry.Discovery {
 	return discovery
 }
 
-func NewMinIoClient(ctx *bootstrap.Context) *oss.MinIOClient {
-	return oss.NewMinIoClient(ctx.GetConfig(), ctx.GetLogger())
+// NewStorageOption 读取自定义配置 Storage，未配置时返回 nil（仅启用 MinIO）
+func NewStorageOption(ctx *bootstrap.Context) *storageV1.StorageOption {
+	var cfg *storageV1.StorageOptionWrapper
+	rawCfg, ok := ctx.GetCustomConfig("Storage")
+	if ok {
+		cfg = rawCfg.(*storageV1.StorageOptionWrapper)
+	}
+	if cfg == nil {
+		return nil
+	}
+	return cfg.Storage
 }
 
-// NewTranslator 创建翻译器
-func NewTranslator(_ *bootstrap.Context) translator.Translator {
-	return google.NewTranslator(
-		google.WithVersion("v1"),
-	)
+// NewStorageRouter 创建存储驱动路由，MinIO 为默认驱动
+func NewStorageRouter(ctx *bootstrap.Context, cfg *storageV1.StorageOption) (*oss.StorageRouter, error) {
+	opts := &oss.RouterOptions{
+		DefaultProvider: cfg.GetDefaultProvider(),
+		TenantProviders: cfg.GetTenantProviders(),
+	}
+	if cfg.GetLocal() != nil {
+		opts.Local = &oss.LocalOptions{
+			Root:    cfg.GetLocal().GetRoot(),
+			BaseURL: cfg.GetLocal().GetBaseUrl(),
+			SignKey: cfg.GetLocal().GetSignKey(),
+		}
+	}
+	return oss.NewStorageRouter(ctx.GetConfig(), opts, ctx.GetLogger())
+}
+
+// NewUploadGuard 创建上传检查，按租户策略校验 MIME 与大小，配置 scanner 时扫描恶意内容
+func NewUploadGuard(ctx *bootstrap.Context, cfg *storageV1.StorageOption) *oss.UploadGuard {
+	opts := &oss.UploadGuardOptions{
+		DefaultPolicy:  toUploadPolicy(cfg.GetUploadPolicy()),
+		TenantPolicies: make(map[uint32]*oss.UploadPolicy, len(cfg.GetTenantUploadPolicies())),
+	}
+	for tenantID, p := range cfg.GetTenantUploadPolicies() {
+		opts.TenantPolicies[tenantID] = toUploadPolicy(p)
+	}
+
+	if sc := cfg.GetScanner(); sc != nil {
+		opts.FailOpen = sc.GetFailOpen()
+		opts.QuarantineBucket = sc.GetQuarantineBucket()
+
+		switch sc.GetDriver() {
+		case "clamd":
+			opts.Scanner = scanner.NewClamdScanner(
+				sc.GetNetwork(), sc.GetAddress(),
+				scanner.WithClamdTimeout(sc.GetTimeout().AsDuration()),
+			)
+		case "":
+		default:
+			ctx.NewLoggerHelper("upload-guard/data").Warnf("unknown upload scanner driver [%s], scanning disabled", sc.GetDriver())
+		}
+	}
+
+	return oss.NewUploadGuard(opts, ctx.GetLogger())
+}
+
+func toUploadPolicy(p *storageV1.StorageOption_UploadPolicy) *oss.UploadPolicy {
+	if p == nil {
+		return nil
+	}
+	return &oss.UploadPolicy{
+		AllowedMimeTypes: p.GetAllowedMimeTypes(),
+		MaxSize:          p.GetMaxSize(),
+	}
+}
+
+// NewTranslatorServiceClient 创建翻译服务客户端，翻译由核心服务叠加租户的翻译记忆与术语表完成
+func NewTranslatorServiceClient(ctx *bootstrap.Context, r registry.Discovery) translatorV1.TranslatorServiceClient {
+	cli, err := rpc.CreateGrpcClient(ctx.Context(), r, serviceid.NewDiscoveryName(serviceid.CoreService), ctx.GetConfig())
+	if err != nil {
+		return nil
+	}
+
+	return translatorV1.NewTranslatorServiceClient(cli)
 }
 
 // NewAuthorizer 创建权鉴器
@@ -346,6 +413,15 @@ func NewCommentServiceClient(ctx *bootstrap.Context, r registry.Discovery) comme
 	return commentV1.NewCommentServiceClient(cli)
 }
 
+func NewCommentModerationServiceClient(ctx *bootstrap.Context, r registry.Discovery) commentV1.CommentModerationServiceClient {
+	cli, err := rpc.CreateGrpcClient(ctx.Context(), r, serviceid.NewDiscoveryName(serviceid.CoreService), ctx.GetConfig())
+	if err != nil {
+		return nil
+	}
+
+	return commentV1.NewCommentModerationServiceClient(cli)
+}
+
 func NewInteractionAdminServiceClient(ctx *bootstrap.Context, r registry.Discovery) interactionV1.InteractionAdminServiceClient {
 	cli, err := rpc.CreateGrpcClient(ctx.Context(), r, serviceid.NewDiscoveryName(serviceid.CoreService), ctx.GetConfig())
 	if err != nil {
@@ -400,6 +476,159 @@ func NewTagServiceClient(ctx *bootstrap.Context, r registry.Discovery) contentV1
 	return contentV1.NewTagServiceClient(cli)
 }
 
+func NewRedirectServiceClient(ctx *bootstrap.Context, r registry.Discovery) contentV1.RedirectServiceClient {
+	cli, err := rpc.CreateGrpcClient(ctx.Context(), r, serviceid.NewDiscoveryName(serviceid.CoreService), ctx.GetConfig())
+	if err != nil {
+		return nil
+	}
+
+	return contentV1.NewRedirectServiceClient(cli)
+}
+
+func NewFieldGroupServiceClient(ctx *bootstrap.Context, r registry.Discovery) contentV1.FieldGroupServiceClient {
+	cli, err := rpc.CreateGrpcClient(ctx.Context(), r, serviceid.NewDiscoveryName(serviceid.CoreService), ctx.GetConfig())
+	if err != nil {
+		return nil
+	}
+
+	return contentV1.NewFieldGroupServiceClient(cli)
+}
+
+func NewContentModelServiceClient(ctx *bootstrap.Context, r registry.Discovery) contentV1.ContentModelServiceClient {
+	cli, err := rpc.CreateGrpcClient(ctx.Context(), r, serviceid.NewDiscoveryName(serviceid.CoreService), ctx.GetConfig())
+	if err != nil {
+		return nil
+	}
+
+	return contentV1.NewContentModelServiceClient(cli)
+}
+
+func NewContentEntryServiceClient(ctx *bootstrap.Context, r registry.Discovery) contentV1.ContentEntryServiceClient {
+	cli, err := rpc.CreateGrpcClient(ctx.Context(), r, serviceid.NewDiscoveryName(serviceid.CoreService), ctx.GetConfig())
+	if err != nil {
+		return nil
+	}
+
+	return contentV1.NewContentEntryServiceClient(cli)
+}
+
+func NewWorkflowServiceClient(ctx *bootstrap.Context, r registry.Discovery) contentV1.WorkflowServiceClient {
+	cli, err := rpc.CreateGrpcClient(ctx.Context(), r, serviceid.NewDiscoveryName(serviceid.CoreService), ctx.GetConfig())
+	if err != nil {
+		return nil
+	}
+
+	return contentV1.NewWorkflowServiceClient(cli)
+}
+
+func NewEditorialServiceClient(ctx *bootstrap.Context, r registry.Discovery) contentV1.EditorialServiceClient {
+	cli, err := rpc.CreateGrpcClient(ctx.Context(), r, serviceid.NewDiscoveryName(serviceid.CoreService), ctx.GetConfig())
+	if err != nil {
+		return nil
+	}
+
+	return contentV1.NewEditorialServiceClient(cli)
+}
+
+func NewTrashServiceClient(ctx *bootstrap.Context, r registry.Discovery) contentV1.TrashServiceClient {
+	cli, err := rpc.CreateGrpcClient(ctx.Context(), r, serviceid.NewDiscoveryName(serviceid.CoreService), ctx.GetConfig())
+	if err != nil {
+		return nil
+	}
+
+	return contentV1.NewTrashServiceClient(cli)
+}
+
+func NewPreviewServiceClient(ctx *bootstrap.Context, r registry.Discovery) contentV1.PreviewServiceClient {
+	cli, err := rpc.CreateGrpcClient(ctx.Context(), r, serviceid.NewDiscoveryName(serviceid.CoreService), ctx.GetConfig())
+	if err != nil {
+		return nil
+	}
+
+	return contentV1.NewPreviewServiceClient(cli)
+}
+
+func NewReleaseServiceClient(ctx *bootstrap.Context, r registry.Discovery) contentV1.ReleaseServiceClient {
+	cli, err := rpc.CreateGrpcClient(ctx.Context(), r, serviceid.NewDiscoveryName(serviceid.CoreService), ctx.GetConfig())
+	if err != nil {
+		return nil
+	}
+
+	return contentV1.NewReleaseServiceClient(cli)
+}
+
+func NewFormServiceClient(ctx *bootstrap.Context, r registry.Discovery) contentV1.FormServiceClient {
+	cli, err := rpc.CreateGrpcClient(ctx.Context(), r, serviceid.NewDiscoveryName(serviceid.CoreService), ctx.GetConfig())
+	if err != nil {
+		return nil
+	}
+
+	return contentV1.NewFormServiceClient(cli)
+}
+
+func NewTranslationJobServiceClient(ctx *bootstrap.Context, r registry.Discovery) contentV1.TranslationJobServiceClient {
+	cli, err := rpc.CreateGrpcClient(ctx.Context(), r, serviceid.NewDiscoveryName(serviceid.CoreService), ctx.GetConfig())
+	if err != nil {
+		return nil
+	}
+
+	return contentV1.NewTranslationJobServiceClient(cli)
+}
+
+func NewTranslationMemoryServiceClient(ctx *bootstrap.Context, r registry.Discovery) contentV1.TranslationMemoryServiceClient {
+	cli, err := rpc.CreateGrpcClient(ctx.Context(), r, serviceid.NewDiscoveryName(serviceid.CoreService), ctx.GetConfig())
+	if err != nil {
+		return nil
+	}
+
+	return contentV1.NewTranslationMemoryServiceClient(cli)
+}
+
+func NewGlossaryServiceClient(ctx *bootstrap.Context, r registry.Discovery) contentV1.GlossaryServiceClient {
+	cli, err := rpc.CreateGrpcClient(ctx.Context(), r, serviceid.NewDiscoveryName(serviceid.CoreService), ctx.GetConfig())
+	if err != nil {
+		return nil
+	}
+
+	return contentV1.NewGlossaryServiceClient(cli)
+}
+
+func NewTranslationDashboardServiceClient(ctx *bootstrap.Context, r registry.Discovery) contentV1.TranslationDashboardServiceClient {
+	cli, err := rpc.CreateGrpcClient(ctx.Context(), r, serviceid.NewDiscoveryName(serviceid.CoreService), ctx.GetConfig())
+	if err != nil {
+		return nil
+	}
+
+	return contentV1.NewTranslationDashboardServiceClient(cli)
+}
+
+func NewAiAssistServiceClient(ctx *bootstrap.Context, r registry.Discovery) contentV1.AiAssistServiceClient {
+	cli, err := rpc.CreateGrpcClient(ctx.Context(), r, serviceid.NewDiscoveryName(serviceid.CoreService), ctx.GetConfig())
+	if err != nil {
+		return nil
+	}
+
+	return contentV1.NewAiAssistServiceClient(cli)
+}
+
+func NewSeoServiceClient(ctx *bootstrap.Context, r registry.Discovery) contentV1.SeoServiceClient {
+	cli, err := rpc.CreateGrpcClient(ctx.Context(), r, serviceid.NewDiscoveryName(serviceid.CoreService), ctx.GetConfig())
+	if err != nil {
+		return nil
+	}
+
+	return contentV1.NewSeoServiceClient(cli)
+}
+
+func NewImportJobServiceClient(ctx *bootstrap.Context, r registry.Discovery) contentV1.ImportJobServiceClient {
+	cli, err := rpc.CreateGrpcClient(ctx.Context(), r, serviceid.NewDiscoveryName(serviceid.CoreService), ctx.GetConfig())
+	if err != nil {
+		return nil
+	}
+
+	return contentV1.NewImportJobServiceClient(cli)
+}
+
 func NewNavigationServiceClient(ctx *bootstrap.Context, r registry.Discovery) siteV1.NavigationServiceClient {
 	cli, err := rpc.CreateGrpcClient(ctx.Context(), r, serviceid.NewDiscoveryName(serviceid.CoreService), ctx.GetConfig())
 	if err != nil {
@@ -22,13 +22,15 @@ import (
 var ProviderSet = wire.NewSet(
 	data.NewRedisClient,
 	data.NewCaptcha,
-	data.NewMinIoClient,
+	data.NewStorageOption,
+	data.NewStorageRouter,
+	data.NewUploadGuard,
 	data.NewDiscovery,
 
 	data.NewClientType,
 	data.NewAuthorizer,
 
-	data.NewTranslator,
+	data.NewTranslatorServiceClient,
 
 	auth.NewTokenChecker,
 
@@ -71,9 +73,27 @@ var ProviderSet = wire.NewSet(
 	data.NewCategoryServiceClient,
 	data.NewPostServiceClient,
 	data.NewTagServiceClient,
+	data.NewRedirectServiceClient,
+	data.NewFieldGroupServiceClient,
+	data.NewContentModelServiceClient,
+	data.NewContentEntryServiceClient,
+	data.NewWorkflowServiceClient,
+	data.NewEditorialServiceClient,
+	data.NewTrashServiceClient,
+	data.NewPreviewServiceClient,
+	data.NewReleaseServiceClient,
+	data.NewFormServiceClient,
+	data.NewTranslationJobServiceClient,
+	data.NewTranslationMemoryServiceClient,
+	data.NewGlossaryServiceClient,
+	data.NewTranslationDashboardServiceClient,
+	data.NewAiAssistServiceClient,
+	data.NewSeoServiceClient,
+	data.NewImportJobServiceClient,
 
 	data.NewCommentServiceClient,
 	data.NewInteractionAdminServiceClient,
+	data.NewCommentModerationServiceClient,
 
 	data.NewNavigationServiceClient,
 	data.NewNavigationItemServiceClient,
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-kratos/kratos/v2/transport/http"
+
+	"go-wind-cms/app/admin/service/internal/service"
+
+	adminV1 "go-wind-cms/api/gen/go/admin/service/v1"
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+)
+
+func registerFormExportServiceHandler(srv *http.Server, svc *service.FormService) {
+	r := srv.Route("/")
+
+	r.GET("admin/v1/forms/{form_id}/submissions/export", _FormExportService_ExportSubmissions_HTTP_Handler(svc))
+}
+
+// _FormExportService_ExportSubmissions_HTTP_Handler 以附件形式返回导出文件，而非 JSON 包装的字节
+func _FormExportService_ExportSubmissions_HTTP_Handler(svc *service.FormService) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		http.SetOperation(ctx, adminV1.OperationFormExportServiceExportSubmissions)
+
+		var in contentV1.ExportFormSubmissionsRequest
+		var err error
+
+		if err = ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err = ctx.BindVars(&in); err != nil {
+			return err
+		}
+
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			aReq := req.(*contentV1.ExportFormSubmissionsRequest)
+			var resp *contentV1.ExportFormSubmissionsResponse
+			resp, err = svc.ExportSubmissions(ctx, aReq)
+			return resp, err
+		})
+
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+
+		reply := out.(*contentV1.ExportFormSubmissionsResponse)
+		rw := ctx.Response()
+		if rw == nil {
+			return ctx.Result(500, "response writer not available")
+		}
+
+		data := reply.GetFile()
+		rw.Header().Set("Content-Type", reply.GetMime())
+		rw.Header().Set("Content-Disposition", "attachment; filename=\""+reply.GetFileName()+"\"")
+		rw.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		rw.Header().Set("X-Export-Count", strconv.FormatUint(uint64(reply.GetCount()), 10))
+		rw.WriteHeader(200)
+		if _, err = rw.Write(data); err != nil {
+			return ctx.Result(500, err.Error())
+		}
+		return nil
+	}
+}
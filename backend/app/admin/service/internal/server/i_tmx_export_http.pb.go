@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-kratos/kratos/v2/transport/http"
+
+	"go-wind-cms/app/admin/service/internal/service"
+
+	adminV1 "go-wind-cms/api/gen/go/admin/service/v1"
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+)
+
+// registerTmxExportServiceHandler 注册翻译记忆与术语表的 TMX 导出接口，
+// 须在对应的 CRUD 路由之前注册，避免 export 被 {id} 路由匹配
+func registerTmxExportServiceHandler(srv *http.Server, translationMemoryService *service.TranslationMemoryService, glossaryService *service.GlossaryService) {
+	r := srv.Route("/")
+
+	r.GET("admin/v1/translation-memories/export", _TranslationMemoryExportService_ExportTmx_HTTP_Handler(translationMemoryService))
+	r.GET("admin/v1/glossary-terms/export", _GlossaryExportService_ExportTmx_HTTP_Handler(glossaryService))
+}
+
+// tmxExporter 提供 TMX 导出的服务
+type tmxExporter interface {
+	ExportTmx(context.Context, *contentV1.ExportTmxRequest) (*contentV1.ExportTmxResponse, error)
+}
+
+func _TranslationMemoryExportService_ExportTmx_HTTP_Handler(svc *service.TranslationMemoryService) func(ctx http.Context) error {
+	return tmxExportHandler(adminV1.OperationTranslationMemoryExportServiceExportTmx, svc)
+}
+
+func _GlossaryExportService_ExportTmx_HTTP_Handler(svc *service.GlossaryService) func(ctx http.Context) error {
+	return tmxExportHandler(adminV1.OperationGlossaryExportServiceExportTmx, svc)
+}
+
+// tmxExportHandler 以附件形式返回 TMX 文件，而非 JSON 包装的字节
+func tmxExportHandler(operation string, svc tmxExporter) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		http.SetOperation(ctx, operation)
+
+		var in contentV1.ExportTmxRequest
+		var err error
+
+		if err = ctx.BindQuery(&in); err != nil {
+			return err
+		}
+
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			aReq := req.(*contentV1.ExportTmxRequest)
+			var resp *contentV1.ExportTmxResponse
+			resp, err = svc.ExportTmx(ctx, aReq)
+			return resp, err
+		})
+
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+
+		reply := out.(*contentV1.ExportTmxResponse)
+		rw := ctx.Response()
+		if rw == nil {
+			return ctx.Result(500, "response writer not available")
+		}
+
+		data := reply.GetFile()
+		rw.Header().Set("Content-Type", reply.GetMime())
+		rw.Header().Set("Content-Disposition", "attachment; filename=\""+reply.GetFileName()+"\"")
+		rw.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		rw.Header().Set("X-Export-Count", strconv.FormatUint(uint64(reply.GetCount()), 10))
+		rw.WriteHeader(200)
+		if _, err = rw.Write(data); err != nil {
+			return ctx.Result(500, err.Error())
+		}
+		return nil
+	}
+}
@@ -0,0 +1,41 @@
+package server
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	sseServer "github.com/tx7do/kratos-transport/transport/sse"
+
+	authenticationV1 "go-wind-cms/api/gen/go/authentication/service/v1"
+
+	"go-wind-cms/pkg/notification"
+)
+
+// NewNotificationRelay 创建站内通知中继：订阅 core 发布的推送事件，经 SSE 推送给在线的后台用户。
+func NewNotificationRelay(
+	ctx *bootstrap.Context,
+	rdb *redis.Client,
+	ss *sseServer.Server,
+	authenticationServiceClient authenticationV1.AuthenticationServiceClient,
+) *notification.Relay {
+	var publisher notification.Publisher
+	if ss != nil {
+		publisher = ss
+	}
+
+	return notification.NewRelay(rdb, notification.DefaultPushChannel, publisher,
+		func(c context.Context, userID uint32) ([]string, error) {
+			resp, err := authenticationServiceClient.GetAccessTokens(c, &authenticationV1.GetAccessTokensRequest{
+				UserId:     userID,
+				ClientType: authenticationV1.ClientType_admin,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return resp.GetAccessTokens(), nil
+		},
+		ctx.GetLogger(),
+	)
+}
@@ -27,6 +27,7 @@ import (
 	"go-wind-cms/pkg/middleware/auth"
 	applogging "go-wind-cms/pkg/middleware/logging"
 	entmiddleware "go-wind-cms/pkg/middleware/ent"
+	"go-wind-cms/pkg/oss"
 )
 
 // NewRestMiddleware 创建中间件
@@ -113,6 +114,7 @@ func NewRestServer(
 
 	fileSvc *service.FileService,
 	fileTransferService *service.FileTransferService,
+	storage *oss.StorageRouter,
 
 	translatorService *service.TranslatorService,
 
@@ -129,12 +131,30 @@ func NewRestServer(
 
 	commentService *service.CommentService,
 	interactionAdminService *service.InteractionAdminService,
+	commentModerationService *service.CommentModerationService,
 
 	postService *service.PostService,
 	categoryService *service.CategoryService,
 	tagService *service.TagService,
 	pageService *service.PageService,
 	sectionService *service.SectionService,
+	redirectService *service.RedirectService,
+	fieldGroupService *service.FieldGroupService,
+	contentModelService *service.ContentModelService,
+	contentEntryService *service.ContentEntryService,
+	workflowService *service.WorkflowService,
+	editorialService *service.EditorialService,
+	trashService *service.TrashService,
+	previewService *service.PreviewService,
+	releaseService *service.ReleaseService,
+	formService *service.FormService,
+	translationJobService *service.TranslationJobService,
+	translationMemoryService *service.TranslationMemoryService,
+	glossaryService *service.GlossaryService,
+	translationDashboardService *service.TranslationDashboardService,
+	aiAssistService *service.AiAssistService,
+	seoService *service.SeoService,
+	importJobService *service.ImportJobService,
 
 	siteService *service.SiteService,
 	siteSettingService *service.SiteSettingService,
@@ -195,13 +215,40 @@ func NewRestServer(
 	registerFileTransferServiceHandler(srv, fileTransferService)
 	adminV1.RegisterFileServiceHTTPServer(srv, fileSvc)
 
+	// 本地存储驱动的签名地址由 REST 服务器提供读写，签名即鉴权，不经过业务中间件
+	if local := storage.Local(); local != nil {
+		srv.HandlePrefix(oss.LocalRoutePrefix, local.Handler())
+	}
+
 	adminV1.RegisterPostServiceHTTPServer(srv, postService)
 	adminV1.RegisterCategoryServiceHTTPServer(srv, categoryService)
 	adminV1.RegisterTagServiceHTTPServer(srv, tagService)
 	adminV1.RegisterCommentServiceHTTPServer(srv, commentService)
 	adminV1.RegisterInteractionAdminServiceHTTPServer(srv, interactionAdminService)
+	adminV1.RegisterCommentModerationServiceHTTPServer(srv, commentModerationService)
 	adminV1.RegisterPageServiceHTTPServer(srv, pageService)
 	adminV1.RegisterSectionServiceHTTPServer(srv, sectionService)
+	adminV1.RegisterRedirectServiceHTTPServer(srv, redirectService)
+	adminV1.RegisterFieldGroupServiceHTTPServer(srv, fieldGroupService)
+	adminV1.RegisterContentModelServiceHTTPServer(srv, contentModelService)
+	adminV1.RegisterContentEntryServiceHTTPServer(srv, contentEntryService)
+	adminV1.RegisterWorkflowServiceHTTPServer(srv, workflowService)
+	adminV1.RegisterEditorialServiceHTTPServer(srv, editorialService)
+	adminV1.RegisterTrashServiceHTTPServer(srv, trashService)
+	adminV1.RegisterPreviewServiceHTTPServer(srv, previewService)
+	adminV1.RegisterReleaseServiceHTTPServer(srv, releaseService)
+	adminV1.RegisterFormServiceHTTPServer(srv, formService)
+	// 导出接口返回文件下载，与文件传输服务一样需要手动注册 Handler
+	registerFormExportServiceHandler(srv, formService)
+	adminV1.RegisterTranslationJobServiceHTTPServer(srv, translationJobService)
+	// TMX 导出同样手动注册，且须先于 CRUD 路由注册
+	registerTmxExportServiceHandler(srv, translationMemoryService, glossaryService)
+	adminV1.RegisterTranslationMemoryServiceHTTPServer(srv, translationMemoryService)
+	adminV1.RegisterGlossaryServiceHTTPServer(srv, glossaryService)
+	adminV1.RegisterTranslationDashboardServiceHTTPServer(srv, translationDashboardService)
+	adminV1.RegisterAiAssistServiceHTTPServer(srv, aiAssistService)
+	adminV1.RegisterSeoServiceHTTPServer(srv, seoService)
+	adminV1.RegisterImportJobServiceHTTPServer(srv, importJobService)
 
 	adminV1.RegisterSiteSettingServiceHTTPServer(srv, siteSettingService)
 	adminV1.RegisterSiteServiceHTTPServer(srv, siteService)
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	adminV1 "go-wind-cms/api/gen/go/admin/service/v1"
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+)
+
+type AiAssistService struct {
+	adminV1.AiAssistServiceHTTPServer
+
+	aiAssistServiceClient contentV1.AiAssistServiceClient
+	log                   *log.Helper
+}
+
+func NewAiAssistService(ctx *bootstrap.Context, aiAssistServiceClient contentV1.AiAssistServiceClient) *AiAssistService {
+	return &AiAssistService{
+		log:                   ctx.NewLoggerHelper("ai-assist/service/admin-service"),
+		aiAssistServiceClient: aiAssistServiceClient,
+	}
+}
+
+func (s *AiAssistService) ListAiAssistJobs(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListAiAssistJobResponse, error) {
+	return s.aiAssistServiceClient.ListAiAssistJobs(ctx, req)
+}
+
+func (s *AiAssistService) GetAiAssistJob(ctx context.Context, req *contentV1.GetAiAssistJobRequest) (*contentV1.AiAssistJob, error) {
+	return s.aiAssistServiceClient.GetAiAssistJob(ctx, req)
+}
+
+func (s *AiAssistService) CreateAiAssistJob(ctx context.Context, req *contentV1.CreateAiAssistJobRequest) (*contentV1.AiAssistJob, error) {
+	if req == nil || req.GetEntityId() == 0 || req.GetLanguageCode() == "" || len(req.GetTasks()) == 0 {
+		return nil, adminV1.ErrorBadRequest("invalid parameter")
+	}
+
+	return s.aiAssistServiceClient.CreateAiAssistJob(ctx, req)
+}
+
+func (s *AiAssistService) GetLlmUsage(ctx context.Context, req *contentV1.GetLlmUsageRequest) (*contentV1.LlmUsageReport, error) {
+	return s.aiAssistServiceClient.GetLlmUsage(ctx, req)
+}
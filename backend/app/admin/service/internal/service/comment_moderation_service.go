@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	adminV1 "go-wind-cms/api/gen/go/admin/service/v1"
+	commentV1 "go-wind-cms/api/gen/go/comment/service/v1"
+)
+
+// CommentModerationService 是 admin BFF 对 core CommentModerationService 的纯透传转发器。
+// 租户隔离与审计均在 core 侧执行（viewer context + OperationAuditLog）。
+type CommentModerationService struct {
+	adminV1.CommentModerationServiceHTTPServer
+
+	commentModerationServiceClient commentV1.CommentModerationServiceClient
+	log                            *log.Helper
+}
+
+func NewCommentModerationService(
+	ctx *bootstrap.Context,
+	commentModerationServiceClient commentV1.CommentModerationServiceClient,
+) *CommentModerationService {
+	return &CommentModerationService{
+		commentModerationServiceClient: commentModerationServiceClient,
+		log:                            ctx.NewLoggerHelper("comment-moderation/service/admin-service"),
+	}
+}
+
+func (s *CommentModerationService) ListModerationQueue(ctx context.Context, req *commentV1.ListModerationQueueRequest) (*commentV1.ListCommentResponse, error) {
+	return s.commentModerationServiceClient.ListModerationQueue(ctx, req)
+}
+
+func (s *CommentModerationService) BulkModerate(ctx context.Context, req *commentV1.BulkModerateRequest) (*commentV1.BulkModerateResponse, error) {
+	return s.commentModerationServiceClient.BulkModerate(ctx, req)
+}
+
+func (s *CommentModerationService) BanAuthor(ctx context.Context, req *commentV1.BanAuthorRequest) (*commentV1.AuthorRuleResponse, error) {
+	return s.commentModerationServiceClient.BanAuthor(ctx, req)
+}
+
+func (s *CommentModerationService) TrustAuthor(ctx context.Context, req *commentV1.TrustAuthorRequest) (*commentV1.AuthorRuleResponse, error) {
+	return s.commentModerationServiceClient.TrustAuthor(ctx, req)
+}
+
+func (s *CommentModerationService) ListAuthorRules(ctx context.Context, req *paginationV1.PagingRequest) (*commentV1.ListCommentAuthorRuleResponse, error) {
+	return s.commentModerationServiceClient.ListAuthorRules(ctx, req)
+}
+
+func (s *CommentModerationService) DeleteAuthorRule(ctx context.Context, req *commentV1.DeleteCommentAuthorRuleRequest) (*emptypb.Empty, error) {
+	return s.commentModerationServiceClient.DeleteAuthorRule(ctx, req)
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/go-utils/trans"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	adminV1 "go-wind-cms/api/gen/go/admin/service/v1"
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/middleware/auth"
+)
+
+type ContentEntryService struct {
+	adminV1.ContentEntryServiceHTTPServer
+
+	contentEntryServiceClient contentV1.ContentEntryServiceClient
+	log                       *log.Helper
+}
+
+func NewContentEntryService(ctx *bootstrap.Context, contentEntryServiceClient contentV1.ContentEntryServiceClient) *ContentEntryService {
+	return &ContentEntryService{
+		log:                       ctx.NewLoggerHelper("content-entry/service/admin-service"),
+		contentEntryServiceClient: contentEntryServiceClient,
+	}
+}
+
+func (s *ContentEntryService) List(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListContentEntryResponse, error) {
+	return s.contentEntryServiceClient.List(ctx, req)
+}
+
+func (s *ContentEntryService) Get(ctx context.Context, req *contentV1.GetContentEntryRequest) (*contentV1.ContentEntry, error) {
+	return s.contentEntryServiceClient.Get(ctx, req)
+}
+
+func (s *ContentEntryService) Search(ctx context.Context, req *contentV1.SearchContentEntriesRequest) (*contentV1.SearchContentEntriesResponse, error) {
+	return s.contentEntryServiceClient.Search(ctx, req)
+}
+
+func (s *ContentEntryService) Create(ctx context.Context, req *contentV1.CreateContentEntryRequest) (*contentV1.ContentEntry, error) {
+	if req == nil || req.Data == nil {
+		return nil, adminV1.ErrorBadRequest("invalid parameter")
+	}
+
+	// 获取操作人信息
+	operator, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Data.CreatedBy = trans.Ptr(operator.UserId)
+	if req.Data.AuthorId == nil {
+		req.Data.AuthorId = trans.Ptr(operator.UserId)
+	}
+
+	return s.contentEntryServiceClient.Create(ctx, req)
+}
+
+func (s *ContentEntryService) Update(ctx context.Context, req *contentV1.UpdateContentEntryRequest) (*contentV1.ContentEntry, error) {
+	if req == nil || req.Data == nil {
+		return nil, adminV1.ErrorBadRequest("invalid parameter")
+	}
+
+	// 获取操作人信息
+	operator, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Data.Id = trans.Ptr(req.GetId())
+
+	req.Data.UpdatedBy = trans.Ptr(operator.GetUserId())
+	if req.UpdateMask != nil {
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "updated_by")
+	}
+
+	return s.contentEntryServiceClient.Update(ctx, req)
+}
+
+func (s *ContentEntryService) Delete(ctx context.Context, req *contentV1.DeleteContentEntryRequest) (*emptypb.Empty, error) {
+	return s.contentEntryServiceClient.Delete(ctx, req)
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	adminV1 "go-wind-cms/api/gen/go/admin/service/v1"
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+)
+
+type EditorialService struct {
+	adminV1.EditorialServiceHTTPServer
+
+	editorialServiceClient contentV1.EditorialServiceClient
+	log                    *log.Helper
+}
+
+func NewEditorialService(ctx *bootstrap.Context, editorialServiceClient contentV1.EditorialServiceClient) *EditorialService {
+	return &EditorialService{
+		log:                    ctx.NewLoggerHelper("editorial/service/admin-service"),
+		editorialServiceClient: editorialServiceClient,
+	}
+}
+
+func (s *EditorialService) GetPostWorkflow(ctx context.Context, req *contentV1.GetPostWorkflowRequest) (*contentV1.PostWorkflowState, error) {
+	return s.editorialServiceClient.GetPostWorkflow(ctx, req)
+}
+
+func (s *EditorialService) TransitionPost(ctx context.Context, req *contentV1.TransitionPostRequest) (*contentV1.PostWorkflowState, error) {
+	return s.editorialServiceClient.TransitionPost(ctx, req)
+}
+
+func (s *EditorialService) AssignReviewers(ctx context.Context, req *contentV1.AssignReviewersRequest) (*contentV1.PostWorkflowState, error) {
+	return s.editorialServiceClient.AssignReviewers(ctx, req)
+}
+
+func (s *EditorialService) AddReviewComment(ctx context.Context, req *contentV1.AddReviewCommentRequest) (*contentV1.PostReviewLog, error) {
+	return s.editorialServiceClient.AddReviewComment(ctx, req)
+}
+
+func (s *EditorialService) ListReviewLogs(ctx context.Context, req *contentV1.ListReviewLogsRequest) (*contentV1.ListPostReviewLogResponse, error) {
+	return s.editorialServiceClient.ListReviewLogs(ctx, req)
+}
+
+func (s *EditorialService) ListReviewQueue(ctx context.Context, req *contentV1.ListReviewQueueRequest) (*contentV1.ListReviewQueueResponse, error) {
+	return s.editorialServiceClient.ListReviewQueue(ctx, req)
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/go-utils/trans"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	adminV1 "go-wind-cms/api/gen/go/admin/service/v1"
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/middleware/auth"
+)
+
+type FieldGroupService struct {
+	adminV1.FieldGroupServiceHTTPServer
+
+	fieldGroupServiceClient contentV1.FieldGroupServiceClient
+	log                     *log.Helper
+}
+
+func NewFieldGroupService(ctx *bootstrap.Context, fieldGroupServiceClient contentV1.FieldGroupServiceClient) *FieldGroupService {
+	return &FieldGroupService{
+		log:                     ctx.NewLoggerHelper("field-group/service/admin-service"),
+		fieldGroupServiceClient: fieldGroupServiceClient,
+	}
+}
+
+func (s *FieldGroupService) List(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListFieldGroupResponse, error) {
+	return s.fieldGroupServiceClient.List(ctx, req)
+}
+
+func (s *FieldGroupService) Get(ctx context.Context, req *contentV1.GetFieldGroupRequest) (*contentV1.FieldGroup, error) {
+	return s.fieldGroupServiceClient.Get(ctx, req)
+}
+
+func (s *FieldGroupService) Create(ctx context.Context, req *contentV1.CreateFieldGroupRequest) (*contentV1.FieldGroup, error) {
+	if req == nil || req.Data == nil {
+		return nil, adminV1.ErrorBadRequest("invalid parameter")
+	}
+
+	// 获取操作人信息
+	operator, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Data.CreatedBy = trans.Ptr(operator.UserId)
+
+	return s.fieldGroupServiceClient.Create(ctx, req)
+}
+
+func (s *FieldGroupService) Update(ctx context.Context, req *contentV1.UpdateFieldGroupRequest) (*contentV1.FieldGroup, error) {
+	if req == nil || req.Data == nil {
+		return nil, adminV1.ErrorBadRequest("invalid parameter")
+	}
+
+	// 获取操作人信息
+	operator, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Data.Id = trans.Ptr(req.GetId())
+
+	req.Data.UpdatedBy = trans.Ptr(operator.GetUserId())
+	if req.UpdateMask != nil {
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "updated_by")
+	}
+
+	return s.fieldGroupServiceClient.Update(ctx, req)
+}
+
+func (s *FieldGroupService) Delete(ctx context.Context, req *contentV1.DeleteFieldGroupRequest) (*emptypb.Empty, error) {
+	return s.fieldGroupServiceClient.Delete(ctx, req)
+}
@@ -8,7 +8,6 @@ import (
 	"strings"
 
 	"github.com/go-kratos/kratos/v2/log"
-	"github.com/minio/minio-go/v7"
 
 	"github.com/tx7do/go-utils/id"
 	"github.com/tx7do/go-utils/trans"
@@ -28,7 +27,8 @@ type FileTransferService struct {
 
 	log *log.Helper
 
-	mc *oss.MinIOClient
+	storage     *oss.StorageRouter
+	uploadGuard *oss.UploadGuard
 
 	fileServiceClient       storageV1.FileServiceClient
 	mediaAssetServiceClient mediaV1.MediaAssetServiceClient
@@ -36,13 +36,15 @@ type FileTransferService struct {
 
 func NewFileTransferService(
 	ctx *bootstrap.Context,
-	mc *oss.MinIOClient,
+	storage *oss.StorageRouter,
+	uploadGuard *oss.UploadGuard,
 	fileServiceClient storageV1.FileServiceClient,
 	mediaAssetServiceClient mediaV1.MediaAssetServiceClient,
 ) *FileTransferService {
 	return &FileTransferService{
 		log:                     ctx.NewLoggerHelper("file-transfer/service/admin-service"),
-		mc:                      mc,
+		storage:                 storage,
+		uploadGuard:             uploadGuard,
 		fileServiceClient:       fileServiceClient,
 		mediaAssetServiceClient: mediaAssetServiceClient,
 	}
@@ -93,7 +95,8 @@ func (s *FileTransferService) recordFile(
 	ctx context.Context,
 	tenantID, userID uint32,
 	sourceFileName string,
-	info minio.UploadInfo,
+	provider storageV1.OSSProvider,
+	info oss.ObjectInfo,
 	downloadUrl string,
 ) (*storageV1.File, error) {
 
@@ -103,7 +106,7 @@ func (s *FileTransferService) recordFile(
 	var file *storageV1.File
 	if file, err = s.fileServiceClient.Create(ctx, &storageV1.CreateFileRequest{
 		Data: &storageV1.File{
-			Provider:      trans.Ptr(storageV1.OSSProvider_MINIO),
+			Provider:      trans.Ptr(provider),
 			BucketName:    trans.Ptr(info.Bucket),
 			SaveFileName:  trans.Ptr(fileName + "." + ext),
 			FileDirectory: trans.Ptr(dir),
@@ -122,6 +125,32 @@ func (s *FileTransferService) recordFile(
 	return file, nil
 }
 
+// quarantineUpload 将检出恶意内容的上传转存至隔离桶并记录文件元数据，隔离文件不对外提供下载地址
+func (s *FileTransferService) quarantineUpload(
+	ctx context.Context,
+	driver oss.Storage,
+	tenantID, userID uint32,
+	sourceFileName, objectName string,
+	upload *oss.InspectedUpload,
+) (*storageV1.File, string, error) {
+	info, storagePath, err := s.uploadGuard.Quarantine(ctx, driver, objectName, sourceFileName, upload)
+	if err != nil {
+		return nil, "", err
+	}
+
+	file, err := s.recordFile(ctx, tenantID, userID, sourceFileName, driver.Provider(), info, "")
+	if err != nil {
+		if delErr := driver.DeleteFile(ctx, info.Bucket, info.Key); delErr != nil {
+			s.log.Errorf("cleanup quarantined object after recordFile failure failed: %s", delErr.Error())
+		}
+		return nil, "", err
+	}
+
+	s.log.Warnf("upload [%s] of tenant [%d] user [%d] quarantined: %s", sourceFileName, tenantID, userID, upload.Signature)
+
+	return file, storagePath, nil
+}
+
 // directUploadFile 直接上传文件
 func (s *FileTransferService) directUploadFile(ctx context.Context, req *storageV1.UploadFileRequest) (*storageV1.UploadFileResponse, error) {
 	if req == nil || req.StorageObject == nil {
@@ -163,12 +192,32 @@ func (s *FileTransferService) directUploadFile(ctx context.Context, req *storage
 		)
 	}
 
-	info, _, downloadUrl, err := s.mc.UploadFile(
+	upload, err := s.uploadGuard.Inspect(ctx, operator.GetTenantId(), req.GetMime(), reader, objectSize)
+	if err != nil {
+		return nil, err
+	}
+	defer upload.Close()
+
+	driver := s.storage.ForTenant(operator.GetTenantId())
+
+	if upload.Infected {
+		if _, _, err = s.quarantineUpload(
+			ctx, driver,
+			operator.GetTenantId(), operator.GetUserId(),
+			req.GetSourceFileName(), req.GetStorageObject().GetObjectName(),
+			upload,
+		); err != nil {
+			return nil, err
+		}
+		return nil, storageV1.ErrorUnprocessableEntity("file rejected: malware detected (%s)", upload.Signature)
+	}
+
+	info, _, downloadUrl, err := driver.UploadFile(
 		ctx,
 		req.GetStorageObject().GetBucketName(),
 		req.GetStorageObject().GetObjectName(),
 		req.GetMime(),
-		reader, objectSize,
+		upload.Reader(), upload.Size(),
 	)
 	if err != nil {
 		return nil, err
@@ -178,9 +227,10 @@ func (s *FileTransferService) directUploadFile(ctx context.Context, req *storage
 		ctx,
 		operator.GetTenantId(), operator.GetUserId(),
 		req.GetSourceFileName(),
+		driver.Provider(),
 		info, downloadUrl); err != nil {
 		// 元数据写入失败，回滚已上传的对象，避免产生孤儿文件
-		if delErr := s.mc.DeleteFile(ctx, req.GetStorageObject().GetBucketName(), req.GetStorageObject().GetObjectName()); delErr != nil {
+		if delErr := driver.DeleteFile(ctx, req.GetStorageObject().GetBucketName(), req.GetStorageObject().GetObjectName()); delErr != nil {
 			s.log.Errorf("cleanup orphaned object after recordFile failure failed: %s", delErr.Error())
 		}
 		return nil, err
@@ -275,6 +325,11 @@ func (s *FileTransferService) DownloadFile(ctx context.Context, req *storageV1.D
 			return nil, storageV1.ErrorDownloadFailed("forbidden: file does not belong to caller's tenant")
 		}
 
+		driver, err := s.storage.ForProvider(resp.GetProvider())
+		if err != nil {
+			return nil, err
+		}
+
 		req.Selector = &storageV1.DownloadFileRequest_StorageObject{
 			StorageObject: &storageV1.StorageObject{
 				BucketName: resp.BucketName,
@@ -282,7 +337,7 @@ func (s *FileTransferService) DownloadFile(ctx context.Context, req *storageV1.D
 			},
 		}
 
-		return s.mc.DownloadFile(ctx, req)
+		return driver.DownloadFile(ctx, req)
 
 	case *storageV1.DownloadFileRequest_StorageObject:
 		return nil, storageV1.ErrorDownloadFailed("storageObject selector is not allowed for external callers, use fileId")
@@ -410,14 +465,26 @@ func (s *FileTransferService) UploadMediaAsset(ctx context.Context, req *storage
 	req.TenantId = trans.Ptr(operator.GetTenantId())
 	req.UserId = trans.Ptr(operator.GetUserId())
 
-	var bucketName = s.mimeTypeToBucketName(req.GetMimeType())
+	upload, err := s.uploadGuard.Inspect(ctx, operator.GetTenantId(), req.GetMimeType(), reader, objectSize)
+	if err != nil {
+		return nil, err
+	}
+	defer upload.Close()
+
+	driver := s.storage.ForTenant(operator.GetTenantId())
+
+	if upload.Infected {
+		return nil, s.quarantineMediaAsset(ctx, driver, operator.GetTenantId(), operator.GetUserId(), req, upload)
+	}
+
+	var bucketName = s.mimeTypeToBucketName(upload.MimeType)
 
-	info, storagePath, downloadUrl, err := s.mc.UploadFile(
+	info, storagePath, downloadUrl, err := driver.UploadFile(
 		ctx,
 		bucketName,
 		"",
-		req.GetMimeType(),
-		reader, objectSize,
+		upload.MimeType,
+		upload.Reader(), upload.Size(),
 	)
 	if err != nil {
 		return nil, err
@@ -428,10 +495,11 @@ func (s *FileTransferService) UploadMediaAsset(ctx context.Context, req *storage
 		ctx,
 		operator.GetTenantId(), operator.GetUserId(),
 		req.GetSourceFileName(),
+		driver.Provider(),
 		info, downloadUrl,
 	); err != nil {
 		// 元数据写入失败，回滚已上传的对象，避免孤儿文件
-		if delErr := s.mc.DeleteFile(ctx, bucketName, info.Key); delErr != nil {
+		if delErr := driver.DeleteFile(ctx, bucketName, info.Key); delErr != nil {
 			s.log.Errorf("cleanup orphaned object after recordFile failure failed: %s", delErr.Error())
 		}
 		return nil, err
@@ -446,15 +514,15 @@ func (s *FileTransferService) UploadMediaAsset(ctx context.Context, req *storage
 			Url:              trans.Ptr(downloadUrl),
 			StoragePath:      trans.Ptr(storagePath),
 			Size:             trans.Ptr(uint64(info.Size)),
-			MimeType:         req.MimeType,
+			MimeType:         trans.Ptr(upload.MimeType),
 			Filename:         req.SourceFileName,
-			Type:             s.mimeTypeToAssetType(req.GetMimeType()),
+			Type:             s.mimeTypeToAssetType(upload.MimeType),
 			CreatedBy:        trans.Ptr(operator.GetUserId()),
 			ProcessingStatus: trans.Ptr(mediaV1.MediaAsset_PROCESSING_STATUS_COMPLETED),
 		},
 	}); err != nil {
 		// MediaAsset 创建失败，回滚已上传的对象及其 File 元数据，避免孤儿文件/悬空记录
-		if delErr := s.mc.DeleteFile(ctx, bucketName, info.Key); delErr != nil {
+		if delErr := driver.DeleteFile(ctx, bucketName, info.Key); delErr != nil {
 			s.log.Errorf("cleanup orphaned object after mediaasset failure failed: %s", delErr.Error())
 		}
 		if file != nil && file.Id != nil {
@@ -471,3 +539,38 @@ func (s *FileTransferService) UploadMediaAsset(ctx context.Context, req *storage
 		ObjectName: trans.Ptr(downloadUrl),
 	}, nil
 }
+
+// quarantineMediaAsset 隔离检出恶意内容的媒体上传，并以隔离状态创建媒体资源，便于管理员审查
+func (s *FileTransferService) quarantineMediaAsset(
+	ctx context.Context,
+	driver oss.Storage,
+	tenantID, userID uint32,
+	req *storageV1.UploadMediaAssetRequest,
+	upload *oss.InspectedUpload,
+) error {
+	file, storagePath, err := s.quarantineUpload(ctx, driver, tenantID, userID, req.GetSourceFileName(), "", upload)
+	if err != nil {
+		return err
+	}
+
+	if _, err = s.mediaAssetServiceClient.Create(ctx, &mediaV1.CreateMediaAssetRequest{
+		Data: &mediaV1.MediaAsset{
+			FileId:           file.Id,
+			AltText:          req.AltText,
+			Title:            req.Title,
+			Caption:          req.Caption,
+			StoragePath:      trans.Ptr(storagePath),
+			Size:             trans.Ptr(uint64(upload.Size())),
+			MimeType:         trans.Ptr(upload.MimeType),
+			Filename:         req.SourceFileName,
+			Type:             s.mimeTypeToAssetType(upload.MimeType),
+			CreatedBy:        trans.Ptr(userID),
+			ProcessingStatus: trans.Ptr(mediaV1.MediaAsset_PROCESSING_STATUS_QUARANTINED),
+			ProcessingError:  trans.Ptr("malware detected: " + upload.Signature),
+		},
+	}); err != nil {
+		s.log.Errorf("create quarantined media asset failed: %s", err.Error())
+	}
+
+	return storageV1.ErrorUnprocessableEntity("file rejected: malware detected (%s)", upload.Signature)
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/go-utils/trans"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	adminV1 "go-wind-cms/api/gen/go/admin/service/v1"
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/middleware/auth"
+)
+
+type FormService struct {
+	adminV1.FormServiceHTTPServer
+
+	formServiceClient contentV1.FormServiceClient
+	log               *log.Helper
+}
+
+func NewFormService(ctx *bootstrap.Context, formServiceClient contentV1.FormServiceClient) *FormService {
+	return &FormService{
+		log:               ctx.NewLoggerHelper("form/service/admin-service"),
+		formServiceClient: formServiceClient,
+	}
+}
+
+func (s *FormService) List(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListFormResponse, error) {
+	return s.formServiceClient.List(ctx, req)
+}
+
+func (s *FormService) Get(ctx context.Context, req *contentV1.GetFormRequest) (*contentV1.Form, error) {
+	return s.formServiceClient.Get(ctx, req)
+}
+
+func (s *FormService) Create(ctx context.Context, req *contentV1.CreateFormRequest) (*contentV1.Form, error) {
+	if req == nil || req.Data == nil {
+		return nil, adminV1.ErrorBadRequest("invalid parameter")
+	}
+
+	// 获取操作人信息
+	operator, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Data.CreatedBy = trans.Ptr(operator.UserId)
+
+	return s.formServiceClient.Create(ctx, req)
+}
+
+func (s *FormService) Update(ctx context.Context, req *contentV1.UpdateFormRequest) (*contentV1.Form, error) {
+	if req == nil || req.Data == nil {
+		return nil, adminV1.ErrorBadRequest("invalid parameter")
+	}
+
+	// 获取操作人信息
+	operator, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Data.Id = trans.Ptr(req.GetId())
+
+	req.Data.UpdatedBy = trans.Ptr(operator.GetUserId())
+	if req.UpdateMask != nil {
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "updated_by")
+	}
+
+	return s.formServiceClient.Update(ctx, req)
+}
+
+func (s *FormService) Delete(ctx context.Context, req *contentV1.DeleteFormRequest) (*emptypb.Empty, error) {
+	return s.formServiceClient.Delete(ctx, req)
+}
+
+func (s *FormService) ListSubmissions(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListFormSubmissionResponse, error) {
+	return s.formServiceClient.ListSubmissions(ctx, req)
+}
+
+func (s *FormService) ExportSubmissions(ctx context.Context, req *contentV1.ExportFormSubmissionsRequest) (*contentV1.ExportFormSubmissionsResponse, error) {
+	return s.formServiceClient.ExportSubmissions(ctx, req)
+}
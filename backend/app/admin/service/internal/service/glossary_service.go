@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/go-utils/trans"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	adminV1 "go-wind-cms/api/gen/go/admin/service/v1"
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/middleware/auth"
+)
+
+type GlossaryService struct {
+	adminV1.GlossaryServiceHTTPServer
+
+	glossaryServiceClient contentV1.GlossaryServiceClient
+	log                   *log.Helper
+}
+
+func NewGlossaryService(ctx *bootstrap.Context, glossaryServiceClient contentV1.GlossaryServiceClient) *GlossaryService {
+	return &GlossaryService{
+		log:                   ctx.NewLoggerHelper("glossary/service/admin-service"),
+		glossaryServiceClient: glossaryServiceClient,
+	}
+}
+
+func (s *GlossaryService) List(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListGlossaryTermResponse, error) {
+	return s.glossaryServiceClient.List(ctx, req)
+}
+
+func (s *GlossaryService) Get(ctx context.Context, req *contentV1.GetGlossaryTermRequest) (*contentV1.GlossaryTerm, error) {
+	return s.glossaryServiceClient.Get(ctx, req)
+}
+
+func (s *GlossaryService) Create(ctx context.Context, req *contentV1.CreateGlossaryTermRequest) (*contentV1.GlossaryTerm, error) {
+	if req == nil || req.Data == nil {
+		return nil, adminV1.ErrorBadRequest("invalid parameter")
+	}
+
+	// 获取操作人信息
+	operator, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Data.CreatedBy = trans.Ptr(operator.UserId)
+
+	return s.glossaryServiceClient.Create(ctx, req)
+}
+
+func (s *GlossaryService) Update(ctx context.Context, req *contentV1.UpdateGlossaryTermRequest) (*contentV1.GlossaryTerm, error) {
+	if req == nil || req.Data == nil {
+		return nil, adminV1.ErrorBadRequest("invalid parameter")
+	}
+
+	// 获取操作人信息
+	operator, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Data.Id = trans.Ptr(req.GetId())
+
+	req.Data.UpdatedBy = trans.Ptr(operator.GetUserId())
+	if req.UpdateMask != nil {
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "updated_by")
+	}
+
+	return s.glossaryServiceClient.Update(ctx, req)
+}
+
+func (s *GlossaryService) Delete(ctx context.Context, req *contentV1.DeleteGlossaryTermRequest) (*emptypb.Empty, error) {
+	return s.glossaryServiceClient.Delete(ctx, req)
+}
+
+// ImportTmx 导入 TMX 文件
+func (s *GlossaryService) ImportTmx(ctx context.Context, req *contentV1.ImportTmxRequest) (*contentV1.ImportTmxResponse, error) {
+	if req == nil || len(req.GetFile()) == 0 {
+		return nil, adminV1.ErrorBadRequest("invalid parameter")
+	}
+
+	return s.glossaryServiceClient.ImportTmx(ctx, req)
+}
+
+// ExportTmx 导出 TMX 文件
+func (s *GlossaryService) ExportTmx(ctx context.Context, req *contentV1.ExportTmxRequest) (*contentV1.ExportTmxResponse, error) {
+	return s.glossaryServiceClient.ExportTmx(ctx, req)
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	adminV1 "go-wind-cms/api/gen/go/admin/service/v1"
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+)
+
+type ImportJobService struct {
+	adminV1.ImportJobServiceHTTPServer
+
+	importJobServiceClient contentV1.ImportJobServiceClient
+	log                    *log.Helper
+}
+
+func NewImportJobService(ctx *bootstrap.Context, importJobServiceClient contentV1.ImportJobServiceClient) *ImportJobService {
+	return &ImportJobService{
+		log:                    ctx.NewLoggerHelper("import-job/service/admin-service"),
+		importJobServiceClient: importJobServiceClient,
+	}
+}
+
+func (s *ImportJobService) ListImportJobs(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListImportJobResponse, error) {
+	return s.importJobServiceClient.ListImportJobs(ctx, req)
+}
+
+func (s *ImportJobService) GetImportJob(ctx context.Context, req *contentV1.GetImportJobRequest) (*contentV1.ImportJob, error) {
+	return s.importJobServiceClient.GetImportJob(ctx, req)
+}
+
+func (s *ImportJobService) CreateImportJob(ctx context.Context, req *contentV1.CreateImportJobRequest) (*contentV1.ImportJob, error) {
+	if req == nil || req.GetFileId() == 0 || req.GetFormat() == contentV1.ImportJob_FORMAT_UNSPECIFIED {
+		return nil, adminV1.ErrorBadRequest("invalid parameter")
+	}
+
+	return s.importJobServiceClient.CreateImportJob(ctx, req)
+}
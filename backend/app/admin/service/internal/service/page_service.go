@@ -77,3 +77,7 @@ func (s *PageService) Update(ctx context.Context, req *contentV1.UpdatePageReque
 func (s *PageService) Delete(ctx context.Context, req *contentV1.DeletePageRequest) (*emptypb.Empty, error) {
 	return s.pageServiceClient.Delete(ctx, req)
 }
+
+func (s *PageService) Duplicate(ctx context.Context, req *contentV1.DuplicatePageRequest) (*contentV1.Page, error) {
+	return s.pageServiceClient.Duplicate(ctx, req)
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	adminV1 "go-wind-cms/api/gen/go/admin/service/v1"
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+)
+
+type PreviewService struct {
+	adminV1.PreviewServiceHTTPServer
+
+	previewServiceClient contentV1.PreviewServiceClient
+	log                  *log.Helper
+}
+
+func NewPreviewService(ctx *bootstrap.Context, previewServiceClient contentV1.PreviewServiceClient) *PreviewService {
+	return &PreviewService{
+		log:                  ctx.NewLoggerHelper("preview/service/admin-service"),
+		previewServiceClient: previewServiceClient,
+	}
+}
+
+func (s *PreviewService) CreatePreviewToken(ctx context.Context, req *contentV1.CreatePreviewTokenRequest) (*contentV1.CreatePreviewTokenResponse, error) {
+	return s.previewServiceClient.CreatePreviewToken(ctx, req)
+}
+
+func (s *PreviewService) ListPreviewTokens(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListPreviewTokenResponse, error) {
+	return s.previewServiceClient.ListPreviewTokens(ctx, req)
+}
+
+func (s *PreviewService) RevokePreviewToken(ctx context.Context, req *contentV1.RevokePreviewTokenRequest) (*emptypb.Empty, error) {
+	return s.previewServiceClient.RevokePreviewToken(ctx, req)
+}
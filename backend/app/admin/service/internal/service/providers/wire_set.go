@@ -60,9 +60,27 @@ var ProviderSet = wire.NewSet(
 	service.NewPageService,
 	service.NewSectionService,
 	service.NewPostService,
+	service.NewRedirectService,
+	service.NewFieldGroupService,
+	service.NewContentModelService,
+	service.NewContentEntryService,
+	service.NewWorkflowService,
+	service.NewEditorialService,
+	service.NewTrashService,
+	service.NewPreviewService,
+	service.NewReleaseService,
+	service.NewFormService,
+	service.NewTranslationJobService,
+	service.NewTranslationMemoryService,
+	service.NewGlossaryService,
+	service.NewTranslationDashboardService,
+	service.NewAiAssistService,
+	service.NewSeoService,
+	service.NewImportJobService,
 
 	service.NewCommentService,
 	service.NewInteractionAdminService,
+	service.NewCommentModerationService,
 
 	service.NewMediaAssetService,
 
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	adminV1 "go-wind-cms/api/gen/go/admin/service/v1"
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+)
+
+type ReleaseService struct {
+	adminV1.ReleaseServiceHTTPServer
+
+	releaseServiceClient contentV1.ReleaseServiceClient
+	log                  *log.Helper
+}
+
+func NewReleaseService(ctx *bootstrap.Context, releaseServiceClient contentV1.ReleaseServiceClient) *ReleaseService {
+	return &ReleaseService{
+		log:                  ctx.NewLoggerHelper("release/service/admin-service"),
+		releaseServiceClient: releaseServiceClient,
+	}
+}
+
+func (s *ReleaseService) ListReleases(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListReleaseResponse, error) {
+	return s.releaseServiceClient.ListReleases(ctx, req)
+}
+
+func (s *ReleaseService) GetRelease(ctx context.Context, req *contentV1.GetReleaseRequest) (*contentV1.Release, error) {
+	return s.releaseServiceClient.GetRelease(ctx, req)
+}
+
+func (s *ReleaseService) CreateRelease(ctx context.Context, req *contentV1.CreateReleaseRequest) (*contentV1.Release, error) {
+	return s.releaseServiceClient.CreateRelease(ctx, req)
+}
+
+func (s *ReleaseService) UpdateRelease(ctx context.Context, req *contentV1.UpdateReleaseRequest) (*contentV1.Release, error) {
+	return s.releaseServiceClient.UpdateRelease(ctx, req)
+}
+
+func (s *ReleaseService) DeleteRelease(ctx context.Context, req *contentV1.DeleteReleaseRequest) (*emptypb.Empty, error) {
+	return s.releaseServiceClient.DeleteRelease(ctx, req)
+}
+
+func (s *ReleaseService) SetReleaseItem(ctx context.Context, req *contentV1.SetReleaseItemRequest) (*contentV1.ReleaseItem, error) {
+	return s.releaseServiceClient.SetReleaseItem(ctx, req)
+}
+
+func (s *ReleaseService) RemoveReleaseItem(ctx context.Context, req *contentV1.RemoveReleaseItemRequest) (*emptypb.Empty, error) {
+	return s.releaseServiceClient.RemoveReleaseItem(ctx, req)
+}
+
+func (s *ReleaseService) PreviewRelease(ctx context.Context, req *contentV1.PreviewReleaseRequest) (*contentV1.PreviewReleaseResponse, error) {
+	return s.releaseServiceClient.PreviewRelease(ctx, req)
+}
+
+func (s *ReleaseService) ScheduleRelease(ctx context.Context, req *contentV1.ScheduleReleaseRequest) (*contentV1.Release, error) {
+	return s.releaseServiceClient.ScheduleRelease(ctx, req)
+}
+
+func (s *ReleaseService) PublishRelease(ctx context.Context, req *contentV1.PublishReleaseRequest) (*contentV1.Release, error) {
+	return s.releaseServiceClient.PublishRelease(ctx, req)
+}
+
+func (s *ReleaseService) RollbackRelease(ctx context.Context, req *contentV1.RollbackReleaseRequest) (*contentV1.Release, error) {
+	return s.releaseServiceClient.RollbackRelease(ctx, req)
+}
@@ -77,3 +77,7 @@ func (s *SectionService) Update(ctx context.Context, req *contentV1.UpdateSectio
 func (s *SectionService) Delete(ctx context.Context, req *contentV1.DeleteSectionRequest) (*emptypb.Empty, error) {
 	return s.sectionServiceClient.Delete(ctx, req)
 }
+
+func (s *SectionService) ReorderSections(ctx context.Context, req *contentV1.ReorderSectionsRequest) (*contentV1.ListSectionResponse, error) {
+	return s.sectionServiceClient.ReorderSections(ctx, req)
+}
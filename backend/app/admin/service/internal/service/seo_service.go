@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	adminV1 "go-wind-cms/api/gen/go/admin/service/v1"
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+)
+
+type SeoService struct {
+	adminV1.SeoServiceHTTPServer
+
+	seoServiceClient contentV1.SeoServiceClient
+	log              *log.Helper
+}
+
+func NewSeoService(ctx *bootstrap.Context, seoServiceClient contentV1.SeoServiceClient) *SeoService {
+	return &SeoService{
+		log:              ctx.NewLoggerHelper("seo/service/admin-service"),
+		seoServiceClient: seoServiceClient,
+	}
+}
+
+func (s *SeoService) AnalyzeSeo(ctx context.Context, req *contentV1.AnalyzeSeoRequest) (*contentV1.AnalyzeSeoResponse, error) {
+	if req == nil || req.GetEntityId() == 0 || req.GetLanguageCode() == "" {
+		return nil, adminV1.ErrorBadRequest("invalid parameter")
+	}
+
+	return s.seoServiceClient.AnalyzeSeo(ctx, req)
+}
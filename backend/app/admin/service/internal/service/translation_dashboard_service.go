@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	adminV1 "go-wind-cms/api/gen/go/admin/service/v1"
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+)
+
+type TranslationDashboardService struct {
+	adminV1.TranslationDashboardServiceHTTPServer
+
+	translationDashboardServiceClient contentV1.TranslationDashboardServiceClient
+	log                               *log.Helper
+}
+
+func NewTranslationDashboardService(ctx *bootstrap.Context, translationDashboardServiceClient contentV1.TranslationDashboardServiceClient) *TranslationDashboardService {
+	return &TranslationDashboardService{
+		log:                               ctx.NewLoggerHelper("translation-dashboard/service/admin-service"),
+		translationDashboardServiceClient: translationDashboardServiceClient,
+	}
+}
+
+func (s *TranslationDashboardService) GetTranslationDashboard(ctx context.Context, req *contentV1.GetTranslationDashboardRequest) (*contentV1.TranslationDashboard, error) {
+	return s.translationDashboardServiceClient.GetTranslationDashboard(ctx, req)
+}
+
+func (s *TranslationDashboardService) ListTranslationWorkItems(ctx context.Context, req *contentV1.ListTranslationWorkItemsRequest) (*contentV1.ListTranslationWorkItemsResponse, error) {
+	if req == nil || req.GetLanguageCode() == "" {
+		return nil, adminV1.ErrorBadRequest("invalid parameter")
+	}
+
+	return s.translationDashboardServiceClient.ListTranslationWorkItems(ctx, req)
+}
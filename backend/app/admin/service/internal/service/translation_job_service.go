@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	adminV1 "go-wind-cms/api/gen/go/admin/service/v1"
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+)
+
+type TranslationJobService struct {
+	adminV1.TranslationJobServiceHTTPServer
+
+	translationJobServiceClient contentV1.TranslationJobServiceClient
+	log                         *log.Helper
+}
+
+func NewTranslationJobService(ctx *bootstrap.Context, translationJobServiceClient contentV1.TranslationJobServiceClient) *TranslationJobService {
+	return &TranslationJobService{
+		log:                         ctx.NewLoggerHelper("translation-job/service/admin-service"),
+		translationJobServiceClient: translationJobServiceClient,
+	}
+}
+
+func (s *TranslationJobService) ListTranslationJobs(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListTranslationJobResponse, error) {
+	return s.translationJobServiceClient.ListTranslationJobs(ctx, req)
+}
+
+func (s *TranslationJobService) GetTranslationJob(ctx context.Context, req *contentV1.GetTranslationJobRequest) (*contentV1.TranslationJob, error) {
+	return s.translationJobServiceClient.GetTranslationJob(ctx, req)
+}
+
+func (s *TranslationJobService) CreateTranslationJob(ctx context.Context, req *contentV1.CreateTranslationJobRequest) (*contentV1.TranslationJob, error) {
+	if req == nil || req.GetEntityId() == 0 || req.GetSourceLanguage() == "" || len(req.GetTargetLanguages()) == 0 {
+		return nil, adminV1.ErrorBadRequest("invalid parameter")
+	}
+
+	return s.translationJobServiceClient.CreateTranslationJob(ctx, req)
+}
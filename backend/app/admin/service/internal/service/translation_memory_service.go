@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/go-utils/trans"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	adminV1 "go-wind-cms/api/gen/go/admin/service/v1"
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/middleware/auth"
+)
+
+type TranslationMemoryService struct {
+	adminV1.TranslationMemoryServiceHTTPServer
+
+	translationMemoryServiceClient contentV1.TranslationMemoryServiceClient
+	log                            *log.Helper
+}
+
+func NewTranslationMemoryService(ctx *bootstrap.Context, translationMemoryServiceClient contentV1.TranslationMemoryServiceClient) *TranslationMemoryService {
+	return &TranslationMemoryService{
+		log:                            ctx.NewLoggerHelper("translation-memory/service/admin-service"),
+		translationMemoryServiceClient: translationMemoryServiceClient,
+	}
+}
+
+func (s *TranslationMemoryService) List(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListTranslationMemoryResponse, error) {
+	return s.translationMemoryServiceClient.List(ctx, req)
+}
+
+func (s *TranslationMemoryService) Get(ctx context.Context, req *contentV1.GetTranslationMemoryRequest) (*contentV1.TranslationMemory, error) {
+	return s.translationMemoryServiceClient.Get(ctx, req)
+}
+
+func (s *TranslationMemoryService) Create(ctx context.Context, req *contentV1.CreateTranslationMemoryRequest) (*contentV1.TranslationMemory, error) {
+	if req == nil || req.Data == nil {
+		return nil, adminV1.ErrorBadRequest("invalid parameter")
+	}
+
+	// 获取操作人信息
+	operator, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Data.CreatedBy = trans.Ptr(operator.UserId)
+
+	return s.translationMemoryServiceClient.Create(ctx, req)
+}
+
+func (s *TranslationMemoryService) Update(ctx context.Context, req *contentV1.UpdateTranslationMemoryRequest) (*contentV1.TranslationMemory, error) {
+	if req == nil || req.Data == nil {
+		return nil, adminV1.ErrorBadRequest("invalid parameter")
+	}
+
+	// 获取操作人信息
+	operator, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Data.Id = trans.Ptr(req.GetId())
+
+	req.Data.UpdatedBy = trans.Ptr(operator.GetUserId())
+	if req.UpdateMask != nil {
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "updated_by")
+	}
+
+	return s.translationMemoryServiceClient.Update(ctx, req)
+}
+
+func (s *TranslationMemoryService) Delete(ctx context.Context, req *contentV1.DeleteTranslationMemoryRequest) (*emptypb.Empty, error) {
+	return s.translationMemoryServiceClient.Delete(ctx, req)
+}
+
+// ImportTmx 导入 TMX 文件
+func (s *TranslationMemoryService) ImportTmx(ctx context.Context, req *contentV1.ImportTmxRequest) (*contentV1.ImportTmxResponse, error) {
+	if req == nil || len(req.GetFile()) == 0 {
+		return nil, adminV1.ErrorBadRequest("invalid parameter")
+	}
+
+	return s.translationMemoryServiceClient.ImportTmx(ctx, req)
+}
+
+// ExportTmx 导出 TMX 文件
+func (s *TranslationMemoryService) ExportTmx(ctx context.Context, req *contentV1.ExportTmxRequest) (*contentV1.ExportTmxResponse, error) {
+	return s.translationMemoryServiceClient.ExportTmx(ctx, req)
+}
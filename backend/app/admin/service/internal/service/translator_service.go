@@ -4,8 +4,6 @@ import (
 	"context"
 
 	"github.com/go-kratos/kratos/v2/log"
-	"github.com/tx7do/go-utils/trans"
-	"github.com/tx7do/go-utils/translator"
 	"github.com/tx7do/kratos-bootstrap/bootstrap"
 
 	adminV1 "go-wind-cms/api/gen/go/admin/service/v1"
@@ -17,28 +15,24 @@ type TranslatorService struct {
 
 	log *log.Helper
 
-	translator translator.Translator
+	translatorServiceClient translatorV1.TranslatorServiceClient
 }
 
 func NewTranslatorService(
 	ctx *bootstrap.Context,
-	translator translator.Translator,
+	translatorServiceClient translatorV1.TranslatorServiceClient,
 ) *TranslatorService {
 	return &TranslatorService{
-		log:        ctx.NewLoggerHelper("translator/service/admin-service"),
-		translator: translator,
+		log:                     ctx.NewLoggerHelper("translator/service/admin-service"),
+		translatorServiceClient: translatorServiceClient,
 	}
 }
 
-func (s *TranslatorService) Translate(_ context.Context, req *translatorV1.TranslateRequest) (*translatorV1.TranslateResponse, error) {
-	targetContent, err := s.translator.Translate(req.GetContent(), req.GetSourceLanguage(), req.GetTargetLanguage())
-	if err != nil {
-		s.log.Errorf("translator.Translate err: %+v", err)
-		return nil, adminV1.ErrorInternalServerError("翻译失败")
+// Translate 由核心服务翻译，译文会应用当前租户的翻译记忆与术语表
+func (s *TranslatorService) Translate(ctx context.Context, req *translatorV1.TranslateRequest) (*translatorV1.TranslateResponse, error) {
+	if req == nil || req.GetContent() == "" {
+		return nil, adminV1.ErrorBadRequest("invalid parameter")
 	}
 
-	return &translatorV1.TranslateResponse{
-		TranslatedContent: trans.Ptr(targetContent),
-		RawContent:        req.Content,
-	}, nil
+	return s.translatorServiceClient.Translate(ctx, req)
 }
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	adminV1 "go-wind-cms/api/gen/go/admin/service/v1"
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+)
+
+type TrashService struct {
+	adminV1.TrashServiceHTTPServer
+
+	trashServiceClient contentV1.TrashServiceClient
+	log                *log.Helper
+}
+
+func NewTrashService(ctx *bootstrap.Context, trashServiceClient contentV1.TrashServiceClient) *TrashService {
+	return &TrashService{
+		log:                ctx.NewLoggerHelper("trash/service/admin-service"),
+		trashServiceClient: trashServiceClient,
+	}
+}
+
+func (s *TrashService) ListTrash(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListTrashResponse, error) {
+	return s.trashServiceClient.ListTrash(ctx, req)
+}
+
+func (s *TrashService) Restore(ctx context.Context, req *contentV1.RestoreTrashRequest) (*contentV1.RestoreTrashResponse, error) {
+	return s.trashServiceClient.Restore(ctx, req)
+}
+
+func (s *TrashService) PurgeTrash(ctx context.Context, req *contentV1.PurgeTrashRequest) (*contentV1.PurgeTrashResponse, error) {
+	return s.trashServiceClient.PurgeTrash(ctx, req)
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/go-utils/trans"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	adminV1 "go-wind-cms/api/gen/go/admin/service/v1"
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/middleware/auth"
+)
+
+type WorkflowService struct {
+	adminV1.WorkflowServiceHTTPServer
+
+	workflowServiceClient contentV1.WorkflowServiceClient
+	log                   *log.Helper
+}
+
+func NewWorkflowService(ctx *bootstrap.Context, workflowServiceClient contentV1.WorkflowServiceClient) *WorkflowService {
+	return &WorkflowService{
+		log:                   ctx.NewLoggerHelper("workflow/service/admin-service"),
+		workflowServiceClient: workflowServiceClient,
+	}
+}
+
+func (s *WorkflowService) List(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListWorkflowResponse, error) {
+	return s.workflowServiceClient.List(ctx, req)
+}
+
+func (s *WorkflowService) Get(ctx context.Context, req *contentV1.GetWorkflowRequest) (*contentV1.Workflow, error) {
+	return s.workflowServiceClient.Get(ctx, req)
+}
+
+func (s *WorkflowService) Create(ctx context.Context, req *contentV1.CreateWorkflowRequest) (*contentV1.Workflow, error) {
+	if req == nil || req.Data == nil {
+		return nil, adminV1.ErrorBadRequest("invalid parameter")
+	}
+
+	// 获取操作人信息
+	operator, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Data.CreatedBy = trans.Ptr(operator.UserId)
+
+	return s.workflowServiceClient.Create(ctx, req)
+}
+
+func (s *WorkflowService) Update(ctx context.Context, req *contentV1.UpdateWorkflowRequest) (*contentV1.Workflow, error) {
+	if req == nil || req.Data == nil {
+		return nil, adminV1.ErrorBadRequest("invalid parameter")
+	}
+
+	// 获取操作人信息
+	operator, err := auth.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Data.Id = trans.Ptr(req.GetId())
+
+	req.Data.UpdatedBy = trans.Ptr(operator.GetUserId())
+	if req.UpdateMask != nil {
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "updated_by")
+	}
+
+	return s.workflowServiceClient.Update(ctx, req)
+}
+
+func (s *WorkflowService) Delete(ctx context.Context, req *contentV1.DeleteWorkflowRequest) (*emptypb.Empty, error) {
+	return s.workflowServiceClient.Delete(ctx, req)
+}
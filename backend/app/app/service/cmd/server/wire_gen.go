@@ -32,10 +32,21 @@ func initApp(context *bootstrap.Context) (*kratos.App, func(), error) {
 	tenantServiceClient := data.NewTenantServiceClient(context, discovery)
 	tenantResolver := data.NewTenantResolver(tenantServiceClient)
 	v := server.NewRestMiddleware(context, accessTokenChecker, engine, tenantResolver)
-	authenticationService := service.NewAuthenticationService(context, authenticationServiceClient)
-	minIOClient := data.NewMinIoClient(context)
+	client, cleanup, err := data.NewRedisClient(context)
+	if err != nil {
+		return nil, nil, err
+	}
+	captcha := data.NewCaptcha(client)
+	authenticationService := service.NewAuthenticationService(context, authenticationServiceClient, captcha)
+	storageOption := data.NewStorageOption(context)
+	storageRouter, err := data.NewStorageRouter(context, storageOption)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	uploadGuard := data.NewUploadGuard(context, storageOption)
 	fileServiceClient := data.NewFileServiceClient(context, discovery)
-	fileTransferService := service.NewFileTransferService(context, minIOClient, fileServiceClient)
+	fileTransferService := service.NewFileTransferService(context, storageRouter, uploadGuard, fileServiceClient)
 	userServiceClient := data.NewUserServiceClient(context, discovery)
 	orgUnitServiceClient := data.NewOrgUnitServiceClient(context, discovery)
 	positionServiceClient := data.NewPositionServiceClient(context, discovery)
@@ -48,6 +59,8 @@ func initApp(context *bootstrap.Context) (*kratos.App, func(), error) {
 	categoryService := service.NewCategoryService(context, categoryServiceClient)
 	commentServiceClient := data.NewCommentServiceClient(context, discovery)
 	commentService := service.NewCommentService(context, commentServiceClient)
+	commentNotificationServiceClient := data.NewCommentNotificationServiceClient(context, discovery)
+	commentNotificationService := service.NewCommentNotificationService(context, commentNotificationServiceClient)
 	interactionServiceClient := data.NewInteractionServiceClient(context, discovery)
 	interactionService := service.NewInteractionService(context, interactionServiceClient)
 	tagServiceClient := data.NewTagServiceClient(context, discovery)
@@ -58,14 +71,26 @@ func initApp(context *bootstrap.Context) (*kratos.App, func(), error) {
 	sectionService := service.NewSectionService(context, sectionServiceClient)
 	navigationServiceClient := data.NewNavigationServiceClient(context, discovery)
 	navigationService := service.NewNavigationService(context, navigationServiceClient)
-	httpServer := server.NewRestServer(context, v, authenticationService, fileTransferService, userProfileService, postService, categoryService, commentService, interactionService, tagService, pageService, sectionService, navigationService)
+	routeServiceClient := data.NewRouteServiceClient(context, discovery)
+	routeService := service.NewRouteService(context, routeServiceClient)
+	contentEntryServiceClient := data.NewContentEntryServiceClient(context, discovery)
+	contentModelServiceClient := data.NewContentModelServiceClient(context, discovery)
+	contentEntryService := service.NewContentEntryService(context, contentEntryServiceClient, contentModelServiceClient)
+	formServiceClient := data.NewFormServiceClient(context, discovery)
+	formService := service.NewFormService(context, formServiceClient, captcha)
+	syndicationServiceClient := data.NewSyndicationServiceClient(context, discovery)
+	syndicationService := service.NewSyndicationService(context, syndicationServiceClient)
+	httpServer := server.NewRestServer(context, v, authenticationService, fileTransferService, storageRouter, userProfileService, postService, categoryService, commentService, commentNotificationService, interactionService, tagService, pageService, sectionService, navigationService, routeService, contentEntryService, formService, syndicationService)
 	grpcMiddlewares := server.NewGrpcMiddleware(context)
 	grpcServer, err := server.NewGrpcServer(context, grpcMiddlewares)
 	if err != nil {
+		cleanup()
 		return nil, nil, err
 	}
 	sseServer := server.NewSseServer(context, authenticationServiceClient)
-	app := newApp(context, httpServer, grpcServer, sseServer)
+	relay := server.NewNotificationRelay(context, client, sseServer, authenticationServiceClient)
+	app := newApp(context, httpServer, grpcServer, sseServer, relay)
 	return app, func() {
+		cleanup()
 	}, nil
 }
@@ -1,7 +1,10 @@
 package data
 
 import (
+	"time"
+
 	"github.com/redis/go-redis/v9"
+	"github.com/tx7do/go-utils/captcha"
 
 	authnEngine "github.com/tx7do/kratos-authn/engine"
 	"github.com/tx7do/kratos-authn/engine/jwt"
@@ -28,6 +31,7 @@ import (
 	storageV1 "go-wind-cms/api/gen/go/storage/service/v1"
 
 	"go-wind-cms/pkg/oss"
+	"go-wind-cms/pkg/scanner"
 	"go-wind-cms/pkg/serviceid"
 )
 
@@ -53,6 +57,18 @@ func NewRedisClient(ctx *bootstrap.Context) (*redis.Client, func(), error) {
 	}, nil
 }
 
+// NewCaptcha 创建图形验证码，与 admin 服务共用 Redis 键前缀
+func NewCaptcha(rdb *redis.Client) *captcha.Captcha {
+	captchaInstance := captcha.NewCaptcha(rdb,
+		captcha.WithDriverType(captcha.DriverString),
+		captcha.WithExpire(10*time.Minute),
+		captcha.WithKeyPrefix(serviceid.ProjectName+":captcha"),
+		captcha.WithStringCount(6),
+		captcha.WithStringSource("ABCDEFGHJKLMNPQRSTUVWXYZ23456789"),
+	)
+	return captchaInstance
+}
+
 // NewDiscovery 创建服务发现客户端
 func NewDiscovery(ctx *bootstrap.Context) registry.Discovery {
 	cfg := ctx.GetConfig()
@@ -70,8 +86,72 @@ func NewDiscovery(ctx *bootstrap.Context) registry.Discovery {
 	return discovery
 }
 
-func NewMinIoClient(ctx *bootstrap.Context) *oss.MinIOClient {
-	return oss.NewMinIoClient(ctx.GetConfig(), ctx.GetLogger())
+// NewStorageOption 读取自定义配置 Storage，未配置时返回 nil（仅启用 MinIO）
+func NewStorageOption(ctx *bootstrap.Context) *storageV1.StorageOption {
+	var cfg *storageV1.StorageOptionWrapper
+	rawCfg, ok := ctx.GetCustomConfig("Storage")
+	if ok {
+		cfg = rawCfg.(*storageV1.StorageOptionWrapper)
+	}
+	if cfg == nil {
+		return nil
+	}
+	return cfg.Storage
+}
+
+// NewStorageRouter 创建存储驱动路由，MinIO 为默认驱动
+func NewStorageRouter(ctx *bootstrap.Context, cfg *storageV1.StorageOption) (*oss.StorageRouter, error) {
+	opts := &oss.RouterOptions{
+		DefaultProvider: cfg.GetDefaultProvider(),
+		TenantProviders: cfg.GetTenantProviders(),
+	}
+	if cfg.GetLocal() != nil {
+		opts.Local = &oss.LocalOptions{
+			Root:    cfg.GetLocal().GetRoot(),
+			BaseURL: cfg.GetLocal().GetBaseUrl(),
+			SignKey: cfg.GetLocal().GetSignKey(),
+		}
+	}
+	return oss.NewStorageRouter(ctx.GetConfig(), opts, ctx.GetLogger())
+}
+
+// NewUploadGuard 创建上传检查，按租户策略校验 MIME 与大小，配置 scanner 时扫描恶意内容
+func NewUploadGuard(ctx *bootstrap.Context, cfg *storageV1.StorageOption) *oss.UploadGuard {
+	opts := &oss.UploadGuardOptions{
+		DefaultPolicy:  toUploadPolicy(cfg.GetUploadPolicy()),
+		TenantPolicies: make(map[uint32]*oss.UploadPolicy, len(cfg.GetTenantUploadPolicies())),
+	}
+	for tenantID, p := range cfg.GetTenantUploadPolicies() {
+		opts.TenantPolicies[tenantID] = toUploadPolicy(p)
+	}
+
+	if sc := cfg.GetScanner(); sc != nil {
+		opts.FailOpen = sc.GetFailOpen()
+		opts.QuarantineBucket = sc.GetQuarantineBucket()
+
+		switch sc.GetDriver() {
+		case "clamd":
+			opts.Scanner = scanner.NewClamdScanner(
+				sc.GetNetwork(), sc.GetAddress(),
+				scanner.WithClamdTimeout(sc.GetTimeout().AsDuration()),
+			)
+		case "":
+		default:
+			ctx.NewLoggerHelper("upload-guard/data").Warnf("unknown upload scanner driver [%s], scanning disabled", sc.GetDriver())
+		}
+	}
+
+	return oss.NewUploadGuard(opts, ctx.GetLogger())
+}
+
+func toUploadPolicy(p *storageV1.StorageOption_UploadPolicy) *oss.UploadPolicy {
+	if p == nil {
+		return nil
+	}
+	return &oss.UploadPolicy{
+		AllowedMimeTypes: p.GetAllowedMimeTypes(),
+		MaxSize:          p.GetMaxSize(),
+	}
 }
 
 // NewAuthenticator 创建认证器
@@ -176,6 +256,15 @@ func NewCommentServiceClient(ctx *bootstrap.Context, r registry.Discovery) comme
 	return commentV1.NewCommentServiceClient(cli)
 }
 
+func NewCommentNotificationServiceClient(ctx *bootstrap.Context, r registry.Discovery) commentV1.CommentNotificationServiceClient {
+	cli, err := rpc.CreateGrpcClient(ctx.Context(), r, serviceid.NewDiscoveryName(serviceid.CoreService), ctx.GetConfig())
+	if err != nil {
+		return nil
+	}
+
+	return commentV1.NewCommentNotificationServiceClient(cli)
+}
+
 func NewInteractionServiceClient(ctx *bootstrap.Context, r registry.Discovery) interactionV1.InteractionServiceClient {
 	cli, err := rpc.CreateGrpcClient(ctx.Context(), r, serviceid.NewDiscoveryName(serviceid.CoreService), ctx.GetConfig())
 	if err != nil {
@@ -230,6 +319,42 @@ func NewTagServiceClient(ctx *bootstrap.Context, r registry.Discovery) contentV1
 	return contentV1.NewTagServiceClient(cli)
 }
 
+func NewRouteServiceClient(ctx *bootstrap.Context, r registry.Discovery) contentV1.RouteServiceClient {
+	cli, err := rpc.CreateGrpcClient(ctx.Context(), r, serviceid.NewDiscoveryName(serviceid.CoreService), ctx.GetConfig())
+	if err != nil {
+		return nil
+	}
+
+	return contentV1.NewRouteServiceClient(cli)
+}
+
+func NewSyndicationServiceClient(ctx *bootstrap.Context, r registry.Discovery) contentV1.SyndicationServiceClient {
+	cli, err := rpc.CreateGrpcClient(ctx.Context(), r, serviceid.NewDiscoveryName(serviceid.CoreService), ctx.GetConfig())
+	if err != nil {
+		return nil
+	}
+
+	return contentV1.NewSyndicationServiceClient(cli)
+}
+
+func NewContentModelServiceClient(ctx *bootstrap.Context, r registry.Discovery) contentV1.ContentModelServiceClient {
+	cli, err := rpc.CreateGrpcClient(ctx.Context(), r, serviceid.NewDiscoveryName(serviceid.CoreService), ctx.GetConfig())
+	if err != nil {
+		return nil
+	}
+
+	return contentV1.NewContentModelServiceClient(cli)
+}
+
+func NewContentEntryServiceClient(ctx *bootstrap.Context, r registry.Discovery) contentV1.ContentEntryServiceClient {
+	cli, err := rpc.CreateGrpcClient(ctx.Context(), r, serviceid.NewDiscoveryName(serviceid.CoreService), ctx.GetConfig())
+	if err != nil {
+		return nil
+	}
+
+	return contentV1.NewContentEntryServiceClient(cli)
+}
+
 func NewNavigationServiceClient(ctx *bootstrap.Context, r registry.Discovery) siteV1.NavigationServiceClient {
 	cli, err := rpc.CreateGrpcClient(ctx.Context(), r, serviceid.NewDiscoveryName(serviceid.CoreService), ctx.GetConfig())
 	if err != nil {
@@ -248,6 +373,15 @@ func NewSiteSettingServiceClient(ctx *bootstrap.Context, r registry.Discovery) s
 	return siteV1.NewSiteSettingServiceClient(cli)
 }
 
+func NewFormServiceClient(ctx *bootstrap.Context, r registry.Discovery) contentV1.FormServiceClient {
+	cli, err := rpc.CreateGrpcClient(ctx.Context(), r, serviceid.NewDiscoveryName(serviceid.CoreService), ctx.GetConfig())
+	if err != nil {
+		return nil
+	}
+
+	return contentV1.NewFormServiceClient(cli)
+}
+
 func NewMediaAssetServiceClient(ctx *bootstrap.Context, r registry.Discovery) mediaV1.MediaAssetServiceClient {
 	cli, err := rpc.CreateGrpcClient(ctx.Context(), r, serviceid.NewDiscoveryName(serviceid.CoreService), ctx.GetConfig())
 	if err != nil {
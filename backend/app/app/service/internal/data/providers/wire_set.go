@@ -21,7 +21,10 @@ import (
 // ProviderSet is the Wire provider set for data layer.
 var ProviderSet = wire.NewSet(
 	data.NewRedisClient,
-	data.NewMinIoClient,
+	data.NewCaptcha,
+	data.NewStorageOption,
+	data.NewStorageRouter,
+	data.NewUploadGuard,
 	data.NewDiscovery,
 
 	data.NewClientType,
@@ -46,8 +49,14 @@ var ProviderSet = wire.NewSet(
 	data.NewCategoryServiceClient,
 	data.NewPostServiceClient,
 	data.NewTagServiceClient,
+	data.NewRouteServiceClient,
+	data.NewSyndicationServiceClient,
+	data.NewContentModelServiceClient,
+	data.NewContentEntryServiceClient,
+	data.NewFormServiceClient,
 
 	data.NewCommentServiceClient,
+	data.NewCommentNotificationServiceClient,
 
 	data.NewInteractionServiceClient,
 
@@ -0,0 +1,126 @@
+package server
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/tx7do/go-utils/trans"
+
+	"go-wind-cms/app/app/service/internal/service"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+)
+
+// syndicationCacheControl 站点地图与订阅源的浏览器/CDN 缓存时长；core 端另有 Redis 缓存并在发布时失效
+const syndicationCacheControl = "public, max-age=300"
+
+// registerSyndicationServiceHandler 在站点根路径下输出 sitemap.xml、feed.xml、atom.xml 与 robots.txt，
+// 响应体为原始 XML/文本而非 JSON，故不走 protoc 生成的 HTTP 绑定。
+func registerSyndicationServiceHandler(srv *http.Server, svc *service.SyndicationService) {
+	r := srv.Route("/")
+
+	r.GET("sitemap.xml", _SyndicationService_GetSitemap_HTTP_Handler(svc))
+	r.GET("sitemap-{name:[a-z]+-[0-9]+}.xml", _SyndicationService_GetSitemap_HTTP_Handler(svc))
+
+	r.GET("feed.xml", _SyndicationService_GetFeed_HTTP_Handler(svc, contentV1.GetFeedRequest_SCOPE_SITE, contentV1.GetFeedRequest_FORMAT_RSS))
+	r.GET("atom.xml", _SyndicationService_GetFeed_HTTP_Handler(svc, contentV1.GetFeedRequest_SCOPE_SITE, contentV1.GetFeedRequest_FORMAT_ATOM))
+	r.GET("category/{slug}/feed.xml", _SyndicationService_GetFeed_HTTP_Handler(svc, contentV1.GetFeedRequest_SCOPE_CATEGORY, contentV1.GetFeedRequest_FORMAT_RSS))
+	r.GET("category/{slug}/atom.xml", _SyndicationService_GetFeed_HTTP_Handler(svc, contentV1.GetFeedRequest_SCOPE_CATEGORY, contentV1.GetFeedRequest_FORMAT_ATOM))
+	r.GET("tag/{slug}/feed.xml", _SyndicationService_GetFeed_HTTP_Handler(svc, contentV1.GetFeedRequest_SCOPE_TAG, contentV1.GetFeedRequest_FORMAT_RSS))
+	r.GET("tag/{slug}/atom.xml", _SyndicationService_GetFeed_HTTP_Handler(svc, contentV1.GetFeedRequest_SCOPE_TAG, contentV1.GetFeedRequest_FORMAT_ATOM))
+
+	r.GET("robots.txt", _SyndicationService_GetRobots_HTTP_Handler(svc))
+}
+
+const OperationSyndicationServiceGetSitemap = "/app.service.v1.SyndicationService/GetSitemap"
+const OperationSyndicationServiceGetFeed = "/app.service.v1.SyndicationService/GetFeed"
+const OperationSyndicationServiceGetRobots = "/app.service.v1.SyndicationService/GetRobots"
+
+func _SyndicationService_GetSitemap_HTTP_Handler(svc *service.SyndicationService) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		http.SetOperation(ctx, OperationSyndicationServiceGetSitemap)
+
+		in := contentV1.GetSitemapRequest{
+			Host: ctx.Request().Host,
+			Name: ctx.Vars().Get("name"),
+		}
+
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return svc.GetSitemap(ctx, req.(*contentV1.GetSitemapRequest))
+		})
+
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+
+		return writeSyndicationDocument(ctx, out.(*contentV1.SyndicationDocument))
+	}
+}
+
+func _SyndicationService_GetFeed_HTTP_Handler(svc *service.SyndicationService, scope contentV1.GetFeedRequest_Scope, format contentV1.GetFeedRequest_Format) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		http.SetOperation(ctx, OperationSyndicationServiceGetFeed)
+
+		in := contentV1.GetFeedRequest{
+			Host:   ctx.Request().Host,
+			Format: format,
+			Scope:  scope,
+			Slug:   ctx.Vars().Get("slug"),
+		}
+		if lang := ctx.Query().Get("lang"); lang != "" {
+			in.Language = trans.Ptr(lang)
+		}
+
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return svc.GetFeed(ctx, req.(*contentV1.GetFeedRequest))
+		})
+
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+
+		return writeSyndicationDocument(ctx, out.(*contentV1.SyndicationDocument))
+	}
+}
+
+func _SyndicationService_GetRobots_HTTP_Handler(svc *service.SyndicationService) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		http.SetOperation(ctx, OperationSyndicationServiceGetRobots)
+
+		in := contentV1.GetRobotsRequest{
+			Host: ctx.Request().Host,
+		}
+
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return svc.GetRobots(ctx, req.(*contentV1.GetRobotsRequest))
+		})
+
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+
+		return writeSyndicationDocument(ctx, out.(*contentV1.SyndicationDocument))
+	}
+}
+
+// writeSyndicationDocument 按 core 返回的 MIME 类型原样输出文档
+func writeSyndicationDocument(ctx http.Context, doc *contentV1.SyndicationDocument) error {
+	rw := ctx.Response()
+	if rw == nil {
+		return ctx.Result(500, "response writer not available")
+	}
+
+	contentType := doc.GetContentType()
+	if contentType == "" {
+		contentType = "application/xml; charset=utf-8"
+	}
+	rw.Header().Set("Content-Type", contentType)
+	rw.Header().Set("Cache-Control", syndicationCacheControl)
+	rw.WriteHeader(200)
+
+	_, err := rw.Write([]byte(doc.GetBody()))
+	return err
+}
@@ -25,6 +25,7 @@ import (
 	"go-wind-cms/pkg/middleware/auth"
 	applogging "go-wind-cms/pkg/middleware/logging"
 	entmiddleware "go-wind-cms/pkg/middleware/ent"
+	"go-wind-cms/pkg/oss"
 )
 
 // NewRestMiddleware 创建中间件
@@ -63,10 +64,48 @@ func NewRestMiddleware(
 		// 指定或绕过 tenant。
 		appV1.OperationPostServiceSearchPosts,
 
+		// PostService.ListRelatedPosts：相关文章推荐，与文章详情的匿名可见性一致。
+		// tenant_id 由 core 端从 viewer 提取，仅返回该租户下已发布的文章。
+		appV1.OperationPostServiceListRelatedPosts,
+
+		// PostService.UnlockPost：受密码保护文章的解锁，匿名访客可用，与文章详情一致。
+		// 以文章密码授权，core 端按访客（用户或 IP）限流，令牌仅对该文章短期有效。
+		appV1.OperationPostServiceUnlockPost,
+
+		// RouteService.ResolvePath：前台按路径路由，匿名访客可用，与文章/页面详情一致。
+		// 只解析已发布内容与启用的重定向规则，tenant 由 core 端从 viewer 提取。
+		appV1.OperationRouteServiceResolvePath,
+
+		// ContentEntryService：自定义内容类型条目的前台只读访问，匿名访客可用，与文章列表/详情一致。
+		// 仅返回已启用类型下已发布的条目，tenant 由 core 端从 viewer 提取。
+		appV1.OperationContentEntryServiceList,
+		appV1.OperationContentEntryServiceGet,
+		appV1.OperationContentEntryServiceSearch,
+
 		// InteractionService.GetCounts：公开计数（如点赞数）随文章列表展示，
 		// 仅按 tenant 隔离、不依赖 viewer 身份。Like/Unlike/Watch 等写操作
 		// 及 GetInteractionStatus（含 viewer 个人状态）仍需登录，故不在此登记。
 		appV1.OperationInteractionServiceGetCounts,
+
+		// CommentNotificationService.Unsubscribe：邮件一键退订，由邮件客户端直接 POST，
+		// 无登录态；以 HMAC 签名的退订令牌授权，只能关闭令牌所属用户的邮件通知。
+		appV1.OperationCommentNotificationServiceUnsubscribe,
+
+		// AuthenticationService.GenerateCaptcha：图形验证码，供匿名表单提交做人机校验，
+		// 验证码存于 Redis、一次有效，不涉及任何租户数据。
+		appV1.OperationAuthenticationServiceGenerateCaptcha,
+
+		// FormService：前台表单的读取与提交，匿名访客可用。Get 仅返回已启用表单的
+		// 渲染字段，不含通知配置；SubmitForm 由 core 端按表单定义校验字段值，
+		// 要求验证码的表单在 BFF 先行校验，tenant 取自表单本身。
+		appV1.OperationFormServiceGet,
+		appV1.OperationFormServiceSubmitForm,
+
+		// SyndicationService：站点地图、RSS/Atom 订阅源与 robots.txt，供搜索引擎与订阅阅读器匿名抓取。
+		// 仅输出已发布内容，tenant 由 core 端从 viewer（按 Host 注入的 AnonymousTenantViewer）提取。
+		OperationSyndicationServiceGetSitemap,
+		OperationSyndicationServiceGetFeed,
+		OperationSyndicationServiceGetRobots,
 	)
 
 	ms = append(ms, applogging.Server(
@@ -107,16 +146,22 @@ func NewRestServer(
 
 	authenticationService *service.AuthenticationService,
 	fileTransferService *service.FileTransferService,
+	storage *oss.StorageRouter,
 	userProfileService *service.UserProfileService,
 
 	postService *service.PostService,
 	categoryService *service.CategoryService,
 	commentService *service.CommentService,
+	commentNotificationService *service.CommentNotificationService,
 	interactionService *service.InteractionService,
 	tagService *service.TagService,
 	pageService *service.PageService,
 	sectionService *service.SectionService,
 	navigationService *service.NavigationService,
+	routeService *service.RouteService,
+	contentEntryService *service.ContentEntryService,
+	formService *service.FormService,
+	syndicationService *service.SyndicationService,
 ) *http.Server {
 	cfg := ctx.GetConfig()
 
@@ -133,18 +178,29 @@ func NewRestServer(
 	appV1.RegisterFileTransferServiceHTTPServer(srv, fileTransferService)
 	appV1.RegisterUserProfileServiceHTTPServer(srv, userProfileService)
 
+	// 本地存储驱动的签名地址由 REST 服务器提供读写，签名即鉴权，不经过业务中间件
+	if local := storage.Local(); local != nil {
+		srv.HandlePrefix(oss.LocalRoutePrefix, local.Handler())
+	}
+
 	appV1.RegisterNavigationServiceHTTPServer(srv, navigationService)
+	appV1.RegisterRouteServiceHTTPServer(srv, routeService)
 
 	appV1.RegisterPostServiceHTTPServer(srv, postService)
 	appV1.RegisterCategoryServiceHTTPServer(srv, categoryService)
 	appV1.RegisterTagServiceHTTPServer(srv, tagService)
 	appV1.RegisterPageServiceHTTPServer(srv, pageService)
 	appV1.RegisterSectionServiceHTTPServer(srv, sectionService)
+	appV1.RegisterContentEntryServiceHTTPServer(srv, contentEntryService)
+	appV1.RegisterFormServiceHTTPServer(srv, formService)
 
 	appV1.RegisterCommentServiceHTTPServer(srv, commentService)
+	appV1.RegisterCommentNotificationServiceHTTPServer(srv, commentNotificationService)
 
 	appV1.RegisterInteractionServiceHTTPServer(srv, interactionService)
 
+	registerSyndicationServiceHandler(srv, syndicationService)
+
 	if cfg.GetServer().GetRest().GetEnableSwagger() {
 		swaggerUI.RegisterSwaggerUIServerWithOption(
 			srv,
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/tx7do/go-utils/captcha"
 	"github.com/tx7do/go-utils/trans"
 	"github.com/tx7do/kratos-bootstrap/bootstrap"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -18,6 +19,7 @@ type AuthenticationService struct {
 	appV1.AuthenticationServiceHTTPServer
 
 	authenticationServiceClient authenticationV1.AuthenticationServiceClient
+	captchaClient               *captcha.Captcha
 
 	log *log.Helper
 }
@@ -25,13 +27,35 @@ type AuthenticationService struct {
 func NewAuthenticationService(
 	ctx *bootstrap.Context,
 	authenticationServiceClient authenticationV1.AuthenticationServiceClient,
+	captchaClient *captcha.Captcha,
 ) *AuthenticationService {
 	return &AuthenticationService{
 		log:                         ctx.NewLoggerHelper("authn/service/app-service"),
 		authenticationServiceClient: authenticationServiceClient,
+		captchaClient:               captchaClient,
 	}
 }
 
+// GenerateCaptcha 生成图形验证码，供表单提交等匿名操作校验
+func (s *AuthenticationService) GenerateCaptcha(ctx context.Context, _ *emptypb.Empty) (*authenticationV1.GenerateCaptchaResponse, error) {
+	captchaId, captchaImage, answer, err := s.captchaClient.Generate()
+	if err != nil {
+		s.log.Errorf("generate captcha failed: %s", err.Error())
+		return nil, authenticationV1.ErrorInternalServerError("generate captcha failed")
+	}
+
+	// Generate() 只生成验证码但不落盘，必须手动 Save 到 Redis，否则 Verify 时查不到。
+	if err = s.captchaClient.Save(ctx, captchaId, answer); err != nil {
+		s.log.Errorf("save captcha failed: %s", err.Error())
+		return nil, authenticationV1.ErrorInternalServerError("save captcha failed")
+	}
+
+	return &authenticationV1.GenerateCaptchaResponse{
+		CaptchaId:   captchaId,
+		ImageBase64: captchaImage,
+	}, nil
+}
+
 // Login 登陆
 func (s *AuthenticationService) Login(ctx context.Context, req *authenticationV1.LoginRequest) (*authenticationV1.LoginResponse, error) {
 	if req == nil {
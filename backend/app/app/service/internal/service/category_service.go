@@ -5,11 +5,14 @@ import (
 
 	"github.com/go-kratos/kratos/v2/log"
 	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/go-utils/trans"
 	"github.com/tx7do/kratos-bootstrap/bootstrap"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	appV1 "go-wind-cms/api/gen/go/app/service/v1"
 	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/netutil"
 )
 
 type CategoryService struct {
@@ -36,6 +39,7 @@ func (s *CategoryService) List(ctx context.Context, req *paginationV1.PagingRequ
 		filtered := make([]*contentV1.Category, 0, len(resp.GetItems()))
 		for _, c := range resp.GetItems() {
 			if c != nil && c.GetStatus() == contentV1.Category_CATEGORY_STATUS_ACTIVE {
+				dropDraftCategoryTranslations(c)
 				filtered = append(filtered, c)
 			}
 		}
@@ -46,6 +50,12 @@ func (s *CategoryService) List(ctx context.Context, req *paginationV1.PagingRequ
 }
 
 func (s *CategoryService) Get(ctx context.Context, req *contentV1.GetCategoryRequest) (*contentV1.Category, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+	// JSON-LD 中的绝对地址以请求 Host 为根，不接受客户端传入值
+	req.Host = trans.Ptr(netutil.HostFromContext(ctx))
+
 	resp, err := s.categoryClient.Get(ctx, req)
 	if err != nil {
 		return nil, err
@@ -54,6 +64,7 @@ func (s *CategoryService) Get(ctx context.Context, req *contentV1.GetCategoryReq
 	if resp == nil || resp.GetStatus() != contentV1.Category_CATEGORY_STATUS_ACTIVE {
 		return nil, contentV1.ErrorNotFound("category not found")
 	}
+	dropDraftCategoryTranslations(resp)
 	return resp, nil
 }
 
@@ -71,5 +82,30 @@ func (s *CategoryService) Delete(_ context.Context, _ *contentV1.DeleteCategoryR
 }
 
 func (s *CategoryService) GetTranslation(ctx context.Context, req *contentV1.GetCategoryRequest) (*contentV1.CategoryTranslation, error) {
-	return s.categoryClient.GetTranslation(ctx, req)
+	resp, err := s.categoryClient.GetTranslation(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	// 待审阅的草稿翻译（如机器翻译结果）不对外提供
+	if resp == nil || resp.GetIsDraft() {
+		return nil, contentV1.ErrorNotFound("category translation not found")
+	}
+	return resp, nil
+}
+
+// dropDraftCategoryTranslations 移除分类及其子分类中待审阅的草稿翻译（如机器翻译结果）
+func dropDraftCategoryTranslations(c *contentV1.Category) {
+	translations := make([]*contentV1.CategoryTranslation, 0, len(c.GetTranslations()))
+	for _, tr := range c.GetTranslations() {
+		if tr != nil && !tr.GetIsDraft() {
+			translations = append(translations, tr)
+		}
+	}
+	c.Translations = translations
+
+	for _, child := range c.GetChildren() {
+		if child != nil {
+			dropDraftCategoryTranslations(child)
+		}
+	}
 }
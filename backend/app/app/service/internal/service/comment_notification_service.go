@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	appV1 "go-wind-cms/api/gen/go/app/service/v1"
+	commentV1 "go-wind-cms/api/gen/go/comment/service/v1"
+)
+
+// CommentNotificationService 是 app 网关的评论通知偏好与退订转发器。
+//
+// 偏好读写需登录，用户身份由 core 从 viewer context 提取；
+// Unsubscribe 凭邮件中的签名令牌授权，在 rest_server 中登记为白名单。
+type CommentNotificationService struct {
+	appV1.CommentNotificationServiceHTTPServer
+
+	notificationClient commentV1.CommentNotificationServiceClient
+	log                *log.Helper
+}
+
+func NewCommentNotificationService(ctx *bootstrap.Context, notificationClient commentV1.CommentNotificationServiceClient) *CommentNotificationService {
+	return &CommentNotificationService{
+		log:                ctx.NewLoggerHelper("comment-notification/service/app-service"),
+		notificationClient: notificationClient,
+	}
+}
+
+func (s *CommentNotificationService) GetPreference(ctx context.Context, req *emptypb.Empty) (*commentV1.NotificationPreference, error) {
+	return s.notificationClient.GetPreference(ctx, req)
+}
+
+func (s *CommentNotificationService) UpdatePreference(ctx context.Context, req *commentV1.UpdateNotificationPreferenceRequest) (*commentV1.NotificationPreference, error) {
+	return s.notificationClient.UpdatePreference(ctx, req)
+}
+
+func (s *CommentNotificationService) Unsubscribe(ctx context.Context, req *commentV1.UnsubscribeRequest) (*commentV1.UnsubscribeResponse, error) {
+	return s.notificationClient.Unsubscribe(ctx, req)
+}
@@ -13,6 +13,7 @@ import (
 	commentV1 "go-wind-cms/api/gen/go/comment/service/v1"
 
 	"go-wind-cms/pkg/middleware/auth"
+	"go-wind-cms/pkg/netutil"
 )
 
 type CommentService struct {
@@ -73,6 +74,14 @@ func (s *CommentService) Create(ctx context.Context, req *commentV1.CreateCommen
 
 	req.Data.CreatedBy = trans.Ptr(operator.UserId)
 
+	// 审核相关字段由服务端填写：清空状态交由 core 自动审核，IP/UA 取自请求
+	req.Data.Status = nil
+	req.Data.IsSpam = nil
+	req.Data.SpamScore = nil
+	req.Data.IsSticky = nil
+	req.Data.IpAddress = trans.Ptr(netutil.ClientIPFromContext(ctx))
+	req.Data.UserAgent = trans.Ptr(netutil.UserAgentFromContext(ctx))
+
 	return s.commentClient.Create(ctx, req)
 }
 
@@ -108,6 +117,14 @@ func (s *CommentService) Update(ctx context.Context, req *commentV1.UpdateCommen
 	}
 	req.Data.UpdatedBy = trans.Ptr(operator.GetUserId())
 
+	// 作者不能自行修改审核状态
+	req.Data.Status = nil
+	req.Data.IsSpam = nil
+	req.Data.SpamScore = nil
+	req.Data.IsSticky = nil
+	req.Data.IpAddress = nil
+	req.Data.UserAgent = nil
+
 	return s.commentClient.Update(ctx, req)
 }
 
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/go-utils/trans"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	appV1 "go-wind-cms/api/gen/go/app/service/v1"
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+)
+
+type ContentEntryService struct {
+	appV1.ContentEntryServiceHTTPServer
+
+	contentEntryClient contentV1.ContentEntryServiceClient
+	contentModelClient contentV1.ContentModelServiceClient
+	log                *log.Helper
+}
+
+func NewContentEntryService(
+	ctx *bootstrap.Context,
+	contentEntryClient contentV1.ContentEntryServiceClient,
+	contentModelClient contentV1.ContentModelServiceClient,
+) *ContentEntryService {
+	return &ContentEntryService{
+		log:                ctx.NewLoggerHelper("content-entry/service/app-service"),
+		contentEntryClient: contentEntryClient,
+		contentModelClient: contentModelClient,
+	}
+}
+
+func (s *ContentEntryService) List(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListContentEntryResponse, error) {
+	resp, err := s.contentEntryClient.List(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || len(resp.GetItems()) == 0 {
+		return resp, nil
+	}
+
+	// 列表限定单一内容类型，类型停用后前台不再返回其条目
+	model, err := s.contentModelClient.Get(ctx, &contentV1.GetContentModelRequest{
+		QueryBy: &contentV1.GetContentModelRequest_Code{Code: resp.GetItems()[0].GetModelCode()},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !model.GetEnabled() {
+		return &contentV1.ListContentEntryResponse{Total: 0, Items: nil}, nil
+	}
+
+	// 公开端点仅返回已发布条目，过滤草稿/归档
+	filtered := make([]*contentV1.ContentEntry, 0, len(resp.GetItems()))
+	for _, e := range resp.GetItems() {
+		if e != nil && e.GetStatus() == contentV1.ContentEntry_CONTENT_ENTRY_STATUS_PUBLISHED {
+			filtered = append(filtered, e)
+		}
+	}
+	resp.Items = filtered
+	resp.Total = uint64(len(filtered))
+
+	return resp, nil
+}
+
+func (s *ContentEntryService) Get(ctx context.Context, req *contentV1.GetContentEntryRequest) (*contentV1.ContentEntry, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+	// 强制前台视图：core 端对未发布条目或已停用类型按未找到处理
+	req.PublicView = trans.Ptr(true)
+
+	return s.contentEntryClient.Get(ctx, req)
+}
+
+// Search 关键词搜索条目，纯透传到 core 服务。
+//
+// core 端从 viewer 上下文提取 tenant_id，并硬编码 status=PUBLISHED、
+// 要求内容类型已启用，调用方无法指定或绕过。
+func (s *ContentEntryService) Search(ctx context.Context, req *contentV1.SearchContentEntriesRequest) (*contentV1.SearchContentEntriesResponse, error) {
+	return s.contentEntryClient.Search(ctx, req)
+}
@@ -8,7 +8,6 @@ import (
 	"strings"
 
 	"github.com/go-kratos/kratos/v2/log"
-	"github.com/minio/minio-go/v7"
 
 	"github.com/tx7do/go-utils/id"
 	"github.com/tx7do/go-utils/trans"
@@ -27,18 +26,21 @@ type FileTransferService struct {
 
 	log *log.Helper
 
-	mc                *oss.MinIOClient
+	storage           *oss.StorageRouter
+	uploadGuard       *oss.UploadGuard
 	fileServiceClient storageV1.FileServiceClient
 }
 
 func NewFileTransferService(
 	ctx *bootstrap.Context,
-	mc *oss.MinIOClient,
+	storage *oss.StorageRouter,
+	uploadGuard *oss.UploadGuard,
 	fileServiceClient storageV1.FileServiceClient,
 ) *FileTransferService {
 	return &FileTransferService{
 		log:               ctx.NewLoggerHelper("file-transfer/service/app-service"),
-		mc:                mc,
+		storage:           storage,
+		uploadGuard:       uploadGuard,
 		fileServiceClient: fileServiceClient,
 	}
 }
@@ -88,7 +90,8 @@ func (s *FileTransferService) recordFile(
 	ctx context.Context,
 	tenantID, userID uint32,
 	sourceFileName string,
-	info minio.UploadInfo,
+	provider storageV1.OSSProvider,
+	info oss.ObjectInfo,
 	downloadUrl string,
 ) error {
 
@@ -96,7 +99,7 @@ func (s *FileTransferService) recordFile(
 
 	if _, err := s.fileServiceClient.Create(ctx, &storageV1.CreateFileRequest{
 		Data: &storageV1.File{
-			Provider:      trans.Ptr(storageV1.OSSProvider_MINIO),
+			Provider:      trans.Ptr(provider),
 			BucketName:    trans.Ptr(info.Bucket),
 			SaveFileName:  trans.Ptr(fileName + "." + ext),
 			FileDirectory: trans.Ptr(dir),
@@ -115,6 +118,31 @@ func (s *FileTransferService) recordFile(
 	return nil
 }
 
+// quarantineUpload 将检出恶意内容的上传转存至隔离桶并记录文件元数据，隔离文件不对外提供下载地址
+func (s *FileTransferService) quarantineUpload(
+	ctx context.Context,
+	driver oss.Storage,
+	tenantID, userID uint32,
+	sourceFileName, objectName string,
+	upload *oss.InspectedUpload,
+) error {
+	info, _, err := s.uploadGuard.Quarantine(ctx, driver, objectName, sourceFileName, upload)
+	if err != nil {
+		return err
+	}
+
+	if err = s.recordFile(ctx, tenantID, userID, sourceFileName, driver.Provider(), info, ""); err != nil {
+		if delErr := driver.DeleteFile(ctx, info.Bucket, info.Key); delErr != nil {
+			s.log.Errorf("cleanup quarantined object after recordFile failure failed: %s", delErr.Error())
+		}
+		return err
+	}
+
+	s.log.Warnf("upload [%s] of tenant [%d] user [%d] quarantined: %s", sourceFileName, tenantID, userID, upload.Signature)
+
+	return storageV1.ErrorUnprocessableEntity("file rejected: malware detected (%s)", upload.Signature)
+}
+
 // directUploadFile 直接上传文件
 func (s *FileTransferService) directUploadFile(ctx context.Context, req *storageV1.UploadFileRequest) (*storageV1.UploadFileResponse, error) {
 	if req == nil || req.StorageObject == nil {
@@ -156,12 +184,29 @@ func (s *FileTransferService) directUploadFile(ctx context.Context, req *storage
 		)
 	}
 
-	info, _, downloadUrl, err := s.mc.UploadFile(
+	upload, err := s.uploadGuard.Inspect(ctx, operator.GetTenantId(), req.GetMime(), reader, objectSize)
+	if err != nil {
+		return nil, err
+	}
+	defer upload.Close()
+
+	driver := s.storage.ForTenant(operator.GetTenantId())
+
+	if upload.Infected {
+		return nil, s.quarantineUpload(
+			ctx, driver,
+			operator.GetTenantId(), operator.GetUserId(),
+			req.GetSourceFileName(), req.GetStorageObject().GetObjectName(),
+			upload,
+		)
+	}
+
+	info, _, downloadUrl, err := driver.UploadFile(
 		ctx,
 		req.GetStorageObject().GetBucketName(),
 		req.GetStorageObject().GetObjectName(),
 		req.GetMime(),
-		reader, objectSize,
+		upload.Reader(), upload.Size(),
 	)
 	if err != nil {
 		return nil, err
@@ -171,9 +216,10 @@ func (s *FileTransferService) directUploadFile(ctx context.Context, req *storage
 		ctx,
 		operator.GetTenantId(), operator.GetUserId(),
 		req.GetSourceFileName(),
+		driver.Provider(),
 		info, downloadUrl); err != nil {
 		// 元数据写入失败，回滚已上传的对象，避免孤儿文件
-		if delErr := s.mc.DeleteFile(ctx, req.GetStorageObject().GetBucketName(), req.GetStorageObject().GetObjectName()); delErr != nil {
+		if delErr := driver.DeleteFile(ctx, req.GetStorageObject().GetBucketName(), req.GetStorageObject().GetObjectName()); delErr != nil {
 			s.log.Errorf("cleanup orphaned object after recordFile failure failed: %s", delErr.Error())
 		}
 		return nil, err
@@ -268,6 +314,11 @@ func (s *FileTransferService) DownloadFile(ctx context.Context, req *storageV1.D
 			return nil, storageV1.ErrorDownloadFailed("forbidden: file does not belong to caller's tenant")
 		}
 
+		driver, err := s.storage.ForProvider(resp.GetProvider())
+		if err != nil {
+			return nil, err
+		}
+
 		req.Selector = &storageV1.DownloadFileRequest_StorageObject{
 			StorageObject: &storageV1.StorageObject{
 				BucketName: resp.BucketName,
@@ -275,7 +326,7 @@ func (s *FileTransferService) DownloadFile(ctx context.Context, req *storageV1.D
 			},
 		}
 
-		return s.mc.DownloadFile(ctx, req)
+		return driver.DownloadFile(ctx, req)
 
 	case *storageV1.DownloadFileRequest_StorageObject:
 		return nil, storageV1.ErrorDownloadFailed("storageObject selector is not allowed for external callers, use fileId")
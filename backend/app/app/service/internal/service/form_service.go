@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/tx7do/go-utils/captcha"
+	"github.com/tx7do/go-utils/trans"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	appV1 "go-wind-cms/api/gen/go/app/service/v1"
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/netutil"
+)
+
+type FormService struct {
+	appV1.FormServiceHTTPServer
+
+	formServiceClient contentV1.FormServiceClient
+	captchaClient     *captcha.Captcha
+	log               *log.Helper
+}
+
+func NewFormService(ctx *bootstrap.Context, formServiceClient contentV1.FormServiceClient, captchaClient *captcha.Captcha) *FormService {
+	return &FormService{
+		log:               ctx.NewLoggerHelper("form/service/app-service"),
+		formServiceClient: formServiceClient,
+		captchaClient:     captchaClient,
+	}
+}
+
+// Get 返回前台渲染表单所需的定义；停用的表单按未找到处理，通知接收人与 Webhook 配置不对外暴露
+func (s *FormService) Get(ctx context.Context, req *contentV1.GetFormRequest) (*contentV1.Form, error) {
+	f, err := s.formServiceClient.Get(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if f.Enabled != nil && !f.GetEnabled() {
+		return nil, contentV1.ErrorNotFound("form not found")
+	}
+
+	return &contentV1.Form{
+		Id:              f.Id,
+		Name:            f.Name,
+		Description:     f.Description,
+		Fields:          f.Fields,
+		HoneypotField:   f.HoneypotField,
+		CaptchaRequired: f.CaptchaRequired,
+		SuccessMessage:  f.SuccessMessage,
+	}, nil
+}
+
+// SubmitForm 提交表单。表单要求验证码时先校验（验证码一次有效），
+// client_ip 与 user_agent 由服务端填充，不接受客户端传入值。
+func (s *FormService) SubmitForm(ctx context.Context, req *contentV1.SubmitFormRequest) (*contentV1.SubmitFormResponse, error) {
+	if req == nil || req.GetFormId() == 0 {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	f, err := s.formServiceClient.Get(ctx, &contentV1.GetFormRequest{Id: req.GetFormId()})
+	if err != nil {
+		return nil, err
+	}
+
+	if f.GetCaptchaRequired() {
+		if req.GetCaptchaId() == "" || req.GetCaptchaCode() == "" {
+			return nil, contentV1.ErrorBadRequest("captcha is required")
+		}
+		ok, err := s.captchaClient.Verify(ctx, req.GetCaptchaId(), req.GetCaptchaCode())
+		if err != nil {
+			s.log.Errorf("verify captcha failed: %s", err.Error())
+			return nil, contentV1.ErrorInternalServerError("verify captcha failed")
+		}
+		if !ok {
+			return nil, contentV1.ErrorBadRequest("invalid captcha")
+		}
+	}
+
+	req.ClientIp = trans.Ptr(netutil.ClientIPFromContext(ctx))
+	req.UserAgent = nil
+	if ua := netutil.UserAgentFromContext(ctx); ua != "" {
+		req.UserAgent = trans.Ptr(ua)
+	}
+
+	return s.formServiceClient.SubmitForm(ctx, req)
+}
@@ -5,11 +5,14 @@ import (
 
 	"github.com/go-kratos/kratos/v2/log"
 	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/go-utils/trans"
 	"github.com/tx7do/kratos-bootstrap/bootstrap"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	appV1 "go-wind-cms/api/gen/go/app/service/v1"
 	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/netutil"
 )
 
 type PageService struct {
@@ -36,6 +39,7 @@ func (s *PageService) List(ctx context.Context, req *paginationV1.PagingRequest)
 		filtered := make([]*contentV1.Page, 0, len(resp.GetItems()))
 		for _, p := range resp.GetItems() {
 			if p != nil && p.GetStatus() == contentV1.Page_PAGE_STATUS_PUBLISHED {
+				dropDraftPageTranslations(p)
 				filtered = append(filtered, p)
 			}
 		}
@@ -46,14 +50,22 @@ func (s *PageService) List(ctx context.Context, req *paginationV1.PagingRequest)
 }
 
 func (s *PageService) Get(ctx context.Context, req *contentV1.GetPageRequest) (*contentV1.Page, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+	// JSON-LD 中的绝对地址以请求 Host 为根，不接受客户端传入值
+	req.Host = trans.Ptr(netutil.HostFromContext(ctx))
+
 	resp, err := s.pageServiceClient.Get(ctx, req)
 	if err != nil {
 		return nil, err
 	}
-	// 公开端点仅返回已发布页面，草稿/归档/私有等状态按未找到处理
-	if resp == nil || resp.GetStatus() != contentV1.Page_PAGE_STATUS_PUBLISHED {
+	// 公开端点仅返回已发布页面，草稿/归档/私有等状态按未找到处理；
+	// 携带预览令牌时由 core 校验令牌后标记 is_preview，放行草稿
+	if resp == nil || (resp.GetStatus() != contentV1.Page_PAGE_STATUS_PUBLISHED && !resp.GetIsPreview()) {
 		return nil, contentV1.ErrorNotFound("page not found")
 	}
+	dropDraftPageTranslations(resp)
 	return resp, nil
 }
 
@@ -71,5 +83,24 @@ func (s *PageService) Delete(_ context.Context, _ *contentV1.DeletePageRequest)
 }
 
 func (s *PageService) GetTranslation(ctx context.Context, req *contentV1.GetPageRequest) (*contentV1.PageTranslation, error) {
-	return s.pageServiceClient.GetTranslation(ctx, req)
+	resp, err := s.pageServiceClient.GetTranslation(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	// 待审阅的草稿翻译（如机器翻译结果）不对外提供
+	if resp == nil || resp.GetIsDraft() {
+		return nil, contentV1.ErrorNotFound("page translation not found")
+	}
+	return resp, nil
+}
+
+// dropDraftPageTranslations 移除待审阅的草稿翻译（如机器翻译结果），仅保留已发布的语言版本
+func dropDraftPageTranslations(p *contentV1.Page) {
+	translations := make([]*contentV1.PageTranslation, 0, len(p.GetTranslations()))
+	for _, tr := range p.GetTranslations() {
+		if tr != nil && !tr.GetIsDraft() {
+			translations = append(translations, tr)
+		}
+	}
+	p.Translations = translations
 }
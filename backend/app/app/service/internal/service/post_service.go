@@ -5,11 +5,14 @@ import (
 
 	"github.com/go-kratos/kratos/v2/log"
 	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/go-utils/trans"
 	"github.com/tx7do/kratos-bootstrap/bootstrap"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	appV1 "go-wind-cms/api/gen/go/app/service/v1"
 	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/netutil"
 )
 
 type PostService struct {
@@ -36,6 +39,11 @@ func (s *PostService) List(ctx context.Context, req *paginationV1.PagingRequest)
 		filtered := make([]*contentV1.Post, 0, len(resp.GetItems()))
 		for _, p := range resp.GetItems() {
 			if p != nil && p.GetStatus() == contentV1.Post_POST_STATUS_PUBLISHED {
+				// 受密码保护的文章在列表中只展示元数据与摘要，正文须经 UnlockPost 解锁后按详情读取
+				if p.GetPasswordProtected() {
+					redactProtectedPost(p)
+				}
+				dropDraftPostTranslations(p)
 				filtered = append(filtered, p)
 			}
 		}
@@ -46,17 +54,38 @@ func (s *PostService) List(ctx context.Context, req *paginationV1.PagingRequest)
 }
 
 func (s *PostService) Get(ctx context.Context, req *contentV1.GetPostRequest) (*contentV1.Post, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+	// 强制前台视图：受密码保护的文章须携带有效 unlock_token 才返回正文
+	req.PublicView = trans.Ptr(true)
+	// JSON-LD 中的绝对地址以请求 Host 为根，不接受客户端传入值
+	req.Host = trans.Ptr(netutil.HostFromContext(ctx))
+
 	resp, err := s.postClient.Get(ctx, req)
 	if err != nil {
 		return nil, err
 	}
-	// 公开端点仅返回已发布文章，草稿/归档/私有等状态按未找到处理
-	if resp == nil || resp.GetStatus() != contentV1.Post_POST_STATUS_PUBLISHED {
+	// 公开端点仅返回已发布文章，草稿/归档/私有等状态按未找到处理；
+	// 携带预览令牌时由 core 校验令牌后标记 is_preview，放行草稿
+	if resp == nil || (resp.GetStatus() != contentV1.Post_POST_STATUS_PUBLISHED && !resp.GetIsPreview()) {
 		return nil, contentV1.ErrorNotFound("post not found")
 	}
+	dropDraftPostTranslations(resp)
 	return resp, nil
 }
 
+// UnlockPost 解锁受密码保护的文章。client_ip 由服务端填充，不接受客户端传入值，
+// core 端据此对匿名访客限流。
+func (s *PostService) UnlockPost(ctx context.Context, req *contentV1.UnlockPostRequest) (*contentV1.UnlockPostResponse, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+	req.ClientIp = trans.Ptr(netutil.ClientIPFromContext(ctx))
+
+	return s.postClient.UnlockPost(ctx, req)
+}
+
 // Create/Update/Delete 在 app（公开站点）服务上禁用：CMS 内容的写操作应经由 admin 服务，
 // 公开站点登录用户不应直接创建/修改/删除文章。RBAC 为故意的 noop，故在此显式拒绝。
 func (s *PostService) Create(_ context.Context, _ *contentV1.CreatePostRequest) (*contentV1.Post, error) {
@@ -72,7 +101,21 @@ func (s *PostService) Delete(_ context.Context, _ *contentV1.DeletePostRequest)
 }
 
 func (s *PostService) GetTranslation(ctx context.Context, req *contentV1.GetPostRequest) (*contentV1.PostTranslation, error) {
-	return s.postClient.GetTranslation(ctx, req)
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+	// 与 Get 一致，受密码保护的文章未解锁时不返回正文
+	req.PublicView = trans.Ptr(true)
+
+	resp, err := s.postClient.GetTranslation(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	// 待审阅的草稿翻译（如机器翻译结果）不对外提供
+	if resp == nil || resp.GetIsDraft() {
+		return nil, contentV1.ErrorNotFound("post translation not found")
+	}
+	return resp, nil
 }
 
 // SearchPosts 全文搜索帖子，纯透传到 core 服务。
@@ -84,3 +127,33 @@ func (s *PostService) GetTranslation(ctx context.Context, req *contentV1.GetPost
 func (s *PostService) SearchPosts(ctx context.Context, req *contentV1.SearchPostsRequest) (*contentV1.SearchPostsResponse, error) {
 	return s.postClient.SearchPosts(ctx, req)
 }
+
+// ListRelatedPosts 相关文章推荐，纯透传到 core 服务。
+//
+// core 端从 viewer 上下文取 tenant_id，仅返回该租户下已发布的文章且不含草稿翻译，
+// 受密码保护的文章不含正文。与文章详情一致，本端点在鉴权白名单中。
+func (s *PostService) ListRelatedPosts(ctx context.Context, req *contentV1.ListRelatedPostsRequest) (*contentV1.ListRelatedPostsResponse, error) {
+	return s.postClient.ListRelatedPosts(ctx, req)
+}
+
+// redactProtectedPost 清除文章各语言版本的正文，仅保留元数据与摘要
+func redactProtectedPost(p *contentV1.Post) {
+	for _, tr := range p.GetTranslations() {
+		if tr == nil {
+			continue
+		}
+		tr.Content = nil
+		tr.OriginalContent = nil
+	}
+}
+
+// dropDraftPostTranslations 移除待审阅的草稿翻译（如机器翻译结果），仅保留已发布的语言版本
+func dropDraftPostTranslations(p *contentV1.Post) {
+	translations := make([]*contentV1.PostTranslation, 0, len(p.GetTranslations()))
+	for _, tr := range p.GetTranslations() {
+		if tr != nil && !tr.GetIsDraft() {
+			translations = append(translations, tr)
+		}
+	}
+	p.Translations = translations
+}
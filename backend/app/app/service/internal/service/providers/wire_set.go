@@ -25,9 +25,14 @@ var ProviderSet = wire.NewSet(
 	service.NewCategoryService,
 	service.NewTagService,
 	service.NewCommentService,
+	service.NewCommentNotificationService,
 	service.NewInteractionService,
 	service.NewPageService,
 	service.NewSectionService,
 	service.NewPostService,
 	service.NewNavigationService,
+	service.NewRouteService,
+	service.NewSyndicationService,
+	service.NewContentEntryService,
+	service.NewFormService,
 )
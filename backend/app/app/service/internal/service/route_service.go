@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	appV1 "go-wind-cms/api/gen/go/app/service/v1"
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+)
+
+type RouteService struct {
+	appV1.RouteServiceHTTPServer
+
+	routeClient contentV1.RouteServiceClient
+	log         *log.Helper
+}
+
+func NewRouteService(ctx *bootstrap.Context, routeClient contentV1.RouteServiceClient) *RouteService {
+	return &RouteService{
+		log:         ctx.NewLoggerHelper("route/service/app-service"),
+		routeClient: routeClient,
+	}
+}
+
+// ResolvePath 解析前台路径；租户由 core 端从 viewer 提取，调用方无法指定
+func (s *RouteService) ResolvePath(ctx context.Context, req *contentV1.ResolvePathRequest) (*contentV1.ResolvePathResponse, error) {
+	return s.routeClient.ResolvePath(ctx, req)
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+)
+
+// SyndicationService 站点地图、订阅源与 robots.txt，由 server 包的原始 HTTP 处理器按 Host 调用
+type SyndicationService struct {
+	syndicationClient contentV1.SyndicationServiceClient
+	log               *log.Helper
+}
+
+func NewSyndicationService(ctx *bootstrap.Context, syndicationClient contentV1.SyndicationServiceClient) *SyndicationService {
+	return &SyndicationService{
+		log:               ctx.NewLoggerHelper("syndication/service/app-service"),
+		syndicationClient: syndicationClient,
+	}
+}
+
+// GetSitemap 获取站点地图；租户由 core 端从 viewer 提取，调用方无法指定
+func (s *SyndicationService) GetSitemap(ctx context.Context, req *contentV1.GetSitemapRequest) (*contentV1.SyndicationDocument, error) {
+	return s.syndicationClient.GetSitemap(ctx, req)
+}
+
+// GetFeed 获取 RSS/Atom 订阅源
+func (s *SyndicationService) GetFeed(ctx context.Context, req *contentV1.GetFeedRequest) (*contentV1.SyndicationDocument, error) {
+	return s.syndicationClient.GetFeed(ctx, req)
+}
+
+// GetRobots 获取 robots.txt
+func (s *SyndicationService) GetRobots(ctx context.Context, req *contentV1.GetRobotsRequest) (*contentV1.SyndicationDocument, error) {
+	return s.syndicationClient.GetRobots(ctx, req)
+}
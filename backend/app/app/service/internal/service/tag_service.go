@@ -27,11 +27,27 @@ func NewTagService(ctx *bootstrap.Context, tagClient contentV1.TagServiceClient)
 }
 
 func (s *TagService) List(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListTagResponse, error) {
-	return s.tagClient.List(ctx, req)
+	resp, err := s.tagClient.List(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range resp.GetItems() {
+		if t != nil {
+			dropDraftTagTranslations(t)
+		}
+	}
+	return resp, nil
 }
 
 func (s *TagService) Get(ctx context.Context, req *contentV1.GetTagRequest) (*contentV1.Tag, error) {
-	return s.tagClient.Get(ctx, req)
+	resp, err := s.tagClient.Get(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp != nil {
+		dropDraftTagTranslations(resp)
+	}
+	return resp, nil
 }
 
 // Create/Update/Delete 在 app（公开站点）服务上禁用：CMS 内容的写操作应经由 admin 服务。
@@ -48,5 +64,24 @@ func (s *TagService) Delete(_ context.Context, _ *contentV1.DeleteTagRequest) (*
 }
 
 func (s *TagService) GetTranslation(ctx context.Context, req *contentV1.GetTagRequest) (*contentV1.TagTranslation, error) {
-	return s.tagClient.GetTranslation(ctx, req)
+	resp, err := s.tagClient.GetTranslation(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	// 待审阅的草稿翻译（如机器翻译结果）不对外提供
+	if resp == nil || resp.GetIsDraft() {
+		return nil, contentV1.ErrorNotFound("tag translation not found")
+	}
+	return resp, nil
+}
+
+// dropDraftTagTranslations 移除待审阅的草稿翻译（如机器翻译结果），仅保留已发布的语言版本
+func dropDraftTagTranslations(t *contentV1.Tag) {
+	translations := make([]*contentV1.TagTranslation, 0, len(t.GetTranslations()))
+	for _, tr := range t.GetTranslations() {
+		if tr != nil && !tr.GetIsDraft() {
+			translations = append(translations, tr)
+		}
+	}
+	t.Translations = translations
 }
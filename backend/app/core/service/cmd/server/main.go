@@ -14,6 +14,9 @@ import (
 	_ "github.com/tx7do/kratos-bootstrap/tracer"
 
 	authenticationV1 "go-wind-cms/api/gen/go/authentication/service/v1"
+	commentV1 "go-wind-cms/api/gen/go/comment/service/v1"
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+	storageV1 "go-wind-cms/api/gen/go/storage/service/v1"
 
 	"go-wind-cms/pkg/serviceid"
 )
@@ -45,6 +48,15 @@ func runApp() error {
 	)
 
 	ctx.RegisterCustomConfig("Authenticator", &authenticationV1.AuthenticatorOptionWrapper{})
+	ctx.RegisterCustomConfig("Storage", &storageV1.StorageOptionWrapper{})
+	ctx.RegisterCustomConfig("Moderation", &commentV1.ModerationOptionWrapper{})
+	ctx.RegisterCustomConfig("Notification", &commentV1.NotificationOptionWrapper{})
+	ctx.RegisterCustomConfig("PostProtection", &contentV1.PostProtectionOptionWrapper{})
+	ctx.RegisterCustomConfig("Trash", &contentV1.TrashOptionWrapper{})
+	ctx.RegisterCustomConfig("Preview", &contentV1.PreviewOptionWrapper{})
+	ctx.RegisterCustomConfig("RelatedPosts", &contentV1.RelatedPostsOptionWrapper{})
+	ctx.RegisterCustomConfig("Llm", &contentV1.LlmOptionWrapper{})
+	ctx.RegisterCustomConfig("Syndication", &contentV1.SyndicationOptionWrapper{})
 
 	return bootstrap.RunApp(ctx, initApp)
 }
@@ -61,10 +61,17 @@ func initApp(context *bootstrap.Context) (*kratos.App, func(), error) {
 	taskRepo := data.NewTaskRepo(context, entClient)
 	taskService := service.NewTaskService(context, taskRepo, userRepo)
 	fileRepo := data.NewFileRepo(context, entClient)
-	minIOClient := client.NewMinIoClient(context)
-	fileService := service.NewFileService(context, fileRepo, minIOClient)
+	storageOption := client.NewStorageOption(context)
+	storageRouter, err := client.NewStorageRouter(context, storageOption)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	fileService := service.NewFileService(context, fileRepo, storageRouter)
 	dictEntryI18nRepo := data.NewDictEntryI18nRepo(context, entClient)
-	dictEntryRepo := data.NewDictEntryRepo(context, entClient, dictEntryI18nRepo)
+	localeResolver := data.NewLocaleResolver(context, entClient)
+	dictEntryRepo := data.NewDictEntryRepo(context, entClient, dictEntryI18nRepo, localeResolver)
 	dictTypeRepo := data.NewDictTypeRepo(context, entClient, dictEntryRepo)
 	dictTypeService := service.NewDictTypeService(context, dictTypeRepo)
 	dictEntryService := service.NewDictEntryService(context, dictEntryRepo)
@@ -102,15 +109,35 @@ func initApp(context *bootstrap.Context) (*kratos.App, func(), error) {
 	internalMessageService := service.NewInternalMessageService(context, internalMessageRepo, internalMessageCategoryRepo, internalMessageRecipientRepo, userRepo)
 	internalMessageCategoryService := service.NewInternalMessageCategoryService(context, internalMessageCategoryRepo)
 	internalMessageRecipientService := service.NewInternalMessageRecipientService(context, internalMessageRepo, internalMessageRecipientRepo)
-	commentRepo := data.NewCommentRepo(context, entClient)
-	commentService := service.NewCommentService(context, commentRepo)
+	moderationOption := data.NewModerationOption(context)
+	moderator, err := data.NewCommentModerator(context, redisClient, moderationOption)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	commentAuthorRuleRepo := data.NewCommentAuthorRuleRepo(context, entClient)
+	commentRepo := data.NewCommentRepo(context, entClient, moderator, commentAuthorRuleRepo)
+	commentService := service.NewCommentService(context, commentRepo, taskService)
 	interactionRepo := data.NewInteractionRepo(context, entClient)
-	postTranslationRepo := data.NewPostTranslationRepo(context, entClient)
+	redirectRepo := data.NewRedirectRepo(context, entClient)
+	postTranslationRepo := data.NewPostTranslationRepo(context, entClient, redirectRepo)
 	postCategoryRepo := data.NewPostCategoryRepo(context, entClient)
 	postTagRepo := data.NewPostTagRepo(context, entClient)
-	postRepo := data.NewPostRepo(context, entClient, postTranslationRepo, postCategoryRepo, postTagRepo)
+	postProtectionOption := data.NewPostProtectionOption(context)
+	postProtection := data.NewPostProtection(context, redisClient, postProtectionOption)
+	fieldGroupRepo := data.NewFieldGroupRepo(context, entClient)
+	workflowRepo := data.NewWorkflowRepo(context, entClient)
+	postRepo := data.NewPostRepo(context, entClient, postTranslationRepo, postCategoryRepo, postTagRepo, fieldGroupRepo, workflowRepo, crypto, postProtection, localeResolver)
 	interactionService := service.NewInteractionService(context, interactionRepo, postRepo)
 	interactionAdminService := service.NewInteractionAdminService(context, interactionRepo, operationAuditLogRepo)
+	commentModerationService := service.NewCommentModerationService(context, commentRepo, commentAuthorRuleRepo, operationAuditLogRepo, taskService)
+	notificationOption := data.NewNotificationOption(context)
+	mailer := data.NewMailer(context, notificationOption)
+	tokenSigner := data.NewUnsubscribeTokenSigner(context, notificationOption)
+	commentNotificationRepo := data.NewCommentNotificationRepo(context, entClient, redisClient, interactionRepo, notificationOption)
+	notificationPreferenceRepo := data.NewNotificationPreferenceRepo(context, entClient)
+	commentNotificationService := service.NewCommentNotificationService(context, notificationOption, mailer, tokenSigner, commentNotificationRepo, notificationPreferenceRepo, internalMessageRepo, internalMessageRecipientRepo)
 	opensearchClient, cleanup3, err := client.NewElasticSearchClient(context)
 	if err != nil {
 		cleanup2()
@@ -119,38 +146,87 @@ func initApp(context *bootstrap.Context) (*kratos.App, func(), error) {
 	}
 	searchRepo := data.NewSearchRepo(context, opensearchClient)
 	searchService := service.NewSearchService(context, searchRepo, postRepo)
-	postService := service.NewPostService(context, postRepo, searchService, taskService)
-	categoryTranslationRepo := data.NewCategoryTranslationRepo(context, entClient)
-	categoryRepo := data.NewCategoryRepo(context, entClient, categoryTranslationRepo)
-	categoryService := service.NewCategoryService(context, categoryRepo)
-	tagTranslationRepo := data.NewTagTranslationRepo(context, entClient)
-	tagRepo := data.NewTagRepo(context, entClient, tagTranslationRepo)
-	tagService := service.NewTagService(context, tagRepo)
-	pageTranslationRepo := data.NewPageTranslationRepo(context, entClient)
+	trashOption := data.NewTrashOption(context)
+	pageTranslationRepo := data.NewPageTranslationRepo(context, entClient, redirectRepo)
 	sectionTranslationRepo := data.NewSectionTranslationRepo(context, entClient)
-	sectionRepo := data.NewSectionRepo(context, entClient, sectionTranslationRepo)
-	pageRepo := data.NewPageRepo(context, entClient, pageTranslationRepo, sectionRepo)
-	pageService := service.NewPageService(context, pageRepo)
-	sectionService := service.NewSectionService(context, sectionRepo)
+	sectionRepo := data.NewSectionRepo(context, entClient, sectionTranslationRepo, localeResolver)
+	pageRepo := data.NewPageRepo(context, entClient, pageTranslationRepo, sectionRepo, fieldGroupRepo, localeResolver)
+	categoryTranslationRepo := data.NewCategoryTranslationRepo(context, entClient, redirectRepo)
+	categoryRepo := data.NewCategoryRepo(context, entClient, categoryTranslationRepo, localeResolver)
+	tagTranslationRepo := data.NewTagTranslationRepo(context, entClient)
+	tagRepo := data.NewTagRepo(context, entClient, tagTranslationRepo, localeResolver)
+	mediaVariantRepo := data.NewMediaVariantRepo(context, entClient)
+	mediaAssetRepo := data.NewMediaAssetRepo(context, entClient, mediaVariantRepo)
+	trashRepo := data.NewTrashRepo(context, entClient, trashOption, postRepo, pageRepo, sectionRepo, categoryRepo, tagRepo, mediaAssetRepo, postCategoryRepo, postTagRepo)
+	previewOption := data.NewPreviewOption(context)
+	previewTokenRepo := data.NewPreviewTokenRepo(context, entClient, previewOption)
+	relatedPostsOption := data.NewRelatedPostsOption(context)
+	relatedPostRepo := data.NewRelatedPostRepo(context, entClient, redisClient, searchRepo, relatedPostsOption)
+	aiAssistJobRepo := data.NewAiAssistJobRepo(context, entClient)
+	llmOption := data.NewLlmOption(context)
+	llmProviders := data.NewLlmProviders(context, llmOption)
+	llmUsageRepo := data.NewLlmUsageRepo(context, entClient, llmProviders)
+	aiAssistService := service.NewAiAssistService(context, aiAssistJobRepo, llmUsageRepo, llmProviders, taskService)
+	seoRepo := data.NewSeoRepo(context, entClient)
+	syndicationOption := data.NewSyndicationOption(context)
+	syndicationRepo := data.NewSyndicationRepo(context, entClient, redisClient, syndicationOption)
+	structuredDataRepo := data.NewStructuredDataRepo(context, entClient, syndicationRepo)
+	postService := service.NewPostService(context, postRepo, trashRepo, previewTokenRepo, relatedPostRepo, searchService, taskService, aiAssistService, seoRepo, syndicationRepo, structuredDataRepo)
+	categoryService := service.NewCategoryService(context, categoryRepo, trashRepo, syndicationRepo, structuredDataRepo)
+	tagService := service.NewTagService(context, tagRepo, trashRepo, syndicationRepo)
+	pageService := service.NewPageService(context, pageRepo, trashRepo, previewTokenRepo, seoRepo, syndicationRepo, structuredDataRepo)
+	sectionService := service.NewSectionService(context, sectionRepo, trashRepo)
+	redirectService := service.NewRedirectService(context, redirectRepo)
+	routeRepo := data.NewRouteRepo(context, entClient, redirectRepo)
+	routeService := service.NewRouteService(context, routeRepo)
+	fieldGroupService := service.NewFieldGroupService(context, fieldGroupRepo)
+	contentModelRepo := data.NewContentModelRepo(context, entClient)
+	contentModelService := service.NewContentModelService(context, contentModelRepo)
+	contentEntryRepo := data.NewContentEntryRepo(context, entClient, contentModelRepo)
+	contentEntryService := service.NewContentEntryService(context, contentEntryRepo)
+	workflowService := service.NewWorkflowService(context, workflowRepo)
+	editorialRepo := data.NewEditorialRepo(context, entClient)
+	editorialService := service.NewEditorialService(context, editorialRepo, workflowRepo, userRepo, roleRepo, permissionRepo, commentNotificationRepo, internalMessageRepo, internalMessageRecipientRepo, syndicationRepo)
+	trashService := service.NewTrashService(context, trashRepo, syndicationRepo, postService)
+	previewService := service.NewPreviewService(context, previewTokenRepo)
+	releaseRepo := data.NewReleaseRepo(context, entClient)
+	releaseService := service.NewReleaseService(context, releaseRepo, syndicationRepo, postService)
+	formRepo := data.NewFormRepo(context, entClient)
+	formSubmissionRepo := data.NewFormSubmissionRepo(context, entClient)
+	formService := service.NewFormService(context, formRepo, formSubmissionRepo, taskService, commentNotificationRepo, internalMessageRepo, internalMessageRecipientRepo)
+	translationJobRepo := data.NewTranslationJobRepo(context, entClient)
+	translator := data.NewTranslator()
+	translationMemoryRepo := data.NewTranslationMemoryRepo(context, entClient)
+	glossaryTermRepo := data.NewGlossaryTermRepo(context, entClient)
+	machineTranslator := data.NewMachineTranslator(context, translator, translationMemoryRepo, glossaryTermRepo)
+	translationJobService := service.NewTranslationJobService(context, translationJobRepo, taskService, machineTranslator)
+	translationMemoryService := service.NewTranslationMemoryService(context, translationMemoryRepo)
+	glossaryService := service.NewGlossaryService(context, glossaryTermRepo)
+	translationDashboardRepo := data.NewTranslationDashboardRepo(context, entClient)
+	translationDashboardService := service.NewTranslationDashboardService(context, translationDashboardRepo)
+	translatorService := service.NewTranslatorService(context, machineTranslator)
+	seoService := service.NewSeoService(context, seoRepo)
+	syndicationService := service.NewSyndicationService(context, syndicationRepo)
 	siteRepo := data.NewSiteRepo(context, entClient)
-	siteService := service.NewSiteService(context, siteRepo)
+	siteService := service.NewSiteService(context, siteRepo, syndicationRepo)
 	siteSettingRepo := data.NewSiteSettingRepo(context, entClient)
-	siteSettingService := service.NewSiteSettingService(context, siteSettingRepo)
+	siteSettingService := service.NewSiteSettingService(context, siteSettingRepo, syndicationRepo)
 	navigationItemRepo := data.NewNavigationItemRepo(context, entClient)
-	navigationRepo := data.NewNavigationRepo(context, entClient, navigationItemRepo)
+	navigationRepo := data.NewNavigationRepo(context, entClient, navigationItemRepo, localeResolver)
 	navigationService := service.NewNavigationService(context, navigationRepo)
 	navigationItemService := service.NewNavigationItemService(context, navigationItemRepo)
-	mediaVariantRepo := data.NewMediaVariantRepo(context, entClient)
-	mediaAssetRepo := data.NewMediaAssetRepo(context, entClient, mediaVariantRepo)
-	mediaAssetService := service.NewMediaAssetService(context, mediaAssetRepo)
-	grpcServer, err := server.NewGrpcServer(context, v, authenticationService, loginPolicyService, userCredentialService, taskService, fileService, dictTypeService, dictEntryService, languageService, tenantService, userService, roleService, positionService, orgUnitService, menuService, apiService, permissionService, permissionGroupService, permissionAuditLogService, policyEvaluationLogService, loginAuditLogService, apiAuditLogService, operationAuditLogService, dataAccessAuditLogService, internalMessageService, internalMessageCategoryService, internalMessageRecipientService, commentService, interactionService, interactionAdminService, postService, categoryService, tagService, pageService, sectionService, siteService, siteSettingService, navigationService, navigationItemService, mediaAssetService)
+	mediaAssetService := service.NewMediaAssetService(context, mediaAssetRepo, trashRepo)
+	importJobRepo := data.NewImportJobRepo(context, entClient, workflowRepo, crypto)
+	uploadGuard := client.NewUploadGuard(context, storageOption)
+	importJobService := service.NewImportJobService(context, importJobRepo, fileRepo, redirectRepo, syndicationRepo, taskService, storageRouter, uploadGuard)
+	grpcServer, err := server.NewGrpcServer(context, v, authenticationService, loginPolicyService, userCredentialService, taskService, fileService, dictTypeService, dictEntryService, languageService, tenantService, userService, roleService, positionService, orgUnitService, menuService, apiService, permissionService, permissionGroupService, permissionAuditLogService, policyEvaluationLogService, loginAuditLogService, apiAuditLogService, operationAuditLogService, dataAccessAuditLogService, internalMessageService, internalMessageCategoryService, internalMessageRecipientService, commentService, commentModerationService, commentNotificationService, interactionService, interactionAdminService, postService, categoryService, tagService, pageService, sectionService, redirectService, routeService, fieldGroupService, contentModelService, contentEntryService, workflowService, editorialService, trashService, previewService, releaseService, formService, translationJobService, translationMemoryService, glossaryService, translationDashboardService, translatorService, aiAssistService, seoService, syndicationService, importJobService, siteService, siteSettingService, navigationService, navigationItemService, mediaAssetService)
 	if err != nil {
 		cleanup3()
 		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
-	asynqServer := server.NewAsynqServer(context, taskService, searchService)
+	asynqServer := server.NewAsynqServer(context, taskService, searchService, commentNotificationService, trashService, releaseService, formService, translationJobService, aiAssistService, importJobService)
 	app := newApp(context, grpcServer, asynqServer)
 	return app, func() {
 		cleanup3()
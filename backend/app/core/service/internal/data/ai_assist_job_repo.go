@@ -0,0 +1,582 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/tx7do/go-utils/copierutil"
+	"github.com/tx7do/go-utils/mapper"
+	"github.com/tx7do/go-utils/trans"
+
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	entCrud "github.com/tx7do/go-crud/entgo"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/aiassistjob"
+	"go-wind-cms/app/core/service/internal/data/ent/page"
+	"go-wind-cms/app/core/service/internal/data/ent/pagetranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/post"
+	"go-wind-cms/app/core/service/internal/data/ent/posttranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/predicate"
+	"go-wind-cms/app/core/service/internal/data/ent/section"
+	"go-wind-cms/app/core/service/internal/data/ent/sectiontranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/tagtranslation"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/locale"
+)
+
+const (
+	// aiAssistJobStaleAfter 执行中的作业超过该时长未结束（如 worker 崩溃），允许重新认领
+	aiAssistJobStaleAfter = 15 * time.Minute
+
+	// maxAiAssistKnownTags 推荐标签时提供给模型的已有标签数上限
+	maxAiAssistKnownTags = 200
+)
+
+// AiAssistSource AI 辅助作业依据的内容：某语言版本的标题、正文（页面为各区块内容）、摘要与 SEO 描述
+type AiAssistSource struct {
+	Title           string
+	Content         string
+	Summary         string
+	MetaDescription string
+	Revision        uint32
+
+	// KnownTags 站点该语言已有的标签名，推荐标签时优先从中选择
+	KnownTags []string
+}
+
+// AiAssistApply 待应用到内容的生成结果，为 nil 的字段不修改
+type AiAssistApply struct {
+	Summary         *string
+	MetaDescription *string
+}
+
+// AiAssistJobUsage 作业的供应商与累计 Token 用量
+type AiAssistJobUsage struct {
+	Provider         string
+	Model            string
+	PromptTokens     int
+	CompletionTokens int
+}
+
+// AiAssistJobRepo AI 内容辅助作业：创建、认领、结果记录，以及把生成结果应用到内容。
+type AiAssistJobRepo struct {
+	entClient *entCrud.EntClient[*ent.Client]
+	log       *log.Helper
+
+	mapper *mapper.CopierMapper[contentV1.AiAssistJob, ent.AiAssistJob]
+
+	repository *entCrud.Repository[
+		ent.AiAssistJobQuery, ent.AiAssistJobSelect,
+		ent.AiAssistJobCreate, ent.AiAssistJobCreateBulk,
+		ent.AiAssistJobUpdate, ent.AiAssistJobUpdateOne,
+		ent.AiAssistJobDelete,
+		predicate.AiAssistJob,
+		contentV1.AiAssistJob, ent.AiAssistJob,
+	]
+
+	statusConverter     *mapper.EnumTypeConverter[contentV1.AiAssistJob_AiAssistJobStatus, aiassistjob.Status]
+	entityTypeConverter *mapper.EnumTypeConverter[contentV1.AiAssistJob_EntityType, aiassistjob.EntityType]
+}
+
+func NewAiAssistJobRepo(ctx *bootstrap.Context, entClient *entCrud.EntClient[*ent.Client]) *AiAssistJobRepo {
+	repo := &AiAssistJobRepo{
+		entClient: entClient,
+		log:       ctx.NewLoggerHelper("ai-assist-job/repo/core-service"),
+		mapper:    mapper.NewCopierMapper[contentV1.AiAssistJob, ent.AiAssistJob](),
+		statusConverter: mapper.NewEnumTypeConverter[contentV1.AiAssistJob_AiAssistJobStatus, aiassistjob.Status](
+			contentV1.AiAssistJob_AiAssistJobStatus_name, contentV1.AiAssistJob_AiAssistJobStatus_value,
+		),
+		entityTypeConverter: mapper.NewEnumTypeConverter[contentV1.AiAssistJob_EntityType, aiassistjob.EntityType](
+			contentV1.AiAssistJob_EntityType_name, contentV1.AiAssistJob_EntityType_value,
+		),
+	}
+
+	repo.init()
+
+	return repo
+}
+
+func (r *AiAssistJobRepo) init() {
+	r.repository = entCrud.NewRepository[
+		ent.AiAssistJobQuery, ent.AiAssistJobSelect,
+		ent.AiAssistJobCreate, ent.AiAssistJobCreateBulk,
+		ent.AiAssistJobUpdate, ent.AiAssistJobUpdateOne,
+		ent.AiAssistJobDelete,
+		predicate.AiAssistJob,
+		contentV1.AiAssistJob, ent.AiAssistJob,
+	](r.mapper)
+
+	r.mapper.AppendConverters(copierutil.NewTimeStringConverterPair())
+	r.mapper.AppendConverters(copierutil.NewTimeTimestamppbConverterPair())
+	r.mapper.AppendConverters(r.statusConverter.NewConverterPair())
+	r.mapper.AppendConverters(r.entityTypeConverter.NewConverterPair())
+}
+
+func (r *AiAssistJobRepo) List(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListAiAssistJobResponse, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	builder := r.entClient.Client().AiAssistJob.Query()
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(aiassistjob.TenantIDEQ(tid))
+	}
+
+	ret, err := r.repository.ListWithPaging(ctx, builder, builder.Clone(), req)
+	if err != nil {
+		return nil, err
+	}
+	if ret == nil {
+		return &contentV1.ListAiAssistJobResponse{Total: 0, Items: nil}, nil
+	}
+
+	return &contentV1.ListAiAssistJobResponse{
+		Total: ret.Total,
+		Items: ret.Items,
+	}, nil
+}
+
+func (r *AiAssistJobRepo) Get(ctx context.Context, id uint32) (*contentV1.AiAssistJob, error) {
+	builder := r.entClient.Client().AiAssistJob.Query().
+		Where(aiassistjob.IDEQ(id))
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(aiassistjob.TenantIDEQ(tid))
+	}
+
+	entity, err := builder.Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound("ai assist job not found")
+		}
+		r.log.Errorf("query ai assist job failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query ai assist job failed")
+	}
+
+	return r.mapper.ToDTO(entity), nil
+}
+
+// normalizeAiAssistTasks 校验任务：取值合法、去重，保持请求中的顺序
+func normalizeAiAssistTasks(tasks []contentV1.AiAssistTask) ([]contentV1.AiAssistTask, error) {
+	seen := make(map[contentV1.AiAssistTask]struct{}, len(tasks))
+	out := make([]contentV1.AiAssistTask, 0, len(tasks))
+	for _, t := range tasks {
+		if _, ok := contentV1.AiAssistTask_name[int32(t)]; !ok || t == contentV1.AiAssistTask_AI_ASSIST_TASK_UNSPECIFIED {
+			return nil, fmt.Errorf("invalid task %d", t)
+		}
+		if _, ok := seen[t]; ok {
+			continue
+		}
+		seen[t] = struct{}{}
+		out = append(out, t)
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no task specified")
+	}
+	return out, nil
+}
+
+// AiAssistTaskSupported 内容类型是否支持该任务：页面没有摘要与标签
+func AiAssistTaskSupported(et aiassistjob.EntityType, t contentV1.AiAssistTask) bool {
+	switch t {
+	case contentV1.AiAssistTask_AI_ASSIST_TASK_SEO_DESCRIPTION,
+		contentV1.AiAssistTask_AI_ASSIST_TASK_TITLE_ALTERNATIVES:
+		return true
+	case contentV1.AiAssistTask_AI_ASSIST_TASK_SUMMARY,
+		contentV1.AiAssistTask_AI_ASSIST_TASK_TAG_SUGGESTIONS:
+		return et == aiassistjob.EntityTypeEntityTypePost
+	default:
+		return false
+	}
+}
+
+// Create 创建作业：确认内容属于当前租户且该语言版本存在，记录其当前修订号
+func (r *AiAssistJobRepo) Create(ctx context.Context, req *contentV1.CreateAiAssistJobRequest) (*contentV1.AiAssistJob, error) {
+	if req == nil || req.GetEntityId() == 0 {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+	if !locale.ValidCode(req.GetLanguageCode()) {
+		return nil, contentV1.ErrorBadRequest("invalid language code")
+	}
+	tasks, err := normalizeAiAssistTasks(req.GetTasks())
+	if err != nil {
+		return nil, contentV1.ErrorBadRequest(err.Error())
+	}
+
+	et := req.GetEntityType()
+	entityType := r.entityTypeConverter.ToEntity(&et)
+	if entityType == nil {
+		return nil, contentV1.ErrorBadRequest("unsupported entity type")
+	}
+
+	tid, hasTenant := maybeTenantFromViewer(ctx)
+	var tenantID *uint32
+	if hasTenant {
+		tenantID = trans.Ptr(tid)
+	}
+	src, err := r.loadTranslation(ctx, *entityType, req.GetEntityId(), req.GetLanguageCode(), tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := r.entClient.Client().AiAssistJob.Create().
+		SetEntityType(*entityType).
+		SetEntityID(req.GetEntityId()).
+		SetLanguageCode(req.GetLanguageCode()).
+		SetTasks(tasks).
+		SetApply(req.GetApply()).
+		SetBaseRevision(src.Revision).
+		SetStatus(aiassistjob.StatusAiAssistJobStatusPending).
+		SetCreatedAt(time.Now())
+	if hasTenant {
+		builder.SetTenantID(tid)
+	}
+	if operatorID, ok := viewerUserIDFromContext(ctx); ok {
+		builder.SetCreatedBy(operatorID)
+	}
+
+	entity, err := builder.Save(ctx)
+	if err != nil {
+		r.log.Errorf("insert ai assist job failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("insert ai assist job failed")
+	}
+
+	return r.mapper.ToDTO(entity), nil
+}
+
+// Claim 认领待执行（或执行超时）的作业并置为执行中；已被认领或已结束时返回 nil
+func (r *AiAssistJobRepo) Claim(ctx context.Context, id uint32) (*ent.AiAssistJob, error) {
+	now := time.Now()
+	n, err := r.entClient.Client().AiAssistJob.Update().
+		Where(
+			aiassistjob.IDEQ(id),
+			aiassistjob.Or(
+				aiassistjob.StatusEQ(aiassistjob.StatusAiAssistJobStatusPending),
+				aiassistjob.And(
+					aiassistjob.StatusEQ(aiassistjob.StatusAiAssistJobStatusRunning),
+					aiassistjob.StartedAtLT(now.Add(-aiAssistJobStaleAfter)),
+				),
+			),
+		).
+		SetStatus(aiassistjob.StatusAiAssistJobStatusRunning).
+		SetStartedAt(now).
+		ClearResults().
+		SetUpdatedAt(now).
+		Save(ctx)
+	if err != nil {
+		r.log.Errorf("claim ai assist job failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("claim ai assist job failed")
+	}
+	if n == 0 {
+		return nil, nil
+	}
+
+	entity, err := r.entClient.Client().AiAssistJob.Get(ctx, id)
+	if err != nil {
+		r.log.Errorf("query ai assist job failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query ai assist job failed")
+	}
+	return entity, nil
+}
+
+// Finish 结束作业并记录结果与用量；cause 非空时作业失败并记录原因
+func (r *AiAssistJobRepo) Finish(ctx context.Context, id uint32, results []*contentV1.AiAssistResult, usage *AiAssistJobUsage, cause error) error {
+	now := time.Now()
+	builder := r.entClient.Client().AiAssistJob.UpdateOneID(id).
+		SetResults(results).
+		SetFinishedAt(now).
+		SetUpdatedAt(now)
+
+	if usage != nil {
+		builder.
+			SetProvider(usage.Provider).
+			SetModel(usage.Model).
+			SetPromptTokens(uint32(usage.PromptTokens)).
+			SetCompletionTokens(uint32(usage.CompletionTokens))
+	}
+
+	if cause != nil {
+		msg := cause.Error()
+		if e := kerrors.FromError(cause); e != nil && e.GetMessage() != "" {
+			msg = e.GetMessage()
+		}
+		if len(msg) > 1024 {
+			msg = msg[:1024]
+		}
+		builder.SetStatus(aiassistjob.StatusAiAssistJobStatusFailed).SetLastError(msg)
+	} else {
+		builder.SetStatus(aiassistjob.StatusAiAssistJobStatusCompleted)
+	}
+
+	if err := builder.Exec(ctx); err != nil {
+		r.log.Errorf("finish ai assist job failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("finish ai assist job failed")
+	}
+	return nil
+}
+
+// LoadSource 读取作业内容的语言版本
+func (r *AiAssistJobRepo) LoadSource(ctx context.Context, job *ent.AiAssistJob) (*AiAssistSource, error) {
+	src, err := r.loadTranslation(ctx, job.EntityType, job.EntityID, job.LanguageCode, job.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range job.Tasks {
+		if t != contentV1.AiAssistTask_AI_ASSIST_TASK_TAG_SUGGESTIONS {
+			continue
+		}
+		q := r.entClient.Client().TagTranslation.Query().
+			Where(tagtranslation.LanguageCodeEQ(job.LanguageCode), tagtranslation.NameNotNil())
+		if tid := trans.Uint32Value(job.TenantID); tid != 0 {
+			q.Where(tagtranslation.TenantIDEQ(tid))
+		}
+		if src.KnownTags, err = q.Limit(maxAiAssistKnownTags).Select(tagtranslation.FieldName).Strings(ctx); err != nil {
+			r.log.Errorf("query known tags failed: %s", err.Error())
+			return nil, contentV1.ErrorInternalServerError("query known tags failed")
+		}
+		break
+	}
+
+	return src, nil
+}
+
+// loadTranslation 读取内容的语言版本及其当前修订号
+func (r *AiAssistJobRepo) loadTranslation(ctx context.Context, et aiassistjob.EntityType, id uint32, lang string, tenantID *uint32) (*AiAssistSource, error) {
+	client := r.entClient.Client()
+	tid := trans.Uint32Value(tenantID)
+	src := &AiAssistSource{}
+
+	// 确认内容存在、未移入回收站且属于指定租户
+	var (
+		exist bool
+		err   error
+	)
+	switch et {
+	case aiassistjob.EntityTypeEntityTypePost:
+		q := client.Post.Query().Where(post.IDEQ(id), post.DeletedAtIsNil())
+		if tid != 0 {
+			q.Where(post.TenantIDEQ(tid))
+		}
+		exist, err = q.Exist(ctx)
+	case aiassistjob.EntityTypeEntityTypePage:
+		q := client.Page.Query().Where(page.IDEQ(id), page.DeletedAtIsNil())
+		if tid != 0 {
+			q.Where(page.TenantIDEQ(tid))
+		}
+		exist, err = q.Exist(ctx)
+	default:
+		return nil, contentV1.ErrorBadRequest("unsupported entity type")
+	}
+	if err != nil {
+		r.log.Errorf("query %s failed: %s", et, err.Error())
+		return nil, contentV1.ErrorInternalServerError("query content failed")
+	}
+	if !exist {
+		return nil, contentV1.ErrorNotFound(fmt.Sprintf("content %d not found", id))
+	}
+
+	switch et {
+	case aiassistjob.EntityTypeEntityTypePost:
+		var t *ent.PostTranslation
+		if t, err = client.PostTranslation.Query().
+			Where(posttranslation.PostIDEQ(id), posttranslation.LanguageCodeEQ(lang)).
+			First(ctx); err == nil {
+			src.Title = trans.StringValue(t.Title)
+			src.Content = trans.StringValue(t.Content)
+			src.Summary = trans.StringValue(t.Summary)
+			src.MetaDescription = t.Seo.GetMetaDescription()
+			src.Revision = translationRevisionValue(t.Revision)
+		}
+
+	case aiassistjob.EntityTypeEntityTypePage:
+		var t *ent.PageTranslation
+		if t, err = client.PageTranslation.Query().
+			Where(pagetranslation.PageIDEQ(id), pagetranslation.LanguageCodeEQ(lang)).
+			First(ctx); err == nil {
+			src.Title = trans.StringValue(t.Title)
+			src.MetaDescription = t.Seo.GetMetaDescription()
+			src.Revision = translationRevisionValue(t.Revision)
+			src.Content, err = pageTranslationContent(ctx, client, id, lang)
+		}
+	}
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound(fmt.Sprintf("%q version of content %d not found", lang, id))
+		}
+		r.log.Errorf("query %s translation failed: %s", et, err.Error())
+		return nil, contentV1.ErrorInternalServerError("query content failed")
+	}
+
+	return src, nil
+}
+
+// pageTranslationContent 拼接页面各区块该语言的内容，作为生成 SEO 描述、标题与 SEO 分析的依据
+func pageTranslationContent(ctx context.Context, client *ent.Client, pageID uint32, lang string) (string, error) {
+	sectionIDs, err := client.Section.Query().
+		Where(section.PageIDEQ(pageID)).
+		Order(ent.Asc(section.FieldSortOrder), ent.Asc(section.FieldID)).
+		IDs(ctx)
+	if err != nil || len(sectionIDs) == 0 {
+		return "", err
+	}
+
+	translations, err := client.SectionTranslation.Query().
+		Where(sectiontranslation.SectionIDIn(sectionIDs...), sectiontranslation.LanguageCodeEQ(lang)).
+		All(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	bySection := make(map[uint32]*ent.SectionTranslation, len(translations))
+	for _, t := range translations {
+		bySection[trans.Uint32Value(t.SectionID)] = t
+	}
+
+	var sb strings.Builder
+	for _, id := range sectionIDs {
+		t, ok := bySection[id]
+		if !ok || t.Content == nil {
+			continue
+		}
+		keys := make([]string, 0, len(*t.Content))
+		for k := range *t.Content {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			sb.WriteString((*t.Content)[k])
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String(), nil
+}
+
+// Apply 把生成结果应用到作业内容的语言版本；仅当该版本的修订号仍为作业创建时的修订号才写入，
+// 写入后递增修订号。内容已被修改时返回 false，结果保留为建议
+func (r *AiAssistJobRepo) Apply(ctx context.Context, job *ent.AiAssistJob, apply *AiAssistApply) (applied bool, err error) {
+	if apply == nil || (apply.Summary == nil && apply.MetaDescription == nil) {
+		return false, nil
+	}
+
+	var tx *ent.Tx
+	tx, err = r.entClient.Client().Tx(ctx)
+	if err != nil {
+		r.log.Errorf("start transaction failed: %s", err.Error())
+		return false, contentV1.ErrorInternalServerError("start transaction failed")
+	}
+	defer func() {
+		if err != nil || !applied {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				r.log.Errorf("transaction rollback failed: %s", rollbackErr.Error())
+			}
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			r.log.Errorf("transaction commit failed: %s", commitErr.Error())
+			applied, err = false, contentV1.ErrorInternalServerError("transaction commit failed")
+		}
+	}()
+
+	withSeo := func(seo *contentV1.SeoMeta) *contentV1.SeoMeta {
+		if apply.MetaDescription == nil {
+			return seo
+		}
+		if seo == nil {
+			seo = &contentV1.SeoMeta{}
+		} else {
+			seo = proto.Clone(seo).(*contentV1.SeoMeta)
+		}
+		seo.MetaDescription = apply.MetaDescription
+		return seo
+	}
+
+	switch job.EntityType {
+	case aiassistjob.EntityTypeEntityTypePost:
+		var t *ent.PostTranslation
+		t, err = tx.PostTranslation.Query().
+			Where(posttranslation.PostIDEQ(job.EntityID), posttranslation.LanguageCodeEQ(job.LanguageCode)).
+			First(ctx)
+		if err != nil || translationRevisionValue(t.Revision) != job.BaseRevision {
+			break
+		}
+		// 以修订号为条件更新，避免覆盖读取之后的并发修改；修订号为空的历史数据视为 1
+		unchanged := posttranslation.RevisionEQ(job.BaseRevision)
+		if job.BaseRevision == 1 {
+			unchanged = posttranslation.Or(unchanged, posttranslation.RevisionIsNil())
+		}
+		var n int
+		n, err = tx.PostTranslation.Update().
+			Where(posttranslation.IDEQ(t.ID), unchanged).
+			SetNillableSummary(apply.Summary).
+			SetSeo(withSeo(t.Seo)).
+			SetUpdatedAt(time.Now()).
+			Save(ctx)
+		if err == nil && n > 0 {
+			if _, err = bumpPostTranslationRevision(ctx, tx.Client(), t.ID); err == nil {
+				applied = true
+			}
+		}
+
+	case aiassistjob.EntityTypeEntityTypePage:
+		var t *ent.PageTranslation
+		t, err = tx.PageTranslation.Query().
+			Where(pagetranslation.PageIDEQ(job.EntityID), pagetranslation.LanguageCodeEQ(job.LanguageCode)).
+			First(ctx)
+		if err != nil || translationRevisionValue(t.Revision) != job.BaseRevision || apply.MetaDescription == nil {
+			break
+		}
+		unchanged := pagetranslation.RevisionEQ(job.BaseRevision)
+		if job.BaseRevision == 1 {
+			unchanged = pagetranslation.Or(unchanged, pagetranslation.RevisionIsNil())
+		}
+		var n int
+		n, err = tx.PageTranslation.Update().
+			Where(pagetranslation.IDEQ(t.ID), unchanged).
+			SetSeo(withSeo(t.Seo)).
+			SetUpdatedAt(time.Now()).
+			Save(ctx)
+		if err == nil && n > 0 {
+			if _, err = bumpPageTranslationRevision(ctx, tx.Client(), t.ID); err == nil {
+				applied = true
+			}
+		}
+	}
+	if err != nil {
+		if ent.IsNotFound(err) {
+			// 语言版本已被删除，结果保留为建议
+			err = nil
+			return false, nil
+		}
+		r.log.Errorf("apply ai assist job [%d] failed: %s", job.ID, err.Error())
+		return false, contentV1.ErrorInternalServerError("apply ai assist result failed")
+	}
+
+	return applied, nil
+}
+
+// AutoSummaryEnabled 文章是否开启了自动摘要
+func (r *AiAssistJobRepo) AutoSummaryEnabled(ctx context.Context, postID uint32) (bool, error) {
+	entity, err := r.entClient.Client().Post.Query().
+		Where(post.IDEQ(postID)).
+		Select(post.FieldAutoSummary).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return false, nil
+		}
+		r.log.Errorf("query post failed: %s", err.Error())
+		return false, contentV1.ErrorInternalServerError("query post failed")
+	}
+	return trans.BoolValue(entity.AutoSummary), nil
+}
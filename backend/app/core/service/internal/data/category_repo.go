@@ -46,12 +46,14 @@ type CategoryRepo struct {
 	statusConverter *mapper.EnumTypeConverter[contentV1.Category_CategoryStatus, category.Status]
 
 	categoryTranslationRepo *CategoryTranslationRepo
+	localeResolver          *LocaleResolver
 }
 
 func NewCategoryRepo(
 	ctx *bootstrap.Context,
 	entClient *entCrud.EntClient[*ent.Client],
 	categoryTranslationRepo *CategoryTranslationRepo,
+	localeResolver *LocaleResolver,
 ) *CategoryRepo {
 	repo := &CategoryRepo{
 		entClient: entClient,
@@ -61,6 +63,7 @@ func NewCategoryRepo(
 			contentV1.Category_CategoryStatus_name, contentV1.Category_CategoryStatus_value,
 		),
 		categoryTranslationRepo: categoryTranslationRepo,
+		localeResolver:          localeResolver,
 	}
 
 	repo.init()
@@ -205,7 +208,8 @@ func (r *CategoryRepo) List(ctx context.Context, req *paginationV1.PagingRequest
 		return nil, contentV1.ErrorBadRequest("invalid parameter")
 	}
 
-	builder := r.entClient.Client().Category.Query()
+	builder := r.entClient.Client().Category.Query().
+		Where(category.DeletedAtIsNil())
 
 	excludeConditions, translationMaskFields, needQueryTranslation, treeTravel, err := r.prepareTranslationMaskFields(req)
 	if err != nil {
@@ -235,8 +239,24 @@ func (r *CategoryRepo) List(ctx context.Context, req *paginationV1.PagingRequest
 			}
 		}
 
+		// 指定语言时按回退链逐项选出实际返回的翻译语言，没有可用翻译的分类不附带翻译
+		chain := r.localeResolver.Chain(ctx, locale)
+
 		for _, item := range ret.Items {
-			translations, err := r.categoryTranslationRepo.ListTranslations(ctx, item.GetId(), locale, viewMask)
+			served := locale
+			if len(chain) > 0 {
+				languages, err := r.categoryTranslationRepo.ListAvailedLanguages(ctx, item.GetId())
+				if err != nil {
+					r.log.Errorf("query availed languages failed: %s", err.Error())
+					return nil, contentV1.ErrorInternalServerError("query availed languages failed")
+				}
+				if served = pickLocale(chain, languages); served == "" {
+					continue
+				}
+				item.ServedLocale = trans.Ptr(served)
+			}
+
+			translations, err := r.categoryTranslationRepo.ListTranslations(ctx, item.GetId(), served, viewMask)
 			if err != nil {
 				r.log.Errorf("query translations failed: %s", err.Error())
 				return nil, contentV1.ErrorInternalServerError("query translations failed")
@@ -268,9 +288,9 @@ func (r *CategoryRepo) List(ctx context.Context, req *paginationV1.PagingRequest
 	}, nil
 }
 
-// 递归查询子节点
-func (r *CategoryRepo) getCategoryWithChildren(ctx context.Context, id uint32, locale string, viewMask *fieldmaskpb.FieldMask, translationMaskFields []string) (*contentV1.Category, error) {
-	entity, err := r.entClient.Client().Category.Query().Where(category.IDEQ(id)).Only(ctx)
+// 递归查询子节点；chain 为语言回退顺序，为空时返回全部翻译
+func (r *CategoryRepo) getCategoryWithChildren(ctx context.Context, id uint32, chain []string, viewMask *fieldmaskpb.FieldMask, translationMaskFields []string) (*contentV1.Category, error) {
+	entity, err := r.entClient.Client().Category.Query().Where(category.IDEQ(id), category.DeletedAtIsNil()).Only(ctx)
 	if err != nil {
 		if ent.IsNotFound(err) {
 			return nil, contentV1.ErrorFileNotFound("category not found")
@@ -281,33 +301,40 @@ func (r *CategoryRepo) getCategoryWithChildren(ctx context.Context, id uint32, l
 	dto := r.mapper.ToDTO(entity)
 
 	// 查询子节点
-	childrenEntities, err := r.entClient.Client().Category.Query().Where(category.ParentIDEQ(id)).All(ctx)
+	childrenEntities, err := r.entClient.Client().Category.Query().Where(category.ParentIDEQ(id), category.DeletedAtIsNil()).All(ctx)
 	if err != nil {
 		r.log.Errorf("query children failed: %s", err.Error())
 		return nil, contentV1.ErrorInternalServerError("query children failed")
 	}
 	for _, child := range childrenEntities {
-		childDTO, err := r.getCategoryWithChildren(ctx, child.ID, locale, viewMask, translationMaskFields)
+		childDTO, err := r.getCategoryWithChildren(ctx, child.ID, chain, viewMask, translationMaskFields)
 		if err != nil {
 			return nil, err
 		}
 		dto.Children = append(dto.Children, childDTO)
 	}
 
-	// 查询翻译和可用语言（可复用原有逻辑）
+	// 查询可用语言和翻译（可复用原有逻辑）
+	languages, err := r.categoryTranslationRepo.ListAvailedLanguages(ctx, dto.GetId())
+	if err == nil {
+		dto.AvailableLanguages = languages
+	}
+	served := ""
+	if len(chain) > 0 {
+		if served = pickLocale(chain, languages); served == "" {
+			return dto, nil
+		}
+		dto.ServedLocale = trans.Ptr(served)
+	}
 	translations, err := r.categoryTranslationRepo.ListTranslations(
 		ctx,
 		dto.GetId(),
-		locale,
+		served,
 		&fieldmaskpb.FieldMask{Paths: translationMaskFields},
 	)
 	if err == nil {
 		dto.Translations = translations
 	}
-	languages, err := r.categoryTranslationRepo.ListAvailedLanguages(ctx, dto.GetId())
-	if err == nil {
-		dto.AvailableLanguages = languages
-	}
 
 	return dto, nil
 }
@@ -345,7 +372,7 @@ func (r *CategoryRepo) Get(ctx context.Context, req *contentV1.GetCategoryReques
 
 		switch req.QueryBy.(type) {
 		case *contentV1.GetCategoryRequest_Id:
-			entity, err := r.entClient.Client().Category.Query().Where(category.IDEQ(req.GetId())).Only(ctx)
+			entity, err := r.entClient.Client().Category.Query().Where(category.IDEQ(req.GetId()), category.DeletedAtIsNil()).Only(ctx)
 			if err != nil {
 				if ent.IsNotFound(err) {
 					return nil, contentV1.ErrorFileNotFound("category not found")
@@ -356,7 +383,7 @@ func (r *CategoryRepo) Get(ctx context.Context, req *contentV1.GetCategoryReques
 			dto = r.mapper.ToDTO(entity)
 
 		case *contentV1.GetCategoryRequest_Code:
-			entity, err := r.entClient.Client().Category.Query().Where(category.CodeEQ(req.GetCode())).Only(ctx)
+			entity, err := r.entClient.Client().Category.Query().Where(category.CodeEQ(req.GetCode()), category.DeletedAtIsNil()).Only(ctx)
 			if err != nil {
 				if ent.IsNotFound(err) {
 					return nil, contentV1.ErrorFileNotFound("category not found")
@@ -370,10 +397,26 @@ func (r *CategoryRepo) Get(ctx context.Context, req *contentV1.GetCategoryReques
 			return nil, contentV1.ErrorBadRequest("invalid query_by value")
 		}
 
+		languages, err := r.categoryTranslationRepo.ListAvailedLanguages(ctx, dto.GetId())
+		if err != nil {
+			r.log.Errorf("query availed languages failed: %s", err.Error())
+			return nil, contentV1.ErrorInternalServerError("query availed languages failed")
+		}
+		dto.AvailableLanguages = languages
+
+		// 指定语言时只返回按回退链选出的翻译，均不可用时不附带翻译
+		served := req.GetLocale()
+		if served != "" {
+			if served = r.localeResolver.Serve(ctx, req.GetLocale(), languages); served == "" {
+				return dto, nil
+			}
+			dto.ServedLocale = trans.Ptr(served)
+		}
+
 		translations, err := r.categoryTranslationRepo.ListTranslations(
 			ctx,
 			dto.GetId(),
-			req.GetLocale(),
+			served,
 			&fieldmaskpb.FieldMask{Paths: translationMaskFields},
 		)
 		if err != nil {
@@ -382,13 +425,6 @@ func (r *CategoryRepo) Get(ctx context.Context, req *contentV1.GetCategoryReques
 		}
 		dto.Translations = translations
 
-		languages, err := r.categoryTranslationRepo.ListAvailedLanguages(ctx, dto.GetId())
-		if err != nil {
-			r.log.Errorf("query availed languages failed: %s", err.Error())
-			return nil, contentV1.ErrorInternalServerError("query availed languages failed")
-		}
-		dto.AvailableLanguages = languages
-
 		return dto, nil
 	}
 
@@ -397,7 +433,7 @@ func (r *CategoryRepo) Get(ctx context.Context, req *contentV1.GetCategoryReques
 	case *contentV1.GetCategoryRequest_Id:
 		id = req.GetId()
 	case *contentV1.GetCategoryRequest_Code:
-		entity, err := r.entClient.Client().Category.Query().Where(category.CodeEQ(req.GetCode())).Only(ctx)
+		entity, err := r.entClient.Client().Category.Query().Where(category.CodeEQ(req.GetCode()), category.DeletedAtIsNil()).Only(ctx)
 		if err != nil {
 			if ent.IsNotFound(err) {
 				return nil, contentV1.ErrorFileNotFound("category not found")
@@ -410,7 +446,7 @@ func (r *CategoryRepo) Get(ctx context.Context, req *contentV1.GetCategoryReques
 		return nil, contentV1.ErrorBadRequest("invalid query_by value")
 	}
 
-	return r.getCategoryWithChildren(ctx, id, req.GetLocale(), req.GetViewMask(), translationMaskFields)
+	return r.getCategoryWithChildren(ctx, id, r.localeResolver.Chain(ctx, req.GetLocale()), req.GetViewMask(), translationMaskFields)
 }
 
 func (r *CategoryRepo) Create(ctx context.Context, req *contentV1.CreateCategoryRequest) (dto *contentV1.Category, err error) {
@@ -499,6 +535,14 @@ func (r *CategoryRepo) Update(ctx context.Context, req *contentV1.UpdateCategory
 		}
 	}
 
+	// 整体替换语言版本前记下各语言的完整路径，提交后对比并记录自动重定向
+	var beforePaths map[string]string
+	if len(req.Data.Translations) > 0 {
+		if beforePaths, _, err = r.categoryTranslationRepo.FullPaths(ctx, req.GetId()); err != nil {
+			return nil, err
+		}
+	}
+
 	var tx *ent.Tx
 	tx, err = r.entClient.Client().Tx(ctx)
 	if err != nil {
@@ -515,7 +559,9 @@ func (r *CategoryRepo) Update(ctx context.Context, req *contentV1.UpdateCategory
 		if commitErr := tx.Commit(); commitErr != nil {
 			r.log.Errorf("transaction commit failed: %s", commitErr.Error())
 			err = contentV1.ErrorInternalServerError("transaction commit failed")
+			return
 		}
+		r.categoryTranslationRepo.RecordReplacedPaths(ctx, req.GetId(), beforePaths)
 	}()
 
 	if len(req.Data.Translations) > 0 {
@@ -544,7 +590,7 @@ func (r *CategoryRepo) Update(ctx context.Context, req *contentV1.UpdateCategory
 		})
 	}
 	builder := tx.Category.UpdateOneID(req.GetId())
-	builder.Where(category.IDEQ(req.GetId()))
+	builder.Where(category.IDEQ(req.GetId()), category.DeletedAtIsNil())
 	if hasTenant {
 		builder.Where(category.TenantIDEQ(tid))
 	}
@@ -578,42 +624,20 @@ func (r *CategoryRepo) Update(ctx context.Context, req *contentV1.UpdateCategory
 	return result, err
 }
 
-func (r *CategoryRepo) Delete(ctx context.Context, req *contentV1.DeleteCategoryRequest) (err error) {
-	if req == nil {
-		return contentV1.ErrorBadRequest("invalid parameter")
-	}
-
-	var tx *ent.Tx
-	tx, err = r.entClient.Client().Tx(ctx)
-	if err != nil {
-		r.log.Errorf("start transaction failed: %s", err.Error())
-		return contentV1.ErrorInternalServerError("start transaction failed")
-	}
-	defer func() {
-		if err != nil {
-			if rollbackErr := tx.Rollback(); rollbackErr != nil {
-				r.log.Errorf("transaction rollback failed: %s", rollbackErr.Error())
-			}
-			return
-		}
-		if commitErr := tx.Commit(); commitErr != nil {
-			r.log.Errorf("transaction commit failed: %s", commitErr.Error())
-			err = contentV1.ErrorInternalServerError("transaction commit failed")
-		}
-	}()
-
+// Purge 在给定事务中物理删除分类及其翻译，仅由回收站彻底清理时调用
+func (r *CategoryRepo) Purge(ctx context.Context, tx *ent.Tx, id uint32) error {
 	tid, hasTenant := maybeTenantFromViewer(ctx)
 	delBuilder := tx.Category.Delete()
-	delBuilder.Where(category.IDEQ(req.GetId()))
+	delBuilder.Where(category.IDEQ(id))
 	if hasTenant {
 		delBuilder.Where(category.TenantIDEQ(tid))
 	}
-	if _, err = delBuilder.Exec(ctx); err != nil {
+	if _, err := delBuilder.Exec(ctx); err != nil {
 		r.log.Errorf("delete one data failed: %s", err.Error())
 		return contentV1.ErrorInternalServerError("delete one data failed")
 	}
 
-	if err = r.categoryTranslationRepo.CleanTranslations(ctx, tx, req.GetId()); err != nil {
+	if err := r.categoryTranslationRepo.CleanTranslations(ctx, tx, id); err != nil {
 		r.log.Errorf("clean translations failed: %s", err.Error())
 		return contentV1.ErrorInternalServerError("clean translations failed")
 	}
@@ -677,7 +701,16 @@ func (r *CategoryRepo) GetTranslation(ctx context.Context, req *contentV1.GetCat
 		return nil, contentV1.ErrorBadRequest("invalid parameter")
 	}
 
-	return r.categoryTranslationRepo.GetTranslation(ctx, req.GetId(), req.GetLocale())
+	languages, err := r.categoryTranslationRepo.ListAvailedLanguages(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	served := r.localeResolver.Serve(ctx, req.GetLocale(), languages)
+	if served == "" {
+		return nil, contentV1.ErrorNotFound("translation not found")
+	}
+
+	return r.categoryTranslationRepo.GetTranslation(ctx, req.GetId(), served)
 }
 
 func (r *CategoryRepo) ListTranslations(ctx context.Context, categoryID uint32) ([]*contentV1.CategoryTranslation, error) {
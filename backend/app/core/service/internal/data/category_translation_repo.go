@@ -2,6 +2,7 @@ package data
 
 import (
 	"context"
+	"slices"
 	"strconv"
 	"time"
 
@@ -20,6 +21,8 @@ import (
 	"go-wind-cms/app/core/service/internal/data/ent/predicate"
 
 	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/utils"
 )
 
 type CategoryTranslationRepo struct {
@@ -36,13 +39,21 @@ type CategoryTranslationRepo struct {
 		predicate.CategoryTranslation,
 		contentV1.CategoryTranslation, ent.CategoryTranslation,
 	]
+
+	translationStatusConverter *mapper.EnumTypeConverter[contentV1.TranslationStatus, categorytranslation.TranslationStatus]
+
+	redirectRepo *RedirectRepo
 }
 
-func NewCategoryTranslationRepo(ctx *bootstrap.Context, entClient *entCrud.EntClient[*ent.Client]) *CategoryTranslationRepo {
+func NewCategoryTranslationRepo(ctx *bootstrap.Context, entClient *entCrud.EntClient[*ent.Client], redirectRepo *RedirectRepo) *CategoryTranslationRepo {
 	repo := &CategoryTranslationRepo{
-		entClient: entClient,
-		log:       ctx.NewLoggerHelper("category-translation/repo/core-service"),
-		mapper:    mapper.NewCopierMapper[contentV1.CategoryTranslation, ent.CategoryTranslation](),
+		entClient:    entClient,
+		redirectRepo: redirectRepo,
+		log:          ctx.NewLoggerHelper("category-translation/repo/core-service"),
+		mapper:       mapper.NewCopierMapper[contentV1.CategoryTranslation, ent.CategoryTranslation](),
+		translationStatusConverter: mapper.NewEnumTypeConverter[contentV1.TranslationStatus, categorytranslation.TranslationStatus](
+			contentV1.TranslationStatus_name, contentV1.TranslationStatus_value,
+		),
 	}
 
 	repo.init()
@@ -62,6 +73,7 @@ func (r *CategoryTranslationRepo) init() {
 
 	r.mapper.AppendConverters(copierutil.NewTimeStringConverterPair())
 	r.mapper.AppendConverters(copierutil.NewTimeTimestamppbConverterPair())
+	r.mapper.AppendConverters(r.translationStatusConverter.NewConverterPair())
 }
 
 func (r *CategoryTranslationRepo) CleanTranslations(
@@ -130,6 +142,10 @@ func (r *CategoryTranslationRepo) newCreateBuilder(data *contentV1.CategoryTrans
 		SetNillableThumbnail(data.Thumbnail).
 		SetNillableCoverImage(data.CoverImage).
 		SetNillableFullPath(data.FullPath).
+		SetNillableIsDraft(data.IsDraft).
+		SetNillableSourceLanguage(data.SourceLanguage).
+		SetNillableSourceRevision(data.SourceRevision).
+		SetNillableTranslationStatus(r.translationStatusConverter.ToEntity(data.TranslationStatus)).
 		SetNillableCreatedBy(data.CreatedBy).
 		SetCreatedAt(now)
 
@@ -148,6 +164,9 @@ func (r *CategoryTranslationRepo) BatchCreate(ctx context.Context, tx *ent.Tx, i
 	builders := make([]*ent.CategoryTranslationCreate, 0, len(items))
 	for _, data := range items {
 		_ = r.PrepareTranslation(ctx, data)
+		if err := r.anchorTranslation(ctx, tx.Client(), data, items); err != nil {
+			return err
+		}
 
 		builder := r.newCreateBuilder(data)
 
@@ -165,6 +184,9 @@ func (r *CategoryTranslationRepo) BatchCreate(ctx context.Context, tx *ent.Tx, i
 
 func (r *CategoryTranslationRepo) CreateTranslation(ctx context.Context, data *contentV1.CategoryTranslation) (*contentV1.CategoryTranslation, error) {
 	_ = r.PrepareTranslation(ctx, data)
+	if err := r.anchorTranslation(ctx, r.entClient.Client(), data, nil); err != nil {
+		return nil, err
+	}
 
 	builder := r.newCreateBuilder(data)
 
@@ -182,6 +204,39 @@ func (r *CategoryTranslationRepo) UpdateTranslation(ctx context.Context, id uint
 		return nil, nil
 	}
 
+	// 记录更新前的完整路径，变更后自动生成旧路径的重定向
+	old, err := r.entClient.Client().CategoryTranslation.Get(ctx, id)
+	if err != nil && !ent.IsNotFound(err) {
+		r.log.Errorf("query category translation failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query category translation failed")
+	}
+
+	// 完整路径由服务端随 slug 推导，旧路径在更新后记录为重定向
+	if old != nil {
+		if fullPath := deriveFullPath(trans.StringValue(old.Slug), trans.StringValue(old.FullPath), data.Slug, data.FullPath); fullPath != data.FullPath {
+			data.FullPath = fullPath
+			if updateMask != nil && !slices.Contains(updateMask.GetPaths(), "full_path") {
+				updateMask.Paths = append(updateMask.Paths, "full_path")
+			}
+		}
+	}
+
+	// 修订跟踪字段由服务端维护：修改源语言或确认已校对时重新锚定，其余情况忽略客户端传入值
+	var anchor *translationAnchor
+	if old != nil {
+		if lang, ok := translationAnchorRequested(updateMask, data.SourceLanguage, data.TranslationStatus, old.SourceLanguage); ok {
+			if anchor, err = resolveTranslationAnchor(trans.StringValue(old.LanguageCode), lang, func(l string) (uint32, bool, error) {
+				return categoryTranslationRevision(ctx, r.entClient.Client(), trans.Uint32Value(old.CategoryID), l)
+			}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if updateMask != nil {
+		updateMask.Paths = utils.FilterBlacklist(updateMask.GetPaths(), translationTrackingFields)
+	}
+	data.Revision, data.SourceLanguage, data.SourceRevision, data.TranslationStatus = nil, nil, nil, nil
+
 	builder := r.entClient.Client().CategoryTranslation.UpdateOneID(id)
 	// 租户作用域：仅更新本租户翻译，避免跨租户改他人翻译（按 hasTenant 条件加）
 	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
@@ -200,6 +255,7 @@ func (r *CategoryTranslationRepo) UpdateTranslation(ctx context.Context, id uint
 				SetNillableThumbnail(dto.Thumbnail).
 				SetNillableCoverImage(dto.CoverImage).
 				SetNillableFullPath(dto.FullPath).
+				SetNillableIsDraft(dto.IsDraft).
 				SetUpdatedAt(time.Now())
 
 			// updated_by 强制由服务端 viewer context 推导，忽略客户端传入值
@@ -210,6 +266,15 @@ func (r *CategoryTranslationRepo) UpdateTranslation(ctx context.Context, id uint
 			if data.Seo != nil {
 				builder.SetSeo(data.Seo)
 			}
+
+			if anchor != nil {
+				builder.SetNillableTranslationStatus(r.translationStatusConverter.ToEntity(&anchor.status))
+				if anchor.language != nil {
+					builder.SetSourceLanguage(*anchor.language).SetSourceRevision(*anchor.revision)
+				} else {
+					builder.ClearSourceLanguage().ClearSourceRevision()
+				}
+			}
 		},
 		func(s *sql.Selector) {
 			s.Where(sql.EQ(categorytranslation.FieldID, id))
@@ -220,9 +285,80 @@ func (r *CategoryTranslationRepo) UpdateTranslation(ctx context.Context, id uint
 		return nil, contentV1.ErrorInternalServerError("update category translation failed")
 	}
 
+	// 可翻译字段有修改时递增修订号，依据此语言版本的译文随之过期
+	if old != nil && categoryTranslationChanged(old, dto) {
+		revision, bumpErr := bumpCategoryTranslationRevision(ctx, r.entClient.Client(), id)
+		if bumpErr != nil {
+			r.log.Errorf("bump category translation revision failed: %s", bumpErr.Error())
+			return nil, contentV1.ErrorInternalServerError("bump category translation revision failed")
+		}
+		dto.Revision = trans.Ptr(revision)
+	}
+
+	if old != nil {
+		r.redirectRepo.RecordTranslationPathChange(ctx, old.TenantID, contentV1.ContentType_CONTENT_TYPE_CATEGORY,
+			trans.Uint32Value(old.CategoryID), trans.StringValue(old.LanguageCode),
+			trans.StringValue(old.FullPath), dto.GetFullPath())
+	}
+
 	return dto, nil
 }
 
+// FullPaths 返回分类各语言版本的完整路径与所属租户，用于整体替换语言版本前后对比
+func (r *CategoryTranslationRepo) FullPaths(ctx context.Context, categoryID uint32) (map[string]string, *uint32, error) {
+	entities, err := r.entClient.Client().CategoryTranslation.Query().
+		Where(categorytranslation.CategoryIDEQ(categoryID)).
+		Select(categorytranslation.FieldTenantID, categorytranslation.FieldLanguageCode, categorytranslation.FieldFullPath).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query category translation paths failed: %s", err.Error())
+		return nil, nil, contentV1.ErrorInternalServerError("query category translation paths failed")
+	}
+
+	var tenantID *uint32
+	paths := make(map[string]string, len(entities))
+	for _, e := range entities {
+		tenantID = e.TenantID
+		paths[trans.StringValue(e.LanguageCode)] = trans.StringValue(e.FullPath)
+	}
+	return paths, tenantID, nil
+}
+
+// RecordReplacedPaths 整体替换语言版本后对比各语言的完整路径，有变化时记录自动重定向（best-effort）
+func (r *CategoryTranslationRepo) RecordReplacedPaths(ctx context.Context, categoryID uint32, before map[string]string) {
+	if len(before) == 0 {
+		return
+	}
+	after, tenantID, err := r.FullPaths(ctx, categoryID)
+	if err != nil {
+		return
+	}
+	r.redirectRepo.RecordReplacedPaths(ctx, tenantID, contentV1.ContentType_CONTENT_TYPE_CATEGORY, categoryID, before, after)
+}
+
+// anchorTranslation 设置新建语言版本的依据：指定源语言时锚定到其当前修订号，否则作为原文。
+// batch 为同批创建的语言版本，源语言在其中时修订号为初始值 1。
+func (r *CategoryTranslationRepo) anchorTranslation(ctx context.Context, client *ent.Client, data *contentV1.CategoryTranslation, batch []*contentV1.CategoryTranslation) error {
+	anchor, err := resolveTranslationAnchor(data.GetLanguageCode(), data.GetSourceLanguage(), func(lang string) (uint32, bool, error) {
+		for _, item := range batch {
+			if item != data && item.GetLanguageCode() == lang {
+				return 1, true, nil
+			}
+		}
+		return categoryTranslationRevision(ctx, client, data.GetCategoryId(), lang)
+	})
+	if err != nil {
+		return err
+	}
+
+	data.Revision = nil
+	data.TranslationStatus = trans.Ptr(anchor.status)
+	data.SourceLanguage = anchor.language
+	data.SourceRevision = anchor.revision
+
+	return nil
+}
+
 func (r *CategoryTranslationRepo) CountByBaseSlug(ctx context.Context, baseSlug string) (int64, error) {
 	c, err := r.entClient.Client().CategoryTranslation.Query().
 		Where(
@@ -253,11 +389,13 @@ func (r *CategoryTranslationRepo) TranslationExists(ctx context.Context, categor
 	return c > 0, nil
 }
 
-// ListAvailedLanguages lists the language codes of all translations available for the given category ID.
+// ListAvailedLanguages lists the language codes of all published (non-draft) translations available for the given category ID.
 func (r *CategoryTranslationRepo) ListAvailedLanguages(ctx context.Context, categoryId uint32) ([]string, error) {
 	entities, err := r.entClient.Client().CategoryTranslation.Query().
 		Where(
 			categorytranslation.CategoryIDEQ(categoryId),
+			// 草稿翻译待审阅，不对外提供
+			categorytranslation.Or(categorytranslation.IsDraftIsNil(), categorytranslation.IsDraftEQ(false)),
 		).
 		Select(categorytranslation.FieldLanguageCode).
 		Strings(ctx)
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	storageV1 "go-wind-cms/api/gen/go/storage/service/v1"
+
+	"go-wind-cms/pkg/oss"
+	"go-wind-cms/pkg/scanner"
+)
+
+// NewStorageOption 读取自定义配置 Storage，未配置时返回 nil（仅启用 MinIO）
+func NewStorageOption(ctx *bootstrap.Context) *storageV1.StorageOption {
+	var cfg *storageV1.StorageOptionWrapper
+	rawCfg, ok := ctx.GetCustomConfig("Storage")
+	if ok {
+		cfg = rawCfg.(*storageV1.StorageOptionWrapper)
+	}
+	if cfg == nil {
+		return nil
+	}
+	return cfg.Storage
+}
+
+// NewStorageRouter 创建存储驱动路由，MinIO 为默认驱动
+func NewStorageRouter(ctx *bootstrap.Context, cfg *storageV1.StorageOption) (*oss.StorageRouter, error) {
+	opts := &oss.RouterOptions{
+		DefaultProvider: cfg.GetDefaultProvider(),
+		TenantProviders: cfg.GetTenantProviders(),
+	}
+	if cfg.GetLocal() != nil {
+		opts.Local = &oss.LocalOptions{
+			Root:    cfg.GetLocal().GetRoot(),
+			BaseURL: cfg.GetLocal().GetBaseUrl(),
+			SignKey: cfg.GetLocal().GetSignKey(),
+		}
+	}
+	return oss.NewStorageRouter(ctx.GetConfig(), opts, ctx.GetLogger())
+}
+
+// NewUploadGuard 创建上传检查，导入作业下载的附件与后台上传一样按租户策略校验 MIME 与大小，配置 scanner 时扫描恶意内容
+func NewUploadGuard(ctx *bootstrap.Context, cfg *storageV1.StorageOption) *oss.UploadGuard {
+	opts := &oss.UploadGuardOptions{
+		DefaultPolicy:  toUploadPolicy(cfg.GetUploadPolicy()),
+		TenantPolicies: make(map[uint32]*oss.UploadPolicy, len(cfg.GetTenantUploadPolicies())),
+	}
+	for tenantID, p := range cfg.GetTenantUploadPolicies() {
+		opts.TenantPolicies[tenantID] = toUploadPolicy(p)
+	}
+
+	if sc := cfg.GetScanner(); sc != nil {
+		opts.FailOpen = sc.GetFailOpen()
+		opts.QuarantineBucket = sc.GetQuarantineBucket()
+
+		switch sc.GetDriver() {
+		case "clamd":
+			opts.Scanner = scanner.NewClamdScanner(
+				sc.GetNetwork(), sc.GetAddress(),
+				scanner.WithClamdTimeout(sc.GetTimeout().AsDuration()),
+			)
+		case "":
+		default:
+			ctx.NewLoggerHelper("upload-guard/data").Warnf("unknown upload scanner driver [%s], scanning disabled", sc.GetDriver())
+		}
+	}
+
+	return oss.NewUploadGuard(opts, ctx.GetLogger())
+}
+
+func toUploadPolicy(p *storageV1.StorageOption_UploadPolicy) *oss.UploadPolicy {
+	if p == nil {
+		return nil
+	}
+	return &oss.UploadPolicy{
+		AllowedMimeTypes: p.GetAllowedMimeTypes(),
+		MaxSize:          p.GetMaxSize(),
+	}
+}
@@ -0,0 +1,320 @@
+package data
+
+import (
+	"context"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/tx7do/go-utils/copierutil"
+	"github.com/tx7do/go-utils/mapper"
+
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	entCrud "github.com/tx7do/go-crud/entgo"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/commentauthorrule"
+	"go-wind-cms/app/core/service/internal/data/ent/predicate"
+	"go-wind-cms/app/core/service/internal/data/ent/user"
+
+	commentV1 "go-wind-cms/api/gen/go/comment/service/v1"
+)
+
+// CommentAuthorRuleRepo 评论作者封禁/信任规则
+type CommentAuthorRuleRepo struct {
+	entClient *entCrud.EntClient[*ent.Client]
+	log       *log.Helper
+
+	mapper *mapper.CopierMapper[commentV1.CommentAuthorRule, ent.CommentAuthorRule]
+
+	repository *entCrud.Repository[
+		ent.CommentAuthorRuleQuery, ent.CommentAuthorRuleSelect,
+		ent.CommentAuthorRuleCreate, ent.CommentAuthorRuleCreateBulk,
+		ent.CommentAuthorRuleUpdate, ent.CommentAuthorRuleUpdateOne,
+		ent.CommentAuthorRuleDelete,
+		predicate.CommentAuthorRule,
+		commentV1.CommentAuthorRule, ent.CommentAuthorRule,
+	]
+
+	typeConverter *mapper.EnumTypeConverter[commentV1.CommentAuthorRule_Type, commentauthorrule.Type]
+}
+
+func NewCommentAuthorRuleRepo(ctx *bootstrap.Context, entClient *entCrud.EntClient[*ent.Client]) *CommentAuthorRuleRepo {
+	repo := &CommentAuthorRuleRepo{
+		entClient: entClient,
+		log:       ctx.NewLoggerHelper("comment-author-rule/repo/core-service"),
+		mapper:    mapper.NewCopierMapper[commentV1.CommentAuthorRule, ent.CommentAuthorRule](),
+		typeConverter: mapper.NewEnumTypeConverter[commentV1.CommentAuthorRule_Type, commentauthorrule.Type](
+			commentV1.CommentAuthorRule_Type_name, commentV1.CommentAuthorRule_Type_value,
+		),
+	}
+
+	repo.init()
+
+	return repo
+}
+
+func (r *CommentAuthorRuleRepo) init() {
+	r.repository = entCrud.NewRepository[
+		ent.CommentAuthorRuleQuery, ent.CommentAuthorRuleSelect,
+		ent.CommentAuthorRuleCreate, ent.CommentAuthorRuleCreateBulk,
+		ent.CommentAuthorRuleUpdate, ent.CommentAuthorRuleUpdateOne,
+		ent.CommentAuthorRuleDelete,
+		predicate.CommentAuthorRule,
+		commentV1.CommentAuthorRule, ent.CommentAuthorRule,
+	](r.mapper)
+
+	r.mapper.AppendConverters(copierutil.NewTimeStringConverterPair())
+	r.mapper.AppendConverters(copierutil.NewTimeTimestamppbConverterPair())
+
+	r.mapper.AppendConverters(r.typeConverter.NewConverterPair())
+}
+
+// NormalizeAuthorRuleValue 规范化规则匹配值：邮箱忽略大小写，IP 与网段转为标准写法，其余去除首尾空白
+func NormalizeAuthorRuleValue(typ commentV1.CommentAuthorRule_Type, value string) string {
+	value = strings.TrimSpace(value)
+	switch typ {
+	case commentV1.CommentAuthorRule_TYPE_TRUST_EMAIL:
+		value = strings.ToLower(value)
+	case commentV1.CommentAuthorRule_TYPE_BAN_IP:
+		if prefix, err := netip.ParsePrefix(value); err == nil {
+			value = prefix.Masked().String()
+		} else if addr, err := netip.ParseAddr(value); err == nil {
+			value = addr.Unmap().String()
+		}
+	}
+	return value
+}
+
+// ipRuleMatches IP 规则是否命中客户端 IP，规则值可以是单个地址或 CIDR 网段
+func ipRuleMatches(value, ip string) bool {
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+
+	if strings.Contains(value, "/") {
+		prefix, err := netip.ParsePrefix(value)
+		return err == nil && prefix.Contains(addr)
+	}
+	ruleAddr, err := netip.ParseAddr(value)
+	return err == nil && ruleAddr.Unmap() == addr
+}
+
+// authorRuleSubject 参与规则匹配的评论作者。
+// Email 只取服务端按用户ID查得的用户记录邮箱，不使用评论提交时客户端填写的邮箱。
+type authorRuleSubject struct {
+	UserID uint32
+	IP     string
+	Email  string
+}
+
+// matchAuthorRules 从候选规则中找出命中的规则：过期规则忽略，封禁优先于信任
+func matchAuthorRules(rules []*ent.CommentAuthorRule, subject authorRuleSubject, now time.Time) (banned *ent.CommentAuthorRule, trusted bool) {
+	var uid string
+	if subject.UserID != 0 {
+		uid = strconv.FormatUint(uint64(subject.UserID), 10)
+	}
+
+	for _, rule := range rules {
+		if rule.Type == nil || rule.Value == nil {
+			continue
+		}
+		if rule.ExpiredAt != nil && !rule.ExpiredAt.After(now) {
+			continue
+		}
+
+		value := *rule.Value
+		switch *rule.Type {
+		case commentauthorrule.TypeTypeBanUser:
+			if uid != "" && value == uid {
+				return rule, false
+			}
+		case commentauthorrule.TypeTypeBanIP:
+			if ipRuleMatches(value, subject.IP) {
+				return rule, false
+			}
+		case commentauthorrule.TypeTypeTrustUser:
+			if uid != "" && value == uid {
+				trusted = true
+			}
+		case commentauthorrule.TypeTypeTrustEmail:
+			if uid != "" && subject.Email != "" && strings.EqualFold(value, subject.Email) {
+				trusted = true
+			}
+		}
+	}
+	return nil, trusted
+}
+
+func (r *CommentAuthorRuleRepo) List(ctx context.Context, req *paginationV1.PagingRequest) (*commentV1.ListCommentAuthorRuleResponse, error) {
+	if req == nil {
+		return nil, commentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	builder := r.entClient.Client().CommentAuthorRule.Query()
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(commentauthorrule.TenantIDEQ(tid))
+	}
+
+	ret, err := r.repository.ListWithPaging(ctx, builder, builder.Clone(), req)
+	if err != nil {
+		return nil, err
+	}
+	if ret == nil {
+		return &commentV1.ListCommentAuthorRuleResponse{Total: 0, Items: nil}, nil
+	}
+
+	return &commentV1.ListCommentAuthorRuleResponse{
+		Total: ret.Total,
+		Items: ret.Items,
+	}, nil
+}
+
+// Save 按 (租户, 类型, 匹配值) 新建或更新规则，重复封禁/信任时仅刷新原因与到期时间
+func (r *CommentAuthorRuleRepo) Save(ctx context.Context, tenantID uint32, typ commentV1.CommentAuthorRule_Type, value string, reason *string, expiredAt *time.Time, operatorID uint32) (*commentV1.CommentAuthorRule, error) {
+	value = NormalizeAuthorRuleValue(typ, value)
+	if value == "" {
+		return nil, commentV1.ErrorBadRequest("author rule value is required")
+	}
+	if typ == commentV1.CommentAuthorRule_TYPE_BAN_IP && !validIPRuleValue(value) {
+		return nil, commentV1.ErrorBadRequest("invalid ip address or cidr")
+	}
+	entType := r.typeConverter.ToEntity(&typ)
+
+	existing, err := r.entClient.Client().CommentAuthorRule.Query().
+		Where(
+			commentauthorrule.TenantIDEQ(tenantID),
+			commentauthorrule.TypeEQ(*entType),
+			commentauthorrule.ValueEQ(value),
+		).
+		Only(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		r.log.Errorf("query comment author rule failed: %s", err.Error())
+		return nil, commentV1.ErrorInternalServerError("query comment author rule failed")
+	}
+
+	var entity *ent.CommentAuthorRule
+	if existing != nil {
+		builder := r.entClient.Client().CommentAuthorRule.UpdateOne(existing).
+			SetNillableReason(reason).
+			SetUpdatedBy(operatorID).
+			SetUpdatedAt(time.Now())
+		if expiredAt != nil {
+			builder.SetExpiredAt(*expiredAt)
+		} else {
+			builder.ClearExpiredAt()
+		}
+		entity, err = builder.Save(ctx)
+	} else {
+		entity, err = r.entClient.Client().CommentAuthorRule.Create().
+			SetTenantID(tenantID).
+			SetNillableType(entType).
+			SetValue(value).
+			SetNillableReason(reason).
+			SetNillableExpiredAt(expiredAt).
+			SetCreatedBy(operatorID).
+			SetCreatedAt(time.Now()).
+			Save(ctx)
+	}
+	if err != nil {
+		r.log.Errorf("save comment author rule failed: %s", err.Error())
+		return nil, commentV1.ErrorInternalServerError("save comment author rule failed")
+	}
+
+	return r.mapper.ToDTO(entity), nil
+}
+
+func (r *CommentAuthorRuleRepo) Delete(ctx context.Context, id uint32) (*commentV1.CommentAuthorRule, error) {
+	builder := r.entClient.Client().CommentAuthorRule.Query().
+		Where(commentauthorrule.IDEQ(id))
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(commentauthorrule.TenantIDEQ(tid))
+	}
+
+	entity, err := builder.Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, commentV1.ErrorNotFound("comment author rule not found")
+		}
+		r.log.Errorf("query comment author rule failed: %s", err.Error())
+		return nil, commentV1.ErrorInternalServerError("query comment author rule failed")
+	}
+
+	if err = r.entClient.Client().CommentAuthorRule.DeleteOne(entity).Exec(ctx); err != nil {
+		r.log.Errorf("delete comment author rule failed: %s", err.Error())
+		return nil, commentV1.ErrorInternalServerError("delete comment author rule failed")
+	}
+
+	return r.mapper.ToDTO(entity), nil
+}
+
+// validIPRuleValue IP 规则值须为合法的 IP 地址或 CIDR 网段
+func validIPRuleValue(value string) bool {
+	if _, err := netip.ParsePrefix(value); err == nil {
+		return true
+	}
+	_, err := netip.ParseAddr(value)
+	return err == nil
+}
+
+// Match 匹配评论作者命中的规则。封禁优先于信任；查询失败时视为未命中，不阻塞评论提交。
+// 信任邮箱规则只对登录用户生效，邮箱取自用户记录，游客自填的邮箱不参与匹配。
+func (r *CommentAuthorRuleRepo) Match(ctx context.Context, tenantID, userID uint32, ip string) (banned *ent.CommentAuthorRule, trusted bool) {
+	subject := authorRuleSubject{UserID: userID, IP: strings.TrimSpace(ip)}
+
+	var conds []predicate.CommentAuthorRule
+	if userID != 0 {
+		uid := strconv.FormatUint(uint64(userID), 10)
+		conds = append(conds,
+			commentauthorrule.And(commentauthorrule.TypeEQ(commentauthorrule.TypeTypeBanUser), commentauthorrule.ValueEQ(uid)),
+			commentauthorrule.And(commentauthorrule.TypeEQ(commentauthorrule.TypeTypeTrustUser), commentauthorrule.ValueEQ(uid)),
+		)
+
+		u, err := r.entClient.Client().User.Query().
+			Where(user.IDEQ(userID)).
+			Select(user.FieldEmail).
+			Only(ctx)
+		if err != nil && !ent.IsNotFound(err) {
+			r.log.Errorf("query comment author email failed: %s", err.Error())
+		}
+		if u != nil && u.Email != nil {
+			subject.Email = strings.ToLower(strings.TrimSpace(*u.Email))
+		}
+		if subject.Email != "" {
+			conds = append(conds,
+				commentauthorrule.And(commentauthorrule.TypeEQ(commentauthorrule.TypeTypeTrustEmail), commentauthorrule.ValueEQ(subject.Email)),
+			)
+		}
+	}
+	if subject.IP != "" {
+		// IP 规则可能是网段，取出租户全部 IP 规则后逐条匹配
+		conds = append(conds, commentauthorrule.TypeEQ(commentauthorrule.TypeTypeBanIP))
+	}
+	if len(conds) == 0 {
+		return nil, false
+	}
+
+	now := time.Now()
+	rules, err := r.entClient.Client().CommentAuthorRule.Query().
+		Where(
+			commentauthorrule.TenantIDEQ(tenantID),
+			commentauthorrule.Or(conds...),
+			commentauthorrule.Or(
+				commentauthorrule.ExpiredAtIsNil(),
+				commentauthorrule.ExpiredAtGT(now),
+			),
+		).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("match comment author rules failed: %s", err.Error())
+		return nil, false
+	}
+
+	return matchAuthorRules(rules, subject, now)
+}
@@ -0,0 +1,112 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tx7do/go-utils/trans"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/commentauthorrule"
+
+	commentV1 "go-wind-cms/api/gen/go/comment/service/v1"
+)
+
+func TestNormalizeAuthorRuleValue(t *testing.T) {
+	tests := []struct {
+		typ   commentV1.CommentAuthorRule_Type
+		value string
+		want  string
+	}{
+		{commentV1.CommentAuthorRule_TYPE_TRUST_EMAIL, " Alice@Example.COM ", "alice@example.com"},
+		{commentV1.CommentAuthorRule_TYPE_BAN_IP, " 10.1.2.3 ", "10.1.2.3"},
+		{commentV1.CommentAuthorRule_TYPE_BAN_IP, "::ffff:10.1.2.3", "10.1.2.3"},
+		{commentV1.CommentAuthorRule_TYPE_BAN_IP, "10.1.2.3/16", "10.1.0.0/16"},
+		{commentV1.CommentAuthorRule_TYPE_BAN_IP, "2001:DB8::1/32", "2001:db8::/32"},
+		{commentV1.CommentAuthorRule_TYPE_BAN_IP, "not an ip", "not an ip"},
+		{commentV1.CommentAuthorRule_TYPE_BAN_USER, " 42 ", "42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typ.String()+"/"+tt.value, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeAuthorRuleValue(tt.typ, tt.value))
+		})
+	}
+
+	assert.True(t, validIPRuleValue("10.0.0.0/8"))
+	assert.True(t, validIPRuleValue("::1"))
+	assert.False(t, validIPRuleValue("not an ip"))
+	assert.False(t, validIPRuleValue("10.0.0.0/33"))
+}
+
+func TestIPRuleMatches(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		ip    string
+		want  bool
+	}{
+		{name: "same address", value: "10.0.0.1", ip: "10.0.0.1", want: true},
+		{name: "other address", value: "10.0.0.1", ip: "10.0.0.2"},
+		{name: "mapped ipv4", value: "10.0.0.1", ip: "::ffff:10.0.0.1", want: true},
+		{name: "inside cidr", value: "10.0.0.0/8", ip: "10.200.3.4", want: true},
+		{name: "outside cidr", value: "10.0.0.0/8", ip: "11.0.0.1"},
+		{name: "ipv6 cidr", value: "2001:db8::/32", ip: "2001:db8:1::5", want: true},
+		{name: "family mismatch", value: "10.0.0.0/8", ip: "2001:db8::1"},
+		{name: "invalid client ip", value: "10.0.0.0/8", ip: "unknown"},
+		{name: "empty client ip", value: "10.0.0.1", ip: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ipRuleMatches(tt.value, tt.ip))
+		})
+	}
+}
+
+func TestMatchAuthorRules(t *testing.T) {
+	now := time.Now()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	rule := func(id uint32, typ commentauthorrule.Type, value string, expiredAt *time.Time) *ent.CommentAuthorRule {
+		return &ent.CommentAuthorRule{ID: id, Type: &typ, Value: trans.Ptr(value), ExpiredAt: expiredAt}
+	}
+
+	var (
+		banUser    = rule(1, commentauthorrule.TypeTypeBanUser, "7", nil)
+		banIP      = rule(2, commentauthorrule.TypeTypeBanIP, "10.0.0.9", nil)
+		banCIDR    = rule(3, commentauthorrule.TypeTypeBanIP, "192.168.0.0/16", &future)
+		trustUser  = rule(4, commentauthorrule.TypeTypeTrustUser, "8", nil)
+		trustEmail = rule(5, commentauthorrule.TypeTypeTrustEmail, "alice@example.com", nil)
+		expiredBan = rule(6, commentauthorrule.TypeTypeBanUser, "8", &past)
+		expiredIP  = rule(7, commentauthorrule.TypeTypeBanIP, "172.16.0.0/12", &now)
+	)
+	rules := []*ent.CommentAuthorRule{trustUser, trustEmail, expiredBan, expiredIP, banUser, banIP, banCIDR}
+
+	tests := []struct {
+		name        string
+		subject     authorRuleSubject
+		wantBanned  *ent.CommentAuthorRule
+		wantTrusted bool
+	}{
+		{name: "no match", subject: authorRuleSubject{UserID: 9, IP: "1.1.1.1"}},
+		{name: "banned user", subject: authorRuleSubject{UserID: 7, IP: "1.1.1.1"}, wantBanned: banUser},
+		{name: "banned ip", subject: authorRuleSubject{IP: "10.0.0.9"}, wantBanned: banIP},
+		{name: "banned cidr", subject: authorRuleSubject{IP: "192.168.3.4"}, wantBanned: banCIDR},
+		{name: "trusted user", subject: authorRuleSubject{UserID: 8, IP: "1.1.1.1"}, wantTrusted: true},
+		{name: "trusted user email", subject: authorRuleSubject{UserID: 9, Email: "Alice@Example.com"}, wantTrusted: true},
+		{name: "email without user", subject: authorRuleSubject{Email: "alice@example.com"}},
+		{name: "ban user beats trusted email", subject: authorRuleSubject{UserID: 7, Email: "alice@example.com"}, wantBanned: banUser},
+		{name: "ban ip beats trusted user", subject: authorRuleSubject{UserID: 8, IP: "192.168.0.1"}, wantBanned: banCIDR},
+		{name: "expired ban ignored", subject: authorRuleSubject{UserID: 8, IP: "172.16.5.5"}, wantTrusted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			banned, trusted := matchAuthorRules(rules, tt.subject, now)
+			assert.Equal(t, tt.wantBanned, banned)
+			assert.Equal(t, tt.wantTrusted, trusted)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	commentV1 "go-wind-cms/api/gen/go/comment/service/v1"
+
+	"go-wind-cms/pkg/moderation"
+)
+
+const (
+	// ModerationCounterKeyFormat 评论审核计数键格式 cm:cnt:{key}，用于限流与重复检测
+	ModerationCounterKeyFormat = ProjectPrefix + "cm:cnt:%s"
+
+	// ModerationTokenKeyFormat 朴素贝叶斯词频键格式 cm:nb:{tid}:{class}
+	ModerationTokenKeyFormat = ProjectPrefix + "cm:nb:%d:%s"
+	// ModerationMetaKeyFormat 朴素贝叶斯汇总键格式 cm:nb:{tid}:meta
+	ModerationMetaKeyFormat = ProjectPrefix + "cm:nb:%d:meta"
+)
+
+// NewModerationOption 读取自定义配置 Moderation，未配置时返回 nil（不做自动审核）
+func NewModerationOption(ctx *bootstrap.Context) *commentV1.ModerationOption {
+	var cfg *commentV1.ModerationOptionWrapper
+	rawCfg, ok := ctx.GetCustomConfig("Moderation")
+	if ok {
+		cfg = rawCfg.(*commentV1.ModerationOptionWrapper)
+	}
+	if cfg == nil {
+		return nil
+	}
+	return cfg.Moderation
+}
+
+// NewCommentModerator 创建评论审核链，限流、重复检测与分类器模型均存于 Redis，多实例共享
+func NewCommentModerator(ctx *bootstrap.Context, rdb *redis.Client, cfg *commentV1.ModerationOption) (*moderation.Moderator, error) {
+	opts := &moderation.Options{
+		DefaultPolicy:  toModerationPolicy(cfg.GetDefaultPolicy()),
+		TenantPolicies: make(map[uint32]*moderation.Policy, len(cfg.GetTenantPolicies())),
+		Store:          &redisCounterStore{rdb: rdb},
+	}
+	for tenantID, p := range cfg.GetTenantPolicies() {
+		opts.TenantPolicies[tenantID] = toModerationPolicy(p)
+	}
+
+	switch cfg.GetClassifier().GetDriver() {
+	case "naive_bayes":
+		opts.Classifier = moderation.NewNaiveBayes(&redisTokenStore{rdb: rdb}, cfg.GetClassifier().GetMinSamples())
+	case "":
+	default:
+		ctx.NewLoggerHelper("comment-moderator/data/core-service").
+			Warnf("unknown spam classifier [%s], classifier disabled", cfg.GetClassifier().GetDriver())
+	}
+
+	return moderation.NewModerator(opts, ctx.GetLogger())
+}
+
+func toModerationPolicy(p *commentV1.ModerationOption_Policy) *moderation.Policy {
+	if p == nil {
+		return nil
+	}
+
+	verdict := moderation.VerdictSpam
+	if p.GetBlocklistStatus() == commentV1.Comment_STATUS_REJECTED {
+		verdict = moderation.VerdictReject
+	}
+
+	return &moderation.Policy{
+		Enabled:          p.GetEnabled(),
+		RequireApproval:  p.GetRequireApproval(),
+		Keywords:         p.GetBlockedKeywords(),
+		Patterns:         p.GetBlockedPatterns(),
+		BlocklistVerdict: verdict,
+		MaxLinks:         int(p.GetMaxLinks()),
+		DuplicateWindow:  p.GetDuplicateWindow().AsDuration(),
+		IPRateLimit: moderation.RateLimit{
+			Limit:  int(p.GetIpRateLimit().GetLimit()),
+			Window: p.GetIpRateLimit().GetWindow().AsDuration(),
+		},
+		UserRateLimit: moderation.RateLimit{
+			Limit:  int(p.GetUserRateLimit().GetLimit()),
+			Window: p.GetUserRateLimit().GetWindow().AsDuration(),
+		},
+		UseClassifier:    p.GetUseClassifier(),
+		SpamThreshold:    p.GetSpamThreshold(),
+		PendingThreshold: p.GetPendingThreshold(),
+	}
+}
+
+// redisCounterStore 基于 Redis INCR 的计数存储
+type redisCounterStore struct {
+	rdb *redis.Client
+}
+
+func (s *redisCounterStore) Incr(ctx context.Context, key string, window time.Duration) (int64, error) {
+	key = fmt.Sprintf(ModerationCounterKeyFormat, key)
+
+	var incr *redis.IntCmd
+	if _, err := s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.ExpireNX(ctx, key, window)
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+const (
+	bayesClassSpam = "spam"
+	bayesClassHam  = "ham"
+
+	bayesFieldDocs   = "_docs"
+	bayesFieldTokens = "_tokens"
+)
+
+// redisTokenStore 基于 Redis Hash 的朴素贝叶斯词频存储，按租户与类别分键
+type redisTokenStore struct {
+	rdb *redis.Client
+}
+
+func (s *redisTokenStore) Add(ctx context.Context, tenantID uint32, spam bool, tokens []string) error {
+	class := bayesClassHam
+	if spam {
+		class = bayesClassSpam
+	}
+	tokenKey := fmt.Sprintf(ModerationTokenKeyFormat, tenantID, class)
+	metaKey := fmt.Sprintf(ModerationMetaKeyFormat, tenantID)
+
+	_, err := s.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, t := range tokens {
+			pipe.HIncrBy(ctx, tokenKey, t, 1)
+		}
+		pipe.HIncrBy(ctx, metaKey, class+bayesFieldDocs, 1)
+		pipe.HIncrBy(ctx, metaKey, class+bayesFieldTokens, int64(len(tokens)))
+		return nil
+	})
+	return err
+}
+
+func (s *redisTokenStore) Stats(ctx context.Context, tenantID uint32, tokens []string) (*moderation.TokenStats, error) {
+	spamKey := fmt.Sprintf(ModerationTokenKeyFormat, tenantID, bayesClassSpam)
+	hamKey := fmt.Sprintf(ModerationTokenKeyFormat, tenantID, bayesClassHam)
+	metaKey := fmt.Sprintf(ModerationMetaKeyFormat, tenantID)
+
+	var spamCmd, hamCmd *redis.SliceCmd
+	var metaCmd *redis.MapStringStringCmd
+	var spamLen, hamLen *redis.IntCmd
+	if _, err := s.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		spamCmd = pipe.HMGet(ctx, spamKey, tokens...)
+		hamCmd = pipe.HMGet(ctx, hamKey, tokens...)
+		metaCmd = pipe.HGetAll(ctx, metaKey)
+		spamLen = pipe.HLen(ctx, spamKey)
+		hamLen = pipe.HLen(ctx, hamKey)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	meta := metaCmd.Val()
+	stats := &moderation.TokenStats{
+		SpamDocs:   parseRedisInt(meta[bayesClassSpam+bayesFieldDocs]),
+		HamDocs:    parseRedisInt(meta[bayesClassHam+bayesFieldDocs]),
+		SpamTokens: parseRedisInt(meta[bayesClassSpam+bayesFieldTokens]),
+		HamTokens:  parseRedisInt(meta[bayesClassHam+bayesFieldTokens]),
+		// 两个类别共有的词会被重复计数，词表大小偏大对平滑影响可忽略
+		Vocabulary: spamLen.Val() + hamLen.Val(),
+		Spam:       make(map[string]int64, len(tokens)),
+		Ham:        make(map[string]int64, len(tokens)),
+	}
+	for i, t := range tokens {
+		if v, ok := spamCmd.Val()[i].(string); ok {
+			stats.Spam[t] = parseRedisInt(v)
+		}
+		if v, ok := hamCmd.Val()[i].(string); ok {
+			stats.Ham[t] = parseRedisInt(v)
+		}
+	}
+	return stats, nil
+}
+
+func parseRedisInt(v string) int64 {
+	n, _ := strconv.ParseInt(v, 10, 64)
+	return n
+}
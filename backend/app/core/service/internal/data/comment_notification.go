@@ -0,0 +1,292 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+	"github.com/tx7do/go-utils/trans"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	entCrud "github.com/tx7do/go-crud/entgo"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/comment"
+	"go-wind-cms/app/core/service/internal/data/ent/post"
+	"go-wind-cms/app/core/service/internal/data/ent/posttranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/user"
+
+	commentV1 "go-wind-cms/api/gen/go/comment/service/v1"
+
+	"go-wind-cms/pkg/notification"
+)
+
+const (
+	// CommentNotifiedKeyFormat 评论已通知标记键格式 cm:notified:{id}，保证同一评论只通知一次
+	CommentNotifiedKeyFormat = ProjectPrefix + "cm:notified:%d"
+
+	commentNotifiedTTL = 7 * 24 * time.Hour
+)
+
+// NewNotificationOption 读取自定义配置 Notification，未配置时返回 nil（不发送评论通知）
+func NewNotificationOption(ctx *bootstrap.Context) *commentV1.NotificationOption {
+	var cfg *commentV1.NotificationOptionWrapper
+	rawCfg, ok := ctx.GetCustomConfig("Notification")
+	if ok {
+		cfg = rawCfg.(*commentV1.NotificationOptionWrapper)
+	}
+	if cfg == nil {
+		return nil
+	}
+	return cfg.Notification
+}
+
+// NewMailer 创建邮件发送器，未启用邮件通道或配置无效时返回 NopMailer
+func NewMailer(ctx *bootstrap.Context, cfg *commentV1.NotificationOption) notification.Mailer {
+	emailCfg := cfg.GetEmail()
+	if !cfg.GetEnabled() || !emailCfg.GetEnabled() {
+		return notification.NopMailer{}
+	}
+
+	mailer, err := notification.NewSMTPMailer(notification.SMTPConfig{
+		Host:        emailCfg.GetHost(),
+		Port:        int(emailCfg.GetPort()),
+		Username:    emailCfg.GetUsername(),
+		Password:    emailCfg.GetPassword(),
+		From:        emailCfg.GetFrom(),
+		ImplicitTLS: emailCfg.GetImplicitTls(),
+		Timeout:     emailCfg.GetTimeout().AsDuration(),
+	})
+	if err != nil {
+		ctx.NewLoggerHelper("mailer/data/core-service").
+			Warnf("invalid smtp config, email notification disabled: %s", err.Error())
+		return notification.NopMailer{}
+	}
+	return mailer
+}
+
+// NewUnsubscribeTokenSigner 创建退订令牌签发器，未配置密钥时返回 nil（此时不发送邮件通知）
+func NewUnsubscribeTokenSigner(ctx *bootstrap.Context, cfg *commentV1.NotificationOption) *notification.TokenSigner {
+	if cfg.GetTokenSecret() == "" {
+		return nil
+	}
+
+	signer, err := notification.NewTokenSigner(cfg.GetTokenSecret())
+	if err != nil {
+		ctx.NewLoggerHelper("mailer/data/core-service").
+			Warnf("invalid unsubscribe token secret, email notification disabled: %s", err.Error())
+		return nil
+	}
+	return signer
+}
+
+// CommentNotifyRecipient 通知接收人
+type CommentNotifyRecipient struct {
+	UserID uint32
+	Reason commentV1.NotificationReason
+}
+
+// CommentNotifyTarget 一条评论需要通知的内容与接收人
+type CommentNotifyTarget struct {
+	Comment    *ent.Comment
+	PostID     uint32
+	PostTitle  string
+	Recipients []CommentNotifyRecipient
+}
+
+// CommentNotificationRepo 评论通知的接收人解析、去重标记与站内推送
+type CommentNotificationRepo struct {
+	entClient *entCrud.EntClient[*ent.Client]
+	rdb       *redis.Client
+	log       *log.Helper
+
+	interactionRepo *InteractionRepo
+
+	pushChannel string
+}
+
+func NewCommentNotificationRepo(
+	ctx *bootstrap.Context,
+	entClient *entCrud.EntClient[*ent.Client],
+	rdb *redis.Client,
+	interactionRepo *InteractionRepo,
+	cfg *commentV1.NotificationOption,
+) *CommentNotificationRepo {
+	pushChannel := cfg.GetPushChannel()
+	if pushChannel == "" {
+		pushChannel = notification.DefaultPushChannel
+	}
+
+	return &CommentNotificationRepo{
+		entClient:       entClient,
+		rdb:             rdb,
+		log:             ctx.NewLoggerHelper("comment-notification/repo/core-service"),
+		interactionRepo: interactionRepo,
+		pushChannel:     pushChannel,
+	}
+}
+
+// Resolve 解析评论的通知接收人：被回复评论的作者、文章作者与收藏该文章的用户。
+// 仅处理已通过审核的文章评论；评论者本人不会收到通知。
+func (r *CommentNotificationRepo) Resolve(ctx context.Context, commentID uint32) (*CommentNotifyTarget, error) {
+	client := r.entClient.Client()
+
+	entity, err := client.Comment.Get(ctx, commentID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		r.log.Errorf("query comment failed: %s", err.Error())
+		return nil, commentV1.ErrorInternalServerError("query comment failed")
+	}
+
+	if entity.Status == nil || *entity.Status != comment.StatusStatusApproved ||
+		entity.ContentType == nil || *entity.ContentType != comment.ContentTypeContentTypePost ||
+		entity.ObjectID == nil {
+		return nil, nil
+	}
+
+	tenantID := trans.Uint32Value(entity.TenantID)
+	postID := *entity.ObjectID
+
+	target := &CommentNotifyTarget{
+		Comment: entity,
+		PostID:  postID,
+	}
+
+	var replyAuthorID uint32
+	parentID := trans.Uint32Value(entity.ReplyToID)
+	if parentID == 0 {
+		parentID = trans.Uint32Value(entity.ParentID)
+	}
+	if parentID != 0 {
+		parent, err := client.Comment.Query().
+			Where(comment.IDEQ(parentID), comment.TenantIDEQ(tenantID)).
+			Only(ctx)
+		if err != nil && !ent.IsNotFound(err) {
+			r.log.Errorf("query parent comment failed: %s", err.Error())
+			return nil, commentV1.ErrorInternalServerError("query parent comment failed")
+		}
+		if parent != nil {
+			replyAuthorID = commentAuthorUserID(parent)
+		}
+	}
+
+	var postAuthorID uint32
+	p, err := client.Post.Query().
+		Where(post.IDEQ(postID), post.TenantIDEQ(tenantID)).
+		Select(post.FieldID, post.FieldAuthorID).
+		Only(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		r.log.Errorf("query post failed: %s", err.Error())
+		return nil, commentV1.ErrorInternalServerError("query post failed")
+	}
+	if p != nil {
+		postAuthorID = trans.Uint32Value(p.AuthorID)
+	}
+
+	translation, err := client.PostTranslation.Query().
+		Where(posttranslation.PostIDEQ(postID), posttranslation.TitleNotNil()).
+		Order(ent.Asc(posttranslation.FieldID)).
+		First(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		r.log.Errorf("query post translation failed: %s", err.Error())
+		return nil, commentV1.ErrorInternalServerError("query post translation failed")
+	}
+	if translation != nil {
+		target.PostTitle = trans.StringValue(translation.Title)
+	}
+
+	watcherIDs, err := r.interactionRepo.ListPostWatcherIDs(ctx, tenantID, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	target.Recipients = collectNotifyRecipients(commentAuthorUserID(entity), replyAuthorID, postAuthorID, watcherIDs)
+
+	return target, nil
+}
+
+// commentAuthorUserID 评论作者的用户ID，游客评论返回 0
+func commentAuthorUserID(c *ent.Comment) uint32 {
+	if uid := trans.Uint32Value(c.AuthorID); uid != 0 {
+		return uid
+	}
+	return trans.Uint32Value(c.CreatedBy)
+}
+
+// collectNotifyRecipients 合并接收人并去重，同一用户只保留优先级最高的原因（回复 > 文章作者 > 收藏）
+func collectNotifyRecipients(commenterID, replyAuthorID, postAuthorID uint32, watcherIDs []uint32) []CommentNotifyRecipient {
+	seen := make(map[uint32]struct{}, len(watcherIDs)+2)
+	seen[0] = struct{}{}
+	if commenterID != 0 {
+		seen[commenterID] = struct{}{}
+	}
+
+	var recipients []CommentNotifyRecipient
+	add := func(uid uint32, reason commentV1.NotificationReason) {
+		if _, ok := seen[uid]; ok {
+			return
+		}
+		seen[uid] = struct{}{}
+		recipients = append(recipients, CommentNotifyRecipient{UserID: uid, Reason: reason})
+	}
+
+	add(replyAuthorID, commentV1.NotificationReason_NOTIFICATION_REASON_REPLY)
+	add(postAuthorID, commentV1.NotificationReason_NOTIFICATION_REASON_POST_COMMENT)
+	for _, uid := range watcherIDs {
+		add(uid, commentV1.NotificationReason_NOTIFICATION_REASON_WATCHED_POST)
+	}
+
+	return recipients
+}
+
+// ListUserContacts 批量查询接收人的昵称与邮箱
+func (r *CommentNotificationRepo) ListUserContacts(ctx context.Context, tenantID uint32, userIDs []uint32) (map[uint32]*ent.User, error) {
+	res := make(map[uint32]*ent.User, len(userIDs))
+	if len(userIDs) == 0 {
+		return res, nil
+	}
+
+	users, err := r.entClient.Client().User.Query().
+		Where(user.IDIn(userIDs...), user.TenantIDEQ(tenantID)).
+		Select(user.FieldID, user.FieldUsername, user.FieldNickname, user.FieldEmail).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query users failed: %s", err.Error())
+		return nil, commentV1.ErrorInternalServerError("query users failed")
+	}
+
+	for _, u := range users {
+		res[u.ID] = u
+	}
+	return res, nil
+}
+
+// MarkNotified 标记评论已通知，返回 false 表示已被标记过（重复的任务或重复的审核通过）
+func (r *CommentNotificationRepo) MarkNotified(ctx context.Context, commentID uint32) (bool, error) {
+	ok, err := r.rdb.SetNX(ctx, fmt.Sprintf(CommentNotifiedKeyFormat, commentID), 1, commentNotifiedTTL).Result()
+	if err != nil {
+		r.log.Errorf("mark comment [%d] notified failed: %s", commentID, err.Error())
+		return false, commentV1.ErrorInternalServerError("mark comment notified failed")
+	}
+	return ok, nil
+}
+
+// UnmarkNotified 清除已通知标记，用于投递失败后允许任务重试
+func (r *CommentNotificationRepo) UnmarkNotified(ctx context.Context, commentID uint32) {
+	if err := r.rdb.Del(ctx, fmt.Sprintf(CommentNotifiedKeyFormat, commentID)).Err(); err != nil {
+		r.log.Warnf("unmark comment [%d] notified failed: %s", commentID, err.Error())
+	}
+}
+
+// Push 发布站内推送事件，由订阅该频道的服务经 SSE 推送给在线用户
+func (r *CommentNotificationRepo) Push(ctx context.Context, event *notification.PushEvent) error {
+	payload, err := event.Marshal()
+	if err != nil {
+		return err
+	}
+	return r.rdb.Publish(ctx, r.pushChannel, payload).Err()
+}
@@ -0,0 +1,63 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	commentV1 "go-wind-cms/api/gen/go/comment/service/v1"
+)
+
+func TestCollectNotifyRecipients(t *testing.T) {
+	const (
+		reply   = commentV1.NotificationReason_NOTIFICATION_REASON_REPLY
+		author  = commentV1.NotificationReason_NOTIFICATION_REASON_POST_COMMENT
+		watched = commentV1.NotificationReason_NOTIFICATION_REASON_WATCHED_POST
+	)
+
+	tests := []struct {
+		name                               string
+		commenter, replyAuthor, postAuthor uint32
+		watchers                           []uint32
+		want                               []CommentNotifyRecipient
+	}{
+		{
+			name:        "all reasons",
+			commenter:   1,
+			replyAuthor: 2,
+			postAuthor:  3,
+			watchers:    []uint32{4, 5},
+			want: []CommentNotifyRecipient{
+				{UserID: 2, Reason: reply},
+				{UserID: 3, Reason: author},
+				{UserID: 4, Reason: watched},
+				{UserID: 5, Reason: watched},
+			},
+		},
+		{
+			name:        "commenter excluded",
+			commenter:   3,
+			replyAuthor: 3,
+			postAuthor:  3,
+			watchers:    []uint32{3},
+			want:        nil,
+		},
+		{
+			name:        "highest priority reason kept",
+			commenter:   0,
+			replyAuthor: 2,
+			postAuthor:  2,
+			watchers:    []uint32{2, 0, 6, 6},
+			want: []CommentNotifyRecipient{
+				{UserID: 2, Reason: reply},
+				{UserID: 6, Reason: watched},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := collectNotifyRecipients(tt.commenter, tt.replyAuthor, tt.postAuthor, tt.watchers)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -2,6 +2,8 @@ package data
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"time"
 
 	"entgo.io/ent/dialect/sql"
@@ -9,6 +11,7 @@ import (
 
 	"github.com/tx7do/go-utils/copierutil"
 	"github.com/tx7do/go-utils/mapper"
+	"github.com/tx7do/go-utils/trans"
 
 	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
 	entCrud "github.com/tx7do/go-crud/entgo"
@@ -17,8 +20,11 @@ import (
 	"go-wind-cms/app/core/service/internal/data/ent"
 	"go-wind-cms/app/core/service/internal/data/ent/comment"
 	"go-wind-cms/app/core/service/internal/data/ent/predicate"
+	"go-wind-cms/app/core/service/internal/data/ent/user"
 
 	commentV1 "go-wind-cms/api/gen/go/comment/service/v1"
+
+	"go-wind-cms/pkg/moderation"
 )
 
 type CommentRepo struct {
@@ -39,13 +45,23 @@ type CommentRepo struct {
 	statusConverter      *mapper.EnumTypeConverter[commentV1.Comment_Status, comment.Status]
 	contentTypeConverter *mapper.EnumTypeConverter[commentV1.Comment_ContentType, comment.ContentType]
 	authorTypeConverter  *mapper.EnumTypeConverter[commentV1.Comment_AuthorType, comment.AuthorType]
+
+	moderator      *moderation.Moderator
+	authorRuleRepo *CommentAuthorRuleRepo
 }
 
-func NewCommentRepo(ctx *bootstrap.Context, entClient *entCrud.EntClient[*ent.Client]) *CommentRepo {
+func NewCommentRepo(
+	ctx *bootstrap.Context,
+	entClient *entCrud.EntClient[*ent.Client],
+	moderator *moderation.Moderator,
+	authorRuleRepo *CommentAuthorRuleRepo,
+) *CommentRepo {
 	repo := &CommentRepo{
-		entClient: entClient,
-		log:       ctx.NewLoggerHelper("comment/repo/core-service"),
-		mapper:    mapper.NewCopierMapper[commentV1.Comment, ent.Comment](),
+		entClient:      entClient,
+		moderator:      moderator,
+		authorRuleRepo: authorRuleRepo,
+		log:            ctx.NewLoggerHelper("comment/repo/core-service"),
+		mapper:         mapper.NewCopierMapper[commentV1.Comment, ent.Comment](),
 		statusConverter: mapper.NewEnumTypeConverter[commentV1.Comment_Status, comment.Status](
 			commentV1.Comment_Status_name, commentV1.Comment_Status_value,
 		),
@@ -183,11 +199,122 @@ func (r *CommentRepo) Get(ctx context.Context, req *commentV1.GetCommentRequest)
 	return r.mapper.ToDTO(entity), nil
 }
 
+// moderationInput 由评论构造审核输入
+func moderationInput(tenantID uint32, c *commentV1.Comment) *moderation.Input {
+	userID := c.GetCreatedBy()
+	if userID == 0 {
+		userID = c.GetAuthorId()
+	}
+	return &moderation.Input{
+		TenantID:    tenantID,
+		UserID:      userID,
+		IP:          c.GetIpAddress(),
+		UserAgent:   c.GetUserAgent(),
+		ObjectID:    c.GetObjectId(),
+		Content:     c.GetContent(),
+		AuthorName:  c.GetAuthorName(),
+		AuthorEmail: c.GetAuthorEmail(),
+		AuthorURL:   c.GetAuthorUrl(),
+	}
+}
+
+// moderate 执行自动审核并设置评论的初始状态。
+// 调用方已指定状态时（如管理端代发评论）不做审核；app 端提交的评论由 BFF 清空状态，必然经过审核。
+// 作者规则先于审核链：封禁作者拒绝保存，信任作者只做限流检查后直接通过。
+func (r *CommentRepo) moderate(ctx context.Context, data *commentV1.Comment) error {
+	if data.GetStatus() != commentV1.Comment_STATUS_UNSPECIFIED {
+		return nil
+	}
+
+	tid, _ := maybeTenantFromViewer(ctx)
+	in := moderationInput(tid, data)
+
+	if r.authorRuleRepo != nil {
+		banned, trusted := r.authorRuleRepo.Match(ctx, tid, in.UserID, in.IP)
+		if banned != nil {
+			r.log.Infof("comment rejected by author rule [%d]: tenant [%d] user [%d] ip [%s]", banned.ID, tid, in.UserID, in.IP)
+			return commentV1.ErrorForbidden("you are not allowed to comment")
+		}
+		if trusted {
+			// 信任作者跳过内容审核，但仍受限流约束
+			if r.moderator != nil {
+				if d := r.moderator.RateLimit(ctx, in); d != nil {
+					r.log.Infof("comment moderated: tenant [%d] ip [%s] verdict [%s] rule [%s] reason [%s]",
+						tid, data.GetIpAddress(), d.Verdict, d.Rule, d.Reason)
+					return commentV1.ErrorTooManyRequests("too many comments, please try again later")
+				}
+			}
+			data.Status = trans.Ptr(commentV1.Comment_STATUS_APPROVED)
+			data.IsSpam = trans.Ptr(false)
+			return nil
+		}
+	}
+
+	if r.moderator == nil || !r.moderator.Enabled(tid) {
+		return nil
+	}
+
+	decision := r.moderator.Moderate(ctx, in)
+	if decision.Score >= 0 {
+		data.SpamScore = trans.Ptr(decision.Score)
+	}
+	if decision.Verdict != moderation.VerdictApprove {
+		r.log.Infof("comment moderated: tenant [%d] ip [%s] verdict [%s] rule [%s] reason [%s] score [%.2f]",
+			tid, data.GetIpAddress(), decision.Verdict, decision.Rule, decision.Reason, decision.Score)
+	}
+
+	switch decision.Verdict {
+	case moderation.VerdictBlock:
+		return commentV1.ErrorTooManyRequests("too many comments, please try again later")
+	case moderation.VerdictPending:
+		data.Status = trans.Ptr(commentV1.Comment_STATUS_PENDING)
+		data.IsSpam = trans.Ptr(false)
+	case moderation.VerdictReject:
+		data.Status = trans.Ptr(commentV1.Comment_STATUS_REJECTED)
+		data.IsSpam = trans.Ptr(false)
+	case moderation.VerdictSpam:
+		data.Status = trans.Ptr(commentV1.Comment_STATUS_SPAM)
+		data.IsSpam = trans.Ptr(true)
+	default:
+		data.Status = trans.Ptr(commentV1.Comment_STATUS_APPROVED)
+		data.IsSpam = trans.Ptr(false)
+	}
+	return nil
+}
+
+// learn 审核员将评论改判为垃圾或通过时训练分类器
+func (r *CommentRepo) learn(ctx context.Context, tenantID uint32, before *ent.Comment, after *commentV1.Comment) {
+	if r.moderator == nil || before == nil || after == nil {
+		return
+	}
+
+	var spam bool
+	switch after.GetStatus() {
+	case commentV1.Comment_STATUS_SPAM:
+		spam = true
+	case commentV1.Comment_STATUS_APPROVED:
+		spam = false
+	default:
+		return
+	}
+	if before.Status != nil && *r.statusConverter.ToEntity(after.Status) == *before.Status {
+		return
+	}
+
+	if err := r.moderator.Learn(ctx, tenantID, moderationInput(tenantID, after), spam); err != nil {
+		r.log.Errorf("train spam classifier failed: %s", err.Error())
+	}
+}
+
 func (r *CommentRepo) Create(ctx context.Context, req *commentV1.CreateCommentRequest) (*commentV1.Comment, error) {
 	if req == nil || req.Data == nil {
 		return nil, commentV1.ErrorBadRequest("invalid parameter")
 	}
 
+	if err := r.moderate(ctx, req.Data); err != nil {
+		return nil, err
+	}
+
 	builder := r.entClient.Client().Comment.Create().
 		SetNillableContentType(r.contentTypeConverter.ToEntity(req.Data.ContentType)).
 		SetNillableObjectID(req.Data.ObjectId).
@@ -204,6 +331,7 @@ func (r *CommentRepo) Create(ctx context.Context, req *commentV1.CreateCommentRe
 		SetNillableDetectedLanguage(req.Data.DetectedLanguage).
 		SetNillableIsSpam(req.Data.IsSpam).
 		SetNillableIsSticky(req.Data.IsSticky).
+		SetNillableSpamScore(req.Data.SpamScore).
 		SetNillableParentID(req.Data.ParentId).
 		SetNillableCreatedBy(req.Data.CreatedBy).
 		SetCreatedAt(time.Now())
@@ -239,6 +367,17 @@ func (r *CommentRepo) Update(ctx context.Context, req *commentV1.UpdateCommentRe
 
 	tid, hasTenant := maybeTenantFromViewer(ctx)
 	callerUserID, hasUser := viewerUserIDFromContext(ctx)
+
+	// 状态变更前的评论，用于以审核结果训练分类器
+	var before *ent.Comment
+	if req.Data.Status != nil {
+		q := r.entClient.Client().Comment.Query().Where(comment.IDEQ(req.GetId()))
+		if hasTenant {
+			q.Where(comment.TenantIDEQ(tid))
+		}
+		before, _ = q.Only(ctx)
+	}
+
 	// 计数列已从 Comment 表移除，统一存于 interaction_counter 表（由 InteractionService 独占写入），
 	// 故此处不再需要 FilterBlacklist 保护计数列。
 	builder := r.entClient.Client().Comment.UpdateOneID(req.GetId())
@@ -276,6 +415,9 @@ func (r *CommentRepo) Update(ctx context.Context, req *commentV1.UpdateCommentRe
 			s.Where(sql.EQ(comment.FieldID, req.GetId()))
 		},
 	)
+	if err == nil {
+		r.learn(ctx, tid, before, result)
+	}
 
 	return result, err
 }
@@ -294,3 +436,236 @@ func (r *CommentRepo) Delete(ctx context.Context, req *commentV1.DeleteCommentRe
 
 	return err
 }
+
+// ListModerationQueue 审核队列，按提交时间先后排列；未指定状态时返回待审核评论
+func (r *CommentRepo) ListModerationQueue(ctx context.Context, req *commentV1.ListModerationQueueRequest) (*commentV1.ListCommentResponse, error) {
+	if req == nil {
+		return nil, commentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	builder := r.entClient.Client().Comment.Query()
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(comment.TenantIDEQ(tid))
+	}
+
+	statuses := req.GetStatuses()
+	if len(statuses) == 0 {
+		statuses = []commentV1.Comment_Status{commentV1.Comment_STATUS_PENDING}
+	}
+	entStatuses := make([]comment.Status, 0, len(statuses))
+	for _, st := range statuses {
+		if st == commentV1.Comment_STATUS_UNSPECIFIED {
+			continue
+		}
+		entStatuses = append(entStatuses, *r.statusConverter.ToEntity(&st))
+	}
+	builder.Where(comment.StatusIn(entStatuses...))
+
+	if req.MinSpamScore != nil {
+		builder.Where(comment.SpamScoreGTE(req.GetMinSpamScore()))
+	}
+	if req.MaxSpamScore != nil {
+		builder.Where(comment.SpamScoreLTE(req.GetMaxSpamScore()))
+	}
+	if req.ContentType != nil {
+		builder.Where(comment.ContentTypeEQ(*r.contentTypeConverter.ToEntity(req.ContentType)))
+	}
+	if req.ObjectId != nil {
+		builder.Where(comment.ObjectIDEQ(req.GetObjectId()))
+	}
+	if req.AuthorId != nil {
+		builder.Where(comment.AuthorIDEQ(req.GetAuthorId()))
+	}
+	if req.IpAddress != nil {
+		builder.Where(comment.IPAddressEQ(req.GetIpAddress()))
+	}
+
+	total, err := builder.Clone().Count(ctx)
+	if err != nil {
+		r.log.Errorf("query moderation queue count failed: %s", err.Error())
+		return nil, commentV1.ErrorInternalServerError("query moderation queue failed")
+	}
+
+	page := int(req.GetPage())
+	if page < 1 {
+		page = 1
+	}
+	pageSize := int(req.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	entities, err := builder.
+		Order(ent.Asc(comment.FieldCreatedAt), ent.Asc(comment.FieldID)).
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query moderation queue failed: %s", err.Error())
+		return nil, commentV1.ErrorInternalServerError("query moderation queue failed")
+	}
+
+	items := make([]*commentV1.Comment, 0, len(entities))
+	for _, entity := range entities {
+		items = append(items, r.mapper.ToDTO(entity))
+	}
+
+	return &commentV1.ListCommentResponse{
+		Total: uint64(total),
+		Items: items,
+	}, nil
+}
+
+// BulkSetStatus 批量设置评论状态，返回实际变更的评论ID
+func (r *CommentRepo) BulkSetStatus(ctx context.Context, ids []uint32, status commentV1.Comment_Status) ([]uint32, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return r.setStatusWhere(ctx, status, nil, comment.IDIn(ids...))
+}
+
+// SetStatusByAuthor 按作者（用户ID、IP 或邮箱，任一匹配即可）批量设置评论状态。
+// IP 可以是 CIDR 网段；邮箱只按用户记录解析为用户ID，不匹配游客评论自填的邮箱。
+// onlyStatus 不为空时只处理该状态的评论，例如信任作者时只通过其待审核评论。
+func (r *CommentRepo) SetStatusByAuthor(ctx context.Context, userID uint32, ip, email string, onlyStatus *commentV1.Comment_Status, status commentV1.Comment_Status) ([]uint32, error) {
+	userIDs, err := r.authorUserIDs(ctx, userID, email)
+	if err != nil {
+		return nil, err
+	}
+
+	var authorConds []predicate.Comment
+	if len(userIDs) > 0 {
+		authorConds = append(authorConds, comment.AuthorIDIn(userIDs...), comment.CreatedByIn(userIDs...))
+	}
+
+	var keep func(*ent.Comment) bool
+	if ip = strings.TrimSpace(ip); ip != "" {
+		ip = NormalizeAuthorRuleValue(commentV1.CommentAuthorRule_TYPE_BAN_IP, ip)
+		if !strings.Contains(ip, "/") {
+			authorConds = append(authorConds, comment.IPAddressEQ(ip))
+		} else {
+			// 网段无法在 SQL 中通用匹配，先取有 IP 的评论，再逐条判断
+			authorConds = append(authorConds, comment.IPAddressNotNil())
+			keep = func(c *ent.Comment) bool {
+				if (c.AuthorID != nil && slices.Contains(userIDs, *c.AuthorID)) ||
+					(c.CreatedBy != nil && slices.Contains(userIDs, *c.CreatedBy)) {
+					return true
+				}
+				return c.IPAddress != nil && ipRuleMatches(ip, *c.IPAddress)
+			}
+		}
+	}
+	if len(authorConds) == 0 {
+		return nil, nil
+	}
+
+	preds := []predicate.Comment{comment.Or(authorConds...)}
+	if onlyStatus != nil {
+		preds = append(preds, comment.StatusEQ(*r.statusConverter.ToEntity(onlyStatus)))
+	}
+	return r.setStatusWhere(ctx, status, keep, preds...)
+}
+
+// authorUserIDs 汇总作者对应的用户ID：显式的用户ID，以及用户记录中邮箱匹配的用户
+func (r *CommentRepo) authorUserIDs(ctx context.Context, userID uint32, email string) ([]uint32, error) {
+	var ids []uint32
+	if userID != 0 {
+		ids = append(ids, userID)
+	}
+
+	if email = strings.TrimSpace(email); email != "" {
+		builder := r.entClient.Client().User.Query().
+			Where(user.EmailEqualFold(email))
+		if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+			builder.Where(user.TenantIDEQ(tid))
+		}
+		found, err := builder.IDs(ctx)
+		if err != nil {
+			r.log.Errorf("query users by email failed: %s", err.Error())
+			return nil, commentV1.ErrorInternalServerError("query users failed")
+		}
+		for _, id := range found {
+			if !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	return ids, nil
+}
+
+// setStatusWhere 将满足条件且状态不同的评论改为指定状态，并以改判结果训练分类器，返回变更的评论ID。
+// keep 不为空时进一步筛选查询结果。
+func (r *CommentRepo) setStatusWhere(ctx context.Context, status commentV1.Comment_Status, keep func(*ent.Comment) bool, preds ...predicate.Comment) ([]uint32, error) {
+	if status == commentV1.Comment_STATUS_UNSPECIFIED {
+		return nil, commentV1.ErrorBadRequest("invalid comment status")
+	}
+	entStatus := *r.statusConverter.ToEntity(&status)
+
+	preds = append(preds, comment.Or(comment.StatusIsNil(), comment.StatusNEQ(entStatus)))
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		preds = append(preds, comment.TenantIDEQ(tid))
+	}
+
+	rows, err := r.entClient.Client().Comment.Query().Where(preds...).All(ctx)
+	if err != nil {
+		r.log.Errorf("query comments for moderation failed: %s", err.Error())
+		return nil, commentV1.ErrorInternalServerError("query comments failed")
+	}
+	if keep != nil {
+		rows = slices.DeleteFunc(rows, func(c *ent.Comment) bool { return !keep(c) })
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uint32, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+
+	builder := r.entClient.Client().Comment.Update().
+		Where(comment.IDIn(ids...)).
+		SetStatus(entStatus).
+		SetIsSpam(status == commentV1.Comment_STATUS_SPAM).
+		SetUpdatedAt(time.Now())
+	if uid, ok := viewerUserIDFromContext(ctx); ok {
+		builder.SetUpdatedBy(uid)
+	}
+
+	if _, err = builder.Save(ctx); err != nil {
+		r.log.Errorf("bulk update comment status failed: %s", err.Error())
+		return nil, commentV1.ErrorInternalServerError("bulk update comment status failed")
+	}
+
+	for _, row := range rows {
+		after := r.mapper.ToDTO(row)
+		after.Status = trans.Ptr(status)
+		r.learn(ctx, trans.Uint32Value(row.TenantID), row, after)
+	}
+
+	return ids, nil
+}
+
+// BulkDelete 批量删除评论，返回删除的条数
+func (r *CommentRepo) BulkDelete(ctx context.Context, ids []uint32) (uint32, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	builder := r.entClient.Client().Comment.Delete().
+		Where(comment.IDIn(ids...))
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(comment.TenantIDEQ(tid))
+	}
+
+	affected, err := builder.Exec(ctx)
+	if err != nil {
+		r.log.Errorf("bulk delete comments failed: %s", err.Error())
+		return 0, commentV1.ErrorInternalServerError("bulk delete comments failed")
+	}
+	return uint32(affected), nil
+}
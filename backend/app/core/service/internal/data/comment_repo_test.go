@@ -0,0 +1,155 @@
+package data
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tx7do/go-utils/trans"
+
+	entCrud "github.com/tx7do/go-crud/entgo"
+	conf "github.com/tx7do/kratos-bootstrap/api/gen/go/conf/v1"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/comment"
+	"go-wind-cms/app/core/service/internal/data/ent/enttest"
+
+	_ "github.com/xiaoqidun/entps"
+
+	commentV1 "go-wind-cms/api/gen/go/comment/service/v1"
+)
+
+// newTestCommentRepo 构造一个连内存 sqlite 的 CommentRepo，不启用自动审核与作者规则
+func newTestCommentRepo(t *testing.T) (*CommentRepo, *ent.Client) {
+	t.Helper()
+
+	drv, err := entCrud.CreateDriver(
+		"sqlite3",
+		"file:comment_moderation?mode=memory&cache=shared&_fk=1",
+		false, false,
+	)
+	require.NoError(t, err, "创建 sqlite driver 失败")
+
+	db := enttest.NewClient(t, enttest.WithOptions(ent.Driver(drv)))
+	t.Cleanup(func() { _ = db.Close() })
+
+	bctx := bootstrap.NewContextWithParam(context.Background(), &conf.AppInfo{}, &conf.Bootstrap{}, log.DefaultLogger)
+	return NewCommentRepo(bctx, entCrud.NewEntClient(db, drv), nil, nil), db
+}
+
+// testComment 待写入的评论行
+type testComment struct {
+	tenantID  uint32
+	status    comment.Status
+	createdBy uint32
+	email     string
+	ip        string
+}
+
+func createTestComment(t *testing.T, db *ent.Client, c testComment) uint32 {
+	t.Helper()
+	builder := db.Comment.Create().
+		SetTenantID(c.tenantID).
+		SetStatus(c.status).
+		SetContent("test comment")
+	if c.createdBy != 0 {
+		builder.SetCreatedBy(c.createdBy).SetAuthorID(c.createdBy)
+	}
+	if c.email != "" {
+		builder.SetAuthorEmail(c.email)
+	}
+	if c.ip != "" {
+		builder.SetIPAddress(c.ip)
+	}
+	entity, err := builder.Save(viewerCtx(c.tenantID, 1))
+	require.NoError(t, err, "create test comment failed")
+	return entity.ID
+}
+
+func commentStatus(t *testing.T, db *ent.Client, tid, id uint32) (comment.Status, bool) {
+	t.Helper()
+	entity, err := db.Comment.Get(viewerCtx(tid, 1), id)
+	require.NoError(t, err)
+	require.NotNil(t, entity.Status)
+	return *entity.Status, trans.BoolValue(entity.IsSpam)
+}
+
+// TestBulkSetStatus 只变更本租户内状态不同的评论，并同步 is_spam
+func TestBulkSetStatus(t *testing.T) {
+	repo, db := newTestCommentRepo(t)
+	ctx := viewerCtx(1, 100)
+
+	pending := createTestComment(t, db, testComment{tenantID: 1, status: comment.StatusStatusPending})
+	approved := createTestComment(t, db, testComment{tenantID: 1, status: comment.StatusStatusApproved})
+	spam := createTestComment(t, db, testComment{tenantID: 1, status: comment.StatusStatusSpam})
+	otherTenant := createTestComment(t, db, testComment{tenantID: 2, status: comment.StatusStatusPending})
+
+	changed, err := repo.BulkSetStatus(ctx, []uint32{pending, approved, spam, otherTenant}, commentV1.Comment_STATUS_APPROVED)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uint32{pending, spam}, changed)
+
+	status, isSpam := commentStatus(t, db, 1, spam)
+	assert.Equal(t, comment.StatusStatusApproved, status)
+	assert.False(t, isSpam)
+
+	status, _ = commentStatus(t, db, 2, otherTenant)
+	assert.Equal(t, comment.StatusStatusPending, status, "其他租户的评论不应被修改")
+
+	changed, err = repo.BulkSetStatus(ctx, []uint32{approved}, commentV1.Comment_STATUS_SPAM)
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{approved}, changed)
+	status, isSpam = commentStatus(t, db, 1, approved)
+	assert.Equal(t, comment.StatusStatusSpam, status)
+	assert.True(t, isSpam)
+
+	// 再次设置为相同状态不产生变更
+	changed, err = repo.BulkSetStatus(ctx, []uint32{approved}, commentV1.Comment_STATUS_SPAM)
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+
+	_, err = repo.BulkSetStatus(ctx, []uint32{pending}, commentV1.Comment_STATUS_UNSPECIFIED)
+	assert.Error(t, err)
+}
+
+// TestSetStatusByAuthor 邮箱按用户记录匹配，IP 支持网段，onlyStatus 限定处理范围
+func TestSetStatusByAuthor(t *testing.T) {
+	repo, db := newTestCommentRepo(t)
+	ctx := viewerCtx(1, 100)
+
+	bob, err := db.User.Create().
+		SetTenantID(1).
+		SetUsername("bob").
+		SetEmail("bob@example.com").
+		Save(ctx)
+	require.NoError(t, err)
+
+	bobPending := createTestComment(t, db, testComment{tenantID: 1, status: comment.StatusStatusPending, createdBy: bob.ID})
+	bobApproved := createTestComment(t, db, testComment{tenantID: 1, status: comment.StatusStatusApproved, createdBy: bob.ID})
+	guestWithBobEmail := createTestComment(t, db, testComment{tenantID: 1, status: comment.StatusStatusPending, email: "bob@example.com"})
+
+	changed, err := repo.SetStatusByAuthor(ctx, 0, "", "BOB@example.com",
+		trans.Ptr(commentV1.Comment_STATUS_PENDING), commentV1.Comment_STATUS_APPROVED)
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{bobPending}, changed)
+
+	status, _ := commentStatus(t, db, 1, guestWithBobEmail)
+	assert.Equal(t, comment.StatusStatusPending, status, "游客自填的邮箱不应匹配信任规则")
+
+	inRange := createTestComment(t, db, testComment{tenantID: 1, status: comment.StatusStatusApproved, ip: "10.1.2.3"})
+	outOfRange := createTestComment(t, db, testComment{tenantID: 1, status: comment.StatusStatusApproved, ip: "10.2.0.1"})
+
+	changed, err = repo.SetStatusByAuthor(ctx, 0, "10.1.0.0/16", "", nil, commentV1.Comment_STATUS_SPAM)
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{inRange}, changed)
+
+	changed, err = repo.SetStatusByAuthor(ctx, bob.ID, "10.2.0.1", "", nil, commentV1.Comment_STATUS_SPAM)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uint32{bobPending, bobApproved, outOfRange}, changed)
+
+	changed, err = repo.SetStatusByAuthor(ctx, 0, "", "nobody@example.com", nil, commentV1.Comment_STATUS_SPAM)
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+}
@@ -0,0 +1,649 @@
+package data
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/jinzhu/copier"
+
+	"github.com/tx7do/go-utils/copierutil"
+	"github.com/tx7do/go-utils/mapper"
+	"github.com/tx7do/go-utils/timeutil"
+	"github.com/tx7do/go-utils/trans"
+
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	entCrud "github.com/tx7do/go-crud/entgo"
+	"github.com/tx7do/go-crud/pagination"
+	paginationFilter "github.com/tx7do/go-crud/pagination/filter"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/contententry"
+	"go-wind-cms/app/core/service/internal/data/ent/predicate"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/customfield"
+	"go-wind-cms/pkg/content/entry"
+	"go-wind-cms/pkg/utils"
+)
+
+const (
+	defaultEntrySearchPageSize = 10
+	maxEntrySearchPageSize     = 50
+)
+
+// ContentEntryRepo 自定义内容类型的条目
+type ContentEntryRepo struct {
+	entClient *entCrud.EntClient[*ent.Client]
+	log       *log.Helper
+
+	mapper *mapper.CopierMapper[contentV1.ContentEntry, ent.ContentEntry]
+
+	repository *entCrud.Repository[
+		ent.ContentEntryQuery, ent.ContentEntrySelect,
+		ent.ContentEntryCreate, ent.ContentEntryCreateBulk,
+		ent.ContentEntryUpdate, ent.ContentEntryUpdateOne,
+		ent.ContentEntryDelete,
+		predicate.ContentEntry,
+		contentV1.ContentEntry, ent.ContentEntry,
+	]
+
+	statusConverter *mapper.EnumTypeConverter[contentV1.ContentEntry_ContentEntryStatus, contententry.Status]
+
+	contentModelRepo *ContentModelRepo
+}
+
+func NewContentEntryRepo(
+	ctx *bootstrap.Context,
+	entClient *entCrud.EntClient[*ent.Client],
+	contentModelRepo *ContentModelRepo,
+) *ContentEntryRepo {
+	repo := &ContentEntryRepo{
+		entClient: entClient,
+		log:       ctx.NewLoggerHelper("content-entry/repo/core-service"),
+		mapper:    mapper.NewCopierMapper[contentV1.ContentEntry, ent.ContentEntry](),
+		statusConverter: mapper.NewEnumTypeConverter[contentV1.ContentEntry_ContentEntryStatus, contententry.Status](
+			contentV1.ContentEntry_ContentEntryStatus_name, contentV1.ContentEntry_ContentEntryStatus_value,
+		),
+		contentModelRepo: contentModelRepo,
+	}
+
+	repo.init()
+
+	return repo
+}
+
+func (r *ContentEntryRepo) init() {
+	r.repository = entCrud.NewRepository[
+		ent.ContentEntryQuery, ent.ContentEntrySelect,
+		ent.ContentEntryCreate, ent.ContentEntryCreateBulk,
+		ent.ContentEntryUpdate, ent.ContentEntryUpdateOne,
+		ent.ContentEntryDelete,
+		predicate.ContentEntry,
+		contentV1.ContentEntry, ent.ContentEntry,
+	](r.mapper)
+
+	r.mapper.AppendConverters(copierutil.NewTimeStringConverterPair())
+	r.mapper.AppendConverters(copierutil.NewTimeTimestamppbConverterPair())
+
+	r.mapper.AppendConverters(r.statusConverter.NewConverterPair())
+	r.mapper.AppendConverters(newCustomFieldValuesConverterPair())
+	r.mapper.AppendConverters(newContentEntryTranslationsConverterPair())
+}
+
+// newContentEntryTranslationsConverterPair 条目 translations 列（以语言代码为键）与 API 翻译列表互转
+func newContentEntryTranslationsConverterPair() []copier.TypeConverter {
+	return copierutil.NewGenericTypeConverterPair(
+		map[string]*entry.Translation{}, []*contentV1.ContentEntryTranslation{},
+		contentEntryTranslationsToProto,
+		func(list []*contentV1.ContentEntryTranslation) map[string]*entry.Translation {
+			translations, _ := contentEntryTranslationsFromProto(list)
+			return translations
+		},
+	)
+}
+
+func contentEntryTranslationsToProto(translations map[string]*entry.Translation) []*contentV1.ContentEntryTranslation {
+	if translations == nil {
+		return nil
+	}
+	out := make([]*contentV1.ContentEntryTranslation, 0, len(translations))
+	for _, lang := range entry.Languages(translations) {
+		tr := translations[lang]
+		item := &contentV1.ContentEntryTranslation{
+			LanguageCode: lang,
+			Title:        trans.Ptr(tr.Title),
+			FieldValues:  customFieldValuesToProto(tr.FieldValues),
+		}
+		if tr.Slug != "" {
+			item.Slug = trans.Ptr(tr.Slug)
+		}
+		if tr.Summary != "" {
+			item.Summary = trans.Ptr(tr.Summary)
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
+func contentEntryTranslationsFromProto(list []*contentV1.ContentEntryTranslation) (map[string]*entry.Translation, error) {
+	if list == nil {
+		return nil, nil
+	}
+	translations := make(map[string]*entry.Translation, len(list))
+	for _, item := range list {
+		lang := strings.TrimSpace(item.GetLanguageCode())
+		if lang == "" {
+			return nil, contentV1.ErrorBadRequest("translation language code is required")
+		}
+		if _, ok := translations[lang]; ok {
+			return nil, contentV1.ErrorBadRequest("duplicate translation %s", lang)
+		}
+		fieldValues := customFieldValuesFromProto(item.GetFieldValues())
+		if fieldValues == nil {
+			fieldValues = map[string]*customfield.Value{}
+		}
+		translations[lang] = &entry.Translation{
+			Title:       strings.TrimSpace(item.GetTitle()),
+			Slug:        strings.TrimSpace(item.GetSlug()),
+			Summary:     item.GetSummary(),
+			FieldValues: fieldValues,
+		}
+	}
+	return translations, nil
+}
+
+// validateContentEntry 按内容类型校验条目的字段值、各语言翻译与分类法绑定
+func validateContentEntry(model *ent.ContentModel, values map[string]*customfield.Value, translations map[string]*entry.Translation, categoryIDs, tagIDs []uint32) error {
+	shared, localized := entry.SplitDefinitions(model.Fields, model.LocalizedFields)
+
+	if err := customfield.Validate(shared, values); err != nil {
+		return contentV1.ErrorBadRequest("invalid custom field %s", err.Error())
+	}
+
+	if len(translations) == 0 {
+		return contentV1.ErrorBadRequest("at least one translation is required")
+	}
+	for lang, tr := range translations {
+		if tr.Title == "" {
+			return contentV1.ErrorBadRequest("translation %s requires a title", lang)
+		}
+		if tr.FieldValues == nil {
+			tr.FieldValues = map[string]*customfield.Value{}
+		}
+		if err := customfield.Validate(localized, tr.FieldValues); err != nil {
+			return contentV1.ErrorBadRequest("invalid custom field %s.%s", lang, err.Error())
+		}
+	}
+
+	if len(categoryIDs) > 0 && !slices.Contains(model.Taxonomies, entry.TaxonomyCategory) {
+		return contentV1.ErrorBadRequest("content model %s does not support categories", trans.StringValue(model.Code))
+	}
+	if len(tagIDs) > 0 && !slices.Contains(model.Taxonomies, entry.TaxonomyTag) {
+		return contentV1.ErrorBadRequest("content model %s does not support tags", trans.StringValue(model.Code))
+	}
+
+	return nil
+}
+
+// fillAvailableLanguages 由翻译列表推导已有语言，并按 locale 只保留对应翻译
+func fillAvailableLanguages(dto *contentV1.ContentEntry, locale string) {
+	languages := make([]string, 0, len(dto.Translations))
+	for _, tr := range dto.Translations {
+		languages = append(languages, tr.GetLanguageCode())
+	}
+	sort.Strings(languages)
+	dto.AvailableLanguages = languages
+
+	if locale == "" {
+		return
+	}
+	filtered := dto.Translations[:0]
+	for _, tr := range dto.Translations {
+		if tr.GetLanguageCode() == locale {
+			filtered = append(filtered, tr)
+		}
+	}
+	dto.Translations = filtered
+}
+
+func parseUint32Values(cond *paginationV1.FilterCondition) ([]uint32, error) {
+	raws := cond.GetValues()
+	if v := cond.GetValue(); v != "" {
+		raws = append([]string{v}, raws...)
+	}
+	ids := make([]uint32, 0, len(raws))
+	for _, raw := range raws {
+		id, err := strconv.ParseUint(strings.TrimSpace(raw), 10, 32)
+		if err != nil {
+			return nil, contentV1.ErrorBadRequest("invalid %s value", cond.GetField())
+		}
+		ids = append(ids, uint32(id))
+	}
+	return ids, nil
+}
+
+// jsonContainsAny JSON 数组列包含任一给定值
+func jsonContainsAny(column string, ids []uint32) func(s *sql.Selector) {
+	return func(s *sql.Selector) {
+		ps := make([]*sql.Predicate, 0, len(ids))
+		for _, id := range ids {
+			ps = append(ps, sqljson.ValueContains(s.C(column), id))
+		}
+		s.Where(sql.Or(ps...))
+	}
+}
+
+func (r *ContentEntryRepo) List(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListContentEntryResponse, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	builder := r.entClient.Client().ContentEntry.Query()
+	tid, hasTenant := maybeTenantFromViewer(ctx)
+	if hasTenant {
+		builder.Where(contententry.TenantIDEQ(tid))
+	}
+
+	filterExpr, err := paginationFilter.ConvertFilterByPagingRequest(req)
+	if err != nil {
+		r.log.Errorf("convert filter by paging request failed: %s", err.Error())
+		return nil, contentV1.ErrorBadRequest("invalid filter")
+	}
+	excludeConditions := pagination.FilterFields(filterExpr, []string{
+		"model_code",
+		"category_ids",
+		"tag_ids",
+	})
+	req.FilteringType = &paginationV1.PagingRequest_FilterExpr{FilterExpr: filterExpr}
+
+	var modelCode string
+	for _, cond := range excludeConditions {
+		switch cond.GetField() {
+		case "model_code":
+			modelCode = cond.GetValue()
+
+		case "category_ids", "tag_ids":
+			ids, err := parseUint32Values(cond)
+			if err != nil {
+				return nil, err
+			}
+			if len(ids) == 0 {
+				continue
+			}
+			column := contententry.FieldCategoryIds
+			if cond.GetField() == "tag_ids" {
+				column = contententry.FieldTagIds
+			}
+			builder.Modify(jsonContainsAny(column, ids))
+		}
+	}
+
+	// 字段定义因内容类型而异，列表必须限定内容类型
+	if modelCode == "" {
+		return nil, contentV1.ErrorBadRequest("model_code filter is required")
+	}
+	model, err := r.contentModelRepo.GetByCode(ctx, tid, modelCode)
+	if err != nil {
+		return nil, err
+	}
+	builder.Where(contententry.ModelIDEQ(model.ID))
+
+	query, err := takeCustomFieldQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	if !query.empty() {
+		shared, _ := entry.SplitDefinitions(model.Fields, model.LocalizedFields)
+		wheres, orders, err := query.build(contententry.FieldFieldValues, shared)
+		if err != nil {
+			return nil, err
+		}
+		if len(wheres) > 0 {
+			builder.Modify(wheres...)
+		}
+		for _, order := range orders {
+			builder.Order(contententry.OrderOption(order))
+		}
+	}
+
+	ret, err := r.repository.ListWithPaging(ctx, builder, builder.Clone(), req)
+	if err != nil {
+		return nil, err
+	}
+	if ret == nil {
+		return &contentV1.ListContentEntryResponse{Total: 0, Items: nil}, nil
+	}
+
+	for _, item := range ret.Items {
+		fillAvailableLanguages(item, "")
+	}
+
+	return &contentV1.ListContentEntryResponse{
+		Total: ret.Total,
+		Items: ret.Items,
+	}, nil
+}
+
+func (r *ContentEntryRepo) get(ctx context.Context, id uint32) (*ent.ContentEntry, error) {
+	builder := r.entClient.Client().ContentEntry.Query().
+		Where(contententry.IDEQ(id))
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(contententry.TenantIDEQ(tid))
+	}
+
+	entity, err := builder.Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound("content entry not found")
+		}
+		r.log.Errorf("query content entry failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query content entry failed")
+	}
+
+	return entity, nil
+}
+
+func (r *ContentEntryRepo) Get(ctx context.Context, req *contentV1.GetContentEntryRequest) (*contentV1.ContentEntry, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	entity, err := r.get(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	// 前台视图：仅返回已启用类型下已发布的条目，其余按未找到处理
+	if req.GetPublicView() {
+		if entity.Status == nil || *entity.Status != contententry.StatusContentEntryStatusPublished {
+			return nil, contentV1.ErrorNotFound("content entry not found")
+		}
+		model, err := r.contentModelRepo.GetByID(ctx, trans.Uint32Value(entity.ModelID))
+		if err != nil {
+			return nil, err
+		}
+		if !modelEnabled(model) {
+			return nil, contentV1.ErrorNotFound("content entry not found")
+		}
+	}
+
+	dto := r.mapper.ToDTO(entity)
+	fillAvailableLanguages(dto, req.GetLocale())
+
+	return dto, nil
+}
+
+func (r *ContentEntryRepo) Create(ctx context.Context, req *contentV1.CreateContentEntryRequest) (*contentV1.ContentEntry, error) {
+	if req == nil || req.Data == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	tid, hasTenant := maybeTenantFromViewer(ctx)
+	model, err := r.contentModelRepo.GetByCode(ctx, tid, req.Data.GetModelCode())
+	if err != nil {
+		return nil, err
+	}
+
+	values := customFieldValuesFromProto(req.Data.GetFieldValues())
+	if values == nil {
+		values = map[string]*customfield.Value{}
+	}
+	translations, err := contentEntryTranslationsFromProto(req.Data.GetTranslations())
+	if err != nil {
+		return nil, err
+	}
+	if err = validateContentEntry(model, values, translations, req.Data.GetCategoryIds(), req.Data.GetTagIds()); err != nil {
+		return nil, err
+	}
+
+	status := r.statusConverter.ToEntity(req.Data.Status)
+	publishTime := timeutil.TimestamppbToTime(req.Data.PublishTime)
+	if publishTime == nil && status != nil && *status == contententry.StatusContentEntryStatusPublished {
+		publishTime = trans.Ptr(time.Now())
+	}
+
+	builder := r.entClient.Client().ContentEntry.Create().
+		SetModelID(model.ID).
+		SetModelCode(trans.StringValue(model.Code)).
+		SetNillableStatus(status).
+		SetNillableAuthorID(req.Data.AuthorId).
+		SetNillableAuthorName(req.Data.AuthorName).
+		SetFieldValues(values).
+		SetTranslations(translations).
+		SetLanguages(entry.Languages(translations)).
+		SetCategoryIds(req.Data.GetCategoryIds()).
+		SetTagIds(req.Data.GetTagIds()).
+		SetSearchText(entry.SearchText(values, translations)).
+		SetNillableSortOrder(req.Data.SortOrder).
+		SetNillablePublishTime(publishTime).
+		SetNillableCreatedBy(req.Data.CreatedBy).
+		SetCreatedAt(time.Now())
+	if hasTenant {
+		builder.SetTenantID(tid)
+	}
+
+	entity, err := builder.Save(ctx)
+	if err != nil {
+		r.log.Errorf("insert content entry failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("insert content entry failed")
+	}
+
+	dto := r.mapper.ToDTO(entity)
+	fillAvailableLanguages(dto, "")
+
+	return dto, nil
+}
+
+func (r *ContentEntryRepo) Update(ctx context.Context, req *contentV1.UpdateContentEntryRequest) (*contentV1.ContentEntry, error) {
+	if req == nil || req.Data == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	current, err := r.get(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	model, err := r.contentModelRepo.GetByID(ctx, trans.Uint32Value(current.ModelID))
+	if err != nil {
+		return nil, err
+	}
+
+	// 字段值、翻译与分类法按合并后的完整条目校验：
+	// 翻译默认按语言合并，updateMask 含 translations 时整体替换
+	paths := req.GetUpdateMask().GetPaths()
+
+	values := current.FieldValues
+	if req.Data.FieldValues != nil || slices.Contains(paths, "field_values") {
+		values = customFieldValuesFromProto(req.Data.GetFieldValues())
+	}
+	if values == nil {
+		values = map[string]*customfield.Value{}
+	}
+
+	incoming, err := contentEntryTranslationsFromProto(req.Data.GetTranslations())
+	if err != nil {
+		return nil, err
+	}
+	translations := make(map[string]*entry.Translation, len(current.Translations)+len(incoming))
+	if !slices.Contains(paths, "translations") {
+		for lang, tr := range current.Translations {
+			translations[lang] = tr
+		}
+	}
+	for lang, tr := range incoming {
+		translations[lang] = tr
+	}
+
+	categoryIDs := current.CategoryIds
+	if req.Data.CategoryIds != nil || slices.Contains(paths, "category_ids") {
+		categoryIDs = req.Data.GetCategoryIds()
+	}
+	tagIDs := current.TagIds
+	if req.Data.TagIds != nil || slices.Contains(paths, "tag_ids") {
+		tagIDs = req.Data.GetTagIds()
+	}
+
+	if err = validateContentEntry(model, values, translations, categoryIDs, tagIDs); err != nil {
+		return nil, err
+	}
+
+	status := r.statusConverter.ToEntity(req.Data.Status)
+	publishTime := timeutil.TimestamppbToTime(req.Data.PublishTime)
+	if publishTime == nil && current.PublishTime == nil &&
+		status != nil && *status == contententry.StatusContentEntryStatusPublished {
+		publishTime = trans.Ptr(time.Now())
+	}
+
+	// 内容类型创建后不可修改；author_id 仅在创建时设置；
+	// 字段值、翻译与分类法经校验后单独写入
+	if req.UpdateMask != nil {
+		req.UpdateMask.Paths = utils.FilterBlacklist(req.UpdateMask.GetPaths(), []string{
+			"model_code", "author_id", "field_values", "translations", "available_languages",
+			"category_ids", "tag_ids",
+		})
+	}
+	req.Data.ModelCode = nil
+	req.Data.AuthorId = nil
+
+	tid, hasTenant := maybeTenantFromViewer(ctx)
+	callerUserID, hasUser := viewerUserIDFromContext(ctx)
+
+	builder := r.entClient.Client().ContentEntry.UpdateOneID(req.GetId())
+	if hasTenant {
+		builder.Where(contententry.TenantIDEQ(tid))
+	}
+	result, err := r.repository.UpdateOne(ctx, builder, req.Data, req.GetUpdateMask(),
+		func(dto *contentV1.ContentEntry) {
+			builder.
+				SetNillableStatus(status).
+				SetNillableAuthorName(req.Data.AuthorName).
+				SetNillableSortOrder(req.Data.SortOrder).
+				SetNillablePublishTime(publishTime).
+				SetFieldValues(values).
+				SetTranslations(translations).
+				SetLanguages(entry.Languages(translations)).
+				SetCategoryIds(categoryIDs).
+				SetTagIds(tagIDs).
+				SetSearchText(entry.SearchText(values, translations)).
+				SetUpdatedAt(time.Now())
+
+			// updated_by 强制由服务端 viewer context 推导，忽略客户端传入值
+			if hasUser {
+				builder.SetUpdatedBy(callerUserID)
+			}
+		},
+		func(s *sql.Selector) {
+			s.Where(sql.EQ(contententry.FieldID, req.GetId()))
+		},
+	)
+	if result != nil {
+		fillAvailableLanguages(result, "")
+	}
+
+	return result, err
+}
+
+func (r *ContentEntryRepo) Delete(ctx context.Context, req *contentV1.DeleteContentEntryRequest) error {
+	if req == nil {
+		return contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	builder := r.entClient.Client().ContentEntry.Delete().
+		Where(contententry.IDEQ(req.GetId()))
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(contententry.TenantIDEQ(tid))
+	}
+
+	affected, err := builder.Exec(ctx)
+	if err != nil {
+		r.log.Errorf("delete content entry failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("delete content entry failed")
+	}
+	if affected == 0 {
+		return contentV1.ErrorNotFound("content entry not found")
+	}
+
+	return nil
+}
+
+// Search 按关键词搜索已启用类型下已发布、且有指定语言翻译的条目。
+// 租户取自 viewer，状态固定为已发布，调用方无法指定或绕过。
+func (r *ContentEntryRepo) Search(ctx context.Context, req *contentV1.SearchContentEntriesRequest) (*contentV1.SearchContentEntriesResponse, error) {
+	if req == nil || req.GetModelCode() == "" || req.GetLanguage() == "" {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	tid, _ := maybeTenantFromViewer(ctx)
+	model, err := r.contentModelRepo.GetByCode(ctx, tid, req.GetModelCode())
+	if err != nil {
+		return nil, err
+	}
+	if !modelEnabled(model) {
+		return nil, contentV1.ErrorNotFound("content model not found")
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultEntrySearchPageSize
+	}
+	pageSize = min(pageSize, maxEntrySearchPageSize)
+	page := max(int(req.GetPage()), 0)
+
+	language := req.GetLanguage()
+	builder := r.entClient.Client().ContentEntry.Query().
+		Where(
+			contententry.TenantIDEQ(tid),
+			contententry.ModelIDEQ(model.ID),
+			contententry.StatusEQ(contententry.StatusContentEntryStatusPublished),
+			func(s *sql.Selector) {
+				s.Where(sqljson.ValueContains(s.C(contententry.FieldLanguages), language))
+			},
+		)
+	if q := strings.ToLower(strings.TrimSpace(req.GetQuery())); q != "" {
+		builder.Where(contententry.SearchTextContains(q))
+	}
+
+	total, err := builder.Clone().Count(ctx)
+	if err != nil {
+		r.log.Errorf("count content entries failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("search content entries failed")
+	}
+
+	entities, err := builder.
+		Order(ent.Desc(contententry.FieldPublishTime), ent.Desc(contententry.FieldID)).
+		Offset(page * pageSize).
+		Limit(pageSize).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("search content entries failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("search content entries failed")
+	}
+
+	resp := &contentV1.SearchContentEntriesResponse{
+		Total: int32(total),
+		Items: make([]*contentV1.SearchContentEntryHit, 0, len(entities)),
+	}
+	for _, e := range entities {
+		tr := e.Translations[language]
+		if tr == nil {
+			continue
+		}
+		resp.Items = append(resp.Items, &contentV1.SearchContentEntryHit{
+			EntryId:  e.ID,
+			Language: language,
+			Title:    tr.Title,
+			Slug:     tr.Slug,
+		})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,322 @@
+package data
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/tx7do/go-utils/copierutil"
+	"github.com/tx7do/go-utils/mapper"
+	"github.com/tx7do/go-utils/trans"
+
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	entCrud "github.com/tx7do/go-crud/entgo"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/contententry"
+	"go-wind-cms/app/core/service/internal/data/ent/contentmodel"
+	"go-wind-cms/app/core/service/internal/data/ent/predicate"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/customfield"
+	"go-wind-cms/pkg/content/entry"
+	"go-wind-cms/pkg/utils"
+)
+
+// ContentModelRepo 自定义内容类型
+type ContentModelRepo struct {
+	entClient *entCrud.EntClient[*ent.Client]
+	log       *log.Helper
+
+	mapper *mapper.CopierMapper[contentV1.ContentModel, ent.ContentModel]
+
+	repository *entCrud.Repository[
+		ent.ContentModelQuery, ent.ContentModelSelect,
+		ent.ContentModelCreate, ent.ContentModelCreateBulk,
+		ent.ContentModelUpdate, ent.ContentModelUpdateOne,
+		ent.ContentModelDelete,
+		predicate.ContentModel,
+		contentV1.ContentModel, ent.ContentModel,
+	]
+}
+
+func NewContentModelRepo(ctx *bootstrap.Context, entClient *entCrud.EntClient[*ent.Client]) *ContentModelRepo {
+	repo := &ContentModelRepo{
+		entClient: entClient,
+		log:       ctx.NewLoggerHelper("content-model/repo/core-service"),
+		mapper:    mapper.NewCopierMapper[contentV1.ContentModel, ent.ContentModel](),
+	}
+
+	repo.init()
+
+	return repo
+}
+
+func (r *ContentModelRepo) init() {
+	r.repository = entCrud.NewRepository[
+		ent.ContentModelQuery, ent.ContentModelSelect,
+		ent.ContentModelCreate, ent.ContentModelCreateBulk,
+		ent.ContentModelUpdate, ent.ContentModelUpdateOne,
+		ent.ContentModelDelete,
+		predicate.ContentModel,
+		contentV1.ContentModel, ent.ContentModel,
+	](r.mapper)
+
+	r.mapper.AppendConverters(copierutil.NewTimeStringConverterPair())
+	r.mapper.AppendConverters(copierutil.NewTimeTimestamppbConverterPair())
+
+	r.mapper.AppendConverters(newCustomFieldDefinitionsConverterPair())
+}
+
+// checkContentModelDefinition 校验字段定义、多语言字段与分类法
+func checkContentModelDefinition(defs []customfield.Definition, localizedFields, taxonomies []string) error {
+	if err := customfield.ValidateDefinitions(defs); err != nil {
+		return contentV1.ErrorBadRequest("invalid field definition %s", err.Error())
+	}
+	if err := entry.ValidateLocalizedFields(defs, localizedFields); err != nil {
+		return contentV1.ErrorBadRequest("invalid content model %s", err.Error())
+	}
+	if err := entry.ValidTaxonomies(taxonomies); err != nil {
+		return contentV1.ErrorBadRequest("invalid content model %s", err.Error())
+	}
+	return nil
+}
+
+func (r *ContentModelRepo) List(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListContentModelResponse, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	builder := r.entClient.Client().ContentModel.Query()
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(contentmodel.TenantIDEQ(tid))
+	}
+
+	ret, err := r.repository.ListWithPaging(ctx, builder, builder.Clone(), req)
+	if err != nil {
+		return nil, err
+	}
+	if ret == nil {
+		return &contentV1.ListContentModelResponse{Total: 0, Items: nil}, nil
+	}
+
+	return &contentV1.ListContentModelResponse{
+		Total: ret.Total,
+		Items: ret.Items,
+	}, nil
+}
+
+func (r *ContentModelRepo) Get(ctx context.Context, req *contentV1.GetContentModelRequest) (*contentV1.ContentModel, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	builder := r.entClient.Client().ContentModel.Query()
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(contentmodel.TenantIDEQ(tid))
+	}
+
+	switch req.QueryBy.(type) {
+	case *contentV1.GetContentModelRequest_Id:
+		builder.Where(contentmodel.IDEQ(req.GetId()))
+	case *contentV1.GetContentModelRequest_Code:
+		builder.Where(contentmodel.CodeEQ(req.GetCode()))
+	default:
+		return nil, contentV1.ErrorBadRequest("invalid query field")
+	}
+
+	entity, err := builder.Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound("content model not found")
+		}
+		r.log.Errorf("query content model failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query content model failed")
+	}
+
+	return r.mapper.ToDTO(entity), nil
+}
+
+// GetByCode 按编码查找租户下的内容类型，供条目读写时加载字段定义
+func (r *ContentModelRepo) GetByCode(ctx context.Context, tenantID uint32, code string) (*ent.ContentModel, error) {
+	entity, err := r.entClient.Client().ContentModel.Query().
+		Where(
+			contentmodel.TenantIDEQ(tenantID),
+			contentmodel.CodeEQ(code),
+		).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound("content model not found")
+		}
+		r.log.Errorf("query content model failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query content model failed")
+	}
+	return entity, nil
+}
+
+// GetByID 按ID查找内容类型
+func (r *ContentModelRepo) GetByID(ctx context.Context, id uint32) (*ent.ContentModel, error) {
+	entity, err := r.entClient.Client().ContentModel.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound("content model not found")
+		}
+		r.log.Errorf("query content model failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query content model failed")
+	}
+	return entity, nil
+}
+
+func (r *ContentModelRepo) Create(ctx context.Context, req *contentV1.CreateContentModelRequest) (*contentV1.ContentModel, error) {
+	if req == nil || req.Data == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	code := strings.TrimSpace(req.Data.GetCode())
+	if !entry.ValidCode(code) {
+		return nil, contentV1.ErrorBadRequest("invalid content model code")
+	}
+	defs := customFieldDefinitionsFromProto(req.Data.GetFields())
+	if err := checkContentModelDefinition(defs, req.Data.GetLocalizedFields(), req.Data.GetTaxonomies()); err != nil {
+		return nil, err
+	}
+
+	builder := r.entClient.Client().ContentModel.Create().
+		SetCode(code).
+		SetNillableName(req.Data.Name).
+		SetNillableDescription(req.Data.Description).
+		SetFields(defs).
+		SetLocalizedFields(req.Data.GetLocalizedFields()).
+		SetTaxonomies(req.Data.GetTaxonomies()).
+		SetNillableEnabled(req.Data.Enabled).
+		SetNillableSortOrder(req.Data.SortOrder).
+		SetNillableCreatedBy(req.Data.CreatedBy).
+		SetCreatedAt(time.Now())
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.SetTenantID(tid)
+	}
+
+	entity, err := builder.Save(ctx)
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return nil, contentV1.ErrorConflict("content model code already exists")
+		}
+		r.log.Errorf("insert content model failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("insert content model failed")
+	}
+
+	return r.mapper.ToDTO(entity), nil
+}
+
+func (r *ContentModelRepo) Update(ctx context.Context, req *contentV1.UpdateContentModelRequest) (*contentV1.ContentModel, error) {
+	if req == nil || req.Data == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	current, err := r.Get(ctx, &contentV1.GetContentModelRequest{QueryBy: &contentV1.GetContentModelRequest_Id{Id: req.GetId()}})
+	if err != nil {
+		return nil, err
+	}
+
+	// 字段定义、多语言字段与分类法相互约束，按合并后的完整定义校验
+	paths := req.GetUpdateMask().GetPaths()
+	if req.Data.Fields == nil && !slices.Contains(paths, "fields") {
+		req.Data.Fields = current.Fields
+	}
+	if req.Data.LocalizedFields == nil && !slices.Contains(paths, "localized_fields") {
+		req.Data.LocalizedFields = current.LocalizedFields
+	}
+	if req.Data.Taxonomies == nil && !slices.Contains(paths, "taxonomies") {
+		req.Data.Taxonomies = current.Taxonomies
+	}
+	defs := customFieldDefinitionsFromProto(req.Data.GetFields())
+	if err = checkContentModelDefinition(defs, req.Data.GetLocalizedFields(), req.Data.GetTaxonomies()); err != nil {
+		return nil, err
+	}
+
+	// 编码被条目引用，创建后不可修改
+	if req.UpdateMask != nil {
+		req.UpdateMask.Paths = utils.FilterBlacklist(req.UpdateMask.GetPaths(), []string{"code"})
+	}
+	req.Data.Code = nil
+
+	callerUserID, hasUser := viewerUserIDFromContext(ctx)
+
+	builder := r.entClient.Client().ContentModel.UpdateOneID(req.GetId())
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(contentmodel.TenantIDEQ(tid))
+	}
+	return r.repository.UpdateOne(ctx, builder, req.Data, req.GetUpdateMask(),
+		func(dto *contentV1.ContentModel) {
+			builder.
+				SetNillableName(req.Data.Name).
+				SetNillableDescription(req.Data.Description).
+				SetFields(defs).
+				SetLocalizedFields(req.Data.GetLocalizedFields()).
+				SetTaxonomies(req.Data.GetTaxonomies()).
+				SetNillableEnabled(req.Data.Enabled).
+				SetNillableSortOrder(req.Data.SortOrder).
+				SetUpdatedAt(time.Now())
+
+			// updated_by 强制由服务端 viewer context 推导，忽略客户端传入值
+			if hasUser {
+				builder.SetUpdatedBy(callerUserID)
+			}
+		},
+		func(s *sql.Selector) {
+			s.Where(sql.EQ(contentmodel.FieldID, req.GetId()))
+		},
+	)
+}
+
+func (r *ContentModelRepo) Delete(ctx context.Context, req *contentV1.DeleteContentModelRequest) error {
+	if req == nil {
+		return contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	tid, hasTenant := maybeTenantFromViewer(ctx)
+
+	// 仍有条目时拒绝删除，避免条目失去字段定义
+	entries := r.entClient.Client().ContentEntry.Query().
+		Where(contententry.ModelIDEQ(req.GetId()))
+	if hasTenant {
+		entries.Where(contententry.TenantIDEQ(tid))
+	}
+	hasEntries, err := entries.Exist(ctx)
+	if err != nil {
+		r.log.Errorf("query content entries failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("query content entries failed")
+	}
+	if hasEntries {
+		return contentV1.ErrorConflict("content model still has entries")
+	}
+
+	builder := r.entClient.Client().ContentModel.Delete().
+		Where(contentmodel.IDEQ(req.GetId()))
+	if hasTenant {
+		builder.Where(contentmodel.TenantIDEQ(tid))
+	}
+
+	affected, err := builder.Exec(ctx)
+	if err != nil {
+		r.log.Errorf("delete content model failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("delete content model failed")
+	}
+	if affected == 0 {
+		return contentV1.ErrorNotFound("content model not found")
+	}
+
+	return nil
+}
+
+// modelEnabled 内容类型是否启用（未设置视为启用）
+func modelEnabled(m *ent.ContentModel) bool {
+	return trans.BoolValue(m.Enabled) || m.Enabled == nil
+}
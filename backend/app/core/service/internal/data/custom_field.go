@@ -0,0 +1,442 @@
+package data
+
+import (
+	"strings"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"github.com/jinzhu/copier"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	paginationFilter "github.com/tx7do/go-crud/pagination/filter"
+
+	"github.com/tx7do/go-utils/copierutil"
+	"github.com/tx7do/go-utils/trans"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/customfield"
+)
+
+var (
+	customFieldTypeToProto = map[customfield.Type]contentV1.CustomFieldType{
+		customfield.TypeText:     contentV1.CustomFieldType_CUSTOM_FIELD_TYPE_TEXT,
+		customfield.TypeNumber:   contentV1.CustomFieldType_CUSTOM_FIELD_TYPE_NUMBER,
+		customfield.TypeBool:     contentV1.CustomFieldType_CUSTOM_FIELD_TYPE_BOOL,
+		customfield.TypeDate:     contentV1.CustomFieldType_CUSTOM_FIELD_TYPE_DATE,
+		customfield.TypeMedia:    contentV1.CustomFieldType_CUSTOM_FIELD_TYPE_MEDIA,
+		customfield.TypeContent:  contentV1.CustomFieldType_CUSTOM_FIELD_TYPE_CONTENT,
+		customfield.TypeSelect:   contentV1.CustomFieldType_CUSTOM_FIELD_TYPE_SELECT,
+		customfield.TypeRepeater: contentV1.CustomFieldType_CUSTOM_FIELD_TYPE_REPEATER,
+	}
+	customFieldTypeFromProto = func() map[contentV1.CustomFieldType]customfield.Type {
+		m := make(map[contentV1.CustomFieldType]customfield.Type, len(customFieldTypeToProto))
+		for k, v := range customFieldTypeToProto {
+			m[v] = k
+		}
+		return m
+	}()
+)
+
+// newCustomFieldValuesConverterPair 帖子/页面 field_values 列与 API 类型化字段值互转
+func newCustomFieldValuesConverterPair() []copier.TypeConverter {
+	return copierutil.NewGenericTypeConverterPair(
+		map[string]*customfield.Value{}, map[string]*contentV1.CustomFieldValue{},
+		customFieldValuesToProto, customFieldValuesFromProto,
+	)
+}
+
+// newCustomFieldDefinitionsConverterPair 字段组 fields 列与 API 字段定义互转
+func newCustomFieldDefinitionsConverterPair() []copier.TypeConverter {
+	return copierutil.NewGenericTypeConverterPair(
+		[]customfield.Definition{}, []*contentV1.CustomFieldDefinition{},
+		customFieldDefinitionsToProto, customFieldDefinitionsFromProto,
+	)
+}
+
+func customFieldValuesToProto(values map[string]*customfield.Value) map[string]*contentV1.CustomFieldValue {
+	if values == nil {
+		return nil
+	}
+	out := make(map[string]*contentV1.CustomFieldValue, len(values))
+	for key, v := range values {
+		if pv := customFieldValueToProto(v); pv != nil {
+			out[key] = pv
+		}
+	}
+	return out
+}
+
+func customFieldValueToProto(v *customfield.Value) *contentV1.CustomFieldValue {
+	switch v.Type() {
+	case customfield.TypeText:
+		return &contentV1.CustomFieldValue{Kind: &contentV1.CustomFieldValue_TextValue{TextValue: *v.Text}}
+	case customfield.TypeNumber:
+		return &contentV1.CustomFieldValue{Kind: &contentV1.CustomFieldValue_NumberValue{NumberValue: *v.Number}}
+	case customfield.TypeBool:
+		return &contentV1.CustomFieldValue{Kind: &contentV1.CustomFieldValue_BoolValue{BoolValue: *v.Bool}}
+	case customfield.TypeDate:
+		return &contentV1.CustomFieldValue{Kind: &contentV1.CustomFieldValue_DateValue{DateValue: timestamppb.New(*v.Date)}}
+	case customfield.TypeMedia:
+		return &contentV1.CustomFieldValue{Kind: &contentV1.CustomFieldValue_MediaId{MediaId: *v.Media}}
+	case customfield.TypeContent:
+		return &contentV1.CustomFieldValue{Kind: &contentV1.CustomFieldValue_ContentRef{ContentRef: &contentV1.ContentReference{
+			ContentType: contentV1.ContentType(contentV1.ContentType_value[v.Content.Type]),
+			Id:          v.Content.ID,
+		}}}
+	case customfield.TypeSelect:
+		return &contentV1.CustomFieldValue{Kind: &contentV1.CustomFieldValue_SelectValue{SelectValue: &contentV1.CustomFieldSelectValue{
+			Values: v.Select,
+		}}}
+	case customfield.TypeRepeater:
+		rows := make([]*contentV1.CustomFieldRow, 0, len(v.Rows))
+		for _, row := range v.Rows {
+			rows = append(rows, &contentV1.CustomFieldRow{Fields: customFieldValuesToProto(row)})
+		}
+		return &contentV1.CustomFieldValue{Kind: &contentV1.CustomFieldValue_RepeaterValue{RepeaterValue: &contentV1.CustomFieldRepeaterValue{
+			Rows: rows,
+		}}}
+	default:
+		return nil
+	}
+}
+
+func customFieldValuesFromProto(values map[string]*contentV1.CustomFieldValue) map[string]*customfield.Value {
+	if values == nil {
+		return nil
+	}
+	out := make(map[string]*customfield.Value, len(values))
+	for key, v := range values {
+		out[key] = customFieldValueFromProto(v)
+	}
+	return out
+}
+
+func customFieldValueFromProto(v *contentV1.CustomFieldValue) *customfield.Value {
+	switch kind := v.GetKind().(type) {
+	case *contentV1.CustomFieldValue_TextValue:
+		return &customfield.Value{Text: trans.Ptr(kind.TextValue)}
+	case *contentV1.CustomFieldValue_NumberValue:
+		return &customfield.Value{Number: trans.Ptr(kind.NumberValue)}
+	case *contentV1.CustomFieldValue_BoolValue:
+		return &customfield.Value{Bool: trans.Ptr(kind.BoolValue)}
+	case *contentV1.CustomFieldValue_DateValue:
+		if kind.DateValue == nil {
+			return &customfield.Value{}
+		}
+		return &customfield.Value{Date: trans.Ptr(kind.DateValue.AsTime())}
+	case *contentV1.CustomFieldValue_MediaId:
+		return &customfield.Value{Media: trans.Ptr(kind.MediaId)}
+	case *contentV1.CustomFieldValue_ContentRef:
+		return &customfield.Value{Content: &customfield.ContentRef{
+			Type: kind.ContentRef.GetContentType().String(),
+			ID:   kind.ContentRef.GetId(),
+		}}
+	case *contentV1.CustomFieldValue_SelectValue:
+		// 空选择同样保留为 select 值，交由校验判断是否满足必填
+		return &customfield.Value{Select: append([]string{}, kind.SelectValue.GetValues()...)}
+	case *contentV1.CustomFieldValue_RepeaterValue:
+		rows := make([]map[string]*customfield.Value, 0, len(kind.RepeaterValue.GetRows()))
+		for _, row := range kind.RepeaterValue.GetRows() {
+			fields := customFieldValuesFromProto(row.GetFields())
+			if fields == nil {
+				fields = map[string]*customfield.Value{}
+			}
+			rows = append(rows, fields)
+		}
+		return &customfield.Value{Rows: rows}
+	default:
+		return &customfield.Value{}
+	}
+}
+
+func customFieldDefinitionsToProto(defs []customfield.Definition) []*contentV1.CustomFieldDefinition {
+	if defs == nil {
+		return nil
+	}
+	out := make([]*contentV1.CustomFieldDefinition, 0, len(defs))
+	for _, def := range defs {
+		pd := &contentV1.CustomFieldDefinition{
+			Key:      def.Key,
+			Type:     customFieldTypeToProto[def.Type],
+			Required: def.Required,
+			Min:      def.Min,
+			Max:      def.Max,
+			Options:  def.Options,
+			Multiple: def.Multiple,
+			Fields:   customFieldDefinitionsToProto(def.Fields),
+		}
+		if def.Label != "" {
+			pd.Label = trans.Ptr(def.Label)
+		}
+		if def.Description != "" {
+			pd.Description = trans.Ptr(def.Description)
+		}
+		if def.MaxLength > 0 {
+			pd.MaxLength = trans.Ptr(def.MaxLength)
+		}
+		if def.MaxItems > 0 {
+			pd.MaxItems = trans.Ptr(def.MaxItems)
+		}
+		for _, ct := range def.ContentTypes {
+			pd.ContentTypes = append(pd.ContentTypes, contentV1.ContentType(contentV1.ContentType_value[ct]))
+		}
+		out = append(out, pd)
+	}
+	return out
+}
+
+func customFieldDefinitionsFromProto(defs []*contentV1.CustomFieldDefinition) []customfield.Definition {
+	if defs == nil {
+		return nil
+	}
+	out := make([]customfield.Definition, 0, len(defs))
+	for _, pd := range defs {
+		def := customfield.Definition{
+			Key:         strings.TrimSpace(pd.GetKey()),
+			Label:       pd.GetLabel(),
+			Description: pd.GetDescription(),
+			Type:        customFieldTypeFromProto[pd.GetType()],
+			Required:    pd.GetRequired(),
+			MaxLength:   pd.GetMaxLength(),
+			Min:         pd.Min,
+			Max:         pd.Max,
+			Options:     pd.GetOptions(),
+			Multiple:    pd.GetMultiple(),
+			MaxItems:    pd.GetMaxItems(),
+			Fields:      customFieldDefinitionsFromProto(pd.GetFields()),
+		}
+		for _, ct := range pd.GetContentTypes() {
+			def.ContentTypes = append(def.ContentTypes, ct.String())
+		}
+		out = append(out, def)
+	}
+	return out
+}
+
+// validateCustomFieldValues 按字段定义校验 API 传入的字段值，返回可直接落库的值
+func validateCustomFieldValues(defs []customfield.Definition, values map[string]*contentV1.CustomFieldValue) (map[string]*customfield.Value, error) {
+	converted := customFieldValuesFromProto(values)
+	if converted == nil {
+		converted = map[string]*customfield.Value{}
+	}
+	if err := customfield.Validate(defs, converted); err != nil {
+		return nil, contentV1.ErrorBadRequest("invalid custom field %s", err.Error())
+	}
+	return converted, nil
+}
+
+// customFieldQuery 列表中按自定义字段过滤与排序的条件
+type customFieldQuery struct {
+	conditions []*paginationV1.FilterCondition
+	sorting    []*paginationV1.Sorting
+}
+
+func (q *customFieldQuery) empty() bool {
+	return q == nil || (len(q.conditions) == 0 && len(q.sorting) == 0)
+}
+
+// takeCustomFieldQuery 从分页请求中取出 "field_values.<key>" 的过滤条件与排序规则，
+// 其余条件原样留给通用分页查询。自定义字段条件只能位于顶层 AND 表达式中。
+func takeCustomFieldQuery(req *paginationV1.PagingRequest) (*customFieldQuery, error) {
+	query := &customFieldQuery{}
+
+	filterExpr, err := paginationFilter.ConvertFilterByPagingRequest(req)
+	if err != nil {
+		return nil, contentV1.ErrorBadRequest("invalid filter")
+	}
+	if filterExpr != nil {
+		kept := make([]*paginationV1.FilterCondition, 0, len(filterExpr.GetConditions()))
+		for _, cond := range filterExpr.GetConditions() {
+			if strings.HasPrefix(cond.GetField(), customfield.FieldPrefix) {
+				query.conditions = append(query.conditions, cond)
+				continue
+			}
+			kept = append(kept, cond)
+		}
+		if len(query.conditions) > 0 && filterExpr.GetType() == paginationV1.ExprType_OR {
+			return nil, contentV1.ErrorBadRequest("custom field filters only support AND expressions")
+		}
+		for _, group := range filterExpr.GetGroups() {
+			if hasCustomFieldCondition(group) {
+				return nil, contentV1.ErrorBadRequest("custom field filters only support top-level conditions")
+			}
+		}
+		filterExpr.Conditions = kept
+		req.FilteringType = &paginationV1.PagingRequest_FilterExpr{FilterExpr: filterExpr}
+	}
+
+	kept := make([]*paginationV1.Sorting, 0, len(req.GetSorting()))
+	for _, sorting := range req.GetSorting() {
+		if strings.HasPrefix(sorting.GetField(), customfield.FieldPrefix) {
+			query.sorting = append(query.sorting, sorting)
+			continue
+		}
+		kept = append(kept, sorting)
+	}
+	req.Sorting = kept
+
+	return query, nil
+}
+
+func hasCustomFieldCondition(expr *paginationV1.FilterExpr) bool {
+	for _, cond := range expr.GetConditions() {
+		if strings.HasPrefix(cond.GetField(), customfield.FieldPrefix) {
+			return true
+		}
+	}
+	for _, group := range expr.GetGroups() {
+		if hasCustomFieldCondition(group) {
+			return true
+		}
+	}
+	return false
+}
+
+// build 按字段定义把条件转换为 JSON 列上的查询，column 为存储字段值的 JSON 列名
+func (q *customFieldQuery) build(column string, defs []customfield.Definition) (wheres, orders []func(*sql.Selector), err error) {
+	byKey := make(map[string]customfield.Definition, len(defs))
+	for _, def := range defs {
+		byKey[def.Key] = def
+	}
+	lookup := func(field string) (customfield.Definition, error) {
+		key, ok := customfield.ParseFieldName(field)
+		if !ok {
+			return customfield.Definition{}, contentV1.ErrorBadRequest("invalid custom field %s", field)
+		}
+		def, ok := byKey[key]
+		if !ok {
+			return customfield.Definition{}, contentV1.ErrorBadRequest("custom field %s is not defined", key)
+		}
+		return def, nil
+	}
+
+	for _, cond := range q.conditions {
+		def, err := lookup(cond.GetField())
+		if err != nil {
+			return nil, nil, err
+		}
+		where, err := customFieldPredicate(column, def, cond)
+		if err != nil {
+			return nil, nil, err
+		}
+		wheres = append(wheres, where)
+	}
+
+	for _, sorting := range q.sorting {
+		def, err := lookup(sorting.GetField())
+		if err != nil {
+			return nil, nil, err
+		}
+		if !customfield.Sortable(def) {
+			return nil, nil, contentV1.ErrorBadRequest("custom field %s is not sortable", def.Key)
+		}
+		path, _ := customfield.ValuePath(def)
+		desc := sorting.GetDirection() == paginationV1.Sorting_DESC
+		orders = append(orders, func(s *sql.Selector) {
+			if desc {
+				sqljson.OrderValueDesc(s.C(column), sqljson.DotPath(path))(s)
+			} else {
+				sqljson.OrderValue(s.C(column), sqljson.DotPath(path))(s)
+			}
+		})
+	}
+
+	return wheres, orders, nil
+}
+
+func customFieldPredicate(column string, def customfield.Definition, cond *paginationV1.FilterCondition) (func(*sql.Selector), error) {
+	path, ok := customfield.ValuePath(def)
+	if !ok {
+		return nil, contentV1.ErrorBadRequest("custom field %s is not filterable", def.Key)
+	}
+
+	op := cond.GetOp()
+	if op == paginationV1.Operator_IS_NULL || op == paginationV1.Operator_IS_NOT_NULL {
+		return func(s *sql.Selector) {
+			p := sqljson.HasKey(s.C(column), sqljson.DotPath(def.Key))
+			if op == paginationV1.Operator_IS_NULL {
+				p = sql.Not(p)
+			}
+			s.Where(p)
+		}, nil
+	}
+
+	raws := cond.GetValues()
+	if _, ok := cond.GetValueOneof().(*paginationV1.FilterCondition_Value); ok {
+		raws = append([]string{cond.GetValue()}, raws...)
+	}
+	if len(raws) == 0 {
+		return nil, contentV1.ErrorBadRequest("custom field %s filter requires a value", def.Key)
+	}
+	args := make([]any, 0, len(raws))
+	for _, raw := range raws {
+		arg, err := customfield.ParseFilterValue(def, raw)
+		if err != nil {
+			return nil, contentV1.ErrorBadRequest("invalid custom field filter %s", err.Error())
+		}
+		args = append(args, arg)
+	}
+
+	ordered := def.Type == customfield.TypeNumber || def.Type == customfield.TypeDate ||
+		def.Type == customfield.TypeText || def.Type == customfield.TypeMedia
+	multi := def.Type == customfield.TypeSelect
+
+	var build func(col string) *sql.Predicate
+	switch {
+	case (op == paginationV1.Operator_EQ || op == paginationV1.Operator_EXACT) && multi:
+		build = func(col string) *sql.Predicate { return sqljson.ValueContains(col, args[0], sqljson.DotPath(path)) }
+	case op == paginationV1.Operator_EQ || op == paginationV1.Operator_EXACT:
+		build = func(col string) *sql.Predicate { return sqljson.ValueEQ(col, args[0], sqljson.DotPath(path)) }
+	case op == paginationV1.Operator_NEQ && multi:
+		build = func(col string) *sql.Predicate {
+			return sql.Not(sqljson.ValueContains(col, args[0], sqljson.DotPath(path)))
+		}
+	case op == paginationV1.Operator_NEQ:
+		build = func(col string) *sql.Predicate { return sqljson.ValueNEQ(col, args[0], sqljson.DotPath(path)) }
+	case op == paginationV1.Operator_GT && ordered:
+		build = func(col string) *sql.Predicate { return sqljson.ValueGT(col, args[0], sqljson.DotPath(path)) }
+	case op == paginationV1.Operator_GTE && ordered:
+		build = func(col string) *sql.Predicate { return sqljson.ValueGTE(col, args[0], sqljson.DotPath(path)) }
+	case op == paginationV1.Operator_LT && ordered:
+		build = func(col string) *sql.Predicate { return sqljson.ValueLT(col, args[0], sqljson.DotPath(path)) }
+	case op == paginationV1.Operator_LTE && ordered:
+		build = func(col string) *sql.Predicate { return sqljson.ValueLTE(col, args[0], sqljson.DotPath(path)) }
+	case op == paginationV1.Operator_BETWEEN && ordered && len(args) == 2:
+		build = func(col string) *sql.Predicate {
+			return sql.And(
+				sqljson.ValueGTE(col, args[0], sqljson.DotPath(path)),
+				sqljson.ValueLTE(col, args[1], sqljson.DotPath(path)),
+			)
+		}
+	case op == paginationV1.Operator_IN && multi:
+		build = func(col string) *sql.Predicate {
+			ps := make([]*sql.Predicate, 0, len(args))
+			for _, arg := range args {
+				ps = append(ps, sqljson.ValueContains(col, arg, sqljson.DotPath(path)))
+			}
+			return sql.Or(ps...)
+		}
+	case op == paginationV1.Operator_IN:
+		build = func(col string) *sql.Predicate { return sqljson.ValueIn(col, args, sqljson.DotPath(path)) }
+	case op == paginationV1.Operator_NIN && !multi:
+		build = func(col string) *sql.Predicate { return sqljson.ValueNotIn(col, args, sqljson.DotPath(path)) }
+	case op == paginationV1.Operator_CONTAINS && multi:
+		build = func(col string) *sql.Predicate { return sqljson.ValueContains(col, args[0], sqljson.DotPath(path)) }
+	case op == paginationV1.Operator_CONTAINS && def.Type == customfield.TypeText:
+		build = func(col string) *sql.Predicate {
+			return sqljson.StringContains(col, args[0].(string), sqljson.DotPath(path))
+		}
+	case op == paginationV1.Operator_STARTS_WITH && def.Type == customfield.TypeText:
+		build = func(col string) *sql.Predicate {
+			return sqljson.StringHasPrefix(col, args[0].(string), sqljson.DotPath(path))
+		}
+	default:
+		return nil, contentV1.ErrorBadRequest("operator %s is not supported on custom field %s", op.String(), def.Key)
+	}
+
+	return func(s *sql.Selector) {
+		s.Where(build(s.C(column)))
+	}, nil
+}
@@ -2,6 +2,8 @@ package data
 
 import (
 	"github.com/tx7do/go-utils/password"
+	"github.com/tx7do/go-utils/translator"
+	"github.com/tx7do/go-utils/translator/google"
 
 	_ "github.com/go-sql-driver/mysql"
 	_ "github.com/jackc/pgx/v5/stdlib"
@@ -15,3 +17,10 @@ func NewPasswordCrypto() password.Crypto {
 	}
 	return crypto
 }
+
+// NewTranslator 创建翻译引擎，由 MachineTranslator 叠加翻译记忆与术语表后使用
+func NewTranslator() translator.Translator {
+	return google.NewTranslator(
+		google.WithVersion("v1"),
+	)
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/tx7do/go-utils/copierutil"
 	"github.com/tx7do/go-utils/mapper"
+	"github.com/tx7do/go-utils/trans"
 
 	"go-wind-cms/app/core/service/internal/data/ent"
 	"go-wind-cms/app/core/service/internal/data/ent/dictentry"
@@ -39,19 +40,23 @@ type DictEntryRepo struct {
 		dictV1.DictEntry, ent.DictEntry,
 	]
 
-	i18n *DictEntryI18nRepo
+	i18n           *DictEntryI18nRepo
+	localeResolver *LocaleResolver
 }
 
 func NewDictEntryRepo(
 	ctx *bootstrap.Context,
 	entClient *entCrud.EntClient[*ent.Client],
 	i18n *DictEntryI18nRepo,
+	localeResolver *LocaleResolver,
 ) *DictEntryRepo {
 	repo := &DictEntryRepo{
 		log:       ctx.NewLoggerHelper("dict-entry/repo/admin-service"),
 		entClient: entClient,
 		mapper:    mapper.NewCopierMapper[dictV1.DictEntry, ent.DictEntry](),
 		i18n:      i18n,
+
+		localeResolver: localeResolver,
 	}
 
 	repo.init()
@@ -465,15 +470,30 @@ func (r *DictEntryRepo) ListByTypeCode(ctx context.Context, req *dictV1.ListDict
 	}
 
 	if req.GetLocal() != "" {
-		var i18n *dictV1.DictEntryI18N
+		// 按回退链逐项选出实际返回的语言，均不可用时不附带多语言数据
+		chain := r.localeResolver.Chain(ctx, req.GetLocal())
+
+		var i18ns map[string]*dictV1.DictEntryI18N
 		for _, item := range dtos {
-			i18n, err = r.i18n.GetByEntryIDAndLangCode(ctx, item.GetId(), req.GetLocal())
+			i18ns, err = r.i18n.ListByEntryID(ctx, item.GetId())
 			if err != nil {
 				return nil, err
 			}
+
+			available := make([]string, 0, len(i18ns))
+			for code := range i18ns {
+				available = append(available, code)
+			}
+			served := pickLocale(chain, available)
+			if served == "" {
+				continue
+			}
+
 			item.I18N = map[string]*dictV1.DictEntryI18N{
-				req.GetLocal(): i18n,
+				served: i18ns[served],
 			}
+			item.CurrentI18N = i18ns[served]
+			item.ServedLocale = trans.Ptr(served)
 		}
 	} else {
 		var i18ns map[string]*dictV1.DictEntryI18N
@@ -0,0 +1,364 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/tx7do/go-utils/copierutil"
+	"github.com/tx7do/go-utils/mapper"
+	"github.com/tx7do/go-utils/timeutil"
+	"github.com/tx7do/go-utils/trans"
+
+	entCrud "github.com/tx7do/go-crud/entgo"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/post"
+	"go-wind-cms/app/core/service/internal/data/ent/postreviewlog"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/workflow"
+)
+
+const (
+	defaultReviewQueuePageSize = 20
+	maxReviewQueuePageSize     = 100
+)
+
+// PostWorkflow 文章及其所处的工作流与阶段
+type PostWorkflow struct {
+	Post       *ent.Post
+	Workflow   *ent.Workflow
+	Definition *workflow.Definition
+	Stage      *workflow.Stage
+}
+
+// EditorialRepo 文章的工作流流转、审阅人指派与审阅日志
+type EditorialRepo struct {
+	entClient *entCrud.EntClient[*ent.Client]
+	log       *log.Helper
+
+	logMapper       *mapper.CopierMapper[contentV1.PostReviewLog, ent.PostReviewLog]
+	actionConverter *mapper.EnumTypeConverter[contentV1.PostReviewLog_Action, postreviewlog.Action]
+}
+
+func NewEditorialRepo(ctx *bootstrap.Context, entClient *entCrud.EntClient[*ent.Client]) *EditorialRepo {
+	repo := &EditorialRepo{
+		entClient: entClient,
+		log:       ctx.NewLoggerHelper("editorial/repo/core-service"),
+		logMapper: mapper.NewCopierMapper[contentV1.PostReviewLog, ent.PostReviewLog](),
+		actionConverter: mapper.NewEnumTypeConverter[contentV1.PostReviewLog_Action, postreviewlog.Action](
+			contentV1.PostReviewLog_Action_name, contentV1.PostReviewLog_Action_value,
+		),
+	}
+
+	repo.init()
+
+	return repo
+}
+
+func (r *EditorialRepo) init() {
+	r.logMapper.AppendConverters(copierutil.NewTimeStringConverterPair())
+	r.logMapper.AppendConverters(copierutil.NewTimeTimestamppbConverterPair())
+
+	r.logMapper.AppendConverters(r.actionConverter.NewConverterPair())
+}
+
+// Load 加载文章的工作流上下文（按 viewer 租户隔离），文章不受工作流约束时返回 BadRequest
+func (r *EditorialRepo) Load(ctx context.Context, postID uint32) (*PostWorkflow, error) {
+	builder := r.entClient.Client().Post.Query().
+		Where(post.IDEQ(postID), post.DeletedAtIsNil())
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(post.TenantIDEQ(tid))
+	}
+
+	p, err := builder.Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound("post not found")
+		}
+		r.log.Errorf("query post failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query post failed")
+	}
+	if p.WorkflowID == nil || p.WorkflowStage == nil {
+		return nil, contentV1.ErrorBadRequest("post is not under an editorial workflow")
+	}
+
+	w, err := r.entClient.Client().Workflow.Get(ctx, *p.WorkflowID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound("workflow not found")
+		}
+		r.log.Errorf("query workflow failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query workflow failed")
+	}
+
+	def := workflowDefinition(w)
+	stage, ok := def.Stage(*p.WorkflowStage)
+	if !ok {
+		return nil, contentV1.ErrorConflict("post is in unknown workflow stage %s", *p.WorkflowStage)
+	}
+
+	return &PostWorkflow{Post: p, Workflow: w, Definition: def, Stage: stage}, nil
+}
+
+// State 文章的工作流状态，available 为调用者可执行的流转
+func (r *EditorialRepo) State(pw *PostWorkflow, available []workflow.Transition) *contentV1.PostWorkflowState {
+	return &contentV1.PostWorkflowState{
+		PostId:               pw.Post.ID,
+		WorkflowId:           pw.Workflow.ID,
+		WorkflowName:         pw.Workflow.Name,
+		Stage:                workflowStageToProto(pw.Stage),
+		ReviewerIds:          pw.Post.ReviewerIds,
+		AvailableTransitions: workflowTransitionsToProto(available),
+	}
+}
+
+func (r *EditorialRepo) insertLog(tx *ent.Tx, pw *PostWorkflow, action postreviewlog.Action, operatorID uint32) *ent.PostReviewLogCreate {
+	builder := tx.PostReviewLog.Create().
+		SetPostID(pw.Post.ID).
+		SetWorkflowID(pw.Workflow.ID).
+		SetAction(action).
+		SetCreatedAt(time.Now())
+	if operatorID != 0 {
+		builder.SetOperatorID(operatorID)
+	}
+	if pw.Post.TenantID != nil {
+		builder.SetTenantID(*pw.Post.TenantID)
+	}
+	return builder
+}
+
+// withTx 在事务中执行 fn
+func (r *EditorialRepo) withTx(ctx context.Context, fn func(tx *ent.Tx) error) (err error) {
+	var tx *ent.Tx
+	tx, err = r.entClient.Client().Tx(ctx)
+	if err != nil {
+		r.log.Errorf("start transaction failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("start transaction failed")
+	}
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				r.log.Errorf("transaction rollback failed: %s", rollbackErr.Error())
+			}
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			r.log.Errorf("transaction commit failed: %s", commitErr.Error())
+			err = contentV1.ErrorInternalServerError("transaction commit failed")
+		}
+	}()
+
+	return fn(tx)
+}
+
+// Transition 将文章流转到目标阶段并写入审阅日志。
+//
+// 进入发布阶段时文章置为已发布（首次发布补齐发布时间），离开发布阶段时退回草稿；
+// 处于审阅阶段时 in_progress 为 true。以当前阶段为条件更新，并发流转时后到者返回 Conflict。
+func (r *EditorialRepo) Transition(ctx context.Context, pw *PostWorkflow, to *workflow.Stage, comment string, operatorID uint32) error {
+	return r.withTx(ctx, func(tx *ent.Tx) error {
+		now := time.Now()
+		builder := tx.Post.Update().
+			Where(
+				post.IDEQ(pw.Post.ID),
+				post.WorkflowStageEQ(pw.Stage.Code),
+			).
+			SetWorkflowStage(to.Code).
+			SetInProgress(to.Review).
+			SetUpdatedAt(now)
+		switch {
+		case to.Publish:
+			builder.SetStatus(post.StatusPostStatusPublished)
+			if pw.Post.PublishTime == nil {
+				builder.SetPublishTime(now)
+			}
+		case pw.Stage.Publish:
+			builder.SetStatus(post.StatusPostStatusDraft)
+		}
+		if operatorID != 0 {
+			builder.SetUpdatedBy(operatorID)
+		}
+
+		affected, err := builder.Save(ctx)
+		if err != nil {
+			r.log.Errorf("update post workflow stage failed: %s", err.Error())
+			return contentV1.ErrorInternalServerError("update post workflow stage failed")
+		}
+		if affected == 0 {
+			return contentV1.ErrorConflict("post workflow stage has changed, please reload")
+		}
+
+		logBuilder := r.insertLog(tx, pw, postreviewlog.ActionActionTransition, operatorID).
+			SetFromStage(pw.Stage.Code).
+			SetToStage(to.Code)
+		if comment != "" {
+			logBuilder.SetComment(comment)
+		}
+		if _, err = logBuilder.Save(ctx); err != nil {
+			r.log.Errorf("insert post review log failed: %s", err.Error())
+			return contentV1.ErrorInternalServerError("insert post review log failed")
+		}
+
+		pw.Stage = to
+		return nil
+	})
+}
+
+// AssignReviewers 整体替换文章的审阅人并写入审阅日志
+func (r *EditorialRepo) AssignReviewers(ctx context.Context, pw *PostWorkflow, reviewerIDs []uint32, operatorID uint32) error {
+	return r.withTx(ctx, func(tx *ent.Tx) error {
+		builder := tx.Post.UpdateOneID(pw.Post.ID).
+			SetReviewerIds(reviewerIDs).
+			SetUpdatedAt(time.Now())
+		if operatorID != 0 {
+			builder.SetUpdatedBy(operatorID)
+		}
+		if err := builder.Exec(ctx); err != nil {
+			r.log.Errorf("update post reviewers failed: %s", err.Error())
+			return contentV1.ErrorInternalServerError("update post reviewers failed")
+		}
+
+		if _, err := r.insertLog(tx, pw, postreviewlog.ActionActionAssign, operatorID).
+			SetReviewerIds(reviewerIDs).
+			Save(ctx); err != nil {
+			r.log.Errorf("insert post review log failed: %s", err.Error())
+			return contentV1.ErrorInternalServerError("insert post review log failed")
+		}
+
+		pw.Post.ReviewerIds = reviewerIDs
+		return nil
+	})
+}
+
+// AddComment 写入一条审阅意见
+func (r *EditorialRepo) AddComment(ctx context.Context, pw *PostWorkflow, comment string, operatorID uint32) (*contentV1.PostReviewLog, error) {
+	var entity *ent.PostReviewLog
+	err := r.withTx(ctx, func(tx *ent.Tx) error {
+		var err error
+		entity, err = r.insertLog(tx, pw, postreviewlog.ActionActionComment, operatorID).
+			SetToStage(pw.Stage.Code).
+			SetComment(comment).
+			Save(ctx)
+		if err != nil {
+			r.log.Errorf("insert post review log failed: %s", err.Error())
+			return contentV1.ErrorInternalServerError("insert post review log failed")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.logMapper.ToDTO(entity), nil
+}
+
+// ListLogs 按时间顺序返回文章的审阅日志
+func (r *EditorialRepo) ListLogs(ctx context.Context, postID uint32) (*contentV1.ListPostReviewLogResponse, error) {
+	builder := r.entClient.Client().PostReviewLog.Query().
+		Where(postreviewlog.PostIDEQ(postID))
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(postreviewlog.TenantIDEQ(tid))
+	}
+
+	entities, err := builder.
+		Order(ent.Asc(postreviewlog.FieldCreatedAt), ent.Asc(postreviewlog.FieldID)).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query post review logs failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query post review logs failed")
+	}
+
+	items := make([]*contentV1.PostReviewLog, 0, len(entities))
+	for _, e := range entities {
+		items = append(items, r.logMapper.ToDTO(e))
+	}
+
+	return &contentV1.ListPostReviewLogResponse{
+		Items: items,
+		Total: uint64(len(items)),
+	}, nil
+}
+
+// ReviewQueue 返回处于审阅阶段、且指派给 userID 的文章。
+// 各工作流的审阅阶段不同，按 (workflow_id, workflow_stage) 组合过滤。
+func (r *EditorialRepo) ReviewQueue(ctx context.Context, tenantID, userID uint32, workflows []*ent.Workflow, page, pageSize int) (*contentV1.ListReviewQueueResponse, error) {
+	stageCond := func(s *sql.Selector) {
+		var stagePreds []*sql.Predicate
+		for _, w := range workflows {
+			var codes []any
+			for _, stage := range w.Stages {
+				if stage.Review {
+					codes = append(codes, stage.Code)
+				}
+			}
+			if len(codes) == 0 {
+				continue
+			}
+			stagePreds = append(stagePreds, sql.And(
+				sql.EQ(s.C(post.FieldWorkflowID), w.ID),
+				sql.In(s.C(post.FieldWorkflowStage), codes...),
+			))
+		}
+		if len(stagePreds) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.Or(stagePreds...))
+	}
+
+	if pageSize <= 0 {
+		pageSize = defaultReviewQueuePageSize
+	}
+	pageSize = min(pageSize, maxReviewQueuePageSize)
+	page = max(page, 0)
+
+	builder := r.entClient.Client().Post.Query().
+		Where(
+			post.TenantIDEQ(tenantID),
+			post.DeletedAtIsNil(),
+			stageCond,
+			func(s *sql.Selector) {
+				s.Where(sqljson.ValueContains(s.C(post.FieldReviewerIds), userID))
+			},
+		)
+
+	total, err := builder.Clone().Count(ctx)
+	if err != nil {
+		r.log.Errorf("count review queue failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query review queue failed")
+	}
+
+	entities, err := builder.
+		Order(ent.Asc(post.FieldUpdatedAt), ent.Asc(post.FieldID)).
+		Offset(page * pageSize).
+		Limit(pageSize).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query review queue failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query review queue failed")
+	}
+
+	items := make([]*contentV1.ReviewQueueItem, 0, len(entities))
+	for _, p := range entities {
+		items = append(items, &contentV1.ReviewQueueItem{
+			PostId:     p.ID,
+			WorkflowId: trans.Uint32Value(p.WorkflowID),
+			Stage:      trans.StringValue(p.WorkflowStage),
+			AuthorId:   p.AuthorID,
+			AuthorName: p.AuthorName,
+			UpdatedAt:  timeutil.TimeToTimestamppb(p.UpdatedAt),
+		})
+	}
+
+	return &contentV1.ListReviewQueueResponse{
+		Items: items,
+		Total: uint64(total),
+	}, nil
+}
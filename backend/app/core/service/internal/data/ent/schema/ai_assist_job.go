@@ -0,0 +1,135 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/tx7do/go-crud/entgo/mixin"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+)
+
+// AiAssistJob holds the schema definition for the AiAssistJob entity.
+//
+// AI 内容辅助作业：为文章或页面的一个语言版本生成摘要、SEO 描述、推荐标签与备选标题，
+// 由 ai.assist 异步任务执行，记录各任务结果与 Token 用量。
+type AiAssistJob struct {
+	ent.Schema
+}
+
+func (AiAssistJob) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{
+			Table:     "ai_assist_jobs",
+			Charset:   "utf8mb4",
+			Collation: "utf8mb4_bin",
+		},
+		entsql.WithComments(true),
+		schema.Comment("AI内容辅助作业表"),
+	}
+}
+
+// Fields of the AiAssistJob.
+func (AiAssistJob) Fields() []ent.Field {
+	return []ent.Field{
+		field.Enum("entity_type").
+			Comment("内容类型").
+			NamedValues(
+				"EntityTypePost", "ENTITY_TYPE_POST",
+				"EntityTypePage", "ENTITY_TYPE_PAGE",
+			).
+			Immutable(),
+
+		field.Uint32("entity_id").
+			Comment("内容ID").
+			Immutable(),
+
+		field.String("language_code").
+			Comment("语言代码").
+			MaxLen(32).
+			Immutable(),
+
+		field.JSON("tasks", []contentV1.AiAssistTask{}).
+			Comment("任务").
+			Immutable(),
+
+		field.Bool("apply").
+			Comment("是否把摘要与 SEO 描述直接应用到内容").
+			Default(false).
+			Immutable(),
+
+		field.Uint32("base_revision").
+			Comment("创建作业时该语言版本的修订号；执行时修订号已变化则结果仅作为建议").
+			Default(0).
+			Immutable(),
+
+		field.Enum("status").
+			Comment("状态").
+			NamedValues(
+				"AiAssistJobStatusPending", "AI_ASSIST_JOB_STATUS_PENDING",
+				"AiAssistJobStatusRunning", "AI_ASSIST_JOB_STATUS_RUNNING",
+				"AiAssistJobStatusCompleted", "AI_ASSIST_JOB_STATUS_COMPLETED",
+				"AiAssistJobStatusFailed", "AI_ASSIST_JOB_STATUS_FAILED",
+			).
+			Default("AI_ASSIST_JOB_STATUS_PENDING"),
+
+		field.JSON("results", []*contentV1.AiAssistResult{}).
+			Comment("各任务的结果").
+			Optional(),
+
+		field.String("provider").
+			Comment("供应商类型").
+			MaxLen(32).
+			Optional().
+			Nillable(),
+
+		field.String("model").
+			Comment("实际使用的模型").
+			MaxLen(128).
+			Optional().
+			Nillable(),
+
+		field.Uint32("prompt_tokens").
+			Comment("提示词 Token 数").
+			Default(0),
+
+		field.Uint32("completion_tokens").
+			Comment("生成 Token 数").
+			Default(0),
+
+		field.Time("started_at").
+			Comment("开始执行时间").
+			Optional().
+			Nillable(),
+
+		field.Time("finished_at").
+			Comment("结束时间").
+			Optional().
+			Nillable(),
+
+		field.String("last_error").
+			Comment("作业失败的原因").
+			MaxLen(1024).
+			Optional().
+			Nillable(),
+	}
+}
+
+// Mixin of the AiAssistJob.
+func (AiAssistJob) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixin.AutoIncrementId{},
+		mixin.TimeAt{},
+		mixin.OperatorID{},
+		mixin.TenantID[uint32]{},
+	}
+}
+
+func (AiAssistJob) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("tenant_id", "entity_type", "entity_id"),
+		index.Fields("tenant_id", "status"),
+	}
+}
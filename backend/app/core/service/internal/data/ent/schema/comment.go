@@ -35,6 +35,7 @@ func (Comment) Fields() []ent.Field {
 				"ContentTypePost", "CONTENT_TYPE_POST",
 				"ContentTypePage", "CONTENT_TYPE_PAGE",
 				"ContentTypeProduct", "CONTENT_TYPE_PRODUCT",
+				"ContentTypeEntry", "CONTENT_TYPE_ENTRY",
 			).
 			Optional().
 			Nillable(),
@@ -124,6 +125,11 @@ func (Comment) Fields() []ent.Field {
 			Optional().
 			Nillable(),
 
+		field.Float("spam_score").
+			Comment("垃圾分类器给出的垃圾概率").
+			Optional().
+			Nillable(),
+
 		field.Uint32("reply_to_id").
 			Comment("回复的评论ID").
 			Optional().
@@ -156,5 +162,7 @@ func (Comment) Indexes() []ent.Index {
 		index.Fields("is_spam"),
 		// 单字段索引，用于置顶评论查询
 		index.Fields("is_sticky"),
+		// 复合索引，用于审核队列按状态与垃圾概率筛选
+		index.Fields("tenant_id", "status", "spam_score"),
 	}
 }
@@ -0,0 +1,80 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/tx7do/go-crud/entgo/mixin"
+)
+
+// CommentAuthorRule holds the schema definition for the CommentAuthorRule entity.
+//
+// 评论作者规则：封禁（用户/IP 或网段）与信任（用户/用户记录邮箱）。评论创建时按租户匹配，
+// 封禁作者的评论拒绝保存，信任作者的评论只受限流约束，跳过其余自动审核直接通过。
+type CommentAuthorRule struct {
+	ent.Schema
+}
+
+func (CommentAuthorRule) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{
+			Table:     "comment_author_rules",
+			Charset:   "utf8mb4",
+			Collation: "utf8mb4_bin",
+		},
+		entsql.WithComments(true),
+		schema.Comment("评论作者规则表"),
+	}
+}
+
+// Fields of the CommentAuthorRule.
+func (CommentAuthorRule) Fields() []ent.Field {
+	return []ent.Field{
+		field.Enum("type").
+			Comment("规则类型").
+			NamedValues(
+				"TypeBanUser", "TYPE_BAN_USER",
+				"TypeBanIP", "TYPE_BAN_IP",
+				"TypeTrustUser", "TYPE_TRUST_USER",
+				"TypeTrustEmail", "TYPE_TRUST_EMAIL",
+			).
+			Optional().
+			Nillable(),
+
+		field.String("value").
+			Comment("匹配值：用户ID、IP 或邮箱").
+			NotEmpty().
+			Optional().
+			Nillable(),
+
+		field.String("reason").
+			Comment("原因说明").
+			Optional().
+			Nillable(),
+
+		field.Time("expired_at").
+			Comment("到期时间，NULL表示永久").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Mixin of the CommentAuthorRule.
+func (CommentAuthorRule) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixin.AutoIncrementId{},
+		mixin.TimeAt{},
+		mixin.OperatorID{},
+		mixin.TenantID[uint32]{},
+	}
+}
+
+func (CommentAuthorRule) Indexes() []ent.Index {
+	return []ent.Index{
+		// 同一租户下同类规则的匹配值唯一，也用于创建评论时的规则匹配
+		index.Fields("tenant_id", "type", "value").
+			Unique(),
+	}
+}
@@ -0,0 +1,120 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/tx7do/go-crud/entgo/mixin"
+
+	"go-wind-cms/pkg/content/customfield"
+	"go-wind-cms/pkg/content/entry"
+)
+
+// ContentEntry holds the schema definition for the ContentEntry entity.
+//
+// 自定义内容类型的条目：字段值、翻译与分类法绑定均以 JSON 存储。
+type ContentEntry struct {
+	ent.Schema
+}
+
+func (ContentEntry) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{
+			Table:     "content_entries",
+			Charset:   "utf8mb4",
+			Collation: "utf8mb4_bin",
+		},
+		entsql.WithComments(true),
+		schema.Comment("自定义内容条目表"),
+	}
+}
+
+// Fields of the ContentEntry.
+func (ContentEntry) Fields() []ent.Field {
+	return []ent.Field{
+		field.Uint32("model_id").
+			Comment("内容类型ID").
+			Immutable().
+			Optional().
+			Nillable(),
+
+		field.String("model_code").
+			Comment("内容类型编码").
+			MaxLen(64).
+			Immutable().
+			Optional().
+			Nillable(),
+
+		field.Enum("status").
+			Comment("状态").
+			NamedValues(
+				"ContentEntryStatusDraft", "CONTENT_ENTRY_STATUS_DRAFT",
+				"ContentEntryStatusPublished", "CONTENT_ENTRY_STATUS_PUBLISHED",
+				"ContentEntryStatusArchived", "CONTENT_ENTRY_STATUS_ARCHIVED",
+			).
+			Default("CONTENT_ENTRY_STATUS_DRAFT").
+			Optional().
+			Nillable(),
+
+		field.Uint32("author_id").
+			Comment("作者ID").
+			Optional().
+			Nillable(),
+
+		field.String("author_name").
+			Comment("作者名称").
+			Optional().
+			Nillable(),
+
+		field.JSON("field_values", map[string]*customfield.Value{}).
+			Comment("字段值（不含多语言字段）").
+			Optional(),
+
+		field.JSON("translations", map[string]*entry.Translation{}).
+			Comment("翻译，以语言代码为键").
+			Optional(),
+
+		field.JSON("languages", []string{}).
+			Comment("已有翻译的语言代码").
+			Optional(),
+
+		field.JSON("category_ids", []uint32{}).
+			Comment("分类ID列表").
+			Optional(),
+
+		field.JSON("tag_ids", []uint32{}).
+			Comment("标签ID列表").
+			Optional(),
+
+		field.Text("search_text").
+			Comment("检索文本，由标题、摘要与文本字段值汇总而成").
+			Optional().
+			Nillable(),
+
+		field.Time("publish_time").
+			Comment("发布时间").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Mixin of the ContentEntry.
+func (ContentEntry) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixin.AutoIncrementId{},
+		mixin.TimeAt{},
+		mixin.OperatorID{},
+		mixin.SortOrder{},
+		mixin.TenantID[uint32]{},
+	}
+}
+
+func (ContentEntry) Indexes() []ent.Index {
+	return []ent.Index{
+		// 按租户与内容类型列出条目
+		index.Fields("tenant_id", "model_id", "status"),
+		index.Fields("tenant_id", "model_code", "status"),
+	}
+}
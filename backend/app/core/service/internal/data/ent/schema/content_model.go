@@ -0,0 +1,94 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/tx7do/go-crud/entgo/mixin"
+
+	"go-wind-cms/pkg/content/customfield"
+)
+
+// ContentModel holds the schema definition for the ContentModel entity.
+//
+// 自定义内容类型：定义帖子/页面以外内容的类型化字段、多语言字段与可绑定的分类法。
+type ContentModel struct {
+	ent.Schema
+}
+
+func (ContentModel) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{
+			Table:     "content_models",
+			Charset:   "utf8mb4",
+			Collation: "utf8mb4_bin",
+		},
+		entsql.WithComments(true),
+		schema.Comment("自定义内容类型表"),
+	}
+}
+
+// Fields of the ContentModel.
+func (ContentModel) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("code").
+			Comment("编码，租户内唯一，创建后不可修改").
+			NotEmpty().
+			MaxLen(64).
+			Immutable().
+			Optional().
+			Nillable(),
+
+		field.String("name").
+			Comment("名称").
+			NotEmpty().
+			MaxLen(128).
+			Optional().
+			Nillable(),
+
+		field.String("description").
+			Comment("描述").
+			MaxLen(1024).
+			Optional().
+			Nillable(),
+
+		field.JSON("fields", []customfield.Definition{}).
+			Comment("字段定义").
+			Optional(),
+
+		field.JSON("localized_fields", []string{}).
+			Comment("多语言字段的键名").
+			Optional(),
+
+		field.JSON("taxonomies", []string{}).
+			Comment("可绑定的分类法").
+			Optional(),
+
+		field.Bool("enabled").
+			Comment("是否启用").
+			Default(true).
+			Optional().
+			Nillable(),
+	}
+}
+
+// Mixin of the ContentModel.
+func (ContentModel) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixin.AutoIncrementId{},
+		mixin.TimeAt{},
+		mixin.OperatorID{},
+		mixin.SortOrder{},
+		mixin.TenantID[uint32]{},
+	}
+}
+
+func (ContentModel) Indexes() []ent.Index {
+	return []ent.Index{
+		// 同一租户下编码唯一，也用于按编码查找
+		index.Fields("tenant_id", "code").
+			Unique(),
+	}
+}
@@ -0,0 +1,99 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/tx7do/go-crud/entgo/mixin"
+
+	"go-wind-cms/pkg/content/customfield"
+)
+
+// FieldGroup holds the schema definition for the FieldGroup entity.
+//
+// 自定义字段组：按帖子类型、分类或页面模板挂载到帖子/页面，定义其类型化自定义字段。
+type FieldGroup struct {
+	ent.Schema
+}
+
+func (FieldGroup) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{
+			Table:     "field_groups",
+			Charset:   "utf8mb4",
+			Collation: "utf8mb4_bin",
+		},
+		entsql.WithComments(true),
+		schema.Comment("自定义字段组表"),
+	}
+}
+
+// Fields of the FieldGroup.
+func (FieldGroup) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").
+			Comment("字段组名称").
+			NotEmpty().
+			MaxLen(128).
+			Optional().
+			Nillable(),
+
+		field.String("description").
+			Comment("描述").
+			MaxLen(1024).
+			Optional().
+			Nillable(),
+
+		field.Enum("location").
+			Comment("挂载位置").
+			NamedValues(
+				"LocationPost", "LOCATION_POST",
+				"LocationPage", "LOCATION_PAGE",
+			).
+			Default("LOCATION_POST").
+			Optional().
+			Nillable(),
+
+		field.JSON("post_types", []string{}).
+			Comment("挂载的帖子类型").
+			Optional(),
+
+		field.JSON("category_ids", []uint32{}).
+			Comment("挂载的分类ID").
+			Optional(),
+
+		field.JSON("page_templates", []string{}).
+			Comment("挂载的页面模板").
+			Optional(),
+
+		field.JSON("fields", []customfield.Definition{}).
+			Comment("字段定义").
+			Optional(),
+
+		field.Bool("enabled").
+			Comment("是否启用").
+			Default(true).
+			Optional().
+			Nillable(),
+	}
+}
+
+// Mixin of the FieldGroup.
+func (FieldGroup) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixin.AutoIncrementId{},
+		mixin.TimeAt{},
+		mixin.OperatorID{},
+		mixin.SortOrder{},
+		mixin.TenantID[uint32]{},
+	}
+}
+
+func (FieldGroup) Indexes() []ent.Index {
+	return []ent.Index{
+		// 保存帖子/页面时按租户与挂载位置加载启用的字段组
+		index.Fields("tenant_id", "location", "enabled"),
+	}
+}
@@ -0,0 +1,108 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/tx7do/go-crud/entgo/mixin"
+
+	"go-wind-cms/pkg/content/form"
+)
+
+// Form holds the schema definition for the Form entity.
+//
+// 表单定义：由页面的表单区块（SECTION_TYPE_FORM，配置 form_id）引用，
+// 访客提交的数据写入 form_submissions，并通知配置的接收人与 Webhook。
+type Form struct {
+	ent.Schema
+}
+
+func (Form) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{
+			Table:     "forms",
+			Charset:   "utf8mb4",
+			Collation: "utf8mb4_bin",
+		},
+		entsql.WithComments(true),
+		schema.Comment("表单表"),
+	}
+}
+
+// Fields of the Form.
+func (Form) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").
+			Comment("表单名称").
+			NotEmpty().
+			MaxLen(128).
+			Optional().
+			Nillable(),
+
+		field.String("description").
+			Comment("描述").
+			MaxLen(1024).
+			Optional().
+			Nillable(),
+
+		field.JSON("fields", []form.Field{}).
+			Comment("字段定义").
+			Optional(),
+
+		field.String("honeypot_field").
+			Comment("蜜罐字段名，前台渲染为隐藏输入框，填写了该字段的提交视为机器人").
+			MaxLen(64).
+			Optional().
+			Nillable(),
+
+		field.Bool("captcha_required").
+			Comment("提交是否需要图形验证码").
+			Default(false).
+			Optional().
+			Nillable(),
+
+		field.String("success_message").
+			Comment("提交成功后的提示文本").
+			MaxLen(1024).
+			Optional().
+			Nillable(),
+
+		field.JSON("recipient_user_ids", []uint32{}).
+			Comment("收到提交时通知的用户ID（站内信）").
+			Optional(),
+
+		field.JSON("webhook_urls", []string{}).
+			Comment("收到提交时回调的 Webhook 地址").
+			Optional(),
+
+		field.String("webhook_secret").
+			Comment("Webhook 签名密钥").
+			MaxLen(128).
+			Optional().
+			Nillable(),
+
+		field.Bool("enabled").
+			Comment("是否接受提交").
+			Default(true).
+			Optional().
+			Nillable(),
+	}
+}
+
+// Mixin of the Form.
+func (Form) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixin.AutoIncrementId{},
+		mixin.TimeAt{},
+		mixin.OperatorID{},
+		mixin.TenantID[uint32]{},
+	}
+}
+
+func (Form) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("tenant_id", "enabled"),
+	}
+}
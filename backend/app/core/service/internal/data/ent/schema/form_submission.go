@@ -0,0 +1,98 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/tx7do/go-crud/entgo/mixin"
+
+	"go-wind-cms/pkg/content/customfield"
+)
+
+// FormSubmission holds the schema definition for the FormSubmission entity.
+//
+// 表单提交记录：字段值以自定义字段值结构存储，提交后不可修改。
+type FormSubmission struct {
+	ent.Schema
+}
+
+func (FormSubmission) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{
+			Table:     "form_submissions",
+			Charset:   "utf8mb4",
+			Collation: "utf8mb4_bin",
+		},
+		entsql.WithComments(true),
+		schema.Comment("表单提交表"),
+	}
+}
+
+// Fields of the FormSubmission.
+func (FormSubmission) Fields() []ent.Field {
+	return []ent.Field{
+		field.Uint32("form_id").
+			Comment("表单ID").
+			Immutable(),
+
+		field.JSON("data", map[string]*customfield.Value{}).
+			Comment("提交的字段值").
+			Optional().
+			Immutable(),
+
+		field.Uint32("page_id").
+			Comment("提交来源页面ID").
+			Optional().
+			Nillable().
+			Immutable(),
+
+		field.Uint32("section_id").
+			Comment("提交来源区块ID").
+			Optional().
+			Nillable().
+			Immutable(),
+
+		field.Uint32("user_id").
+			Comment("提交者用户ID，匿名提交为空").
+			Optional().
+			Nillable().
+			Immutable(),
+
+		field.String("client_ip").
+			Comment("提交者IP").
+			MaxLen(64).
+			Optional().
+			Nillable().
+			Immutable(),
+
+		field.String("user_agent").
+			Comment("提交者 User-Agent").
+			MaxLen(512).
+			Optional().
+			Nillable().
+			Immutable(),
+
+		field.Time("notified_at").
+			Comment("通知投递时间，为空表示尚未通知").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Mixin of the FormSubmission.
+func (FormSubmission) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixin.AutoIncrementId{},
+		mixin.CreatedAt{},
+		mixin.TenantID[uint32]{},
+	}
+}
+
+func (FormSubmission) Indexes() []ent.Index {
+	return []ent.Index{
+		// 后台按表单分页列出与导出提交
+		index.Fields("tenant_id", "form_id", "created_at"),
+	}
+}
@@ -0,0 +1,96 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/tx7do/go-crud/entgo/mixin"
+)
+
+// GlossaryTerm holds the schema definition for the GlossaryTerm entity.
+//
+// 租户术语表：机器翻译时不翻译的术语保留原文，强制译法的术语替换为指定译文。
+type GlossaryTerm struct {
+	ent.Schema
+}
+
+func (GlossaryTerm) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{
+			Table:     "glossary_terms",
+			Charset:   "utf8mb4",
+			Collation: "utf8mb4_bin",
+		},
+		entsql.WithComments(true),
+		schema.Comment("术语表"),
+	}
+}
+
+// Fields of the GlossaryTerm.
+func (GlossaryTerm) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("source_language").
+			Comment("源语言代码").
+			NotEmpty().
+			MaxLen(32).
+			Optional().
+			Nillable(),
+
+		field.String("target_language").
+			Comment("目标语言代码，空字符串表示所有目标语言").
+			MaxLen(32).
+			Default("").
+			Optional().
+			Nillable(),
+
+		field.String("term").
+			Comment("源语言术语").
+			NotEmpty().
+			MaxLen(255).
+			Optional().
+			Nillable(),
+
+		field.String("translation").
+			Comment("强制译法").
+			MaxLen(255).
+			Optional().
+			Nillable(),
+
+		field.Enum("type").
+			Comment("术语类型").
+			NamedValues(
+				"TypeDoNotTranslate", "TYPE_DO_NOT_TRANSLATE",
+				"TypeForced", "TYPE_FORCED",
+			).
+			Default("TYPE_DO_NOT_TRANSLATE").
+			Optional().
+			Nillable(),
+
+		field.Bool("case_sensitive").
+			Comment("是否区分大小写匹配").
+			Default(false).
+			Optional().
+			Nillable(),
+	}
+}
+
+// Mixin of the GlossaryTerm.
+func (GlossaryTerm) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixin.AutoIncrementId{},
+		mixin.TimeAt{},
+		mixin.OperatorID{},
+		mixin.Remark{},
+		mixin.TenantID[uint32]{},
+	}
+}
+
+func (GlossaryTerm) Indexes() []ent.Index {
+	return []ent.Index{
+		// 同一租户、同一语言对下术语唯一；机器翻译时按语言对整体加载
+		index.Fields("tenant_id", "source_language", "target_language", "term").
+			Unique(),
+	}
+}
@@ -0,0 +1,140 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/tx7do/go-crud/entgo/mixin"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+)
+
+// ImportJob holds the schema definition for the ImportJob entity.
+//
+// 内容导入作业：从 WordPress 导出文件或 Markdown 归档导入文章、页面、分类、标签与评论，
+// 由 content.import 异步任务执行，记录进度、统计与逐条报告。
+type ImportJob struct {
+	ent.Schema
+}
+
+func (ImportJob) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{
+			Table:     "import_jobs",
+			Charset:   "utf8mb4",
+			Collation: "utf8mb4_bin",
+		},
+		entsql.WithComments(true),
+		schema.Comment("内容导入作业表"),
+	}
+}
+
+// Fields of the ImportJob.
+func (ImportJob) Fields() []ent.Field {
+	return []ent.Field{
+		field.Enum("format").
+			Comment("导入文件格式").
+			NamedValues(
+				"FormatWxr", "FORMAT_WXR",
+				"FormatMarkdown", "FORMAT_MARKDOWN",
+			).
+			Immutable(),
+
+		field.Uint32("file_id").
+			Comment("导入文件ID").
+			Immutable(),
+
+		field.String("language").
+			Comment("导入内容的语言代码").
+			MaxLen(32).
+			Immutable(),
+
+		field.Bool("dry_run").
+			Comment("是否为试运行：只生成报告，不写入数据").
+			Default(false).
+			Immutable(),
+
+		field.Bool("download_attachments").
+			Comment("是否下载附件并改写其地址").
+			Default(true).
+			Immutable(),
+
+		field.Bool("import_comments").
+			Comment("是否导入评论").
+			Default(true).
+			Immutable(),
+
+		field.Bool("as_draft").
+			Comment("是否全部导入为草稿").
+			Default(false).
+			Immutable(),
+
+		field.String("source_url").
+			Comment("旧站点地址，用于识别站内链接").
+			MaxLen(512).
+			Optional().
+			Nillable().
+			Immutable(),
+
+		field.Enum("status").
+			Comment("状态").
+			NamedValues(
+				"ImportJobStatusPending", "IMPORT_JOB_STATUS_PENDING",
+				"ImportJobStatusRunning", "IMPORT_JOB_STATUS_RUNNING",
+				"ImportJobStatusCompleted", "IMPORT_JOB_STATUS_COMPLETED",
+				"ImportJobStatusFailed", "IMPORT_JOB_STATUS_FAILED",
+			).
+			Default("IMPORT_JOB_STATUS_PENDING"),
+
+		field.Uint32("total").
+			Comment("待导入的条目数").
+			Default(0),
+
+		field.Uint32("processed").
+			Comment("已处理的条目数").
+			Default(0),
+
+		field.JSON("stats", []*contentV1.ImportJobStat{}).
+			Comment("按内容类型统计的结果").
+			Optional(),
+
+		field.JSON("items", []*contentV1.ImportJobItem{}).
+			Comment("逐条结果").
+			Optional(),
+
+		field.Time("started_at").
+			Comment("开始执行时间").
+			Optional().
+			Nillable(),
+
+		field.Time("finished_at").
+			Comment("结束时间").
+			Optional().
+			Nillable(),
+
+		field.String("last_error").
+			Comment("作业失败的原因").
+			MaxLen(1024).
+			Optional().
+			Nillable(),
+	}
+}
+
+// Mixin of the ImportJob.
+func (ImportJob) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixin.AutoIncrementId{},
+		mixin.TimeAt{},
+		mixin.OperatorID{},
+		mixin.TenantID[uint32]{},
+	}
+}
+
+func (ImportJob) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("tenant_id", "status"),
+		index.Fields("tenant_id", "created_at"),
+	}
+}
@@ -0,0 +1,91 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/tx7do/go-crud/entgo/mixin"
+)
+
+// LlmUsage holds the schema definition for the LlmUsage entity.
+//
+// 大语言模型用量台账：每次成功调用记录一行，按租户、月份汇总用于用量报告与每月上限校验。
+type LlmUsage struct {
+	ent.Schema
+}
+
+func (LlmUsage) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{
+			Table:     "llm_usages",
+			Charset:   "utf8mb4",
+			Collation: "utf8mb4_bin",
+		},
+		entsql.WithComments(true),
+		schema.Comment("大语言模型用量表"),
+	}
+}
+
+// Fields of the LlmUsage.
+func (LlmUsage) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("task").
+			Comment("任务，AiAssistTask 的名称，如 AI_ASSIST_TASK_SUMMARY").
+			MaxLen(64).
+			Immutable(),
+
+		field.String("provider").
+			Comment("供应商类型").
+			MaxLen(32).
+			Immutable(),
+
+		field.String("model").
+			Comment("模型").
+			MaxLen(128).
+			Optional().
+			Immutable(),
+
+		field.Uint32("job_id").
+			Comment("AI辅助作业ID").
+			Optional().
+			Nillable().
+			Immutable(),
+
+		field.Uint32("requests").
+			Comment("调用次数").
+			Default(1).
+			Immutable(),
+
+		field.Uint32("prompt_tokens").
+			Comment("提示词 Token 数").
+			Default(0).
+			Immutable(),
+
+		field.Uint32("completion_tokens").
+			Comment("生成 Token 数").
+			Default(0).
+			Immutable(),
+
+		field.Uint32("total_tokens").
+			Comment("总 Token 数").
+			Default(0).
+			Immutable(),
+	}
+}
+
+// Mixin of the LlmUsage.
+func (LlmUsage) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixin.AutoIncrementId{},
+		mixin.TimeAt{},
+		mixin.TenantID[uint32]{},
+	}
+}
+
+func (LlmUsage) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("tenant_id", "created_at"),
+	}
+}
@@ -108,6 +108,7 @@ func (MediaAsset) Fields() []ent.Field {
 				"ProcessingStatusProcessing", "PROCESSING_STATUS_PROCESSING",
 				"ProcessingStatusCompleted", "PROCESSING_STATUS_COMPLETED",
 				"ProcessingStatusFailed", "PROCESSING_STATUS_FAILED",
+				"ProcessingStatusQuarantined", "PROCESSING_STATUS_QUARANTINED",
 			).
 			Optional().
 			Nillable(),
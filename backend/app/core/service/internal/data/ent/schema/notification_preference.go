@@ -0,0 +1,85 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/tx7do/go-crud/entgo/mixin"
+)
+
+// NotificationPreference holds the schema definition for the NotificationPreference entity.
+//
+// 用户通知偏好，每个 (tenant, user) 一行；没有记录的用户按默认值（全部开启）处理。
+type NotificationPreference struct {
+	ent.Schema
+}
+
+func (NotificationPreference) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{
+			Table:     "notification_preferences",
+			Charset:   "utf8mb4",
+			Collation: "utf8mb4_bin",
+		},
+		entsql.WithComments(true),
+		schema.Comment("用户通知偏好表"),
+	}
+}
+
+// Fields of the NotificationPreference.
+func (NotificationPreference) Fields() []ent.Field {
+	return []ent.Field{
+		field.Uint32("user_id").
+			Comment("用户ID").
+			Optional().
+			Nillable(),
+
+		field.Bool("reply_enabled").
+			Comment("评论被回复时通知").
+			Default(true).
+			Optional().
+			Nillable(),
+
+		field.Bool("post_comment_enabled").
+			Comment("自己的文章收到评论时通知").
+			Default(true).
+			Optional().
+			Nillable(),
+
+		field.Bool("watched_post_enabled").
+			Comment("收藏的文章有新评论时通知").
+			Default(true).
+			Optional().
+			Nillable(),
+
+		field.Bool("in_app_enabled").
+			Comment("是否接收站内通知").
+			Default(true).
+			Optional().
+			Nillable(),
+
+		field.Bool("email_enabled").
+			Comment("是否接收邮件通知").
+			Default(true).
+			Optional().
+			Nillable(),
+	}
+}
+
+// Mixin of the NotificationPreference.
+func (NotificationPreference) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixin.AutoIncrementId{},
+		mixin.TimeAt{},
+		mixin.TenantID[uint32]{},
+	}
+}
+
+func (NotificationPreference) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("tenant_id", "user_id").
+			Unique(),
+	}
+}
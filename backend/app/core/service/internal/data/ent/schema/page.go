@@ -9,6 +9,7 @@ import (
 
 	"github.com/tx7do/go-crud/entgo/mixin"
 
+	"go-wind-cms/pkg/content/customfield"
 	appMixin "go-wind-cms/pkg/entgo/mixin"
 )
 
@@ -104,6 +105,10 @@ func (Page) Fields() []ent.Field {
 			Comment("自定义字段").
 			Optional(),
 
+		field.JSON("field_values", map[string]*customfield.Value{}).
+			Comment("类型化自定义字段值").
+			Optional(),
+
 		field.Int32("depth").
 			Comment("页面层级深度").
 			Default(0).
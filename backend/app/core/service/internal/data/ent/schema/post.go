@@ -9,6 +9,7 @@ import (
 
 	"github.com/tx7do/go-crud/entgo/mixin"
 
+	"go-wind-cms/pkg/content/customfield"
 	appMixin "go-wind-cms/pkg/entgo/mixin"
 )
 
@@ -93,6 +94,32 @@ func (Post) Fields() []ent.Field {
 			Comment("自定义字段").
 			Optional(),
 
+		field.String("post_type").
+			Comment("帖子类型，用于挂载字段组").
+			Default("post").
+			MaxLen(64).
+			Optional().
+			Nillable(),
+
+		field.JSON("field_values", map[string]*customfield.Value{}).
+			Comment("类型化自定义字段值").
+			Optional(),
+
+		field.Uint32("workflow_id").
+			Comment("编辑工作流ID，为空表示不受工作流约束").
+			Optional().
+			Nillable(),
+
+		field.String("workflow_stage").
+			Comment("当前工作流阶段编码").
+			MaxLen(32).
+			Optional().
+			Nillable(),
+
+		field.JSON("reviewer_ids", []uint32{}).
+			Comment("指派的审阅人用户ID").
+			Optional(),
+
 		//field.JSON("category_ids", &[]uint32{}).
 		//	Comment("关联的分类ID列表").
 		//	Optional(),
@@ -142,5 +169,9 @@ func (Post) Indexes() []ent.Index {
 		index.Fields("status", "is_featured"),
 		// 复合索引，优化按状态和审核状态查询
 		index.Fields("status", "in_progress"),
+		// 复合索引，优化按租户和帖子类型查询
+		index.Fields("tenant_id", "post_type"),
+		// 复合索引，优化按租户和工作流阶段查询审阅队列
+		index.Fields("tenant_id", "workflow_stage"),
 	}
 }
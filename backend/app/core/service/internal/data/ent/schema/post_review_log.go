@@ -0,0 +1,100 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/tx7do/go-crud/entgo/mixin"
+)
+
+// PostReviewLog holds the schema definition for the PostReviewLog entity.
+//
+// 文章审阅日志：记录工作流流转、审阅人指派与审阅意见，只追加不修改。
+type PostReviewLog struct {
+	ent.Schema
+}
+
+func (PostReviewLog) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{
+			Table:     "post_review_logs",
+			Charset:   "utf8mb4",
+			Collation: "utf8mb4_bin",
+		},
+		entsql.WithComments(true),
+		schema.Comment("文章审阅日志表"),
+	}
+}
+
+// Fields of the PostReviewLog.
+func (PostReviewLog) Fields() []ent.Field {
+	return []ent.Field{
+		field.Uint32("post_id").
+			Comment("文章ID").
+			Immutable(),
+
+		field.Uint32("workflow_id").
+			Comment("工作流ID").
+			Optional().
+			Nillable().
+			Immutable(),
+
+		field.Enum("action").
+			Comment("动作").
+			NamedValues(
+				"ActionTransition", "ACTION_TRANSITION",
+				"ActionAssign", "ACTION_ASSIGN",
+				"ActionComment", "ACTION_COMMENT",
+			).
+			Immutable(),
+
+		field.String("from_stage").
+			Comment("流转前阶段").
+			MaxLen(32).
+			Optional().
+			Nillable().
+			Immutable(),
+
+		field.String("to_stage").
+			Comment("流转后阶段").
+			MaxLen(32).
+			Optional().
+			Nillable().
+			Immutable(),
+
+		field.JSON("reviewer_ids", []uint32{}).
+			Comment("指派后的审阅人用户ID").
+			Optional().
+			Immutable(),
+
+		field.Text("comment").
+			Comment("审阅意见").
+			Optional().
+			Nillable().
+			Immutable(),
+
+		field.Uint32("operator_id").
+			Comment("操作人用户ID").
+			Optional().
+			Nillable().
+			Immutable(),
+	}
+}
+
+// Mixin of the PostReviewLog.
+func (PostReviewLog) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixin.AutoIncrementId{},
+		mixin.CreatedAt{},
+		mixin.TenantID[uint32]{},
+	}
+}
+
+func (PostReviewLog) Indexes() []ent.Index {
+	return []ent.Index{
+		// 按文章查看审阅历史
+		index.Fields("tenant_id", "post_id", "created_at"),
+	}
+}
@@ -0,0 +1,110 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/tx7do/go-crud/entgo/mixin"
+)
+
+// PreviewToken holds the schema definition for the PreviewToken entity.
+//
+// 草稿预览令牌：令牌本身为签名字符串不落库，这里只登记令牌ID及其范围，
+// 用于后台列出与吊销；前台预览时按令牌ID校验是否已吊销。
+type PreviewToken struct {
+	ent.Schema
+}
+
+func (PreviewToken) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{
+			Table:     "preview_tokens",
+			Charset:   "utf8mb4",
+			Collation: "utf8mb4_bin",
+		},
+		entsql.WithComments(true),
+		schema.Comment("草稿预览令牌表"),
+	}
+}
+
+// Fields of the PreviewToken.
+func (PreviewToken) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("token_id").
+			Comment("令牌ID").
+			MaxLen(64).
+			NotEmpty().
+			Immutable(),
+
+		field.Enum("content_type").
+			Comment("内容类型").
+			NamedValues(
+				"ContentTypePost", "CONTENT_TYPE_POST",
+				"ContentTypePage", "CONTENT_TYPE_PAGE",
+			).
+			Immutable(),
+
+		field.Uint32("content_id").
+			Comment("内容ID").
+			Immutable(),
+
+		field.Time("revision").
+			Comment("锁定的修订（签发时内容的更新时间），为空表示始终预览最新草稿").
+			Optional().
+			Nillable().
+			Immutable(),
+
+		field.String("locale").
+			Comment("锁定的语言代码").
+			MaxLen(32).
+			Optional().
+			Nillable().
+			Immutable(),
+
+		field.String("note").
+			Comment("备注，如分享对象").
+			MaxLen(255).
+			Optional().
+			Nillable().
+			Immutable(),
+
+		field.Uint32("created_by").
+			Comment("签发者用户ID").
+			Optional().
+			Nillable().
+			Immutable(),
+
+		field.Time("expires_at").
+			Comment("过期时间").
+			Immutable(),
+
+		field.Time("revoked_at").
+			Comment("吊销时间").
+			Optional().
+			Nillable(),
+
+		field.Uint32("revoked_by").
+			Comment("吊销者用户ID").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Mixin of the PreviewToken.
+func (PreviewToken) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixin.AutoIncrementId{},
+		mixin.CreatedAt{},
+		mixin.TenantID[uint32]{},
+	}
+}
+
+func (PreviewToken) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("token_id").Unique(),
+		// 按内容列出令牌
+		index.Fields("tenant_id", "content_type", "content_id"),
+	}
+}
@@ -0,0 +1,120 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/tx7do/go-crud/entgo/mixin"
+)
+
+// Redirect holds the schema definition for the Redirect entity.
+//
+// 前台路径重定向规则：后台手工维护，或在内容完整路径变更时自动记录旧路径。
+type Redirect struct {
+	ent.Schema
+}
+
+func (Redirect) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{
+			Table:     "redirects",
+			Charset:   "utf8mb4",
+			Collation: "utf8mb4_bin",
+		},
+		entsql.WithComments(true),
+		schema.Comment("重定向规则表"),
+	}
+}
+
+// Fields of the Redirect.
+func (Redirect) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("source_path").
+			Comment("源路径，末尾为 * 时为通配规则").
+			NotEmpty().
+			MaxLen(1024).
+			Optional().
+			Nillable(),
+
+		field.String("target_path").
+			Comment("目标路径或绝对 URL").
+			NotEmpty().
+			MaxLen(2048).
+			Optional().
+			Nillable(),
+
+		field.Uint32("status_code").
+			Comment("HTTP 状态码").
+			Default(301).
+			Optional().
+			Nillable(),
+
+		field.String("language_code").
+			Comment("限定语言代码，空字符串表示所有语言").
+			Default("").
+			Optional().
+			Nillable(),
+
+		field.Bool("is_wildcard").
+			Comment("是否通配规则").
+			Default(false).
+			Optional().
+			Nillable(),
+
+		field.Enum("origin").
+			Comment("规则来源").
+			NamedValues(
+				"OriginManual", "ORIGIN_MANUAL",
+				"OriginAuto", "ORIGIN_AUTO",
+			).
+			Default("ORIGIN_MANUAL").
+			Optional().
+			Nillable(),
+
+		field.Enum("content_type").
+			Comment("自动记录时关联的内容类型").
+			NamedValues(
+				"ContentTypePost", "CONTENT_TYPE_POST",
+				"ContentTypePage", "CONTENT_TYPE_PAGE",
+				"ContentTypeCategory", "CONTENT_TYPE_CATEGORY",
+			).
+			Optional().
+			Nillable(),
+
+		field.Uint32("content_id").
+			Comment("自动记录时关联的内容ID").
+			Optional().
+			Nillable(),
+
+		field.Bool("enabled").
+			Comment("是否启用").
+			Default(true).
+			Optional().
+			Nillable(),
+	}
+}
+
+// Mixin of the Redirect.
+func (Redirect) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixin.AutoIncrementId{},
+		mixin.TimeAt{},
+		mixin.OperatorID{},
+		mixin.Remark{},
+		mixin.TenantID[uint32]{},
+	}
+}
+
+func (Redirect) Indexes() []ent.Index {
+	return []ent.Index{
+		// 同一租户、同一语言下源路径唯一，也用于精确匹配
+		index.Fields("tenant_id", "source_path", "language_code").
+			Unique(),
+		// 通配规则按租户整体加载后做最长前缀匹配
+		index.Fields("tenant_id", "is_wildcard"),
+		// 路径变更时查找指向旧路径的规则并改写
+		index.Fields("tenant_id", "target_path"),
+	}
+}
@@ -0,0 +1,106 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/tx7do/go-crud/entgo/mixin"
+)
+
+// Release holds the schema definition for the Release entity.
+//
+// 发布集：把多篇文章、页面、区块、导航项与站点配置的待发布变更归为一组，
+// 可预览、定时，并在同一事务中整体发布或整体回滚。
+type Release struct {
+	ent.Schema
+}
+
+func (Release) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{
+			Table:     "releases",
+			Charset:   "utf8mb4",
+			Collation: "utf8mb4_bin",
+		},
+		entsql.WithComments(true),
+		schema.Comment("发布集表"),
+	}
+}
+
+// Fields of the Release.
+func (Release) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").
+			Comment("名称").
+			MaxLen(128).
+			NotEmpty(),
+
+		field.String("description").
+			Comment("说明").
+			MaxLen(1024).
+			Optional().
+			Nillable(),
+
+		field.Enum("status").
+			Comment("状态").
+			NamedValues(
+				"ReleaseStatusDraft", "RELEASE_STATUS_DRAFT",
+				"ReleaseStatusScheduled", "RELEASE_STATUS_SCHEDULED",
+				"ReleaseStatusPublished", "RELEASE_STATUS_PUBLISHED",
+				"ReleaseStatusFailed", "RELEASE_STATUS_FAILED",
+				"ReleaseStatusRolledBack", "RELEASE_STATUS_ROLLED_BACK",
+			).
+			Default("RELEASE_STATUS_DRAFT"),
+
+		field.Time("scheduled_at").
+			Comment("定时发布时间").
+			Optional().
+			Nillable(),
+
+		field.Time("published_at").
+			Comment("发布时间").
+			Optional().
+			Nillable(),
+
+		field.Uint32("published_by").
+			Comment("发布者用户ID，定时发布为空").
+			Optional().
+			Nillable(),
+
+		field.Time("rolled_back_at").
+			Comment("回滚时间").
+			Optional().
+			Nillable(),
+
+		field.Uint32("rolled_back_by").
+			Comment("回滚者用户ID").
+			Optional().
+			Nillable(),
+
+		field.String("last_error").
+			Comment("最近一次定时发布失败的原因").
+			MaxLen(1024).
+			Optional().
+			Nillable(),
+	}
+}
+
+// Mixin of the Release.
+func (Release) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixin.AutoIncrementId{},
+		mixin.TimeAt{},
+		mixin.OperatorID{},
+		mixin.TenantID[uint32]{},
+	}
+}
+
+func (Release) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("tenant_id", "status"),
+		// 定时发布扫描
+		index.Fields("status", "scheduled_at"),
+	}
+}
@@ -0,0 +1,76 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/tx7do/go-crud/entgo/mixin"
+)
+
+// ReleaseItem holds the schema definition for the ReleaseItem entity.
+//
+// 发布集条目：一条内容的待发布字段变更；发布时记录各字段的原值快照，回滚时据此还原。
+type ReleaseItem struct {
+	ent.Schema
+}
+
+func (ReleaseItem) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{
+			Table:     "release_items",
+			Charset:   "utf8mb4",
+			Collation: "utf8mb4_bin",
+		},
+		entsql.WithComments(true),
+		schema.Comment("发布集条目表"),
+	}
+}
+
+// Fields of the ReleaseItem.
+func (ReleaseItem) Fields() []ent.Field {
+	return []ent.Field{
+		field.Uint32("release_id").
+			Comment("发布集ID").
+			Immutable(),
+
+		field.Enum("entity_type").
+			Comment("内容类型").
+			NamedValues(
+				"EntityTypePost", "ENTITY_TYPE_POST",
+				"EntityTypePage", "ENTITY_TYPE_PAGE",
+				"EntityTypeSection", "ENTITY_TYPE_SECTION",
+				"EntityTypeNavigationItem", "ENTITY_TYPE_NAVIGATION_ITEM",
+				"EntityTypeSiteSetting", "ENTITY_TYPE_SITE_SETTING",
+			).
+			Immutable(),
+
+		field.Uint32("entity_id").
+			Comment("内容ID").
+			Immutable(),
+
+		field.JSON("changes", map[string]string{}).
+			Comment("字段变更，字段名到新值"),
+
+		field.JSON("snapshot", map[string]*string{}).
+			Comment("发布前的字段原值，回滚时还原；null 表示原值为空").
+			Optional(),
+	}
+}
+
+// Mixin of the ReleaseItem.
+func (ReleaseItem) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixin.AutoIncrementId{},
+		mixin.TimeAt{},
+		mixin.TenantID[uint32]{},
+	}
+}
+
+func (ReleaseItem) Indexes() []ent.Index {
+	return []ent.Index{
+		// 同一发布集中每条内容只有一个条目
+		index.Fields("release_id", "entity_type", "entity_id").Unique(),
+	}
+}
@@ -65,6 +65,17 @@ func (Section) Fields() []ent.Field {
 		field.JSON("config", &map[string]string{}).
 			Comment("区块样式/布局配置（边距、CSS class、列数等，语言无关）").
 			Optional(),
+
+		field.Bool("reusable").
+			Comment("是否为全局可复用区块（不属于任何页面，可被多个页面引用）").
+			Default(false).
+			Optional().
+			Nillable(),
+
+		field.Uint32("block_id").
+			Comment("引用的可复用区块ID，非空时类型、配置与翻译均取自该区块").
+			Optional().
+			Nillable(),
 	}
 }
 
@@ -85,5 +96,8 @@ func (Section) Indexes() []ent.Index {
 		index.Fields("page_id"),
 		// 复合索引，优化按页面查询并排序
 		index.Fields("page_id", "sort_order"),
+		// 查询可复用区块及其引用方
+		index.Fields("reusable"),
+		index.Fields("block_id"),
 	}
 }
@@ -7,6 +7,8 @@ import (
 	"entgo.io/ent/schema/field"
 	"entgo.io/ent/schema/index"
 	"github.com/tx7do/go-crud/entgo/mixin"
+
+	"go-wind-cms/pkg/content/locale"
 )
 
 // Site holds the schema definition for the Site entity.
@@ -80,6 +82,10 @@ func (Site) Fields() []ent.Field {
 			Optional().
 			Nillable(),
 
+		field.JSON("locale_fallbacks", []locale.Fallback{}).
+			Comment("语言回退链：请求语言缺少翻译时依次尝试的语言").
+			Optional(),
+
 	}
 }
 
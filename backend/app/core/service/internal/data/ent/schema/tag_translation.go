@@ -66,6 +66,45 @@ func (TagTranslation) Fields() []ent.Field {
 			Comment("完整路径").
 			Optional().
 			Nillable(),
+
+		field.Bool("is_draft").
+			Comment("是否为草稿；草稿翻译不对外提供，审阅后发布").
+			Default(false).
+			Optional().
+			Nillable(),
+
+		field.Bool("machine_translated").
+			Comment("是否由机器翻译生成").
+			Default(false).
+			Optional().
+			Nillable(),
+
+		field.Uint32("revision").
+			Comment("内容修订号，可翻译字段修改时递增").
+			Default(1).
+			Optional().
+			Nillable(),
+
+		field.String("source_language").
+			Comment("译文所依据的源语言代码，为空表示本身即为原文").
+			Optional().
+			Nillable(),
+
+		field.Uint32("source_revision").
+			Comment("译文所依据的源语言版本修订号").
+			Optional().
+			Nillable(),
+
+		field.Enum("translation_status").
+			Comment("翻译状态").
+			NamedValues(
+				"TranslationStatusSource", "TRANSLATION_STATUS_SOURCE",
+				"TranslationStatusCurrent", "TRANSLATION_STATUS_CURRENT",
+				"TranslationStatusStale", "TRANSLATION_STATUS_STALE",
+			).
+			Default("TRANSLATION_STATUS_SOURCE").
+			Optional().
+			Nillable(),
 	}
 }
 
@@ -94,5 +133,9 @@ func (TagTranslation) Indexes() []ent.Index {
 		index.Fields("tag_id", "language_code"),
 		// 复合索引，优化按语言代码和slug查询
 		index.Fields("language_code", "slug"),
+		// 复合索引，优化翻译看板按语言与翻译状态统计
+		index.Fields("language_code", "translation_status"),
+		// 复合索引，优化源语言版本修改时查找依据它的译文
+		index.Fields("tag_id", "source_language"),
 	}
 }
@@ -0,0 +1,120 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/tx7do/go-crud/entgo/mixin"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+)
+
+// TranslationJob holds the schema definition for the TranslationJob entity.
+//
+// 批量机器翻译作业：把一条内容的源语言版本翻译为多个目标语言，结果写入机器翻译草稿，
+// 由 translation.job 异步任务执行，逐语言记录进度与结果。
+type TranslationJob struct {
+	ent.Schema
+}
+
+func (TranslationJob) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{
+			Table:     "translation_jobs",
+			Charset:   "utf8mb4",
+			Collation: "utf8mb4_bin",
+		},
+		entsql.WithComments(true),
+		schema.Comment("机器翻译作业表"),
+	}
+}
+
+// Fields of the TranslationJob.
+func (TranslationJob) Fields() []ent.Field {
+	return []ent.Field{
+		field.Enum("entity_type").
+			Comment("内容类型").
+			NamedValues(
+				"EntityTypePost", "ENTITY_TYPE_POST",
+				"EntityTypePage", "ENTITY_TYPE_PAGE",
+				"EntityTypeCategory", "ENTITY_TYPE_CATEGORY",
+				"EntityTypeTag", "ENTITY_TYPE_TAG",
+			).
+			Immutable(),
+
+		field.Uint32("entity_id").
+			Comment("内容ID").
+			Immutable(),
+
+		field.String("source_language").
+			Comment("源语言代码").
+			MaxLen(32).
+			Immutable(),
+
+		field.JSON("target_languages", []string{}).
+			Comment("目标语言代码").
+			Immutable(),
+
+		field.Bool("overwrite_drafts").
+			Comment("是否覆盖已有的机器翻译草稿；人工翻译与已发布的翻译始终跳过").
+			Default(true).
+			Immutable(),
+
+		field.Enum("status").
+			Comment("状态").
+			NamedValues(
+				"TranslationJobStatusPending", "TRANSLATION_JOB_STATUS_PENDING",
+				"TranslationJobStatusRunning", "TRANSLATION_JOB_STATUS_RUNNING",
+				"TranslationJobStatusCompleted", "TRANSLATION_JOB_STATUS_COMPLETED",
+				"TranslationJobStatusFailed", "TRANSLATION_JOB_STATUS_FAILED",
+			).
+			Default("TRANSLATION_JOB_STATUS_PENDING"),
+
+		field.Uint32("total").
+			Comment("目标语言数").
+			Default(0),
+
+		field.Uint32("processed").
+			Comment("已处理的目标语言数").
+			Default(0),
+
+		field.JSON("results", []*contentV1.TranslationJobResult{}).
+			Comment("各目标语言的处理结果").
+			Optional(),
+
+		field.Time("started_at").
+			Comment("开始执行时间").
+			Optional().
+			Nillable(),
+
+		field.Time("finished_at").
+			Comment("结束时间").
+			Optional().
+			Nillable(),
+
+		field.String("last_error").
+			Comment("作业失败的原因").
+			MaxLen(1024).
+			Optional().
+			Nillable(),
+	}
+}
+
+// Mixin of the TranslationJob.
+func (TranslationJob) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixin.AutoIncrementId{},
+		mixin.TimeAt{},
+		mixin.OperatorID{},
+		mixin.TenantID[uint32]{},
+	}
+}
+
+func (TranslationJob) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("tenant_id", "entity_type", "entity_id"),
+		index.Fields("tenant_id", "status"),
+	}
+}
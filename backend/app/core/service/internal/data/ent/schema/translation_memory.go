@@ -0,0 +1,104 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/tx7do/go-crud/entgo/mixin"
+)
+
+// TranslationMemory holds the schema definition for the TranslationMemory entity.
+//
+// 翻译记忆：按租户与语言对保存句段级的原文/译文对，机器翻译时按原文哈希精确匹配复用。
+type TranslationMemory struct {
+	ent.Schema
+}
+
+func (TranslationMemory) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{
+			Table:     "translation_memories",
+			Charset:   "utf8mb4",
+			Collation: "utf8mb4_bin",
+		},
+		entsql.WithComments(true),
+		schema.Comment("翻译记忆表"),
+	}
+}
+
+// Fields of the TranslationMemory.
+func (TranslationMemory) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("source_language").
+			Comment("源语言代码").
+			NotEmpty().
+			MaxLen(32).
+			Optional().
+			Nillable(),
+
+		field.String("target_language").
+			Comment("目标语言代码").
+			NotEmpty().
+			MaxLen(32).
+			Optional().
+			Nillable(),
+
+		field.Text("source_text").
+			Comment("原文句段").
+			Optional().
+			Nillable(),
+
+		field.Text("target_text").
+			Comment("译文句段").
+			Optional().
+			Nillable(),
+
+		field.String("source_hash").
+			Comment("原文句段的 SHA-256，用于精确匹配").
+			MaxLen(64).
+			Optional().
+			Nillable(),
+
+		field.Enum("origin").
+			Comment("来源").
+			NamedValues(
+				"OriginManual", "ORIGIN_MANUAL",
+				"OriginMachine", "ORIGIN_MACHINE",
+				"OriginImport", "ORIGIN_IMPORT",
+			).
+			Default("ORIGIN_MANUAL").
+			Optional().
+			Nillable(),
+
+		field.Uint32("hit_count").
+			Comment("被机器翻译复用的次数").
+			Default(0).
+			Optional().
+			Nillable(),
+
+		field.Time("last_used_at").
+			Comment("最近一次被复用的时间").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Mixin of the TranslationMemory.
+func (TranslationMemory) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixin.AutoIncrementId{},
+		mixin.TimeAt{},
+		mixin.OperatorID{},
+		mixin.TenantID[uint32]{},
+	}
+}
+
+func (TranslationMemory) Indexes() []ent.Index {
+	return []ent.Index{
+		// 同一租户、同一语言对下原文唯一，也用于机器翻译时的精确匹配
+		index.Fields("tenant_id", "source_language", "target_language", "source_hash").
+			Unique(),
+	}
+}
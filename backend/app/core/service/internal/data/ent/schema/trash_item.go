@@ -0,0 +1,89 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/tx7do/go-crud/entgo/mixin"
+)
+
+// TrashItem holds the schema definition for the TrashItem entity.
+//
+// 回收站条目：内容删除时只标记 deleted_at 并在此登记，恢复时删除条目，
+// 彻底清理（手动或按保留期定时）时连同内容一并物理删除。
+type TrashItem struct {
+	ent.Schema
+}
+
+func (TrashItem) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{
+			Table:     "trash_items",
+			Charset:   "utf8mb4",
+			Collation: "utf8mb4_bin",
+		},
+		entsql.WithComments(true),
+		schema.Comment("回收站表"),
+	}
+}
+
+// Fields of the TrashItem.
+func (TrashItem) Fields() []ent.Field {
+	return []ent.Field{
+		field.Enum("entity_type").
+			Comment("内容类型").
+			NamedValues(
+				"EntityTypePost", "ENTITY_TYPE_POST",
+				"EntityTypePage", "ENTITY_TYPE_PAGE",
+				"EntityTypeSection", "ENTITY_TYPE_SECTION",
+				"EntityTypeCategory", "ENTITY_TYPE_CATEGORY",
+				"EntityTypeTag", "ENTITY_TYPE_TAG",
+				"EntityTypeMediaAsset", "ENTITY_TYPE_MEDIA_ASSET",
+			).
+			Immutable(),
+
+		field.Uint32("entity_id").
+			Comment("内容ID").
+			Immutable(),
+
+		field.String("title").
+			Comment("删除时的标题快照").
+			MaxLen(255).
+			Optional().
+			Nillable().
+			Immutable(),
+
+		field.String("previous_status").
+			Comment("删除前的状态，恢复时还原").
+			MaxLen(64).
+			Optional().
+			Nillable().
+			Immutable(),
+
+		field.Uint32("deleted_by").
+			Comment("删除者用户ID").
+			Optional().
+			Nillable().
+			Immutable(),
+	}
+}
+
+// Mixin of the TrashItem.
+func (TrashItem) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixin.AutoIncrementId{},
+		mixin.CreatedAt{},
+		mixin.TenantID[uint32]{},
+	}
+}
+
+func (TrashItem) Indexes() []ent.Index {
+	return []ent.Index{
+		// 同一内容在回收站中只有一条记录
+		index.Fields("entity_type", "entity_id").Unique(),
+		// 回收站列表与按保留期清理
+		index.Fields("tenant_id", "created_at"),
+	}
+}
@@ -0,0 +1,91 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/tx7do/go-crud/entgo/mixin"
+
+	"go-wind-cms/pkg/content/workflow"
+)
+
+// Workflow holds the schema definition for the Workflow entity.
+//
+// 编辑工作流：租户自定义的文章审阅阶段与流转规则，按帖子类型挂载。
+type Workflow struct {
+	ent.Schema
+}
+
+func (Workflow) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{
+			Table:     "workflows",
+			Charset:   "utf8mb4",
+			Collation: "utf8mb4_bin",
+		},
+		entsql.WithComments(true),
+		schema.Comment("编辑工作流表"),
+	}
+}
+
+// Fields of the Workflow.
+func (Workflow) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").
+			Comment("工作流名称").
+			NotEmpty().
+			MaxLen(128).
+			Optional().
+			Nillable(),
+
+		field.String("description").
+			Comment("描述").
+			MaxLen(1024).
+			Optional().
+			Nillable(),
+
+		field.String("initial_stage").
+			Comment("新建文章的初始阶段编码").
+			MaxLen(32).
+			Optional().
+			Nillable(),
+
+		field.JSON("stages", []workflow.Stage{}).
+			Comment("阶段定义").
+			Optional(),
+
+		field.JSON("transitions", []workflow.Transition{}).
+			Comment("流转定义").
+			Optional(),
+
+		field.JSON("post_types", []string{}).
+			Comment("挂载的帖子类型，为空表示租户内的默认工作流").
+			Optional(),
+
+		field.Bool("enabled").
+			Comment("是否启用").
+			Default(true).
+			Optional().
+			Nillable(),
+	}
+}
+
+// Mixin of the Workflow.
+func (Workflow) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixin.AutoIncrementId{},
+		mixin.TimeAt{},
+		mixin.OperatorID{},
+		mixin.SortOrder{},
+		mixin.TenantID[uint32]{},
+	}
+}
+
+func (Workflow) Indexes() []ent.Index {
+	return []ent.Index{
+		// 新建文章时按租户加载启用的工作流
+		index.Fields("tenant_id", "enabled"),
+	}
+}
@@ -0,0 +1,368 @@
+package data
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/tx7do/go-utils/copierutil"
+	"github.com/tx7do/go-utils/mapper"
+	"github.com/tx7do/go-utils/trans"
+
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	entCrud "github.com/tx7do/go-crud/entgo"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/fieldgroup"
+	"go-wind-cms/app/core/service/internal/data/ent/predicate"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/customfield"
+)
+
+// FieldTarget 帖子/页面匹配字段组所需的属性
+type FieldTarget struct {
+	PostType     string
+	CategoryIDs  []uint32
+	PageTemplate string
+}
+
+// FieldGroupRepo 自定义字段组
+type FieldGroupRepo struct {
+	entClient *entCrud.EntClient[*ent.Client]
+	log       *log.Helper
+
+	mapper *mapper.CopierMapper[contentV1.FieldGroup, ent.FieldGroup]
+
+	repository *entCrud.Repository[
+		ent.FieldGroupQuery, ent.FieldGroupSelect,
+		ent.FieldGroupCreate, ent.FieldGroupCreateBulk,
+		ent.FieldGroupUpdate, ent.FieldGroupUpdateOne,
+		ent.FieldGroupDelete,
+		predicate.FieldGroup,
+		contentV1.FieldGroup, ent.FieldGroup,
+	]
+
+	locationConverter *mapper.EnumTypeConverter[contentV1.FieldGroup_Location, fieldgroup.Location]
+}
+
+func NewFieldGroupRepo(ctx *bootstrap.Context, entClient *entCrud.EntClient[*ent.Client]) *FieldGroupRepo {
+	repo := &FieldGroupRepo{
+		entClient: entClient,
+		log:       ctx.NewLoggerHelper("field-group/repo/core-service"),
+		mapper:    mapper.NewCopierMapper[contentV1.FieldGroup, ent.FieldGroup](),
+		locationConverter: mapper.NewEnumTypeConverter[contentV1.FieldGroup_Location, fieldgroup.Location](
+			contentV1.FieldGroup_Location_name, contentV1.FieldGroup_Location_value,
+		),
+	}
+
+	repo.init()
+
+	return repo
+}
+
+func (r *FieldGroupRepo) init() {
+	r.repository = entCrud.NewRepository[
+		ent.FieldGroupQuery, ent.FieldGroupSelect,
+		ent.FieldGroupCreate, ent.FieldGroupCreateBulk,
+		ent.FieldGroupUpdate, ent.FieldGroupUpdateOne,
+		ent.FieldGroupDelete,
+		predicate.FieldGroup,
+		contentV1.FieldGroup, ent.FieldGroup,
+	](r.mapper)
+
+	r.mapper.AppendConverters(copierutil.NewTimeStringConverterPair())
+	r.mapper.AppendConverters(copierutil.NewTimeTimestamppbConverterPair())
+
+	r.mapper.AppendConverters(r.locationConverter.NewConverterPair())
+	r.mapper.AppendConverters(newCustomFieldDefinitionsConverterPair())
+}
+
+func (r *FieldGroupRepo) List(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListFieldGroupResponse, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	builder := r.entClient.Client().FieldGroup.Query()
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(fieldgroup.TenantIDEQ(tid))
+	}
+
+	ret, err := r.repository.ListWithPaging(ctx, builder, builder.Clone(), req)
+	if err != nil {
+		return nil, err
+	}
+	if ret == nil {
+		return &contentV1.ListFieldGroupResponse{Total: 0, Items: nil}, nil
+	}
+
+	return &contentV1.ListFieldGroupResponse{
+		Total: ret.Total,
+		Items: ret.Items,
+	}, nil
+}
+
+func (r *FieldGroupRepo) Get(ctx context.Context, req *contentV1.GetFieldGroupRequest) (*contentV1.FieldGroup, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	entity, err := r.get(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return r.mapper.ToDTO(entity), nil
+}
+
+func (r *FieldGroupRepo) get(ctx context.Context, id uint32) (*ent.FieldGroup, error) {
+	builder := r.entClient.Client().FieldGroup.Query().
+		Where(fieldgroup.IDEQ(id))
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(fieldgroup.TenantIDEQ(tid))
+	}
+
+	entity, err := builder.Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound("field group not found")
+		}
+		r.log.Errorf("query field group failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query field group failed")
+	}
+
+	return entity, nil
+}
+
+// checkDefinitions 校验字段定义，并要求同一挂载位置下同名字段的类型一致，
+// 否则多个字段组同时命中时列表的过滤/排序语义不确定。
+func (r *FieldGroupRepo) checkDefinitions(ctx context.Context, tenantID uint32, excludeID uint32, location fieldgroup.Location, defs []customfield.Definition) error {
+	if err := customfield.ValidateDefinitions(defs); err != nil {
+		return contentV1.ErrorBadRequest("invalid field definition %s", err.Error())
+	}
+
+	builder := r.entClient.Client().FieldGroup.Query().
+		Where(
+			fieldgroup.TenantIDEQ(tenantID),
+			fieldgroup.LocationEQ(location),
+		)
+	if excludeID != 0 {
+		builder.Where(fieldgroup.IDNEQ(excludeID))
+	}
+	others, err := builder.All(ctx)
+	if err != nil {
+		r.log.Errorf("query field groups failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("query field groups failed")
+	}
+
+	types := make(map[string]customfield.Type)
+	for _, other := range others {
+		for _, def := range other.Fields {
+			types[def.Key] = def.Type
+		}
+	}
+	for _, def := range defs {
+		if t, ok := types[def.Key]; ok && t != def.Type {
+			return contentV1.ErrorConflict("field %s is already defined as %s in another field group", def.Key, t)
+		}
+	}
+
+	return nil
+}
+
+func (r *FieldGroupRepo) Create(ctx context.Context, req *contentV1.CreateFieldGroupRequest) (*contentV1.FieldGroup, error) {
+	if req == nil || req.Data == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	tid, _ := maybeTenantFromViewer(ctx)
+	location := fieldgroup.LocationLocationPost
+	if l := r.locationConverter.ToEntity(req.Data.Location); l != nil {
+		location = *l
+	}
+	defs := customFieldDefinitionsFromProto(req.Data.GetFields())
+	if err := r.checkDefinitions(ctx, tid, 0, location, defs); err != nil {
+		return nil, err
+	}
+
+	builder := r.entClient.Client().FieldGroup.Create().
+		SetNillableName(req.Data.Name).
+		SetNillableDescription(req.Data.Description).
+		SetLocation(location).
+		SetPostTypes(req.Data.GetPostTypes()).
+		SetCategoryIds(req.Data.GetCategoryIds()).
+		SetPageTemplates(req.Data.GetPageTemplates()).
+		SetFields(defs).
+		SetNillableEnabled(req.Data.Enabled).
+		SetNillableSortOrder(req.Data.SortOrder).
+		SetNillableCreatedBy(req.Data.CreatedBy).
+		SetCreatedAt(time.Now())
+	if tid != 0 {
+		builder.SetTenantID(tid)
+	}
+
+	entity, err := builder.Save(ctx)
+	if err != nil {
+		r.log.Errorf("insert field group failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("insert field group failed")
+	}
+
+	return r.mapper.ToDTO(entity), nil
+}
+
+func (r *FieldGroupRepo) Update(ctx context.Context, req *contentV1.UpdateFieldGroupRequest) (*contentV1.FieldGroup, error) {
+	if req == nil || req.Data == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	// 字段定义与挂载位置共同决定同名字段的类型约束，按合并后的结果校验
+	current, err := r.get(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	location := fieldgroup.LocationLocationPost
+	if current.Location != nil {
+		location = *current.Location
+	}
+	if l := r.locationConverter.ToEntity(req.Data.Location); l != nil {
+		location = *l
+	}
+	defs := current.Fields
+	if req.Data.Fields != nil || slices.Contains(req.GetUpdateMask().GetPaths(), "fields") {
+		defs = customFieldDefinitionsFromProto(req.Data.GetFields())
+	}
+	if err = r.checkDefinitions(ctx, trans.Uint32Value(current.TenantID), current.ID, location, defs); err != nil {
+		return nil, err
+	}
+
+	callerUserID, hasUser := viewerUserIDFromContext(ctx)
+
+	builder := r.entClient.Client().FieldGroup.UpdateOneID(req.GetId())
+	return r.repository.UpdateOne(ctx, builder, req.Data, req.GetUpdateMask(),
+		func(dto *contentV1.FieldGroup) {
+			builder.
+				SetNillableName(req.Data.Name).
+				SetNillableDescription(req.Data.Description).
+				SetLocation(location).
+				SetFields(defs).
+				SetNillableEnabled(req.Data.Enabled).
+				SetNillableSortOrder(req.Data.SortOrder).
+				SetUpdatedAt(time.Now())
+			if req.Data.PostTypes != nil {
+				builder.SetPostTypes(req.Data.PostTypes)
+			}
+			if req.Data.CategoryIds != nil {
+				builder.SetCategoryIds(req.Data.CategoryIds)
+			}
+			if req.Data.PageTemplates != nil {
+				builder.SetPageTemplates(req.Data.PageTemplates)
+			}
+
+			// updated_by 强制由服务端 viewer context 推导，忽略客户端传入值
+			if hasUser {
+				builder.SetUpdatedBy(callerUserID)
+			}
+		},
+		func(s *sql.Selector) {
+			s.Where(sql.EQ(fieldgroup.FieldID, req.GetId()))
+		},
+	)
+}
+
+func (r *FieldGroupRepo) Delete(ctx context.Context, req *contentV1.DeleteFieldGroupRequest) error {
+	if req == nil {
+		return contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	builder := r.entClient.Client().FieldGroup.Delete().
+		Where(fieldgroup.IDEQ(req.GetId()))
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(fieldgroup.TenantIDEQ(tid))
+	}
+
+	affected, err := builder.Exec(ctx)
+	if err != nil {
+		r.log.Errorf("delete field group failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("delete field group failed")
+	}
+	if affected == 0 {
+		return contentV1.ErrorNotFound("field group not found")
+	}
+
+	return nil
+}
+
+func (r *FieldGroupRepo) enabledGroups(ctx context.Context, tenantID uint32, location fieldgroup.Location) ([]*ent.FieldGroup, error) {
+	groups, err := r.entClient.Client().FieldGroup.Query().
+		Where(
+			fieldgroup.TenantIDEQ(tenantID),
+			fieldgroup.LocationEQ(location),
+			fieldgroup.EnabledEQ(true),
+		).
+		Order(ent.Asc(fieldgroup.FieldSortOrder), ent.Asc(fieldgroup.FieldID)).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query field groups failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query field groups failed")
+	}
+	return groups, nil
+}
+
+// Definitions 返回命中目标的字段组合并后的字段定义，用于保存帖子/页面时校验字段值。
+//
+//   - 帖子：字段组未限定帖子类型与分类时挂载到全部帖子，否则帖子类型或任一分类命中即可
+//   - 页面：字段组未限定页面模板时挂载到全部页面
+func (r *FieldGroupRepo) Definitions(ctx context.Context, tenantID uint32, location fieldgroup.Location, target FieldTarget) ([]customfield.Definition, error) {
+	groups, err := r.enabledGroups(ctx, tenantID, location)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched [][]customfield.Definition
+	for _, g := range groups {
+		if fieldGroupMatches(g, location, target) {
+			matched = append(matched, g.Fields)
+		}
+	}
+
+	return customfield.Merge(matched...), nil
+}
+
+// AllDefinitions 返回挂载位置下全部启用字段组的字段定义，用于列表按自定义字段过滤与排序
+func (r *FieldGroupRepo) AllDefinitions(ctx context.Context, tenantID uint32, location fieldgroup.Location) ([]customfield.Definition, error) {
+	groups, err := r.enabledGroups(ctx, tenantID, location)
+	if err != nil {
+		return nil, err
+	}
+
+	all := make([][]customfield.Definition, 0, len(groups))
+	for _, g := range groups {
+		all = append(all, g.Fields)
+	}
+
+	return customfield.Merge(all...), nil
+}
+
+func fieldGroupMatches(g *ent.FieldGroup, location fieldgroup.Location, target FieldTarget) bool {
+	if location == fieldgroup.LocationLocationPage {
+		return len(g.PageTemplates) == 0 || slices.Contains(g.PageTemplates, target.PageTemplate)
+	}
+
+	if len(g.PostTypes) == 0 && len(g.CategoryIds) == 0 {
+		return true
+	}
+	if slices.Contains(g.PostTypes, target.PostType) {
+		return true
+	}
+	for _, id := range target.CategoryIDs {
+		if slices.Contains(g.CategoryIds, id) {
+			return true
+		}
+	}
+	return false
+}
@@ -186,6 +186,22 @@ func (r *FileRepo) Get(ctx context.Context, req *storageV1.GetFileRequest) (*sto
 	return dto, err
 }
 
+// GetForTenant 按租户查询文件，供后台任务在 SystemViewer 下读取作业所属租户上传的文件
+func (r *FileRepo) GetForTenant(ctx context.Context, tenantID, id uint32) (*storageV1.File, error) {
+	entity, err := r.entClient.Client().File.Query().
+		Where(file.IDEQ(id), file.TenantIDEQ(tenantID)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, storageV1.ErrorNotFound("file not found")
+		}
+		r.log.Errorf("query file failed: %s", err.Error())
+		return nil, storageV1.ErrorInternalServerError("query file failed")
+	}
+
+	return r.mapper.ToDTO(entity), nil
+}
+
 func (r *FileRepo) Create(ctx context.Context, req *storageV1.CreateFileRequest) (*storageV1.File, error) {
 	if req == nil || req.Data == nil {
 		return nil, storageV1.ErrorBadRequest("invalid parameter")
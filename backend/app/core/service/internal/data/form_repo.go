@@ -0,0 +1,425 @@
+package data
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/jinzhu/copier"
+
+	"github.com/tx7do/go-utils/copierutil"
+	"github.com/tx7do/go-utils/mapper"
+	"github.com/tx7do/go-utils/trans"
+
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	entCrud "github.com/tx7do/go-crud/entgo"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+	entForm "go-wind-cms/app/core/service/internal/data/ent/form"
+	"go-wind-cms/app/core/service/internal/data/ent/formsubmission"
+	"go-wind-cms/app/core/service/internal/data/ent/predicate"
+	"go-wind-cms/app/core/service/internal/data/ent/section"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/customfield"
+	"go-wind-cms/pkg/content/form"
+	"go-wind-cms/pkg/notification"
+)
+
+const (
+	// maxFormRecipients 单个表单最多通知的用户数
+	maxFormRecipients = 20
+	// maxFormWebhooks 单个表单最多回调的 Webhook 数
+	maxFormWebhooks = 5
+)
+
+var (
+	formFieldFormatToProto = map[form.Format]contentV1.FormField_Format{
+		form.FormatEmail: contentV1.FormField_FORMAT_EMAIL,
+		form.FormatURL:   contentV1.FormField_FORMAT_URL,
+		form.FormatPhone: contentV1.FormField_FORMAT_PHONE,
+	}
+	formFieldFormatFromProto = func() map[contentV1.FormField_Format]form.Format {
+		m := make(map[contentV1.FormField_Format]form.Format, len(formFieldFormatToProto))
+		for k, v := range formFieldFormatToProto {
+			m[v] = k
+		}
+		return m
+	}()
+)
+
+// FormRepo 表单定义
+type FormRepo struct {
+	entClient *entCrud.EntClient[*ent.Client]
+	log       *log.Helper
+
+	mapper *mapper.CopierMapper[contentV1.Form, ent.Form]
+
+	repository *entCrud.Repository[
+		ent.FormQuery, ent.FormSelect,
+		ent.FormCreate, ent.FormCreateBulk,
+		ent.FormUpdate, ent.FormUpdateOne,
+		ent.FormDelete,
+		predicate.Form,
+		contentV1.Form, ent.Form,
+	]
+}
+
+func NewFormRepo(ctx *bootstrap.Context, entClient *entCrud.EntClient[*ent.Client]) *FormRepo {
+	repo := &FormRepo{
+		entClient: entClient,
+		log:       ctx.NewLoggerHelper("form/repo/core-service"),
+		mapper:    mapper.NewCopierMapper[contentV1.Form, ent.Form](),
+	}
+
+	repo.init()
+
+	return repo
+}
+
+func (r *FormRepo) init() {
+	r.repository = entCrud.NewRepository[
+		ent.FormQuery, ent.FormSelect,
+		ent.FormCreate, ent.FormCreateBulk,
+		ent.FormUpdate, ent.FormUpdateOne,
+		ent.FormDelete,
+		predicate.Form,
+		contentV1.Form, ent.Form,
+	](r.mapper)
+
+	r.mapper.AppendConverters(copierutil.NewTimeStringConverterPair())
+	r.mapper.AppendConverters(copierutil.NewTimeTimestamppbConverterPair())
+
+	r.mapper.AppendConverters(newFormFieldsConverterPair())
+}
+
+// newFormFieldsConverterPair 表单 fields 列与 API 字段定义互转
+func newFormFieldsConverterPair() []copier.TypeConverter {
+	return copierutil.NewGenericTypeConverterPair(
+		[]form.Field{}, []*contentV1.FormField{},
+		formFieldsToProto, formFieldsFromProto,
+	)
+}
+
+func formFieldsToProto(fields []form.Field) []*contentV1.FormField {
+	if fields == nil {
+		return nil
+	}
+	out := make([]*contentV1.FormField, 0, len(fields))
+	for i := range fields {
+		f := &fields[i]
+		pf := &contentV1.FormField{
+			Definition: customFieldDefinitionsToProto([]customfield.Definition{f.Definition})[0],
+		}
+		if format, ok := formFieldFormatToProto[f.Format]; ok {
+			pf.Format = trans.Ptr(format)
+		}
+		if f.Pattern != "" {
+			pf.Pattern = trans.Ptr(f.Pattern)
+		}
+		if f.Placeholder != "" {
+			pf.Placeholder = trans.Ptr(f.Placeholder)
+		}
+		out = append(out, pf)
+	}
+	return out
+}
+
+func formFieldsFromProto(fields []*contentV1.FormField) []form.Field {
+	if fields == nil {
+		return nil
+	}
+	out := make([]form.Field, 0, len(fields))
+	for _, pf := range fields {
+		f := form.Field{
+			Format:      formFieldFormatFromProto[pf.GetFormat()],
+			Pattern:     pf.GetPattern(),
+			Placeholder: pf.GetPlaceholder(),
+		}
+		if pf.GetDefinition() != nil {
+			f.Definition = customFieldDefinitionsFromProto([]*contentV1.CustomFieldDefinition{pf.GetDefinition()})[0]
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+// toDTO 转换为 API 对象；Webhook 密钥只写不读，仅返回是否已设置
+func (r *FormRepo) toDTO(entity *ent.Form) *contentV1.Form {
+	dto := r.mapper.ToDTO(entity)
+	if dto == nil {
+		return nil
+	}
+	dto.WebhookSecret = nil
+	dto.WebhookSecretSet = trans.Ptr(trans.StringValue(entity.WebhookSecret) != "")
+	return dto
+}
+
+// checkForm 校验表单定义：字段、蜜罐字段名、通知接收人与 Webhook 地址
+func checkForm(fields []form.Field, honeypot string, recipients []uint32, webhooks []string) error {
+	if err := form.ValidateFields(fields); err != nil {
+		return contentV1.ErrorBadRequest("invalid form field %s", err.Error())
+	}
+	if honeypot != "" && slices.ContainsFunc(fields, func(f form.Field) bool { return f.Key == honeypot }) {
+		return contentV1.ErrorBadRequest("honeypot field %s conflicts with a form field", honeypot)
+	}
+	if len(recipients) > maxFormRecipients {
+		return contentV1.ErrorBadRequest("too many recipients (max %d)", maxFormRecipients)
+	}
+	if len(webhooks) > maxFormWebhooks {
+		return contentV1.ErrorBadRequest("too many webhooks (max %d)", maxFormWebhooks)
+	}
+	for _, u := range webhooks {
+		if err := notification.ValidateWebhookURL(u); err != nil {
+			return contentV1.ErrorBadRequest("%s", err.Error())
+		}
+	}
+	return nil
+}
+
+func (r *FormRepo) List(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListFormResponse, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	builder := r.entClient.Client().Form.Query()
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(entForm.TenantIDEQ(tid))
+	}
+
+	ret, err := r.repository.ListWithPaging(ctx, builder, builder.Clone(), req)
+	if err != nil {
+		return nil, err
+	}
+	if ret == nil {
+		return &contentV1.ListFormResponse{Total: 0, Items: nil}, nil
+	}
+
+	for _, item := range ret.Items {
+		item.WebhookSecretSet = trans.Ptr(item.GetWebhookSecret() != "")
+		item.WebhookSecret = nil
+	}
+
+	return &contentV1.ListFormResponse{
+		Total: ret.Total,
+		Items: ret.Items,
+	}, nil
+}
+
+func (r *FormRepo) Get(ctx context.Context, req *contentV1.GetFormRequest) (*contentV1.Form, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	entity, err := r.GetEntity(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toDTO(entity), nil
+}
+
+// GetEntity 查询调用方租户下的表单，供提交与通知读取完整定义（含 Webhook 密钥）
+func (r *FormRepo) GetEntity(ctx context.Context, id uint32) (*ent.Form, error) {
+	builder := r.entClient.Client().Form.Query().
+		Where(entForm.IDEQ(id))
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(entForm.TenantIDEQ(tid))
+	}
+
+	entity, err := builder.Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound("form not found")
+		}
+		r.log.Errorf("query form failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query form failed")
+	}
+
+	return entity, nil
+}
+
+func (r *FormRepo) Create(ctx context.Context, req *contentV1.CreateFormRequest) (*contentV1.Form, error) {
+	if req == nil || req.Data == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	fields := formFieldsFromProto(req.Data.GetFields())
+	honeypot := strings.TrimSpace(req.Data.GetHoneypotField())
+	if err := checkForm(fields, honeypot, req.Data.GetRecipientUserIds(), req.Data.GetWebhookUrls()); err != nil {
+		return nil, err
+	}
+
+	builder := r.entClient.Client().Form.Create().
+		SetNillableName(req.Data.Name).
+		SetNillableDescription(req.Data.Description).
+		SetFields(fields).
+		SetNillableCaptchaRequired(req.Data.CaptchaRequired).
+		SetNillableSuccessMessage(req.Data.SuccessMessage).
+		SetRecipientUserIds(req.Data.GetRecipientUserIds()).
+		SetWebhookUrls(req.Data.GetWebhookUrls()).
+		SetNillableWebhookSecret(req.Data.WebhookSecret).
+		SetNillableEnabled(req.Data.Enabled).
+		SetNillableCreatedBy(req.Data.CreatedBy).
+		SetCreatedAt(time.Now())
+	if honeypot != "" {
+		builder.SetHoneypotField(honeypot)
+	}
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.SetTenantID(tid)
+	}
+
+	entity, err := builder.Save(ctx)
+	if err != nil {
+		r.log.Errorf("insert form failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("insert form failed")
+	}
+
+	return r.toDTO(entity), nil
+}
+
+func (r *FormRepo) Update(ctx context.Context, req *contentV1.UpdateFormRequest) (*contentV1.Form, error) {
+	if req == nil || req.Data == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	// 字段、蜜罐字段名与通知配置相互约束，按合并后的结果校验
+	current, err := r.GetEntity(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	paths := req.GetUpdateMask().GetPaths()
+
+	fields := current.Fields
+	if req.Data.Fields != nil || slices.Contains(paths, "fields") {
+		fields = formFieldsFromProto(req.Data.GetFields())
+	}
+	honeypot := trans.StringValue(current.HoneypotField)
+	if req.Data.HoneypotField != nil {
+		honeypot = strings.TrimSpace(req.Data.GetHoneypotField())
+	}
+	recipients := current.RecipientUserIds
+	if req.Data.RecipientUserIds != nil || slices.Contains(paths, "recipient_user_ids") {
+		recipients = req.Data.GetRecipientUserIds()
+	}
+	webhooks := current.WebhookUrls
+	if req.Data.WebhookUrls != nil || slices.Contains(paths, "webhook_urls") {
+		webhooks = req.Data.GetWebhookUrls()
+	}
+	if err = checkForm(fields, honeypot, recipients, webhooks); err != nil {
+		return nil, err
+	}
+
+	callerUserID, hasUser := viewerUserIDFromContext(ctx)
+
+	builder := r.entClient.Client().Form.UpdateOneID(req.GetId())
+	dto, err := r.repository.UpdateOne(ctx, builder, req.Data, req.GetUpdateMask(),
+		func(dto *contentV1.Form) {
+			builder.
+				SetNillableName(req.Data.Name).
+				SetNillableDescription(req.Data.Description).
+				SetFields(fields).
+				SetNillableCaptchaRequired(req.Data.CaptchaRequired).
+				SetNillableSuccessMessage(req.Data.SuccessMessage).
+				SetRecipientUserIds(recipients).
+				SetWebhookUrls(webhooks).
+				SetNillableEnabled(req.Data.Enabled).
+				SetUpdatedAt(time.Now())
+			if req.Data.HoneypotField != nil {
+				builder.SetHoneypotField(honeypot)
+			}
+			// 密钥只写不读：显式传入空串时清除
+			if req.Data.WebhookSecret != nil {
+				if req.Data.GetWebhookSecret() == "" {
+					builder.ClearWebhookSecret()
+				} else {
+					builder.SetWebhookSecret(req.Data.GetWebhookSecret())
+				}
+			}
+
+			// updated_by 强制由服务端 viewer context 推导，忽略客户端传入值
+			if hasUser {
+				builder.SetUpdatedBy(callerUserID)
+			}
+		},
+		func(s *sql.Selector) {
+			s.Where(sql.EQ(entForm.FieldID, req.GetId()))
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	dto.WebhookSecretSet = trans.Ptr(dto.GetWebhookSecret() != "")
+	dto.WebhookSecret = nil
+	return dto, nil
+}
+
+// Delete 删除表单及其提交记录；仍被未删除的表单区块引用时拒绝删除
+func (r *FormRepo) Delete(ctx context.Context, req *contentV1.DeleteFormRequest) (err error) {
+	if req == nil {
+		return contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	if _, err = r.GetEntity(ctx, req.GetId()); err != nil {
+		return err
+	}
+
+	formID := strconv.FormatUint(uint64(req.GetId()), 10)
+	refs, err := r.entClient.Client().Section.Query().
+		Where(
+			section.TypeEQ(section.TypeSectionTypeForm),
+			section.DeletedAtIsNil(),
+			predicate.Section(func(s *sql.Selector) {
+				s.Where(sqljson.ValueEQ(s.C(section.FieldConfig), formID, sqljson.Path("form_id")))
+			}),
+		).
+		Count(ctx)
+	if err != nil {
+		r.log.Errorf("count form references failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("count form references failed")
+	}
+	if refs > 0 {
+		return contentV1.ErrorConflict("form is still used by %d section(s)", refs)
+	}
+
+	var tx *ent.Tx
+	tx, err = r.entClient.Client().Tx(ctx)
+	if err != nil {
+		r.log.Errorf("start transaction failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("start transaction failed")
+	}
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				r.log.Errorf("transaction rollback failed: %s", rollbackErr.Error())
+			}
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			r.log.Errorf("transaction commit failed: %s", commitErr.Error())
+			err = contentV1.ErrorInternalServerError("transaction commit failed")
+		}
+	}()
+
+	if _, err = tx.FormSubmission.Delete().
+		Where(formsubmission.FormIDEQ(req.GetId())).
+		Exec(ctx); err != nil {
+		r.log.Errorf("delete form submissions failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("delete form submissions failed")
+	}
+
+	if err = tx.Form.DeleteOneID(req.GetId()).Exec(ctx); err != nil {
+		r.log.Errorf("delete form failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("delete form failed")
+	}
+
+	return nil
+}
@@ -0,0 +1,261 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/tx7do/go-utils/copierutil"
+	"github.com/tx7do/go-utils/mapper"
+	"github.com/tx7do/go-utils/trans"
+
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	entCrud "github.com/tx7do/go-crud/entgo"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/formsubmission"
+	"go-wind-cms/app/core/service/internal/data/ent/predicate"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/customfield"
+	"go-wind-cms/pkg/content/form"
+)
+
+// FormSubmissionRepo 表单提交记录
+type FormSubmissionRepo struct {
+	entClient *entCrud.EntClient[*ent.Client]
+	log       *log.Helper
+
+	mapper *mapper.CopierMapper[contentV1.FormSubmission, ent.FormSubmission]
+
+	repository *entCrud.Repository[
+		ent.FormSubmissionQuery, ent.FormSubmissionSelect,
+		ent.FormSubmissionCreate, ent.FormSubmissionCreateBulk,
+		ent.FormSubmissionUpdate, ent.FormSubmissionUpdateOne,
+		ent.FormSubmissionDelete,
+		predicate.FormSubmission,
+		contentV1.FormSubmission, ent.FormSubmission,
+	]
+}
+
+func NewFormSubmissionRepo(ctx *bootstrap.Context, entClient *entCrud.EntClient[*ent.Client]) *FormSubmissionRepo {
+	repo := &FormSubmissionRepo{
+		entClient: entClient,
+		log:       ctx.NewLoggerHelper("form-submission/repo/core-service"),
+		mapper:    mapper.NewCopierMapper[contentV1.FormSubmission, ent.FormSubmission](),
+	}
+
+	repo.init()
+
+	return repo
+}
+
+func (r *FormSubmissionRepo) init() {
+	r.repository = entCrud.NewRepository[
+		ent.FormSubmissionQuery, ent.FormSubmissionSelect,
+		ent.FormSubmissionCreate, ent.FormSubmissionCreateBulk,
+		ent.FormSubmissionUpdate, ent.FormSubmissionUpdateOne,
+		ent.FormSubmissionDelete,
+		predicate.FormSubmission,
+		contentV1.FormSubmission, ent.FormSubmission,
+	](r.mapper)
+
+	r.mapper.AppendConverters(copierutil.NewTimeStringConverterPair())
+	r.mapper.AppendConverters(copierutil.NewTimeTimestamppbConverterPair())
+}
+
+// toDTO 转换为 API 对象；字段值列名为 data，与 API 的 values 不同名，单独转换
+func (r *FormSubmissionRepo) toDTO(entity *ent.FormSubmission) *contentV1.FormSubmission {
+	dto := r.mapper.ToDTO(entity)
+	if dto == nil {
+		return nil
+	}
+	dto.Values = customFieldValuesToProto(entity.Data)
+	return dto
+}
+
+func (r *FormSubmissionRepo) List(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListFormSubmissionResponse, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	builder := r.entClient.Client().FormSubmission.Query()
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(formsubmission.TenantIDEQ(tid))
+	}
+
+	ret, err := r.repository.ListWithPaging(ctx, builder, builder.Clone(), req)
+	if err != nil {
+		return nil, err
+	}
+	if ret == nil {
+		return &contentV1.ListFormSubmissionResponse{Total: 0, Items: nil}, nil
+	}
+
+	// 分页结果经通用映射得到，字段值需按 ID 回填
+	if len(ret.Items) > 0 {
+		ids := make([]uint32, 0, len(ret.Items))
+		for _, item := range ret.Items {
+			ids = append(ids, item.GetId())
+		}
+		entities, err := r.entClient.Client().FormSubmission.Query().
+			Where(formsubmission.IDIn(ids...)).
+			Select(formsubmission.FieldID, formsubmission.FieldData).
+			All(ctx)
+		if err != nil {
+			r.log.Errorf("query form submission values failed: %s", err.Error())
+			return nil, contentV1.ErrorInternalServerError("query form submission values failed")
+		}
+		values := make(map[uint32]map[string]*customfield.Value, len(entities))
+		for _, e := range entities {
+			values[e.ID] = e.Data
+		}
+		for _, item := range ret.Items {
+			item.Values = customFieldValuesToProto(values[item.GetId()])
+		}
+	}
+
+	return &contentV1.ListFormSubmissionResponse{
+		Total: ret.Total,
+		Items: ret.Items,
+	}, nil
+}
+
+// FormNotifyTarget 表单提交通知所需的数据
+type FormNotifyTarget struct {
+	Form       *ent.Form
+	Submission *ent.FormSubmission
+}
+
+// ResolveNotify 查询提交及其表单，供通知任务使用（调用方为系统身份，不按租户过滤）；
+// 提交或表单已删除时返回 nil
+func (r *FormSubmissionRepo) ResolveNotify(ctx context.Context, id uint32) (*FormNotifyTarget, error) {
+	submission, err := r.entClient.Client().FormSubmission.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		r.log.Errorf("query form submission failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query form submission failed")
+	}
+
+	f, err := r.entClient.Client().Form.Get(ctx, submission.FormID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		r.log.Errorf("query form failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query form failed")
+	}
+
+	return &FormNotifyTarget{Form: f, Submission: submission}, nil
+}
+
+// Create 按表单定义校验字段值后保存一次提交，租户取自表单
+func (r *FormSubmissionRepo) Create(ctx context.Context, f *ent.Form, req *contentV1.SubmitFormRequest) (*contentV1.FormSubmission, error) {
+	values := customFieldValuesFromProto(req.GetValues())
+	if values == nil {
+		values = map[string]*customfield.Value{}
+	}
+	if err := form.Validate(f.Fields, values); err != nil {
+		return nil, contentV1.ErrorBadRequest("invalid form value %s", err.Error())
+	}
+
+	builder := r.entClient.Client().FormSubmission.Create().
+		SetFormID(f.ID).
+		SetData(values).
+		SetNillablePageID(req.PageId).
+		SetNillableSectionID(req.SectionId).
+		SetNillableClientIP(req.ClientIp).
+		SetNillableUserAgent(truncateUserAgent(req.UserAgent)).
+		SetCreatedAt(time.Now())
+	if f.TenantID != nil {
+		builder.SetTenantID(*f.TenantID)
+	}
+	if uid, hasUser := viewerUserIDFromContext(ctx); hasUser {
+		builder.SetUserID(uid)
+	}
+
+	entity, err := builder.Save(ctx)
+	if err != nil {
+		r.log.Errorf("insert form submission failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("insert form submission failed")
+	}
+
+	return r.toDTO(entity), nil
+}
+
+// truncateUserAgent User-Agent 超出列宽时截断
+func truncateUserAgent(ua *string) *string {
+	if ua == nil {
+		return nil
+	}
+	runes := []rune(*ua)
+	if len(runes) <= 512 {
+		return ua
+	}
+	return trans.Ptr(string(runes[:512]))
+}
+
+// ListForExport 按提交时间升序列出表单的提交记录，最多 form.MaxExportRows 条
+func (r *FormSubmissionRepo) ListForExport(ctx context.Context, formID uint32, start, end *time.Time) ([]form.Submission, error) {
+	builder := r.entClient.Client().FormSubmission.Query().
+		Where(formsubmission.FormIDEQ(formID))
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(formsubmission.TenantIDEQ(tid))
+	}
+	if start != nil {
+		builder.Where(formsubmission.CreatedAtGTE(*start))
+	}
+	if end != nil {
+		builder.Where(formsubmission.CreatedAtLT(*end))
+	}
+
+	entities, err := builder.
+		Order(ent.Asc(formsubmission.FieldCreatedAt), ent.Asc(formsubmission.FieldID)).
+		Limit(form.MaxExportRows).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query form submissions failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query form submissions failed")
+	}
+
+	rows := make([]form.Submission, 0, len(entities))
+	for _, e := range entities {
+		rows = append(rows, form.Submission{
+			ID:          e.ID,
+			SubmittedAt: trans.TimeValue(e.CreatedAt),
+			PageID:      trans.Uint32Value(e.PageID),
+			Values:      e.Data,
+		})
+	}
+	return rows, nil
+}
+
+// ClaimNotify 标记提交已通知，返回 false 表示已被其他任务标记（重复入队）
+func (r *FormSubmissionRepo) ClaimNotify(ctx context.Context, id uint32) (bool, error) {
+	affected, err := r.entClient.Client().FormSubmission.Update().
+		Where(
+			formsubmission.IDEQ(id),
+			formsubmission.NotifiedAtIsNil(),
+		).
+		SetNotifiedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		r.log.Errorf("mark form submission [%d] notified failed: %s", id, err.Error())
+		return false, contentV1.ErrorInternalServerError("mark form submission notified failed")
+	}
+	return affected > 0, nil
+}
+
+// UnclaimNotify 清除已通知标记，用于投递失败后允许任务重试
+func (r *FormSubmissionRepo) UnclaimNotify(ctx context.Context, id uint32) {
+	if err := r.entClient.Client().FormSubmission.UpdateOneID(id).
+		ClearNotifiedAt().
+		Exec(ctx); err != nil {
+		r.log.Warnf("unmark form submission [%d] notified failed: %s", id, err.Error())
+	}
+}
@@ -0,0 +1,497 @@
+package data
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/tx7do/go-utils/copierutil"
+	"github.com/tx7do/go-utils/mapper"
+	"github.com/tx7do/go-utils/trans"
+
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	entCrud "github.com/tx7do/go-crud/entgo"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/glossaryterm"
+	"go-wind-cms/app/core/service/internal/data/ent/predicate"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/locale"
+	"go-wind-cms/pkg/content/mt"
+)
+
+const (
+	// maxGlossaryTermLength 术语与译法的最大长度
+	maxGlossaryTermLength = 255
+
+	// maxGlossaryTermsPerPair 机器翻译时单个语言对加载的术语上限
+	maxGlossaryTermsPerPair = 5000
+)
+
+// GlossaryTermImport 待导入的术语
+type GlossaryTermImport struct {
+	SourceLanguage string
+	TargetLanguage string
+	Term           string
+	Translation    string
+	DoNotTranslate bool
+	CaseSensitive  bool
+}
+
+// GlossaryTermRepo 租户术语表：后台维护、TMX 导入导出，以及机器翻译时按语言对加载。
+type GlossaryTermRepo struct {
+	entClient *entCrud.EntClient[*ent.Client]
+	log       *log.Helper
+
+	mapper *mapper.CopierMapper[contentV1.GlossaryTerm, ent.GlossaryTerm]
+
+	repository *entCrud.Repository[
+		ent.GlossaryTermQuery, ent.GlossaryTermSelect,
+		ent.GlossaryTermCreate, ent.GlossaryTermCreateBulk,
+		ent.GlossaryTermUpdate, ent.GlossaryTermUpdateOne,
+		ent.GlossaryTermDelete,
+		predicate.GlossaryTerm,
+		contentV1.GlossaryTerm, ent.GlossaryTerm,
+	]
+
+	typeConverter *mapper.EnumTypeConverter[contentV1.GlossaryTerm_Type, glossaryterm.Type]
+}
+
+func NewGlossaryTermRepo(ctx *bootstrap.Context, entClient *entCrud.EntClient[*ent.Client]) *GlossaryTermRepo {
+	repo := &GlossaryTermRepo{
+		entClient: entClient,
+		log:       ctx.NewLoggerHelper("glossary-term/repo/core-service"),
+		mapper:    mapper.NewCopierMapper[contentV1.GlossaryTerm, ent.GlossaryTerm](),
+		typeConverter: mapper.NewEnumTypeConverter[contentV1.GlossaryTerm_Type, glossaryterm.Type](
+			contentV1.GlossaryTerm_Type_name, contentV1.GlossaryTerm_Type_value,
+		),
+	}
+
+	repo.init()
+
+	return repo
+}
+
+func (r *GlossaryTermRepo) init() {
+	r.repository = entCrud.NewRepository[
+		ent.GlossaryTermQuery, ent.GlossaryTermSelect,
+		ent.GlossaryTermCreate, ent.GlossaryTermCreateBulk,
+		ent.GlossaryTermUpdate, ent.GlossaryTermUpdateOne,
+		ent.GlossaryTermDelete,
+		predicate.GlossaryTerm,
+		contentV1.GlossaryTerm, ent.GlossaryTerm,
+	](r.mapper)
+
+	r.mapper.AppendConverters(copierutil.NewTimeStringConverterPair())
+	r.mapper.AppendConverters(copierutil.NewTimeTimestamppbConverterPair())
+
+	r.mapper.AppendConverters(r.typeConverter.NewConverterPair())
+}
+
+// normalizeGlossaryTerm 校验语言代码与术语；不翻译术语不保留译法，强制译法必须填写译法
+func normalizeGlossaryTerm(data *contentV1.GlossaryTerm) error {
+	if !locale.ValidCode(data.GetSourceLanguage()) {
+		return contentV1.ErrorBadRequest("invalid glossary source language")
+	}
+	if data.GetTargetLanguage() != "" {
+		if !locale.ValidCode(data.GetTargetLanguage()) {
+			return contentV1.ErrorBadRequest("invalid glossary target language")
+		}
+		if strings.EqualFold(data.GetSourceLanguage(), data.GetTargetLanguage()) {
+			return contentV1.ErrorBadRequest("glossary source and target language must differ")
+		}
+	}
+	data.TargetLanguage = trans.Ptr(data.GetTargetLanguage())
+
+	term := strings.TrimSpace(data.GetTerm())
+	if term == "" || len(term) > maxGlossaryTermLength {
+		return contentV1.ErrorBadRequest("invalid glossary term")
+	}
+	data.Term = trans.Ptr(term)
+
+	switch data.GetType() {
+	case contentV1.GlossaryTerm_TYPE_FORCED:
+		translation := strings.TrimSpace(data.GetTranslation())
+		if translation == "" || len(translation) > maxGlossaryTermLength {
+			return contentV1.ErrorBadRequest("forced glossary term requires a translation")
+		}
+		if data.GetTargetLanguage() == "" {
+			return contentV1.ErrorBadRequest("forced glossary term requires a target language")
+		}
+		data.Translation = trans.Ptr(translation)
+	default:
+		data.Type = contentV1.GlossaryTerm_TYPE_DO_NOT_TRANSLATE.Enum()
+		data.Translation = nil
+	}
+
+	return nil
+}
+
+func (r *GlossaryTermRepo) List(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListGlossaryTermResponse, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	builder := r.entClient.Client().GlossaryTerm.Query()
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(glossaryterm.TenantIDEQ(tid))
+	}
+
+	ret, err := r.repository.ListWithPaging(ctx, builder, builder.Clone(), req)
+	if err != nil {
+		return nil, err
+	}
+	if ret == nil {
+		return &contentV1.ListGlossaryTermResponse{Total: 0, Items: nil}, nil
+	}
+
+	return &contentV1.ListGlossaryTermResponse{
+		Total: ret.Total,
+		Items: ret.Items,
+	}, nil
+}
+
+func (r *GlossaryTermRepo) getEntity(ctx context.Context, id uint32) (*ent.GlossaryTerm, error) {
+	builder := r.entClient.Client().GlossaryTerm.Query().
+		Where(glossaryterm.IDEQ(id))
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(glossaryterm.TenantIDEQ(tid))
+	}
+
+	entity, err := builder.Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound("glossary term not found")
+		}
+		r.log.Errorf("query glossary term failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query glossary term failed")
+	}
+
+	return entity, nil
+}
+
+func (r *GlossaryTermRepo) Get(ctx context.Context, req *contentV1.GetGlossaryTermRequest) (*contentV1.GlossaryTerm, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	entity, err := r.getEntity(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return r.mapper.ToDTO(entity), nil
+}
+
+func (r *GlossaryTermRepo) Create(ctx context.Context, req *contentV1.CreateGlossaryTermRequest) (*contentV1.GlossaryTerm, error) {
+	if req == nil || req.Data == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+	if err := normalizeGlossaryTerm(req.Data); err != nil {
+		return nil, err
+	}
+
+	builder := r.entClient.Client().GlossaryTerm.Create().
+		SetNillableSourceLanguage(req.Data.SourceLanguage).
+		SetNillableTargetLanguage(req.Data.TargetLanguage).
+		SetNillableTerm(req.Data.Term).
+		SetNillableTranslation(req.Data.Translation).
+		SetNillableType(r.typeConverter.ToEntity(req.Data.Type)).
+		SetNillableCaseSensitive(req.Data.CaseSensitive).
+		SetNillableRemark(req.Data.Remark).
+		SetNillableCreatedBy(req.Data.CreatedBy).
+		SetCreatedAt(time.Now())
+	tid, hasTenant := maybeTenantFromViewer(ctx)
+	if hasTenant {
+		builder.SetTenantID(tid)
+	}
+
+	entity, err := builder.Save(ctx)
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return nil, contentV1.ErrorConflict("glossary term already exists")
+		}
+		r.log.Errorf("insert glossary term failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("insert glossary term failed")
+	}
+
+	r.invalidate(ctx, entity)
+
+	return r.mapper.ToDTO(entity), nil
+}
+
+func (r *GlossaryTermRepo) Update(ctx context.Context, req *contentV1.UpdateGlossaryTermRequest) (*contentV1.GlossaryTerm, error) {
+	if req == nil || req.Data == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	// 类型与译法相互约束，按合并后的完整术语校验
+	current, err := r.getEntity(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	merged := r.mapper.ToDTO(current)
+	if req.Data.SourceLanguage == nil {
+		req.Data.SourceLanguage = merged.SourceLanguage
+	}
+	if req.Data.TargetLanguage == nil {
+		req.Data.TargetLanguage = merged.TargetLanguage
+	}
+	if req.Data.Term == nil {
+		req.Data.Term = merged.Term
+	}
+	if req.Data.Translation == nil {
+		req.Data.Translation = merged.Translation
+	}
+	if req.Data.Type == nil {
+		req.Data.Type = merged.Type
+	}
+	if err = normalizeGlossaryTerm(req.Data); err != nil {
+		return nil, err
+	}
+
+	callerUserID, hasUser := viewerUserIDFromContext(ctx)
+
+	builder := r.entClient.Client().GlossaryTerm.UpdateOneID(req.GetId())
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(glossaryterm.TenantIDEQ(tid))
+	}
+	result, err := r.repository.UpdateOne(ctx, builder, req.Data, req.GetUpdateMask(),
+		func(dto *contentV1.GlossaryTerm) {
+			builder.
+				SetNillableSourceLanguage(req.Data.SourceLanguage).
+				SetNillableTargetLanguage(req.Data.TargetLanguage).
+				SetNillableTerm(req.Data.Term).
+				SetNillableType(r.typeConverter.ToEntity(req.Data.Type)).
+				SetNillableCaseSensitive(req.Data.CaseSensitive).
+				SetNillableRemark(req.Data.Remark).
+				SetUpdatedAt(time.Now())
+			if req.Data.Translation != nil {
+				builder.SetTranslation(req.Data.GetTranslation())
+			} else {
+				builder.ClearTranslation()
+			}
+
+			// updated_by 强制由服务端 viewer context 推导，忽略客户端传入值
+			if hasUser {
+				builder.SetUpdatedBy(callerUserID)
+			}
+		},
+		func(s *sql.Selector) {
+			s.Where(sql.EQ(glossaryterm.FieldID, req.GetId()))
+		},
+	)
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return nil, contentV1.ErrorConflict("glossary term already exists")
+		}
+		return nil, err
+	}
+
+	// 旧术语与新术语涉及的机器翻译记忆都可能与术语表不一致
+	r.invalidate(ctx, current)
+	if updated, err := r.getEntity(ctx, req.GetId()); err == nil {
+		r.invalidate(ctx, updated)
+	}
+
+	return result, nil
+}
+
+func (r *GlossaryTermRepo) Delete(ctx context.Context, req *contentV1.DeleteGlossaryTermRequest) error {
+	if req == nil {
+		return contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	current, err := r.getEntity(ctx, req.GetId())
+	if err != nil {
+		return err
+	}
+
+	if err = r.entClient.Client().GlossaryTerm.DeleteOneID(current.ID).Exec(ctx); err != nil {
+		if ent.IsNotFound(err) {
+			return contentV1.ErrorNotFound("glossary term not found")
+		}
+		r.log.Errorf("delete glossary term failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("delete glossary term failed")
+	}
+
+	r.invalidate(ctx, current)
+
+	return nil
+}
+
+// invalidate 删除包含该术语的机器翻译记忆，下次翻译时按新的术语表重新翻译
+func (r *GlossaryTermRepo) invalidate(ctx context.Context, entity *ent.GlossaryTerm) {
+	if err := invalidateMachineTranslations(ctx, r.entClient.Client(),
+		trans.Uint32Value(entity.TenantID),
+		trans.StringValue(entity.SourceLanguage),
+		trans.StringValue(entity.TargetLanguage),
+		trans.StringValue(entity.Term),
+	); err != nil {
+		r.log.Warnf("invalidate machine translations for glossary term [%d] failed: %s", entity.ID, err.Error())
+	}
+}
+
+// Terms 加载语言对适用的术语：目标语言匹配或对所有目标语言生效的术语
+func (r *GlossaryTermRepo) Terms(ctx context.Context, tenantID uint32, sourceLanguage, targetLanguage string) ([]mt.Term, error) {
+	tenantPred := glossaryterm.TenantIDEQ(tenantID)
+	if tenantID == 0 {
+		tenantPred = glossaryterm.Or(glossaryterm.TenantIDIsNil(), glossaryterm.TenantIDEQ(0))
+	}
+
+	entities, err := r.entClient.Client().GlossaryTerm.Query().
+		Where(
+			tenantPred,
+			glossaryterm.SourceLanguageEqualFold(sourceLanguage),
+			glossaryterm.Or(
+				glossaryterm.TargetLanguageEQ(""),
+				glossaryterm.TargetLanguageEqualFold(targetLanguage),
+			),
+		).
+		Limit(maxGlossaryTermsPerPair).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query glossary terms failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query glossary terms failed")
+	}
+
+	terms := make([]mt.Term, 0, len(entities))
+	for _, e := range entities {
+		terms = append(terms, mt.Term{
+			Source:        trans.StringValue(e.Term),
+			Target:        trans.StringValue(e.Translation),
+			Keep:          e.Type == nil || *e.Type != glossaryterm.TypeTypeForced,
+			CaseSensitive: trans.BoolValue(e.CaseSensitive),
+		})
+	}
+
+	return terms, nil
+}
+
+// Import 批量写入 TMX 导入的术语；overwrite 为 false 时跳过已存在的术语
+func (r *GlossaryTermRepo) Import(ctx context.Context, items []GlossaryTermImport, overwrite bool) (created, updated, skipped uint32, err error) {
+	client := r.entClient.Client()
+	tid, hasTenant := maybeTenantFromViewer(ctx)
+	operatorID, hasUser := viewerUserIDFromContext(ctx)
+	now := time.Now()
+
+	type languagePair struct{ source, target string }
+	touched := make(map[languagePair]struct{})
+
+	for _, item := range items {
+		termType := glossaryterm.TypeTypeForced
+		var translation *string
+		if item.DoNotTranslate {
+			termType = glossaryterm.TypeTypeDoNotTranslate
+		} else {
+			translation = trans.Ptr(item.Translation)
+		}
+
+		query := client.GlossaryTerm.Query().
+			Where(
+				glossaryterm.SourceLanguageEqualFold(item.SourceLanguage),
+				glossaryterm.TargetLanguageEqualFold(item.TargetLanguage),
+				glossaryterm.TermEQ(item.Term),
+			)
+		if hasTenant {
+			query.Where(glossaryterm.TenantIDEQ(tid))
+		} else {
+			query.Where(glossaryterm.Or(glossaryterm.TenantIDIsNil(), glossaryterm.TenantIDEQ(0)))
+		}
+		existing, qErr := query.First(ctx)
+		if qErr != nil && !ent.IsNotFound(qErr) {
+			r.log.Errorf("query glossary term failed: %s", qErr.Error())
+			return created, updated, skipped, contentV1.ErrorInternalServerError("import glossary terms failed")
+		}
+
+		switch {
+		case existing != nil && !overwrite:
+			skipped++
+			continue
+
+		case existing != nil:
+			builder := client.GlossaryTerm.UpdateOneID(existing.ID).
+				SetType(termType).
+				SetCaseSensitive(item.CaseSensitive).
+				SetUpdatedAt(now)
+			if translation != nil {
+				builder.SetTranslation(*translation)
+			} else {
+				builder.ClearTranslation()
+			}
+			if hasUser {
+				builder.SetUpdatedBy(operatorID)
+			}
+			err = builder.Exec(ctx)
+			updated++
+
+		default:
+			builder := client.GlossaryTerm.Create().
+				SetSourceLanguage(item.SourceLanguage).
+				SetTargetLanguage(item.TargetLanguage).
+				SetTerm(item.Term).
+				SetNillableTranslation(translation).
+				SetType(termType).
+				SetCaseSensitive(item.CaseSensitive).
+				SetCreatedAt(now)
+			if hasTenant {
+				builder.SetTenantID(tid)
+			}
+			if hasUser {
+				builder.SetCreatedBy(operatorID)
+			}
+			err = builder.Exec(ctx)
+			created++
+		}
+		if err != nil {
+			r.log.Errorf("import glossary term failed: %s", err.Error())
+			return created, updated, skipped, contentV1.ErrorInternalServerError("import glossary terms failed")
+		}
+
+		touched[languagePair{source: item.SourceLanguage, target: item.TargetLanguage}] = struct{}{}
+	}
+
+	// 按语言对整体失效机器翻译记忆，避免逐术语扫描
+	for pair := range touched {
+		if iErr := invalidateMachineTranslations(ctx, client, tid, pair.source, pair.target, ""); iErr != nil {
+			r.log.Warnf("invalidate machine translations failed: %s", iErr.Error())
+		}
+	}
+
+	return created, updated, skipped, nil
+}
+
+// ListForExport 按语言对列出当前租户的术语，最多 MaxTmxUnits 条
+func (r *GlossaryTermRepo) ListForExport(ctx context.Context, sourceLanguage, targetLanguage string) ([]*ent.GlossaryTerm, error) {
+	builder := r.entClient.Client().GlossaryTerm.Query()
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(glossaryterm.TenantIDEQ(tid))
+	}
+	if sourceLanguage != "" {
+		builder.Where(glossaryterm.SourceLanguageEqualFold(sourceLanguage))
+	}
+	if targetLanguage != "" {
+		builder.Where(glossaryterm.TargetLanguageEqualFold(targetLanguage))
+	}
+
+	entities, err := builder.
+		Order(
+			ent.Asc(glossaryterm.FieldSourceLanguage),
+			ent.Asc(glossaryterm.FieldTargetLanguage),
+			ent.Asc(glossaryterm.FieldTerm),
+		).
+		Limit(MaxTmxUnits).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query glossary terms for export failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query glossary terms failed")
+	}
+
+	return entities, nil
+}
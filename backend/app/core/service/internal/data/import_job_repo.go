@@ -0,0 +1,835 @@
+package data
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/tx7do/go-utils/copierutil"
+	"github.com/tx7do/go-utils/mapper"
+	"github.com/tx7do/go-utils/password"
+	"github.com/tx7do/go-utils/trans"
+
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	entCrud "github.com/tx7do/go-crud/entgo"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/category"
+	"go-wind-cms/app/core/service/internal/data/ent/categorytranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/comment"
+	"go-wind-cms/app/core/service/internal/data/ent/file"
+	"go-wind-cms/app/core/service/internal/data/ent/importjob"
+	"go-wind-cms/app/core/service/internal/data/ent/page"
+	"go-wind-cms/app/core/service/internal/data/ent/pagetranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/post"
+	"go-wind-cms/app/core/service/internal/data/ent/posttranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/predicate"
+	"go-wind-cms/app/core/service/internal/data/ent/section"
+	"go-wind-cms/app/core/service/internal/data/ent/tag"
+	"go-wind-cms/app/core/service/internal/data/ent/tagtranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/user"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/count"
+	"go-wind-cms/pkg/content/importer"
+	"go-wind-cms/pkg/content/locale"
+)
+
+const (
+	// importJobStaleAfter 执行中的作业超过该时长未结束（如 worker 崩溃），允许重新认领
+	importJobStaleAfter = 2 * time.Hour
+)
+
+// ImportedPost 待写入的文章，正文中的链接与附件地址已改写
+type ImportedPost struct {
+	Language string
+	Title    string
+	Slug     string
+	Summary  string
+	Content  string
+	Markdown bool
+	Status   importer.Status
+	Password string
+
+	AuthorID   uint32
+	AuthorName string
+	Thumbnail  string
+
+	DisallowComment bool
+	CategoryIDs     []uint32
+	TagIDs          []uint32
+
+	PublishTime time.Time
+	UpdatedAt   time.Time
+}
+
+// ImportedPage 待写入的页面，正文写入一个富文本或 Markdown 区块
+type ImportedPage struct {
+	Language string
+	Title    string
+	Slug     string
+	Content  string
+	Markdown bool
+	Status   importer.Status
+
+	ParentID  uint32
+	Depth     int32
+	SortOrder uint32
+
+	AuthorID   uint32
+	AuthorName string
+	Thumbnail  string
+
+	DisallowComment bool
+
+	PublishTime time.Time
+	UpdatedAt   time.Time
+}
+
+// ImportJobRepo 内容导入作业：创建、认领、进度记录，以及把导入内容写入作业所属租户。
+//
+// worker 在 SystemViewer 下执行，写入的内容显式设置作业记录上的 tenant_id，查重也按该租户过滤。
+type ImportJobRepo struct {
+	entClient *entCrud.EntClient[*ent.Client]
+	log       *log.Helper
+
+	mapper *mapper.CopierMapper[contentV1.ImportJob, ent.ImportJob]
+
+	repository *entCrud.Repository[
+		ent.ImportJobQuery, ent.ImportJobSelect,
+		ent.ImportJobCreate, ent.ImportJobCreateBulk,
+		ent.ImportJobUpdate, ent.ImportJobUpdateOne,
+		ent.ImportJobDelete,
+		predicate.ImportJob,
+		contentV1.ImportJob, ent.ImportJob,
+	]
+
+	statusConverter *mapper.EnumTypeConverter[contentV1.ImportJob_ImportJobStatus, importjob.Status]
+	formatConverter *mapper.EnumTypeConverter[contentV1.ImportJob_Format, importjob.Format]
+
+	workflowRepo   *WorkflowRepo
+	passwordCrypto password.Crypto
+}
+
+func NewImportJobRepo(
+	ctx *bootstrap.Context,
+	entClient *entCrud.EntClient[*ent.Client],
+	workflowRepo *WorkflowRepo,
+	passwordCrypto password.Crypto,
+) *ImportJobRepo {
+	repo := &ImportJobRepo{
+		entClient: entClient,
+		log:       ctx.NewLoggerHelper("import-job/repo/core-service"),
+		mapper:    mapper.NewCopierMapper[contentV1.ImportJob, ent.ImportJob](),
+		statusConverter: mapper.NewEnumTypeConverter[contentV1.ImportJob_ImportJobStatus, importjob.Status](
+			contentV1.ImportJob_ImportJobStatus_name, contentV1.ImportJob_ImportJobStatus_value,
+		),
+		formatConverter: mapper.NewEnumTypeConverter[contentV1.ImportJob_Format, importjob.Format](
+			contentV1.ImportJob_Format_name, contentV1.ImportJob_Format_value,
+		),
+		workflowRepo:   workflowRepo,
+		passwordCrypto: passwordCrypto,
+	}
+
+	repo.init()
+
+	return repo
+}
+
+func (r *ImportJobRepo) init() {
+	r.repository = entCrud.NewRepository[
+		ent.ImportJobQuery, ent.ImportJobSelect,
+		ent.ImportJobCreate, ent.ImportJobCreateBulk,
+		ent.ImportJobUpdate, ent.ImportJobUpdateOne,
+		ent.ImportJobDelete,
+		predicate.ImportJob,
+		contentV1.ImportJob, ent.ImportJob,
+	](r.mapper)
+
+	r.mapper.AppendConverters(copierutil.NewTimeStringConverterPair())
+	r.mapper.AppendConverters(copierutil.NewTimeTimestamppbConverterPair())
+	r.mapper.AppendConverters(r.statusConverter.NewConverterPair())
+	r.mapper.AppendConverters(r.formatConverter.NewConverterPair())
+}
+
+func (r *ImportJobRepo) List(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListImportJobResponse, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	builder := r.entClient.Client().ImportJob.Query()
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(importjob.TenantIDEQ(tid))
+	}
+
+	ret, err := r.repository.ListWithPaging(ctx, builder, builder.Clone(), req)
+	if err != nil {
+		return nil, err
+	}
+	if ret == nil {
+		return &contentV1.ListImportJobResponse{Total: 0, Items: nil}, nil
+	}
+
+	// 列表不返回逐条结果，详情见 Get
+	for _, item := range ret.Items {
+		item.Items = nil
+	}
+
+	return &contentV1.ListImportJobResponse{
+		Total: ret.Total,
+		Items: ret.Items,
+	}, nil
+}
+
+func (r *ImportJobRepo) Get(ctx context.Context, id uint32) (*contentV1.ImportJob, error) {
+	builder := r.entClient.Client().ImportJob.Query().
+		Where(importjob.IDEQ(id))
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(importjob.TenantIDEQ(tid))
+	}
+
+	entity, err := builder.Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound("import job not found")
+		}
+		r.log.Errorf("query import job failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query import job failed")
+	}
+
+	return r.mapper.ToDTO(entity), nil
+}
+
+// normalizeSourceURL 校验旧站点地址：须为 http(s) 绝对地址，只保留协议与主机
+func normalizeSourceURL(raw string) (string, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", true
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", false
+	}
+	return u.Scheme + "://" + u.Host, true
+}
+
+// Create 创建作业：确认导入文件属于当前租户
+func (r *ImportJobRepo) Create(ctx context.Context, req *contentV1.CreateImportJobRequest) (*contentV1.ImportJob, error) {
+	if req == nil || req.GetFileId() == 0 {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+	if !locale.ValidCode(req.GetLanguage()) {
+		return nil, contentV1.ErrorBadRequest("invalid language")
+	}
+
+	f := req.GetFormat()
+	format := r.formatConverter.ToEntity(&f)
+	if format == nil {
+		return nil, contentV1.ErrorBadRequest("unsupported import format")
+	}
+
+	sourceURL, ok := normalizeSourceURL(req.GetSourceUrl())
+	if !ok {
+		return nil, contentV1.ErrorBadRequest("invalid source url")
+	}
+
+	tid, hasTenant := maybeTenantFromViewer(ctx)
+
+	fileQuery := r.entClient.Client().File.Query().Where(file.IDEQ(req.GetFileId()))
+	if hasTenant {
+		fileQuery.Where(file.TenantIDEQ(tid))
+	}
+	exist, err := fileQuery.Exist(ctx)
+	if err != nil {
+		r.log.Errorf("query import file failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query import file failed")
+	}
+	if !exist {
+		return nil, contentV1.ErrorNotFound("import file not found")
+	}
+
+	builder := r.entClient.Client().ImportJob.Create().
+		SetFormat(*format).
+		SetFileID(req.GetFileId()).
+		SetLanguage(req.GetLanguage()).
+		SetDryRun(req.GetDryRun()).
+		SetDownloadAttachments(req.DownloadAttachments == nil || req.GetDownloadAttachments()).
+		SetImportComments(req.ImportComments == nil || req.GetImportComments()).
+		SetAsDraft(req.GetAsDraft()).
+		SetStatus(importjob.StatusImportJobStatusPending).
+		SetCreatedAt(time.Now())
+	if sourceURL != "" {
+		builder.SetSourceURL(sourceURL)
+	}
+	if hasTenant {
+		builder.SetTenantID(tid)
+	}
+	if operatorID, ok := viewerUserIDFromContext(ctx); ok {
+		builder.SetCreatedBy(operatorID)
+	}
+
+	entity, err := builder.Save(ctx)
+	if err != nil {
+		r.log.Errorf("insert import job failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("insert import job failed")
+	}
+
+	return r.mapper.ToDTO(entity), nil
+}
+
+// Claim 认领待执行（或执行超时）的作业并置为执行中；已被认领或已结束时返回 nil
+func (r *ImportJobRepo) Claim(ctx context.Context, id uint32) (*ent.ImportJob, error) {
+	now := time.Now()
+	n, err := r.entClient.Client().ImportJob.Update().
+		Where(
+			importjob.IDEQ(id),
+			importjob.Or(
+				importjob.StatusEQ(importjob.StatusImportJobStatusPending),
+				importjob.And(
+					importjob.StatusEQ(importjob.StatusImportJobStatusRunning),
+					importjob.StartedAtLT(now.Add(-importJobStaleAfter)),
+				),
+			),
+		).
+		SetStatus(importjob.StatusImportJobStatusRunning).
+		SetStartedAt(now).
+		SetProcessed(0).
+		ClearStats().
+		ClearItems().
+		SetUpdatedAt(now).
+		Save(ctx)
+	if err != nil {
+		r.log.Errorf("claim import job failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("claim import job failed")
+	}
+	if n == 0 {
+		return nil, nil
+	}
+
+	entity, err := r.entClient.Client().ImportJob.Get(ctx, id)
+	if err != nil {
+		r.log.Errorf("query import job failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query import job failed")
+	}
+	return entity, nil
+}
+
+// UpdateProgress 记录条目总数与已处理数
+func (r *ImportJobRepo) UpdateProgress(ctx context.Context, id uint32, total, processed uint32) error {
+	if err := r.entClient.Client().ImportJob.UpdateOneID(id).
+		SetTotal(total).
+		SetProcessed(processed).
+		SetUpdatedAt(time.Now()).
+		Exec(ctx); err != nil {
+		r.log.Errorf("update import job progress failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("update import job progress failed")
+	}
+	return nil
+}
+
+// Finish 结束作业并保存报告；cause 非空时作业失败并记录原因
+func (r *ImportJobRepo) Finish(ctx context.Context, id uint32, stats []*contentV1.ImportJobStat, items []*contentV1.ImportJobItem, cause error) error {
+	now := time.Now()
+	builder := r.entClient.Client().ImportJob.UpdateOneID(id).
+		SetStats(stats).
+		SetItems(items).
+		SetFinishedAt(now).
+		SetUpdatedAt(now)
+
+	if cause != nil {
+		msg := cause.Error()
+		if e := kerrors.FromError(cause); e != nil && e.GetMessage() != "" {
+			msg = e.GetMessage()
+		}
+		if len(msg) > 1024 {
+			msg = msg[:1024]
+		}
+		builder.SetStatus(importjob.StatusImportJobStatusFailed).SetLastError(msg)
+	} else {
+		builder.SetStatus(importjob.StatusImportJobStatusCompleted)
+	}
+
+	if err := builder.Exec(ctx); err != nil {
+		r.log.Errorf("finish import job failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("finish import job failed")
+	}
+	return nil
+}
+
+// FindUserByEmail 按邮箱查找租户内的用户，返回用户ID与显示名称；未找到时 ID 为 0
+func (r *ImportJobRepo) FindUserByEmail(ctx context.Context, tenantID uint32, email string) (uint32, string, error) {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return 0, "", nil
+	}
+
+	u, err := r.entClient.Client().User.Query().
+		Where(user.TenantIDEQ(tenantID), user.EmailEqualFold(email)).
+		First(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return 0, "", nil
+		}
+		r.log.Errorf("query user by email failed: %s", err.Error())
+		return 0, "", contentV1.ErrorInternalServerError("query user failed")
+	}
+
+	name := trans.StringValue(u.Nickname)
+	if name == "" {
+		name = trans.StringValue(u.Realname)
+	}
+	if name == "" {
+		name = trans.StringValue(u.Username)
+	}
+	return u.ID, name, nil
+}
+
+// FindCategory 按语言与 slug 查找租户内的分类，未找到时返回 0
+func (r *ImportJobRepo) FindCategory(ctx context.Context, tenantID uint32, lang, slug string) (uint32, error) {
+	t, err := r.entClient.Client().CategoryTranslation.Query().
+		Where(
+			categorytranslation.TenantIDEQ(tenantID),
+			categorytranslation.LanguageCodeEQ(lang),
+			categorytranslation.SlugEQ(slug),
+		).
+		First(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return 0, nil
+		}
+		r.log.Errorf("query category by slug failed: %s", err.Error())
+		return 0, contentV1.ErrorInternalServerError("query category failed")
+	}
+	return trans.Uint32Value(t.CategoryID), nil
+}
+
+// FindTag 按语言与 slug 查找租户内的标签，未找到时返回 0
+func (r *ImportJobRepo) FindTag(ctx context.Context, tenantID uint32, lang, slug string) (uint32, error) {
+	t, err := r.entClient.Client().TagTranslation.Query().
+		Where(
+			tagtranslation.TenantIDEQ(tenantID),
+			tagtranslation.LanguageCodeEQ(lang),
+			tagtranslation.SlugEQ(slug),
+		).
+		First(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return 0, nil
+		}
+		r.log.Errorf("query tag by slug failed: %s", err.Error())
+		return 0, contentV1.ErrorInternalServerError("query tag failed")
+	}
+	return trans.Uint32Value(t.TagID), nil
+}
+
+// PostSlugExists 租户内该语言是否已有相同 slug 的文章（含回收站中的文章）
+func (r *ImportJobRepo) PostSlugExists(ctx context.Context, tenantID uint32, lang, slug string) (bool, error) {
+	exist, err := r.entClient.Client().PostTranslation.Query().
+		Where(
+			posttranslation.TenantIDEQ(tenantID),
+			posttranslation.LanguageCodeEQ(lang),
+			posttranslation.SlugEQ(slug),
+		).
+		Exist(ctx)
+	if err != nil {
+		r.log.Errorf("query post by slug failed: %s", err.Error())
+		return false, contentV1.ErrorInternalServerError("query post failed")
+	}
+	return exist, nil
+}
+
+// PageSlugExists 租户内该语言是否已有相同 slug 的页面
+func (r *ImportJobRepo) PageSlugExists(ctx context.Context, tenantID uint32, lang, slug string) (bool, error) {
+	exist, err := r.entClient.Client().PageTranslation.Query().
+		Where(
+			pagetranslation.TenantIDEQ(tenantID),
+			pagetranslation.LanguageCodeEQ(lang),
+			pagetranslation.SlugEQ(slug),
+		).
+		Exist(ctx)
+	if err != nil {
+		r.log.Errorf("query page by slug failed: %s", err.Error())
+		return false, contentV1.ErrorInternalServerError("query page failed")
+	}
+	return exist, nil
+}
+
+// txn 在事务中执行 fn
+func (r *ImportJobRepo) txn(ctx context.Context, fn func(tx *ent.Tx) error) (err error) {
+	var tx *ent.Tx
+	tx, err = r.entClient.Client().Tx(ctx)
+	if err != nil {
+		r.log.Errorf("start transaction failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("start transaction failed")
+	}
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				r.log.Errorf("transaction rollback failed: %s", rollbackErr.Error())
+			}
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			r.log.Errorf("transaction commit failed: %s", commitErr.Error())
+			err = contentV1.ErrorInternalServerError("transaction commit failed")
+		}
+	}()
+
+	return fn(tx)
+}
+
+// CreateCategory 创建分类及其该语言的翻译
+func (r *ImportJobRepo) CreateCategory(ctx context.Context, job *ent.ImportJob, lang string, term importer.Term, parentID uint32, depth int32) (id uint32, err error) {
+	tid := trans.Uint32Value(job.TenantID)
+	now := time.Now()
+
+	err = r.txn(ctx, func(tx *ent.Tx) error {
+		builder := tx.Category.Create().
+			SetStatus(category.StatusCategoryStatusActive).
+			SetPostCount(0).
+			SetDirectPostCount(0).
+			SetDepth(depth).
+			SetTenantID(tid).
+			SetNillableCreatedBy(job.CreatedBy).
+			SetCreatedAt(now)
+		if parentID != 0 {
+			builder.SetParentID(parentID)
+		}
+		entity, err := builder.Save(ctx)
+		if err != nil {
+			return err
+		}
+		id = entity.ID
+
+		t := tx.CategoryTranslation.Create().
+			SetCategoryID(entity.ID).
+			SetLanguageCode(lang).
+			SetName(term.Name).
+			SetSlug(term.Slug).
+			SetTenantID(tid).
+			SetNillableCreatedBy(job.CreatedBy).
+			SetCreatedAt(now)
+		if term.Description != "" {
+			t.SetDescription(term.Description)
+		}
+		return t.Exec(ctx)
+	})
+	if err != nil {
+		r.log.Errorf("import category [%s] failed: %s", term.Slug, err.Error())
+		return 0, contentV1.ErrorInternalServerError("insert category failed")
+	}
+	return id, nil
+}
+
+// CreateTag 创建标签及其该语言的翻译
+func (r *ImportJobRepo) CreateTag(ctx context.Context, job *ent.ImportJob, lang string, term importer.Term) (id uint32, err error) {
+	tid := trans.Uint32Value(job.TenantID)
+	now := time.Now()
+
+	err = r.txn(ctx, func(tx *ent.Tx) error {
+		entity, err := tx.Tag.Create().
+			SetStatus(tag.StatusTAG_STATUS_ACTIVE).
+			SetPostCount(0).
+			SetTenantID(tid).
+			SetNillableCreatedBy(job.CreatedBy).
+			SetCreatedAt(now).
+			Save(ctx)
+		if err != nil {
+			return err
+		}
+		id = entity.ID
+
+		t := tx.TagTranslation.Create().
+			SetTagID(entity.ID).
+			SetLanguageCode(lang).
+			SetName(term.Name).
+			SetSlug(term.Slug).
+			SetTenantID(tid).
+			SetNillableCreatedBy(job.CreatedBy).
+			SetCreatedAt(now)
+		if term.Description != "" {
+			t.SetDescription(term.Description)
+		}
+		return t.Exec(ctx)
+	})
+	if err != nil {
+		r.log.Errorf("import tag [%s] failed: %s", term.Slug, err.Error())
+		return 0, contentV1.ErrorInternalServerError("insert tag failed")
+	}
+	return id, nil
+}
+
+// importCreatedAt 导入内容保留源站点的发布时间作为创建时间，缺失时取当前时间
+func importCreatedAt(published time.Time, now time.Time) time.Time {
+	if published.IsZero() || published.After(now) {
+		return now
+	}
+	return published
+}
+
+// CreatePost 创建文章、该语言的翻译与分类、标签关联。
+// 命中编辑工作流的文章进入其初始阶段并导入为草稿，此时 drafted 为 true
+func (r *ImportJobRepo) CreatePost(ctx context.Context, job *ent.ImportJob, p *ImportedPost) (id uint32, drafted bool, err error) {
+	tid := trans.Uint32Value(job.TenantID)
+	now := time.Now()
+
+	var passwordHash string
+	if p.Password != "" {
+		if passwordHash, err = r.passwordCrypto.Encrypt(p.Password); err != nil {
+			r.log.Errorf("hash post password failed: %s", err.Error())
+			return 0, false, contentV1.ErrorInternalServerError("hash post password failed")
+		}
+	}
+
+	var wf *ent.Workflow
+	if wf, err = r.workflowRepo.Resolve(ctx, tid, defaultPostType); err != nil {
+		return 0, false, err
+	}
+
+	status := post.StatusPostStatusDraft
+	switch {
+	case wf != nil:
+		drafted = p.Status != importer.StatusDraft
+	case p.Status == importer.StatusPublished:
+		status = post.StatusPostStatusPublished
+	case p.Status == importer.StatusScheduled:
+		status = post.StatusPostStatusScheduled
+	}
+
+	editorType := post.EditorTypeEditorTypeRichText
+	if p.Markdown {
+		editorType = post.EditorTypeEditorTypeMarkdown
+	}
+
+	createdAt := importCreatedAt(p.PublishTime, now)
+
+	err = r.txn(ctx, func(tx *ent.Tx) error {
+		builder := tx.Post.Create().
+			SetStatus(status).
+			SetEditorType(editorType).
+			SetDisallowComment(p.DisallowComment).
+			SetPostType(defaultPostType).
+			SetAuthorID(p.AuthorID).
+			SetTenantID(tid).
+			SetNillableCreatedBy(job.CreatedBy).
+			SetCreatedAt(createdAt)
+		if p.AuthorName != "" {
+			builder.SetAuthorName(p.AuthorName)
+		}
+		if passwordHash != "" {
+			builder.SetPasswordHash(passwordHash)
+		}
+		if !p.PublishTime.IsZero() {
+			builder.SetPublishTime(p.PublishTime)
+		}
+		if !p.UpdatedAt.IsZero() && p.UpdatedAt.After(createdAt) && !p.UpdatedAt.After(now) {
+			builder.SetUpdatedAt(p.UpdatedAt)
+		}
+		if wf != nil {
+			builder.
+				SetWorkflowID(wf.ID).
+				SetWorkflowStage(trans.StringValue(wf.InitialStage))
+		}
+
+		entity, err := builder.Save(ctx)
+		if err != nil {
+			return err
+		}
+		id = entity.ID
+
+		t := tx.PostTranslation.Create().
+			SetPostID(entity.ID).
+			SetLanguageCode(p.Language).
+			SetTitle(p.Title).
+			SetSlug(p.Slug).
+			SetContent(p.Content).
+			SetWordCount(uint32(count.NewContentCounter(p.Content).RawChars())).
+			SetIsDraft(status == post.StatusPostStatusDraft).
+			SetTenantID(tid).
+			SetNillableCreatedBy(job.CreatedBy).
+			SetCreatedAt(createdAt)
+		if p.Summary != "" {
+			t.SetSummary(p.Summary)
+		}
+		if p.Thumbnail != "" {
+			t.SetThumbnail(p.Thumbnail)
+		}
+		if err = t.Exec(ctx); err != nil {
+			return err
+		}
+
+		for _, categoryID := range p.CategoryIDs {
+			if err = tx.PostCategory.Create().
+				SetPostID(entity.ID).
+				SetCategoryID(categoryID).
+				SetTenantID(tid).
+				SetCreatedAt(now).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+		for _, tagID := range p.TagIDs {
+			if err = tx.PostTag.Create().
+				SetPostID(entity.ID).
+				SetTagID(tagID).
+				SetTenantID(tid).
+				SetCreatedAt(now).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		r.log.Errorf("import post [%s] failed: %s", p.Slug, err.Error())
+		return 0, false, contentV1.ErrorInternalServerError("insert post failed")
+	}
+	return id, drafted, nil
+}
+
+// CreatePage 创建页面、该语言的翻译，以及承载正文的区块
+func (r *ImportJobRepo) CreatePage(ctx context.Context, job *ent.ImportJob, p *ImportedPage) (id uint32, err error) {
+	tid := trans.Uint32Value(job.TenantID)
+	now := time.Now()
+
+	status := page.StatusPageStatusDraft
+	if p.Status == importer.StatusPublished {
+		status = page.StatusPageStatusPublished
+	}
+
+	editorType := page.EditorTypeEditorTypeRichText
+	sectionType := section.TypeSectionTypeRichText
+	if p.Markdown {
+		editorType = page.EditorTypeEditorTypeMarkdown
+		sectionType = section.TypeSectionTypeMarkdown
+	}
+
+	createdAt := importCreatedAt(p.PublishTime, now)
+
+	err = r.txn(ctx, func(tx *ent.Tx) error {
+		builder := tx.Page.Create().
+			SetStatus(status).
+			SetType(page.TypePageTypeDefault).
+			SetEditorType(editorType).
+			SetSlug(p.Slug).
+			SetDisallowComment(p.DisallowComment).
+			SetAuthorID(p.AuthorID).
+			SetDepth(p.Depth).
+			SetSortOrder(p.SortOrder).
+			SetTenantID(tid).
+			SetNillableCreatedBy(job.CreatedBy).
+			SetCreatedAt(createdAt)
+		if p.ParentID != 0 {
+			builder.SetParentID(p.ParentID)
+		}
+		if p.AuthorName != "" {
+			builder.SetAuthorName(p.AuthorName)
+		}
+		if !p.UpdatedAt.IsZero() && p.UpdatedAt.After(createdAt) && !p.UpdatedAt.After(now) {
+			builder.SetUpdatedAt(p.UpdatedAt)
+		}
+
+		entity, err := builder.Save(ctx)
+		if err != nil {
+			return err
+		}
+		id = entity.ID
+
+		t := tx.PageTranslation.Create().
+			SetPageID(entity.ID).
+			SetLanguageCode(p.Language).
+			SetTitle(p.Title).
+			SetSlug(p.Slug).
+			SetIsDraft(status == page.StatusPageStatusDraft).
+			SetTenantID(tid).
+			SetNillableCreatedBy(job.CreatedBy).
+			SetCreatedAt(createdAt)
+		if p.Thumbnail != "" {
+			t.SetThumbnail(p.Thumbnail)
+		}
+		if err = t.Exec(ctx); err != nil {
+			return err
+		}
+
+		if strings.TrimSpace(p.Content) == "" {
+			return nil
+		}
+
+		sec, err := tx.Section.Create().
+			SetPageID(entity.ID).
+			SetType(sectionType).
+			SetName("content").
+			SetSortOrder(0).
+			SetTenantID(tid).
+			SetNillableCreatedBy(job.CreatedBy).
+			SetCreatedAt(now).
+			Save(ctx)
+		if err != nil {
+			return err
+		}
+
+		return tx.SectionTranslation.Create().
+			SetSectionID(sec.ID).
+			SetLanguageCode(p.Language).
+			SetContent(&map[string]string{"body": p.Content}).
+			SetTenantID(tid).
+			SetNillableCreatedBy(job.CreatedBy).
+			SetCreatedAt(now).
+			Exec(ctx)
+	})
+	if err != nil {
+		r.log.Errorf("import page [%s] failed: %s", p.Slug, err.Error())
+		return 0, contentV1.ErrorInternalServerError("insert page failed")
+	}
+	return id, nil
+}
+
+// CreateComment 创建访客评论；未审核的评论进入待审核队列，不直接展示
+func (r *ImportJobRepo) CreateComment(ctx context.Context, job *ent.ImportJob, kind importer.Kind, objectID, parentID uint32, c importer.Comment) (uint32, error) {
+	contentType := comment.ContentTypeContentTypePost
+	if kind == importer.KindPage {
+		contentType = comment.ContentTypeContentTypePage
+	}
+
+	status := comment.StatusStatusPending
+	if c.Approved {
+		status = comment.StatusStatusApproved
+	}
+
+	builder := r.entClient.Client().Comment.Create().
+		SetContentType(contentType).
+		SetObjectID(objectID).
+		SetContent(c.Content).
+		SetAuthorType(comment.AuthorTypeAuthorTypeGuest).
+		SetStatus(status).
+		SetTenantID(trans.Uint32Value(job.TenantID)).
+		SetCreatedAt(importCreatedAt(c.Date, time.Now()))
+	if c.AuthorName != "" {
+		builder.SetAuthorName(c.AuthorName)
+	}
+	if c.AuthorEmail != "" {
+		builder.SetAuthorEmail(c.AuthorEmail)
+	}
+	if c.AuthorURL != "" {
+		builder.SetAuthorURL(c.AuthorURL)
+	}
+	if c.AuthorIP != "" {
+		builder.SetIPAddress(c.AuthorIP)
+	}
+	if parentID != 0 {
+		builder.SetParentID(parentID).SetReplyToID(parentID)
+	}
+
+	entity, err := builder.Save(ctx)
+	if err != nil {
+		r.log.Errorf("import comment [%s] failed: %s", c.ID, err.Error())
+		return 0, contentV1.ErrorInternalServerError("insert comment failed")
+	}
+	return entity.ID, nil
+}
@@ -659,6 +659,24 @@ func (r *InteractionRepo) GetInteractionStatus(ctx context.Context, viewerUserID
 	return result, nil
 }
 
+// ListPostWatcherIDs 列出收藏了指定 post 的用户ID，供评论通知投递使用。
+// 由后台任务调用，租户由调用方显式传入（任务上下文为 SystemViewer，不携带租户）。
+func (r *InteractionRepo) ListPostWatcherIDs(ctx context.Context, tenantID, postID uint32) ([]uint32, error) {
+	var ids []uint32
+	if err := r.entClient.Client().PostWatch.Query().
+		Where(
+			postwatch.TenantIDEQ(tenantID),
+			postwatch.PostIDEQ(postID),
+			postwatch.UserIDNotNil(),
+		).
+		Select(postwatch.FieldUserID).
+		Scan(ctx, &ids); err != nil {
+		r.log.Errorf("query post watchers failed: %s", err.Error())
+		return nil, interactionV1.ErrorInternalServerError("query post watchers failed")
+	}
+	return ids, nil
+}
+
 // ListWatchedPosts 列出当前 viewer 收藏的 post。
 // 查 post_watch 拿到分页后的 post_ids，再逐个调 PostRepo.Get 复用其完整的
 // 附带查询（translations/tags/categories/view_mask）逻辑。
@@ -0,0 +1,109 @@
+package data
+
+import (
+	"sync"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/llm"
+)
+
+// NewLlmOption 读取自定义配置 Llm，未配置时返回 nil（AI 辅助不可用）
+func NewLlmOption(ctx *bootstrap.Context) *contentV1.LlmOption {
+	var cfg *contentV1.LlmOptionWrapper
+	rawCfg, ok := ctx.GetCustomConfig("Llm")
+	if ok {
+		cfg = rawCfg.(*contentV1.LlmOptionWrapper)
+	}
+	if cfg == nil {
+		return nil
+	}
+	return cfg.Llm
+}
+
+// LlmProviders 按租户选择大语言模型供应商：租户有专属配置时使用专属配置，否则使用默认配置。
+// 供应商按配置创建一次后复用（共享 HTTP 连接）。
+type LlmProviders struct {
+	log    *log.Helper
+	option *contentV1.LlmOption
+
+	mu        sync.Mutex
+	providers map[uint32]llm.Provider // key 为租户ID，0 为默认供应商
+}
+
+func NewLlmProviders(ctx *bootstrap.Context, option *contentV1.LlmOption) *LlmProviders {
+	return &LlmProviders{
+		log:       ctx.NewLoggerHelper("llm-providers/data/core-service"),
+		option:    option,
+		providers: map[uint32]llm.Provider{},
+	}
+}
+
+// providerOption 租户生效的供应商配置及其缓存键；未配置时返回 nil
+func (p *LlmProviders) providerOption(tenantID uint32) (*contentV1.LlmOption_Provider, uint32) {
+	if p.option == nil {
+		return nil, 0
+	}
+	if cfg, ok := p.option.GetTenantProviders()[tenantID]; ok && tenantID != 0 && cfg != nil {
+		return cfg, tenantID
+	}
+	return p.option.GetProvider(), 0
+}
+
+// Configured 租户是否可使用 AI 辅助
+func (p *LlmProviders) Configured(tenantID uint32) bool {
+	cfg, _ := p.providerOption(tenantID)
+	return cfg != nil
+}
+
+// Provider 返回租户生效的供应商；未配置时返回 llm.ErrNotConfigured
+func (p *LlmProviders) Provider(tenantID uint32) (llm.Provider, error) {
+	cfg, key := p.providerOption(tenantID)
+	if cfg == nil {
+		return nil, llm.ErrNotConfigured
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if provider, ok := p.providers[key]; ok {
+		return provider, nil
+	}
+
+	provider, err := llm.New(toLlmConfig(cfg))
+	if err != nil {
+		p.log.Errorf("create llm provider for tenant [%d] failed: %s", key, err.Error())
+		return nil, err
+	}
+	p.providers[key] = provider
+	return provider, nil
+}
+
+// MonthlyTokenLimit 租户每月的 Token 用量上限，0 表示不限制
+func (p *LlmProviders) MonthlyTokenLimit(tenantID uint32) uint64 {
+	if p.option == nil {
+		return 0
+	}
+	if limit, ok := p.option.GetTenantMonthlyTokenLimits()[tenantID]; ok && tenantID != 0 {
+		return limit
+	}
+	return p.option.GetMonthlyTokenLimit()
+}
+
+func toLlmConfig(cfg *contentV1.LlmOption_Provider) llm.Config {
+	c := llm.Config{
+		Type:        cfg.GetType(),
+		BaseURL:     cfg.GetBaseUrl(),
+		APIKey:      cfg.GetApiKey(),
+		Model:       cfg.GetModel(),
+		MaxTokens:   int(cfg.GetMaxTokens()),
+		Temperature: cfg.GetTemperature(),
+	}
+	if cfg.GetTimeout() != nil {
+		c.Timeout = cfg.GetTimeout().AsDuration()
+	}
+	return c
+}
@@ -0,0 +1,223 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	entCrud "github.com/tx7do/go-crud/entgo"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/llmusage"
+	"go-wind-cms/app/core/service/internal/data/ent/predicate"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/llm"
+)
+
+const (
+	// usageMonthLayout 用量报告的月份格式
+	usageMonthLayout = "2006-01"
+
+	// maxUsageReportMonths 用量报告最多跨越的月数
+	maxUsageReportMonths = 12
+)
+
+// LlmUsageRepo 大语言模型用量台账：记录每次调用的 Token 用量，按月汇总并校验租户每月上限。
+type LlmUsageRepo struct {
+	entClient *entCrud.EntClient[*ent.Client]
+	log       *log.Helper
+
+	llmProviders *LlmProviders
+}
+
+func NewLlmUsageRepo(ctx *bootstrap.Context, entClient *entCrud.EntClient[*ent.Client], llmProviders *LlmProviders) *LlmUsageRepo {
+	return &LlmUsageRepo{
+		entClient:    entClient,
+		log:          ctx.NewLoggerHelper("llm-usage/repo/core-service"),
+		llmProviders: llmProviders,
+	}
+}
+
+// llmUsageTenantPredicate 租户过滤；平台（租户ID为 0）只统计平台自身的用量
+func llmUsageTenantPredicate(tenantID uint32) predicate.LlmUsage {
+	if tenantID == 0 {
+		return llmusage.Or(llmusage.TenantIDIsNil(), llmusage.TenantIDEQ(0))
+	}
+	return llmusage.TenantIDEQ(tenantID)
+}
+
+// Record 记录一次调用的用量
+func (r *LlmUsageRepo) Record(ctx context.Context, tenantID, jobID uint32, task contentV1.AiAssistTask, provider, model string, usage llm.Usage) error {
+	builder := r.entClient.Client().LlmUsage.Create().
+		SetTask(task.String()).
+		SetProvider(provider).
+		SetModel(model).
+		SetRequests(1).
+		SetPromptTokens(uint32(usage.PromptTokens)).
+		SetCompletionTokens(uint32(usage.CompletionTokens)).
+		SetTotalTokens(uint32(usage.Total())).
+		SetCreatedAt(time.Now())
+	if tenantID != 0 {
+		builder.SetTenantID(tenantID)
+	}
+	if jobID != 0 {
+		builder.SetJobID(jobID)
+	}
+
+	if err := builder.Exec(ctx); err != nil {
+		r.log.Errorf("insert llm usage failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("insert llm usage failed")
+	}
+	return nil
+}
+
+// usedTokens 统计租户在 [from, to) 内的总 Token 数
+func (r *LlmUsageRepo) usedTokens(ctx context.Context, tenantID uint32, from, to time.Time) (uint64, error) {
+	var rows []struct {
+		Sum sql.NullInt64 `json:"sum"`
+	}
+	if err := r.entClient.Client().LlmUsage.Query().
+		Where(
+			llmUsageTenantPredicate(tenantID),
+			llmusage.CreatedAtGTE(from),
+			llmusage.CreatedAtLT(to),
+		).
+		Aggregate(ent.Sum(llmusage.FieldTotalTokens)).
+		Scan(ctx, &rows); err != nil {
+		r.log.Errorf("sum llm usage failed: %s", err.Error())
+		return 0, contentV1.ErrorInternalServerError("query llm usage failed")
+	}
+	if len(rows) == 0 || !rows[0].Sum.Valid {
+		return 0, nil
+	}
+	return uint64(rows[0].Sum.Int64), nil
+}
+
+// UsedThisMonth 租户当月已用的 Token 数
+func (r *LlmUsageRepo) UsedThisMonth(ctx context.Context, tenantID uint32) (uint64, error) {
+	start := monthStart(time.Now())
+	return r.usedTokens(ctx, tenantID, start, start.AddDate(0, 1, 0))
+}
+
+// CheckQuota 租户当月用量已达上限时返回错误
+func (r *LlmUsageRepo) CheckQuota(ctx context.Context, tenantID uint32) error {
+	limit := r.llmProviders.MonthlyTokenLimit(tenantID)
+	if limit == 0 {
+		return nil
+	}
+
+	used, err := r.UsedThisMonth(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	if used >= limit {
+		return contentV1.ErrorTooManyRequests("monthly llm token limit exceeded (%d/%d)", used, limit)
+	}
+	return nil
+}
+
+// Report 当前租户的用量报告：按月份、任务汇总
+func (r *LlmUsageRepo) Report(ctx context.Context, req *contentV1.GetLlmUsageRequest) (*contentV1.LlmUsageReport, error) {
+	months, err := usageMonths(req.GetFromMonth(), req.GetToMonth(), time.Now())
+	if err != nil {
+		return nil, contentV1.ErrorBadRequest(err.Error())
+	}
+
+	tid, _ := maybeTenantFromViewer(ctx)
+
+	report := &contentV1.LlmUsageReport{
+		MonthlyTokenLimit: r.llmProviders.MonthlyTokenLimit(tid),
+		Configured:        r.llmProviders.Configured(tid),
+	}
+
+	for _, start := range months {
+		var rows []struct {
+			Task             string        `json:"task"`
+			Requests         sql.NullInt64 `json:"requests"`
+			PromptTokens     sql.NullInt64 `json:"prompt_tokens"`
+			CompletionTokens sql.NullInt64 `json:"completion_tokens"`
+			TotalTokens      sql.NullInt64 `json:"total_tokens"`
+		}
+		if err = r.entClient.Client().LlmUsage.Query().
+			Where(
+				llmUsageTenantPredicate(tid),
+				llmusage.CreatedAtGTE(start),
+				llmusage.CreatedAtLT(start.AddDate(0, 1, 0)),
+			).
+			GroupBy(llmusage.FieldTask).
+			Aggregate(
+				ent.As(ent.Sum(llmusage.FieldRequests), "requests"),
+				ent.As(ent.Sum(llmusage.FieldPromptTokens), "prompt_tokens"),
+				ent.As(ent.Sum(llmusage.FieldCompletionTokens), "completion_tokens"),
+				ent.As(ent.Sum(llmusage.FieldTotalTokens), "total_tokens"),
+			).
+			Scan(ctx, &rows); err != nil {
+			r.log.Errorf("aggregate llm usage failed: %s", err.Error())
+			return nil, contentV1.ErrorInternalServerError("query llm usage failed")
+		}
+
+		for _, row := range rows {
+			report.Items = append(report.Items, &contentV1.LlmUsageReport_Item{
+				Month:            start.Format(usageMonthLayout),
+				Task:             contentV1.AiAssistTask(contentV1.AiAssistTask_value[row.Task]),
+				Requests:         uint64(row.Requests.Int64),
+				PromptTokens:     uint64(row.PromptTokens.Int64),
+				CompletionTokens: uint64(row.CompletionTokens.Int64),
+				TotalTokens:      uint64(row.TotalTokens.Int64),
+			})
+		}
+	}
+
+	if report.UsedThisMonth, err = r.UsedThisMonth(ctx, tid); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// monthStart 所在月份第一天零点（UTC）
+func monthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// usageMonths 解析报告的月份范围，返回每个月第一天；为空的一端默认为当月
+func usageMonths(from, to string, now time.Time) ([]time.Time, error) {
+	parse := func(s string) (time.Time, error) {
+		if s == "" {
+			return monthStart(now), nil
+		}
+		t, err := time.Parse(usageMonthLayout, s)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid month %q, expected format %s", s, usageMonthLayout)
+		}
+		return t, nil
+	}
+
+	start, err := parse(from)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parse(to)
+	if err != nil {
+		return nil, err
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("to_month is before from_month")
+	}
+
+	var months []time.Time
+	for m := start; !m.After(end); m = m.AddDate(0, 1, 0) {
+		if len(months) == maxUsageReportMonths {
+			return nil, fmt.Errorf("too many months (max %d)", maxUsageReportMonths)
+		}
+		months = append(months, m)
+	}
+	return months, nil
+}
@@ -0,0 +1,44 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsageMonths(t *testing.T) {
+	now := time.Date(2026, 3, 15, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		want    []string
+		wantErr bool
+	}{
+		{name: "default current month", want: []string{"2026-03"}},
+		{name: "range", from: "2025-12", to: "2026-02", want: []string{"2025-12", "2026-01", "2026-02"}},
+		{name: "open end", from: "2026-02", want: []string{"2026-02", "2026-03"}},
+		{name: "reversed", from: "2026-03", to: "2026-01", wantErr: true},
+		{name: "invalid", from: "2026/01", wantErr: true},
+		{name: "too many months", from: "2024-01", to: "2026-01", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := usageMonths(tt.from, tt.to, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			var months []string
+			for _, m := range got {
+				months = append(months, m.Format(usageMonthLayout))
+			}
+			assert.Equal(t, tt.want, months)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package data
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/tx7do/go-utils/trans"
+
+	entCrud "github.com/tx7do/go-crud/entgo"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/language"
+	"go-wind-cms/app/core/service/internal/data/ent/site"
+
+	"go-wind-cms/pkg/content/locale"
+)
+
+// LocaleResolver 计算请求语言的回退顺序。
+//
+// 回退链与默认语言取自当前租户的默认站点（sites.is_default），其后追加系统默认语言
+// （sys_languages.is_default）。查询失败时只记录日志并退化为仅请求语言本身，不影响内容读取。
+type LocaleResolver struct {
+	entClient *entCrud.EntClient[*ent.Client]
+	log       *log.Helper
+}
+
+func NewLocaleResolver(ctx *bootstrap.Context, entClient *entCrud.EntClient[*ent.Client]) *LocaleResolver {
+	return &LocaleResolver{
+		entClient: entClient,
+		log:       ctx.NewLoggerHelper("locale-resolver/repo/core-service"),
+	}
+}
+
+// Chain 返回请求语言的回退顺序；requested 为空时返回 nil，表示不指定语言
+func (r *LocaleResolver) Chain(ctx context.Context, requested string) []string {
+	if strings.TrimSpace(requested) == "" {
+		return nil
+	}
+
+	var fallbacks []locale.Fallback
+	var defaults []string
+
+	// 无租户上下文时无法确定站点，只使用系统默认语言
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		entity, err := r.entClient.Client().Site.Query().
+			Where(
+				site.TenantIDEQ(tid),
+				site.IsDefaultEQ(true),
+			).
+			Select(site.FieldDefaultLocale, site.FieldLocaleFallbacks).
+			First(ctx)
+		switch {
+		case err == nil:
+			fallbacks = entity.LocaleFallbacks
+			defaults = append(defaults, trans.StringValue(entity.DefaultLocale))
+		case !ent.IsNotFound(err):
+			r.log.Warnf("query default site of tenant [%d] failed: %s", tid, err.Error())
+		}
+	}
+
+	lang, err := r.entClient.Client().Language.Query().
+		Where(
+			language.IsDefaultEQ(true),
+			language.IsEnabledEQ(true),
+		).
+		Select(language.FieldLanguageCode).
+		First(ctx)
+	switch {
+	case err == nil:
+		defaults = append(defaults, trans.StringValue(lang.LanguageCode))
+	case !ent.IsNotFound(err):
+		r.log.Warnf("query default language failed: %s", err.Error())
+	}
+
+	return locale.Chain(requested, fallbacks, defaults...)
+}
+
+// Serve 按回退顺序从可用语言中选出实际返回的语言，均不可用时返回空串
+func (r *LocaleResolver) Serve(ctx context.Context, requested string, available []string) string {
+	return locale.Pick(r.Chain(ctx, requested), available)
+}
+
+// pickLocale 列表场景下复用同一回退顺序，逐项选出实际返回的语言
+func pickLocale(chain []string, available []string) string {
+	return locale.Pick(chain, available)
+}
@@ -0,0 +1,66 @@
+package data
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/tx7do/go-utils/translator"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/pkg/content/mt"
+)
+
+// MachineTranslator 带翻译记忆与术语表的机器翻译。
+//
+// 逐句段处理：先按原文精确匹配翻译记忆，命中则直接复用；未命中时按租户术语表约束调用翻译引擎，
+// 结果以机器翻译来源记入翻译记忆，相同句段不再重复送翻。
+type MachineTranslator struct {
+	log *log.Helper
+
+	translator            translator.Translator
+	translationMemoryRepo *TranslationMemoryRepo
+	glossaryTermRepo      *GlossaryTermRepo
+}
+
+func NewMachineTranslator(
+	ctx *bootstrap.Context,
+	translator translator.Translator,
+	translationMemoryRepo *TranslationMemoryRepo,
+	glossaryTermRepo *GlossaryTermRepo,
+) *MachineTranslator {
+	return &MachineTranslator{
+		log:                   ctx.NewLoggerHelper("machine-translator/data/core-service"),
+		translator:            translator,
+		translationMemoryRepo: translationMemoryRepo,
+		glossaryTermRepo:      glossaryTermRepo,
+	}
+}
+
+// Session 返回租户在指定语言对下的句段翻译函数；术语表在创建时加载一次，供同一批内容复用。
+// tenantID 为 0 时使用平台级的翻译记忆与术语表。
+func (t *MachineTranslator) Session(ctx context.Context, tenantID uint32, sourceLanguage, targetLanguage string) (mt.TranslateFunc, error) {
+	terms, err := t.glossaryTermRepo.Terms(ctx, tenantID, sourceLanguage, targetLanguage)
+	if err != nil {
+		return nil, err
+	}
+	glossary := mt.NewGlossary(terms)
+
+	provider := func(text string) (string, error) {
+		return t.translator.Translate(text, sourceLanguage, targetLanguage)
+	}
+
+	return func(text string) (string, error) {
+		if translated, ok := t.translationMemoryRepo.Lookup(ctx, tenantID, sourceLanguage, targetLanguage, text); ok {
+			return translated, nil
+		}
+
+		translated, err := glossary.Apply(text, provider)
+		if err != nil {
+			return "", err
+		}
+
+		t.translationMemoryRepo.Remember(ctx, tenantID, sourceLanguage, targetLanguage, text, translated)
+
+		return translated, nil
+	}, nil
+}
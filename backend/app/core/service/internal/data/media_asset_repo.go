@@ -111,7 +111,8 @@ func (r *MediaAssetRepo) List(ctx context.Context, req *paginationV1.PagingReque
 		return nil, mediaV1.ErrorBadRequest("invalid parameter")
 	}
 
-	builder := r.entClient.Client().MediaAsset.Query()
+	builder := r.entClient.Client().MediaAsset.Query().
+		Where(mediaasset.DeletedAtIsNil())
 
 	ret, err := r.repository.ListWithPaging(ctx, builder, builder.Clone(), req)
 	if err != nil {
@@ -132,7 +133,9 @@ func (r *MediaAssetRepo) Get(ctx context.Context, req *mediaV1.GetMediaAssetRequ
 		return nil, mediaV1.ErrorBadRequest("invalid parameter")
 	}
 
-	entity, err := r.entClient.Client().MediaAsset.Get(ctx, req.GetId())
+	entity, err := r.entClient.Client().MediaAsset.Query().
+		Where(mediaasset.IDEQ(req.GetId()), mediaasset.DeletedAtIsNil()).
+		Only(ctx)
 	if err != nil {
 		if ent.IsNotFound(err) {
 			return nil, mediaV1.ErrorFileNotFound("media asset not found")
@@ -206,7 +209,7 @@ func (r *MediaAssetRepo) Update(ctx context.Context, req *mediaV1.UpdateMediaAss
 	tid, hasTenant := maybeTenantFromViewer(ctx)
 	callerUserID, hasUser := viewerUserIDFromContext(ctx)
 	builder := r.entClient.Client().MediaAsset.UpdateOneID(req.GetId())
-	builder.Where(mediaasset.IDEQ(req.GetId()))
+	builder.Where(mediaasset.IDEQ(req.GetId()), mediaasset.DeletedAtIsNil())
 	if hasTenant {
 		builder.Where(mediaasset.TenantIDEQ(tid))
 	}
@@ -246,17 +249,18 @@ func (r *MediaAssetRepo) Update(ctx context.Context, req *mediaV1.UpdateMediaAss
 	return result, err
 }
 
-func (r *MediaAssetRepo) Delete(ctx context.Context, req *mediaV1.DeleteMediaAssetRequest) (bool, error) {
+// Purge 在给定事务中物理删除媒体资源记录，仅由回收站彻底清理时调用
+func (r *MediaAssetRepo) Purge(ctx context.Context, tx *ent.Tx, id uint32) error {
 	tid, hasTenant := maybeTenantFromViewer(ctx)
-	delBuilder := r.entClient.Client().MediaAsset.Delete()
-	delBuilder.Where(mediaasset.IDEQ(req.GetId()))
+	delBuilder := tx.MediaAsset.Delete()
+	delBuilder.Where(mediaasset.IDEQ(id))
 	if hasTenant {
 		delBuilder.Where(mediaasset.TenantIDEQ(tid))
 	}
-	_, err := delBuilder.Exec(ctx)
-	if err != nil {
+	if _, err := delBuilder.Exec(ctx); err != nil {
 		r.log.Errorf("delete one data failed: %s", err.Error())
+		return mediaV1.ErrorInternalServerError("delete one data failed")
 	}
 
-	return err == nil, err
+	return nil
 }
@@ -10,6 +10,8 @@ import (
 
 	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
 	entCrud "github.com/tx7do/go-crud/entgo"
+	"github.com/tx7do/go-crud/pagination"
+	paginationFilter "github.com/tx7do/go-crud/pagination/filter"
 
 	"github.com/tx7do/go-utils/copierutil"
 	"github.com/tx7do/go-utils/mapper"
@@ -39,18 +41,21 @@ type NavigationRepo struct {
 	locationConverter *mapper.EnumTypeConverter[siteV1.Navigation_Location, navigation.Location]
 
 	navigationItemRepo *NavigationItemRepo
+	localeResolver     *LocaleResolver
 }
 
 func NewNavigationRepo(
 	ctx *bootstrap.Context,
 	entClient *entCrud.EntClient[*ent.Client],
 	navigationItemRepo *NavigationItemRepo,
+	localeResolver *LocaleResolver,
 ) *NavigationRepo {
 	repo := &NavigationRepo{
 		entClient:          entClient,
 		log:                ctx.NewLoggerHelper("navigation/repo/core-service"),
 		mapper:             mapper.NewCopierMapper[siteV1.Navigation, ent.Navigation](),
 		navigationItemRepo: navigationItemRepo,
+		localeResolver:     localeResolver,
 		locationConverter: mapper.NewEnumTypeConverter[siteV1.Navigation_Location, navigation.Location](
 			siteV1.Navigation_Location_name, siteV1.Navigation_Location_value,
 		),
@@ -109,6 +114,19 @@ func (r *NavigationRepo) List(ctx context.Context, req *paginationV1.PagingReque
 
 	builder := r.entClient.Client().Navigation.Query()
 
+	// 按语言过滤时改为按回退链匹配，未设置语言的导航视为通用导航
+	requested, err := r.extractLocaleFilter(req)
+	if err != nil {
+		return nil, err
+	}
+	chain := r.localeResolver.Chain(ctx, requested)
+	if len(chain) > 0 {
+		builder.Where(navigation.Or(
+			navigation.LocaleIn(chain...),
+			navigation.LocaleIsNil(),
+		))
+	}
+
 	ret, err := r.repository.ListWithPaging(ctx, builder, builder.Clone(), req)
 	if err != nil {
 		return nil, err
@@ -117,6 +135,11 @@ func (r *NavigationRepo) List(ctx context.Context, req *paginationV1.PagingReque
 		return &siteV1.ListNavigationResponse{Total: 0, Items: nil}, nil
 	}
 
+	if len(chain) > 0 {
+		ret.Items = pickNavigationsByLocale(ret.Items, chain)
+		ret.Total = uint64(len(ret.Items))
+	}
+
 	for _, item := range ret.Items {
 		navigationItems, err := r.navigationItemRepo.ListItems(ctx, item.GetId(), true)
 		if err != nil {
@@ -132,6 +155,48 @@ func (r *NavigationRepo) List(ctx context.Context, req *paginationV1.PagingReque
 	}, nil
 }
 
+// extractLocaleFilter 从过滤条件中取出 locale 条件（由调用方按回退链处理），返回请求的语言
+func (r *NavigationRepo) extractLocaleFilter(req *paginationV1.PagingRequest) (string, error) {
+	filterExpr, err := paginationFilter.ConvertFilterByPagingRequest(req)
+	if err != nil {
+		r.log.Errorf("convert filter by paging request failed: %s", err.Error())
+		return "", siteV1.ErrorBadRequest("invalid filter")
+	}
+
+	conditions := pagination.FilterFields(filterExpr, []string{"locale"})
+	req.FilteringType = &paginationV1.PagingRequest_FilterExpr{FilterExpr: filterExpr}
+
+	for _, cond := range conditions {
+		if cond.GetField() == "locale" {
+			return cond.GetValue(), nil
+		}
+	}
+	return "", nil
+}
+
+// pickNavigationsByLocale 每个渲染位置只保留回退顺序中最靠前的语言的导航，未设置语言的导航始终保留
+func pickNavigationsByLocale(items []*siteV1.Navigation, chain []string) []*siteV1.Navigation {
+	byLocation := make(map[siteV1.Navigation_Location][]string)
+	for _, item := range items {
+		if item.Locale != nil {
+			byLocation[item.GetLocation()] = append(byLocation[item.GetLocation()], item.GetLocale())
+		}
+	}
+
+	served := make(map[siteV1.Navigation_Location]string, len(byLocation))
+	for location, locales := range byLocation {
+		served[location] = pickLocale(chain, locales)
+	}
+
+	out := make([]*siteV1.Navigation, 0, len(items))
+	for _, item := range items {
+		if item.Locale == nil || item.GetLocale() == served[item.GetLocation()] {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
 func (r *NavigationRepo) Get(ctx context.Context, req *siteV1.GetNavigationRequest) (*siteV1.Navigation, error) {
 	if req == nil {
 		return nil, siteV1.ErrorBadRequest("invalid parameter")
@@ -146,6 +211,27 @@ func (r *NavigationRepo) Get(ctx context.Context, req *siteV1.GetNavigationReque
 	case *siteV1.GetNavigationRequest_Name:
 		builder.Where(navigation.NameEQ(req.GetName()))
 
+		// 同名导航按语言区分时，按回退链选出实际返回的语言
+		if chain := r.localeResolver.Chain(ctx, req.GetLocale()); len(chain) > 0 {
+			candidates, err := builder.Clone().
+				Where(navigation.LocaleNotNil()).
+				Select(navigation.FieldID, navigation.FieldLocale).
+				All(ctx)
+			if err != nil {
+				r.log.Errorf("query navigation locales failed: %s", err.Error())
+				return nil, siteV1.ErrorInternalServerError("query navigation locales failed")
+			}
+			locales := make([]string, 0, len(candidates))
+			for _, c := range candidates {
+				locales = append(locales, *c.Locale)
+			}
+			if served := pickLocale(chain, locales); served != "" {
+				builder.Where(navigation.LocaleEQ(served))
+			} else {
+				builder.Where(navigation.LocaleIsNil())
+			}
+		}
+
 	default:
 		return nil, siteV1.ErrorBadRequest("invalid query_by value")
 	}
@@ -0,0 +1,138 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/tx7do/go-utils/copierutil"
+	"github.com/tx7do/go-utils/mapper"
+	"github.com/tx7do/go-utils/trans"
+
+	entCrud "github.com/tx7do/go-crud/entgo"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/notificationpreference"
+
+	commentV1 "go-wind-cms/api/gen/go/comment/service/v1"
+)
+
+// NotificationPreferenceRepo 用户通知偏好
+type NotificationPreferenceRepo struct {
+	entClient *entCrud.EntClient[*ent.Client]
+	log       *log.Helper
+
+	mapper *mapper.CopierMapper[commentV1.NotificationPreference, ent.NotificationPreference]
+}
+
+func NewNotificationPreferenceRepo(ctx *bootstrap.Context, entClient *entCrud.EntClient[*ent.Client]) *NotificationPreferenceRepo {
+	repo := &NotificationPreferenceRepo{
+		entClient: entClient,
+		log:       ctx.NewLoggerHelper("notification-preference/repo/core-service"),
+		mapper:    mapper.NewCopierMapper[commentV1.NotificationPreference, ent.NotificationPreference](),
+	}
+
+	repo.mapper.AppendConverters(copierutil.NewTimeStringConverterPair())
+	repo.mapper.AppendConverters(copierutil.NewTimeTimestamppbConverterPair())
+
+	return repo
+}
+
+// DefaultNotificationPreference 未设置偏好的用户默认全部开启
+func DefaultNotificationPreference(userID uint32) *commentV1.NotificationPreference {
+	return &commentV1.NotificationPreference{
+		UserId:             trans.Ptr(userID),
+		ReplyEnabled:       trans.Ptr(true),
+		PostCommentEnabled: trans.Ptr(true),
+		WatchedPostEnabled: trans.Ptr(true),
+		InAppEnabled:       trans.Ptr(true),
+		EmailEnabled:       trans.Ptr(true),
+	}
+}
+
+// Get 获取用户通知偏好，未设置时返回默认值
+func (r *NotificationPreferenceRepo) Get(ctx context.Context, tenantID, userID uint32) (*commentV1.NotificationPreference, error) {
+	prefs, err := r.GetMany(ctx, tenantID, []uint32{userID})
+	if err != nil {
+		return nil, err
+	}
+	return prefs[userID], nil
+}
+
+// GetMany 批量获取用户通知偏好，未设置的用户返回默认值
+func (r *NotificationPreferenceRepo) GetMany(ctx context.Context, tenantID uint32, userIDs []uint32) (map[uint32]*commentV1.NotificationPreference, error) {
+	res := make(map[uint32]*commentV1.NotificationPreference, len(userIDs))
+	if len(userIDs) == 0 {
+		return res, nil
+	}
+
+	entities, err := r.entClient.Client().NotificationPreference.Query().
+		Where(
+			notificationpreference.TenantIDEQ(tenantID),
+			notificationpreference.UserIDIn(userIDs...),
+		).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query notification preferences failed: %s", err.Error())
+		return nil, commentV1.ErrorInternalServerError("query notification preferences failed")
+	}
+
+	for _, entity := range entities {
+		res[trans.Uint32Value(entity.UserID)] = r.mapper.ToDTO(entity)
+	}
+	for _, uid := range userIDs {
+		if _, ok := res[uid]; !ok {
+			res[uid] = DefaultNotificationPreference(uid)
+		}
+	}
+	return res, nil
+}
+
+// Save 保存用户通知偏好，data 中未设置的字段保持不变
+func (r *NotificationPreferenceRepo) Save(ctx context.Context, tenantID, userID uint32, data *commentV1.NotificationPreference) (*commentV1.NotificationPreference, error) {
+	if data == nil {
+		return nil, commentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	existing, err := r.entClient.Client().NotificationPreference.Query().
+		Where(
+			notificationpreference.TenantIDEQ(tenantID),
+			notificationpreference.UserIDEQ(userID),
+		).
+		Only(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		r.log.Errorf("query notification preference failed: %s", err.Error())
+		return nil, commentV1.ErrorInternalServerError("query notification preference failed")
+	}
+
+	var entity *ent.NotificationPreference
+	if existing != nil {
+		entity, err = r.entClient.Client().NotificationPreference.UpdateOne(existing).
+			SetNillableReplyEnabled(data.ReplyEnabled).
+			SetNillablePostCommentEnabled(data.PostCommentEnabled).
+			SetNillableWatchedPostEnabled(data.WatchedPostEnabled).
+			SetNillableInAppEnabled(data.InAppEnabled).
+			SetNillableEmailEnabled(data.EmailEnabled).
+			SetUpdatedAt(time.Now()).
+			Save(ctx)
+	} else {
+		entity, err = r.entClient.Client().NotificationPreference.Create().
+			SetTenantID(tenantID).
+			SetUserID(userID).
+			SetNillableReplyEnabled(data.ReplyEnabled).
+			SetNillablePostCommentEnabled(data.PostCommentEnabled).
+			SetNillableWatchedPostEnabled(data.WatchedPostEnabled).
+			SetNillableInAppEnabled(data.InAppEnabled).
+			SetNillableEmailEnabled(data.EmailEnabled).
+			SetCreatedAt(time.Now()).
+			Save(ctx)
+	}
+	if err != nil {
+		r.log.Errorf("save notification preference failed: %s", err.Error())
+		return nil, commentV1.ErrorInternalServerError("save notification preference failed")
+	}
+
+	return r.mapper.ToDTO(entity), nil
+}
@@ -2,6 +2,8 @@ package data
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"time"
 
 	"entgo.io/ent/dialect/sql"
@@ -16,10 +18,15 @@ import (
 	"github.com/tx7do/go-utils/trans"
 
 	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/fieldgroup"
 	"go-wind-cms/app/core/service/internal/data/ent/page"
 	"go-wind-cms/app/core/service/internal/data/ent/predicate"
 
 	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/customfield"
+	"go-wind-cms/pkg/content/trash"
+	"go-wind-cms/pkg/utils"
 )
 
 type PageRepo struct {
@@ -43,6 +50,8 @@ type PageRepo struct {
 
 	pageTranslationRepo *PageTranslationRepo
 	sectionRepo         *SectionRepo
+	fieldGroupRepo      *FieldGroupRepo
+	localeResolver      *LocaleResolver
 }
 
 func NewPageRepo(
@@ -50,6 +59,8 @@ func NewPageRepo(
 	entClient *entCrud.EntClient[*ent.Client],
 	pageTranslationRepo *PageTranslationRepo,
 	sectionRepo *SectionRepo,
+	fieldGroupRepo *FieldGroupRepo,
+	localeResolver *LocaleResolver,
 ) *PageRepo {
 	repo := &PageRepo{
 		entClient: entClient,
@@ -66,6 +77,8 @@ func NewPageRepo(
 		),
 		pageTranslationRepo: pageTranslationRepo,
 		sectionRepo:         sectionRepo,
+		fieldGroupRepo:      fieldGroupRepo,
+		localeResolver:      localeResolver,
 	}
 
 	repo.init()
@@ -89,6 +102,83 @@ func (r *PageRepo) init() {
 	r.mapper.AppendConverters(r.statusConverter.NewConverterPair())
 	r.mapper.AppendConverters(r.typeConverter.NewConverterPair())
 	r.mapper.AppendConverters(r.editorTypeConverter.NewConverterPair())
+	r.mapper.AppendConverters(newCustomFieldValuesConverterPair())
+}
+
+// validateFieldValues 按页面模板命中的字段组校验类型化字段值，返回可直接落库的值
+func (r *PageRepo) validateFieldValues(ctx context.Context, tenantID uint32, template string, values map[string]*contentV1.CustomFieldValue) (map[string]*customfield.Value, error) {
+	defs, err := r.fieldGroupRepo.Definitions(ctx, tenantID, fieldgroup.LocationLocationPage, FieldTarget{
+		PageTemplate: template,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return validateCustomFieldValues(defs, values)
+}
+
+// prepareFieldValues 更新时页面模板或字段值变化都会改变命中的字段组，
+// 此时按合并后的完整数据重新校验；均未变化时返回 nil，不改动字段值。
+func (r *PageRepo) prepareFieldValues(ctx context.Context, req *contentV1.UpdatePageRequest) (map[string]*customfield.Value, bool, error) {
+	valuesChanged := req.Data.FieldValues != nil || slices.Contains(req.GetUpdateMask().GetPaths(), "field_values")
+	if !valuesChanged && req.Data.Template == nil {
+		return nil, false, nil
+	}
+
+	entity, err := r.entClient.Client().Page.Query().
+		Where(page.IDEQ(req.GetId())).
+		Select(page.FieldTenantID, page.FieldTemplate, page.FieldFieldValues).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, false, contentV1.ErrorNotFound("page not found")
+		}
+		r.log.Errorf("query page failed: %s", err.Error())
+		return nil, false, contentV1.ErrorInternalServerError("query page failed")
+	}
+
+	template := trans.StringValue(entity.Template)
+	if req.Data.Template != nil {
+		template = req.Data.GetTemplate()
+	}
+
+	values := req.Data.GetFieldValues()
+	if !valuesChanged {
+		values = customFieldValuesToProto(entity.FieldValues)
+	}
+
+	fieldValues, err := r.validateFieldValues(ctx, trans.Uint32Value(entity.TenantID), template, values)
+	if err != nil {
+		return nil, false, err
+	}
+	return fieldValues, true, nil
+}
+
+// applyCustomFieldQuery 处理列表中 "field_values.<key>" 的过滤与排序，
+// 字段定义取自当前租户全部启用的页面字段组。
+func (r *PageRepo) applyCustomFieldQuery(ctx context.Context, builder *ent.PageQuery, req *paginationV1.PagingRequest) error {
+	query, err := takeCustomFieldQuery(req)
+	if err != nil || query.empty() {
+		return err
+	}
+
+	tid, _ := maybeTenantFromViewer(ctx)
+	defs, err := r.fieldGroupRepo.AllDefinitions(ctx, tid, fieldgroup.LocationLocationPage)
+	if err != nil {
+		return err
+	}
+
+	wheres, orders, err := query.build(page.FieldFieldValues, defs)
+	if err != nil {
+		return err
+	}
+	if len(wheres) > 0 {
+		builder.Modify(wheres...)
+	}
+	for _, order := range orders {
+		builder.Order(page.OrderOption(order))
+	}
+
+	return nil
 }
 
 func (r *PageRepo) IsExist(ctx context.Context, id uint32) (bool, error) {
@@ -120,7 +210,12 @@ func (r *PageRepo) List(ctx context.Context, req *paginationV1.PagingRequest) (*
 		return nil, contentV1.ErrorBadRequest("invalid parameter")
 	}
 
-	builder := r.entClient.Client().Page.Query()
+	builder := r.entClient.Client().Page.Query().
+		Where(page.DeletedAtIsNil())
+
+	if err := r.applyCustomFieldQuery(ctx, builder, req); err != nil {
+		return nil, err
+	}
 
 	ret, err := r.repository.ListWithPaging(ctx, builder, builder.Clone(), req)
 	if err != nil {
@@ -159,7 +254,8 @@ func (r *PageRepo) Get(ctx context.Context, req *contentV1.GetPageRequest) (*con
 		return nil, contentV1.ErrorBadRequest("invalid parameter")
 	}
 
-	builder := r.entClient.Client().Page.Query()
+	builder := r.entClient.Client().Page.Query().
+		Where(page.DeletedAtIsNil())
 
 	switch req.QueryBy.(type) {
 	case *contentV1.GetPageRequest_Id:
@@ -183,13 +279,6 @@ func (r *PageRepo) Get(ctx context.Context, req *contentV1.GetPageRequest) (*con
 
 	dto := r.mapper.ToDTO(entity)
 
-	translations, err := r.pageTranslationRepo.ListTranslations(ctx, dto.GetId())
-	if err != nil {
-		r.log.Errorf("query translations failed: %s", err.Error())
-		return nil, contentV1.ErrorInternalServerError("query translations failed")
-	}
-	dto.Translations = translations
-
 	languages, err := r.pageTranslationRepo.ListAvailedLanguages(ctx, dto.GetId())
 	if err != nil {
 		r.log.Errorf("query availed languages failed: %s", err.Error())
@@ -197,6 +286,23 @@ func (r *PageRepo) Get(ctx context.Context, req *contentV1.GetPageRequest) (*con
 	}
 	dto.AvailableLanguages = languages
 
+	if req.Locale == nil {
+		translations, err := r.pageTranslationRepo.ListTranslations(ctx, dto.GetId())
+		if err != nil {
+			r.log.Errorf("query translations failed: %s", err.Error())
+			return nil, contentV1.ErrorInternalServerError("query translations failed")
+		}
+		dto.Translations = translations
+	} else if served := r.localeResolver.Serve(ctx, req.GetLocale(), languages); served != "" {
+		// 指定语言时只返回按回退链选出的翻译
+		translation, err := r.pageTranslationRepo.GetTranslation(ctx, dto.GetId(), served)
+		if err != nil {
+			return nil, err
+		}
+		dto.Translations = append(dto.Translations, translation)
+		dto.ServedLocale = trans.Ptr(served)
+	}
+
 	return dto, nil
 }
 
@@ -205,6 +311,13 @@ func (r *PageRepo) Create(ctx context.Context, req *contentV1.CreatePageRequest)
 		return nil, contentV1.ErrorBadRequest("invalid parameter")
 	}
 
+	// 未填写的必填字段同样会被拒绝，故创建时总是校验
+	tid, _ := maybeTenantFromViewer(ctx)
+	var fieldValues map[string]*customfield.Value
+	if fieldValues, err = r.validateFieldValues(ctx, tid, req.Data.GetTemplate(), req.Data.GetFieldValues()); err != nil {
+		return nil, err
+	}
+
 	var tx *ent.Tx
 	tx, err = r.entClient.Client().Tx(ctx)
 	if err != nil {
@@ -240,6 +353,7 @@ func (r *PageRepo) Create(ctx context.Context, req *contentV1.CreatePageRequest)
 		SetNillableParentID(req.Data.ParentId).
 		SetNillableDepth(req.Data.Depth).
 		SetNillablePath(req.Data.Path).
+		SetFieldValues(fieldValues).
 		SetNillableCreatedBy(req.Data.CreatedBy).
 		SetCreatedAt(time.Now())
 
@@ -293,6 +407,30 @@ func (r *PageRepo) Update(ctx context.Context, req *contentV1.UpdatePageRequest)
 		}
 	}
 
+	fieldValues, fieldValuesChanged, err := r.prepareFieldValues(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 整体替换语言版本前记下各语言的完整路径，提交后对比并记录自动重定向
+	var beforePaths map[string]string
+	if len(req.Data.Translations) > 0 {
+		if beforePaths, _, err = r.pageTranslationRepo.FullPaths(ctx, req.GetId()); err != nil {
+			return nil, err
+		}
+	}
+
+	// slug、上级页面或语言版本变化会改变本页面及全部下级页面的层级路径，提交后同样记录重定向
+	var (
+		beforeHierarchy map[pageHierarchyKey]string
+		pageTenantID    *uint32
+	)
+	if req.Data.Slug != nil || req.Data.ParentId != nil || len(req.Data.Translations) > 0 {
+		if beforeHierarchy, pageTenantID, err = r.pageTranslationRepo.redirectRepo.PageHierarchyPaths(ctx, req.GetId()); err != nil {
+			return nil, err
+		}
+	}
+
 	var tx *ent.Tx
 	tx, err = r.entClient.Client().Tx(ctx)
 	if err != nil {
@@ -309,6 +447,13 @@ func (r *PageRepo) Update(ctx context.Context, req *contentV1.UpdatePageRequest)
 		if commitErr := tx.Commit(); commitErr != nil {
 			r.log.Errorf("transaction commit failed: %s", commitErr.Error())
 			err = contentV1.ErrorInternalServerError("transaction commit failed")
+			return
+		}
+		r.pageTranslationRepo.RecordReplacedPaths(ctx, req.GetId(), beforePaths)
+		if beforeHierarchy != nil {
+			if afterHierarchy, _, pathErr := r.pageTranslationRepo.redirectRepo.PageHierarchyPaths(ctx, req.GetId()); pathErr == nil {
+				r.pageTranslationRepo.redirectRepo.RecordPageHierarchyChange(ctx, pageTenantID, beforeHierarchy, afterHierarchy)
+			}
 		}
 	}()
 
@@ -332,8 +477,13 @@ func (r *PageRepo) Update(ctx context.Context, req *contentV1.UpdatePageRequest)
 	callerUserID, hasUser := viewerUserIDFromContext(ctx)
 	// 计数列已从 Page 表移除，统一存于 interaction_counter 表（由 InteractionService 独占写入），
 	// 故此处不再需要 FilterBlacklist 保护计数列。
+	// 类型化字段值经校验后单独写入。
+	if req.UpdateMask != nil {
+		req.UpdateMask.Paths = utils.FilterBlacklist(req.UpdateMask.GetPaths(), []string{"field_values"})
+	}
+
 	builder := tx.Page.UpdateOneID(req.GetId())
-	builder.Where(page.IDEQ(req.GetId()))
+	builder.Where(page.IDEQ(req.GetId()), page.DeletedAtIsNil())
 	if hasTenant {
 		builder.Where(page.TenantIDEQ(tid))
 	}
@@ -365,6 +515,10 @@ func (r *PageRepo) Update(ctx context.Context, req *contentV1.UpdatePageRequest)
 			if req.Data.CustomFields != nil {
 				builder.SetCustomFields(trans.Ptr(req.Data.GetCustomFields()))
 			}
+
+			if fieldValuesChanged {
+				builder.SetFieldValues(fieldValues)
+			}
 		},
 		func(s *sql.Selector) {
 			s.Where(sql.EQ(page.FieldID, req.GetId()))
@@ -374,16 +528,19 @@ func (r *PageRepo) Update(ctx context.Context, req *contentV1.UpdatePageRequest)
 	return result, err
 }
 
-func (r *PageRepo) Delete(ctx context.Context, req *contentV1.DeletePageRequest) (err error) {
-	if req == nil {
-		return contentV1.ErrorBadRequest("invalid parameter")
+// Duplicate 复制页面及其翻译与区块，副本为草稿。
+//
+// 未指定 slug 时在原 slug 后追加序号；翻译的 slug 按标题重新生成，完整路径随之更新。
+func (r *PageRepo) Duplicate(ctx context.Context, req *contentV1.DuplicatePageRequest) (dto *contentV1.Page, err error) {
+	if req == nil || req.GetId() == 0 {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
 	}
 
 	var tx *ent.Tx
 	tx, err = r.entClient.Client().Tx(ctx)
 	if err != nil {
 		r.log.Errorf("start transaction failed: %s", err.Error())
-		return contentV1.ErrorInternalServerError("start transaction failed")
+		return nil, contentV1.ErrorInternalServerError("start transaction failed")
 	}
 	defer func() {
 		if err != nil {
@@ -398,24 +555,144 @@ func (r *PageRepo) Delete(ctx context.Context, req *contentV1.DeletePageRequest)
 		}
 	}()
 
+	tid, hasTenant := maybeTenantFromViewer(ctx)
+	query := tx.Page.Query().Where(page.IDEQ(req.GetId()), page.DeletedAtIsNil())
+	if hasTenant {
+		query.Where(page.TenantIDEQ(tid))
+	}
+	var src *ent.Page
+	if src, err = query.Only(ctx); err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound("page not found")
+		}
+		r.log.Errorf("query page failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query page failed")
+	}
+
+	// 页面 slug 在同级页面中唯一
+	slugTaken := func(candidate string) (bool, error) {
+		q := tx.Page.Query().Where(page.SlugEQ(candidate), page.DeletedAtIsNil())
+		if hasTenant {
+			q.Where(page.TenantIDEQ(tid))
+		}
+		if src.ParentID == nil {
+			q.Where(page.ParentIDIsNil())
+		} else {
+			q.Where(page.ParentIDEQ(*src.ParentID))
+		}
+		return q.Exist(ctx)
+	}
+	var newSlug string
+	if req.Slug != nil {
+		newSlug = req.GetSlug()
+		var taken bool
+		if taken, err = slugTaken(newSlug); err != nil {
+			r.log.Errorf("query page slug failed: %s", err.Error())
+			return nil, contentV1.ErrorInternalServerError("query page slug failed")
+		}
+		if taken {
+			return nil, contentV1.ErrorConflict("page slug %s already exists", newSlug)
+		}
+	} else if oldSlug := trans.StringValue(src.Slug); oldSlug != "" {
+		if newSlug, err = trash.ResolveSlug(oldSlug, slugTaken); err != nil {
+			if errors.Is(err, trash.ErrSlugExhausted) {
+				return nil, contentV1.ErrorConflict("no free slug for %s", oldSlug)
+			}
+			r.log.Errorf("resolve page slug failed: %s", err.Error())
+			return nil, contentV1.ErrorInternalServerError("resolve page slug failed")
+		}
+	}
+
+	var createdBy *uint32
+	if uid, ok := viewerUserIDFromContext(ctx); ok {
+		createdBy = trans.Ptr(uid)
+	}
+
+	builder := tx.Page.Create().
+		SetStatus(page.StatusPageStatusDraft).
+		SetNillableType(src.Type).
+		SetNillableEditorType(src.EditorType).
+		SetNillableAuthorID(src.AuthorID).
+		SetNillableAuthorName(src.AuthorName).
+		SetNillableDisallowComment(src.DisallowComment).
+		SetNillableRedirectURL(src.RedirectURL).
+		SetNillableShowInNavigation(src.ShowInNavigation).
+		SetNillableSortOrder(src.SortOrder).
+		SetNillableTemplate(src.Template).
+		SetNillableIsCustomTemplate(src.IsCustomTemplate).
+		SetNillableParentID(src.ParentID).
+		SetNillableDepth(src.Depth).
+		SetNillablePath(src.Path).
+		SetFieldValues(src.FieldValues).
+		SetNillableCreatedBy(createdBy).
+		SetCreatedAt(time.Now())
+	if newSlug != "" {
+		builder.SetSlug(newSlug)
+	}
+	if src.CustomFields != nil {
+		builder.SetCustomFields(src.CustomFields)
+	}
+
+	var entity *ent.Page
+	if entity, err = builder.Save(ctx); err != nil {
+		r.log.Errorf("insert page failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("insert page failed")
+	}
+
+	var translations []*contentV1.PageTranslation
+	if translations, err = r.pageTranslationRepo.ListTranslations(ctx, src.ID); err != nil {
+		return nil, err
+	}
+	for _, t := range translations {
+		oldSlug := t.GetSlug()
+
+		t.Id = nil
+		t.PageId = trans.Ptr(entity.ID)
+		t.CreatedBy = createdBy
+		t.UpdatedBy = nil
+		t.CreatedAt = nil
+		t.UpdatedAt = nil
+
+		if err = r.pageTranslationRepo.PrepareTranslation(ctx, t); err != nil {
+			return nil, err
+		}
+		if t.FullPath != nil {
+			t.FullPath = trans.Ptr(trash.ReplaceSlug(t.GetFullPath(), oldSlug, t.GetSlug()))
+		}
+	}
+	if err = r.pageTranslationRepo.BatchCreate(ctx, tx, translations); err != nil {
+		return nil, err
+	}
+
+	if err = r.sectionRepo.CopyToPage(ctx, tx, src.ID, entity.ID); err != nil {
+		return nil, err
+	}
+
+	dto = r.mapper.ToDTO(entity)
+	dto.Translations = translations
+	return dto, nil
+}
+
+// Purge 在给定事务中物理删除页面及其翻译与下属区块，仅由回收站彻底清理时调用
+func (r *PageRepo) Purge(ctx context.Context, tx *ent.Tx, id uint32) error {
 	tid, hasTenant := maybeTenantFromViewer(ctx)
 	delBuilder := tx.Page.Delete()
-	delBuilder.Where(page.IDEQ(req.GetId()))
+	delBuilder.Where(page.IDEQ(id))
 	if hasTenant {
 		delBuilder.Where(page.TenantIDEQ(tid))
 	}
-	if _, err = delBuilder.Exec(ctx); err != nil {
+	if _, err := delBuilder.Exec(ctx); err != nil {
 		r.log.Errorf("delete one data failed: %s", err.Error())
 		return contentV1.ErrorInternalServerError("delete one data failed")
 	}
 
-	if err = r.pageTranslationRepo.CleanTranslations(ctx, tx, req.GetId()); err != nil {
+	if err := r.pageTranslationRepo.CleanTranslations(ctx, tx, id); err != nil {
 		r.log.Errorf("clean translations failed: %s", err.Error())
 		return contentV1.ErrorInternalServerError("clean translations failed")
 	}
 
 	// 清理该页面下的所有 section 及其翻译，防止孤儿记录
-	if err = r.sectionRepo.CleanByPageID(ctx, tx, req.GetId()); err != nil {
+	if err := r.sectionRepo.CleanByPageID(ctx, tx, id); err != nil {
 		r.log.Errorf("clean sections by page id failed: %s", err.Error())
 		return contentV1.ErrorInternalServerError("clean sections by page id failed")
 	}
@@ -479,7 +756,17 @@ func (r *PageRepo) GetTranslation(ctx context.Context, req *contentV1.GetPageReq
 		return nil, contentV1.ErrorBadRequest("invalid parameter")
 	}
 
-	return r.pageTranslationRepo.GetTranslation(ctx, req.GetId(), req.GetLocale())
+	languages, err := r.pageTranslationRepo.ListAvailedLanguages(ctx, req.GetId())
+	if err != nil {
+		r.log.Errorf("query availed languages failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query availed languages failed")
+	}
+	served := r.localeResolver.Serve(ctx, req.GetLocale(), languages)
+	if served == "" {
+		return nil, contentV1.ErrorNotFound("translation not found")
+	}
+
+	return r.pageTranslationRepo.GetTranslation(ctx, req.GetId(), served)
 }
 
 func (r *PageRepo) ListTranslations(ctx context.Context, pageID uint32) ([]*contentV1.PageTranslation, error) {
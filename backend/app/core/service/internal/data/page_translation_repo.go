@@ -23,6 +23,8 @@ import (
 	"go-wind-cms/app/core/service/internal/data/ent/predicate"
 
 	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/utils"
 )
 
 type PageTranslationRepo struct {
@@ -39,13 +41,21 @@ type PageTranslationRepo struct {
 		predicate.PageTranslation,
 		contentV1.PageTranslation, ent.PageTranslation,
 	]
+
+	translationStatusConverter *mapper.EnumTypeConverter[contentV1.TranslationStatus, pagetranslation.TranslationStatus]
+
+	redirectRepo *RedirectRepo
 }
 
-func NewPageTranslationRepo(ctx *bootstrap.Context, entClient *entCrud.EntClient[*ent.Client]) *PageTranslationRepo {
+func NewPageTranslationRepo(ctx *bootstrap.Context, entClient *entCrud.EntClient[*ent.Client], redirectRepo *RedirectRepo) *PageTranslationRepo {
 	repo := &PageTranslationRepo{
-		entClient: entClient,
-		log:       ctx.NewLoggerHelper("page-translation/repo/core-service"),
-		mapper:    mapper.NewCopierMapper[contentV1.PageTranslation, ent.PageTranslation](),
+		entClient:    entClient,
+		redirectRepo: redirectRepo,
+		log:          ctx.NewLoggerHelper("page-translation/repo/core-service"),
+		mapper:       mapper.NewCopierMapper[contentV1.PageTranslation, ent.PageTranslation](),
+		translationStatusConverter: mapper.NewEnumTypeConverter[contentV1.TranslationStatus, pagetranslation.TranslationStatus](
+			contentV1.TranslationStatus_name, contentV1.TranslationStatus_value,
+		),
 	}
 
 	repo.init()
@@ -65,6 +75,7 @@ func (r *PageTranslationRepo) init() {
 
 	r.mapper.AppendConverters(copierutil.NewTimeStringConverterPair())
 	r.mapper.AppendConverters(copierutil.NewTimeTimestamppbConverterPair())
+	r.mapper.AppendConverters(r.translationStatusConverter.NewConverterPair())
 }
 
 func (r *PageTranslationRepo) CleanTranslations(
@@ -115,6 +126,10 @@ func (r *PageTranslationRepo) newCreateBuilder(data *contentV1.PageTranslation)
 		SetNillableThumbnail(data.Thumbnail).
 		SetNillableCoverImage(data.CoverImage).
 		SetNillableFullPath(data.FullPath).
+		SetNillableIsDraft(data.IsDraft).
+		SetNillableSourceLanguage(data.SourceLanguage).
+		SetNillableSourceRevision(data.SourceRevision).
+		SetNillableTranslationStatus(r.translationStatusConverter.ToEntity(data.TranslationStatus)).
 		SetNillableCreatedBy(data.CreatedBy).
 		SetCreatedAt(now)
 
@@ -133,6 +148,9 @@ func (r *PageTranslationRepo) BatchCreate(ctx context.Context, tx *ent.Tx, items
 	builders := make([]*ent.PageTranslationCreate, 0, len(items))
 	for _, data := range items {
 		_ = r.PrepareTranslation(ctx, data)
+		if err := r.anchorTranslation(ctx, tx.Client(), data, items); err != nil {
+			return err
+		}
 
 		builder := r.newCreateBuilder(data)
 
@@ -152,6 +170,9 @@ func (r *PageTranslationRepo) CreateTranslation(ctx context.Context, data *conte
 	if err := r.PrepareTranslation(ctx, data); err != nil {
 		return nil, err
 	}
+	if err := r.anchorTranslation(ctx, r.entClient.Client(), data, nil); err != nil {
+		return nil, err
+	}
 
 	builder := r.newCreateBuilder(data)
 
@@ -171,6 +192,31 @@ func (r *PageTranslationRepo) UpdateTranslation(ctx context.Context, id uint32,
 		return nil, nil
 	}
 
+	// 记录更新前的完整路径，变更后自动生成旧路径的重定向
+	old, err := r.entClient.Client().PageTranslation.Get(ctx, id)
+	if err != nil && !ent.IsNotFound(err) {
+		r.log.Errorf("query page translation failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query page translation failed")
+	}
+
+	// 修订跟踪字段由服务端维护：修改源语言或确认已校对时重新锚定，其余情况忽略客户端传入值
+	var anchor *translationAnchor
+	if old != nil {
+		if lang, ok := translationAnchorRequested(updateMask, data.SourceLanguage, data.TranslationStatus, old.SourceLanguage); ok {
+			if anchor, err = resolveTranslationAnchor(trans.StringValue(old.LanguageCode), lang, func(l string) (uint32, bool, error) {
+				return pageTranslationRevision(ctx, r.entClient.Client(), trans.Uint32Value(old.PageID), l)
+			}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if updateMask != nil {
+		updateMask.Paths = utils.FilterBlacklist(updateMask.GetPaths(), translationTrackingFields)
+		updateMask.Paths = utils.FilterBlacklist(updateMask.GetPaths(), seoDerivedFields)
+	}
+	data.Revision, data.SourceLanguage, data.SourceRevision, data.TranslationStatus = nil, nil, nil, nil
+	data.SeoAnalysis = nil
+
 	builder := r.entClient.Client().PageTranslation.UpdateOneID(id)
 	// 租户作用域：仅更新本租户翻译，避免跨租户改他人翻译（按 hasTenant 条件加）
 	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
@@ -185,6 +231,7 @@ func (r *PageTranslationRepo) UpdateTranslation(ctx context.Context, id uint32,
 				SetNillableThumbnail(data.Thumbnail).
 				SetNillableCoverImage(data.CoverImage).
 				SetNillableFullPath(data.FullPath).
+				SetNillableIsDraft(data.IsDraft).
 				SetUpdatedAt(time.Now())
 
 			// updated_by 强制由服务端 viewer context 推导，忽略客户端传入值
@@ -195,6 +242,15 @@ func (r *PageTranslationRepo) UpdateTranslation(ctx context.Context, id uint32,
 			if data.Seo != nil {
 				builder.SetSeo(data.Seo)
 			}
+
+			if anchor != nil {
+				builder.SetNillableTranslationStatus(r.translationStatusConverter.ToEntity(&anchor.status))
+				if anchor.language != nil {
+					builder.SetSourceLanguage(*anchor.language).SetSourceRevision(*anchor.revision)
+				} else {
+					builder.ClearSourceLanguage().ClearSourceRevision()
+				}
+			}
 		},
 		func(s *sql.Selector) {
 			s.Where(sql.EQ(pagetranslation.FieldID, id))
@@ -205,9 +261,80 @@ func (r *PageTranslationRepo) UpdateTranslation(ctx context.Context, id uint32,
 		return nil, contentV1.ErrorInternalServerError("update page translation failed")
 	}
 
+	// 可翻译字段有修改时递增修订号，依据此语言版本的译文随之过期
+	if old != nil && pageTranslationChanged(old, dto) {
+		revision, bumpErr := bumpPageTranslationRevision(ctx, r.entClient.Client(), id)
+		if bumpErr != nil {
+			r.log.Errorf("bump page translation revision failed: %s", bumpErr.Error())
+			return nil, contentV1.ErrorInternalServerError("bump page translation revision failed")
+		}
+		dto.Revision = trans.Ptr(revision)
+	}
+
+	if old != nil {
+		r.redirectRepo.RecordTranslationPathChange(ctx, old.TenantID, contentV1.ContentType_CONTENT_TYPE_PAGE,
+			trans.Uint32Value(old.PageID), trans.StringValue(old.LanguageCode),
+			trans.StringValue(old.FullPath), dto.GetFullPath())
+	}
+
 	return dto, nil
 }
 
+// FullPaths 返回页面各语言版本的完整路径与所属租户，用于整体替换语言版本前后对比
+func (r *PageTranslationRepo) FullPaths(ctx context.Context, pageID uint32) (map[string]string, *uint32, error) {
+	entities, err := r.entClient.Client().PageTranslation.Query().
+		Where(pagetranslation.PageIDEQ(pageID)).
+		Select(pagetranslation.FieldTenantID, pagetranslation.FieldLanguageCode, pagetranslation.FieldFullPath).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query page translation paths failed: %s", err.Error())
+		return nil, nil, contentV1.ErrorInternalServerError("query page translation paths failed")
+	}
+
+	var tenantID *uint32
+	paths := make(map[string]string, len(entities))
+	for _, e := range entities {
+		tenantID = e.TenantID
+		paths[trans.StringValue(e.LanguageCode)] = trans.StringValue(e.FullPath)
+	}
+	return paths, tenantID, nil
+}
+
+// RecordReplacedPaths 整体替换语言版本后对比各语言的完整路径，有变化时记录自动重定向（best-effort）
+func (r *PageTranslationRepo) RecordReplacedPaths(ctx context.Context, pageID uint32, before map[string]string) {
+	if len(before) == 0 {
+		return
+	}
+	after, tenantID, err := r.FullPaths(ctx, pageID)
+	if err != nil {
+		return
+	}
+	r.redirectRepo.RecordReplacedPaths(ctx, tenantID, contentV1.ContentType_CONTENT_TYPE_PAGE, pageID, before, after)
+}
+
+// anchorTranslation 设置新建语言版本的依据：指定源语言时锚定到其当前修订号，否则作为原文。
+// batch 为同批创建的语言版本，源语言在其中时修订号为初始值 1。
+func (r *PageTranslationRepo) anchorTranslation(ctx context.Context, client *ent.Client, data *contentV1.PageTranslation, batch []*contentV1.PageTranslation) error {
+	anchor, err := resolveTranslationAnchor(data.GetLanguageCode(), data.GetSourceLanguage(), func(lang string) (uint32, bool, error) {
+		for _, item := range batch {
+			if item != data && item.GetLanguageCode() == lang {
+				return 1, true, nil
+			}
+		}
+		return pageTranslationRevision(ctx, client, data.GetPageId(), lang)
+	})
+	if err != nil {
+		return err
+	}
+
+	data.Revision = nil
+	data.TranslationStatus = trans.Ptr(anchor.status)
+	data.SourceLanguage = anchor.language
+	data.SourceRevision = anchor.revision
+
+	return nil
+}
+
 func (r *PageTranslationRepo) CountByBaseSlug(ctx context.Context, baseSlug string) (int64, error) {
 	c, err := r.entClient.Client().PageTranslation.Query().
 		Where(
@@ -238,11 +365,13 @@ func (r *PageTranslationRepo) TranslationExists(ctx context.Context, pageId uint
 	return c > 0, nil
 }
 
-// ListAvailedLanguages lists the language codes of all translations available for the given page ID.
+// ListAvailedLanguages lists the language codes of all published (non-draft) translations available for the given page ID.
 func (r *PageTranslationRepo) ListAvailedLanguages(ctx context.Context, pageId uint32) ([]string, error) {
 	entities, err := r.entClient.Client().PageTranslation.Query().
 		Where(
 			pagetranslation.PageIDEQ(pageId),
+			// 草稿翻译待审阅，不对外提供
+			pagetranslation.Or(pagetranslation.IsDraftIsNil(), pagetranslation.IsDraftEQ(false)),
 		).
 		Select(pagetranslation.FieldLanguageCode).
 		Strings(ctx)
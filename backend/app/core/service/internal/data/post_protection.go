@@ -0,0 +1,121 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/unlock"
+)
+
+const (
+	// PostUnlockAttemptKeyFormat 文章解锁尝试计数键格式 post:unlock:{tenant_id}:{post_id}:{subject}
+	PostUnlockAttemptKeyFormat = ProjectPrefix + "post:unlock:%d:%d:%s"
+
+	defaultPostUnlockWindow = 15 * time.Minute
+)
+
+// NewPostProtectionOption 读取自定义配置 PostProtection，未配置时返回 nil（受保护文章无法解锁）
+func NewPostProtectionOption(ctx *bootstrap.Context) *contentV1.PostProtectionOption {
+	var cfg *contentV1.PostProtectionOptionWrapper
+	rawCfg, ok := ctx.GetCustomConfig("PostProtection")
+	if ok {
+		cfg = rawCfg.(*contentV1.PostProtectionOptionWrapper)
+	}
+	if cfg == nil {
+		return nil
+	}
+	return cfg.PostProtection
+}
+
+// PostProtection 受密码保护文章的解锁限流与令牌签发
+type PostProtection struct {
+	rdb    *redis.Client
+	signer *unlock.Signer
+	log    *log.Helper
+
+	limit  int64
+	window time.Duration
+}
+
+func NewPostProtection(ctx *bootstrap.Context, rdb *redis.Client, cfg *contentV1.PostProtectionOption) *PostProtection {
+	p := &PostProtection{
+		rdb:    rdb,
+		log:    ctx.NewLoggerHelper("post-protection/data/core-service"),
+		limit:  int64(cfg.GetRateLimit().GetLimit()),
+		window: cfg.GetRateLimit().GetWindow().AsDuration(),
+	}
+	if p.window <= 0 {
+		p.window = defaultPostUnlockWindow
+	}
+
+	if cfg.GetTokenSecret() == "" {
+		p.log.Warn("post unlock token secret not configured, password protected posts cannot be unlocked")
+		return p
+	}
+
+	signer, err := unlock.NewSigner(cfg.GetTokenSecret(), cfg.GetTokenTtl().AsDuration())
+	if err != nil {
+		p.log.Warnf("invalid post unlock token secret, password protected posts cannot be unlocked: %s", err.Error())
+		return p
+	}
+	p.signer = signer
+
+	return p
+}
+
+// Enabled 是否可签发解锁令牌
+func (p *PostProtection) Enabled() bool {
+	return p != nil && p.signer != nil
+}
+
+// Allow 记录一次解锁尝试，超过限流阈值时返回 429。
+// Redis 不可用时拒绝尝试，避免限流失效后被暴力破解。
+func (p *PostProtection) Allow(ctx context.Context, tenantID, postID uint32, subject string) error {
+	if p.limit <= 0 {
+		return nil
+	}
+	if p.rdb == nil {
+		return contentV1.ErrorServiceUnavailable("post unlock is temporarily unavailable")
+	}
+
+	key := fmt.Sprintf(PostUnlockAttemptKeyFormat, tenantID, postID, subject)
+
+	var incr *redis.IntCmd
+	if _, err := p.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.ExpireNX(ctx, key, p.window)
+		return nil
+	}); err != nil {
+		p.log.Errorf("count post unlock attempts failed: %s", err.Error())
+		return contentV1.ErrorServiceUnavailable("post unlock is temporarily unavailable")
+	}
+
+	if incr.Val() > p.limit {
+		return contentV1.ErrorTooManyRequests("too many unlock attempts, please try again later")
+	}
+	return nil
+}
+
+// Sign 为文章签发解锁令牌
+func (p *PostProtection) Sign(tenantID, postID uint32, passwordHash string) (string, time.Time, error) {
+	if !p.Enabled() {
+		return "", time.Time{}, contentV1.ErrorServiceUnavailable("post unlock is not configured")
+	}
+	token, expiresAt := p.signer.Sign(tenantID, postID, passwordHash, time.Now())
+	return token, expiresAt, nil
+}
+
+// Unlocked 校验解锁令牌是否对该文章及其当前密码有效
+func (p *PostProtection) Unlocked(tenantID, postID uint32, passwordHash, token string) bool {
+	if !p.Enabled() || token == "" {
+		return false
+	}
+	return p.signer.Verify(token, tenantID, postID, passwordHash, time.Now()) == nil
+}
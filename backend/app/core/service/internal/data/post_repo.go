@@ -2,6 +2,7 @@ package data
 
 import (
 	"context"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/tx7do/kratos-bootstrap/bootstrap"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
 	entCrud "github.com/tx7do/go-crud/entgo"
@@ -18,18 +20,26 @@ import (
 
 	"github.com/tx7do/go-utils/copierutil"
 	"github.com/tx7do/go-utils/mapper"
+	"github.com/tx7do/go-utils/password"
 	"github.com/tx7do/go-utils/timeutil"
 	"github.com/tx7do/go-utils/trans"
 
 	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/fieldgroup"
 	"go-wind-cms/app/core/service/internal/data/ent/post"
 	"go-wind-cms/app/core/service/internal/data/ent/postcategory"
 	"go-wind-cms/app/core/service/internal/data/ent/posttag"
 	"go-wind-cms/app/core/service/internal/data/ent/predicate"
 
 	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/customfield"
+	"go-wind-cms/pkg/utils"
 )
 
+// defaultPostType 未指定帖子类型时的默认值，与 schema 默认值一致
+const defaultPostType = "post"
+
 type PostRepo struct {
 	entClient *entCrud.EntClient[*ent.Client]
 	log       *log.Helper
@@ -52,6 +62,13 @@ type PostRepo struct {
 
 	postCategoryRepo *PostCategoryRepo
 	postTagRepo      *PostTagRepo
+
+	fieldGroupRepo *FieldGroupRepo
+	workflowRepo   *WorkflowRepo
+
+	passwordCrypto password.Crypto
+	protection     *PostProtection
+	localeResolver *LocaleResolver
 }
 
 func NewPostRepo(
@@ -60,6 +77,11 @@ func NewPostRepo(
 	postTranslationRepo *PostTranslationRepo,
 	postCategoryRepo *PostCategoryRepo,
 	postTagRepo *PostTagRepo,
+	fieldGroupRepo *FieldGroupRepo,
+	workflowRepo *WorkflowRepo,
+	passwordCrypto password.Crypto,
+	protection *PostProtection,
+	localeResolver *LocaleResolver,
 ) *PostRepo {
 	repo := &PostRepo{
 		entClient: entClient,
@@ -74,6 +96,11 @@ func NewPostRepo(
 		postTranslationRepo: postTranslationRepo,
 		postCategoryRepo:    postCategoryRepo,
 		postTagRepo:         postTagRepo,
+		fieldGroupRepo:      fieldGroupRepo,
+		workflowRepo:        workflowRepo,
+		passwordCrypto:      passwordCrypto,
+		protection:          protection,
+		localeResolver:      localeResolver,
 	}
 
 	repo.init()
@@ -96,6 +123,130 @@ func (r *PostRepo) init() {
 
 	r.mapper.AppendConverters(r.statusConverter.NewConverterPair())
 	r.mapper.AppendConverters(r.editorTypeConverter.NewConverterPair())
+	r.mapper.AppendConverters(newCustomFieldValuesConverterPair())
+}
+
+// validateFieldValues 按帖子类型与分类命中的字段组校验类型化字段值，返回可直接落库的值
+func (r *PostRepo) validateFieldValues(ctx context.Context, tenantID uint32, postType string, categoryIDs []uint32, values map[string]*contentV1.CustomFieldValue) (map[string]*customfield.Value, error) {
+	defs, err := r.fieldGroupRepo.Definitions(ctx, tenantID, fieldgroup.LocationLocationPost, FieldTarget{
+		PostType:    postType,
+		CategoryIDs: categoryIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return validateCustomFieldValues(defs, values)
+}
+
+// prepareFieldValues 更新时帖子类型、分类或字段值任一变化都会改变命中的字段组，
+// 此时按合并后的完整数据重新校验；均未变化时返回 nil，不改动字段值。
+func (r *PostRepo) prepareFieldValues(ctx context.Context, req *contentV1.UpdatePostRequest) (map[string]*customfield.Value, bool, error) {
+	paths := req.GetUpdateMask().GetPaths()
+	valuesChanged := req.Data.FieldValues != nil || slices.Contains(paths, "field_values")
+	if !valuesChanged && req.Data.PostType == nil && req.Data.CategoryIds == nil {
+		return nil, false, nil
+	}
+
+	entity, err := r.entClient.Client().Post.Query().
+		Where(post.IDEQ(req.GetId())).
+		Select(post.FieldTenantID, post.FieldPostType, post.FieldFieldValues).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, false, contentV1.ErrorNotFound("post not found")
+		}
+		r.log.Errorf("query post failed: %s", err.Error())
+		return nil, false, contentV1.ErrorInternalServerError("query post failed")
+	}
+
+	postType := trans.StringValue(entity.PostType)
+	if req.Data.PostType != nil {
+		postType = req.Data.GetPostType()
+	}
+
+	categoryIDs := req.Data.CategoryIds
+	if categoryIDs == nil {
+		if categoryIDs, err = r.postCategoryRepo.ListCategoryIDs(ctx, req.GetId()); err != nil {
+			r.log.Errorf("query category ids failed: %s", err.Error())
+			return nil, false, contentV1.ErrorInternalServerError("query category ids failed")
+		}
+	}
+
+	values := req.Data.GetFieldValues()
+	if !valuesChanged {
+		values = customFieldValuesToProto(entity.FieldValues)
+	}
+
+	fieldValues, err := r.validateFieldValues(ctx, trans.Uint32Value(entity.TenantID), postType, categoryIDs, values)
+	if err != nil {
+		return nil, false, err
+	}
+	return fieldValues, true, nil
+}
+
+// applyCustomFieldQuery 处理列表中 "field_values.<key>" 的过滤与排序，
+// 字段定义取自当前租户全部启用的帖子字段组。
+func (r *PostRepo) applyCustomFieldQuery(ctx context.Context, builder *ent.PostQuery, req *paginationV1.PagingRequest) error {
+	query, err := takeCustomFieldQuery(req)
+	if err != nil || query.empty() {
+		return err
+	}
+
+	tid, _ := maybeTenantFromViewer(ctx)
+	defs, err := r.fieldGroupRepo.AllDefinitions(ctx, tid, fieldgroup.LocationLocationPost)
+	if err != nil {
+		return err
+	}
+
+	wheres, orders, err := query.build(post.FieldFieldValues, defs)
+	if err != nil {
+		return err
+	}
+	if len(wheres) > 0 {
+		builder.Modify(wheres...)
+	}
+	for _, order := range orders {
+		builder.Order(post.OrderOption(order))
+	}
+
+	return nil
+}
+
+// checkTrashedStatus 已删除状态只能经 Delete 移入回收站时设置
+func checkTrashedStatus(status *contentV1.Post_PostStatus) error {
+	if status != nil && *status == contentV1.Post_POST_STATUS_TRASHED {
+		return contentV1.ErrorBadRequest("use delete to move a post to the trash")
+	}
+	return nil
+}
+
+// workflowStatusAllowed 受工作流约束的文章，发布状态由所处阶段决定，
+// 不能直接设为已发布或定时发布。
+func workflowStatusAllowed(status *contentV1.Post_PostStatus) bool {
+	if status == nil {
+		return true
+	}
+	return *status != contentV1.Post_POST_STATUS_PUBLISHED && *status != contentV1.Post_POST_STATUS_SCHEDULED
+}
+
+// checkWorkflowStatus 文章处于工作流中时拒绝直接变更发布状态
+func (r *PostRepo) checkWorkflowStatus(ctx context.Context, id uint32, status *contentV1.Post_PostStatus) error {
+	if workflowStatusAllowed(status) {
+		return nil
+	}
+
+	underWorkflow, err := r.entClient.Client().Post.Query().
+		Where(post.IDEQ(id), post.WorkflowIDNotNil()).
+		Exist(ctx)
+	if err != nil {
+		r.log.Errorf("query post workflow failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("query post workflow failed")
+	}
+	if underWorkflow {
+		return contentV1.ErrorBadRequest("post is under an editorial workflow, publish it through a workflow transition")
+	}
+
+	return nil
 }
 
 func (r *PostRepo) count(ctx context.Context, whereCond []func(s *sql.Selector)) (int, error) {
@@ -173,13 +324,18 @@ func (r *PostRepo) List(ctx context.Context, req *paginationV1.PagingRequest) (*
 		return nil, contentV1.ErrorBadRequest("invalid parameter")
 	}
 
-	builder := r.entClient.Client().Debug().Post.Query()
+	builder := r.entClient.Client().Debug().Post.Query().
+		Where(post.DeletedAtIsNil())
 
 	excludeConditions, translationMaskFields, needQueryTranslation, err := r.prepareTranslationMaskFields(req)
 	if err != nil {
 		return nil, err
 	}
 
+	if err = r.applyCustomFieldQuery(ctx, builder, req); err != nil {
+		return nil, err
+	}
+
 	if len(excludeConditions) > 0 {
 		var postIDs []uint32
 
@@ -266,7 +422,11 @@ func (r *PostRepo) List(ctx context.Context, req *paginationV1.PagingRequest) (*
 	}
 
 	if req.FieldMask != nil && len(req.FieldMask.Paths) > 0 {
-		whereSelectors, err := r.repository.BuildSelectorWithTable(post.Table, req.FieldMask.Paths)
+		// password_hash 始终查询，用于推导 password_protected，前台据此隐藏受保护正文
+		paths := utils.FilterBlacklist(req.FieldMask.Paths, []string{post.FieldPasswordHash})
+		paths = append(paths, post.FieldPasswordHash)
+
+		whereSelectors, err := r.repository.BuildSelectorWithTable(post.Table, paths)
 		if err != nil {
 			r.log.Errorf("build selector with table failed: %s", err.Error())
 			return nil, err
@@ -300,8 +460,24 @@ func (r *PostRepo) List(ctx context.Context, req *paginationV1.PagingRequest) (*
 			}
 		}
 
+		// 指定语言时按回退链逐篇选出实际返回的翻译语言，没有可用翻译的帖子不附带翻译
+		chain := r.localeResolver.Chain(ctx, locale)
+
 		for _, item := range ret.Items {
-			translations, err := r.postTranslationRepo.ListTranslations(ctx, item.GetId(), locale, viewMask)
+			served := locale
+			if len(chain) > 0 {
+				languages, err := r.postTranslationRepo.ListAvailedLanguages(ctx, item.GetId(), true)
+				if err != nil {
+					r.log.Errorf("query availed languages failed: %s", err.Error())
+					return nil, contentV1.ErrorInternalServerError("query availed languages failed")
+				}
+				if served = pickLocale(chain, languages); served == "" {
+					continue
+				}
+				item.ServedLocale = trans.Ptr(served)
+			}
+
+			translations, err := r.postTranslationRepo.ListTranslations(ctx, item.GetId(), served, viewMask)
 			if err != nil {
 				r.log.Errorf("query translations failed: %s", err.Error())
 				return nil, contentV1.ErrorInternalServerError("query translations failed")
@@ -311,7 +487,9 @@ func (r *PostRepo) List(ctx context.Context, req *paginationV1.PagingRequest) (*
 	}
 
 	for _, item := range ret.Items {
-		languages, err := r.postTranslationRepo.ListAvailedLanguages(ctx, item.GetId())
+		hidePasswordHash(item)
+
+		languages, err := r.postTranslationRepo.ListAvailedLanguages(ctx, item.GetId(), true)
 		if err != nil {
 			r.log.Errorf("query availed languages failed: %s", err.Error())
 			return nil, contentV1.ErrorInternalServerError("query availed languages failed")
@@ -344,7 +522,8 @@ func (r *PostRepo) Get(ctx context.Context, req *contentV1.GetPostRequest) (*con
 		return nil, contentV1.ErrorBadRequest("invalid parameter")
 	}
 
-	build := r.entClient.Client().Post.Query()
+	build := r.entClient.Client().Post.Query().
+		Where(post.DeletedAtIsNil())
 
 	switch req.QueryBy.(type) {
 	case *contentV1.GetPostRequest_Id:
@@ -369,8 +548,9 @@ func (r *PostRepo) Get(ctx context.Context, req *contentV1.GetPostRequest) (*con
 	}
 
 	dto := r.mapper.ToDTO(entity)
+	hidePasswordHash(dto)
 
-	languages, err := r.postTranslationRepo.ListAvailedLanguages(ctx, dto.GetId())
+	languages, err := r.postTranslationRepo.ListAvailedLanguages(ctx, dto.GetId(), req.GetPublicView())
 	if err != nil {
 		r.log.Errorf("query availed languages failed: %s", err.Error())
 		return nil, contentV1.ErrorInternalServerError("query availed languages failed")
@@ -384,8 +564,8 @@ func (r *PostRepo) Get(ctx context.Context, req *contentV1.GetPostRequest) (*con
 			return nil, contentV1.ErrorInternalServerError("query translations failed")
 		}
 		dto.Translations = translations
-	} else {
-		translation, err := r.postTranslationRepo.GetTranslation(ctx, dto.GetId(), req.GetLocale())
+	} else if served := r.localeResolver.Serve(ctx, req.GetLocale(), languages); served != "" {
+		translation, err := r.postTranslationRepo.GetTranslation(ctx, dto.GetId(), served)
 		if err != nil {
 			r.log.Errorf("query translation failed: %s", err.Error())
 			return nil, contentV1.ErrorInternalServerError("query translation failed")
@@ -393,6 +573,7 @@ func (r *PostRepo) Get(ctx context.Context, req *contentV1.GetPostRequest) (*con
 		if translation != nil {
 			dto.Translations = append(dto.Translations, translation)
 		}
+		dto.ServedLocale = trans.Ptr(served)
 	}
 
 	if tagIds, err := r.postTagRepo.ListTagIDs(ctx, dto.GetId()); err != nil {
@@ -409,6 +590,13 @@ func (r *PostRepo) Get(ctx context.Context, req *contentV1.GetPostRequest) (*con
 		dto.CategoryIds = categoryIds
 	}
 
+	// 前台视图：受密码保护且未携带有效解锁令牌时，只返回元数据与摘要
+	if req.GetPublicView() && dto.GetPasswordProtected() &&
+		!r.protection.Unlocked(trans.Uint32Value(entity.TenantID), entity.ID,
+			trans.StringValue(entity.PasswordHash), req.GetUnlockToken()) {
+		RedactProtectedPost(dto)
+	}
+
 	return dto, nil
 }
 
@@ -421,6 +609,36 @@ func (r *PostRepo) Create(ctx context.Context, req *contentV1.CreatePostRequest)
 		return nil, contentV1.ErrorBadRequest("at least one translation is required")
 	}
 
+	if err = checkTrashedStatus(req.Data.Status); err != nil {
+		return nil, err
+	}
+
+	// 只接受明文密码并由服务端哈希，不信任客户端传入的 password_hash
+	var passwordHash *string
+	if passwordHash, err = r.hashPassword(req.Data.Password); err != nil {
+		return nil, err
+	}
+
+	// 未填写的必填字段同样会被拒绝，故创建时总是校验
+	postType := req.Data.GetPostType()
+	if postType == "" {
+		postType = defaultPostType
+	}
+	tid, _ := maybeTenantFromViewer(ctx)
+	var fieldValues map[string]*customfield.Value
+	if fieldValues, err = r.validateFieldValues(ctx, tid, postType, req.Data.GetCategoryIds(), req.Data.GetFieldValues()); err != nil {
+		return nil, err
+	}
+
+	// 命中工作流的新文章进入其初始阶段，此后只能经工作流流转发布
+	var wf *ent.Workflow
+	if wf, err = r.workflowRepo.Resolve(ctx, tid, postType); err != nil {
+		return nil, err
+	}
+	if wf != nil && !workflowStatusAllowed(req.Data.Status) {
+		return nil, contentV1.ErrorBadRequest("post is under an editorial workflow, publish it through a workflow transition")
+	}
+
 	var tx *ent.Tx
 	tx, err = r.entClient.Client().Tx(ctx)
 	if err != nil {
@@ -449,11 +667,13 @@ func (r *PostRepo) Create(ctx context.Context, req *contentV1.CreatePostRequest)
 		SetNillableAutoSummary(req.Data.AutoSummary).
 		SetNillableIsFeatured(req.Data.IsFeatured).
 		SetNillableSortOrder(req.Data.SortOrder).
+		SetPostType(postType).
+		SetFieldValues(fieldValues).
 		// 计数列（visits/likes/comment_count）由 schema Default(0) 兜底，
 		// 由 InteractionService 独占递增，Create 路径不再显式设置。
 		SetNillableAuthorID(req.Data.AuthorId).
 		SetNillableAuthorName(req.Data.AuthorName).
-		SetNillablePasswordHash(req.Data.PasswordHash).
+		SetNillablePasswordHash(passwordHash).
 		SetNillableCreatedBy(req.Data.CreatedBy).
 		SetNillablePublishTime(timeutil.TimestamppbToTime(req.Data.PublishTime)).
 		SetCreatedAt(time.Now())
@@ -462,6 +682,12 @@ func (r *PostRepo) Create(ctx context.Context, req *contentV1.CreatePostRequest)
 		builder.SetCustomFields(trans.Ptr(req.Data.GetCustomFields()))
 	}
 
+	if wf != nil {
+		builder.
+			SetWorkflowID(wf.ID).
+			SetWorkflowStage(trans.StringValue(wf.InitialStage))
+	}
+
 	var entity *ent.Post
 	if entity, err = builder.Save(ctx); err != nil {
 		r.log.Errorf("insert post failed: %s", err.Error())
@@ -508,7 +734,10 @@ func (r *PostRepo) Create(ctx context.Context, req *contentV1.CreatePostRequest)
 		}
 	}
 
-	return r.mapper.ToDTO(entity), nil
+	dto = r.mapper.ToDTO(entity)
+	hidePasswordHash(dto)
+
+	return dto, nil
 }
 
 func (r *PostRepo) Update(ctx context.Context, req *contentV1.UpdatePostRequest) (dto *contentV1.Post, err error) {
@@ -530,6 +759,35 @@ func (r *PostRepo) Update(ctx context.Context, req *contentV1.UpdatePostRequest)
 		}
 	}
 
+	// password 为 nil 时不改动；空字符串取消保护，否则重新哈希
+	var passwordHash *string
+	if passwordHash, err = r.hashPassword(req.Data.Password); err != nil {
+		return nil, err
+	}
+
+	if err = checkTrashedStatus(req.Data.Status); err != nil {
+		return nil, err
+	}
+	if err = r.checkWorkflowStatus(ctx, req.GetId(), req.Data.Status); err != nil {
+		return nil, err
+	}
+
+	if req.Data.PostType != nil && req.Data.GetPostType() == "" {
+		req.Data.PostType = trans.Ptr(defaultPostType)
+	}
+	fieldValues, fieldValuesChanged, err := r.prepareFieldValues(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 整体替换语言版本前记下各语言的完整路径，提交后对比并记录自动重定向
+	var beforePaths map[string]string
+	if len(req.Data.Translations) > 0 {
+		if beforePaths, _, err = r.postTranslationRepo.FullPaths(ctx, req.GetId()); err != nil {
+			return nil, err
+		}
+	}
+
 	var tx *ent.Tx
 	tx, err = r.entClient.Client().Tx(ctx)
 	if err != nil {
@@ -546,7 +804,9 @@ func (r *PostRepo) Update(ctx context.Context, req *contentV1.UpdatePostRequest)
 		if commitErr := tx.Commit(); commitErr != nil {
 			r.log.Errorf("transaction commit failed: %s", commitErr.Error())
 			err = contentV1.ErrorInternalServerError("transaction commit failed")
+			return
 		}
+		r.postTranslationRepo.RecordReplacedPaths(ctx, req.GetId(), beforePaths)
 	}()
 
 	if len(req.Data.Translations) > 0 {
@@ -588,15 +848,30 @@ func (r *PostRepo) Update(ctx context.Context, req *contentV1.UpdatePostRequest)
 	callerUserID, hasUser := viewerUserIDFromContext(ctx)
 	// 计数列已从 Post 表移除，统一存于 interaction_counter 表（由 InteractionService 独占写入），
 	// 故此处不再需要 FilterBlacklist 保护计数列。
+	// 密码相关字段只经由 password 明文写入，不随 updateMask 直接落库。
+	// 类型化字段值经校验后单独写入。
+	// 工作流阶段与审阅人只经由 EditorialService 变更。
+	if req.UpdateMask != nil {
+		req.UpdateMask.Paths = utils.FilterBlacklist(req.UpdateMask.GetPaths(), []string{
+			"password", "password_hash", "password_protected", "field_values",
+			"workflow_id", "workflow_stage", "reviewer_ids",
+		})
+	}
+	req.Data.PasswordHash = nil
+	req.Data.PasswordProtected = nil
+	req.Data.WorkflowId = nil
+	req.Data.WorkflowStage = nil
+	req.Data.ReviewerIds = nil
+
 	builder := tx.Post.UpdateOneID(req.GetId())
-	builder.Where(post.IDEQ(req.GetId()))
+	builder.Where(post.IDEQ(req.GetId()), post.DeletedAtIsNil())
 	if hasTenant {
 		builder.Where(post.TenantIDEQ(tid))
 	}
 	result, err := r.repository.UpdateOne(ctx, builder, req.Data, req.GetUpdateMask(),
 		func(dto *contentV1.Post) {
-			// author_id / password_hash 在 Update 时不再接受客户端值（创建时设置），
-			// updated_by 强制取调用者身份，保证审计归属真实。
+			// author_id 在 Update 时不再接受客户端值（创建时设置），password_hash 只由
+			// password 明文经服务端哈希写入，updated_by 强制取调用者身份，保证审计归属真实。
 			builder.
 				SetNillableStatus(r.statusConverter.ToEntity(req.Data.Status)).
 				SetNillableEditorType(r.editorTypeConverter.ToEntity(req.Data.EditorType)).
@@ -606,6 +881,7 @@ func (r *PostRepo) Update(ctx context.Context, req *contentV1.UpdatePostRequest)
 				SetNillableAutoSummary(req.Data.AutoSummary).
 				SetNillableIsFeatured(req.Data.IsFeatured).
 				SetNillableSortOrder(req.Data.SortOrder).
+				SetNillablePostType(req.Data.PostType).
 				SetNillableAuthorName(req.Data.AuthorName).
 				SetNillablePublishTime(timeutil.TimestamppbToTime(req.Data.PublishTime)).
 				SetUpdatedAt(time.Now())
@@ -618,65 +894,57 @@ func (r *PostRepo) Update(ctx context.Context, req *contentV1.UpdatePostRequest)
 			if req.Data.CustomFields != nil {
 				builder.SetCustomFields(trans.Ptr(req.Data.GetCustomFields()))
 			}
+
+			if fieldValuesChanged {
+				builder.SetFieldValues(fieldValues)
+			}
+
+			switch {
+			case passwordHash != nil:
+				builder.SetPasswordHash(*passwordHash)
+			case req.Data.Password != nil:
+				builder.ClearPasswordHash()
+			}
 		},
 		func(s *sql.Selector) {
 			s.Where(sql.EQ(post.FieldID, req.GetId()))
 		},
 	)
+	if result != nil {
+		hidePasswordHash(result)
+	}
 
 	return result, err
 }
 
-func (r *PostRepo) Delete(ctx context.Context, req *contentV1.DeletePostRequest) (err error) {
-	if req == nil {
-		return contentV1.ErrorBadRequest("invalid parameter")
-	}
-
-	var tx *ent.Tx
-	tx, err = r.entClient.Client().Tx(ctx)
-	if err != nil {
-		r.log.Errorf("start transaction failed: %s", err.Error())
-		return contentV1.ErrorInternalServerError("start transaction failed")
-	}
-	defer func() {
-		if err != nil {
-			if rollbackErr := tx.Rollback(); rollbackErr != nil {
-				r.log.Errorf("transaction rollback failed: %s", rollbackErr.Error())
-			}
-			return
-		}
-		if commitErr := tx.Commit(); commitErr != nil {
-			r.log.Errorf("transaction commit failed: %s", commitErr.Error())
-			err = contentV1.ErrorInternalServerError("transaction commit failed")
-		}
-	}()
-
+// Purge 在给定事务中物理删除帖子及其翻译、分类与标签关联，仅由回收站彻底清理时调用
+func (r *PostRepo) Purge(ctx context.Context, tx *ent.Tx, id uint32) error {
 	// 删除帖子数据
 	tid, hasTenant := maybeTenantFromViewer(ctx)
 	delBuilder := tx.Post.Delete()
-	delBuilder.Where(post.IDEQ(req.GetId()))
+	delBuilder.Where(post.IDEQ(id))
 	if hasTenant {
 		delBuilder.Where(post.TenantIDEQ(tid))
 	}
-	if _, err = delBuilder.Exec(ctx); err != nil {
+	if _, err := delBuilder.Exec(ctx); err != nil {
 		r.log.Errorf("delete one data failed: %s", err.Error())
 		return contentV1.ErrorInternalServerError("delete one data failed")
 	}
 
 	// 删除关联数据
-	if err = r.postTranslationRepo.CleanTranslations(ctx, tx, req.GetId()); err != nil {
+	if err := r.postTranslationRepo.CleanTranslations(ctx, tx, id); err != nil {
 		r.log.Errorf("clean translations failed: %s", err.Error())
 		return contentV1.ErrorInternalServerError("clean translations failed")
 	}
 
 	// 删除关联数据
-	if err = r.postCategoryRepo.CleanCategories(ctx, tx, req.GetId()); err != nil {
+	if err := r.postCategoryRepo.CleanCategories(ctx, tx, id); err != nil {
 		r.log.Errorf("clean categories failed: %s", err.Error())
 		return contentV1.ErrorInternalServerError("clean categories failed")
 	}
 
 	// 删除关联数据
-	if err = r.postTagRepo.CleanTags(ctx, tx, req.GetId()); err != nil {
+	if err := r.postTagRepo.CleanTags(ctx, tx, id); err != nil {
 		r.log.Errorf("clean tags failed: %s", err.Error())
 		return contentV1.ErrorInternalServerError("clean tags failed")
 	}
@@ -740,7 +1008,41 @@ func (r *PostRepo) GetTranslation(ctx context.Context, req *contentV1.GetPostReq
 		return nil, contentV1.ErrorBadRequest("invalid parameter")
 	}
 
-	return r.postTranslationRepo.GetTranslation(ctx, req.GetId(), req.GetLocale())
+	languages, err := r.postTranslationRepo.ListAvailedLanguages(ctx, req.GetId(), req.GetPublicView())
+	if err != nil {
+		r.log.Errorf("query availed languages failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query availed languages failed")
+	}
+	served := r.localeResolver.Serve(ctx, req.GetLocale(), languages)
+	if served == "" {
+		return nil, contentV1.ErrorNotFound("translation not found")
+	}
+
+	translation, err := r.postTranslationRepo.GetTranslation(ctx, req.GetId(), served)
+	if err != nil || !req.GetPublicView() {
+		return translation, err
+	}
+
+	// 前台视图：受密码保护且未解锁时清除正文
+	entity, err := r.entClient.Client().Post.Query().
+		Where(post.IDEQ(req.GetId())).
+		Select(post.FieldID, post.FieldTenantID, post.FieldPasswordHash).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound("post not found")
+		}
+		r.log.Errorf("query post failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query post failed")
+	}
+	passwordHash := trans.StringValue(entity.PasswordHash)
+	if passwordHash != "" &&
+		!r.protection.Unlocked(trans.Uint32Value(entity.TenantID), entity.ID, passwordHash, req.GetUnlockToken()) {
+		translation.Content = nil
+		translation.OriginalContent = nil
+	}
+
+	return translation, nil
 }
 
 func (r *PostRepo) ListTranslations(ctx context.Context, postId uint32) ([]*contentV1.PostTranslation, error) {
@@ -785,7 +1087,9 @@ type PostReindexDocument struct {
 // 安全：
 //   - tenant_id 取自 ent.Post.TenantID（DB 记录），非 viewer
 //   - 跳过非 PUBLISHED 状态的帖子（不入索引）
+//   - 跳过受密码保护的帖子（正文不可被检索）
 //   - 跳过 title/content 均空的翻译（避免索引无意义文档）
+//   - 跳过草稿翻译（待审阅的机器翻译不可被检索）
 //   - 调用方须以 SystemViewer ctx 调用，方能跨租户读取
 func (r *PostRepo) GetReindexDocuments(ctx context.Context, postID uint32) ([]PostReindexDocument, error) {
 	if postID == 0 {
@@ -818,6 +1122,11 @@ func (r *PostRepo) GetReindexDocuments(ctx context.Context, postID uint32) ([]Po
 		return nil, nil
 	}
 
+	// 受密码保护的正文不入索引（调用方据此删除残留文档）
+	if trans.StringValue(entity.PasswordHash) != "" {
+		return nil, nil
+	}
+
 	// 取所有翻译（ent privacy 自动过滤软删）
 	translations, err := r.postTranslationRepo.ListTranslations(ctx, postID, "", nil)
 	if err != nil {
@@ -827,7 +1136,7 @@ func (r *PostRepo) GetReindexDocuments(ctx context.Context, postID uint32) ([]Po
 
 	docs := make([]PostReindexDocument, 0, len(translations))
 	for _, tr := range translations {
-		if tr == nil {
+		if tr == nil || tr.GetIsDraft() {
 			continue
 		}
 		// 跳过 title 和 content 均空的翻译
@@ -870,3 +1179,170 @@ func (r *PostRepo) ListPublishedPostIDs(ctx context.Context) ([]uint32, error) {
 	}
 	return ids, nil
 }
+
+// ListPublishedByIDs 按给定顺序返回其中已发布的帖子（前台视图，受保护帖子不含正文）。
+// locale 非空时只附带该语言翻译，没有该语言翻译的帖子被跳过；至多返回 limit 篇。
+// 草稿翻译不返回，只有草稿翻译的帖子被跳过。
+func (r *PostRepo) ListPublishedByIDs(ctx context.Context, ids []uint32, locale string, limit int) ([]*contentV1.Post, error) {
+	if len(ids) == 0 || limit <= 0 {
+		return []*contentV1.Post{}, nil
+	}
+
+	entities, err := r.entClient.Client().Post.Query().
+		Where(
+			post.IDIn(ids...),
+			post.StatusEQ(post.StatusPostStatusPublished),
+			post.DeletedAtIsNil(),
+		).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query posts by ids failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query posts by ids failed")
+	}
+
+	byID := make(map[uint32]*ent.Post, len(entities))
+	for _, e := range entities {
+		byID[e.ID] = e
+	}
+
+	items := make([]*contentV1.Post, 0, min(limit, len(entities)))
+	for _, id := range ids {
+		if len(items) >= limit {
+			break
+		}
+		entity, ok := byID[id]
+		if !ok {
+			continue
+		}
+
+		dto := r.mapper.ToDTO(entity)
+		hidePasswordHash(dto)
+
+		if locale == "" {
+			translations, err := r.postTranslationRepo.ListTranslations(ctx, id, "", nil)
+			if err != nil {
+				r.log.Errorf("query translations failed: %s", err.Error())
+				return nil, contentV1.ErrorInternalServerError("query translations failed")
+			}
+			for _, tr := range translations {
+				if tr != nil && !tr.GetIsDraft() {
+					dto.Translations = append(dto.Translations, tr)
+				}
+			}
+			if len(dto.Translations) == 0 {
+				continue
+			}
+		} else {
+			translation, err := r.postTranslationRepo.GetTranslation(ctx, id, locale)
+			if err != nil {
+				r.log.Errorf("query translation failed: %s", err.Error())
+				return nil, contentV1.ErrorInternalServerError("query translation failed")
+			}
+			if translation == nil || translation.GetIsDraft() {
+				continue
+			}
+			dto.Translations = append(dto.Translations, translation)
+		}
+
+		if dto.GetPasswordProtected() {
+			RedactProtectedPost(dto)
+		}
+
+		items = append(items, dto)
+	}
+
+	return items, nil
+}
+
+// Unlock 校验受保护帖子的访问密码，通过后签发解锁令牌。
+//
+// 安全：
+//   - 按 租户+帖子+访客（登录用户，否则客户端 IP）限流，先计数后校验，失败尝试同样计入
+//   - 仅已发布的帖子可解锁，其余状态按未找到处理，避免探测草稿
+//   - 令牌绑定 DB 记录的 tenant_id 与当前密码哈希，修改密码后旧令牌失效
+func (r *PostRepo) Unlock(ctx context.Context, req *contentV1.UnlockPostRequest) (*contentV1.UnlockPostResponse, error) {
+	if req == nil || req.GetId() == 0 || req.GetPassword() == "" {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	tid, hasTenant := maybeTenantFromViewer(ctx)
+
+	subject := "ip:" + req.GetClientIp()
+	if uid, ok := viewerUserIDFromContext(ctx); ok {
+		subject = "u:" + strconv.FormatUint(uint64(uid), 10)
+	}
+	if err := r.protection.Allow(ctx, tid, req.GetId(), subject); err != nil {
+		return nil, err
+	}
+
+	builder := r.entClient.Client().Post.Query().
+		Where(
+			post.IDEQ(req.GetId()),
+			post.StatusEQ(post.StatusPostStatusPublished),
+		)
+	if hasTenant {
+		builder.Where(post.TenantIDEQ(tid))
+	}
+	entity, err := builder.Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound("post not found")
+		}
+		r.log.Errorf("query post for unlock failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query post failed")
+	}
+
+	passwordHash := trans.StringValue(entity.PasswordHash)
+	if passwordHash == "" {
+		return nil, contentV1.ErrorBadRequest("post is not password protected")
+	}
+
+	ok, err := r.passwordCrypto.Verify(req.GetPassword(), passwordHash)
+	if err != nil || !ok {
+		return nil, contentV1.ErrorForbidden("incorrect password")
+	}
+
+	token, expiresAt, err := r.protection.Sign(trans.Uint32Value(entity.TenantID), entity.ID, passwordHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &contentV1.UnlockPostResponse{
+		Token:     token,
+		ExpiresAt: timestamppb.New(expiresAt),
+	}, nil
+}
+
+// hashPassword 哈希访问密码；nil 表示不改动，空字符串返回 nil（取消保护）
+func (r *PostRepo) hashPassword(plain *string) (*string, error) {
+	if plain == nil || *plain == "" {
+		return nil, nil
+	}
+	hash, err := r.passwordCrypto.Encrypt(*plain)
+	if err != nil {
+		r.log.Errorf("hash post password failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("hash post password failed")
+	}
+	return &hash, nil
+}
+
+// hidePasswordHash 以 password_protected 标记代替密码哈希，哈希不出 core 服务
+func hidePasswordHash(dto *contentV1.Post) {
+	if dto == nil {
+		return
+	}
+	dto.PasswordProtected = trans.Ptr(dto.GetPasswordHash() != "")
+	dto.PasswordHash = nil
+	dto.Password = nil
+}
+
+// RedactProtectedPost 清除受密码保护帖子的正文，仅保留元数据与摘要
+func RedactProtectedPost(dto *contentV1.Post) {
+	for _, tr := range dto.GetTranslations() {
+		if tr == nil {
+			continue
+		}
+		tr.Content = nil
+		tr.OriginalContent = nil
+	}
+}
@@ -2,6 +2,7 @@ package data
 
 import (
 	"context"
+	"slices"
 	"strconv"
 	"time"
 
@@ -25,6 +26,7 @@ import (
 
 	"go-wind-cms/pkg/content/count"
 	"go-wind-cms/pkg/content/summary"
+	"go-wind-cms/pkg/utils"
 )
 
 type PostTranslationRepo struct {
@@ -41,13 +43,21 @@ type PostTranslationRepo struct {
 		predicate.PostTranslation,
 		contentV1.PostTranslation, ent.PostTranslation,
 	]
+
+	translationStatusConverter *mapper.EnumTypeConverter[contentV1.TranslationStatus, posttranslation.TranslationStatus]
+
+	redirectRepo *RedirectRepo
 }
 
-func NewPostTranslationRepo(ctx *bootstrap.Context, entClient *entCrud.EntClient[*ent.Client]) *PostTranslationRepo {
+func NewPostTranslationRepo(ctx *bootstrap.Context, entClient *entCrud.EntClient[*ent.Client], redirectRepo *RedirectRepo) *PostTranslationRepo {
 	repo := &PostTranslationRepo{
-		entClient: entClient,
-		mapper:    mapper.NewCopierMapper[contentV1.PostTranslation, ent.PostTranslation](),
-		log:       ctx.NewLoggerHelper("post-translation/repo/core-service"),
+		entClient:    entClient,
+		redirectRepo: redirectRepo,
+		mapper:       mapper.NewCopierMapper[contentV1.PostTranslation, ent.PostTranslation](),
+		log:          ctx.NewLoggerHelper("post-translation/repo/core-service"),
+		translationStatusConverter: mapper.NewEnumTypeConverter[contentV1.TranslationStatus, posttranslation.TranslationStatus](
+			contentV1.TranslationStatus_name, contentV1.TranslationStatus_value,
+		),
 	}
 
 	repo.init()
@@ -67,6 +77,7 @@ func (r *PostTranslationRepo) init() {
 
 	r.mapper.AppendConverters(copierutil.NewTimeStringConverterPair())
 	r.mapper.AppendConverters(copierutil.NewTimeTimestamppbConverterPair())
+	r.mapper.AppendConverters(r.translationStatusConverter.NewConverterPair())
 }
 
 func (r *PostTranslationRepo) CleanTranslations(
@@ -153,6 +164,10 @@ func (r *PostTranslationRepo) newCreateBuilder(pt *ent.PostTranslationClient, da
 		SetNillableThumbnail(data.Thumbnail).
 		SetNillableWordCount(data.WordCount).
 		SetNillableFullPath(data.FullPath).
+		SetNillableIsDraft(data.IsDraft).
+		SetNillableSourceLanguage(data.SourceLanguage).
+		SetNillableSourceRevision(data.SourceRevision).
+		SetNillableTranslationStatus(r.translationStatusConverter.ToEntity(data.TranslationStatus)).
 		SetNillableCreatedBy(data.CreatedBy).
 		SetCreatedAt(now)
 
@@ -171,6 +186,9 @@ func (r *PostTranslationRepo) BatchCreate(ctx context.Context, tx *ent.Tx, items
 	builders := make([]*ent.PostTranslationCreate, 0, len(items))
 	for _, data := range items {
 		_ = r.PrepareTranslation(ctx, data)
+		if err := r.anchorTranslation(ctx, tx.Client(), data, items); err != nil {
+			return err
+		}
 		builder := r.newCreateBuilder(tx.PostTranslation, data)
 		builders = append(builders, builder)
 	}
@@ -215,6 +233,9 @@ func (r *PostTranslationRepo) CreateTranslation(ctx context.Context, data *conte
 	}
 
 	_ = r.PrepareTranslation(ctx, data)
+	if err := r.anchorTranslation(ctx, r.entClient.Client(), data, nil); err != nil {
+		return nil, err
+	}
 
 	builder := r.newCreateBuilder(r.entClient.Client().PostTranslation, data)
 
@@ -237,6 +258,41 @@ func (r *PostTranslationRepo) UpdateTranslation(ctx context.Context, id uint32,
 	counter := count.NewContentCounter(data.GetContent())
 	data.WordCount = trans.Ptr(uint32(counter.RawChars()))
 
+	// 记录更新前的完整路径，变更后自动生成旧路径的重定向
+	old, err := r.entClient.Client().PostTranslation.Get(ctx, id)
+	if err != nil && !ent.IsNotFound(err) {
+		r.log.Errorf("query post translation failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query post translation failed")
+	}
+
+	// 完整路径由服务端随 slug 推导，旧路径在更新后记录为重定向
+	if old != nil {
+		if fullPath := deriveFullPath(trans.StringValue(old.Slug), trans.StringValue(old.FullPath), data.Slug, data.FullPath); fullPath != data.FullPath {
+			data.FullPath = fullPath
+			if updateMask != nil && !slices.Contains(updateMask.GetPaths(), "full_path") {
+				updateMask.Paths = append(updateMask.Paths, "full_path")
+			}
+		}
+	}
+
+	// 修订跟踪字段由服务端维护：修改源语言或确认已校对时重新锚定，其余情况忽略客户端传入值
+	var anchor *translationAnchor
+	if old != nil {
+		if lang, ok := translationAnchorRequested(updateMask, data.SourceLanguage, data.TranslationStatus, old.SourceLanguage); ok {
+			if anchor, err = resolveTranslationAnchor(trans.StringValue(old.LanguageCode), lang, func(l string) (uint32, bool, error) {
+				return postTranslationRevision(ctx, r.entClient.Client(), trans.Uint32Value(old.PostID), l)
+			}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if updateMask != nil {
+		updateMask.Paths = utils.FilterBlacklist(updateMask.GetPaths(), translationTrackingFields)
+		updateMask.Paths = utils.FilterBlacklist(updateMask.GetPaths(), seoDerivedFields)
+	}
+	data.Revision, data.SourceLanguage, data.SourceRevision, data.TranslationStatus = nil, nil, nil, nil
+	data.SeoAnalysis = nil
+
 	builder := r.entClient.Client().PostTranslation.UpdateOneID(id)
 	// 租户作用域：仅更新本租户翻译，避免跨租户改他人翻译（按 hasTenant 条件加）
 	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
@@ -257,6 +313,7 @@ func (r *PostTranslationRepo) UpdateTranslation(ctx context.Context, id uint32,
 				SetNillableThumbnail(data.Thumbnail).
 				SetNillableWordCount(data.WordCount).
 				SetNillableFullPath(data.FullPath).
+				SetNillableIsDraft(data.IsDraft).
 				SetUpdatedAt(time.Now())
 
 			// updated_by 强制由服务端 viewer context 推导，忽略客户端传入值
@@ -267,6 +324,15 @@ func (r *PostTranslationRepo) UpdateTranslation(ctx context.Context, id uint32,
 			if data.Seo != nil {
 				builder.SetSeo(data.Seo)
 			}
+
+			if anchor != nil {
+				builder.SetNillableTranslationStatus(r.translationStatusConverter.ToEntity(&anchor.status))
+				if anchor.language != nil {
+					builder.SetSourceLanguage(*anchor.language).SetSourceRevision(*anchor.revision)
+				} else {
+					builder.ClearSourceLanguage().ClearSourceRevision()
+				}
+			}
 		},
 		func(s *sql.Selector) {
 			s.Where(sql.EQ(posttranslation.FieldID, id))
@@ -277,9 +343,80 @@ func (r *PostTranslationRepo) UpdateTranslation(ctx context.Context, id uint32,
 		return nil, contentV1.ErrorInternalServerError("update post translation failed")
 	}
 
+	// 可翻译字段有修改时递增修订号，依据此语言版本的译文随之过期
+	if old != nil && postTranslationChanged(old, dto) {
+		revision, bumpErr := bumpPostTranslationRevision(ctx, r.entClient.Client(), id)
+		if bumpErr != nil {
+			r.log.Errorf("bump post translation revision failed: %s", bumpErr.Error())
+			return nil, contentV1.ErrorInternalServerError("bump post translation revision failed")
+		}
+		dto.Revision = trans.Ptr(revision)
+	}
+
+	if old != nil {
+		r.redirectRepo.RecordTranslationPathChange(ctx, old.TenantID, contentV1.ContentType_CONTENT_TYPE_POST,
+			trans.Uint32Value(old.PostID), trans.StringValue(old.LanguageCode),
+			trans.StringValue(old.FullPath), dto.GetFullPath())
+	}
+
 	return dto, nil
 }
 
+// FullPaths 返回帖子各语言版本的完整路径与所属租户，用于整体替换语言版本前后对比
+func (r *PostTranslationRepo) FullPaths(ctx context.Context, postID uint32) (map[string]string, *uint32, error) {
+	entities, err := r.entClient.Client().PostTranslation.Query().
+		Where(posttranslation.PostIDEQ(postID)).
+		Select(posttranslation.FieldTenantID, posttranslation.FieldLanguageCode, posttranslation.FieldFullPath).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query post translation paths failed: %s", err.Error())
+		return nil, nil, contentV1.ErrorInternalServerError("query post translation paths failed")
+	}
+
+	var tenantID *uint32
+	paths := make(map[string]string, len(entities))
+	for _, e := range entities {
+		tenantID = e.TenantID
+		paths[trans.StringValue(e.LanguageCode)] = trans.StringValue(e.FullPath)
+	}
+	return paths, tenantID, nil
+}
+
+// RecordReplacedPaths 整体替换语言版本后对比各语言的完整路径，有变化时记录自动重定向（best-effort）
+func (r *PostTranslationRepo) RecordReplacedPaths(ctx context.Context, postID uint32, before map[string]string) {
+	if len(before) == 0 {
+		return
+	}
+	after, tenantID, err := r.FullPaths(ctx, postID)
+	if err != nil {
+		return
+	}
+	r.redirectRepo.RecordReplacedPaths(ctx, tenantID, contentV1.ContentType_CONTENT_TYPE_POST, postID, before, after)
+}
+
+// anchorTranslation 设置新建语言版本的依据：指定源语言时锚定到其当前修订号，否则作为原文。
+// batch 为同批创建的语言版本，源语言在其中时修订号为初始值 1。
+func (r *PostTranslationRepo) anchorTranslation(ctx context.Context, client *ent.Client, data *contentV1.PostTranslation, batch []*contentV1.PostTranslation) error {
+	anchor, err := resolveTranslationAnchor(data.GetLanguageCode(), data.GetSourceLanguage(), func(lang string) (uint32, bool, error) {
+		for _, item := range batch {
+			if item != data && item.GetLanguageCode() == lang {
+				return 1, true, nil
+			}
+		}
+		return postTranslationRevision(ctx, client, data.GetPostId(), lang)
+	})
+	if err != nil {
+		return err
+	}
+
+	data.Revision = nil
+	data.TranslationStatus = trans.Ptr(anchor.status)
+	data.SourceLanguage = anchor.language
+	data.SourceRevision = anchor.revision
+
+	return nil
+}
+
 // CountByBaseSlug counts the number of post translations with the given base slug (case-insensitive).
 func (r *PostTranslationRepo) CountByBaseSlug(ctx context.Context, baseSlug string) (int64, error) {
 	c, err := r.entClient.Client().PostTranslation.Query().
@@ -311,12 +448,19 @@ func (r *PostTranslationRepo) TranslationExists(ctx context.Context, postId uint
 	return c > 0, nil
 }
 
-// ListAvailedLanguages lists the language codes of all translations available for the given post ID.
-func (r *PostTranslationRepo) ListAvailedLanguages(ctx context.Context, postId uint32) ([]string, error) {
-	entities, err := r.entClient.Client().PostTranslation.Query().
+// ListAvailedLanguages lists the language codes of the translations available for the given post ID.
+// When publicOnly is set, draft translations are excluded.
+func (r *PostTranslationRepo) ListAvailedLanguages(ctx context.Context, postId uint32, publicOnly bool) ([]string, error) {
+	builder := r.entClient.Client().PostTranslation.Query().
 		Where(
 			posttranslation.PostIDEQ(postId),
-		).
+		)
+	if publicOnly {
+		// 草稿翻译待审阅，不对外提供；后台审阅草稿时仍需可选
+		builder.Where(posttranslation.Or(posttranslation.IsDraftIsNil(), posttranslation.IsDraftEQ(false)))
+	}
+
+	entities, err := builder.
 		Select(posttranslation.FieldLanguageCode).
 		Strings(ctx)
 	if err != nil {
@@ -0,0 +1,385 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/tx7do/go-utils/copierutil"
+	"github.com/tx7do/go-utils/mapper"
+	"github.com/tx7do/go-utils/trans"
+
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	entCrud "github.com/tx7do/go-crud/entgo"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/page"
+	"go-wind-cms/app/core/service/internal/data/ent/post"
+	"go-wind-cms/app/core/service/internal/data/ent/predicate"
+	"go-wind-cms/app/core/service/internal/data/ent/previewtoken"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/preview"
+)
+
+// NewPreviewOption 读取自定义配置 Preview，未配置时返回 nil（无法签发预览令牌）
+func NewPreviewOption(ctx *bootstrap.Context) *contentV1.PreviewOption {
+	var cfg *contentV1.PreviewOptionWrapper
+	rawCfg, ok := ctx.GetCustomConfig("Preview")
+	if ok {
+		cfg = rawCfg.(*contentV1.PreviewOptionWrapper)
+	}
+	if cfg == nil {
+		return nil
+	}
+	return cfg.Preview
+}
+
+// previewContent 预览目标内容的租户与当前修订
+type previewContent struct {
+	tenantID  *uint32
+	createdAt *time.Time
+	updatedAt *time.Time
+}
+
+// revision 内容当前修订：以最后更新时间标识，从未更新过的内容取创建时间
+func (c *previewContent) revision() *time.Time {
+	if c.updatedAt != nil {
+		return c.updatedAt
+	}
+	return c.createdAt
+}
+
+// PreviewTokenRepo 草稿预览令牌的签发、吊销与校验。
+//
+// 令牌为签名字符串（见 pkg/content/preview），库中只登记令牌ID与范围；
+// 预览时先校验签名与有效期，再按令牌ID确认未被吊销，并核对租户与锁定的修订。
+type PreviewTokenRepo struct {
+	entClient *entCrud.EntClient[*ent.Client]
+	signer    *preview.Signer
+	log       *log.Helper
+
+	mapper *mapper.CopierMapper[contentV1.PreviewToken, ent.PreviewToken]
+
+	repository *entCrud.Repository[
+		ent.PreviewTokenQuery, ent.PreviewTokenSelect,
+		ent.PreviewTokenCreate, ent.PreviewTokenCreateBulk,
+		ent.PreviewTokenUpdate, ent.PreviewTokenUpdateOne,
+		ent.PreviewTokenDelete,
+		predicate.PreviewToken,
+		contentV1.PreviewToken, ent.PreviewToken,
+	]
+
+	contentTypeConverter *mapper.EnumTypeConverter[contentV1.PreviewToken_ContentType, previewtoken.ContentType]
+}
+
+func NewPreviewTokenRepo(ctx *bootstrap.Context, entClient *entCrud.EntClient[*ent.Client], cfg *contentV1.PreviewOption) *PreviewTokenRepo {
+	repo := &PreviewTokenRepo{
+		entClient: entClient,
+		log:       ctx.NewLoggerHelper("preview-token/repo/core-service"),
+		mapper:    mapper.NewCopierMapper[contentV1.PreviewToken, ent.PreviewToken](),
+		contentTypeConverter: mapper.NewEnumTypeConverter[contentV1.PreviewToken_ContentType, previewtoken.ContentType](
+			contentV1.PreviewToken_ContentType_name, contentV1.PreviewToken_ContentType_value,
+		),
+	}
+
+	repo.init()
+
+	if cfg.GetTokenSecret() == "" {
+		repo.log.Warn("preview token secret not configured, draft preview is disabled")
+		return repo
+	}
+
+	signer, err := preview.NewSigner(cfg.GetTokenSecret(), cfg.GetDefaultTtl().AsDuration(), cfg.GetMaxTtl().AsDuration())
+	if err != nil {
+		repo.log.Warnf("invalid preview token secret, draft preview is disabled: %s", err.Error())
+		return repo
+	}
+	repo.signer = signer
+
+	return repo
+}
+
+func (r *PreviewTokenRepo) init() {
+	r.repository = entCrud.NewRepository[
+		ent.PreviewTokenQuery, ent.PreviewTokenSelect,
+		ent.PreviewTokenCreate, ent.PreviewTokenCreateBulk,
+		ent.PreviewTokenUpdate, ent.PreviewTokenUpdateOne,
+		ent.PreviewTokenDelete,
+		predicate.PreviewToken,
+		contentV1.PreviewToken, ent.PreviewToken,
+	](r.mapper)
+
+	r.mapper.AppendConverters(copierutil.NewTimeStringConverterPair())
+	r.mapper.AppendConverters(copierutil.NewTimeTimestamppbConverterPair())
+
+	r.mapper.AppendConverters(r.contentTypeConverter.NewConverterPair())
+}
+
+// Enabled 是否可签发与校验预览令牌
+func (r *PreviewTokenRepo) Enabled() bool {
+	return r != nil && r.signer != nil
+}
+
+func previewKind(contentType contentV1.PreviewToken_ContentType) string {
+	switch contentType {
+	case contentV1.PreviewToken_CONTENT_TYPE_POST:
+		return preview.KindPost
+	case contentV1.PreviewToken_CONTENT_TYPE_PAGE:
+		return preview.KindPage
+	default:
+		return ""
+	}
+}
+
+// loadContent 查询预览目标内容（不限状态，已移入回收站的除外）
+func (r *PreviewTokenRepo) loadContent(ctx context.Context, kind string, id uint32) (*previewContent, error) {
+	switch kind {
+	case preview.KindPost:
+		builder := r.entClient.Client().Post.Query().Where(post.IDEQ(id), post.DeletedAtIsNil())
+		if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+			builder.Where(post.TenantIDEQ(tid))
+		}
+		entity, err := builder.Only(ctx)
+		if err != nil {
+			if ent.IsNotFound(err) {
+				return nil, contentV1.ErrorNotFound("post not found")
+			}
+			r.log.Errorf("query post failed: %s", err.Error())
+			return nil, contentV1.ErrorInternalServerError("query post failed")
+		}
+		return &previewContent{tenantID: entity.TenantID, createdAt: entity.CreatedAt, updatedAt: entity.UpdatedAt}, nil
+
+	case preview.KindPage:
+		builder := r.entClient.Client().Page.Query().Where(page.IDEQ(id), page.DeletedAtIsNil())
+		if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+			builder.Where(page.TenantIDEQ(tid))
+		}
+		entity, err := builder.Only(ctx)
+		if err != nil {
+			if ent.IsNotFound(err) {
+				return nil, contentV1.ErrorNotFound("page not found")
+			}
+			r.log.Errorf("query page failed: %s", err.Error())
+			return nil, contentV1.ErrorInternalServerError("query page failed")
+		}
+		return &previewContent{tenantID: entity.TenantID, createdAt: entity.CreatedAt, updatedAt: entity.UpdatedAt}, nil
+
+	default:
+		return nil, contentV1.ErrorBadRequest("unsupported content type")
+	}
+}
+
+// Create 为文章或页面签发预览令牌，令牌只在此返回一次
+func (r *PreviewTokenRepo) Create(ctx context.Context, req *contentV1.CreatePreviewTokenRequest) (*contentV1.CreatePreviewTokenResponse, error) {
+	if req == nil || req.GetContentId() == 0 {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+	if !r.Enabled() {
+		return nil, contentV1.ErrorServiceUnavailable("draft preview is not configured")
+	}
+
+	contentType := req.GetContentType()
+	kind := previewKind(contentType)
+	ct := r.contentTypeConverter.ToEntity(&contentType)
+	if kind == "" || ct == nil {
+		return nil, contentV1.ErrorBadRequest("unsupported content type")
+	}
+
+	content, err := r.loadContent(ctx, kind, req.GetContentId())
+	if err != nil {
+		return nil, err
+	}
+
+	claims := preview.Claims{
+		TenantID:  trans.Uint32Value(content.tenantID),
+		Kind:      kind,
+		ContentID: req.GetContentId(),
+		Locale:    req.GetLocale(),
+	}
+	var revision *time.Time
+	if req.GetPinRevision() {
+		if revision = content.revision(); revision == nil {
+			return nil, contentV1.ErrorInternalServerError("content revision is unknown")
+		}
+		claims.Revision = revision.UnixMilli()
+	}
+
+	now := time.Now()
+	token, claims := r.signer.Sign(claims, req.GetTtl().AsDuration(), now)
+
+	builder := r.entClient.Client().PreviewToken.Create().
+		SetTokenID(claims.TokenID).
+		SetContentType(*ct).
+		SetContentID(claims.ContentID).
+		SetNillableRevision(revision).
+		SetNillableLocale(req.Locale).
+		SetNillableNote(req.Note).
+		SetExpiresAt(time.Unix(claims.ExpiresAt, 0)).
+		SetNillableTenantID(content.tenantID).
+		SetCreatedAt(now)
+	if operatorID, ok := viewerUserIDFromContext(ctx); ok {
+		builder.SetCreatedBy(operatorID)
+	}
+
+	entity, err := builder.Save(ctx)
+	if err != nil {
+		r.log.Errorf("insert preview token failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("insert preview token failed")
+	}
+
+	return &contentV1.CreatePreviewTokenResponse{
+		Token: token,
+		Data:  r.mapper.ToDTO(entity),
+	}, nil
+}
+
+func (r *PreviewTokenRepo) List(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListPreviewTokenResponse, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	builder := r.entClient.Client().PreviewToken.Query()
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(previewtoken.TenantIDEQ(tid))
+	}
+
+	ret, err := r.repository.ListWithPaging(ctx, builder, builder.Clone(), req)
+	if err != nil {
+		return nil, err
+	}
+	if ret == nil {
+		return &contentV1.ListPreviewTokenResponse{Total: 0, Items: nil}, nil
+	}
+
+	return &contentV1.ListPreviewTokenResponse{
+		Total: ret.Total,
+		Items: ret.Items,
+	}, nil
+}
+
+// Revoke 吊销预览令牌，已吊销的令牌重复吊销不报错
+func (r *PreviewTokenRepo) Revoke(ctx context.Context, id uint32) error {
+	if id == 0 {
+		return contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	builder := r.entClient.Client().PreviewToken.Update().
+		Where(previewtoken.IDEQ(id), previewtoken.RevokedAtIsNil()).
+		SetRevokedAt(time.Now())
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(previewtoken.TenantIDEQ(tid))
+	}
+	if operatorID, ok := viewerUserIDFromContext(ctx); ok {
+		builder.SetRevokedBy(operatorID)
+	}
+
+	affected, err := builder.Save(ctx)
+	if err != nil {
+		r.log.Errorf("revoke preview token failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("revoke preview token failed")
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	existQuery := r.entClient.Client().PreviewToken.Query().Where(previewtoken.IDEQ(id))
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		existQuery.Where(previewtoken.TenantIDEQ(tid))
+	}
+	exist, err := existQuery.Exist(ctx)
+	if err != nil {
+		r.log.Errorf("query preview token failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("query preview token failed")
+	}
+	if !exist {
+		return contentV1.ErrorNotFound("preview token not found")
+	}
+	return nil
+}
+
+// Parse 校验预览令牌的签名、有效期与内容类型，不访问数据库；
+// 吊销状态、租户与修订由 Authorize 在读取内容后校验。
+func (r *PreviewTokenRepo) Parse(token, kind string) (*preview.Claims, error) {
+	if !r.Enabled() {
+		return nil, contentV1.ErrorForbidden("draft preview is not configured")
+	}
+
+	claims, err := r.signer.Parse(token, time.Now())
+	if err != nil {
+		if errors.Is(err, preview.ErrExpiredToken) {
+			return nil, contentV1.ErrorForbidden("preview token expired")
+		}
+		return nil, contentV1.ErrorForbidden("invalid preview token")
+	}
+	if claims.Kind != kind {
+		return nil, contentV1.ErrorForbidden("invalid preview token")
+	}
+	return claims, nil
+}
+
+// Authorize 以库中登记的令牌为准校验签名声明：令牌未被吊销且未过期，内容类型、内容ID、租户、
+// 语言与锁定的修订均与登记时一致；再确认内容属于该租户，且锁定的修订仍是内容的当前修订。
+// 签名密钥泄露时，伪造或改写的声明无法对上库中记录。
+func (r *PreviewTokenRepo) Authorize(ctx context.Context, claims *preview.Claims, contentID uint32) error {
+	if claims == nil || claims.ContentID != contentID {
+		return contentV1.ErrorForbidden("invalid preview token")
+	}
+
+	entity, err := r.entClient.Client().PreviewToken.Query().
+		Where(
+			previewtoken.TokenIDEQ(claims.TokenID),
+			previewtoken.RevokedAtIsNil(),
+		).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return contentV1.ErrorForbidden("preview token revoked")
+		}
+		r.log.Errorf("query preview token failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("query preview token failed")
+	}
+
+	if !entity.ExpiresAt.After(time.Now()) {
+		return contentV1.ErrorForbidden("preview token expired")
+	}
+	if !previewTokenMatches(entity, claims, r.contentTypeConverter.ToDTO(&entity.ContentType)) {
+		return contentV1.ErrorForbidden("invalid preview token")
+	}
+
+	content, err := r.loadContent(ctx, claims.Kind, contentID)
+	if err != nil {
+		return err
+	}
+	if trans.Uint32Value(content.tenantID) != claims.TenantID {
+		return contentV1.ErrorForbidden("invalid preview token")
+	}
+	if rev := content.revision(); claims.Revision != 0 && (rev == nil || rev.UnixMilli() != claims.Revision) {
+		return contentV1.ErrorConflict("previewed revision has been superseded")
+	}
+
+	return nil
+}
+
+// previewTokenMatches 签名声明是否与库中登记的令牌一致
+func previewTokenMatches(entity *ent.PreviewToken, claims *preview.Claims, contentType *contentV1.PreviewToken_ContentType) bool {
+	if contentType == nil || previewKind(*contentType) != claims.Kind {
+		return false
+	}
+	if entity.ContentID != claims.ContentID ||
+		trans.Uint32Value(entity.TenantID) != claims.TenantID ||
+		trans.StringValue(entity.Locale) != claims.Locale ||
+		entity.ExpiresAt.Unix() != claims.ExpiresAt {
+		return false
+	}
+
+	var revision int64
+	if entity.Revision != nil {
+		revision = entity.Revision.UnixMilli()
+	}
+	return revision == claims.Revision
+}
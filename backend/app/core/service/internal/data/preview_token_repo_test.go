@@ -0,0 +1,63 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tx7do/go-utils/trans"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/preview"
+)
+
+func TestPreviewTokenMatches(t *testing.T) {
+	expiresAt := time.Unix(1800000000, 0)
+	revision := time.UnixMilli(1700000000123)
+	entity := &ent.PreviewToken{
+		TenantID:  trans.Ptr(uint32(2)),
+		ContentID: 7,
+		Revision:  &revision,
+		Locale:    trans.Ptr("en"),
+		ExpiresAt: expiresAt,
+	}
+	post := trans.Ptr(contentV1.PreviewToken_CONTENT_TYPE_POST)
+
+	valid := func() *preview.Claims {
+		return &preview.Claims{
+			TokenID:   "t1",
+			TenantID:  2,
+			Kind:      preview.KindPost,
+			ContentID: 7,
+			Revision:  revision.UnixMilli(),
+			Locale:    "en",
+			ExpiresAt: expiresAt.Unix(),
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(c *preview.Claims)
+		want   bool
+	}{
+		{name: "valid", modify: func(*preview.Claims) {}, want: true},
+		{name: "extended expiry", modify: func(c *preview.Claims) { c.ExpiresAt += 3600 }},
+		{name: "switched kind", modify: func(c *preview.Claims) { c.Kind = preview.KindPage }},
+		{name: "other content", modify: func(c *preview.Claims) { c.ContentID = 8 }},
+		{name: "other tenant", modify: func(c *preview.Claims) { c.TenantID = 3 }},
+		{name: "other locale", modify: func(c *preview.Claims) { c.Locale = "zh-CN" }},
+		{name: "revision unpinned", modify: func(c *preview.Claims) { c.Revision = 0 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := valid()
+			tt.modify(claims)
+			assert.Equal(t, tt.want, previewTokenMatches(entity, claims, post))
+		})
+	}
+
+	assert.False(t, previewTokenMatches(entity, valid(), nil))
+}
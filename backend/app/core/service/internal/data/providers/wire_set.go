@@ -23,7 +23,9 @@ var ProviderSet = wire.NewSet(
 	client.NewRedisClient,
 	client.NewEntClient,
 	client.NewDiscovery,
-	client.NewMinIoClient,
+	client.NewStorageOption,
+	client.NewStorageRouter,
+	client.NewUploadGuard,
 	client.NewElasticSearchClient,
 
 	authorizer.NewAuthorizer,
@@ -33,6 +35,7 @@ var ProviderSet = wire.NewSet(
 	data.NewUserTokenCache,
 
 	data.NewPasswordCrypto,
+	data.NewTranslator,
 
 	data.NewSearchRepo,
 
@@ -40,6 +43,7 @@ var ProviderSet = wire.NewSet(
 	data.NewDictEntryRepo,
 	data.NewDictEntryI18nRepo,
 	data.NewLanguageRepo,
+	data.NewLocaleResolver,
 
 	data.NewTaskRepo,
 	data.NewLoginPolicyRepo,
@@ -87,8 +91,17 @@ var ProviderSet = wire.NewSet(
 	data.NewCategoryRepo,
 	data.NewCategoryTranslationRepo,
 
+	data.NewModerationOption,
+	data.NewCommentModerator,
+	data.NewCommentAuthorRuleRepo,
 	data.NewCommentRepo,
 
+	data.NewNotificationOption,
+	data.NewMailer,
+	data.NewUnsubscribeTokenSigner,
+	data.NewNotificationPreferenceRepo,
+	data.NewCommentNotificationRepo,
+
 	data.NewInteractionRepo,
 
 	data.NewMediaAssetRepo,
@@ -103,11 +116,48 @@ var ProviderSet = wire.NewSet(
 	data.NewSectionRepo,
 	data.NewSectionTranslationRepo,
 
+	data.NewPostProtectionOption,
+	data.NewPostProtection,
 	data.NewPostRepo,
 	data.NewPostTranslationRepo,
 	data.NewPostCategoryRepo,
 	data.NewPostTagRepo,
 
+	data.NewRedirectRepo,
+	data.NewRouteRepo,
+
+	data.NewFieldGroupRepo,
+
+	data.NewWorkflowRepo,
+	data.NewEditorialRepo,
+
+	data.NewTrashOption,
+	data.NewTrashRepo,
+	data.NewPreviewOption,
+	data.NewPreviewTokenRepo,
+	data.NewReleaseRepo,
+	data.NewRelatedPostsOption,
+	data.NewRelatedPostRepo,
+	data.NewFormRepo,
+	data.NewFormSubmissionRepo,
+	data.NewTranslationJobRepo,
+	data.NewImportJobRepo,
+	data.NewTranslationMemoryRepo,
+	data.NewGlossaryTermRepo,
+	data.NewTranslationDashboardRepo,
+	data.NewMachineTranslator,
+	data.NewLlmOption,
+	data.NewLlmProviders,
+	data.NewLlmUsageRepo,
+	data.NewAiAssistJobRepo,
+	data.NewSeoRepo,
+	data.NewSyndicationOption,
+	data.NewSyndicationRepo,
+	data.NewStructuredDataRepo,
+
+	data.NewContentModelRepo,
+	data.NewContentEntryRepo,
+
 	data.NewSiteSettingRepo,
 	data.NewSiteRepo,
 
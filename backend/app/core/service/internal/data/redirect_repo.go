@@ -0,0 +1,656 @@
+package data
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/tx7do/go-utils/copierutil"
+	"github.com/tx7do/go-utils/mapper"
+	"github.com/tx7do/go-utils/trans"
+
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	entCrud "github.com/tx7do/go-crud/entgo"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/page"
+	"go-wind-cms/app/core/service/internal/data/ent/pagetranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/predicate"
+	"go-wind-cms/app/core/service/internal/data/ent/redirect"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/route"
+	"go-wind-cms/pkg/content/trash"
+	"go-wind-cms/pkg/utils"
+)
+
+const (
+	// maxRedirectHops 解析重定向链的最大跳数，防止规则成环
+	maxRedirectHops = 5
+
+	// maxPageHierarchyDepth 计算层级页面路径时向上查找的最大层数，防止父子关系成环
+	maxPageHierarchyDepth = 64
+)
+
+// RedirectRepo 前台路径重定向规则
+type RedirectRepo struct {
+	entClient *entCrud.EntClient[*ent.Client]
+	log       *log.Helper
+
+	mapper *mapper.CopierMapper[contentV1.Redirect, ent.Redirect]
+
+	repository *entCrud.Repository[
+		ent.RedirectQuery, ent.RedirectSelect,
+		ent.RedirectCreate, ent.RedirectCreateBulk,
+		ent.RedirectUpdate, ent.RedirectUpdateOne,
+		ent.RedirectDelete,
+		predicate.Redirect,
+		contentV1.Redirect, ent.Redirect,
+	]
+
+	originConverter      *mapper.EnumTypeConverter[contentV1.Redirect_Origin, redirect.Origin]
+	contentTypeConverter *mapper.EnumTypeConverter[contentV1.ContentType, redirect.ContentType]
+}
+
+func NewRedirectRepo(ctx *bootstrap.Context, entClient *entCrud.EntClient[*ent.Client]) *RedirectRepo {
+	repo := &RedirectRepo{
+		entClient: entClient,
+		log:       ctx.NewLoggerHelper("redirect/repo/core-service"),
+		mapper:    mapper.NewCopierMapper[contentV1.Redirect, ent.Redirect](),
+		originConverter: mapper.NewEnumTypeConverter[contentV1.Redirect_Origin, redirect.Origin](
+			contentV1.Redirect_Origin_name, contentV1.Redirect_Origin_value,
+		),
+		contentTypeConverter: mapper.NewEnumTypeConverter[contentV1.ContentType, redirect.ContentType](
+			contentV1.ContentType_name, contentV1.ContentType_value,
+		),
+	}
+
+	repo.init()
+
+	return repo
+}
+
+func (r *RedirectRepo) init() {
+	r.repository = entCrud.NewRepository[
+		ent.RedirectQuery, ent.RedirectSelect,
+		ent.RedirectCreate, ent.RedirectCreateBulk,
+		ent.RedirectUpdate, ent.RedirectUpdateOne,
+		ent.RedirectDelete,
+		predicate.Redirect,
+		contentV1.Redirect, ent.Redirect,
+	](r.mapper)
+
+	r.mapper.AppendConverters(copierutil.NewTimeStringConverterPair())
+	r.mapper.AppendConverters(copierutil.NewTimeTimestamppbConverterPair())
+
+	r.mapper.AppendConverters(r.originConverter.NewConverterPair())
+	r.mapper.AppendConverters(r.contentTypeConverter.NewConverterPair())
+}
+
+// normalizeRedirect 校验并规范化规则的源路径、目标与状态码
+func normalizeRedirect(data *contentV1.Redirect) error {
+	source, err := route.NormalizeSource(data.GetSourcePath())
+	if err != nil {
+		return contentV1.ErrorBadRequest("invalid redirect source path")
+	}
+	wildcard := route.IsWildcard(source)
+
+	target, err := route.NormalizeTarget(data.GetTargetPath(), wildcard)
+	if err != nil {
+		return contentV1.ErrorBadRequest("invalid redirect target")
+	}
+	if !wildcard && source == target {
+		return contentV1.ErrorBadRequest("redirect source and target must differ")
+	}
+
+	if data.StatusCode == nil || data.GetStatusCode() == 0 {
+		data.StatusCode = trans.Ptr(uint32(301))
+	}
+	if !route.ValidStatusCode(data.GetStatusCode()) {
+		return contentV1.ErrorBadRequest("redirect status code must be one of 301, 302, 307, 308")
+	}
+
+	data.SourcePath = trans.Ptr(source)
+	data.TargetPath = trans.Ptr(target)
+	data.IsWildcard = trans.Ptr(wildcard)
+	data.LanguageCode = trans.Ptr(data.GetLanguageCode())
+
+	return nil
+}
+
+func (r *RedirectRepo) List(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListRedirectResponse, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	builder := r.entClient.Client().Redirect.Query()
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(redirect.TenantIDEQ(tid))
+	}
+
+	ret, err := r.repository.ListWithPaging(ctx, builder, builder.Clone(), req)
+	if err != nil {
+		return nil, err
+	}
+	if ret == nil {
+		return &contentV1.ListRedirectResponse{Total: 0, Items: nil}, nil
+	}
+
+	return &contentV1.ListRedirectResponse{
+		Total: ret.Total,
+		Items: ret.Items,
+	}, nil
+}
+
+func (r *RedirectRepo) Get(ctx context.Context, req *contentV1.GetRedirectRequest) (*contentV1.Redirect, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	builder := r.entClient.Client().Redirect.Query().
+		Where(redirect.IDEQ(req.GetId()))
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(redirect.TenantIDEQ(tid))
+	}
+
+	entity, err := builder.Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound("redirect not found")
+		}
+		r.log.Errorf("query redirect failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query redirect failed")
+	}
+
+	return r.mapper.ToDTO(entity), nil
+}
+
+func (r *RedirectRepo) Create(ctx context.Context, req *contentV1.CreateRedirectRequest) (*contentV1.Redirect, error) {
+	if req == nil || req.Data == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+	if err := normalizeRedirect(req.Data); err != nil {
+		return nil, err
+	}
+
+	builder := r.entClient.Client().Redirect.Create().
+		SetNillableSourcePath(req.Data.SourcePath).
+		SetNillableTargetPath(req.Data.TargetPath).
+		SetNillableStatusCode(req.Data.StatusCode).
+		SetNillableLanguageCode(req.Data.LanguageCode).
+		SetNillableIsWildcard(req.Data.IsWildcard).
+		SetOrigin(redirect.OriginOriginManual).
+		SetNillableEnabled(req.Data.Enabled).
+		SetNillableRemark(req.Data.Remark).
+		SetNillableCreatedBy(req.Data.CreatedBy).
+		SetCreatedAt(time.Now())
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.SetTenantID(tid)
+	}
+
+	entity, err := builder.Save(ctx)
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return nil, contentV1.ErrorConflict("redirect source path already exists")
+		}
+		r.log.Errorf("insert redirect failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("insert redirect failed")
+	}
+
+	return r.mapper.ToDTO(entity), nil
+}
+
+func (r *RedirectRepo) Update(ctx context.Context, req *contentV1.UpdateRedirectRequest) (*contentV1.Redirect, error) {
+	if req == nil || req.Data == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	// 源路径与目标相互约束（通配规则才允许目标含 *），按合并后的完整规则校验
+	current, err := r.Get(ctx, &contentV1.GetRedirectRequest{Id: req.GetId()})
+	if err != nil {
+		return nil, err
+	}
+	if req.Data.SourcePath == nil {
+		req.Data.SourcePath = current.SourcePath
+	}
+	if req.Data.TargetPath == nil {
+		req.Data.TargetPath = current.TargetPath
+	}
+	if req.Data.StatusCode == nil {
+		req.Data.StatusCode = current.StatusCode
+	}
+	if req.Data.LanguageCode == nil {
+		req.Data.LanguageCode = current.LanguageCode
+	}
+	if err = normalizeRedirect(req.Data); err != nil {
+		return nil, err
+	}
+
+	// 来源与关联内容由服务端维护，不随 updateMask 落库
+	if req.UpdateMask != nil {
+		req.UpdateMask.Paths = utils.FilterBlacklist(req.UpdateMask.GetPaths(), []string{
+			"origin", "content_type", "content_id", "is_wildcard",
+		})
+	}
+	req.Data.Origin = nil
+	req.Data.ContentType = nil
+	req.Data.ContentId = nil
+
+	tid, hasTenant := maybeTenantFromViewer(ctx)
+	callerUserID, hasUser := viewerUserIDFromContext(ctx)
+
+	builder := r.entClient.Client().Redirect.UpdateOneID(req.GetId())
+	if hasTenant {
+		builder.Where(redirect.TenantIDEQ(tid))
+	}
+	result, err := r.repository.UpdateOne(ctx, builder, req.Data, req.GetUpdateMask(),
+		func(dto *contentV1.Redirect) {
+			builder.
+				SetNillableSourcePath(req.Data.SourcePath).
+				SetNillableTargetPath(req.Data.TargetPath).
+				SetNillableStatusCode(req.Data.StatusCode).
+				SetNillableLanguageCode(req.Data.LanguageCode).
+				SetNillableIsWildcard(req.Data.IsWildcard).
+				SetNillableEnabled(req.Data.Enabled).
+				SetNillableRemark(req.Data.Remark).
+				SetUpdatedAt(time.Now())
+
+			// updated_by 强制由服务端 viewer context 推导，忽略客户端传入值
+			if hasUser {
+				builder.SetUpdatedBy(callerUserID)
+			}
+		},
+		func(s *sql.Selector) {
+			s.Where(sql.EQ(redirect.FieldID, req.GetId()))
+		},
+	)
+	if err != nil && ent.IsConstraintError(err) {
+		return nil, contentV1.ErrorConflict("redirect source path already exists")
+	}
+
+	return result, err
+}
+
+func (r *RedirectRepo) Delete(ctx context.Context, req *contentV1.DeleteRedirectRequest) error {
+	if req == nil {
+		return contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	builder := r.entClient.Client().Redirect.Delete().
+		Where(redirect.IDEQ(req.GetId()))
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(redirect.TenantIDEQ(tid))
+	}
+
+	affected, err := builder.Exec(ctx)
+	if err != nil {
+		r.log.Errorf("delete redirect failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("delete redirect failed")
+	}
+	if affected == 0 {
+		return contentV1.ErrorNotFound("redirect not found")
+	}
+
+	return nil
+}
+
+// Match 按已启用的规则匹配路径，并沿站内目标继续解析重定向链（最多 maxRedirectHops 跳）。
+// 规则限定语言时仅对该语言生效；未命中时返回 nil。
+func (r *RedirectRepo) Match(ctx context.Context, tenantID uint32, locale, path string) (*route.Rule, string, error) {
+	var (
+		matched *route.Rule
+		target  = path
+		visited = map[string]struct{}{path: {}}
+	)
+	for hop := 0; hop < maxRedirectHops; hop++ {
+		rule, next, err := r.matchOnce(ctx, tenantID, locale, target)
+		if err != nil {
+			return nil, "", err
+		}
+		if rule == nil {
+			break
+		}
+		if matched == nil {
+			matched = rule
+		}
+		target = next
+
+		// 站外地址或已访问过的路径（成环）不再继续解析
+		if _, seen := visited[target]; seen || !isSitePath(target) {
+			break
+		}
+		visited[target] = struct{}{}
+	}
+	if matched == nil {
+		return nil, "", nil
+	}
+
+	return matched, target, nil
+}
+
+func (r *RedirectRepo) matchOnce(ctx context.Context, tenantID uint32, locale, path string) (*route.Rule, string, error) {
+	normalized, err := route.NormalizePath(path)
+	if err != nil {
+		return nil, "", nil
+	}
+
+	languages := []string{""}
+	if locale != "" {
+		languages = append(languages, locale)
+	}
+
+	entities, err := r.entClient.Client().Redirect.Query().
+		Where(
+			redirect.TenantIDEQ(tenantID),
+			redirect.EnabledEQ(true),
+			redirect.LanguageCodeIn(languages...),
+			redirect.Or(
+				redirect.SourcePathEQ(normalized),
+				redirect.IsWildcardEQ(true),
+			),
+		).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query redirects failed: %s", err.Error())
+		return nil, "", contentV1.ErrorInternalServerError("query redirects failed")
+	}
+
+	// 限定语言的规则优先于通用规则
+	var localized, common []route.Rule
+	for _, e := range entities {
+		rule := route.Rule{
+			Source:     trans.StringValue(e.SourcePath),
+			Target:     trans.StringValue(e.TargetPath),
+			StatusCode: trans.Uint32Value(e.StatusCode),
+		}
+		if trans.StringValue(e.LanguageCode) != "" {
+			localized = append(localized, rule)
+		} else {
+			common = append(common, rule)
+		}
+	}
+
+	for _, rules := range [][]route.Rule{localized, common} {
+		if rule, target, ok := route.Match(rules, normalized); ok {
+			return rule, target, nil
+		}
+	}
+	return nil, "", nil
+}
+
+// RecordPathChange 内容完整路径变更后自动记录旧路径到新路径的 301 重定向。
+//
+//   - 已有以旧路径为源的自动规则时改写其目标；存在同源同语言的手工规则时不记录
+//   - 指向旧路径的自动规则改写为指向新路径，避免产生多跳
+//   - 删除以新路径为源的自动规则（如改回旧 slug），保留会导致循环跳转；手工规则由管理员自行维护
+func (r *RedirectRepo) RecordPathChange(ctx context.Context, tenantID uint32, contentType contentV1.ContentType, contentID uint32, languageCode, oldPath, newPath string) error {
+	oldPath, err := route.NormalizePath(oldPath)
+	if err != nil {
+		return nil
+	}
+	newPath, err = route.NormalizePath(newPath)
+	if err != nil || oldPath == newPath {
+		return nil
+	}
+
+	client := r.entClient.Client().Redirect
+	now := time.Now()
+	operatorID, hasOperator := viewerUserIDFromContext(ctx)
+
+	if _, err = client.Delete().
+		Where(
+			redirect.TenantIDEQ(tenantID),
+			redirect.SourcePathEQ(newPath),
+			redirect.LanguageCodeIn("", languageCode),
+			redirect.OriginEQ(redirect.OriginOriginAuto),
+		).
+		Exec(ctx); err != nil {
+		r.log.Errorf("delete redirects from new path failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("record redirect failed")
+	}
+
+	if _, err = client.Update().
+		Where(
+			redirect.TenantIDEQ(tenantID),
+			redirect.TargetPathEQ(oldPath),
+			redirect.OriginEQ(redirect.OriginOriginAuto),
+		).
+		SetTargetPath(newPath).
+		SetUpdatedAt(now).
+		Save(ctx); err != nil {
+		r.log.Errorf("rewrite redirects to old path failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("record redirect failed")
+	}
+
+	manual, err := client.Query().
+		Where(
+			redirect.TenantIDEQ(tenantID),
+			redirect.SourcePathEQ(oldPath),
+			redirect.LanguageCodeEQ(languageCode),
+			redirect.OriginNEQ(redirect.OriginOriginAuto),
+		).
+		Exist(ctx)
+	if err != nil {
+		r.log.Errorf("query manual redirect failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("record redirect failed")
+	}
+	if manual {
+		return nil
+	}
+
+	entContentType := r.contentTypeConverter.ToEntity(&contentType)
+	builder := client.Create().
+		SetTenantID(tenantID).
+		SetSourcePath(oldPath).
+		SetTargetPath(newPath).
+		SetStatusCode(301).
+		SetLanguageCode(languageCode).
+		SetIsWildcard(false).
+		SetOrigin(redirect.OriginOriginAuto).
+		SetNillableContentType(entContentType).
+		SetContentID(contentID).
+		SetEnabled(true).
+		SetCreatedAt(now)
+	if hasOperator {
+		builder.SetCreatedBy(operatorID)
+	}
+	if err = builder.
+		OnConflictColumns(redirect.FieldTenantID, redirect.FieldSourcePath, redirect.FieldLanguageCode).
+		Update(func(u *ent.RedirectUpsert) {
+			u.SetTargetPath(newPath)
+			u.SetStatusCode(301)
+			u.SetEnabled(true)
+			u.SetUpdatedAt(now)
+		}).
+		Exec(ctx); err != nil {
+		r.log.Errorf("record redirect failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("record redirect failed")
+	}
+
+	return nil
+}
+
+// RecordTranslationPathChange 翻译更新后调用：完整路径发生变化时记录自动重定向。
+// best-effort：翻译已更新成功，记录失败只记日志，不影响更新结果。
+func (r *RedirectRepo) RecordTranslationPathChange(ctx context.Context, tenantID *uint32, contentType contentV1.ContentType, contentID uint32, languageCode, oldPath, newPath string) {
+	if r == nil || oldPath == "" || newPath == "" || oldPath == newPath {
+		return
+	}
+	if err := r.RecordPathChange(ctx, trans.Uint32Value(tenantID), contentType, contentID, languageCode, oldPath, newPath); err != nil {
+		r.log.Warnf("record redirect %s -> %s failed: %v", oldPath, newPath, err)
+	}
+}
+
+// RecordReplacedPaths 整体替换语言版本后调用：按语言对比替换前后的完整路径，有变化时记录自动重定向。
+// best-effort：内容已更新成功，记录失败只记日志。
+func (r *RedirectRepo) RecordReplacedPaths(ctx context.Context, tenantID *uint32, contentType contentV1.ContentType, contentID uint32, before, after map[string]string) {
+	for lang, oldPath := range before {
+		r.RecordTranslationPathChange(ctx, tenantID, contentType, contentID, lang, oldPath, after[lang])
+	}
+}
+
+// deriveFullPath 由服务端推导更新后的完整路径：slug 变更而客户端未同时修改完整路径时，
+// 将旧完整路径末段的旧 slug 替换为新 slug，旧路径随后自动记录为重定向
+func deriveFullPath(oldSlug, oldFullPath string, newSlug, newFullPath *string) *string {
+	if newSlug == nil || *newSlug == "" || *newSlug == oldSlug || oldFullPath == "" {
+		return newFullPath
+	}
+	if newFullPath != nil && *newFullPath != "" && *newFullPath != oldFullPath {
+		return newFullPath
+	}
+	return trans.Ptr(trash.ReplaceSlug(oldFullPath, oldSlug, *newSlug))
+}
+
+// pageHierarchyKey 层级页面路径的键，语言为空表示按页面主表 slug 拼接的路径
+type pageHierarchyKey struct {
+	pageID       uint32
+	languageCode string
+}
+
+// PageHierarchyPaths 计算页面及其全部下级页面的层级路径，规则与 RouteRepo.matchPageHierarchy 一致：
+// 每一级优先取该语言非草稿翻译的 slug，没有时取页面主表 slug。
+// 语言路径与主表 slug 路径相同时只保留后者。
+func (r *RedirectRepo) PageHierarchyPaths(ctx context.Context, pageID uint32) (map[pageHierarchyKey]string, *uint32, error) {
+	client := r.entClient.Client()
+
+	root, err := client.Page.Get(ctx, pageID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil, nil
+		}
+		r.log.Errorf("query page failed: %s", err.Error())
+		return nil, nil, contentV1.ErrorInternalServerError("query page failed")
+	}
+	tenantID := trans.Uint32Value(root.TenantID)
+
+	pages, err := client.Page.Query().
+		Where(page.TenantIDEQ(tenantID), page.DeletedAtIsNil()).
+		Select(page.FieldID, page.FieldParentID, page.FieldSlug).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query pages failed: %s", err.Error())
+		return nil, nil, contentV1.ErrorInternalServerError("query pages failed")
+	}
+
+	byID := make(map[uint32]*ent.Page, len(pages))
+	children := make(map[uint32][]uint32)
+	for _, p := range pages {
+		byID[p.ID] = p
+		if p.ParentID != nil {
+			children[*p.ParentID] = append(children[*p.ParentID], p.ID)
+		}
+	}
+
+	// 广度优先收集子树
+	subtree := []uint32{pageID}
+	visited := map[uint32]bool{pageID: true}
+	for i := 0; i < len(subtree); i++ {
+		for _, child := range children[subtree[i]] {
+			if !visited[child] {
+				visited[child] = true
+				subtree = append(subtree, child)
+			}
+		}
+	}
+
+	translations, err := client.PageTranslation.Query().
+		Where(
+			pagetranslation.TenantIDEQ(tenantID),
+			pagetranslation.Or(pagetranslation.IsDraftIsNil(), pagetranslation.IsDraftEQ(false)),
+		).
+		Select(pagetranslation.FieldPageID, pagetranslation.FieldLanguageCode, pagetranslation.FieldSlug).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query page translations failed: %s", err.Error())
+		return nil, nil, contentV1.ErrorInternalServerError("query page translations failed")
+	}
+
+	slugs := make(map[uint32]map[string]string)
+	var languages []string
+	for _, t := range translations {
+		lang, slug := trans.StringValue(t.LanguageCode), trans.StringValue(t.Slug)
+		if lang == "" || slug == "" {
+			continue
+		}
+		id := trans.Uint32Value(t.PageID)
+		if slugs[id] == nil {
+			slugs[id] = make(map[string]string)
+		}
+		slugs[id][lang] = slug
+		if !slices.Contains(languages, lang) {
+			languages = append(languages, lang)
+		}
+	}
+
+	paths := make(map[pageHierarchyKey]string)
+	for _, id := range subtree {
+		chain := pageAncestorChain(byID, id)
+		if chain == nil {
+			continue
+		}
+
+		common := pageHierarchyPath(chain, slugs, "")
+		if common != "" {
+			paths[pageHierarchyKey{pageID: id}] = common
+		}
+		for _, lang := range languages {
+			if p := pageHierarchyPath(chain, slugs, lang); p != "" && p != common {
+				paths[pageHierarchyKey{pageID: id, languageCode: lang}] = p
+			}
+		}
+	}
+
+	return paths, root.TenantID, nil
+}
+
+// RecordPageHierarchyChange 对比页面子树更新前后的层级路径，为路径变化的页面记录自动重定向（best-effort）
+func (r *RedirectRepo) RecordPageHierarchyChange(ctx context.Context, tenantID *uint32, before, after map[pageHierarchyKey]string) {
+	for key, oldPath := range before {
+		r.RecordTranslationPathChange(ctx, tenantID, contentV1.ContentType_CONTENT_TYPE_PAGE,
+			key.pageID, key.languageCode, oldPath, after[key])
+	}
+}
+
+// pageAncestorChain 返回从顶级页面到指定页面的链路；上级缺失或成环时返回 nil
+func pageAncestorChain(byID map[uint32]*ent.Page, id uint32) []*ent.Page {
+	var chain []*ent.Page
+	for cur := byID[id]; cur != nil; {
+		chain = append(chain, cur)
+		if cur.ParentID == nil {
+			slices.Reverse(chain)
+			return chain
+		}
+		if len(chain) >= maxPageHierarchyDepth {
+			return nil
+		}
+		cur = byID[*cur.ParentID]
+	}
+	return nil
+}
+
+// pageHierarchyPath 按语言拼接链路上各级页面的 slug，任一级缺少 slug 时返回空
+func pageHierarchyPath(chain []*ent.Page, slugs map[uint32]map[string]string, lang string) string {
+	segments := make([]string, 0, len(chain))
+	for _, p := range chain {
+		seg := slugs[p.ID][lang]
+		if seg == "" {
+			seg = trans.StringValue(p.Slug)
+		}
+		if seg == "" {
+			return ""
+		}
+		segments = append(segments, seg)
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// isSitePath 是否为站内路径（可继续按规则解析）
+func isSitePath(p string) bool {
+	return len(p) > 0 && p[0] == '/' && (len(p) == 1 || p[1] != '/')
+}
@@ -0,0 +1,328 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	entCrud "github.com/tx7do/go-crud/entgo"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/interactioncounter"
+	"go-wind-cms/app/core/service/internal/data/ent/post"
+	"go-wind-cms/app/core/service/internal/data/ent/postcategory"
+	"go-wind-cms/app/core/service/internal/data/ent/posttag"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+	interactionV1 "go-wind-cms/api/gen/go/interaction/service/v1"
+
+	"go-wind-cms/pkg/content/related"
+)
+
+const (
+	// RelatedPostsKeyFormat 相关文章缓存键格式 post:related:{post_id}，hash 字段为语言
+	RelatedPostsKeyFormat = ProjectPrefix + "post:related:%d"
+
+	defaultRelatedPostsCacheTTL = 10 * time.Minute
+
+	// 共享分类/标签的候选扫描上限（按文章 ID 倒序，即优先较新的文章）
+	relatedTaxonomyScanLimit = 500
+)
+
+// NewRelatedPostsOption 读取自定义配置 RelatedPosts，未配置时返回 nil（使用默认权重）
+func NewRelatedPostsOption(ctx *bootstrap.Context) *contentV1.RelatedPostsOption {
+	var cfg *contentV1.RelatedPostsOptionWrapper
+	rawCfg, ok := ctx.GetCustomConfig("RelatedPosts")
+	if ok {
+		cfg = rawCfg.(*contentV1.RelatedPostsOptionWrapper)
+	}
+	if cfg == nil {
+		return nil
+	}
+	return cfg.RelatedPosts
+}
+
+func toRelatedWeights(w *contentV1.RelatedPostsOption_Weights) related.Weights {
+	return related.Weights{
+		Category:     w.GetCategory(),
+		Tag:          w.GetTag(),
+		MoreLikeThis: w.GetMoreLikeThis(),
+		Popularity:   w.GetPopularity(),
+	}
+}
+
+// RelatedPostRepo 相关文章推荐：综合共享分类/标签、OpenSearch more_like_this
+// 与点赞/收藏计数，按租户权重排序（见 pkg/content/related）。
+//
+// 排序结果（文章 ID 列表）按源文章与语言缓存在 Redis，源文章变更时由 Invalidate 清除；
+// 读取时再按 ID 回查已发布文章，候选文章下线后不会出现在结果中。
+type RelatedPostRepo struct {
+	entClient  *entCrud.EntClient[*ent.Client]
+	rdb        *redis.Client
+	searchRepo *SearchRepo
+	log        *log.Helper
+
+	weights       *related.Weights
+	tenantWeights map[uint32]related.Weights
+	cacheTTL      time.Duration
+}
+
+func NewRelatedPostRepo(
+	ctx *bootstrap.Context,
+	entClient *entCrud.EntClient[*ent.Client],
+	rdb *redis.Client,
+	searchRepo *SearchRepo,
+	cfg *contentV1.RelatedPostsOption,
+) *RelatedPostRepo {
+	repo := &RelatedPostRepo{
+		entClient:  entClient,
+		rdb:        rdb,
+		searchRepo: searchRepo,
+		log:        ctx.NewLoggerHelper("related-post/repo/core-service"),
+		cacheTTL:   cfg.GetCacheTtl().AsDuration(),
+	}
+	if repo.cacheTTL <= 0 {
+		repo.cacheTTL = defaultRelatedPostsCacheTTL
+	}
+
+	if cfg.GetWeights() != nil {
+		w := toRelatedWeights(cfg.GetWeights())
+		repo.weights = &w
+	}
+	if len(cfg.GetTenantWeights()) > 0 {
+		repo.tenantWeights = make(map[uint32]related.Weights, len(cfg.GetTenantWeights()))
+		for tid, w := range cfg.GetTenantWeights() {
+			repo.tenantWeights[tid] = toRelatedWeights(w)
+		}
+	}
+
+	return repo
+}
+
+func relatedPostsKey(postID uint32) string {
+	return fmt.Sprintf(RelatedPostsKeyFormat, postID)
+}
+
+// Rank 返回与源文章相关的已发布文章 ID（按得分降序，至多 related.PoolSize 篇）。
+// 源文章须为调用方租户下已发布的文章，否则返回 NotFound。
+func (r *RelatedPostRepo) Rank(ctx context.Context, postID uint32, language string) ([]uint32, error) {
+	tenantID, hasTenant := maybeTenantFromViewer(ctx)
+	if !hasTenant {
+		// 与搜索一致：无有效租户上下文时不推荐，避免跨租户
+		return nil, nil
+	}
+
+	source, err := r.entClient.Client().Post.Query().
+		Where(
+			post.IDEQ(postID),
+			post.TenantIDEQ(tenantID),
+			post.StatusEQ(post.StatusPostStatusPublished),
+			post.DeletedAtIsNil(),
+		).
+		Exist(ctx)
+	if err != nil {
+		r.log.Errorf("query post failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query post failed")
+	}
+	if !source {
+		return nil, contentV1.ErrorNotFound("post not found")
+	}
+
+	key := relatedPostsKey(postID)
+	if raw, err := r.rdb.HGet(ctx, key, language).Result(); err == nil {
+		return related.DecodeIDs(raw), nil
+	} else if !errors.Is(err, redis.Nil) {
+		// 缓存不可用时直接计算，不影响读取
+		r.log.Warnf("read related posts cache failed: %s", err.Error())
+	}
+
+	scored, err := r.compute(ctx, tenantID, postID, language)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, language, related.EncodeIDs(scored))
+		pipe.Expire(ctx, key, r.cacheTTL)
+		return nil
+	}); err != nil {
+		r.log.Warnf("write related posts cache failed: %s", err.Error())
+	}
+
+	ids := make([]uint32, 0, len(scored))
+	for _, s := range scored {
+		ids = append(ids, s.PostID)
+	}
+	return ids, nil
+}
+
+// Invalidate 清除源文章的相关文章缓存（所有语言）
+func (r *RelatedPostRepo) Invalidate(ctx context.Context, postID uint32) {
+	if postID == 0 {
+		return
+	}
+	if err := r.rdb.Del(ctx, relatedPostsKey(postID)).Err(); err != nil {
+		r.log.Warnf("invalidate related posts cache failed (post_id=%d): %s", postID, err.Error())
+	}
+}
+
+// compute 汇总各信号并排序
+func (r *RelatedPostRepo) compute(ctx context.Context, tenantID, postID uint32, language string) ([]related.Scored, error) {
+	client := r.entClient.Client()
+	candidates := make(map[uint32]*related.Candidate)
+	candidate := func(id uint32) *related.Candidate {
+		c, ok := candidates[id]
+		if !ok {
+			c = &related.Candidate{PostID: id}
+			candidates[id] = c
+		}
+		return c
+	}
+
+	var src related.Source
+
+	// 共享分类
+	categoryLinks, err := client.PostCategory.Query().
+		Where(postcategory.PostIDEQ(postID)).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query post categories failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query post categories failed")
+	}
+	categoryIDs := make([]uint32, 0, len(categoryLinks))
+	for _, l := range categoryLinks {
+		if l.CategoryID != nil {
+			categoryIDs = append(categoryIDs, *l.CategoryID)
+		}
+	}
+	src.Categories = len(categoryIDs)
+	if len(categoryIDs) > 0 {
+		links, err := client.PostCategory.Query().
+			Where(postcategory.CategoryIDIn(categoryIDs...), postcategory.PostIDNEQ(postID)).
+			Order(ent.Desc(postcategory.FieldPostID)).
+			Limit(relatedTaxonomyScanLimit).
+			All(ctx)
+		if err != nil {
+			r.log.Errorf("query posts by categories failed: %s", err.Error())
+			return nil, contentV1.ErrorInternalServerError("query posts by categories failed")
+		}
+		for _, l := range links {
+			if l.PostID != nil {
+				candidate(*l.PostID).SharedCategories++
+			}
+		}
+	}
+
+	// 共享标签
+	tagLinks, err := client.PostTag.Query().
+		Where(posttag.PostIDEQ(postID)).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query post tags failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query post tags failed")
+	}
+	tagIDs := make([]uint32, 0, len(tagLinks))
+	for _, l := range tagLinks {
+		if l.TagID != nil {
+			tagIDs = append(tagIDs, *l.TagID)
+		}
+	}
+	src.Tags = len(tagIDs)
+	if len(tagIDs) > 0 {
+		links, err := client.PostTag.Query().
+			Where(posttag.TagIDIn(tagIDs...), posttag.PostIDNEQ(postID)).
+			Order(ent.Desc(posttag.FieldPostID)).
+			Limit(relatedTaxonomyScanLimit).
+			All(ctx)
+		if err != nil {
+			r.log.Errorf("query posts by tags failed: %s", err.Error())
+			return nil, contentV1.ErrorInternalServerError("query posts by tags failed")
+		}
+		for _, l := range links {
+			if l.PostID != nil {
+				candidate(*l.PostID).SharedTags++
+			}
+		}
+	}
+
+	// 文本相似度：OpenSearch 不可用时退化为仅按分类/标签推荐
+	if language != "" {
+		hits, err := r.searchRepo.MoreLikeThisPosts(ctx, postID, tenantID, language, "POST_STATUS_PUBLISHED", related.PoolSize)
+		if err != nil {
+			r.log.Warnf("more like this query failed (post_id=%d): %s", postID, err.Error())
+		} else {
+			for _, hit := range hits.Hits {
+				pid, err := strconv.ParseUint(hit.PostID, 10, 32)
+				if err != nil || uint32(pid) == postID {
+					continue
+				}
+				candidate(uint32(pid)).MoreLikeThis = hit.Score
+			}
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uint32, 0, len(candidates))
+	for id := range candidates {
+		ids = append(ids, id)
+	}
+
+	// 仅保留同租户已发布的候选
+	published, err := client.Post.Query().
+		Where(
+			post.IDIn(ids...),
+			post.TenantIDEQ(tenantID),
+			post.StatusEQ(post.StatusPostStatusPublished),
+			post.DeletedAtIsNil(),
+		).
+		IDs(ctx)
+	if err != nil {
+		r.log.Errorf("query candidate posts failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query candidate posts failed")
+	}
+	if len(published) == 0 {
+		return nil, nil
+	}
+
+	// 热度：点赞 + 收藏
+	counters, err := client.InteractionCounter.Query().
+		Where(
+			interactioncounter.TenantIDEQ(tenantID),
+			interactioncounter.TargetTypeEQ(uint8(interactionV1.TargetType_TARGET_TYPE_POST)),
+			interactioncounter.TargetIDIn(published...),
+			interactioncounter.MetricIn(
+				uint8(interactionV1.CounterMetric_COUNTER_METRIC_LIKE),
+				uint8(interactionV1.CounterMetric_COUNTER_METRIC_WATCH),
+			),
+		).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query interaction counters failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query interaction counters failed")
+	}
+	for _, c := range counters {
+		if c.TargetID == nil || c.Count == nil {
+			continue
+		}
+		if cand, ok := candidates[*c.TargetID]; ok {
+			cand.Engagement += *c.Count
+		}
+	}
+
+	list := make([]related.Candidate, 0, len(published))
+	for _, id := range published {
+		list = append(list, *candidates[id])
+	}
+
+	weights := related.ResolveWeights(r.weights, r.tenantWeights, tenantID)
+	return related.Rank(src, list, weights, related.PoolSize), nil
+}
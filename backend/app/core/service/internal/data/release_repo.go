@@ -0,0 +1,974 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/tx7do/go-utils/copierutil"
+	"github.com/tx7do/go-utils/mapper"
+	"github.com/tx7do/go-utils/trans"
+
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	entCrud "github.com/tx7do/go-crud/entgo"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/navigationitem"
+	"go-wind-cms/app/core/service/internal/data/ent/page"
+	"go-wind-cms/app/core/service/internal/data/ent/post"
+	"go-wind-cms/app/core/service/internal/data/ent/predicate"
+	entRelease "go-wind-cms/app/core/service/internal/data/ent/release"
+	"go-wind-cms/app/core/service/internal/data/ent/releaseitem"
+	"go-wind-cms/app/core/service/internal/data/ent/section"
+	"go-wind-cms/app/core/service/internal/data/ent/sitesetting"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/release"
+)
+
+// releaseMutation 发布集对内容的字段变更通过 ent 的通用 Mutation 接口完成，
+// 字段白名单与取值解析见 pkg/content/release。
+type releaseMutation interface {
+	OldField(ctx context.Context, name string) (ent.Value, error)
+	SetField(name string, value ent.Value) error
+	ClearField(name string) error
+}
+
+// 可发布（含重试）的发布集状态
+var publishableReleaseStatuses = []entRelease.Status{
+	entRelease.StatusReleaseStatusDraft,
+	entRelease.StatusReleaseStatusScheduled,
+	entRelease.StatusReleaseStatusFailed,
+}
+
+// 可编辑条目的发布集状态
+var editableReleaseStatuses = []entRelease.Status{
+	entRelease.StatusReleaseStatusDraft,
+	entRelease.StatusReleaseStatusFailed,
+}
+
+// ReleaseRepo 发布集：条目编辑、预览、定时、整体发布与回滚。
+//
+// 发布时在同一事务中逐条应用字段变更并记录原值快照，任一条目失败则整体回滚；
+// 回滚时按快照逆序还原，内容在发布后又被修改时默认拒绝回滚。
+type ReleaseRepo struct {
+	entClient *entCrud.EntClient[*ent.Client]
+	log       *log.Helper
+
+	mapper     *mapper.CopierMapper[contentV1.Release, ent.Release]
+	itemMapper *mapper.CopierMapper[contentV1.ReleaseItem, ent.ReleaseItem]
+
+	repository *entCrud.Repository[
+		ent.ReleaseQuery, ent.ReleaseSelect,
+		ent.ReleaseCreate, ent.ReleaseCreateBulk,
+		ent.ReleaseUpdate, ent.ReleaseUpdateOne,
+		ent.ReleaseDelete,
+		predicate.Release,
+		contentV1.Release, ent.Release,
+	]
+
+	statusConverter     *mapper.EnumTypeConverter[contentV1.Release_ReleaseStatus, entRelease.Status]
+	entityTypeConverter *mapper.EnumTypeConverter[contentV1.ReleaseItem_EntityType, releaseitem.EntityType]
+}
+
+func NewReleaseRepo(ctx *bootstrap.Context, entClient *entCrud.EntClient[*ent.Client]) *ReleaseRepo {
+	repo := &ReleaseRepo{
+		entClient:  entClient,
+		log:        ctx.NewLoggerHelper("release/repo/core-service"),
+		mapper:     mapper.NewCopierMapper[contentV1.Release, ent.Release](),
+		itemMapper: mapper.NewCopierMapper[contentV1.ReleaseItem, ent.ReleaseItem](),
+		statusConverter: mapper.NewEnumTypeConverter[contentV1.Release_ReleaseStatus, entRelease.Status](
+			contentV1.Release_ReleaseStatus_name, contentV1.Release_ReleaseStatus_value,
+		),
+		entityTypeConverter: mapper.NewEnumTypeConverter[contentV1.ReleaseItem_EntityType, releaseitem.EntityType](
+			contentV1.ReleaseItem_EntityType_name, contentV1.ReleaseItem_EntityType_value,
+		),
+	}
+
+	repo.init()
+
+	return repo
+}
+
+func (r *ReleaseRepo) init() {
+	r.repository = entCrud.NewRepository[
+		ent.ReleaseQuery, ent.ReleaseSelect,
+		ent.ReleaseCreate, ent.ReleaseCreateBulk,
+		ent.ReleaseUpdate, ent.ReleaseUpdateOne,
+		ent.ReleaseDelete,
+		predicate.Release,
+		contentV1.Release, ent.Release,
+	](r.mapper)
+
+	r.mapper.AppendConverters(copierutil.NewTimeStringConverterPair())
+	r.mapper.AppendConverters(copierutil.NewTimeTimestamppbConverterPair())
+	r.mapper.AppendConverters(r.statusConverter.NewConverterPair())
+
+	r.itemMapper.AppendConverters(copierutil.NewTimeStringConverterPair())
+	r.itemMapper.AppendConverters(copierutil.NewTimeTimestamppbConverterPair())
+	r.itemMapper.AppendConverters(r.entityTypeConverter.NewConverterPair())
+}
+
+func (r *ReleaseRepo) withTx(ctx context.Context, fn func(tx *ent.Tx) error) (err error) {
+	var tx *ent.Tx
+	tx, err = r.entClient.Client().Tx(ctx)
+	if err != nil {
+		r.log.Errorf("start transaction failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("start transaction failed")
+	}
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				r.log.Errorf("transaction rollback failed: %s", rollbackErr.Error())
+			}
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			r.log.Errorf("transaction commit failed: %s", commitErr.Error())
+			err = contentV1.ErrorInternalServerError("transaction commit failed")
+		}
+	}()
+
+	return fn(tx)
+}
+
+// releaseEntityKey 条目内容类型对应的字段白名单键
+func releaseEntityKey(et releaseitem.EntityType) string {
+	switch et {
+	case releaseitem.EntityTypeEntityTypePost:
+		return release.EntityPost
+	case releaseitem.EntityTypeEntityTypePage:
+		return release.EntityPage
+	case releaseitem.EntityTypeEntityTypeSection:
+		return release.EntitySection
+	case releaseitem.EntityTypeEntityTypeNavigationItem:
+		return release.EntityNavigationItem
+	case releaseitem.EntityTypeEntityTypeSiteSetting:
+		return release.EntitySiteSetting
+	default:
+		return ""
+	}
+}
+
+// checkEntity 确认内容存在、未移入回收站且属于发布集所在租户
+func (r *ReleaseRepo) checkEntity(ctx context.Context, client *ent.Client, key string, id uint32, tenantID *uint32) error {
+	tid := trans.Uint32Value(tenantID)
+
+	var (
+		exist bool
+		err   error
+	)
+	switch key {
+	case release.EntityPost:
+		q := client.Post.Query().Where(post.IDEQ(id), post.DeletedAtIsNil())
+		if tid != 0 {
+			q.Where(post.TenantIDEQ(tid))
+		}
+		exist, err = q.Exist(ctx)
+	case release.EntityPage:
+		q := client.Page.Query().Where(page.IDEQ(id), page.DeletedAtIsNil())
+		if tid != 0 {
+			q.Where(page.TenantIDEQ(tid))
+		}
+		exist, err = q.Exist(ctx)
+	case release.EntitySection:
+		q := client.Section.Query().Where(section.IDEQ(id), section.DeletedAtIsNil())
+		if tid != 0 {
+			q.Where(section.TenantIDEQ(tid))
+		}
+		exist, err = q.Exist(ctx)
+	case release.EntityNavigationItem:
+		q := client.NavigationItem.Query().Where(navigationitem.IDEQ(id))
+		if tid != 0 {
+			q.Where(navigationitem.TenantIDEQ(tid))
+		}
+		exist, err = q.Exist(ctx)
+	case release.EntitySiteSetting:
+		q := client.SiteSetting.Query().Where(sitesetting.IDEQ(id))
+		if tid != 0 {
+			q.Where(sitesetting.TenantIDEQ(tid))
+		}
+		exist, err = q.Exist(ctx)
+	default:
+		return contentV1.ErrorBadRequest("unsupported entity type")
+	}
+	if err != nil {
+		r.log.Errorf("query %s failed: %s", key, err.Error())
+		return contentV1.ErrorInternalServerError(fmt.Sprintf("query %s failed", key))
+	}
+	if !exist {
+		return contentV1.ErrorNotFound(fmt.Sprintf("%s %d not found", key, id))
+	}
+	return nil
+}
+
+// checkPostWorkflow 处于编辑工作流中的文章只能经工作流流转发布，发布集同样不能直接变更其发布状态
+func (r *ReleaseRepo) checkPostWorkflow(ctx context.Context, client *ent.Client, id uint32, changes map[string]string) error {
+	raw, ok := changes["status"]
+	if !ok {
+		return nil
+	}
+	status := contentV1.Post_PostStatus(contentV1.Post_PostStatus_value[raw])
+	if workflowStatusAllowed(&status) {
+		return nil
+	}
+
+	underWorkflow, err := client.Post.Query().
+		Where(post.IDEQ(id), post.WorkflowIDNotNil()).
+		Exist(ctx)
+	if err != nil {
+		r.log.Errorf("query post workflow failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("query post workflow failed")
+	}
+	if underWorkflow {
+		return contentV1.ErrorBadRequest(fmt.Sprintf("post %d is under an editorial workflow, publish it through a workflow transition", id))
+	}
+	return nil
+}
+
+// checkItem 发布前校验单个条目
+func (r *ReleaseRepo) checkItem(ctx context.Context, client *ent.Client, key string, item *ent.ReleaseItem, tenantID *uint32) error {
+	if err := r.checkEntity(ctx, client, key, item.EntityID, tenantID); err != nil {
+		return err
+	}
+	if key == release.EntityPost {
+		return r.checkPostWorkflow(ctx, client, item.EntityID, item.Changes)
+	}
+	return nil
+}
+
+// mutationFor 构造内容的更新 Mutation，exec 执行更新
+func mutationFor(client *ent.Client, key string, id uint32) (releaseMutation, func(context.Context) error) {
+	switch key {
+	case release.EntityPost:
+		u := client.Post.UpdateOneID(id)
+		return u.Mutation(), u.Exec
+	case release.EntityPage:
+		u := client.Page.UpdateOneID(id)
+		return u.Mutation(), u.Exec
+	case release.EntitySection:
+		u := client.Section.UpdateOneID(id)
+		return u.Mutation(), u.Exec
+	case release.EntityNavigationItem:
+		u := client.NavigationItem.UpdateOneID(id)
+		return u.Mutation(), u.Exec
+	case release.EntitySiteSetting:
+		u := client.SiteSetting.UpdateOneID(id)
+		return u.Mutation(), u.Exec
+	default:
+		return nil, nil
+	}
+}
+
+// fieldValue 解析字段取值并转换为 ent 字段类型，nil 表示清空
+func fieldValue(key string, f release.Field, raw string) (ent.Value, error) {
+	v, err := f.Parse(raw)
+	if err != nil || v == nil || f.Kind != release.KindEnum {
+		return v, err
+	}
+
+	switch key {
+	case release.EntityPost:
+		return post.Status(v.(string)), nil
+	case release.EntityPage:
+		return page.Status(v.(string)), nil
+	default:
+		return nil, fmt.Errorf("%w: %s.%s", release.ErrUnknownField, key, f.Name)
+	}
+}
+
+// setFields 按字段名顺序写入取值；values 中为 nil 的字段清空
+func setFields(m releaseMutation, key string, values map[string]*string) error {
+	for _, name := range release.SortedNames(toChanges(values)) {
+		f, err := release.Lookup(key, name)
+		if err != nil {
+			return err
+		}
+
+		raw := values[name]
+		if raw == nil {
+			if err = m.ClearField(name); err != nil {
+				return err
+			}
+			continue
+		}
+
+		v, err := fieldValue(key, f, *raw)
+		if err != nil {
+			return err
+		}
+		if v == nil {
+			err = m.ClearField(name)
+		} else {
+			err = m.SetField(name, v)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// snapshotFields 读取变更字段的当前值
+func snapshotFields(ctx context.Context, m releaseMutation, changes map[string]string) (map[string]*string, error) {
+	snapshot := make(map[string]*string, len(changes))
+	for _, name := range release.SortedNames(changes) {
+		old, err := m.OldField(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		snapshot[name] = release.Format(old)
+	}
+	return snapshot, nil
+}
+
+func toChanges(values map[string]*string) map[string]string {
+	changes := make(map[string]string, len(values))
+	for name, v := range values {
+		changes[name] = trans.StringValue(v)
+	}
+	return changes
+}
+
+func fromChanges(changes map[string]string) map[string]*string {
+	values := make(map[string]*string, len(changes))
+	for name, v := range changes {
+		values[name] = trans.Ptr(v)
+	}
+	return values
+}
+
+// touch 记录内容的更新时间与更新者
+func touch(m releaseMutation, now time.Time, operatorID *uint32) error {
+	if err := m.SetField("updated_at", now); err != nil {
+		return err
+	}
+	if operatorID != nil {
+		return m.SetField("updated_by", *operatorID)
+	}
+	return nil
+}
+
+// getEntity 按 ID 查询发布集（按 viewer 租户隔离）
+func (r *ReleaseRepo) getEntity(ctx context.Context, id uint32) (*ent.Release, error) {
+	builder := r.entClient.Client().Release.Query().
+		Where(entRelease.IDEQ(id))
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(entRelease.TenantIDEQ(tid))
+	}
+
+	entity, err := builder.Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound("release not found")
+		}
+		r.log.Errorf("query release failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query release failed")
+	}
+	return entity, nil
+}
+
+func (r *ReleaseRepo) listItems(ctx context.Context, client *ent.Client, releaseID uint32) ([]*ent.ReleaseItem, error) {
+	items, err := client.ReleaseItem.Query().
+		Where(releaseitem.ReleaseIDEQ(releaseID)).
+		Order(ent.Asc(releaseitem.FieldID)).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query release items failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query release items failed")
+	}
+	return items, nil
+}
+
+func (r *ReleaseRepo) List(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListReleaseResponse, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	builder := r.entClient.Client().Release.Query()
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(entRelease.TenantIDEQ(tid))
+	}
+
+	ret, err := r.repository.ListWithPaging(ctx, builder, builder.Clone(), req)
+	if err != nil {
+		return nil, err
+	}
+	if ret == nil {
+		return &contentV1.ListReleaseResponse{Total: 0, Items: nil}, nil
+	}
+
+	return &contentV1.ListReleaseResponse{
+		Total: ret.Total,
+		Items: ret.Items,
+	}, nil
+}
+
+// Get 查询发布集及其条目
+func (r *ReleaseRepo) Get(ctx context.Context, id uint32) (*contentV1.Release, error) {
+	entity, err := r.getEntity(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := r.listItems(ctx, r.entClient.Client(), entity.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := r.mapper.ToDTO(entity)
+	for _, item := range items {
+		dto.Items = append(dto.Items, r.itemMapper.ToDTO(item))
+	}
+	return dto, nil
+}
+
+func (r *ReleaseRepo) Create(ctx context.Context, req *contentV1.CreateReleaseRequest) (*contentV1.Release, error) {
+	if req == nil || req.Data == nil || req.Data.GetName() == "" {
+		return nil, contentV1.ErrorBadRequest("name is required")
+	}
+
+	builder := r.entClient.Client().Release.Create().
+		SetName(req.Data.GetName()).
+		SetNillableDescription(req.Data.Description).
+		SetStatus(entRelease.StatusReleaseStatusDraft).
+		SetCreatedAt(time.Now())
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.SetTenantID(tid)
+	}
+	if operatorID, ok := viewerUserIDFromContext(ctx); ok {
+		builder.SetCreatedBy(operatorID)
+	}
+
+	entity, err := builder.Save(ctx)
+	if err != nil {
+		r.log.Errorf("insert release failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("insert release failed")
+	}
+
+	return r.mapper.ToDTO(entity), nil
+}
+
+// Update 更新名称与说明，已发布或已回滚的发布集仍可修改说明
+func (r *ReleaseRepo) Update(ctx context.Context, req *contentV1.UpdateReleaseRequest) (*contentV1.Release, error) {
+	if req == nil || req.Data == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+	if req.Data.Name != nil && req.Data.GetName() == "" {
+		return nil, contentV1.ErrorBadRequest("name is required")
+	}
+
+	if _, err := r.getEntity(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	builder := r.entClient.Client().Release.UpdateOneID(req.GetId()).
+		SetNillableName(req.Data.Name).
+		SetNillableDescription(req.Data.Description).
+		SetUpdatedAt(time.Now())
+	if operatorID, ok := viewerUserIDFromContext(ctx); ok {
+		builder.SetUpdatedBy(operatorID)
+	}
+	if err := builder.Exec(ctx); err != nil {
+		r.log.Errorf("update release failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("update release failed")
+	}
+
+	return r.Get(ctx, req.GetId())
+}
+
+// Delete 删除发布集及其条目；已发布的发布集保留以便回滚，已定时的须先取消定时
+func (r *ReleaseRepo) Delete(ctx context.Context, id uint32) error {
+	entity, err := r.getEntity(ctx, id)
+	if err != nil {
+		return err
+	}
+	if entity.Status == entRelease.StatusReleaseStatusPublished || entity.Status == entRelease.StatusReleaseStatusScheduled {
+		return contentV1.ErrorConflict("published or scheduled releases cannot be deleted")
+	}
+
+	return r.withTx(ctx, func(tx *ent.Tx) error {
+		if _, err := tx.ReleaseItem.Delete().
+			Where(releaseitem.ReleaseIDEQ(id)).
+			Exec(ctx); err != nil {
+			r.log.Errorf("delete release items failed: %s", err.Error())
+			return contentV1.ErrorInternalServerError("delete release items failed")
+		}
+
+		affected, err := tx.Release.Delete().
+			Where(entRelease.IDEQ(id), entRelease.StatusIn(
+				entRelease.StatusReleaseStatusDraft,
+				entRelease.StatusReleaseStatusFailed,
+				entRelease.StatusReleaseStatusRolledBack,
+			)).
+			Exec(ctx)
+		if err != nil {
+			r.log.Errorf("delete release failed: %s", err.Error())
+			return contentV1.ErrorInternalServerError("delete release failed")
+		}
+		if affected == 0 {
+			return contentV1.ErrorConflict("release status changed, please retry")
+		}
+		return nil
+	})
+}
+
+// getEditable 查询可编辑条目的发布集
+func (r *ReleaseRepo) getEditable(ctx context.Context, id uint32) (*ent.Release, error) {
+	entity, err := r.getEntity(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(editableReleaseStatuses, entity.Status) {
+		return nil, contentV1.ErrorConflict("release items can only be changed while the release is a draft")
+	}
+	return entity, nil
+}
+
+// SetItem 添加或替换发布集中某条内容的变更
+func (r *ReleaseRepo) SetItem(ctx context.Context, req *contentV1.SetReleaseItemRequest) (*contentV1.ReleaseItem, error) {
+	if req == nil || req.GetEntityId() == 0 {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	entityType := req.GetEntityType()
+	et := r.entityTypeConverter.ToEntity(&entityType)
+	if et == nil {
+		return nil, contentV1.ErrorBadRequest("unsupported entity type")
+	}
+	key := releaseEntityKey(*et)
+	if err := release.Validate(key, req.GetChanges()); err != nil {
+		return nil, contentV1.ErrorBadRequest(err.Error())
+	}
+
+	rel, err := r.getEditable(ctx, req.GetReleaseId())
+	if err != nil {
+		return nil, err
+	}
+
+	client := r.entClient.Client()
+	if err = r.checkEntity(ctx, client, key, req.GetEntityId(), rel.TenantID); err != nil {
+		return nil, err
+	}
+	if key == release.EntityPost {
+		if err = r.checkPostWorkflow(ctx, client, req.GetEntityId(), req.GetChanges()); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	existing, err := client.ReleaseItem.Query().
+		Where(
+			releaseitem.ReleaseIDEQ(rel.ID),
+			releaseitem.EntityTypeEQ(*et),
+			releaseitem.EntityIDEQ(req.GetEntityId()),
+		).
+		Only(ctx)
+	switch {
+	case err == nil:
+		existing, err = client.ReleaseItem.UpdateOne(existing).
+			SetChanges(req.GetChanges()).
+			SetUpdatedAt(now).
+			Save(ctx)
+		if err != nil {
+			r.log.Errorf("update release item failed: %s", err.Error())
+			return nil, contentV1.ErrorInternalServerError("update release item failed")
+		}
+		return r.itemMapper.ToDTO(existing), nil
+
+	case ent.IsNotFound(err):
+		item, err := client.ReleaseItem.Create().
+			SetReleaseID(rel.ID).
+			SetEntityType(*et).
+			SetEntityID(req.GetEntityId()).
+			SetChanges(req.GetChanges()).
+			SetNillableTenantID(rel.TenantID).
+			SetCreatedAt(now).
+			Save(ctx)
+		if err != nil {
+			if ent.IsConstraintError(err) {
+				return nil, contentV1.ErrorConflict("release item was changed concurrently, please retry")
+			}
+			r.log.Errorf("insert release item failed: %s", err.Error())
+			return nil, contentV1.ErrorInternalServerError("insert release item failed")
+		}
+		return r.itemMapper.ToDTO(item), nil
+
+	default:
+		r.log.Errorf("query release item failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query release item failed")
+	}
+}
+
+// RemoveItem 移除发布集条目
+func (r *ReleaseRepo) RemoveItem(ctx context.Context, releaseID, itemID uint32) error {
+	if _, err := r.getEditable(ctx, releaseID); err != nil {
+		return err
+	}
+
+	affected, err := r.entClient.Client().ReleaseItem.Delete().
+		Where(releaseitem.IDEQ(itemID), releaseitem.ReleaseIDEQ(releaseID)).
+		Exec(ctx)
+	if err != nil {
+		r.log.Errorf("delete release item failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("delete release item failed")
+	}
+	if affected == 0 {
+		return contentV1.ErrorNotFound("release item not found")
+	}
+	return nil
+}
+
+// Preview 对比各条目字段的当前值与待发布值，不做任何修改；
+// 内容已删除、字段不合法等无法发布的条目在 error 中说明。
+func (r *ReleaseRepo) Preview(ctx context.Context, id uint32) (*contentV1.PreviewReleaseResponse, error) {
+	rel, err := r.getEntity(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	client := r.entClient.Client()
+	items, err := r.listItems(ctx, client, rel.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &contentV1.PreviewReleaseResponse{
+		Items:       make([]*contentV1.ReleaseItemPreview, 0, len(items)),
+		Publishable: len(items) > 0 && slices.Contains(publishableReleaseStatuses, rel.Status),
+	}
+	for _, item := range items {
+		et := item.EntityType
+		key := releaseEntityKey(et)
+		preview := &contentV1.ReleaseItemPreview{
+			ItemId:   item.ID,
+			EntityId: item.EntityID,
+		}
+		if dtoType := r.entityTypeConverter.ToDTO(&et); dtoType != nil {
+			preview.EntityType = *dtoType
+		}
+		resp.Items = append(resp.Items, preview)
+
+		itemErr := r.checkItem(ctx, client, key, item, rel.TenantID)
+		if itemErr == nil {
+			itemErr = release.Validate(key, item.Changes)
+		}
+
+		var snapshot map[string]*string
+		if itemErr == nil {
+			m, _ := mutationFor(client, key, item.EntityID)
+			snapshot, itemErr = snapshotFields(ctx, m, item.Changes)
+		}
+		if itemErr != nil {
+			if kerrors.IsInternalServer(itemErr) {
+				return nil, itemErr
+			}
+			preview.Error = trans.Ptr(kerrors.FromError(itemErr).GetMessage())
+			resp.Publishable = false
+			continue
+		}
+
+		for _, name := range release.SortedNames(item.Changes) {
+			preview.Diffs = append(preview.Diffs, &contentV1.ReleaseFieldDiff{
+				Field:    name,
+				Current:  snapshot[name],
+				Proposed: item.Changes[name],
+			})
+		}
+	}
+
+	return resp, nil
+}
+
+// Schedule 设置定时发布；at 为 nil 时取消定时，发布集回到草稿
+func (r *ReleaseRepo) Schedule(ctx context.Context, id uint32, at *time.Time) (*contentV1.Release, error) {
+	if _, err := r.getEntity(ctx, id); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	builder := r.entClient.Client().Release.Update().
+		Where(entRelease.IDEQ(id)).
+		SetUpdatedAt(now)
+	if operatorID, ok := viewerUserIDFromContext(ctx); ok {
+		builder.SetUpdatedBy(operatorID)
+	}
+
+	if at == nil {
+		builder.
+			Where(entRelease.StatusEQ(entRelease.StatusReleaseStatusScheduled)).
+			SetStatus(entRelease.StatusReleaseStatusDraft).
+			ClearScheduledAt()
+	} else {
+		if !at.After(now) {
+			return nil, contentV1.ErrorBadRequest("scheduled_at must be in the future")
+		}
+		hasItems, err := r.entClient.Client().ReleaseItem.Query().
+			Where(releaseitem.ReleaseIDEQ(id)).
+			Exist(ctx)
+		if err != nil {
+			r.log.Errorf("query release items failed: %s", err.Error())
+			return nil, contentV1.ErrorInternalServerError("query release items failed")
+		}
+		if !hasItems {
+			return nil, contentV1.ErrorBadRequest("release has no items")
+		}
+
+		builder.
+			Where(entRelease.StatusIn(publishableReleaseStatuses...)).
+			SetStatus(entRelease.StatusReleaseStatusScheduled).
+			SetScheduledAt(*at).
+			ClearLastError()
+	}
+
+	affected, err := builder.Save(ctx)
+	if err != nil {
+		r.log.Errorf("schedule release failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("schedule release failed")
+	}
+	if affected == 0 {
+		if at == nil {
+			return nil, contentV1.ErrorConflict("release is not scheduled")
+		}
+		return nil, contentV1.ErrorConflict("release has already been published")
+	}
+
+	return r.Get(ctx, id)
+}
+
+// Publish 在同一事务中发布全部条目，任一条目失败则整体不生效。
+// 返回发布集与其中文章的 ID（用于重新索引搜索）。
+func (r *ReleaseRepo) Publish(ctx context.Context, id uint32) (*contentV1.Release, []uint32, error) {
+	rel, err := r.getEntity(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var operatorID *uint32
+	if uid, ok := viewerUserIDFromContext(ctx); ok {
+		operatorID = trans.Ptr(uid)
+	}
+
+	var postIDs []uint32
+	err = r.withTx(ctx, func(tx *ent.Tx) error {
+		postIDs = postIDs[:0]
+		now := time.Now()
+
+		// 以条件更新抢占状态，避免并发发布（含定时任务）重复应用
+		claimed, err := tx.Release.Update().
+			Where(entRelease.IDEQ(rel.ID), entRelease.StatusIn(publishableReleaseStatuses...)).
+			SetStatus(entRelease.StatusReleaseStatusPublished).
+			SetPublishedAt(now).
+			SetNillablePublishedBy(operatorID).
+			ClearLastError().
+			ClearRolledBackAt().
+			ClearRolledBackBy().
+			SetUpdatedAt(now).
+			Save(ctx)
+		if err != nil {
+			r.log.Errorf("update release status failed: %s", err.Error())
+			return contentV1.ErrorInternalServerError("update release status failed")
+		}
+		if claimed == 0 {
+			return contentV1.ErrorConflict("release has already been published")
+		}
+
+		client := tx.Client()
+		items, err := r.listItems(ctx, client, rel.ID)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return contentV1.ErrorBadRequest("release has no items")
+		}
+
+		for _, item := range items {
+			key := releaseEntityKey(item.EntityType)
+			if err = r.checkItem(ctx, client, key, item, rel.TenantID); err != nil {
+				return err
+			}
+
+			m, exec := mutationFor(client, key, item.EntityID)
+			snapshot, err := snapshotFields(ctx, m, item.Changes)
+			if err != nil {
+				r.log.Errorf("snapshot %s %d failed: %s", key, item.EntityID, err.Error())
+				return contentV1.ErrorInternalServerError("snapshot release item failed")
+			}
+			if err = setFields(m, key, fromChanges(item.Changes)); err != nil {
+				return contentV1.ErrorBadRequest(fmt.Sprintf("%s %d: %s", key, item.EntityID, err.Error()))
+			}
+			if err = touch(m, now, operatorID); err != nil {
+				return contentV1.ErrorBadRequest(fmt.Sprintf("%s %d: %s", key, item.EntityID, err.Error()))
+			}
+			if err = exec(ctx); err != nil {
+				r.log.Errorf("apply release item %d failed: %s", item.ID, err.Error())
+				return contentV1.ErrorInternalServerError(fmt.Sprintf("apply changes to %s %d failed", key, item.EntityID))
+			}
+
+			if err = client.ReleaseItem.UpdateOneID(item.ID).
+				SetSnapshot(snapshot).
+				SetUpdatedAt(now).
+				Exec(ctx); err != nil {
+				r.log.Errorf("save release item snapshot failed: %s", err.Error())
+				return contentV1.ErrorInternalServerError("save release item snapshot failed")
+			}
+
+			if key == release.EntityPost {
+				postIDs = append(postIDs, item.EntityID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dto, err := r.Get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return dto, postIDs, nil
+}
+
+// Rollback 在同一事务中按快照逆序还原全部条目。
+// 内容在发布后又被修改时拒绝回滚，除非 force 为 true（覆盖之后的修改）。
+func (r *ReleaseRepo) Rollback(ctx context.Context, id uint32, force bool) (*contentV1.Release, []uint32, error) {
+	rel, err := r.getEntity(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if rel.Status != entRelease.StatusReleaseStatusPublished || rel.PublishedAt == nil {
+		return nil, nil, contentV1.ErrorConflict("only published releases can be rolled back")
+	}
+	publishedAt := *rel.PublishedAt
+
+	var operatorID *uint32
+	if uid, ok := viewerUserIDFromContext(ctx); ok {
+		operatorID = trans.Ptr(uid)
+	}
+
+	var postIDs []uint32
+	err = r.withTx(ctx, func(tx *ent.Tx) error {
+		postIDs = postIDs[:0]
+		now := time.Now()
+
+		claimed, err := tx.Release.Update().
+			Where(entRelease.IDEQ(rel.ID), entRelease.StatusEQ(entRelease.StatusReleaseStatusPublished)).
+			SetStatus(entRelease.StatusReleaseStatusRolledBack).
+			SetRolledBackAt(now).
+			SetNillableRolledBackBy(operatorID).
+			SetUpdatedAt(now).
+			Save(ctx)
+		if err != nil {
+			r.log.Errorf("update release status failed: %s", err.Error())
+			return contentV1.ErrorInternalServerError("update release status failed")
+		}
+		if claimed == 0 {
+			return contentV1.ErrorConflict("release has already been rolled back")
+		}
+
+		client := tx.Client()
+		items, err := r.listItems(ctx, client, rel.ID)
+		if err != nil {
+			return err
+		}
+		slices.Reverse(items)
+
+		for _, item := range items {
+			if len(item.Snapshot) == 0 {
+				continue
+			}
+
+			key := releaseEntityKey(item.EntityType)
+			if err = r.checkEntity(ctx, client, key, item.EntityID, rel.TenantID); err != nil {
+				return err
+			}
+
+			m, exec := mutationFor(client, key, item.EntityID)
+			if !force {
+				updatedAt, err := m.OldField(ctx, "updated_at")
+				if err != nil {
+					r.log.Errorf("query %s %d failed: %s", key, item.EntityID, err.Error())
+					return contentV1.ErrorInternalServerError(fmt.Sprintf("query %s failed", key))
+				}
+				if t, ok := release.Deref(updatedAt).(time.Time); ok && t.After(publishedAt) {
+					return contentV1.ErrorConflict(fmt.Sprintf("%s %d was modified after the release was published", key, item.EntityID))
+				}
+			}
+
+			if err = setFields(m, key, item.Snapshot); err != nil {
+				return contentV1.ErrorBadRequest(fmt.Sprintf("%s %d: %s", key, item.EntityID, err.Error()))
+			}
+			if err = touch(m, now, operatorID); err != nil {
+				return contentV1.ErrorBadRequest(fmt.Sprintf("%s %d: %s", key, item.EntityID, err.Error()))
+			}
+			if err = exec(ctx); err != nil {
+				r.log.Errorf("restore release item %d failed: %s", item.ID, err.Error())
+				return contentV1.ErrorInternalServerError(fmt.Sprintf("restore %s %d failed", key, item.EntityID))
+			}
+
+			if key == release.EntityPost {
+				postIDs = append(postIDs, item.EntityID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dto, err := r.Get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return dto, postIDs, nil
+}
+
+// ListDue 列出到期待发布的定时发布集（跨租户，由定时任务以 SystemViewer 调用）
+func (r *ReleaseRepo) ListDue(ctx context.Context, now time.Time) ([]uint32, error) {
+	entities, err := r.entClient.Client().Release.Query().
+		Where(
+			entRelease.StatusEQ(entRelease.StatusReleaseStatusScheduled),
+			entRelease.ScheduledAtLTE(now),
+		).
+		Order(ent.Asc(entRelease.FieldScheduledAt), ent.Asc(entRelease.FieldID)).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query due releases failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query due releases failed")
+	}
+
+	ids := make([]uint32, 0, len(entities))
+	for _, e := range entities {
+		ids = append(ids, e.ID)
+	}
+	return ids, nil
+}
+
+// MarkFailed 定时发布失败时记录原因，发布集转为失败状态，修正后可重新定时或立即发布
+func (r *ReleaseRepo) MarkFailed(ctx context.Context, id uint32, cause error) error {
+	msg := cause.Error()
+	if e := kerrors.FromError(cause); e != nil && e.GetMessage() != "" {
+		msg = e.GetMessage()
+	}
+	if len(msg) > 1024 {
+		msg = msg[:1024]
+	}
+
+	if err := r.entClient.Client().Release.Update().
+		Where(entRelease.IDEQ(id), entRelease.StatusEQ(entRelease.StatusReleaseStatusScheduled)).
+		SetStatus(entRelease.StatusReleaseStatusFailed).
+		SetLastError(msg).
+		SetUpdatedAt(time.Now()).
+		Exec(ctx); err != nil {
+		r.log.Errorf("mark release failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("mark release failed")
+	}
+	return nil
+}
@@ -0,0 +1,347 @@
+package data
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/tx7do/go-utils/trans"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	entCrud "github.com/tx7do/go-crud/entgo"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/category"
+	"go-wind-cms/app/core/service/internal/data/ent/categorytranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/page"
+	"go-wind-cms/app/core/service/internal/data/ent/pagetranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/post"
+	"go-wind-cms/app/core/service/internal/data/ent/posttranslation"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/route"
+)
+
+// RouteRepo 前台路径解析：把 (租户, 语言, 路径) 映射到帖子、页面或分类的某个翻译
+type RouteRepo struct {
+	entClient *entCrud.EntClient[*ent.Client]
+	log       *log.Helper
+
+	redirectRepo *RedirectRepo
+}
+
+func NewRouteRepo(ctx *bootstrap.Context, entClient *entCrud.EntClient[*ent.Client], redirectRepo *RedirectRepo) *RouteRepo {
+	return &RouteRepo{
+		entClient:    entClient,
+		log:          ctx.NewLoggerHelper("route/repo/core-service"),
+		redirectRepo: redirectRepo,
+	}
+}
+
+// routeMatch 命中的内容翻译
+type routeMatch struct {
+	contentType   contentV1.ContentType
+	id            uint32
+	translationID uint32
+	languageCode  string
+}
+
+// ResolvePath 解析前台路径。
+//
+// 顺序：翻译完整路径精确匹配（帖子 → 页面 → 分类）→ 层级页面 slug 逐级匹配 → 重定向规则。
+// 只解析已发布的帖子与页面、启用的分类；未命中任何内容与规则时返回 404。
+func (r *RouteRepo) ResolvePath(ctx context.Context, req *contentV1.ResolvePathRequest) (*contentV1.ResolvePathResponse, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	path, err := route.NormalizePath(req.GetPath())
+	if err != nil {
+		return nil, contentV1.ErrorBadRequest("invalid path")
+	}
+	locale := req.GetLocale()
+	tid, hasTenant := maybeTenantFromViewer(ctx)
+
+	resolvers := []func(context.Context, uint32, bool, string, string) (*routeMatch, error){
+		r.matchPostPath,
+		r.matchPagePath,
+		r.matchCategoryPath,
+		r.matchPageHierarchy,
+	}
+	for _, resolve := range resolvers {
+		m, err := resolve(ctx, tid, hasTenant, locale, path)
+		if err != nil {
+			return nil, err
+		}
+		if m != nil {
+			return &contentV1.ResolvePathResponse{
+				ContentType:   m.contentType,
+				Id:            m.id,
+				TranslationId: m.translationID,
+				LanguageCode:  m.languageCode,
+				Path:          path,
+			}, nil
+		}
+	}
+
+	rule, target, err := r.redirectRepo.Match(ctx, tid, locale, path)
+	if err != nil {
+		return nil, err
+	}
+	if rule != nil {
+		return &contentV1.ResolvePathResponse{
+			Path:       path,
+			RedirectTo: trans.Ptr(target),
+			StatusCode: trans.Ptr(rule.StatusCode),
+		}, nil
+	}
+
+	return nil, contentV1.ErrorNotFound("path not found")
+}
+
+func (r *RouteRepo) matchPostPath(ctx context.Context, tid uint32, hasTenant bool, locale, path string) (*routeMatch, error) {
+	builder := r.entClient.Client().PostTranslation.Query().
+		Where(
+			posttranslation.FullPathEQ(path),
+			// 草稿翻译待审阅，其地址不对外解析
+			posttranslation.Or(posttranslation.IsDraftIsNil(), posttranslation.IsDraftEQ(false)),
+		).
+		Order(ent.Asc(posttranslation.FieldID))
+	if locale != "" {
+		builder.Where(posttranslation.LanguageCodeEQ(locale))
+	}
+	if hasTenant {
+		builder.Where(posttranslation.TenantIDEQ(tid))
+	}
+	translations, err := builder.All(ctx)
+	if err != nil {
+		r.log.Errorf("query post translations by path failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("resolve path failed")
+	}
+	if len(translations) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uint32, 0, len(translations))
+	for _, t := range translations {
+		ids = append(ids, trans.Uint32Value(t.PostID))
+	}
+	published, err := r.entClient.Client().Post.Query().
+		Where(post.IDIn(ids...), post.StatusEQ(post.StatusPostStatusPublished)).
+		IDs(ctx)
+	if err != nil {
+		r.log.Errorf("query published posts failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("resolve path failed")
+	}
+
+	for _, t := range translations {
+		if slices.Contains(published, trans.Uint32Value(t.PostID)) {
+			return &routeMatch{
+				contentType:   contentV1.ContentType_CONTENT_TYPE_POST,
+				id:            trans.Uint32Value(t.PostID),
+				translationID: t.ID,
+				languageCode:  trans.StringValue(t.LanguageCode),
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *RouteRepo) matchPagePath(ctx context.Context, tid uint32, hasTenant bool, locale, path string) (*routeMatch, error) {
+	builder := r.entClient.Client().PageTranslation.Query().
+		Where(
+			pagetranslation.FullPathEQ(path),
+			// 草稿翻译待审阅，其地址不对外解析
+			pagetranslation.Or(pagetranslation.IsDraftIsNil(), pagetranslation.IsDraftEQ(false)),
+		).
+		Order(ent.Asc(pagetranslation.FieldID))
+	if locale != "" {
+		builder.Where(pagetranslation.LanguageCodeEQ(locale))
+	}
+	if hasTenant {
+		builder.Where(pagetranslation.TenantIDEQ(tid))
+	}
+	translations, err := builder.All(ctx)
+	if err != nil {
+		r.log.Errorf("query page translations by path failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("resolve path failed")
+	}
+	if len(translations) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uint32, 0, len(translations))
+	for _, t := range translations {
+		ids = append(ids, trans.Uint32Value(t.PageID))
+	}
+	published, err := r.entClient.Client().Page.Query().
+		Where(page.IDIn(ids...), page.StatusEQ(page.StatusPageStatusPublished), page.DeletedAtIsNil()).
+		IDs(ctx)
+	if err != nil {
+		r.log.Errorf("query published pages failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("resolve path failed")
+	}
+
+	for _, t := range translations {
+		if slices.Contains(published, trans.Uint32Value(t.PageID)) {
+			return &routeMatch{
+				contentType:   contentV1.ContentType_CONTENT_TYPE_PAGE,
+				id:            trans.Uint32Value(t.PageID),
+				translationID: t.ID,
+				languageCode:  trans.StringValue(t.LanguageCode),
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *RouteRepo) matchCategoryPath(ctx context.Context, tid uint32, hasTenant bool, locale, path string) (*routeMatch, error) {
+	builder := r.entClient.Client().CategoryTranslation.Query().
+		Where(
+			categorytranslation.FullPathEQ(path),
+			// 草稿翻译待审阅，其地址不对外解析
+			categorytranslation.Or(categorytranslation.IsDraftIsNil(), categorytranslation.IsDraftEQ(false)),
+		).
+		Order(ent.Asc(categorytranslation.FieldID))
+	if locale != "" {
+		builder.Where(categorytranslation.LanguageCodeEQ(locale))
+	}
+	if hasTenant {
+		builder.Where(categorytranslation.TenantIDEQ(tid))
+	}
+	translations, err := builder.All(ctx)
+	if err != nil {
+		r.log.Errorf("query category translations by path failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("resolve path failed")
+	}
+	if len(translations) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uint32, 0, len(translations))
+	for _, t := range translations {
+		ids = append(ids, trans.Uint32Value(t.CategoryID))
+	}
+	active, err := r.entClient.Client().Category.Query().
+		Where(category.IDIn(ids...), category.StatusEQ(category.StatusCategoryStatusActive), category.DeletedAtIsNil()).
+		IDs(ctx)
+	if err != nil {
+		r.log.Errorf("query active categories failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("resolve path failed")
+	}
+
+	for _, t := range translations {
+		if slices.Contains(active, trans.Uint32Value(t.CategoryID)) {
+			return &routeMatch{
+				contentType:   contentV1.ContentType_CONTENT_TYPE_CATEGORY,
+				id:            trans.Uint32Value(t.CategoryID),
+				translationID: t.ID,
+				languageCode:  trans.StringValue(t.LanguageCode),
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+// matchPageHierarchy 按路径片段逐级匹配页面树：第 i 段须是第 i-1 段页面的子页面的 slug。
+// 首段与请求语言相同时视为语言前缀并跳过；片段先匹配翻译 slug，再匹配页面主表 slug。
+func (r *RouteRepo) matchPageHierarchy(ctx context.Context, tid uint32, hasTenant bool, locale, path string) (*routeMatch, error) {
+	segments := route.Segments(path)
+	if len(segments) > 0 && locale != "" && strings.EqualFold(segments[0], locale) {
+		segments = segments[1:]
+	}
+	if len(segments) == 0 {
+		return nil, nil
+	}
+
+	var (
+		parentID    *uint32
+		current     uint32
+		translation *ent.PageTranslation
+	)
+	for _, seg := range segments {
+		tq := r.entClient.Client().PageTranslation.Query().
+			Where(
+				pagetranslation.SlugEQ(seg),
+				// 草稿翻译待审阅，其 slug 不对外解析
+				pagetranslation.Or(pagetranslation.IsDraftIsNil(), pagetranslation.IsDraftEQ(false)),
+			)
+		if locale != "" {
+			tq.Where(pagetranslation.LanguageCodeEQ(locale))
+		}
+		if hasTenant {
+			tq.Where(pagetranslation.TenantIDEQ(tid))
+		}
+		translations, err := tq.All(ctx)
+		if err != nil {
+			r.log.Errorf("query page translations by slug failed: %s", err.Error())
+			return nil, contentV1.ErrorInternalServerError("resolve path failed")
+		}
+
+		candidates := make([]uint32, 0, len(translations))
+		for _, t := range translations {
+			candidates = append(candidates, trans.Uint32Value(t.PageID))
+		}
+
+		pq := r.entClient.Client().Page.Query().
+			Where(
+				page.Or(page.IDIn(candidates...), page.SlugEQ(seg)),
+				page.StatusEQ(page.StatusPageStatusPublished),
+				page.DeletedAtIsNil(),
+			).
+			Order(ent.Asc(page.FieldID))
+		if parentID == nil {
+			pq.Where(page.ParentIDIsNil())
+		} else {
+			pq.Where(page.ParentIDEQ(*parentID))
+		}
+		if hasTenant {
+			pq.Where(page.TenantIDEQ(tid))
+		}
+		id, err := pq.FirstID(ctx)
+		if err != nil {
+			if ent.IsNotFound(err) {
+				return nil, nil
+			}
+			r.log.Errorf("query page by slug failed: %s", err.Error())
+			return nil, contentV1.ErrorInternalServerError("resolve path failed")
+		}
+
+		current = id
+		parentID = trans.Ptr(id)
+		translation = nil
+		for _, t := range translations {
+			if trans.Uint32Value(t.PageID) == id {
+				translation = t
+				break
+			}
+		}
+	}
+
+	m := &routeMatch{
+		contentType: contentV1.ContentType_CONTENT_TYPE_PAGE,
+		id:          current,
+	}
+	if translation == nil && locale != "" {
+		// 末段按页面主表 slug 命中时，补取请求语言的翻译
+		t, err := r.entClient.Client().PageTranslation.Query().
+			Where(
+				pagetranslation.PageIDEQ(current),
+				pagetranslation.LanguageCodeEQ(locale),
+				pagetranslation.Or(pagetranslation.IsDraftIsNil(), pagetranslation.IsDraftEQ(false)),
+			).
+			First(ctx)
+		if err != nil && !ent.IsNotFound(err) {
+			r.log.Errorf("query page translation failed: %s", err.Error())
+			return nil, contentV1.ErrorInternalServerError("resolve path failed")
+		}
+		translation = t
+	}
+	if translation != nil {
+		m.translationID = translation.ID
+		m.languageCode = trans.StringValue(translation.LanguageCode)
+	}
+	return m, nil
+}
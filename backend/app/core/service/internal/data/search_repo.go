@@ -282,14 +282,92 @@ func (r *SearchRepo) SearchPosts(
 		},
 	}
 
+	return r.search(ctx, dsl)
+}
+
+// MoreLikeThisPosts 以某篇文章的同语言文档为样本，查找文本相似的已索引文章（相关文章推荐）。
+//
+// 安全保证与 SearchPosts 一致：
+//   - tenantID==0 / language / status 空 → 返回空
+//   - bool.filter 必带 term{tenant_id} + term{language} + term{status}，样本文章自身被排除
+//   - 样本文档不存在（未发布或尚未索引）时 more_like_this 无匹配，返回空
+func (r *SearchRepo) MoreLikeThisPosts(
+	ctx context.Context,
+	postID uint32,
+	tenantID uint32,
+	language string,
+	status string,
+	size int,
+) (*PostSearchResult, error) {
+	result := &PostSearchResult{}
+
+	if r.esClient == nil {
+		return result, errors.New("elasticsearch client is nil")
+	}
+
+	if tenantID == 0 || postID == 0 {
+		return result, nil
+	}
+	if language == "" || status == "" {
+		return result, nil
+	}
+
+	if size <= 0 {
+		size = 20
+	}
+	if size > maxSearchPageSize {
+		size = maxSearchPageSize
+	}
+
+	tidStr := strconv.FormatUint(uint64(tenantID), 10)
+	pidStr := strconv.FormatUint(uint64(postID), 10)
+
+	dsl := map[string]any{
+		"size": size,
+		"query": map[string]any{
+			"bool": map[string]any{
+				"filter": []any{
+					map[string]any{"term": map[string]any{"tenant_id": tidStr}},
+					map[string]any{"term": map[string]any{"language": language}},
+					map[string]any{"term": map[string]any{"status": status}},
+				},
+				"must_not": []any{
+					map[string]any{"term": map[string]any{"post_id": pidStr}},
+				},
+				"must": []any{
+					map[string]any{
+						"more_like_this": map[string]any{
+							"fields": []string{"title", "summary", "content"},
+							"like": []any{
+								map[string]any{"_index": searchIndexName, "_id": pidStr + "_" + language},
+							},
+							"min_term_freq":        1,
+							"min_doc_freq":         2,
+							"max_query_terms":      25,
+							"minimum_should_match": "30%",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return r.search(ctx, dsl)
+}
+
+// search 执行查询 DSL，结果只回传 post_id / language / title。
+//
+// 调用 raw OpenSearch client（绕过 go-crud Search 的 Lucene query string 封装，
+// 因为后者不支持 multi_match / more_like_this 且注入风险高）。
+func (r *SearchRepo) search(ctx context.Context, dsl map[string]any) (*PostSearchResult, error) {
+	result := &PostSearchResult{}
+
 	bodyBytes, err := json.Marshal(dsl)
 	if err != nil {
 		r.log.Errorf("marshal search DSL failed: %v", err)
 		return result, err
 	}
 
-	// 调用 raw OpenSearch client（绕过 go-crud Search 的 Lucene query string 封装，
-	// 因为后者不支持 multi_match 且注入风险高）
 	searchReq := &opensearchapiV4.SearchReq{
 		Indices: []string{searchIndexName},
 		Body:    bytes.NewReader(bodyBytes),
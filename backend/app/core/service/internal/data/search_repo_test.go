@@ -147,3 +147,38 @@ func TestSearchRepo_NoBypassForZeroTenant(t *testing.T) {
 	// 清理
 	require.NoError(t, repo.DeletePost(ctx, 99003))
 }
+
+func TestSearchRepo_MoreLikeThisTenantIsolation(t *testing.T) {
+	repo := newTestSearchRepo(t)
+	ctx := context.Background()
+	require.NoError(t, repo.EnsureIndexTemplate(ctx))
+
+	docs := []*PostDocument{
+		{TenantID: "1", PostID: "99011", Title: "相关推荐测试 开源内容管理", Content: "开源内容管理系统 相关推荐 样本文章"},
+		{TenantID: "1", PostID: "99012", Title: "相关推荐测试 开源内容管理", Content: "开源内容管理系统 相关推荐 同租户"},
+		{TenantID: "2", PostID: "99013", Title: "相关推荐测试 开源内容管理", Content: "开源内容管理系统 相关推荐 其他租户"},
+	}
+	for _, doc := range docs {
+		doc.Language = "zh"
+		doc.Status = "POST_STATUS_PUBLISHED"
+		require.NoError(t, repo.IndexPost(ctx, doc))
+	}
+	time.Sleep(2 * time.Second)
+
+	result, err := repo.MoreLikeThisPosts(ctx, 99011, 1, "zh", "POST_STATUS_PUBLISHED", 10)
+	require.NoError(t, err)
+	for _, hit := range result.Hits {
+		assert.NotEqual(t, "99011", hit.PostID, "样本文章自身不应出现在结果中")
+		assert.NotEqual(t, "99013", hit.PostID, "不应返回其他租户的文章")
+	}
+
+	// tid==0 应返回空（不接受 SystemViewer bypass）
+	result2, err := repo.MoreLikeThisPosts(ctx, 99011, 0, "zh", "POST_STATUS_PUBLISHED", 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result2.Total, "tid==0 必须返回空，不接受 bypass")
+
+	// 清理
+	for _, id := range []uint32{99011, 99012, 99013} {
+		require.NoError(t, repo.DeletePost(ctx, id))
+	}
+}
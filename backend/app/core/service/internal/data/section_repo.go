@@ -2,6 +2,10 @@ package data
 
 import (
 	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strconv"
 	"time"
 
 	"entgo.io/ent/dialect/sql"
@@ -16,10 +20,15 @@ import (
 	"github.com/tx7do/go-utils/trans"
 
 	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/form"
+	"go-wind-cms/app/core/service/internal/data/ent/pagetranslation"
 	"go-wind-cms/app/core/service/internal/data/ent/predicate"
 	"go-wind-cms/app/core/service/internal/data/ent/section"
+	"go-wind-cms/app/core/service/internal/data/ent/sectiontranslation"
 
 	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/sectionconfig"
 )
 
 type SectionRepo struct {
@@ -40,12 +49,14 @@ type SectionRepo struct {
 	typeConverter *mapper.EnumTypeConverter[contentV1.SectionType, section.Type]
 
 	sectionTranslationRepo *SectionTranslationRepo
+	localeResolver         *LocaleResolver
 }
 
 func NewSectionRepo(
 	ctx *bootstrap.Context,
 	entClient *entCrud.EntClient[*ent.Client],
 	sectionTranslationRepo *SectionTranslationRepo,
+	localeResolver *LocaleResolver,
 ) *SectionRepo {
 	repo := &SectionRepo{
 		entClient: entClient,
@@ -55,6 +66,7 @@ func NewSectionRepo(
 			contentV1.SectionType_name, contentV1.SectionType_value,
 		),
 		sectionTranslationRepo: sectionTranslationRepo,
+		localeResolver:         localeResolver,
 	}
 
 	repo.init()
@@ -78,6 +90,241 @@ func (r *SectionRepo) init() {
 	r.mapper.AppendConverters(r.typeConverter.NewConverterPair())
 }
 
+// validateConfig 按区块类型的 JSON Schema 校验配置
+func (r *SectionRepo) validateConfig(sectionType section.Type, config map[string]string) error {
+	if err := sectionconfig.Validate(string(sectionType), config); err != nil {
+		if errors.Is(err, sectionconfig.ErrUnknownType) {
+			return contentV1.ErrorBadRequest("unknown section type %s", sectionType)
+		}
+		return contentV1.ErrorBadRequest("invalid section config: %s", err.Error())
+	}
+	return nil
+}
+
+// checkForm 表单区块引用的表单须存在于调用方租户下
+func (r *SectionRepo) checkForm(ctx context.Context, formID string) error {
+	id, err := strconv.ParseUint(formID, 10, 32)
+	if err != nil || id == 0 {
+		return contentV1.ErrorBadRequest("invalid form id %s", formID)
+	}
+
+	builder := r.entClient.Client().Form.Query().
+		Where(form.IDEQ(uint32(id)))
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(form.TenantIDEQ(tid))
+	}
+
+	exist, err := builder.Exist(ctx)
+	if err != nil {
+		r.log.Errorf("query form failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("query form failed")
+	}
+	if !exist {
+		return contentV1.ErrorBadRequest("form %d not found", id)
+	}
+	return nil
+}
+
+// getBlock 查询可被引用的可复用区块：须为调用方租户下未删除的可复用区块
+func (r *SectionRepo) getBlock(ctx context.Context, blockID uint32) (*ent.Section, error) {
+	builder := r.entClient.Client().Section.Query().
+		Where(
+			section.IDEQ(blockID),
+			section.ReusableEQ(true),
+			section.DeletedAtIsNil(),
+		)
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(section.TenantIDEQ(tid))
+	}
+
+	entity, err := builder.Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorBadRequest("reusable block %d not found", blockID)
+		}
+		r.log.Errorf("query reusable block failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query reusable block failed")
+	}
+	return entity, nil
+}
+
+// CountReferences 统计引用可复用区块的未删除区块数
+func (r *SectionRepo) CountReferences(ctx context.Context, client *ent.Client, blockID uint32) (int, error) {
+	count, err := client.Section.Query().
+		Where(section.BlockIDEQ(blockID), section.DeletedAtIsNil()).
+		Count(ctx)
+	if err != nil {
+		r.log.Errorf("count block references failed: %s", err.Error())
+		return 0, contentV1.ErrorInternalServerError("count block references failed")
+	}
+	return count, nil
+}
+
+// sectionState 区块更新后的完整状态，用于校验
+type sectionState struct {
+	pageID   uint32
+	typ      section.Type
+	config   map[string]string
+	reusable bool
+	blockID  uint32
+}
+
+// checkState 校验区块状态：可复用区块不属于页面，引用区块不自带配置，
+// 其余区块的配置须符合其类型的 schema。返回被引用的区块（如有）。
+func (r *SectionRepo) checkState(ctx context.Context, id uint32, st sectionState) (*ent.Section, error) {
+	if st.reusable && st.pageID != 0 {
+		return nil, contentV1.ErrorBadRequest("reusable block cannot belong to a page")
+	}
+
+	if st.blockID == 0 {
+		if err := r.validateConfig(st.typ, st.config); err != nil {
+			return nil, err
+		}
+		if st.typ == section.TypeSectionTypeForm {
+			return nil, r.checkForm(ctx, st.config["form_id"])
+		}
+		return nil, nil
+	}
+
+	if st.reusable {
+		return nil, contentV1.ErrorBadRequest("reusable block cannot reference another block")
+	}
+	if id != 0 && st.blockID == id {
+		return nil, contentV1.ErrorBadRequest("section cannot reference itself")
+	}
+	if len(st.config) > 0 {
+		return nil, contentV1.ErrorBadRequest("section referencing a block cannot have its own config")
+	}
+
+	return r.getBlock(ctx, st.blockID)
+}
+
+// resolveBlocks 将引用可复用区块的区块替换为被引用区块的类型、配置与（可选）翻译，
+// 被引用区块已删除时保持原样
+func (r *SectionRepo) resolveBlocks(ctx context.Context, items []*contentV1.Section, withTranslations bool) error {
+	var blockIDs []uint32
+	for _, item := range items {
+		if item.GetBlockId() != 0 && !slices.Contains(blockIDs, item.GetBlockId()) {
+			blockIDs = append(blockIDs, item.GetBlockId())
+		}
+	}
+	if len(blockIDs) == 0 {
+		return nil
+	}
+
+	blocks, err := r.entClient.Client().Section.Query().
+		Where(
+			section.IDIn(blockIDs...),
+			section.ReusableEQ(true),
+			section.DeletedAtIsNil(),
+		).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query reusable blocks failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("query reusable blocks failed")
+	}
+
+	resolved := make(map[uint32]*contentV1.Section, len(blocks))
+	for _, b := range blocks {
+		dto := r.mapper.ToDTO(b)
+		if dto.AvailableLanguages, err = r.sectionTranslationRepo.ListAvailedLanguages(ctx, b.ID); err != nil {
+			return err
+		}
+		if withTranslations {
+			if dto.Translations, err = r.sectionTranslationRepo.ListTranslations(ctx, b.ID); err != nil {
+				return err
+			}
+		}
+		resolved[b.ID] = dto
+	}
+
+	for _, item := range items {
+		block, ok := resolved[item.GetBlockId()]
+		if !ok {
+			continue
+		}
+		item.Type = block.Type
+		item.Config = block.Config
+		item.AvailableLanguages = block.AvailableLanguages
+		if withTranslations {
+			item.Translations = block.Translations
+		}
+	}
+
+	return nil
+}
+
+// contentSectionID 返回承载区块内容的区块ID：引用可复用区块时为被引用区块，否则为自身
+func (r *SectionRepo) contentSectionID(ctx context.Context, id uint32) (uint32, error) {
+	entity, err := r.entClient.Client().Section.Query().
+		Where(section.IDEQ(id)).
+		Select(section.FieldID, section.FieldBlockID).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return 0, contentV1.ErrorNotFound("section not found")
+		}
+		r.log.Errorf("query section failed: %s", err.Error())
+		return 0, contentV1.ErrorInternalServerError("query section failed")
+	}
+	if entity.BlockID != nil && *entity.BlockID != 0 {
+		return *entity.BlockID, nil
+	}
+	return id, nil
+}
+
+// bumpPageTranslations 区块某语言的内容修改后，递增所属页面该语言版本的修订号；
+// 可复用区块则递增所有引用它的页面，依据这些语言版本的译文随之过期
+func (r *SectionRepo) bumpPageTranslations(ctx context.Context, client *ent.Client, sectionID uint32, languageCode string) error {
+	entity, err := client.Section.Query().
+		Where(section.IDEQ(sectionID)).
+		Select(section.FieldID, section.FieldPageID, section.FieldReusable).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil
+		}
+		r.log.Errorf("query section failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("query section failed")
+	}
+
+	var pageIDs []uint32
+	if entity.PageID != nil && *entity.PageID != 0 {
+		pageIDs = append(pageIDs, *entity.PageID)
+	}
+	if trans.BoolValue(entity.Reusable) {
+		var refs []uint32
+		if err = client.Section.Query().
+			Where(section.BlockIDEQ(sectionID), section.DeletedAtIsNil(), section.PageIDNotNil()).
+			Unique(true).
+			Select(section.FieldPageID).
+			Scan(ctx, &refs); err != nil {
+			r.log.Errorf("query block references failed: %s", err.Error())
+			return contentV1.ErrorInternalServerError("query block references failed")
+		}
+		pageIDs = append(pageIDs, refs...)
+	}
+	if len(pageIDs) == 0 {
+		return nil
+	}
+
+	ids, err := client.PageTranslation.Query().
+		Where(pagetranslation.PageIDIn(pageIDs...), pagetranslation.LanguageCodeEQ(languageCode)).
+		IDs(ctx)
+	if err != nil {
+		r.log.Errorf("query page translations failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("query page translations failed")
+	}
+	for _, id := range ids {
+		if _, err = bumpPageTranslationRevision(ctx, client, id); err != nil {
+			r.log.Errorf("bump page translation revision failed: %s", err.Error())
+			return contentV1.ErrorInternalServerError("bump page translation revision failed")
+		}
+	}
+
+	return nil
+}
+
 func (r *SectionRepo) IsExist(ctx context.Context, id uint32) (bool, error) {
 	exist, err := r.entClient.Client().Section.Query().
 		Where(section.IDEQ(id)).
@@ -94,7 +341,8 @@ func (r *SectionRepo) List(ctx context.Context, req *paginationV1.PagingRequest)
 		return nil, contentV1.ErrorBadRequest("invalid parameter")
 	}
 
-	builder := r.entClient.Client().Section.Query()
+	builder := r.entClient.Client().Section.Query().
+		Where(section.DeletedAtIsNil())
 
 	ret, err := r.repository.ListWithPaging(ctx, builder, builder.Clone(), req)
 	if err != nil {
@@ -113,6 +361,10 @@ func (r *SectionRepo) List(ctx context.Context, req *paginationV1.PagingRequest)
 		item.AvailableLanguages = languages
 	}
 
+	if err = r.resolveBlocks(ctx, ret.Items, false); err != nil {
+		return nil, err
+	}
+
 	return &contentV1.ListSectionResponse{
 		Total: ret.Total,
 		Items: ret.Items,
@@ -124,7 +376,8 @@ func (r *SectionRepo) Get(ctx context.Context, req *contentV1.GetSectionRequest)
 		return nil, contentV1.ErrorBadRequest("invalid parameter")
 	}
 
-	builder := r.entClient.Client().Section.Query()
+	builder := r.entClient.Client().Section.Query().
+		Where(section.DeletedAtIsNil())
 
 	switch req.QueryBy.(type) {
 	case *contentV1.GetSectionRequest_Id:
@@ -160,6 +413,25 @@ func (r *SectionRepo) Get(ctx context.Context, req *contentV1.GetSectionRequest)
 	}
 	dto.AvailableLanguages = languages
 
+	if err = r.resolveBlocks(ctx, []*contentV1.Section{dto}, true); err != nil {
+		return nil, err
+	}
+
+	// 指定语言时只保留按回退链选出的翻译；引用可复用区块时按被引用区块的翻译选择
+	if req.Locale != nil {
+		served := r.localeResolver.Serve(ctx, req.GetLocale(), dto.AvailableLanguages)
+		var kept []*contentV1.SectionTranslation
+		for _, t := range dto.Translations {
+			if served != "" && t.GetLanguageCode() == served {
+				kept = append(kept, t)
+			}
+		}
+		dto.Translations = kept
+		if served != "" {
+			dto.ServedLocale = trans.Ptr(served)
+		}
+	}
+
 	return dto, nil
 }
 
@@ -168,6 +440,24 @@ func (r *SectionRepo) Create(ctx context.Context, req *contentV1.CreateSectionRe
 		return nil, contentV1.ErrorBadRequest("invalid parameter")
 	}
 
+	st := sectionState{
+		pageID:   req.Data.GetPageId(),
+		typ:      section.DefaultType,
+		config:   req.Data.GetConfig(),
+		reusable: req.Data.GetReusable(),
+		blockID:  req.Data.GetBlockId(),
+	}
+	if t := r.typeConverter.ToEntity(req.Data.Type); t != nil {
+		st.typ = *t
+	}
+	var block *ent.Section
+	if block, err = r.checkState(ctx, 0, st); err != nil {
+		return nil, err
+	}
+	if block != nil && len(req.Data.Translations) > 0 {
+		return nil, contentV1.ErrorBadRequest("section referencing a block cannot have its own translations")
+	}
+
 	var tx *ent.Tx
 	tx, err = r.entClient.Client().Tx(ctx)
 	if err != nil {
@@ -192,9 +482,16 @@ func (r *SectionRepo) Create(ctx context.Context, req *contentV1.CreateSectionRe
 		SetNillableType(r.typeConverter.ToEntity(req.Data.Type)).
 		SetNillableName(req.Data.Name).
 		SetNillableSortOrder(req.Data.SortOrder).
+		SetNillableReusable(req.Data.Reusable).
+		SetNillableBlockID(req.Data.BlockId).
 		SetNillableCreatedBy(req.Data.CreatedBy).
 		SetCreatedAt(time.Now())
 
+	// 引用区块的类型与被引用区块一致，便于按类型筛选
+	if block != nil {
+		builder.SetNillableType(block.Type)
+	}
+
 	if req.Data.Config != nil {
 		builder.SetConfig(trans.Ptr(req.Data.GetConfig()))
 	}
@@ -219,6 +516,11 @@ func (r *SectionRepo) Create(ctx context.Context, req *contentV1.CreateSectionRe
 			r.log.Errorf("batch insert translations failed: %s", err.Error())
 			return nil, contentV1.ErrorInternalServerError("batch insert translations failed")
 		}
+		for _, t := range req.Data.GetTranslations() {
+			if err = r.bumpPageTranslations(ctx, tx.Client(), entity.ID, t.GetLanguageCode()); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	return r.mapper.ToDTO(entity), nil
@@ -243,6 +545,11 @@ func (r *SectionRepo) Update(ctx context.Context, req *contentV1.UpdateSectionRe
 		}
 	}
 
+	block, err := r.checkUpdate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
 	var tx *ent.Tx
 	tx, err = r.entClient.Client().Tx(ctx)
 	if err != nil {
@@ -271,12 +578,17 @@ func (r *SectionRepo) Update(ctx context.Context, req *contentV1.UpdateSectionRe
 			r.log.Errorf("batch insert translations failed: %s", err.Error())
 			return nil, contentV1.ErrorInternalServerError("batch insert translations failed")
 		}
+		for _, t := range req.Data.GetTranslations() {
+			if err = r.bumpPageTranslations(ctx, tx.Client(), req.GetId(), t.GetLanguageCode()); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	tid, hasTenant := maybeTenantFromViewer(ctx)
 	callerUserID, hasUser := viewerUserIDFromContext(ctx)
 	builder := tx.Section.UpdateOneID(req.GetId())
-	builder.Where(section.IDEQ(req.GetId()))
+	builder.Where(section.IDEQ(req.GetId()), section.DeletedAtIsNil())
 	if hasTenant {
 		builder.Where(section.TenantIDEQ(tid))
 	}
@@ -287,6 +599,8 @@ func (r *SectionRepo) Update(ctx context.Context, req *contentV1.UpdateSectionRe
 				SetNillableType(r.typeConverter.ToEntity(req.Data.Type)).
 				SetNillableName(req.Data.Name).
 				SetNillableSortOrder(req.Data.SortOrder).
+				SetNillableReusable(req.Data.Reusable).
+				SetNillableBlockID(req.Data.BlockId).
 				SetUpdatedAt(time.Now())
 
 			// updated_by 强制由服务端 viewer context 推导，忽略客户端传入值
@@ -297,6 +611,11 @@ func (r *SectionRepo) Update(ctx context.Context, req *contentV1.UpdateSectionRe
 			if req.Data.Config != nil {
 				builder.SetConfig(trans.Ptr(req.Data.GetConfig()))
 			}
+
+			// 改为引用可复用区块时，类型随被引用区块，自身配置不再生效
+			if block != nil {
+				builder.SetNillableType(block.Type).ClearConfig()
+			}
 		},
 		func(s *sql.Selector) {
 			s.Where(sql.EQ(section.FieldID, req.GetId()))
@@ -306,16 +625,106 @@ func (r *SectionRepo) Update(ctx context.Context, req *contentV1.UpdateSectionRe
 	return result, err
 }
 
-func (r *SectionRepo) Delete(ctx context.Context, req *contentV1.DeleteSectionRequest) (err error) {
-	if req == nil {
-		return contentV1.ErrorBadRequest("invalid parameter")
+// checkUpdate 合并现有区块与更新内容后校验；未涉及页面、类型、配置与复用相关字段时不做查询。
+// 返回更新后引用的可复用区块（如有）。
+func (r *SectionRepo) checkUpdate(ctx context.Context, req *contentV1.UpdateSectionRequest) (*ent.Section, error) {
+	paths := req.GetUpdateMask().GetPaths()
+	touched := func(field string, set bool) bool {
+		return set || slices.Contains(paths, field)
+	}
+
+	pageTouched := touched("page_id", req.Data.PageId != nil)
+	typeTouched := touched("type", req.Data.Type != nil)
+	configTouched := touched("config", req.Data.Config != nil)
+	reusableTouched := touched("reusable", req.Data.Reusable != nil)
+	blockTouched := touched("block_id", req.Data.BlockId != nil)
+	if !pageTouched && !typeTouched && !configTouched && !reusableTouched && !blockTouched {
+		return nil, nil
+	}
+
+	builder := r.entClient.Client().Section.Query().
+		Where(section.IDEQ(req.GetId()), section.DeletedAtIsNil())
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(section.TenantIDEQ(tid))
+	}
+	entity, err := builder.Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound("section not found")
+		}
+		r.log.Errorf("query section failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query section failed")
+	}
+
+	st := sectionState{
+		pageID:   trans.Uint32Value(entity.PageID),
+		typ:      section.DefaultType,
+		reusable: entity.Reusable != nil && *entity.Reusable,
+		blockID:  trans.Uint32Value(entity.BlockID),
+	}
+	if entity.Type != nil {
+		st.typ = *entity.Type
+	}
+	if entity.Config != nil {
+		st.config = *entity.Config
+	}
+
+	if pageTouched {
+		st.pageID = req.Data.GetPageId()
+	}
+	if typeTouched {
+		st.typ = ""
+		if t := r.typeConverter.ToEntity(req.Data.Type); t != nil {
+			st.typ = *t
+		}
+	}
+	if reusableTouched {
+		st.reusable = req.Data.GetReusable()
+	}
+	if blockTouched {
+		st.blockID = req.Data.GetBlockId()
+	}
+	switch {
+	case configTouched:
+		st.config = req.Data.GetConfig()
+	case st.blockID != 0:
+		// 引用区块的自身配置会被清除
+		st.config = nil
+	}
+
+	// 仍被引用的可复用区块不能取消复用
+	if entity.Reusable != nil && *entity.Reusable && !st.reusable {
+		count, err := r.CountReferences(ctx, r.entClient.Client(), entity.ID)
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, contentV1.ErrorConflict("reusable block is still referenced by %d sections", count)
+		}
+	}
+
+	block, err := r.checkState(ctx, entity.ID, st)
+	if err != nil {
+		return nil, err
+	}
+	if !blockTouched {
+		// 引用未变化时无需同步类型
+		return nil, nil
+	}
+	return block, nil
+}
+
+// Reorder 按给定顺序重排页面下的区块，ids 须恰好列出页面下全部未删除的区块
+func (r *SectionRepo) Reorder(ctx context.Context, pageID uint32, ids []uint32) (resp *contentV1.ListSectionResponse, err error) {
+	if pageID == 0 || len(ids) == 0 {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
 	}
 
 	var tx *ent.Tx
 	tx, err = r.entClient.Client().Tx(ctx)
 	if err != nil {
 		r.log.Errorf("start transaction failed: %s", err.Error())
-		return contentV1.ErrorInternalServerError("start transaction failed")
+		return nil, contentV1.ErrorInternalServerError("start transaction failed")
 	}
 	defer func() {
 		if err != nil {
@@ -330,18 +739,82 @@ func (r *SectionRepo) Delete(ctx context.Context, req *contentV1.DeleteSectionRe
 		}
 	}()
 
+	tid, hasTenant := maybeTenantFromViewer(ctx)
+	builder := tx.Section.Query().
+		Where(section.PageIDEQ(pageID), section.DeletedAtIsNil())
+	if hasTenant {
+		builder.Where(section.TenantIDEQ(tid))
+	}
+	var existing []uint32
+	if existing, err = builder.IDs(ctx); err != nil {
+		r.log.Errorf("query sections by page id failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query sections by page id failed")
+	}
+
+	// 排序请求须覆盖页面的全部区块且不重复，避免与并发增删的区块交错
+	seen := make(map[uint32]struct{}, len(ids))
+	for _, id := range ids {
+		if _, dup := seen[id]; dup || !slices.Contains(existing, id) {
+			return nil, contentV1.ErrorBadRequest("ids must list every section of the page exactly once")
+		}
+		seen[id] = struct{}{}
+	}
+	if len(seen) != len(existing) {
+		return nil, contentV1.ErrorBadRequest("ids must list every section of the page exactly once")
+	}
+
+	now := time.Now()
+	callerUserID, hasUser := viewerUserIDFromContext(ctx)
+	for i, id := range ids {
+		update := tx.Section.UpdateOneID(id).
+			SetSortOrder(uint32(i + 1)).
+			SetUpdatedAt(now)
+		if hasUser {
+			update.SetUpdatedBy(callerUserID)
+		}
+		if err = update.Exec(ctx); err != nil {
+			r.log.Errorf("update section sort order failed: %s", err.Error())
+			return nil, contentV1.ErrorInternalServerError("update section sort order failed")
+		}
+	}
+
+	var entities []*ent.Section
+	if entities, err = tx.Section.Query().
+		Where(section.IDIn(ids...)).
+		Order(ent.Asc(section.FieldSortOrder)).
+		All(ctx); err != nil {
+		r.log.Errorf("query sections failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query sections failed")
+	}
+
+	items := make([]*contentV1.Section, 0, len(entities))
+	for _, entity := range entities {
+		items = append(items, r.mapper.ToDTO(entity))
+	}
+	if err = r.resolveBlocks(ctx, items, false); err != nil {
+		return nil, err
+	}
+
+	return &contentV1.ListSectionResponse{
+		Total: uint64(len(items)),
+		Items: items,
+	}, nil
+}
+
+// Purge 在给定事务中物理删除区块及其翻译，仅由回收站彻底清理时调用
+func (r *SectionRepo) Purge(ctx context.Context, tx *ent.Tx, id uint32) error {
 	tid, hasTenant := maybeTenantFromViewer(ctx)
 	delBuilder := tx.Section.Delete()
-	delBuilder.Where(section.IDEQ(req.GetId()))
+	delBuilder.Where(section.IDEQ(id))
 	if hasTenant {
 		delBuilder.Where(section.TenantIDEQ(tid))
 	}
-	if _, err = delBuilder.Exec(ctx); err != nil {
+	if _, err := delBuilder.Exec(ctx); err != nil {
 		r.log.Errorf("delete one data failed: %s", err.Error())
 		return contentV1.ErrorInternalServerError("delete one data failed")
 	}
 
-	if err = r.sectionTranslationRepo.CleanTranslations(ctx, tx, req.GetId()); err != nil {
+	if err := r.sectionTranslationRepo.CleanTranslations(ctx, tx, id); err != nil {
 		r.log.Errorf("clean translations failed: %s", err.Error())
 		return contentV1.ErrorInternalServerError("clean translations failed")
 	}
@@ -368,7 +841,16 @@ func (r *SectionRepo) CreateTranslation(ctx context.Context, req *contentV1.Crea
 
 	req.Data.SectionId = trans.Ptr(req.GetSectionId())
 
-	return r.sectionTranslationRepo.CreateTranslation(ctx, req.Data)
+	dto, err := r.sectionTranslationRepo.CreateTranslation(ctx, req.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = r.bumpPageTranslations(ctx, r.entClient.Client(), req.GetSectionId(), req.Data.GetLanguageCode()); err != nil {
+		return nil, err
+	}
+
+	return dto, nil
 }
 
 func (r *SectionRepo) UpdateTranslation(ctx context.Context, req *contentV1.UpdateSectionTranslationRequest) (*contentV1.SectionTranslation, error) {
@@ -397,7 +879,24 @@ func (r *SectionRepo) UpdateTranslation(ctx context.Context, req *contentV1.Upda
 		return nil, contentV1.ErrorFileNotFound("translation not found")
 	}
 
-	return r.sectionTranslationRepo.UpdateTranslation(ctx, req.GetId(), req.Data, req.GetUpdateMask())
+	old, err := r.sectionTranslationRepo.GetTranslation(ctx, req.Data.GetSectionId(), req.Data.GetLanguageCode())
+	if err != nil {
+		return nil, err
+	}
+
+	dto, err := r.sectionTranslationRepo.UpdateTranslation(ctx, req.GetId(), req.Data, req.GetUpdateMask())
+	if err != nil {
+		return nil, err
+	}
+
+	// 区块内容有修改时，所属页面该语言版本的修订号随之递增
+	if !maps.Equal(old.GetContent(), dto.GetContent()) {
+		if err = r.bumpPageTranslations(ctx, r.entClient.Client(), req.Data.GetSectionId(), req.Data.GetLanguageCode()); err != nil {
+			return nil, err
+		}
+	}
+
+	return dto, nil
 }
 
 func (r *SectionRepo) GetTranslation(ctx context.Context, req *contentV1.GetSectionRequest) (*contentV1.SectionTranslation, error) {
@@ -405,7 +904,22 @@ func (r *SectionRepo) GetTranslation(ctx context.Context, req *contentV1.GetSect
 		return nil, contentV1.ErrorBadRequest("invalid parameter")
 	}
 
-	return r.sectionTranslationRepo.GetTranslation(ctx, req.GetId(), req.GetLocale())
+	// 引用可复用区块的区块返回被引用区块的翻译
+	id, err := r.contentSectionID(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	languages, err := r.sectionTranslationRepo.ListAvailedLanguages(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	served := r.localeResolver.Serve(ctx, req.GetLocale(), languages)
+	if served == "" {
+		return nil, contentV1.ErrorNotFound("translation not found")
+	}
+
+	return r.sectionTranslationRepo.GetTranslation(ctx, id, served)
 }
 
 func (r *SectionRepo) ListTranslations(ctx context.Context, sectionID uint32) ([]*contentV1.SectionTranslation, error) {
@@ -420,6 +934,76 @@ func (r *SectionRepo) CleanTranslations(ctx context.Context, tx *ent.Tx, section
 	return r.sectionTranslationRepo.CleanTranslations(ctx, tx, sectionID)
 }
 
+// CopyToPage 在给定事务中将页面下未删除的区块及其翻译复制到另一页面。
+// 引用可复用区块的区块复制后仍引用同一区块。
+func (r *SectionRepo) CopyToPage(ctx context.Context, tx *ent.Tx, srcPageID, dstPageID uint32) error {
+	builder := tx.Section.Query().
+		Where(section.PageIDEQ(srcPageID), section.DeletedAtIsNil())
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(section.TenantIDEQ(tid))
+	}
+	sections, err := builder.Order(ent.Asc(section.FieldSortOrder), ent.Asc(section.FieldID)).All(ctx)
+	if err != nil {
+		r.log.Errorf("query sections by page id failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("query sections by page id failed")
+	}
+
+	now := time.Now()
+	var createdBy *uint32
+	if uid, ok := viewerUserIDFromContext(ctx); ok {
+		createdBy = trans.Ptr(uid)
+	}
+
+	for _, src := range sections {
+		create := tx.Section.Create().
+			SetPageID(dstPageID).
+			SetNillableType(src.Type).
+			SetNillableName(src.Name).
+			SetNillableSortOrder(src.SortOrder).
+			SetNillableBlockID(src.BlockID).
+			SetNillableCreatedBy(createdBy).
+			SetCreatedAt(now)
+		if src.Config != nil {
+			create.SetConfig(src.Config)
+		}
+
+		var copied *ent.Section
+		if copied, err = create.Save(ctx); err != nil {
+			r.log.Errorf("copy section failed: %s", err.Error())
+			return contentV1.ErrorInternalServerError("copy section failed")
+		}
+
+		translations, err := tx.SectionTranslation.Query().
+			Where(sectiontranslation.SectionIDEQ(src.ID)).
+			All(ctx)
+		if err != nil {
+			r.log.Errorf("query section translations failed: %s", err.Error())
+			return contentV1.ErrorInternalServerError("query section translations failed")
+		}
+		if len(translations) == 0 {
+			continue
+		}
+
+		items := make([]*contentV1.SectionTranslation, 0, len(translations))
+		for _, t := range translations {
+			item := &contentV1.SectionTranslation{
+				SectionId:    trans.Ptr(copied.ID),
+				LanguageCode: t.LanguageCode,
+				CreatedBy:    createdBy,
+			}
+			if t.Content != nil {
+				item.Content = *t.Content
+			}
+			items = append(items, item)
+		}
+		if err = r.sectionTranslationRepo.BatchCreate(ctx, tx, items); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // CleanByPageID 删除指定页面下的所有 section 及其翻译。
 // 在页面删除时调用，防止 section 成为孤儿记录。
 func (r *SectionRepo) CleanByPageID(ctx context.Context, tx *ent.Tx, pageID uint32) error {
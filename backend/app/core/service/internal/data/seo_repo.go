@@ -0,0 +1,428 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	entCrud "github.com/tx7do/go-crud/entgo"
+	"github.com/tx7do/go-utils/trans"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/page"
+	"go-wind-cms/app/core/service/internal/data/ent/pagetranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/post"
+	"go-wind-cms/app/core/service/internal/data/ent/posttranslation"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/seo"
+)
+
+// seoDerivedFields 由服务端计算的 SEO 字段，不随 updateMask 直接落库
+var seoDerivedFields = []string{"seo_analysis"}
+
+// SeoRepo SEO 分析：用标题、摘要与缩略图补全文章、页面语言版本缺失的 SEO 字段，计算 SEO 得分并保存到该语言版本。
+type SeoRepo struct {
+	entClient *entCrud.EntClient[*ent.Client]
+	log       *log.Helper
+}
+
+func NewSeoRepo(ctx *bootstrap.Context, entClient *entCrud.EntClient[*ent.Client]) *SeoRepo {
+	return &SeoRepo{
+		entClient: entClient,
+		log:       ctx.NewLoggerHelper("seo/repo/core-service"),
+	}
+}
+
+// seoTarget 待分析的语言版本
+type seoTarget struct {
+	doc          seo.Document
+	meta         *contentV1.SeoMeta
+	lastKeyword  string
+	tenantID     uint32
+	languageCode string
+
+	// excludePostID / excludePageID 统计重复标题时排除自身
+	excludePostID uint32
+	excludePageID uint32
+}
+
+// Analyze 按需分析内容某一语言版本的 SEO 并保存结果
+func (r *SeoRepo) Analyze(ctx context.Context, req *contentV1.AnalyzeSeoRequest) (*contentV1.AnalyzeSeoResponse, error) {
+	client := r.entClient.Client()
+	tid, hasTenant := maybeTenantFromViewer(ctx)
+
+	var (
+		resp *contentV1.AnalyzeSeoResponse
+		err  error
+	)
+	switch req.GetEntityType() {
+	case contentV1.AnalyzeSeoRequest_ENTITY_TYPE_POST:
+		q := client.Post.Query().Where(post.IDEQ(req.GetEntityId()), post.DeletedAtIsNil())
+		if hasTenant {
+			q.Where(post.TenantIDEQ(tid))
+		}
+		if err = r.ensureExists(ctx, q.Exist, req.GetEntityId()); err != nil {
+			return nil, err
+		}
+
+		var t *ent.PostTranslation
+		if t, err = client.PostTranslation.Query().
+			Where(posttranslation.PostIDEQ(req.GetEntityId()), posttranslation.LanguageCodeEQ(req.GetLanguageCode())).
+			First(ctx); err == nil {
+			resp, err = r.refreshPostTranslation(ctx, t, req.GetFocusKeyword())
+		}
+
+	case contentV1.AnalyzeSeoRequest_ENTITY_TYPE_PAGE:
+		q := client.Page.Query().Where(page.IDEQ(req.GetEntityId()), page.DeletedAtIsNil())
+		if hasTenant {
+			q.Where(page.TenantIDEQ(tid))
+		}
+		if err = r.ensureExists(ctx, q.Exist, req.GetEntityId()); err != nil {
+			return nil, err
+		}
+
+		var t *ent.PageTranslation
+		if t, err = client.PageTranslation.Query().
+			Where(pagetranslation.PageIDEQ(req.GetEntityId()), pagetranslation.LanguageCodeEQ(req.GetLanguageCode())).
+			First(ctx); err == nil {
+			resp, err = r.refreshPageTranslation(ctx, t, req.GetFocusKeyword())
+		}
+
+	default:
+		return nil, contentV1.ErrorBadRequest("unsupported entity type")
+	}
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound(fmt.Sprintf("%q version of content %d not found", req.GetLanguageCode(), req.GetEntityId()))
+		}
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *SeoRepo) ensureExists(ctx context.Context, exist func(context.Context) (bool, error), id uint32) error {
+	ok, err := exist(ctx)
+	if err != nil {
+		r.log.Errorf("query content failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("query content failed")
+	}
+	if !ok {
+		return contentV1.ErrorNotFound(fmt.Sprintf("content %d not found", id))
+	}
+	return nil
+}
+
+// RefreshPost 保存文章后重新分析其各语言版本；best-effort，失败仅记日志
+func (r *SeoRepo) RefreshPost(ctx context.Context, postID uint32) {
+	if postID == 0 {
+		return
+	}
+
+	translations, err := r.entClient.Client().PostTranslation.Query().
+		Where(posttranslation.PostIDEQ(postID)).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query post [%d] translations for seo analysis failed: %s", postID, err.Error())
+		return
+	}
+	for _, t := range translations {
+		if _, err = r.refreshPostTranslation(ctx, t, ""); err != nil {
+			r.log.Errorf("seo analysis of post translation [%d] failed: %s", t.ID, err.Error())
+		}
+	}
+}
+
+// RefreshPage 保存页面后重新分析其各语言版本；best-effort，失败仅记日志
+func (r *SeoRepo) RefreshPage(ctx context.Context, pageID uint32) {
+	if pageID == 0 {
+		return
+	}
+
+	translations, err := r.entClient.Client().PageTranslation.Query().
+		Where(pagetranslation.PageIDEQ(pageID)).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query page [%d] translations for seo analysis failed: %s", pageID, err.Error())
+		return
+	}
+	for _, t := range translations {
+		if _, err = r.refreshPageTranslation(ctx, t, ""); err != nil {
+			r.log.Errorf("seo analysis of page translation [%d] failed: %s", t.ID, err.Error())
+		}
+	}
+}
+
+// RefreshPostTranslation 保存文章的一个语言版本后重新分析
+func (r *SeoRepo) RefreshPostTranslation(ctx context.Context, id uint32) (*contentV1.AnalyzeSeoResponse, error) {
+	t, err := r.entClient.Client().PostTranslation.Get(ctx, id)
+	if err != nil {
+		r.log.Errorf("query post translation [%d] for seo analysis failed: %s", id, err.Error())
+		return nil, contentV1.ErrorInternalServerError("query post translation failed")
+	}
+	return r.refreshPostTranslation(ctx, t, "")
+}
+
+// RefreshPageTranslation 保存页面的一个语言版本后重新分析
+func (r *SeoRepo) RefreshPageTranslation(ctx context.Context, id uint32) (*contentV1.AnalyzeSeoResponse, error) {
+	t, err := r.entClient.Client().PageTranslation.Get(ctx, id)
+	if err != nil {
+		r.log.Errorf("query page translation [%d] for seo analysis failed: %s", id, err.Error())
+		return nil, contentV1.ErrorInternalServerError("query page translation failed")
+	}
+	return r.refreshPageTranslation(ctx, t, "")
+}
+
+func (r *SeoRepo) refreshPostTranslation(ctx context.Context, t *ent.PostTranslation, keyword string) (*contentV1.AnalyzeSeoResponse, error) {
+	lang := trans.StringValue(t.LanguageCode)
+
+	canonical := trans.StringValue(t.FullPath)
+	if canonical == "" && trans.StringValue(t.Slug) != "" {
+		canonical = "/" + lang + "/post/" + trans.StringValue(t.Slug)
+	}
+
+	target := &seoTarget{
+		doc: seo.Document{
+			Title:        trans.StringValue(t.Title),
+			Summary:      trans.StringValue(t.Summary),
+			Content:      trans.StringValue(t.Content),
+			Thumbnail:    trans.StringValue(t.Thumbnail),
+			CanonicalURL: canonical,
+		},
+		meta:          t.Seo,
+		lastKeyword:   t.SeoAnalysis.GetFocusKeyword(),
+		tenantID:      trans.Uint32Value(t.TenantID),
+		languageCode:  lang,
+		excludePostID: t.ID,
+	}
+
+	resp, filled, err := r.analyze(ctx, target, keyword)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := r.entClient.Client().PostTranslation.UpdateOneID(t.ID).SetSeoAnalysis(resp.Analysis)
+	if filled {
+		builder.SetSeo(resp.Seo)
+	}
+	if err = builder.Exec(ctx); err != nil {
+		r.log.Errorf("save post translation [%d] seo analysis failed: %s", t.ID, err.Error())
+		return nil, contentV1.ErrorInternalServerError("save seo analysis failed")
+	}
+
+	return resp, nil
+}
+
+func (r *SeoRepo) refreshPageTranslation(ctx context.Context, t *ent.PageTranslation, keyword string) (*contentV1.AnalyzeSeoResponse, error) {
+	lang := trans.StringValue(t.LanguageCode)
+
+	content, err := pageTranslationContent(ctx, r.entClient.Client(), trans.Uint32Value(t.PageID), lang)
+	if err != nil {
+		r.log.Errorf("query page [%d] sections failed: %s", trans.Uint32Value(t.PageID), err.Error())
+		return nil, contentV1.ErrorInternalServerError("query page sections failed")
+	}
+
+	canonical := trans.StringValue(t.FullPath)
+	if canonical == "" && trans.StringValue(t.Slug) != "" {
+		canonical = "/" + lang + "/" + trans.StringValue(t.Slug)
+	}
+
+	thumbnail := trans.StringValue(t.Thumbnail)
+	if thumbnail == "" {
+		thumbnail = trans.StringValue(t.CoverImage)
+	}
+
+	target := &seoTarget{
+		doc: seo.Document{
+			Title:        trans.StringValue(t.Title),
+			Content:      content,
+			Thumbnail:    thumbnail,
+			CanonicalURL: canonical,
+		},
+		meta:          t.Seo,
+		lastKeyword:   t.SeoAnalysis.GetFocusKeyword(),
+		tenantID:      trans.Uint32Value(t.TenantID),
+		languageCode:  lang,
+		excludePageID: t.ID,
+	}
+
+	resp, filled, err := r.analyze(ctx, target, keyword)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := r.entClient.Client().PageTranslation.UpdateOneID(t.ID).SetSeoAnalysis(resp.Analysis)
+	if filled {
+		builder.SetSeo(resp.Seo)
+	}
+	if err = builder.Exec(ctx); err != nil {
+		r.log.Errorf("save page translation [%d] seo analysis failed: %s", t.ID, err.Error())
+		return nil, contentV1.ErrorInternalServerError("save seo analysis failed")
+	}
+
+	return resp, nil
+}
+
+// analyze 补全 SEO 字段并计算得分；焦点关键词依次取请求指定、SEO 关键词第一个、上次分析所用
+func (r *SeoRepo) analyze(ctx context.Context, target *seoTarget, keyword string) (*contentV1.AnalyzeSeoResponse, bool, error) {
+	meta := toSeoMeta(target.meta)
+	filled := seo.Fill(&target.doc, &meta)
+
+	if keyword = strings.TrimSpace(keyword); keyword == "" {
+		keyword = seo.FocusKeyword(meta.MetaKeywords)
+	}
+	if keyword == "" {
+		keyword = target.lastKeyword
+	}
+
+	duplicates, err := r.countDuplicateTitles(ctx, target, meta.SeoTitle)
+	if err != nil {
+		return nil, false, err
+	}
+
+	report := seo.Analyze(&seo.Input{
+		Document:        target.doc,
+		Meta:            meta,
+		Keyword:         keyword,
+		DuplicateTitles: duplicates,
+	})
+
+	analysis := &contentV1.SeoAnalysis{
+		Score:        uint32(report.Score),
+		FilledFields: filled,
+		AnalyzedAt:   timestamppb.New(time.Now()),
+	}
+	if report.Keyword != "" {
+		analysis.FocusKeyword = trans.Ptr(report.Keyword)
+	}
+	for _, c := range report.Checks {
+		analysis.Checks = append(analysis.Checks, &contentV1.SeoCheck{
+			Id:       c.ID,
+			Status:   toSeoCheckStatus(c.Status),
+			Score:    uint32(c.Score),
+			MaxScore: uint32(c.MaxScore),
+			Message:  c.Message,
+		})
+	}
+
+	return &contentV1.AnalyzeSeoResponse{
+		Seo:      fromSeoMeta(target.meta, &meta),
+		Analysis: analysis,
+	}, len(filled) > 0, nil
+}
+
+// countDuplicateTitles 统计租户内同语言下标题或 SEO 标题与之相同的其他文章与页面
+func (r *SeoRepo) countDuplicateTitles(ctx context.Context, target *seoTarget, title string) (int, error) {
+	if title == "" {
+		return 0, nil
+	}
+	client := r.entClient.Client()
+
+	postQuery := client.PostTranslation.Query().
+		Where(
+			posttranslation.LanguageCodeEQ(target.languageCode),
+			posttranslation.Or(
+				posttranslation.TitleEQ(title),
+				func(s *sql.Selector) {
+					s.Where(sqljson.ValueEQ(s.C(posttranslation.FieldSeo), title, sqljson.Path("seo_title")))
+				},
+			),
+		)
+	if target.excludePostID != 0 {
+		postQuery.Where(posttranslation.IDNEQ(target.excludePostID))
+	}
+	if target.tenantID != 0 {
+		postQuery.Where(posttranslation.TenantIDEQ(target.tenantID))
+	} else {
+		postQuery.Where(posttranslation.Or(posttranslation.TenantIDIsNil(), posttranslation.TenantIDEQ(0)))
+	}
+
+	pageQuery := client.PageTranslation.Query().
+		Where(
+			pagetranslation.LanguageCodeEQ(target.languageCode),
+			pagetranslation.Or(
+				pagetranslation.TitleEQ(title),
+				func(s *sql.Selector) {
+					s.Where(sqljson.ValueEQ(s.C(pagetranslation.FieldSeo), title, sqljson.Path("seo_title")))
+				},
+			),
+		)
+	if target.excludePageID != 0 {
+		pageQuery.Where(pagetranslation.IDNEQ(target.excludePageID))
+	}
+	if target.tenantID != 0 {
+		pageQuery.Where(pagetranslation.TenantIDEQ(target.tenantID))
+	} else {
+		pageQuery.Where(pagetranslation.Or(pagetranslation.TenantIDIsNil(), pagetranslation.TenantIDEQ(0)))
+	}
+
+	posts, err := postQuery.Count(ctx)
+	if err != nil {
+		r.log.Errorf("count duplicate post titles failed: %s", err.Error())
+		return 0, contentV1.ErrorInternalServerError("count duplicate titles failed")
+	}
+	pages, err := pageQuery.Count(ctx)
+	if err != nil {
+		r.log.Errorf("count duplicate page titles failed: %s", err.Error())
+		return 0, contentV1.ErrorInternalServerError("count duplicate titles failed")
+	}
+
+	return posts + pages, nil
+}
+
+func toSeoMeta(m *contentV1.SeoMeta) seo.Meta {
+	return seo.Meta{
+		SeoTitle:        m.GetSeoTitle(),
+		MetaKeywords:    m.GetMetaKeywords(),
+		MetaDescription: m.GetMetaDescription(),
+		OgTitle:         m.GetOgTitle(),
+		OgDescription:   m.GetOgDescription(),
+		OgImage:         m.GetOgImage(),
+		CanonicalURL:    m.GetCanonicalUrl(),
+	}
+}
+
+// fromSeoMeta 把补全后的字段写回 SeoMeta，未填写的字段保持为空
+func fromSeoMeta(orig *contentV1.SeoMeta, m *seo.Meta) *contentV1.SeoMeta {
+	out := &contentV1.SeoMeta{}
+	if orig != nil {
+		out = proto.Clone(orig).(*contentV1.SeoMeta)
+	}
+
+	set := func(field **string, v string) {
+		if v != "" {
+			*field = trans.Ptr(v)
+		}
+	}
+	set(&out.SeoTitle, m.SeoTitle)
+	set(&out.MetaKeywords, m.MetaKeywords)
+	set(&out.MetaDescription, m.MetaDescription)
+	set(&out.OgTitle, m.OgTitle)
+	set(&out.OgDescription, m.OgDescription)
+	set(&out.OgImage, m.OgImage)
+	set(&out.CanonicalUrl, m.CanonicalURL)
+	return out
+}
+
+func toSeoCheckStatus(s seo.Status) contentV1.SeoCheck_Status {
+	switch s {
+	case seo.StatusPass:
+		return contentV1.SeoCheck_STATUS_PASS
+	case seo.StatusWarn:
+		return contentV1.SeoCheck_STATUS_WARN
+	case seo.StatusFail:
+		return contentV1.SeoCheck_STATUS_FAIL
+	default:
+		return contentV1.SeoCheck_STATUS_UNSPECIFIED
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"entgo.io/ent/dialect/sql"
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/jinzhu/copier"
 	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
 	entCrud "github.com/tx7do/go-crud/entgo"
 	"github.com/tx7do/go-utils/copierutil"
@@ -17,6 +18,8 @@ import (
 	"go-wind-cms/app/core/service/internal/data/ent/site"
 
 	siteV1 "go-wind-cms/api/gen/go/site/service/v1"
+
+	"go-wind-cms/pkg/content/locale"
 )
 
 type SiteRepo struct {
@@ -66,6 +69,39 @@ func (r *SiteRepo) init() {
 	r.mapper.AppendConverters(copierutil.NewTimeTimestamppbConverterPair())
 
 	r.mapper.AppendConverters(r.statusConverter.NewConverterPair())
+	r.mapper.AppendConverters(newLocaleFallbacksConverterPair())
+}
+
+func newLocaleFallbacksConverterPair() []copier.TypeConverter {
+	return copierutil.NewGenericTypeConverterPair(
+		[]locale.Fallback{}, []*siteV1.LocaleFallback{},
+		localeFallbacksToProto, localeFallbacksFromProto,
+	)
+}
+
+func localeFallbacksToProto(fallbacks []locale.Fallback) []*siteV1.LocaleFallback {
+	if fallbacks == nil {
+		return nil
+	}
+	out := make([]*siteV1.LocaleFallback, 0, len(fallbacks))
+	for _, fb := range fallbacks {
+		out = append(out, &siteV1.LocaleFallback{Locale: fb.Locale, Fallbacks: fb.Fallbacks})
+	}
+	return out
+}
+
+func localeFallbacksFromProto(fallbacks []*siteV1.LocaleFallback) []locale.Fallback {
+	if fallbacks == nil {
+		return nil
+	}
+	out := make([]locale.Fallback, 0, len(fallbacks))
+	for _, fb := range fallbacks {
+		if fb == nil {
+			continue
+		}
+		out = append(out, locale.Fallback{Locale: fb.GetLocale(), Fallbacks: fb.GetFallbacks()})
+	}
+	return out
 }
 
 func (r *SiteRepo) IsExist(ctx context.Context, id uint32) (bool, error) {
@@ -124,6 +160,11 @@ func (r *SiteRepo) Create(ctx context.Context, req *siteV1.CreateSiteRequest) (*
 		return nil, siteV1.ErrorBadRequest("invalid parameter")
 	}
 
+	fallbacks := localeFallbacksFromProto(req.Data.GetLocaleFallbacks())
+	if err := locale.ValidateFallbacks(fallbacks); err != nil {
+		return nil, siteV1.ErrorBadRequest("invalid locale fallbacks: %s", err.Error())
+	}
+
 	builder := r.entClient.Client().Site.Create().
 		SetNillableStatus(r.statusConverter.ToEntity(req.Data.Status)).
 		SetNillableTenantID(req.Data.TenantId).
@@ -140,6 +181,9 @@ func (r *SiteRepo) Create(ctx context.Context, req *siteV1.CreateSiteRequest) (*
 	if req.Data.AlternateDomains != nil {
 		builder.SetAlternateDomains(req.Data.GetAlternateDomains())
 	}
+	if fallbacks != nil {
+		builder.SetLocaleFallbacks(fallbacks)
+	}
 
 	var err error
 	var entity *ent.Site
@@ -170,6 +214,11 @@ func (r *SiteRepo) Update(ctx context.Context, req *siteV1.UpdateSiteRequest) (*
 		}
 	}
 
+	fallbacks := localeFallbacksFromProto(req.Data.GetLocaleFallbacks())
+	if err := locale.ValidateFallbacks(fallbacks); err != nil {
+		return nil, siteV1.ErrorBadRequest("invalid locale fallbacks: %s", err.Error())
+	}
+
 	tid, hasTenant := maybeTenantFromViewer(ctx)
 	callerUserID, hasUser := viewerUserIDFromContext(ctx)
 	// 计数列已从 Site 表移除，统一存于 interaction_counter 表（由 InteractionService 独占写入），
@@ -200,6 +249,9 @@ func (r *SiteRepo) Update(ctx context.Context, req *siteV1.UpdateSiteRequest) (*
 			if req.Data.AlternateDomains != nil {
 				builder.SetAlternateDomains(req.Data.GetAlternateDomains())
 			}
+			if fallbacks != nil {
+				builder.SetLocaleFallbacks(fallbacks)
+			}
 		},
 		func(s *sql.Selector) {
 			s.Where(sql.EQ(site.FieldID, req.GetId()))
@@ -0,0 +1,432 @@
+package data
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/tx7do/go-utils/trans"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	entCrud "github.com/tx7do/go-crud/entgo"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/category"
+	"go-wind-cms/app/core/service/internal/data/ent/categorytranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/page"
+	"go-wind-cms/app/core/service/internal/data/ent/pagetranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/user"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/jsonld"
+)
+
+// 站点设置中与结构化数据相关的键（site_title、site_description 与聚合共用）
+const (
+	settingSiteLogo           = "site_logo"
+	settingOrganizationName   = "organization_name"
+	settingOrganizationSameAs = "organization_same_as" // 多个地址以换行或逗号分隔
+	settingJsonLdOverrides    = "json_ld_overrides"    // 按节点类型覆盖属性的 JSON，见 jsonld.Overrides
+)
+
+// maxTrailDepth 面包屑向上查找祖先的最大层数，防止异常数据形成环
+const maxTrailDepth = 16
+
+// StructuredDataRepo 为前台详情生成 Schema.org JSON-LD（见 pkg/content/jsonld）。
+//
+// 站点与站点设置的解析与 SyndicationRepo 一致：按 host 匹配站点，站点设置优先于租户与全局设置，
+// Organization/WebSite 取自站点设置，租户可通过 json_ld_overrides 按节点类型覆盖生成的属性。
+// 结构化数据是详情的附加信息，生成失败只记日志并返回空串，不影响详情读取。
+type StructuredDataRepo struct {
+	entClient *entCrud.EntClient[*ent.Client]
+	log       *log.Helper
+
+	syndicationRepo *SyndicationRepo
+}
+
+func NewStructuredDataRepo(
+	ctx *bootstrap.Context,
+	entClient *entCrud.EntClient[*ent.Client],
+	syndicationRepo *SyndicationRepo,
+) *StructuredDataRepo {
+	return &StructuredDataRepo{
+		entClient:       entClient,
+		log:             ctx.NewLoggerHelper("structured-data/repo/core-service"),
+		syndicationRepo: syndicationRepo,
+	}
+}
+
+// structuredSite 请求对应的站点及站点级节点
+type structuredSite struct {
+	*syndicationSite
+
+	name      string
+	overrides jsonld.Overrides
+	nodes     []jsonld.Node // Organization 与 WebSite
+}
+
+func (r *StructuredDataRepo) site(ctx context.Context, host, lang string) (*structuredSite, bool) {
+	tid, host, err := r.syndicationRepo.tenant(ctx, host)
+	if err != nil {
+		return nil, false
+	}
+
+	s, err := r.syndicationRepo.resolveSite(ctx, tid, host)
+	if err != nil {
+		return nil, false
+	}
+	if lang == "" {
+		lang = s.defaultLocale
+	}
+
+	settings, err := r.syndicationRepo.settings(ctx, s, lang,
+		settingSiteTitle, settingSiteDescription, settingSiteLogo,
+		settingOrganizationName, settingOrganizationSameAs, settingJsonLdOverrides,
+	)
+	if err != nil {
+		return nil, false
+	}
+
+	result := &structuredSite{syndicationSite: s, name: settings[settingSiteTitle]}
+	if result.name == "" {
+		result.name = s.name
+	}
+	if result.overrides, err = jsonld.ParseOverrides(settings[settingJsonLdOverrides]); err != nil {
+		// 配置错误不影响其余结构化数据
+		r.log.Warnf("invalid %s of tenant [%d]: %s", settingJsonLdOverrides, tid, err.Error())
+	}
+
+	orgName := settings[settingOrganizationName]
+	if orgName == "" {
+		orgName = result.name
+	}
+	org := &jsonld.Organization{BaseURL: s.baseURL, Name: orgName, SameAs: splitList(settings[settingOrganizationSameAs])}
+	if logo := settings[settingSiteLogo]; logo != "" {
+		org.Logo = s.url(logo)
+	}
+	website := &jsonld.WebSite{BaseURL: s.baseURL, Name: result.name, Description: settings[settingSiteDescription], Language: lang}
+	result.nodes = []jsonld.Node{org.Node(), website.Node()}
+
+	return result, true
+}
+
+func (s *structuredSite) home() jsonld.Crumb {
+	return jsonld.Crumb{Name: s.name, URL: s.url("/")}
+}
+
+func (r *StructuredDataRepo) graph(s *structuredSite, nodes ...jsonld.Node) string {
+	body, err := jsonld.Graph(s.overrides, append(s.nodes, nodes...)...)
+	if err != nil {
+		r.log.Errorf("build json-ld failed: %s", err.Error())
+		return ""
+	}
+	return body
+}
+
+// Post 生成文章详情的结构化数据：Organization、WebSite、Article 与 BreadcrumbList（首页 > 分类层级 > 文章）
+func (r *StructuredDataRepo) Post(ctx context.Context, host string, dto *contentV1.Post) string {
+	t := servedTranslation(dto.GetTranslations(), dto.GetServedLocale(), dto.GetIsPreview(),
+		(*contentV1.PostTranslation).GetLanguageCode, (*contentV1.PostTranslation).GetIsDraft)
+	if t == nil {
+		return ""
+	}
+	lang := t.GetLanguageCode()
+
+	s, ok := r.site(ctx, host, lang)
+	if !ok {
+		return ""
+	}
+
+	pageURL := s.url(translationURLPath(t.Seo, t.FullPath, t.Slug, lang, "/post"))
+	article := &jsonld.Article{
+		BaseURL:     s.baseURL,
+		URL:         pageURL,
+		Headline:    firstNonEmpty(t.GetTitle(), t.GetSeo().GetSeoTitle()),
+		Description: firstNonEmpty(t.GetSeo().GetMetaDescription(), t.GetSummary()),
+		Keywords:    splitList(t.GetSeo().GetMetaKeywords()),
+		Language:    lang,
+		AuthorName:  r.authorName(ctx, dto.GetAuthorName(), dto.GetAuthorId()),
+		Published:   timeOf(dto.GetPublishTime(), dto.GetCreatedAt()),
+		Modified:    timeOf(t.GetUpdatedAt(), dto.GetUpdatedAt()),
+	}
+	if image := firstNonEmpty(t.GetThumbnail(), t.GetSeo().GetOgImage()); image != "" {
+		article.Image = s.url(image)
+	}
+
+	crumbs := []jsonld.Crumb{s.home()}
+	if ids := dto.GetCategoryIds(); len(ids) > 0 {
+		trail := r.categoryTrail(ctx, s, lang, ids[0])
+		if len(trail) > 0 {
+			article.Section = trail[len(trail)-1].Name
+		}
+		crumbs = append(crumbs, trail...)
+	}
+	crumbs = append(crumbs, jsonld.Crumb{Name: article.Headline, URL: pageURL})
+
+	return r.graph(s, article.Node(), jsonld.Breadcrumbs(pageURL, crumbs))
+}
+
+// Page 生成页面详情的结构化数据：Organization、WebSite、WebPage 与 BreadcrumbList（首页 > 父页面 > 页面）
+func (r *StructuredDataRepo) Page(ctx context.Context, host string, dto *contentV1.Page) string {
+	t := servedTranslation(dto.GetTranslations(), dto.GetServedLocale(), dto.GetIsPreview(),
+		(*contentV1.PageTranslation).GetLanguageCode, (*contentV1.PageTranslation).GetIsDraft)
+	if t == nil {
+		return ""
+	}
+	lang := t.GetLanguageCode()
+
+	s, ok := r.site(ctx, host, lang)
+	if !ok {
+		return ""
+	}
+
+	pageURL := s.url(translationURLPath(t.Seo, t.FullPath, t.Slug, lang, ""))
+	if dto.GetType() == contentV1.Page_PAGE_TYPE_HOME {
+		pageURL = s.url("/")
+	}
+	webPage := &jsonld.WebPage{
+		BaseURL:     s.baseURL,
+		URL:         pageURL,
+		Name:        firstNonEmpty(t.GetTitle(), t.GetSeo().GetSeoTitle()),
+		Description: t.GetSeo().GetMetaDescription(),
+		Language:    lang,
+		Published:   timeOf(dto.GetCreatedAt()),
+		Modified:    timeOf(t.GetUpdatedAt(), dto.GetUpdatedAt()),
+	}
+	if image := firstNonEmpty(t.GetCoverImage(), t.GetThumbnail(), t.GetSeo().GetOgImage()); image != "" {
+		webPage.Image = s.url(image)
+	}
+
+	var breadcrumbs jsonld.Node
+	if dto.GetType() != contentV1.Page_PAGE_TYPE_HOME {
+		crumbs := append([]jsonld.Crumb{s.home()}, r.pageTrail(ctx, s, lang, dto.GetParentId())...)
+		crumbs = append(crumbs, jsonld.Crumb{Name: webPage.Name, URL: pageURL})
+		breadcrumbs = jsonld.Breadcrumbs(pageURL, crumbs)
+	}
+
+	return r.graph(s, webPage.Node(), breadcrumbs)
+}
+
+// Category 生成分类详情的结构化数据：Organization、WebSite、CollectionPage 与 BreadcrumbList（首页 > 分类层级）
+func (r *StructuredDataRepo) Category(ctx context.Context, host string, dto *contentV1.Category) string {
+	t := servedTranslation(dto.GetTranslations(), dto.GetServedLocale(), false,
+		(*contentV1.CategoryTranslation).GetLanguageCode, (*contentV1.CategoryTranslation).GetIsDraft)
+	if t == nil {
+		return ""
+	}
+	lang := t.GetLanguageCode()
+
+	s, ok := r.site(ctx, host, lang)
+	if !ok {
+		return ""
+	}
+
+	pageURL := s.url(translationURLPath(t.Seo, t.FullPath, t.Slug, lang, "/category"))
+	webPage := &jsonld.WebPage{
+		Type:        jsonld.TypeCollectionPage,
+		BaseURL:     s.baseURL,
+		URL:         pageURL,
+		Name:        firstNonEmpty(t.GetName(), t.GetSeo().GetSeoTitle()),
+		Description: firstNonEmpty(t.GetSeo().GetMetaDescription(), t.GetDescription()),
+		Language:    lang,
+		Modified:    timeOf(t.GetUpdatedAt(), dto.GetUpdatedAt()),
+	}
+	if image := firstNonEmpty(t.GetCoverImage(), t.GetThumbnail(), t.GetSeo().GetOgImage()); image != "" {
+		webPage.Image = s.url(image)
+	}
+
+	crumbs := append([]jsonld.Crumb{s.home()}, r.categoryTrail(ctx, s, lang, dto.GetParentId())...)
+	crumbs = append(crumbs, jsonld.Crumb{Name: webPage.Name, URL: pageURL})
+
+	return r.graph(s, webPage.Node(), jsonld.Breadcrumbs(pageURL, crumbs))
+}
+
+// categoryTrail 分类 id 及其启用的祖先分类，从根到 id 排列；遇到未启用的祖先即停止向上查找
+func (r *StructuredDataRepo) categoryTrail(ctx context.Context, s *structuredSite, lang string, id uint32) []jsonld.Crumb {
+	var ids []uint32
+	for seen := map[uint32]bool{}; id != 0 && !seen[id] && len(ids) < maxTrailDepth; {
+		seen[id] = true
+		c, err := r.entClient.Client().Category.Query().
+			Where(append(activeCategories(s.tenantID), category.IDEQ(id))...).
+			Select(category.FieldID, category.FieldParentID).
+			Only(ctx)
+		if err != nil {
+			if !ent.IsNotFound(err) {
+				r.log.Warnf("query category [%d] failed: %s", id, err.Error())
+			}
+			break
+		}
+		ids = append(ids, c.ID)
+		id = trans.Uint32Value(c.ParentID)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	translations, err := r.entClient.Client().CategoryTranslation.Query().
+		Where(
+			categorytranslation.CategoryIDIn(ids...),
+			categorytranslation.Or(categorytranslation.IsDraftIsNil(), categorytranslation.IsDraftEQ(false)),
+		).
+		All(ctx)
+	if err != nil {
+		r.log.Warnf("query category translations failed: %s", err.Error())
+		return nil
+	}
+
+	best := make(map[uint32]*ent.CategoryTranslation, len(ids))
+	for _, t := range translations {
+		id := trans.Uint32Value(t.CategoryID)
+		if cur, ok := best[id]; !ok || localeRank(trans.StringValue(t.LanguageCode), lang, s.defaultLocale) > localeRank(trans.StringValue(cur.LanguageCode), lang, s.defaultLocale) {
+			best[id] = t
+		}
+	}
+
+	crumbs := make([]jsonld.Crumb, 0, len(ids))
+	for i := len(ids) - 1; i >= 0; i-- {
+		t, ok := best[ids[i]]
+		if !ok {
+			continue
+		}
+		path := translationURLPath(t.Seo, t.FullPath, t.Slug, trans.StringValue(t.LanguageCode), "/category")
+		crumbs = append(crumbs, jsonld.Crumb{Name: trans.StringValue(t.Name), URL: s.url(path)})
+	}
+	return crumbs
+}
+
+// pageTrail 页面 id 及其已发布的祖先页面，从根到 id 排列；首页不重复出现在面包屑中
+func (r *StructuredDataRepo) pageTrail(ctx context.Context, s *structuredSite, lang string, id uint32) []jsonld.Crumb {
+	var ids []uint32
+	for seen := map[uint32]bool{}; id != 0 && !seen[id] && len(ids) < maxTrailDepth; {
+		seen[id] = true
+		p, err := r.entClient.Client().Page.Query().
+			Where(append(publishedPages(s.tenantID), page.IDEQ(id))...).
+			Select(page.FieldID, page.FieldParentID, page.FieldType).
+			Only(ctx)
+		if err != nil {
+			if !ent.IsNotFound(err) {
+				r.log.Warnf("query page [%d] failed: %s", id, err.Error())
+			}
+			break
+		}
+		if p.Type == nil || *p.Type != page.TypePageTypeHome {
+			ids = append(ids, p.ID)
+		}
+		id = trans.Uint32Value(p.ParentID)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	translations, err := r.entClient.Client().PageTranslation.Query().
+		Where(
+			pagetranslation.PageIDIn(ids...),
+			pagetranslation.Or(pagetranslation.IsDraftIsNil(), pagetranslation.IsDraftEQ(false)),
+		).
+		All(ctx)
+	if err != nil {
+		r.log.Warnf("query page translations failed: %s", err.Error())
+		return nil
+	}
+
+	best := make(map[uint32]*ent.PageTranslation, len(ids))
+	for _, t := range translations {
+		id := trans.Uint32Value(t.PageID)
+		if cur, ok := best[id]; !ok || localeRank(trans.StringValue(t.LanguageCode), lang, s.defaultLocale) > localeRank(trans.StringValue(cur.LanguageCode), lang, s.defaultLocale) {
+			best[id] = t
+		}
+	}
+
+	crumbs := make([]jsonld.Crumb, 0, len(ids))
+	for i := len(ids) - 1; i >= 0; i-- {
+		t, ok := best[ids[i]]
+		if !ok {
+			continue
+		}
+		path := translationURLPath(t.Seo, t.FullPath, t.Slug, trans.StringValue(t.LanguageCode), "")
+		crumbs = append(crumbs, jsonld.Crumb{Name: trans.StringValue(t.Title), URL: s.url(path)})
+	}
+	return crumbs
+}
+
+// authorName 优先取内容上记录的作者名称，其次取作者用户的昵称
+func (r *StructuredDataRepo) authorName(ctx context.Context, name string, authorID uint32) string {
+	if name != "" || authorID == 0 {
+		return name
+	}
+	u, err := r.entClient.Client().User.Query().
+		Where(user.IDEQ(authorID)).
+		Select(user.FieldID, user.FieldNickname).
+		Only(ctx)
+	if err != nil {
+		if !ent.IsNotFound(err) {
+			r.log.Warnf("query author [%d] failed: %s", authorID, err.Error())
+		}
+		return ""
+	}
+	return trans.StringValue(u.Nickname)
+}
+
+// servedTranslation 取实际返回的语言版本，未标记时取第一个；草稿语言版本仅在预览时使用
+func servedTranslation[T any](translations []*T, served string, preview bool, langOf func(*T) string, isDraft func(*T) bool) *T {
+	var first *T
+	for _, t := range translations {
+		if !preview && isDraft(t) {
+			continue
+		}
+		if served != "" && langOf(t) == served {
+			return t
+		}
+		if first == nil {
+			first = t
+		}
+	}
+	return first
+}
+
+// localeRank 语言版本的优先级：请求语言 > 站点默认语言 > 其他
+func localeRank(code, lang, defaultLocale string) int {
+	switch code {
+	case lang:
+		return 2
+	case defaultLocale:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func timeOf(ts ...*timestamppb.Timestamp) time.Time {
+	for _, t := range ts {
+		if t != nil {
+			return t.AsTime()
+		}
+	}
+	return time.Time{}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// splitList 按换行与中英文逗号拆分列表，去除空项
+func splitList(s string) []string {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == '\n' || r == ',' || r == '，'
+	})
+	values := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if f = strings.TrimSpace(f); f != "" {
+			values = append(values, f)
+		}
+	}
+	return values
+}
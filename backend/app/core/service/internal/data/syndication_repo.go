@@ -0,0 +1,1040 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+	"github.com/tx7do/go-utils/trans"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	entCrud "github.com/tx7do/go-crud/entgo"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/category"
+	"go-wind-cms/app/core/service/internal/data/ent/categorytranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/language"
+	"go-wind-cms/app/core/service/internal/data/ent/page"
+	"go-wind-cms/app/core/service/internal/data/ent/pagetranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/post"
+	"go-wind-cms/app/core/service/internal/data/ent/postcategory"
+	"go-wind-cms/app/core/service/internal/data/ent/posttag"
+	"go-wind-cms/app/core/service/internal/data/ent/posttranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/predicate"
+	"go-wind-cms/app/core/service/internal/data/ent/site"
+	"go-wind-cms/app/core/service/internal/data/ent/sitesetting"
+	"go-wind-cms/app/core/service/internal/data/ent/tag"
+	"go-wind-cms/app/core/service/internal/data/ent/tagtranslation"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/syndication"
+)
+
+const (
+	// SyndicationKeyPrefix 站点地图、订阅源与 robots.txt 缓存键前缀
+	SyndicationKeyPrefix = ProjectPrefix + "syndication:"
+	// SyndicationKeyFormat 缓存键格式 syndication:{tenant_id}，hash 字段为 {host}|{文档}
+	SyndicationKeyFormat = SyndicationKeyPrefix + "%d"
+
+	defaultSyndicationCacheTTL = time.Hour
+
+	contentTypeXML  = "application/xml; charset=utf-8"
+	contentTypeRSS  = "application/rss+xml; charset=utf-8"
+	contentTypeAtom = "application/atom+xml; charset=utf-8"
+	contentTypeText = "text/plain; charset=utf-8"
+
+	// 订阅源按发布时间倒序分批扫描文章，直到凑满条目数或达到扫描上限
+	feedScanBatch = 100
+	feedScanLimit = 1000
+)
+
+// 站点设置中与聚合相关的键
+const (
+	settingSiteTitle       = "site_title"
+	settingSiteDescription = "site_description"
+	settingRobotsTxt       = "robots_txt"
+	settingRobotsIndex     = "robots_index"
+)
+
+// NewSyndicationOption 读取自定义配置 Syndication，未配置时返回 nil（使用默认值）
+func NewSyndicationOption(ctx *bootstrap.Context) *contentV1.SyndicationOption {
+	var cfg *contentV1.SyndicationOptionWrapper
+	rawCfg, ok := ctx.GetCustomConfig("Syndication")
+	if ok {
+		cfg = rawCfg.(*contentV1.SyndicationOptionWrapper)
+	}
+	if cfg == nil {
+		return nil
+	}
+	return cfg.Syndication
+}
+
+// SyndicationRepo 生成租户的站点地图、RSS/Atom 订阅源与 robots.txt（见 pkg/content/syndication）。
+//
+// 站点按请求的 host 匹配域名或备用域名，未匹配时取租户的默认站点；地址以 https://{host} 为根。
+// 只输出已发布的文章与页面、启用的分类与标签以及非草稿的语言版本。
+// 生成结果按租户缓存在 Redis（每个 host 与文档一个 hash 字段），内容发布、下线或站点变更时由 Invalidate 清除。
+type SyndicationRepo struct {
+	entClient *entCrud.EntClient[*ent.Client]
+	rdb       *redis.Client
+	log       *log.Helper
+
+	cacheTTL time.Duration
+	feedSize int
+}
+
+func NewSyndicationRepo(
+	ctx *bootstrap.Context,
+	entClient *entCrud.EntClient[*ent.Client],
+	rdb *redis.Client,
+	cfg *contentV1.SyndicationOption,
+) *SyndicationRepo {
+	repo := &SyndicationRepo{
+		entClient: entClient,
+		rdb:       rdb,
+		log:       ctx.NewLoggerHelper("syndication/repo/core-service"),
+		cacheTTL:  cfg.GetCacheTtl().AsDuration(),
+		feedSize:  int(cfg.GetFeedSize()),
+	}
+	if repo.cacheTTL <= 0 {
+		repo.cacheTTL = defaultSyndicationCacheTTL
+	}
+	if repo.feedSize <= 0 {
+		repo.feedSize = syndication.DefaultFeedSize
+	}
+	repo.feedSize = min(repo.feedSize, syndication.MaxFeedSize)
+	return repo
+}
+
+func syndicationKey(tenantID uint32) string {
+	return fmt.Sprintf(SyndicationKeyFormat, tenantID)
+}
+
+// syndicationSite 请求对应的站点
+type syndicationSite struct {
+	tenantID      uint32
+	id            uint32 // 0 表示租户未配置站点
+	name          string
+	host          string
+	baseURL       string
+	defaultLocale string
+}
+
+func (s *syndicationSite) url(path string) string {
+	return syndication.JoinURL(s.baseURL, path)
+}
+
+// syndicationEntry 内容的一个语言版本
+type syndicationEntry struct {
+	id      uint32 // 内容ID
+	lang    string
+	path    string // 站内路径或绝对地址
+	updated time.Time
+}
+
+// normalizeHost 小写并去除端口
+func normalizeHost(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.TrimSuffix(host, ".")
+}
+
+// normalizeDomain 站点配置的域名可能带协议或路径，只取主机名
+func normalizeDomain(domain string) string {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	if i := strings.Index(domain, "://"); i >= 0 {
+		domain = domain[i+3:]
+	}
+	if i := strings.IndexByte(domain, '/'); i >= 0 {
+		domain = domain[:i]
+	}
+	return normalizeHost(domain)
+}
+
+// translationURLPath 语言版本的访问路径：优先取 SEO 规范地址，其次完整路径，最后按 slug 拼接默认路径
+func translationURLPath(seo *contentV1.SeoMeta, fullPath, slug *string, lang, prefix string) string {
+	if canonical := strings.TrimSpace(seo.GetCanonicalUrl()); canonical != "" {
+		return canonical
+	}
+	if p := trans.StringValue(fullPath); p != "" {
+		return p
+	}
+	if s := trans.StringValue(slug); s != "" {
+		return "/" + lang + prefix + "/" + s
+	}
+	return ""
+}
+
+// newestTime 取多个时间中最晚的一个，全部为空时返回零值
+func newestTime(times ...*time.Time) time.Time {
+	var latest time.Time
+	for _, t := range times {
+		if t != nil && t.After(latest) {
+			latest = *t
+		}
+	}
+	return latest
+}
+
+// tenant 聚合内容只对有效租户输出，避免跨租户
+func (r *SyndicationRepo) tenant(ctx context.Context, host string) (uint32, string, error) {
+	tid, hasTenant := maybeTenantFromViewer(ctx)
+	if !hasTenant {
+		return 0, "", contentV1.ErrorNotFound("site not found")
+	}
+	host = normalizeHost(host)
+	if host == "" {
+		return 0, "", contentV1.ErrorBadRequest("host is required")
+	}
+	return tid, host, nil
+}
+
+// cached 读取缓存，未命中时生成并写入；缓存不可用时直接生成，不影响读取
+func (r *SyndicationRepo) cached(ctx context.Context, tenantID uint32, field, contentType string, build func() (string, error)) (*contentV1.SyndicationDocument, error) {
+	key := syndicationKey(tenantID)
+	if body, err := r.rdb.HGet(ctx, key, field).Result(); err == nil {
+		return &contentV1.SyndicationDocument{ContentType: contentType, Body: body}, nil
+	} else if !errors.Is(err, redis.Nil) {
+		r.log.Warnf("read syndication cache failed: %s", err.Error())
+	}
+
+	body, err := build()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, field, body)
+		pipe.Expire(ctx, key, r.cacheTTL)
+		return nil
+	}); err != nil {
+		r.log.Warnf("write syndication cache failed: %s", err.Error())
+	}
+
+	return &contentV1.SyndicationDocument{ContentType: contentType, Body: body}, nil
+}
+
+// Invalidate 清除调用方租户的缓存；无租户上下文（平台管理员）时清除全部租户的缓存。
+// best-effort：失败仅记日志，缓存在 TTL 后自然过期
+func (r *SyndicationRepo) Invalidate(ctx context.Context) {
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		if err := r.rdb.Del(ctx, syndicationKey(tid)).Err(); err != nil {
+			r.log.Warnf("invalidate syndication cache of tenant [%d] failed: %s", tid, err.Error())
+		}
+		return
+	}
+
+	iter := r.rdb.Scan(ctx, 0, SyndicationKeyPrefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		if err := r.rdb.Del(ctx, iter.Val()).Err(); err != nil {
+			r.log.Warnf("invalidate syndication cache [%s] failed: %s", iter.Val(), err.Error())
+		}
+	}
+	if err := iter.Err(); err != nil {
+		r.log.Warnf("scan syndication cache failed: %s", err.Error())
+	}
+}
+
+// resolveSite 按 host 匹配租户的站点，未匹配时取默认站点；站点的默认语言为空时取系统默认语言
+func (r *SyndicationRepo) resolveSite(ctx context.Context, tenantID uint32, host string) (*syndicationSite, error) {
+	result := &syndicationSite{tenantID: tenantID, host: host, baseURL: "https://" + host}
+
+	sites, err := r.entClient.Client().Site.Query().
+		Where(
+			site.TenantIDEQ(tenantID),
+			site.DeletedAtIsNil(),
+			site.Or(site.StatusIsNil(), site.StatusEQ(site.StatusSiteStatusActive)),
+		).
+		Order(ent.Asc(site.FieldID)).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query sites failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query sites failed")
+	}
+
+	var matched *ent.Site
+	for _, s := range sites {
+		domains := append([]string{trans.StringValue(s.Domain)}, s.AlternateDomains...)
+		for _, d := range domains {
+			if normalizeDomain(d) == host {
+				matched = s
+				break
+			}
+		}
+		if matched != nil {
+			break
+		}
+	}
+	if matched == nil {
+		for _, s := range sites {
+			if trans.BoolValue(s.IsDefault) {
+				matched = s
+				break
+			}
+		}
+	}
+	if matched == nil && len(sites) > 0 {
+		matched = sites[0]
+	}
+
+	if matched != nil {
+		result.id = matched.ID
+		result.name = trans.StringValue(matched.Name)
+		result.defaultLocale = trans.StringValue(matched.DefaultLocale)
+	}
+
+	if result.defaultLocale == "" {
+		lang, err := r.entClient.Client().Language.Query().
+			Where(
+				language.IsDefaultEQ(true),
+				language.IsEnabledEQ(true),
+			).
+			Select(language.FieldLanguageCode).
+			First(ctx)
+		switch {
+		case err == nil:
+			result.defaultLocale = trans.StringValue(lang.LanguageCode)
+		case !ent.IsNotFound(err):
+			r.log.Warnf("query default language failed: %s", err.Error())
+		}
+	}
+
+	return result, nil
+}
+
+// settings 读取站点设置：站点自身的设置优先于全局设置（site_id=0），同级中请求语言优先
+func (r *SyndicationRepo) settings(ctx context.Context, s *syndicationSite, locale string, keys ...string) (map[string]string, error) {
+	scope := sitesetting.And(
+		sitesetting.Or(sitesetting.SiteIDIsNil(), sitesetting.SiteIDEQ(0)),
+		sitesetting.Or(sitesetting.TenantIDEQ(s.tenantID), sitesetting.TenantIDIsNil(), sitesetting.TenantIDEQ(0)),
+	)
+	if s.id != 0 {
+		scope = sitesetting.Or(sitesetting.SiteIDEQ(s.id), scope)
+	}
+
+	entities, err := r.entClient.Client().SiteSetting.Query().
+		Where(
+			sitesetting.KeyIn(keys...),
+			sitesetting.DeletedAtIsNil(),
+			scope,
+		).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query site settings failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query site settings failed")
+	}
+
+	rank := func(e *ent.SiteSetting) int {
+		n := 0
+		if s.id != 0 && trans.Uint32Value(e.SiteID) == s.id {
+			n += 4
+		}
+		if trans.Uint32Value(e.TenantID) == s.tenantID {
+			n += 2
+		}
+		if trans.StringValue(e.Locale) == locale {
+			n++
+		}
+		return n
+	}
+
+	values := make(map[string]string, len(keys))
+	ranks := make(map[string]int, len(keys))
+	for _, e := range entities {
+		k := trans.StringValue(e.Key)
+		if n, ok := ranks[k]; ok && n >= rank(e) {
+			continue
+		}
+		values[k] = strings.TrimSpace(trans.StringValue(e.Value))
+		ranks[k] = rank(e)
+	}
+	return values, nil
+}
+
+// GetRobots 生成 robots.txt
+func (r *SyndicationRepo) GetRobots(ctx context.Context, req *contentV1.GetRobotsRequest) (*contentV1.SyndicationDocument, error) {
+	tid, host, err := r.tenant(ctx, req.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	return r.cached(ctx, tid, host+"|robots", contentTypeText, func() (string, error) {
+		s, err := r.resolveSite(ctx, tid, host)
+		if err != nil {
+			return "", err
+		}
+		settings, err := r.settings(ctx, s, s.defaultLocale, settingRobotsTxt, settingRobotsIndex)
+		if err != nil {
+			return "", err
+		}
+		noindex := strings.EqualFold(settings[settingRobotsIndex], "noindex")
+		return syndication.BuildRobots(settings[settingRobotsTxt], s.url(syndication.SitemapIndexPath), noindex), nil
+	})
+}
+
+// GetSitemap 生成站点地图索引或分类型站点地图
+func (r *SyndicationRepo) GetSitemap(ctx context.Context, req *contentV1.GetSitemapRequest) (*contentV1.SyndicationDocument, error) {
+	tid, host, err := r.tenant(ctx, req.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.GetName())
+	if name == "" {
+		return r.cached(ctx, tid, host+"|sitemap", contentTypeXML, func() (string, error) {
+			s, err := r.resolveSite(ctx, tid, host)
+			if err != nil {
+				return "", err
+			}
+			return r.buildSitemapIndex(ctx, s)
+		})
+	}
+
+	kind, pageNo, ok := syndication.ParseSitemapName(name)
+	if !ok {
+		return nil, contentV1.ErrorNotFound("sitemap not found")
+	}
+	return r.cached(ctx, tid, host+"|sitemap|"+name, contentTypeXML, func() (string, error) {
+		s, err := r.resolveSite(ctx, tid, host)
+		if err != nil {
+			return "", err
+		}
+		return r.buildSitemap(ctx, s, kind, pageNo)
+	})
+}
+
+// sitemapStat 某类内容的数量与最近更新时间
+type sitemapStat struct {
+	count   int
+	updated time.Time
+}
+
+func (r *SyndicationRepo) buildSitemapIndex(ctx context.Context, s *syndicationSite) (string, error) {
+	var refs []syndication.SitemapRef
+	for _, kind := range syndication.SitemapTypes {
+		stat, err := r.sitemapStat(ctx, s.tenantID, kind)
+		if err != nil {
+			return "", err
+		}
+		pages := (stat.count + syndication.URLsPerSitemap - 1) / syndication.URLsPerSitemap
+		for i := 1; i <= pages; i++ {
+			refs = append(refs, syndication.SitemapRef{
+				Loc:     s.url(syndication.SitemapPath(syndication.SitemapName(kind, i))),
+				LastMod: stat.updated,
+			})
+		}
+	}
+
+	body, err := syndication.BuildSitemapIndex(refs)
+	if err != nil {
+		r.log.Errorf("build sitemap index failed: %s", err.Error())
+		return "", contentV1.ErrorInternalServerError("build sitemap failed")
+	}
+	return string(body), nil
+}
+
+func (r *SyndicationRepo) sitemapStat(ctx context.Context, tenantID uint32, kind string) (*sitemapStat, error) {
+	c := r.entClient.Client()
+	stat := &sitemapStat{}
+	var err error
+	var updated *time.Time
+
+	switch kind {
+	case syndication.SitemapPosts:
+		q := c.Post.Query().Where(publishedPosts(tenantID)...)
+		if stat.count, err = q.Clone().Count(ctx); err == nil && stat.count > 0 {
+			var e *ent.Post
+			if e, err = q.Order(ent.Desc(post.FieldUpdatedAt)).Select(post.FieldUpdatedAt).First(ctx); err == nil {
+				updated = e.UpdatedAt
+			}
+		}
+	case syndication.SitemapPages:
+		q := c.Page.Query().Where(publishedPages(tenantID)...)
+		if stat.count, err = q.Clone().Count(ctx); err == nil && stat.count > 0 {
+			var e *ent.Page
+			if e, err = q.Order(ent.Desc(page.FieldUpdatedAt)).Select(page.FieldUpdatedAt).First(ctx); err == nil {
+				updated = e.UpdatedAt
+			}
+		}
+	case syndication.SitemapCategories:
+		q := c.Category.Query().Where(activeCategories(tenantID)...)
+		if stat.count, err = q.Clone().Count(ctx); err == nil && stat.count > 0 {
+			var e *ent.Category
+			if e, err = q.Order(ent.Desc(category.FieldUpdatedAt)).Select(category.FieldUpdatedAt).First(ctx); err == nil {
+				updated = e.UpdatedAt
+			}
+		}
+	case syndication.SitemapTags:
+		q := c.Tag.Query().Where(activeTags(tenantID)...)
+		if stat.count, err = q.Clone().Count(ctx); err == nil && stat.count > 0 {
+			var e *ent.Tag
+			if e, err = q.Order(ent.Desc(tag.FieldUpdatedAt)).Select(tag.FieldUpdatedAt).First(ctx); err == nil {
+				updated = e.UpdatedAt
+			}
+		}
+	}
+	if err != nil {
+		r.log.Errorf("count %s for sitemap failed: %s", kind, err.Error())
+		return nil, contentV1.ErrorInternalServerError("build sitemap failed")
+	}
+
+	stat.updated = newestTime(updated)
+	return stat, nil
+}
+
+func publishedPosts(tenantID uint32) []predicate.Post {
+	return []predicate.Post{
+		post.TenantIDEQ(tenantID),
+		post.StatusEQ(post.StatusPostStatusPublished),
+		post.DeletedAtIsNil(),
+	}
+}
+
+// publishedPages 已发布页面，不含错误页
+func publishedPages(tenantID uint32) []predicate.Page {
+	return []predicate.Page{
+		page.TenantIDEQ(tenantID),
+		page.StatusEQ(page.StatusPageStatusPublished),
+		page.DeletedAtIsNil(),
+		page.Or(page.TypeIsNil(), page.TypeNotIn(page.TypePageTypeError404, page.TypePageTypeError500)),
+	}
+}
+
+func activeCategories(tenantID uint32) []predicate.Category {
+	return []predicate.Category{
+		category.TenantIDEQ(tenantID),
+		category.StatusEQ(category.StatusCategoryStatusActive),
+		category.DeletedAtIsNil(),
+	}
+}
+
+func activeTags(tenantID uint32) []predicate.Tag {
+	return []predicate.Tag{
+		tag.TenantIDEQ(tenantID),
+		tag.StatusEQ(tag.StatusTAG_STATUS_ACTIVE),
+		tag.DeletedAtIsNil(),
+	}
+}
+
+// buildSitemap 生成某类内容的第 pageNo 页站点地图，每个语言版本一条地址并互链 hreflang
+func (r *SyndicationRepo) buildSitemap(ctx context.Context, s *syndicationSite, kind string, pageNo int) (string, error) {
+	offset := (pageNo - 1) * syndication.URLsPerSitemap
+
+	var entries []syndicationEntry
+	var err error
+	switch kind {
+	case syndication.SitemapPosts:
+		entries, err = r.postEntries(ctx, s.tenantID, offset)
+	case syndication.SitemapPages:
+		entries, err = r.pageEntries(ctx, s.tenantID, offset)
+	case syndication.SitemapCategories:
+		entries, err = r.categoryEntries(ctx, s.tenantID, offset)
+	case syndication.SitemapTags:
+		entries, err = r.tagEntries(ctx, s.tenantID, offset)
+	}
+	if err != nil {
+		return "", err
+	}
+	if len(entries) == 0 && pageNo > 1 {
+		return "", contentV1.ErrorNotFound("sitemap not found")
+	}
+
+	// entries 已按内容ID分组排列
+	var urls []syndication.URL
+	for start := 0; start < len(entries); {
+		end := start
+		hrefs := make(map[string]string)
+		for ; end < len(entries) && entries[end].id == entries[start].id; end++ {
+			hrefs[entries[end].lang] = s.url(entries[end].path)
+		}
+		alternates := syndication.Alternates(hrefs, s.defaultLocale)
+		for _, e := range entries[start:end] {
+			urls = append(urls, syndication.URL{Loc: hrefs[e.lang], LastMod: e.updated, Alternates: alternates})
+		}
+		start = end
+	}
+
+	body, err := syndication.BuildURLSet(urls)
+	if err != nil {
+		r.log.Errorf("build sitemap failed: %s", err.Error())
+		return "", contentV1.ErrorInternalServerError("build sitemap failed")
+	}
+	return string(body), nil
+}
+
+func (r *SyndicationRepo) postEntries(ctx context.Context, tenantID uint32, offset int) ([]syndicationEntry, error) {
+	posts, err := r.entClient.Client().Post.Query().
+		Where(publishedPosts(tenantID)...).
+		Order(ent.Asc(post.FieldID)).
+		Offset(offset).
+		Limit(syndication.URLsPerSitemap).
+		Select(post.FieldID, post.FieldUpdatedAt).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query posts for sitemap failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("build sitemap failed")
+	}
+	if len(posts) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uint32, 0, len(posts))
+	updated := make(map[uint32]*time.Time, len(posts))
+	for _, p := range posts {
+		ids = append(ids, p.ID)
+		updated[p.ID] = p.UpdatedAt
+	}
+
+	translations, err := r.entClient.Client().PostTranslation.Query().
+		Where(
+			posttranslation.PostIDIn(ids...),
+			posttranslation.TenantIDEQ(tenantID),
+			posttranslation.Or(posttranslation.IsDraftIsNil(), posttranslation.IsDraftEQ(false)),
+		).
+		Order(ent.Asc(posttranslation.FieldPostID), ent.Asc(posttranslation.FieldLanguageCode)).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query post translations for sitemap failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("build sitemap failed")
+	}
+
+	entries := make([]syndicationEntry, 0, len(translations))
+	for _, t := range translations {
+		id, lang := trans.Uint32Value(t.PostID), trans.StringValue(t.LanguageCode)
+		path := translationURLPath(t.Seo, t.FullPath, t.Slug, lang, "/post")
+		if path == "" {
+			continue
+		}
+		entries = append(entries, syndicationEntry{id: id, lang: lang, path: path, updated: newestTime(updated[id], t.UpdatedAt)})
+	}
+	return entries, nil
+}
+
+func (r *SyndicationRepo) pageEntries(ctx context.Context, tenantID uint32, offset int) ([]syndicationEntry, error) {
+	pages, err := r.entClient.Client().Page.Query().
+		Where(publishedPages(tenantID)...).
+		Order(ent.Asc(page.FieldID)).
+		Offset(offset).
+		Limit(syndication.URLsPerSitemap).
+		Select(page.FieldID, page.FieldUpdatedAt).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query pages for sitemap failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("build sitemap failed")
+	}
+	if len(pages) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uint32, 0, len(pages))
+	updated := make(map[uint32]*time.Time, len(pages))
+	for _, p := range pages {
+		ids = append(ids, p.ID)
+		updated[p.ID] = p.UpdatedAt
+	}
+
+	translations, err := r.entClient.Client().PageTranslation.Query().
+		Where(
+			pagetranslation.PageIDIn(ids...),
+			pagetranslation.TenantIDEQ(tenantID),
+			pagetranslation.Or(pagetranslation.IsDraftIsNil(), pagetranslation.IsDraftEQ(false)),
+		).
+		Order(ent.Asc(pagetranslation.FieldPageID), ent.Asc(pagetranslation.FieldLanguageCode)).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query page translations for sitemap failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("build sitemap failed")
+	}
+
+	entries := make([]syndicationEntry, 0, len(translations))
+	for _, t := range translations {
+		id, lang := trans.Uint32Value(t.PageID), trans.StringValue(t.LanguageCode)
+		path := translationURLPath(t.Seo, t.FullPath, t.Slug, lang, "")
+		if path == "" {
+			continue
+		}
+		entries = append(entries, syndicationEntry{id: id, lang: lang, path: path, updated: newestTime(updated[id], t.UpdatedAt)})
+	}
+	return entries, nil
+}
+
+func (r *SyndicationRepo) categoryEntries(ctx context.Context, tenantID uint32, offset int) ([]syndicationEntry, error) {
+	categories, err := r.entClient.Client().Category.Query().
+		Where(activeCategories(tenantID)...).
+		Order(ent.Asc(category.FieldID)).
+		Offset(offset).
+		Limit(syndication.URLsPerSitemap).
+		Select(category.FieldID, category.FieldUpdatedAt).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query categories for sitemap failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("build sitemap failed")
+	}
+	if len(categories) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uint32, 0, len(categories))
+	updated := make(map[uint32]*time.Time, len(categories))
+	for _, c := range categories {
+		ids = append(ids, c.ID)
+		updated[c.ID] = c.UpdatedAt
+	}
+
+	translations, err := r.entClient.Client().CategoryTranslation.Query().
+		Where(
+			categorytranslation.CategoryIDIn(ids...),
+			categorytranslation.TenantIDEQ(tenantID),
+			categorytranslation.Or(categorytranslation.IsDraftIsNil(), categorytranslation.IsDraftEQ(false)),
+		).
+		Order(ent.Asc(categorytranslation.FieldCategoryID), ent.Asc(categorytranslation.FieldLanguageCode)).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query category translations for sitemap failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("build sitemap failed")
+	}
+
+	entries := make([]syndicationEntry, 0, len(translations))
+	for _, t := range translations {
+		id, lang := trans.Uint32Value(t.CategoryID), trans.StringValue(t.LanguageCode)
+		path := translationURLPath(t.Seo, t.FullPath, t.Slug, lang, "/category")
+		if path == "" {
+			continue
+		}
+		entries = append(entries, syndicationEntry{id: id, lang: lang, path: path, updated: newestTime(updated[id], t.UpdatedAt)})
+	}
+	return entries, nil
+}
+
+func (r *SyndicationRepo) tagEntries(ctx context.Context, tenantID uint32, offset int) ([]syndicationEntry, error) {
+	tags, err := r.entClient.Client().Tag.Query().
+		Where(activeTags(tenantID)...).
+		Order(ent.Asc(tag.FieldID)).
+		Offset(offset).
+		Limit(syndication.URLsPerSitemap).
+		Select(tag.FieldID, tag.FieldUpdatedAt).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query tags for sitemap failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("build sitemap failed")
+	}
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uint32, 0, len(tags))
+	updated := make(map[uint32]*time.Time, len(tags))
+	for _, t := range tags {
+		ids = append(ids, t.ID)
+		updated[t.ID] = t.UpdatedAt
+	}
+
+	translations, err := r.entClient.Client().TagTranslation.Query().
+		Where(
+			tagtranslation.TagIDIn(ids...),
+			tagtranslation.TenantIDEQ(tenantID),
+			tagtranslation.Or(tagtranslation.IsDraftIsNil(), tagtranslation.IsDraftEQ(false)),
+		).
+		Order(ent.Asc(tagtranslation.FieldTagID), ent.Asc(tagtranslation.FieldLanguageCode)).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query tag translations for sitemap failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("build sitemap failed")
+	}
+
+	entries := make([]syndicationEntry, 0, len(translations))
+	for _, t := range translations {
+		id, lang := trans.Uint32Value(t.TagID), trans.StringValue(t.LanguageCode)
+		path := translationURLPath(t.Seo, t.FullPath, t.Slug, lang, "/tag")
+		if path == "" {
+			continue
+		}
+		entries = append(entries, syndicationEntry{id: id, lang: lang, path: path, updated: newestTime(updated[id], t.UpdatedAt)})
+	}
+	return entries, nil
+}
+
+// GetFeed 生成站点、分类或标签的订阅源，条目为该语言下最新发布的文章
+func (r *SyndicationRepo) GetFeed(ctx context.Context, req *contentV1.GetFeedRequest) (*contentV1.SyndicationDocument, error) {
+	tid, host, err := r.tenant(ctx, req.GetHost())
+	if err != nil {
+		return nil, err
+	}
+
+	var scope string
+	switch req.GetScope() {
+	case contentV1.GetFeedRequest_SCOPE_UNSPECIFIED, contentV1.GetFeedRequest_SCOPE_SITE:
+		scope = syndication.FeedScopeSite
+	case contentV1.GetFeedRequest_SCOPE_CATEGORY:
+		scope = syndication.FeedScopeCategory
+	case contentV1.GetFeedRequest_SCOPE_TAG:
+		scope = syndication.FeedScopeTag
+	default:
+		return nil, contentV1.ErrorBadRequest("invalid feed scope")
+	}
+	slug := strings.TrimSpace(req.GetSlug())
+	if scope != syndication.FeedScopeSite && slug == "" {
+		return nil, contentV1.ErrorBadRequest("slug is required")
+	}
+
+	atom := req.GetFormat() == contentV1.GetFeedRequest_FORMAT_ATOM
+	contentType := contentTypeRSS
+	if atom {
+		contentType = contentTypeAtom
+	}
+
+	field := fmt.Sprintf("%s|feed|%s|%s|%s|%t", host, scope, slug, req.GetLanguage(), atom)
+	return r.cached(ctx, tid, field, contentType, func() (string, error) {
+		s, err := r.resolveSite(ctx, tid, host)
+		if err != nil {
+			return "", err
+		}
+		return r.buildFeed(ctx, s, scope, slug, req.GetLanguage(), atom)
+	})
+}
+
+func (r *SyndicationRepo) buildFeed(ctx context.Context, s *syndicationSite, scope, slug, lang string, atom bool) (string, error) {
+	if lang == "" {
+		lang = s.defaultLocale
+	}
+
+	settings, err := r.settings(ctx, s, lang, settingSiteTitle, settingSiteDescription)
+	if err != nil {
+		return "", err
+	}
+	ch := &syndication.Channel{
+		Title:       settings[settingSiteTitle],
+		Link:        s.url("/"),
+		FeedURL:     s.url(syndication.FeedPath(scope, slug, atom)),
+		Description: settings[settingSiteDescription],
+		Language:    lang,
+	}
+	if ch.Title == "" {
+		ch.Title = s.name
+	}
+	if ch.Title == "" {
+		ch.Title = s.host
+	}
+	if lang != "" {
+		ch.FeedURL += "?lang=" + lang
+	}
+
+	// 分类与标签订阅源只包含其下的文章
+	var postIDs []uint32
+	if scope != syndication.FeedScopeSite {
+		var name, link string
+		postIDs, name, link, err = r.feedTerm(ctx, s.tenantID, scope, slug, lang)
+		if err != nil {
+			return "", err
+		}
+		ch.Title += " - " + name
+		ch.Description = ""
+		ch.Link = s.url(link)
+	}
+
+	items, err := r.feedItems(ctx, s, lang, scope != syndication.FeedScopeSite, postIDs)
+	if err != nil {
+		return "", err
+	}
+
+	var body []byte
+	if atom {
+		body, err = syndication.BuildAtom(ch, items)
+	} else {
+		body, err = syndication.BuildRSS(ch, items)
+	}
+	if err != nil {
+		r.log.Errorf("build feed failed: %s", err.Error())
+		return "", contentV1.ErrorInternalServerError("build feed failed")
+	}
+	return string(body), nil
+}
+
+// feedTerm 按 slug（任一语言版本，优先请求语言）查找启用的分类或标签，返回其下文章ID、名称与访问路径
+func (r *SyndicationRepo) feedTerm(ctx context.Context, tenantID uint32, scope, slug, lang string) ([]uint32, string, string, error) {
+	c := r.entClient.Client()
+
+	var termID uint32
+	var name, path string
+	switch scope {
+	case syndication.FeedScopeCategory:
+		translations, err := c.CategoryTranslation.Query().
+			Where(
+				categorytranslation.TenantIDEQ(tenantID),
+				categorytranslation.SlugEQ(slug),
+				categorytranslation.Or(categorytranslation.IsDraftIsNil(), categorytranslation.IsDraftEQ(false)),
+			).
+			All(ctx)
+		if err != nil {
+			r.log.Errorf("query category translations failed: %s", err.Error())
+			return nil, "", "", contentV1.ErrorInternalServerError("build feed failed")
+		}
+		for _, t := range translations {
+			if termID == 0 || trans.StringValue(t.LanguageCode) == lang {
+				termID = trans.Uint32Value(t.CategoryID)
+				name = trans.StringValue(t.Name)
+				path = translationURLPath(t.Seo, t.FullPath, t.Slug, trans.StringValue(t.LanguageCode), "/category")
+			}
+		}
+		if termID != 0 {
+			exist, err := c.Category.Query().Where(append(activeCategories(tenantID), category.IDEQ(termID))...).Exist(ctx)
+			if err != nil {
+				r.log.Errorf("query category failed: %s", err.Error())
+				return nil, "", "", contentV1.ErrorInternalServerError("build feed failed")
+			}
+			if !exist {
+				termID = 0
+			}
+		}
+		if termID == 0 {
+			return nil, "", "", contentV1.ErrorNotFound("category not found")
+		}
+
+		ids, err := c.PostCategory.Query().
+			Where(postcategory.CategoryIDEQ(termID), postcategory.TenantIDEQ(tenantID)).
+			Select(postcategory.FieldPostID).
+			Uint32s(ctx)
+		if err != nil {
+			r.log.Errorf("query category posts failed: %s", err.Error())
+			return nil, "", "", contentV1.ErrorInternalServerError("build feed failed")
+		}
+		return ids, name, path, nil
+
+	case syndication.FeedScopeTag:
+		translations, err := c.TagTranslation.Query().
+			Where(
+				tagtranslation.TenantIDEQ(tenantID),
+				tagtranslation.SlugEQ(slug),
+				tagtranslation.Or(tagtranslation.IsDraftIsNil(), tagtranslation.IsDraftEQ(false)),
+			).
+			All(ctx)
+		if err != nil {
+			r.log.Errorf("query tag translations failed: %s", err.Error())
+			return nil, "", "", contentV1.ErrorInternalServerError("build feed failed")
+		}
+		for _, t := range translations {
+			if termID == 0 || trans.StringValue(t.LanguageCode) == lang {
+				termID = trans.Uint32Value(t.TagID)
+				name = trans.StringValue(t.Name)
+				path = translationURLPath(t.Seo, t.FullPath, t.Slug, trans.StringValue(t.LanguageCode), "/tag")
+			}
+		}
+		if termID != 0 {
+			exist, err := c.Tag.Query().Where(append(activeTags(tenantID), tag.IDEQ(termID))...).Exist(ctx)
+			if err != nil {
+				r.log.Errorf("query tag failed: %s", err.Error())
+				return nil, "", "", contentV1.ErrorInternalServerError("build feed failed")
+			}
+			if !exist {
+				termID = 0
+			}
+		}
+		if termID == 0 {
+			return nil, "", "", contentV1.ErrorNotFound("tag not found")
+		}
+
+		ids, err := c.PostTag.Query().
+			Where(posttag.TagIDEQ(termID), posttag.TenantIDEQ(tenantID)).
+			Select(posttag.FieldPostID).
+			Uint32s(ctx)
+		if err != nil {
+			r.log.Errorf("query tag posts failed: %s", err.Error())
+			return nil, "", "", contentV1.ErrorInternalServerError("build feed failed")
+		}
+		return ids, name, path, nil
+	}
+
+	return nil, "", "", contentV1.ErrorBadRequest("invalid feed scope")
+}
+
+// feedItems 按发布时间倒序分批扫描已发布文章，取该语言下非草稿的版本，直到凑满条目数。
+// 受密码保护的文章不输出摘要
+func (r *SyndicationRepo) feedItems(ctx context.Context, s *syndicationSite, lang string, restricted bool, postIDs []uint32) ([]syndication.Item, error) {
+	if restricted && len(postIDs) == 0 {
+		return nil, nil
+	}
+
+	predicates := publishedPosts(s.tenantID)
+	if restricted {
+		predicates = append(predicates, post.IDIn(postIDs...))
+	}
+
+	var items []syndication.Item
+	for offset := 0; offset < feedScanLimit && len(items) < r.feedSize; offset += feedScanBatch {
+		posts, err := r.entClient.Client().Post.Query().
+			Where(predicates...).
+			Order(post.ByPublishTime(sql.OrderDesc(), sql.OrderNullsLast()), post.ByID(sql.OrderDesc())).
+			Offset(offset).
+			Limit(feedScanBatch).
+			Select(post.FieldID, post.FieldAuthorName, post.FieldPasswordHash, post.FieldPublishTime, post.FieldCreatedAt, post.FieldUpdatedAt).
+			All(ctx)
+		if err != nil {
+			r.log.Errorf("query posts for feed failed: %s", err.Error())
+			return nil, contentV1.ErrorInternalServerError("build feed failed")
+		}
+		if len(posts) == 0 {
+			break
+		}
+
+		ids := make([]uint32, 0, len(posts))
+		for _, p := range posts {
+			ids = append(ids, p.ID)
+		}
+		translations, err := r.entClient.Client().PostTranslation.Query().
+			Where(
+				posttranslation.PostIDIn(ids...),
+				posttranslation.TenantIDEQ(s.tenantID),
+				posttranslation.LanguageCodeEQ(lang),
+				posttranslation.Or(posttranslation.IsDraftIsNil(), posttranslation.IsDraftEQ(false)),
+			).
+			All(ctx)
+		if err != nil {
+			r.log.Errorf("query post translations for feed failed: %s", err.Error())
+			return nil, contentV1.ErrorInternalServerError("build feed failed")
+		}
+		byPost := make(map[uint32]*ent.PostTranslation, len(translations))
+		for _, t := range translations {
+			byPost[trans.Uint32Value(t.PostID)] = t
+		}
+
+		for _, p := range posts {
+			t := byPost[p.ID]
+			if t == nil {
+				continue
+			}
+			path := translationURLPath(t.Seo, t.FullPath, t.Slug, lang, "/post")
+			if path == "" {
+				continue
+			}
+
+			item := syndication.Item{
+				Title:     trans.StringValue(t.Title),
+				Link:      s.url(path),
+				Author:    trans.StringValue(p.AuthorName),
+				Published: newestTime(p.PublishTime),
+				Updated:   newestTime(p.UpdatedAt, t.UpdatedAt),
+			}
+			if item.Published.IsZero() {
+				item.Published = newestTime(p.CreatedAt)
+			}
+			if trans.StringValue(p.PasswordHash) == "" {
+				item.Summary = trans.StringValue(t.Summary)
+			}
+			items = append(items, item)
+			if len(items) >= r.feedSize {
+				break
+			}
+		}
+	}
+
+	return items, nil
+}
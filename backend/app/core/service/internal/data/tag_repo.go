@@ -46,12 +46,14 @@ type TagRepo struct {
 	statusConverter *mapper.EnumTypeConverter[contentV1.Tag_TagStatus, tag.Status]
 
 	tagTranslationRepo *TagTranslationRepo
+	localeResolver     *LocaleResolver
 }
 
 func NewTagRepo(
 	ctx *bootstrap.Context,
 	entClient *entCrud.EntClient[*ent.Client],
 	tagTranslationRepo *TagTranslationRepo,
+	localeResolver *LocaleResolver,
 ) *TagRepo {
 	repo := &TagRepo{
 		entClient: entClient,
@@ -61,6 +63,7 @@ func NewTagRepo(
 			contentV1.Tag_TagStatus_name, contentV1.Tag_TagStatus_value,
 		),
 		tagTranslationRepo: tagTranslationRepo,
+		localeResolver:     localeResolver,
 	}
 
 	repo.init()
@@ -158,7 +161,8 @@ func (r *TagRepo) List(ctx context.Context, req *paginationV1.PagingRequest) (*c
 		return nil, contentV1.ErrorBadRequest("invalid parameter")
 	}
 
-	builder := r.entClient.Client().Tag.Query()
+	builder := r.entClient.Client().Tag.Query().
+		Where(tag.DeletedAtIsNil())
 
 	excludeConditions, translationMaskFields, needQueryTranslation, err := r.prepareTranslationMaskFields(req)
 	if err != nil {
@@ -188,8 +192,24 @@ func (r *TagRepo) List(ctx context.Context, req *paginationV1.PagingRequest) (*c
 			}
 		}
 
+		// 指定语言时按回退链逐项选出实际返回的翻译语言，没有可用翻译的标签不附带翻译
+		chain := r.localeResolver.Chain(ctx, locale)
+
 		for _, item := range ret.Items {
-			translations, err := r.tagTranslationRepo.ListTranslations(ctx, item.GetId(), locale, viewMask)
+			served := locale
+			if len(chain) > 0 {
+				languages, err := r.tagTranslationRepo.ListAvailedLanguages(ctx, item.GetId())
+				if err != nil {
+					r.log.Errorf("query availed languages failed: %s", err.Error())
+					return nil, contentV1.ErrorInternalServerError("query availed languages failed")
+				}
+				if served = pickLocale(chain, languages); served == "" {
+					continue
+				}
+				item.ServedLocale = trans.Ptr(served)
+			}
+
+			translations, err := r.tagTranslationRepo.ListTranslations(ctx, item.GetId(), served, viewMask)
 			if err != nil {
 				r.log.Errorf("query translations failed: %s", err.Error())
 				return nil, contentV1.ErrorInternalServerError("query translations failed")
@@ -218,7 +238,8 @@ func (r *TagRepo) Get(ctx context.Context, req *contentV1.GetTagRequest) (*conte
 		return nil, contentV1.ErrorBadRequest("invalid parameter")
 	}
 
-	builder := r.entClient.Client().Tag.Query()
+	builder := r.entClient.Client().Tag.Query().
+		Where(tag.DeletedAtIsNil())
 
 	switch req.QueryBy.(type) {
 	case *contentV1.GetTagRequest_Id:
@@ -244,13 +265,6 @@ func (r *TagRepo) Get(ctx context.Context, req *contentV1.GetTagRequest) (*conte
 
 	dto := r.mapper.ToDTO(entity)
 
-	translations, err := r.tagTranslationRepo.ListTranslations(ctx, dto.GetId(), "", nil)
-	if err != nil {
-		r.log.Errorf("query translations failed: %s", err.Error())
-		return nil, contentV1.ErrorInternalServerError("query translations failed")
-	}
-	dto.Translations = translations
-
 	languages, err := r.tagTranslationRepo.ListAvailedLanguages(ctx, dto.GetId())
 	if err != nil {
 		r.log.Errorf("query availed languages failed: %s", err.Error())
@@ -258,6 +272,22 @@ func (r *TagRepo) Get(ctx context.Context, req *contentV1.GetTagRequest) (*conte
 	}
 	dto.AvailableLanguages = languages
 
+	// 指定语言时只返回按回退链选出的翻译，均不可用时不附带翻译
+	served := req.GetLocale()
+	if served != "" {
+		if served = r.localeResolver.Serve(ctx, req.GetLocale(), languages); served == "" {
+			return dto, nil
+		}
+		dto.ServedLocale = trans.Ptr(served)
+	}
+
+	translations, err := r.tagTranslationRepo.ListTranslations(ctx, dto.GetId(), served, nil)
+	if err != nil {
+		r.log.Errorf("query translations failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query translations failed")
+	}
+	dto.Translations = translations
+
 	return dto, nil
 }
 
@@ -388,7 +418,7 @@ func (r *TagRepo) Update(ctx context.Context, req *contentV1.UpdateTagRequest) (
 	tid, hasTenant := maybeTenantFromViewer(ctx)
 	callerUserID, hasUser := viewerUserIDFromContext(ctx)
 	builder := tx.Tag.UpdateOneID(req.GetId())
-	builder.Where(tag.IDEQ(req.GetId()))
+	builder.Where(tag.IDEQ(req.GetId()), tag.DeletedAtIsNil())
 	if hasTenant {
 		builder.Where(tag.TenantIDEQ(tid))
 	}
@@ -417,42 +447,20 @@ func (r *TagRepo) Update(ctx context.Context, req *contentV1.UpdateTagRequest) (
 	return result, err
 }
 
-func (r *TagRepo) Delete(ctx context.Context, req *contentV1.DeleteTagRequest) (err error) {
-	if req == nil {
-		return contentV1.ErrorBadRequest("invalid parameter")
-	}
-
-	var tx *ent.Tx
-	tx, err = r.entClient.Client().Tx(ctx)
-	if err != nil {
-		r.log.Errorf("start transaction failed: %s", err.Error())
-		return contentV1.ErrorInternalServerError("start transaction failed")
-	}
-	defer func() {
-		if err != nil {
-			if rollbackErr := tx.Rollback(); rollbackErr != nil {
-				r.log.Errorf("transaction rollback failed: %s", rollbackErr.Error())
-			}
-			return
-		}
-		if commitErr := tx.Commit(); commitErr != nil {
-			r.log.Errorf("transaction commit failed: %s", commitErr.Error())
-			err = contentV1.ErrorInternalServerError("transaction commit failed")
-		}
-	}()
-
+// Purge 在给定事务中物理删除标签及其翻译，仅由回收站彻底清理时调用
+func (r *TagRepo) Purge(ctx context.Context, tx *ent.Tx, id uint32) error {
 	tid, hasTenant := maybeTenantFromViewer(ctx)
 	delBuilder := tx.Tag.Delete()
-	delBuilder.Where(tag.IDEQ(req.GetId()))
+	delBuilder.Where(tag.IDEQ(id))
 	if hasTenant {
 		delBuilder.Where(tag.TenantIDEQ(tid))
 	}
-	if _, err = delBuilder.Exec(ctx); err != nil {
+	if _, err := delBuilder.Exec(ctx); err != nil {
 		r.log.Errorf("delete one data failed: %s", err.Error())
 		return contentV1.ErrorInternalServerError("delete one data failed")
 	}
 
-	if err = r.tagTranslationRepo.CleanTranslations(ctx, tx, req.GetId()); err != nil {
+	if err := r.tagTranslationRepo.CleanTranslations(ctx, tx, id); err != nil {
 		r.log.Errorf("clean translations failed: %s", err.Error())
 		return contentV1.ErrorInternalServerError("clean translations failed")
 	}
@@ -516,7 +524,16 @@ func (r *TagRepo) GetTranslation(ctx context.Context, req *contentV1.GetTagReque
 		return nil, contentV1.ErrorBadRequest("invalid parameter")
 	}
 
-	return r.tagTranslationRepo.GetTranslation(ctx, req.GetId(), req.GetLocale())
+	languages, err := r.tagTranslationRepo.ListAvailedLanguages(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	served := r.localeResolver.Serve(ctx, req.GetLocale(), languages)
+	if served == "" {
+		return nil, contentV1.ErrorNotFound("translation not found")
+	}
+
+	return r.tagTranslationRepo.GetTranslation(ctx, req.GetId(), served)
 }
 
 func (r *TagRepo) ListTranslations(ctx context.Context, tagID uint32) ([]*contentV1.TagTranslation, error) {
@@ -22,6 +22,8 @@ import (
 	"go-wind-cms/app/core/service/internal/data/ent/tagtranslation"
 
 	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/utils"
 )
 
 type TagTranslationRepo struct {
@@ -38,6 +40,8 @@ type TagTranslationRepo struct {
 		predicate.TagTranslation,
 		contentV1.TagTranslation, ent.TagTranslation,
 	]
+
+	translationStatusConverter *mapper.EnumTypeConverter[contentV1.TranslationStatus, tagtranslation.TranslationStatus]
 }
 
 func NewTagTranslationRepo(ctx *bootstrap.Context, entClient *entCrud.EntClient[*ent.Client]) *TagTranslationRepo {
@@ -45,6 +49,9 @@ func NewTagTranslationRepo(ctx *bootstrap.Context, entClient *entCrud.EntClient[
 		entClient: entClient,
 		log:       ctx.NewLoggerHelper("tag-translation/repo/core-service"),
 		mapper:    mapper.NewCopierMapper[contentV1.TagTranslation, ent.TagTranslation](),
+		translationStatusConverter: mapper.NewEnumTypeConverter[contentV1.TranslationStatus, tagtranslation.TranslationStatus](
+			contentV1.TranslationStatus_name, contentV1.TranslationStatus_value,
+		),
 	}
 
 	repo.init()
@@ -64,6 +71,7 @@ func (r *TagTranslationRepo) init() {
 
 	r.mapper.AppendConverters(copierutil.NewTimeStringConverterPair())
 	r.mapper.AppendConverters(copierutil.NewTimeTimestamppbConverterPair())
+	r.mapper.AppendConverters(r.translationStatusConverter.NewConverterPair())
 }
 
 func (r *TagTranslationRepo) CleanTranslations(
@@ -130,6 +138,10 @@ func (r *TagTranslationRepo) newCreateBuilder(tt *ent.TagTranslationClient, data
 		SetNillableDescription(data.Description).
 		SetNillableCoverImage(data.CoverImage).
 		SetNillableFullPath(data.FullPath).
+		SetNillableIsDraft(data.IsDraft).
+		SetNillableSourceLanguage(data.SourceLanguage).
+		SetNillableSourceRevision(data.SourceRevision).
+		SetNillableTranslationStatus(r.translationStatusConverter.ToEntity(data.TranslationStatus)).
 		SetNillableCreatedBy(data.CreatedBy).
 		SetCreatedAt(time.Now())
 
@@ -148,6 +160,9 @@ func (r *TagTranslationRepo) BatchCreate(ctx context.Context, tx *ent.Tx, items
 	builders := make([]*ent.TagTranslationCreate, 0, len(items))
 	for _, data := range items {
 		_ = r.PrepareTranslation(ctx, data)
+		if err := r.anchorTranslation(ctx, tx.Client(), data, items); err != nil {
+			return err
+		}
 
 		builder := r.newCreateBuilder(r.entClient.Client().TagTranslation, data)
 
@@ -164,8 +179,10 @@ func (r *TagTranslationRepo) BatchCreate(ctx context.Context, tx *ent.Tx, items
 }
 
 func (r *TagTranslationRepo) CreateTranslation(ctx context.Context, data *contentV1.TagTranslation) (*contentV1.TagTranslation, error) {
-
 	_ = r.PrepareTranslation(ctx, data)
+	if err := r.anchorTranslation(ctx, r.entClient.Client(), data, nil); err != nil {
+		return nil, err
+	}
 
 	builder := r.newCreateBuilder(r.entClient.Client().TagTranslation, data)
 
@@ -183,6 +200,29 @@ func (r *TagTranslationRepo) UpdateTranslation(ctx context.Context, id uint32, d
 		return nil, nil
 	}
 
+	// 记录更新前的内容，用于判断可翻译字段是否修改
+	old, err := r.entClient.Client().TagTranslation.Get(ctx, id)
+	if err != nil && !ent.IsNotFound(err) {
+		r.log.Errorf("query tag translation failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query tag translation failed")
+	}
+
+	// 修订跟踪字段由服务端维护：修改源语言或确认已校对时重新锚定，其余情况忽略客户端传入值
+	var anchor *translationAnchor
+	if old != nil {
+		if lang, ok := translationAnchorRequested(updateMask, data.SourceLanguage, data.TranslationStatus, old.SourceLanguage); ok {
+			if anchor, err = resolveTranslationAnchor(trans.StringValue(old.LanguageCode), lang, func(l string) (uint32, bool, error) {
+				return tagTranslationRevision(ctx, r.entClient.Client(), trans.Uint32Value(old.TagID), l)
+			}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if updateMask != nil {
+		updateMask.Paths = utils.FilterBlacklist(updateMask.GetPaths(), translationTrackingFields)
+	}
+	data.Revision, data.SourceLanguage, data.SourceRevision, data.TranslationStatus = nil, nil, nil, nil
+
 	builder := r.entClient.Client().TagTranslation.UpdateOneID(id)
 	// 租户作用域：仅更新本租户翻译，避免跨租户改他人翻译（按 hasTenant 条件加）
 	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
@@ -200,6 +240,7 @@ func (r *TagTranslationRepo) UpdateTranslation(ctx context.Context, id uint32, d
 				SetNillableDescription(data.Description).
 				SetNillableCoverImage(data.CoverImage).
 				SetNillableFullPath(data.FullPath).
+				SetNillableIsDraft(data.IsDraft).
 				SetUpdatedAt(time.Now())
 
 			// updated_by 强制由服务端 viewer context 推导，忽略客户端传入值
@@ -210,6 +251,15 @@ func (r *TagTranslationRepo) UpdateTranslation(ctx context.Context, id uint32, d
 			if data.Seo != nil {
 				builder.SetSeo(data.Seo)
 			}
+
+			if anchor != nil {
+				builder.SetNillableTranslationStatus(r.translationStatusConverter.ToEntity(&anchor.status))
+				if anchor.language != nil {
+					builder.SetSourceLanguage(*anchor.language).SetSourceRevision(*anchor.revision)
+				} else {
+					builder.ClearSourceLanguage().ClearSourceRevision()
+				}
+			}
 		},
 		func(s *sql.Selector) {
 			s.Where(sql.EQ(tagtranslation.FieldID, id))
@@ -220,9 +270,42 @@ func (r *TagTranslationRepo) UpdateTranslation(ctx context.Context, id uint32, d
 		return nil, contentV1.ErrorInternalServerError("update tag translation failed")
 	}
 
+	// 可翻译字段有修改时递增修订号，依据此语言版本的译文随之过期
+	if old != nil && tagTranslationChanged(old, dto) {
+		revision, bumpErr := bumpTagTranslationRevision(ctx, r.entClient.Client(), id)
+		if bumpErr != nil {
+			r.log.Errorf("bump tag translation revision failed: %s", bumpErr.Error())
+			return nil, contentV1.ErrorInternalServerError("bump tag translation revision failed")
+		}
+		dto.Revision = trans.Ptr(revision)
+	}
+
 	return dto, nil
 }
 
+// anchorTranslation 设置新建语言版本的依据：指定源语言时锚定到其当前修订号，否则作为原文。
+// batch 为同批创建的语言版本，源语言在其中时修订号为初始值 1。
+func (r *TagTranslationRepo) anchorTranslation(ctx context.Context, client *ent.Client, data *contentV1.TagTranslation, batch []*contentV1.TagTranslation) error {
+	anchor, err := resolveTranslationAnchor(data.GetLanguageCode(), data.GetSourceLanguage(), func(lang string) (uint32, bool, error) {
+		for _, item := range batch {
+			if item != data && item.GetLanguageCode() == lang {
+				return 1, true, nil
+			}
+		}
+		return tagTranslationRevision(ctx, client, data.GetTagId(), lang)
+	})
+	if err != nil {
+		return err
+	}
+
+	data.Revision = nil
+	data.TranslationStatus = trans.Ptr(anchor.status)
+	data.SourceLanguage = anchor.language
+	data.SourceRevision = anchor.revision
+
+	return nil
+}
+
 func (r *TagTranslationRepo) CountByBaseSlug(ctx context.Context, baseSlug string) (int64, error) {
 	c, err := r.entClient.Client().TagTranslation.Query().
 		Where(
@@ -253,11 +336,13 @@ func (r *TagTranslationRepo) TranslationExists(ctx context.Context, tagId uint32
 	return c > 0, nil
 }
 
-// ListAvailedLanguages lists the language codes of all translations available for the given tag ID.
+// ListAvailedLanguages lists the language codes of all published (non-draft) translations available for the given tag ID.
 func (r *TagTranslationRepo) ListAvailedLanguages(ctx context.Context, tagId uint32) ([]string, error) {
 	entities, err := r.entClient.Client().TagTranslation.Query().
 		Where(
 			tagtranslation.TagIDEQ(tagId),
+			// 草稿翻译待审阅，不对外提供
+			tagtranslation.Or(tagtranslation.IsDraftIsNil(), tagtranslation.IsDraftEQ(false)),
 		).
 		Select(tagtranslation.FieldLanguageCode).
 		Strings(ctx)
@@ -0,0 +1,537 @@
+package data
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/tx7do/go-utils/trans"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	entCrud "github.com/tx7do/go-crud/entgo"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/category"
+	"go-wind-cms/app/core/service/internal/data/ent/categorytranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/language"
+	"go-wind-cms/app/core/service/internal/data/ent/page"
+	"go-wind-cms/app/core/service/internal/data/ent/pagetranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/post"
+	"go-wind-cms/app/core/service/internal/data/ent/posttranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/tag"
+	"go-wind-cms/app/core/service/internal/data/ent/tagtranslation"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+)
+
+const (
+	defaultWorkItemPageSize = 20
+	maxWorkItemPageSize     = 100
+)
+
+// dashboardEntityTypes 看板默认统计的内容类型
+var dashboardEntityTypes = []contentV1.TranslationJob_EntityType{
+	contentV1.TranslationJob_ENTITY_TYPE_POST,
+	contentV1.TranslationJob_ENTITY_TYPE_PAGE,
+	contentV1.TranslationJob_ENTITY_TYPE_CATEGORY,
+	contentV1.TranslationJob_ENTITY_TYPE_TAG,
+}
+
+// translationRow 看板所需的语言版本字段，四类内容的翻译表统一为此结构
+type translationRow struct {
+	id             uint32
+	entityID       uint32
+	languageCode   string
+	title          *string
+	isDraft        bool
+	machine        bool
+	status         contentV1.TranslationStatus
+	sourceLanguage *string
+	sourceRevision *uint32
+	revision       uint32
+	updatedAt      *time.Time
+}
+
+// translationStatusOf 把 ent 枚举转换为 proto 枚举；字段引入前的数据没有状态，视为原文
+func translationStatusOf[E ~string](status *E) contentV1.TranslationStatus {
+	if status == nil {
+		return contentV1.TranslationStatus_TRANSLATION_STATUS_SOURCE
+	}
+	if v, ok := contentV1.TranslationStatus_value[string(*status)]; ok {
+		return contentV1.TranslationStatus(v)
+	}
+	return contentV1.TranslationStatus_TRANSLATION_STATUS_SOURCE
+}
+
+// TranslationDashboardRepo 翻译看板：按内容类型与语言统计覆盖率，列出待处理的语言版本。
+//
+// 统计在内存中完成：读取未删除的内容ID与其语言版本的少量字段（不含正文），数据量与内容数同阶。
+type TranslationDashboardRepo struct {
+	entClient *entCrud.EntClient[*ent.Client]
+	log       *log.Helper
+}
+
+func NewTranslationDashboardRepo(ctx *bootstrap.Context, entClient *entCrud.EntClient[*ent.Client]) *TranslationDashboardRepo {
+	return &TranslationDashboardRepo{
+		entClient: entClient,
+		log:       ctx.NewLoggerHelper("translation-dashboard/repo/core-service"),
+	}
+}
+
+// GetDashboard 统计各内容类型、各语言的翻译覆盖情况
+func (r *TranslationDashboardRepo) GetDashboard(ctx context.Context, req *contentV1.GetTranslationDashboardRequest) (*contentV1.TranslationDashboard, error) {
+	entityTypes := req.GetEntityTypes()
+	if len(entityTypes) == 0 {
+		entityTypes = dashboardEntityTypes
+	}
+	for _, et := range entityTypes {
+		if !slices.Contains(dashboardEntityTypes, et) {
+			return nil, contentV1.ErrorBadRequest("unsupported entity type")
+		}
+	}
+
+	languages, err := r.languages(ctx, req.GetLanguageCodes())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &contentV1.TranslationDashboard{}
+	for _, et := range entityTypes {
+		ids, err := r.liveIDs(ctx, et)
+		if err != nil {
+			return nil, err
+		}
+		rows, err := r.translations(ctx, et, languages)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, lang := range languages {
+			resp.Items = append(resp.Items, summarizeCoverage(et, lang, ids, rows))
+		}
+	}
+
+	return resp, nil
+}
+
+// summarizeCoverage 汇总某类内容在某个语言下的翻译覆盖情况，只统计未删除内容的语言版本
+func summarizeCoverage(et contentV1.TranslationJob_EntityType, lang string, ids []uint32, rows []translationRow) *contentV1.TranslationCoverage {
+	live := make(map[uint32]struct{}, len(ids))
+	for _, id := range ids {
+		live[id] = struct{}{}
+	}
+
+	item := &contentV1.TranslationCoverage{
+		EntityType:   et,
+		LanguageCode: lang,
+		Total:        uint32(len(ids)),
+	}
+
+	var covered uint32
+	for _, row := range rows {
+		if row.languageCode != lang {
+			continue
+		}
+		if _, ok := live[row.entityID]; !ok {
+			continue
+		}
+
+		item.Translated++
+		if row.isDraft {
+			item.Drafts++
+			if row.machine {
+				item.MachineDrafts++
+			}
+		} else {
+			item.Published++
+		}
+
+		switch row.status {
+		case contentV1.TranslationStatus_TRANSLATION_STATUS_SOURCE:
+			item.Sources++
+		case contentV1.TranslationStatus_TRANSLATION_STATUS_STALE:
+			item.Stale++
+		}
+
+		if !row.isDraft && row.status != contentV1.TranslationStatus_TRANSLATION_STATUS_STALE {
+			covered++
+		}
+	}
+
+	if item.Translated < item.Total {
+		item.Missing = item.Total - item.Translated
+	}
+	if item.Total > 0 {
+		item.Coverage = float64(covered) / float64(item.Total)
+	}
+
+	return item
+}
+
+// ListWorkItems 列出某类内容在某个语言下待处理的语言版本
+func (r *TranslationDashboardRepo) ListWorkItems(ctx context.Context, req *contentV1.ListTranslationWorkItemsRequest) (*contentV1.ListTranslationWorkItemsResponse, error) {
+	if req == nil || !slices.Contains(dashboardEntityTypes, req.GetEntityType()) {
+		return nil, contentV1.ErrorBadRequest("unsupported entity type")
+	}
+	lang := strings.TrimSpace(req.GetLanguageCode())
+	if lang == "" {
+		return nil, contentV1.ErrorBadRequest("language code is required")
+	}
+
+	ids, err := r.liveIDs(ctx, req.GetEntityType())
+	if err != nil {
+		return nil, err
+	}
+	// 缺失项的标题与过期项的源语言修订号需要其他语言版本，因此读取全部语言
+	rows, err := r.translations(ctx, req.GetEntityType(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	items := collectWorkItems(req, lang, ids, rows)
+
+	pageSize := req.GetPageSize()
+	switch {
+	case pageSize == 0:
+		pageSize = defaultWorkItemPageSize
+	case pageSize > maxWorkItemPageSize:
+		pageSize = maxWorkItemPageSize
+	}
+	pageNum := max(req.GetPage(), 1)
+
+	total := uint32(len(items))
+	start := min((pageNum-1)*pageSize, total)
+	end := min(start+pageSize, total)
+
+	return &contentV1.ListTranslationWorkItemsResponse{
+		Items: items[start:end],
+		Total: total,
+	}, nil
+}
+
+// collectWorkItems 按待处理原因筛选语言版本：缺失按内容ID倒序，过期与草稿按更新时间倒序
+func collectWorkItems(req *contentV1.ListTranslationWorkItemsRequest, lang string, ids []uint32, rows []translationRow) []*contentV1.TranslationWorkItem {
+	et := req.GetEntityType()
+
+	live := make(map[uint32]struct{}, len(ids))
+	for _, id := range ids {
+		live[id] = struct{}{}
+	}
+	byEntity := make(map[uint32][]translationRow)
+	for _, row := range rows {
+		if _, ok := live[row.entityID]; ok {
+			byEntity[row.entityID] = append(byEntity[row.entityID], row)
+		}
+	}
+	find := func(entityID uint32, languageCode string) *translationRow {
+		for i, row := range byEntity[entityID] {
+			if strings.EqualFold(row.languageCode, languageCode) {
+				return &byEntity[entityID][i]
+			}
+		}
+		return nil
+	}
+
+	var items []*contentV1.TranslationWorkItem
+
+	issue := req.GetIssue()
+	if issue == contentV1.TranslationWorkItem_ISSUE_UNSPECIFIED {
+		issue = contentV1.TranslationWorkItem_ISSUE_STALE
+	}
+
+	if issue == contentV1.TranslationWorkItem_ISSUE_MISSING {
+		sorted := slices.Clone(ids)
+		slices.SortFunc(sorted, func(a, b uint32) int { return int(int64(b) - int64(a)) })
+
+		for _, id := range sorted {
+			if find(id, lang) != nil {
+				continue
+			}
+			item := &contentV1.TranslationWorkItem{
+				EntityType:   et,
+				EntityId:     id,
+				LanguageCode: lang,
+				Issue:        issue,
+			}
+			// 取任一已有语言版本的标题，优先原文
+			for _, row := range byEntity[id] {
+				if row.title == nil {
+					continue
+				}
+				if item.Title == nil || row.status == contentV1.TranslationStatus_TRANSLATION_STATUS_SOURCE {
+					item.Title = row.title
+				}
+			}
+			items = append(items, item)
+		}
+		return items
+	}
+
+	var matched []translationRow
+	for _, entityRows := range byEntity {
+		for _, row := range entityRows {
+			if row.languageCode != lang {
+				continue
+			}
+			switch issue {
+			case contentV1.TranslationWorkItem_ISSUE_STALE:
+				if row.status != contentV1.TranslationStatus_TRANSLATION_STATUS_STALE {
+					continue
+				}
+			case contentV1.TranslationWorkItem_ISSUE_DRAFT:
+				if !row.isDraft {
+					continue
+				}
+			default:
+				continue
+			}
+			if req.SourceLanguage != nil && !strings.EqualFold(trans.StringValue(row.sourceLanguage), req.GetSourceLanguage()) {
+				continue
+			}
+			if req.MachineTranslated != nil && row.machine != req.GetMachineTranslated() {
+				continue
+			}
+			matched = append(matched, row)
+		}
+	}
+	slices.SortFunc(matched, func(a, b translationRow) int {
+		ta, tb := trans.TimeValue(a.updatedAt), trans.TimeValue(b.updatedAt)
+		if c := tb.Compare(ta); c != 0 {
+			return c
+		}
+		return int(int64(b.id) - int64(a.id))
+	})
+
+	for _, row := range matched {
+		item := &contentV1.TranslationWorkItem{
+			EntityType:        et,
+			EntityId:          row.entityID,
+			LanguageCode:      row.languageCode,
+			Issue:             issue,
+			TranslationId:     trans.Ptr(row.id),
+			Title:             row.title,
+			SourceLanguage:    row.sourceLanguage,
+			SourceRevision:    row.sourceRevision,
+			MachineTranslated: trans.Ptr(row.machine),
+		}
+		if row.updatedAt != nil {
+			item.UpdatedAt = timestamppb.New(*row.updatedAt)
+		}
+		if row.sourceLanguage != nil {
+			if source := find(row.entityID, *row.sourceLanguage); source != nil {
+				item.CurrentSourceRevision = trans.Ptr(source.revision)
+			}
+		}
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// languages 返回统计的语言：未指定时为所有启用的语言，按排序值排列
+func (r *TranslationDashboardRepo) languages(ctx context.Context, requested []string) ([]string, error) {
+	if len(requested) > 0 {
+		var out []string
+		for _, code := range requested {
+			if code = strings.TrimSpace(code); code != "" && !slices.Contains(out, code) {
+				out = append(out, code)
+			}
+		}
+		return out, nil
+	}
+
+	entities, err := r.entClient.Client().Language.Query().
+		Where(language.IsEnabledEQ(true)).
+		Order(ent.Asc(language.FieldSortOrder), ent.Asc(language.FieldID)).
+		Select(language.FieldLanguageCode).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query languages failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query languages failed")
+	}
+
+	out := make([]string, 0, len(entities))
+	for _, entity := range entities {
+		if code := trans.StringValue(entity.LanguageCode); code != "" {
+			out = append(out, code)
+		}
+	}
+	return out, nil
+}
+
+// liveIDs 返回本租户未移入回收站的内容ID
+func (r *TranslationDashboardRepo) liveIDs(ctx context.Context, et contentV1.TranslationJob_EntityType) ([]uint32, error) {
+	client := r.entClient.Client()
+	tid, hasTenant := maybeTenantFromViewer(ctx)
+
+	var (
+		ids []uint32
+		err error
+	)
+	switch et {
+	case contentV1.TranslationJob_ENTITY_TYPE_POST:
+		q := client.Post.Query().Where(post.DeletedAtIsNil())
+		if hasTenant {
+			q.Where(post.TenantIDEQ(tid))
+		}
+		ids, err = q.IDs(ctx)
+	case contentV1.TranslationJob_ENTITY_TYPE_PAGE:
+		q := client.Page.Query().Where(page.DeletedAtIsNil())
+		if hasTenant {
+			q.Where(page.TenantIDEQ(tid))
+		}
+		ids, err = q.IDs(ctx)
+	case contentV1.TranslationJob_ENTITY_TYPE_CATEGORY:
+		q := client.Category.Query().Where(category.DeletedAtIsNil())
+		if hasTenant {
+			q.Where(category.TenantIDEQ(tid))
+		}
+		ids, err = q.IDs(ctx)
+	case contentV1.TranslationJob_ENTITY_TYPE_TAG:
+		q := client.Tag.Query().Where(tag.DeletedAtIsNil())
+		if hasTenant {
+			q.Where(tag.TenantIDEQ(tid))
+		}
+		ids, err = q.IDs(ctx)
+	default:
+		return nil, contentV1.ErrorBadRequest("unsupported entity type")
+	}
+	if err != nil {
+		r.log.Errorf("query %s ids failed: %s", et, err.Error())
+		return nil, contentV1.ErrorInternalServerError("query content failed")
+	}
+
+	return ids, nil
+}
+
+// translations 读取本租户的语言版本；languages 为空时读取全部语言
+func (r *TranslationDashboardRepo) translations(ctx context.Context, et contentV1.TranslationJob_EntityType, languages []string) ([]translationRow, error) {
+	client := r.entClient.Client()
+	tid, hasTenant := maybeTenantFromViewer(ctx)
+
+	var (
+		rows []translationRow
+		err  error
+	)
+	switch et {
+	case contentV1.TranslationJob_ENTITY_TYPE_POST:
+		q := client.PostTranslation.Query()
+		if hasTenant {
+			q.Where(posttranslation.TenantIDEQ(tid))
+		}
+		if len(languages) > 0 {
+			q.Where(posttranslation.LanguageCodeIn(languages...))
+		}
+		var entities []*ent.PostTranslation
+		if entities, err = q.Select(
+			posttranslation.FieldID, posttranslation.FieldPostID, posttranslation.FieldLanguageCode,
+			posttranslation.FieldTitle, posttranslation.FieldIsDraft, posttranslation.FieldMachineTranslated,
+			posttranslation.FieldTranslationStatus, posttranslation.FieldSourceLanguage, posttranslation.FieldSourceRevision,
+			posttranslation.FieldRevision, posttranslation.FieldUpdatedAt, posttranslation.FieldCreatedAt,
+		).All(ctx); err == nil {
+			for _, e := range entities {
+				rows = append(rows, translationRow{
+					id: e.ID, entityID: trans.Uint32Value(e.PostID), languageCode: trans.StringValue(e.LanguageCode),
+					title: e.Title, isDraft: trans.BoolValue(e.IsDraft), machine: trans.BoolValue(e.MachineTranslated),
+					status: translationStatusOf(e.TranslationStatus), sourceLanguage: e.SourceLanguage, sourceRevision: e.SourceRevision,
+					revision: translationRevisionValue(e.Revision), updatedAt: latestTime(e.UpdatedAt, e.CreatedAt),
+				})
+			}
+		}
+
+	case contentV1.TranslationJob_ENTITY_TYPE_PAGE:
+		q := client.PageTranslation.Query()
+		if hasTenant {
+			q.Where(pagetranslation.TenantIDEQ(tid))
+		}
+		if len(languages) > 0 {
+			q.Where(pagetranslation.LanguageCodeIn(languages...))
+		}
+		var entities []*ent.PageTranslation
+		if entities, err = q.Select(
+			pagetranslation.FieldID, pagetranslation.FieldPageID, pagetranslation.FieldLanguageCode,
+			pagetranslation.FieldTitle, pagetranslation.FieldIsDraft, pagetranslation.FieldMachineTranslated,
+			pagetranslation.FieldTranslationStatus, pagetranslation.FieldSourceLanguage, pagetranslation.FieldSourceRevision,
+			pagetranslation.FieldRevision, pagetranslation.FieldUpdatedAt, pagetranslation.FieldCreatedAt,
+		).All(ctx); err == nil {
+			for _, e := range entities {
+				rows = append(rows, translationRow{
+					id: e.ID, entityID: trans.Uint32Value(e.PageID), languageCode: trans.StringValue(e.LanguageCode),
+					title: e.Title, isDraft: trans.BoolValue(e.IsDraft), machine: trans.BoolValue(e.MachineTranslated),
+					status: translationStatusOf(e.TranslationStatus), sourceLanguage: e.SourceLanguage, sourceRevision: e.SourceRevision,
+					revision: translationRevisionValue(e.Revision), updatedAt: latestTime(e.UpdatedAt, e.CreatedAt),
+				})
+			}
+		}
+
+	case contentV1.TranslationJob_ENTITY_TYPE_CATEGORY:
+		q := client.CategoryTranslation.Query()
+		if hasTenant {
+			q.Where(categorytranslation.TenantIDEQ(tid))
+		}
+		if len(languages) > 0 {
+			q.Where(categorytranslation.LanguageCodeIn(languages...))
+		}
+		var entities []*ent.CategoryTranslation
+		if entities, err = q.Select(
+			categorytranslation.FieldID, categorytranslation.FieldCategoryID, categorytranslation.FieldLanguageCode,
+			categorytranslation.FieldName, categorytranslation.FieldIsDraft, categorytranslation.FieldMachineTranslated,
+			categorytranslation.FieldTranslationStatus, categorytranslation.FieldSourceLanguage, categorytranslation.FieldSourceRevision,
+			categorytranslation.FieldRevision, categorytranslation.FieldUpdatedAt, categorytranslation.FieldCreatedAt,
+		).All(ctx); err == nil {
+			for _, e := range entities {
+				rows = append(rows, translationRow{
+					id: e.ID, entityID: trans.Uint32Value(e.CategoryID), languageCode: trans.StringValue(e.LanguageCode),
+					title: e.Name, isDraft: trans.BoolValue(e.IsDraft), machine: trans.BoolValue(e.MachineTranslated),
+					status: translationStatusOf(e.TranslationStatus), sourceLanguage: e.SourceLanguage, sourceRevision: e.SourceRevision,
+					revision: translationRevisionValue(e.Revision), updatedAt: latestTime(e.UpdatedAt, e.CreatedAt),
+				})
+			}
+		}
+
+	case contentV1.TranslationJob_ENTITY_TYPE_TAG:
+		q := client.TagTranslation.Query()
+		if hasTenant {
+			q.Where(tagtranslation.TenantIDEQ(tid))
+		}
+		if len(languages) > 0 {
+			q.Where(tagtranslation.LanguageCodeIn(languages...))
+		}
+		var entities []*ent.TagTranslation
+		if entities, err = q.Select(
+			tagtranslation.FieldID, tagtranslation.FieldTagID, tagtranslation.FieldLanguageCode,
+			tagtranslation.FieldName, tagtranslation.FieldIsDraft, tagtranslation.FieldMachineTranslated,
+			tagtranslation.FieldTranslationStatus, tagtranslation.FieldSourceLanguage, tagtranslation.FieldSourceRevision,
+			tagtranslation.FieldRevision, tagtranslation.FieldUpdatedAt, tagtranslation.FieldCreatedAt,
+		).All(ctx); err == nil {
+			for _, e := range entities {
+				rows = append(rows, translationRow{
+					id: e.ID, entityID: trans.Uint32Value(e.TagID), languageCode: trans.StringValue(e.LanguageCode),
+					title: e.Name, isDraft: trans.BoolValue(e.IsDraft), machine: trans.BoolValue(e.MachineTranslated),
+					status: translationStatusOf(e.TranslationStatus), sourceLanguage: e.SourceLanguage, sourceRevision: e.SourceRevision,
+					revision: translationRevisionValue(e.Revision), updatedAt: latestTime(e.UpdatedAt, e.CreatedAt),
+				})
+			}
+		}
+
+	default:
+		return nil, contentV1.ErrorBadRequest("unsupported entity type")
+	}
+	if err != nil {
+		r.log.Errorf("query %s translations failed: %s", et, err.Error())
+		return nil, contentV1.ErrorInternalServerError("query translations failed")
+	}
+
+	return rows, nil
+}
+
+// latestTime 取更新时间，从未更新时取创建时间
+func latestTime(updatedAt, createdAt *time.Time) *time.Time {
+	if updatedAt != nil {
+		return updatedAt
+	}
+	return createdAt
+}
@@ -0,0 +1,706 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/tx7do/go-utils/copierutil"
+	"github.com/tx7do/go-utils/mapper"
+	"github.com/tx7do/go-utils/trans"
+
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	entCrud "github.com/tx7do/go-crud/entgo"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/category"
+	"go-wind-cms/app/core/service/internal/data/ent/categorytranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/page"
+	"go-wind-cms/app/core/service/internal/data/ent/pagetranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/post"
+	"go-wind-cms/app/core/service/internal/data/ent/posttranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/predicate"
+	"go-wind-cms/app/core/service/internal/data/ent/tag"
+	"go-wind-cms/app/core/service/internal/data/ent/tagtranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/translationjob"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/count"
+	"go-wind-cms/pkg/content/locale"
+)
+
+const (
+	// maxTranslationJobTargets 单个作业最多的目标语言数
+	maxTranslationJobTargets = 20
+
+	// translationJobStaleAfter 执行中的作业超过该时长未结束（如 worker 崩溃），允许重新认领
+	translationJobStaleAfter = 30 * time.Minute
+)
+
+// TranslationSource 机器翻译的源语言版本。
+//
+// Fields 为待翻译的文本字段（字段名到原文，如 title、summary、content），Seo 中的文本字段同样翻译；
+// 其余字段（slug、缩略图、封面图）原样复制到草稿，由编辑审阅时调整。
+// Revision 为源语言版本的修订号，草稿据此记录其依据，源语言版本再修改时标记为过期。
+type TranslationSource struct {
+	Fields   map[string]string
+	Seo      *contentV1.SeoMeta
+	Revision uint32
+
+	Slug       *string
+	Thumbnail  *string
+	CoverImage *string
+}
+
+// field 取翻译后的字段值，源语言版本为空的字段保持为空
+func (s *TranslationSource) field(name string) *string {
+	if v, ok := s.Fields[name]; ok {
+		return trans.Ptr(v)
+	}
+	return nil
+}
+
+// TranslationJobRepo 机器翻译作业：创建、认领、进度记录，以及把译文写入草稿翻译。
+type TranslationJobRepo struct {
+	entClient *entCrud.EntClient[*ent.Client]
+	log       *log.Helper
+
+	mapper *mapper.CopierMapper[contentV1.TranslationJob, ent.TranslationJob]
+
+	repository *entCrud.Repository[
+		ent.TranslationJobQuery, ent.TranslationJobSelect,
+		ent.TranslationJobCreate, ent.TranslationJobCreateBulk,
+		ent.TranslationJobUpdate, ent.TranslationJobUpdateOne,
+		ent.TranslationJobDelete,
+		predicate.TranslationJob,
+		contentV1.TranslationJob, ent.TranslationJob,
+	]
+
+	statusConverter     *mapper.EnumTypeConverter[contentV1.TranslationJob_TranslationJobStatus, translationjob.Status]
+	entityTypeConverter *mapper.EnumTypeConverter[contentV1.TranslationJob_EntityType, translationjob.EntityType]
+}
+
+func NewTranslationJobRepo(ctx *bootstrap.Context, entClient *entCrud.EntClient[*ent.Client]) *TranslationJobRepo {
+	repo := &TranslationJobRepo{
+		entClient: entClient,
+		log:       ctx.NewLoggerHelper("translation-job/repo/core-service"),
+		mapper:    mapper.NewCopierMapper[contentV1.TranslationJob, ent.TranslationJob](),
+		statusConverter: mapper.NewEnumTypeConverter[contentV1.TranslationJob_TranslationJobStatus, translationjob.Status](
+			contentV1.TranslationJob_TranslationJobStatus_name, contentV1.TranslationJob_TranslationJobStatus_value,
+		),
+		entityTypeConverter: mapper.NewEnumTypeConverter[contentV1.TranslationJob_EntityType, translationjob.EntityType](
+			contentV1.TranslationJob_EntityType_name, contentV1.TranslationJob_EntityType_value,
+		),
+	}
+
+	repo.init()
+
+	return repo
+}
+
+func (r *TranslationJobRepo) init() {
+	r.repository = entCrud.NewRepository[
+		ent.TranslationJobQuery, ent.TranslationJobSelect,
+		ent.TranslationJobCreate, ent.TranslationJobCreateBulk,
+		ent.TranslationJobUpdate, ent.TranslationJobUpdateOne,
+		ent.TranslationJobDelete,
+		predicate.TranslationJob,
+		contentV1.TranslationJob, ent.TranslationJob,
+	](r.mapper)
+
+	r.mapper.AppendConverters(copierutil.NewTimeStringConverterPair())
+	r.mapper.AppendConverters(copierutil.NewTimeTimestamppbConverterPair())
+	r.mapper.AppendConverters(r.statusConverter.NewConverterPair())
+	r.mapper.AppendConverters(r.entityTypeConverter.NewConverterPair())
+}
+
+func (r *TranslationJobRepo) List(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListTranslationJobResponse, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	builder := r.entClient.Client().TranslationJob.Query()
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(translationjob.TenantIDEQ(tid))
+	}
+
+	ret, err := r.repository.ListWithPaging(ctx, builder, builder.Clone(), req)
+	if err != nil {
+		return nil, err
+	}
+	if ret == nil {
+		return &contentV1.ListTranslationJobResponse{Total: 0, Items: nil}, nil
+	}
+
+	return &contentV1.ListTranslationJobResponse{
+		Total: ret.Total,
+		Items: ret.Items,
+	}, nil
+}
+
+func (r *TranslationJobRepo) Get(ctx context.Context, id uint32) (*contentV1.TranslationJob, error) {
+	builder := r.entClient.Client().TranslationJob.Query().
+		Where(translationjob.IDEQ(id))
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(translationjob.TenantIDEQ(tid))
+	}
+
+	entity, err := builder.Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound("translation job not found")
+		}
+		r.log.Errorf("query translation job failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query translation job failed")
+	}
+
+	return r.mapper.ToDTO(entity), nil
+}
+
+// normalizeTargetLanguages 校验目标语言：格式合法、去重（忽略大小写）、不含源语言
+func normalizeTargetLanguages(source string, targets []string) ([]string, error) {
+	seen := map[string]struct{}{strings.ToLower(source): {}}
+	out := make([]string, 0, len(targets))
+	for _, code := range targets {
+		code = strings.TrimSpace(code)
+		if !locale.ValidCode(code) {
+			return nil, fmt.Errorf("invalid target language %q", code)
+		}
+		key := strings.ToLower(code)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		out = append(out, code)
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no target language other than the source language")
+	}
+	if len(out) > maxTranslationJobTargets {
+		return nil, fmt.Errorf("too many target languages (max %d)", maxTranslationJobTargets)
+	}
+	return out, nil
+}
+
+// Create 创建作业：确认内容属于当前租户且源语言版本存在
+func (r *TranslationJobRepo) Create(ctx context.Context, req *contentV1.CreateTranslationJobRequest) (*contentV1.TranslationJob, error) {
+	if req == nil || req.GetEntityId() == 0 {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+	if !locale.ValidCode(req.GetSourceLanguage()) {
+		return nil, contentV1.ErrorBadRequest("invalid source language")
+	}
+	targets, err := normalizeTargetLanguages(req.GetSourceLanguage(), req.GetTargetLanguages())
+	if err != nil {
+		return nil, contentV1.ErrorBadRequest(err.Error())
+	}
+
+	et := req.GetEntityType()
+	entityType := r.entityTypeConverter.ToEntity(&et)
+	if entityType == nil {
+		return nil, contentV1.ErrorBadRequest("unsupported entity type")
+	}
+
+	tid, hasTenant := maybeTenantFromViewer(ctx)
+	var tenantID *uint32
+	if hasTenant {
+		tenantID = trans.Ptr(tid)
+	}
+	if _, err = r.loadSource(ctx, *entityType, req.GetEntityId(), req.GetSourceLanguage(), tenantID); err != nil {
+		return nil, err
+	}
+
+	builder := r.entClient.Client().TranslationJob.Create().
+		SetEntityType(*entityType).
+		SetEntityID(req.GetEntityId()).
+		SetSourceLanguage(req.GetSourceLanguage()).
+		SetTargetLanguages(targets).
+		SetOverwriteDrafts(req.OverwriteDrafts == nil || req.GetOverwriteDrafts()).
+		SetStatus(translationjob.StatusTranslationJobStatusPending).
+		SetTotal(uint32(len(targets))).
+		SetCreatedAt(time.Now())
+	if hasTenant {
+		builder.SetTenantID(tid)
+	}
+	if operatorID, ok := viewerUserIDFromContext(ctx); ok {
+		builder.SetCreatedBy(operatorID)
+	}
+
+	entity, err := builder.Save(ctx)
+	if err != nil {
+		r.log.Errorf("insert translation job failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("insert translation job failed")
+	}
+
+	return r.mapper.ToDTO(entity), nil
+}
+
+// Claim 认领待执行（或执行超时）的作业并置为执行中；已被认领或已结束时返回 nil
+func (r *TranslationJobRepo) Claim(ctx context.Context, id uint32) (*ent.TranslationJob, error) {
+	now := time.Now()
+	n, err := r.entClient.Client().TranslationJob.Update().
+		Where(
+			translationjob.IDEQ(id),
+			translationjob.Or(
+				translationjob.StatusEQ(translationjob.StatusTranslationJobStatusPending),
+				translationjob.And(
+					translationjob.StatusEQ(translationjob.StatusTranslationJobStatusRunning),
+					translationjob.StartedAtLT(now.Add(-translationJobStaleAfter)),
+				),
+			),
+		).
+		SetStatus(translationjob.StatusTranslationJobStatusRunning).
+		SetStartedAt(now).
+		SetProcessed(0).
+		ClearResults().
+		SetUpdatedAt(now).
+		Save(ctx)
+	if err != nil {
+		r.log.Errorf("claim translation job failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("claim translation job failed")
+	}
+	if n == 0 {
+		return nil, nil
+	}
+
+	entity, err := r.entClient.Client().TranslationJob.Get(ctx, id)
+	if err != nil {
+		r.log.Errorf("query translation job failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query translation job failed")
+	}
+	return entity, nil
+}
+
+// UpdateProgress 记录已处理的目标语言结果
+func (r *TranslationJobRepo) UpdateProgress(ctx context.Context, id uint32, results []*contentV1.TranslationJobResult) error {
+	if err := r.entClient.Client().TranslationJob.UpdateOneID(id).
+		SetProcessed(uint32(len(results))).
+		SetResults(results).
+		SetUpdatedAt(time.Now()).
+		Exec(ctx); err != nil {
+		r.log.Errorf("update translation job progress failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("update translation job progress failed")
+	}
+	return nil
+}
+
+// Finish 结束作业；cause 非空时作业失败并记录原因
+func (r *TranslationJobRepo) Finish(ctx context.Context, id uint32, results []*contentV1.TranslationJobResult, cause error) error {
+	now := time.Now()
+	builder := r.entClient.Client().TranslationJob.UpdateOneID(id).
+		SetProcessed(uint32(len(results))).
+		SetResults(results).
+		SetFinishedAt(now).
+		SetUpdatedAt(now)
+
+	if cause != nil {
+		msg := cause.Error()
+		if e := kerrors.FromError(cause); e != nil && e.GetMessage() != "" {
+			msg = e.GetMessage()
+		}
+		if len(msg) > 1024 {
+			msg = msg[:1024]
+		}
+		builder.SetStatus(translationjob.StatusTranslationJobStatusFailed).SetLastError(msg)
+	} else {
+		builder.SetStatus(translationjob.StatusTranslationJobStatusCompleted)
+	}
+
+	if err := builder.Exec(ctx); err != nil {
+		r.log.Errorf("finish translation job failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("finish translation job failed")
+	}
+	return nil
+}
+
+// LoadSource 读取作业内容的源语言版本
+func (r *TranslationJobRepo) LoadSource(ctx context.Context, job *ent.TranslationJob) (*TranslationSource, error) {
+	return r.loadSource(ctx, job.EntityType, job.EntityID, job.SourceLanguage, job.TenantID)
+}
+
+func (r *TranslationJobRepo) loadSource(ctx context.Context, et translationjob.EntityType, id uint32, lang string, tenantID *uint32) (*TranslationSource, error) {
+	client := r.entClient.Client()
+	tid := trans.Uint32Value(tenantID)
+
+	// 确认内容存在、未移入回收站且属于作业所在租户
+	var (
+		exist bool
+		err   error
+	)
+	switch et {
+	case translationjob.EntityTypeEntityTypePost:
+		q := client.Post.Query().Where(post.IDEQ(id), post.DeletedAtIsNil())
+		if tid != 0 {
+			q.Where(post.TenantIDEQ(tid))
+		}
+		exist, err = q.Exist(ctx)
+	case translationjob.EntityTypeEntityTypePage:
+		q := client.Page.Query().Where(page.IDEQ(id), page.DeletedAtIsNil())
+		if tid != 0 {
+			q.Where(page.TenantIDEQ(tid))
+		}
+		exist, err = q.Exist(ctx)
+	case translationjob.EntityTypeEntityTypeCategory:
+		q := client.Category.Query().Where(category.IDEQ(id), category.DeletedAtIsNil())
+		if tid != 0 {
+			q.Where(category.TenantIDEQ(tid))
+		}
+		exist, err = q.Exist(ctx)
+	case translationjob.EntityTypeEntityTypeTag:
+		q := client.Tag.Query().Where(tag.IDEQ(id), tag.DeletedAtIsNil())
+		if tid != 0 {
+			q.Where(tag.TenantIDEQ(tid))
+		}
+		exist, err = q.Exist(ctx)
+	default:
+		return nil, contentV1.ErrorBadRequest("unsupported entity type")
+	}
+	if err != nil {
+		r.log.Errorf("query %s failed: %s", et, err.Error())
+		return nil, contentV1.ErrorInternalServerError("query content failed")
+	}
+	if !exist {
+		return nil, contentV1.ErrorNotFound(fmt.Sprintf("content %d not found", id))
+	}
+
+	src := &TranslationSource{Fields: map[string]string{}}
+	setField := func(name string, v *string) {
+		if v != nil && strings.TrimSpace(*v) != "" {
+			src.Fields[name] = *v
+		}
+	}
+
+	switch et {
+	case translationjob.EntityTypeEntityTypePost:
+		var t *ent.PostTranslation
+		if t, err = client.PostTranslation.Query().
+			Where(posttranslation.PostIDEQ(id), posttranslation.LanguageCodeEQ(lang)).
+			First(ctx); err == nil {
+			setField("title", t.Title)
+			setField("summary", t.Summary)
+			setField("content", t.Content)
+			setField("original_content", t.OriginalContent)
+			src.Seo, src.Slug, src.Thumbnail = t.Seo, t.Slug, t.Thumbnail
+			src.Revision = translationRevisionValue(t.Revision)
+		}
+	case translationjob.EntityTypeEntityTypePage:
+		var t *ent.PageTranslation
+		if t, err = client.PageTranslation.Query().
+			Where(pagetranslation.PageIDEQ(id), pagetranslation.LanguageCodeEQ(lang)).
+			First(ctx); err == nil {
+			setField("title", t.Title)
+			src.Seo, src.Slug, src.Thumbnail, src.CoverImage = t.Seo, t.Slug, t.Thumbnail, t.CoverImage
+			src.Revision = translationRevisionValue(t.Revision)
+		}
+	case translationjob.EntityTypeEntityTypeCategory:
+		var t *ent.CategoryTranslation
+		if t, err = client.CategoryTranslation.Query().
+			Where(categorytranslation.CategoryIDEQ(id), categorytranslation.LanguageCodeEQ(lang)).
+			First(ctx); err == nil {
+			setField("name", t.Name)
+			setField("description", t.Description)
+			src.Seo, src.Slug, src.Thumbnail, src.CoverImage = t.Seo, t.Slug, t.Thumbnail, t.CoverImage
+			src.Revision = translationRevisionValue(t.Revision)
+		}
+	case translationjob.EntityTypeEntityTypeTag:
+		var t *ent.TagTranslation
+		if t, err = client.TagTranslation.Query().
+			Where(tagtranslation.TagIDEQ(id), tagtranslation.LanguageCodeEQ(lang)).
+			First(ctx); err == nil {
+			setField("name", t.Name)
+			setField("description", t.Description)
+			src.Seo, src.Slug, src.CoverImage = t.Seo, t.Slug, t.CoverImage
+			src.Revision = translationRevisionValue(t.Revision)
+		}
+	}
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound(fmt.Sprintf("source translation %q not found", lang))
+		}
+		r.log.Errorf("query source translation failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query source translation failed")
+	}
+
+	return src, nil
+}
+
+// findTarget 查询目标语言已有的翻译：返回其 ID（0 表示不存在），以及是否应跳过。
+// 人工翻译与已发布的翻译始终跳过；机器翻译草稿在作业不允许覆盖时跳过。
+func (r *TranslationJobRepo) findTarget(ctx context.Context, job *ent.TranslationJob, lang string) (uint32, bool, error) {
+	client := r.entClient.Client()
+
+	var (
+		id                         uint32
+		isDraft, machineTranslated *bool
+		err                        error
+	)
+	switch job.EntityType {
+	case translationjob.EntityTypeEntityTypePost:
+		var t *ent.PostTranslation
+		if t, err = client.PostTranslation.Query().
+			Where(posttranslation.PostIDEQ(job.EntityID), posttranslation.LanguageCodeEQ(lang)).
+			First(ctx); err == nil {
+			id, isDraft, machineTranslated = t.ID, t.IsDraft, t.MachineTranslated
+		}
+	case translationjob.EntityTypeEntityTypePage:
+		var t *ent.PageTranslation
+		if t, err = client.PageTranslation.Query().
+			Where(pagetranslation.PageIDEQ(job.EntityID), pagetranslation.LanguageCodeEQ(lang)).
+			First(ctx); err == nil {
+			id, isDraft, machineTranslated = t.ID, t.IsDraft, t.MachineTranslated
+		}
+	case translationjob.EntityTypeEntityTypeCategory:
+		var t *ent.CategoryTranslation
+		if t, err = client.CategoryTranslation.Query().
+			Where(categorytranslation.CategoryIDEQ(job.EntityID), categorytranslation.LanguageCodeEQ(lang)).
+			First(ctx); err == nil {
+			id, isDraft, machineTranslated = t.ID, t.IsDraft, t.MachineTranslated
+		}
+	case translationjob.EntityTypeEntityTypeTag:
+		var t *ent.TagTranslation
+		if t, err = client.TagTranslation.Query().
+			Where(tagtranslation.TagIDEQ(job.EntityID), tagtranslation.LanguageCodeEQ(lang)).
+			First(ctx); err == nil {
+			id, isDraft, machineTranslated = t.ID, t.IsDraft, t.MachineTranslated
+		}
+	default:
+		return 0, false, contentV1.ErrorBadRequest("unsupported entity type")
+	}
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return 0, false, nil
+		}
+		r.log.Errorf("query target translation [%s] failed: %s", lang, err.Error())
+		return 0, false, contentV1.ErrorInternalServerError("query target translation failed")
+	}
+
+	skip := !trans.BoolValue(isDraft) || !trans.BoolValue(machineTranslated) || !job.OverwriteDrafts
+	return id, skip, nil
+}
+
+// ShouldSkip 目标语言已有不可覆盖的翻译时返回其 ID 与 true，worker 据此省去翻译调用
+func (r *TranslationJobRepo) ShouldSkip(ctx context.Context, job *ent.TranslationJob, lang string) (uint32, bool, error) {
+	return r.findTarget(ctx, job, lang)
+}
+
+// SaveDraft 把译文写入目标语言的机器翻译草稿：不存在时新建，已有机器翻译草稿时按作业配置覆盖，
+// 人工翻译或已发布的翻译跳过
+func (r *TranslationJobRepo) SaveDraft(ctx context.Context, job *ent.TranslationJob, lang string, src *TranslationSource) (*contentV1.TranslationJobResult, error) {
+	existingID, skip, err := r.findTarget(ctx, job, lang)
+	if err != nil {
+		return nil, err
+	}
+	if skip {
+		return &contentV1.TranslationJobResult{
+			Language:      lang,
+			Outcome:       contentV1.TranslationJobResult_OUTCOME_SKIPPED,
+			TranslationId: trans.Ptr(existingID),
+		}, nil
+	}
+
+	client := r.entClient.Client()
+	now := time.Now()
+	tid := trans.Uint32Value(job.TenantID)
+	savedID := existingID
+
+	switch job.EntityType {
+	case translationjob.EntityTypeEntityTypePost:
+		wordCount := uint32(count.NewContentCounter(src.Fields["content"]).RawChars())
+		if existingID == 0 {
+			builder := client.PostTranslation.Create().
+				SetPostID(job.EntityID).
+				SetLanguageCode(lang).
+				SetNillableTitle(src.field("title")).
+				SetNillableSlug(src.Slug).
+				SetNillableSummary(src.field("summary")).
+				SetNillableContent(src.field("content")).
+				SetNillableOriginalContent(src.field("original_content")).
+				SetNillableThumbnail(src.Thumbnail).
+				SetWordCount(wordCount).
+				SetIsDraft(true).
+				SetMachineTranslated(true).
+				SetSourceLanguage(job.SourceLanguage).
+				SetSourceRevision(src.Revision).
+				SetTranslationStatus(posttranslation.TranslationStatusTranslationStatusCurrent).
+				SetNillableCreatedBy(job.CreatedBy).
+				SetCreatedAt(now)
+			if tid != 0 {
+				builder.SetTenantID(tid)
+			}
+			if src.Seo != nil {
+				builder.SetSeo(src.Seo)
+			}
+			var created *ent.PostTranslation
+			if created, err = builder.Save(ctx); err == nil {
+				savedID = created.ID
+			}
+		} else {
+			builder := client.PostTranslation.UpdateOneID(existingID).
+				SetNillableTitle(src.field("title")).
+				SetNillableSummary(src.field("summary")).
+				SetNillableContent(src.field("content")).
+				SetNillableOriginalContent(src.field("original_content")).
+				SetWordCount(wordCount).
+				SetSourceLanguage(job.SourceLanguage).
+				SetSourceRevision(src.Revision).
+				SetTranslationStatus(posttranslation.TranslationStatusTranslationStatusCurrent).
+				SetUpdatedAt(now)
+			if src.Seo != nil {
+				builder.SetSeo(src.Seo)
+			}
+			err = builder.Exec(ctx)
+			if err == nil {
+				_, err = bumpPostTranslationRevision(ctx, client, existingID)
+			}
+		}
+
+	case translationjob.EntityTypeEntityTypePage:
+		if existingID == 0 {
+			builder := client.PageTranslation.Create().
+				SetPageID(job.EntityID).
+				SetLanguageCode(lang).
+				SetNillableTitle(src.field("title")).
+				SetNillableSlug(src.Slug).
+				SetNillableThumbnail(src.Thumbnail).
+				SetNillableCoverImage(src.CoverImage).
+				SetIsDraft(true).
+				SetMachineTranslated(true).
+				SetSourceLanguage(job.SourceLanguage).
+				SetSourceRevision(src.Revision).
+				SetTranslationStatus(pagetranslation.TranslationStatusTranslationStatusCurrent).
+				SetNillableCreatedBy(job.CreatedBy).
+				SetCreatedAt(now)
+			if tid != 0 {
+				builder.SetTenantID(tid)
+			}
+			if src.Seo != nil {
+				builder.SetSeo(src.Seo)
+			}
+			var created *ent.PageTranslation
+			if created, err = builder.Save(ctx); err == nil {
+				savedID = created.ID
+			}
+		} else {
+			builder := client.PageTranslation.UpdateOneID(existingID).
+				SetNillableTitle(src.field("title")).
+				SetSourceLanguage(job.SourceLanguage).
+				SetSourceRevision(src.Revision).
+				SetTranslationStatus(pagetranslation.TranslationStatusTranslationStatusCurrent).
+				SetUpdatedAt(now)
+			if src.Seo != nil {
+				builder.SetSeo(src.Seo)
+			}
+			err = builder.Exec(ctx)
+			if err == nil {
+				_, err = bumpPageTranslationRevision(ctx, client, existingID)
+			}
+		}
+
+	case translationjob.EntityTypeEntityTypeCategory:
+		if existingID == 0 {
+			builder := client.CategoryTranslation.Create().
+				SetCategoryID(job.EntityID).
+				SetLanguageCode(lang).
+				SetNillableName(src.field("name")).
+				SetNillableSlug(src.Slug).
+				SetNillableDescription(src.field("description")).
+				SetNillableThumbnail(src.Thumbnail).
+				SetNillableCoverImage(src.CoverImage).
+				SetIsDraft(true).
+				SetMachineTranslated(true).
+				SetSourceLanguage(job.SourceLanguage).
+				SetSourceRevision(src.Revision).
+				SetTranslationStatus(categorytranslation.TranslationStatusTranslationStatusCurrent).
+				SetNillableCreatedBy(job.CreatedBy).
+				SetCreatedAt(now)
+			if tid != 0 {
+				builder.SetTenantID(tid)
+			}
+			if src.Seo != nil {
+				builder.SetSeo(src.Seo)
+			}
+			var created *ent.CategoryTranslation
+			if created, err = builder.Save(ctx); err == nil {
+				savedID = created.ID
+			}
+		} else {
+			builder := client.CategoryTranslation.UpdateOneID(existingID).
+				SetNillableName(src.field("name")).
+				SetNillableDescription(src.field("description")).
+				SetSourceLanguage(job.SourceLanguage).
+				SetSourceRevision(src.Revision).
+				SetTranslationStatus(categorytranslation.TranslationStatusTranslationStatusCurrent).
+				SetUpdatedAt(now)
+			if src.Seo != nil {
+				builder.SetSeo(src.Seo)
+			}
+			err = builder.Exec(ctx)
+			if err == nil {
+				_, err = bumpCategoryTranslationRevision(ctx, client, existingID)
+			}
+		}
+
+	case translationjob.EntityTypeEntityTypeTag:
+		if existingID == 0 {
+			builder := client.TagTranslation.Create().
+				SetTagID(job.EntityID).
+				SetLanguageCode(lang).
+				SetNillableName(src.field("name")).
+				SetNillableSlug(src.Slug).
+				SetNillableDescription(src.field("description")).
+				SetNillableCoverImage(src.CoverImage).
+				SetIsDraft(true).
+				SetMachineTranslated(true).
+				SetSourceLanguage(job.SourceLanguage).
+				SetSourceRevision(src.Revision).
+				SetTranslationStatus(tagtranslation.TranslationStatusTranslationStatusCurrent).
+				SetNillableCreatedBy(job.CreatedBy).
+				SetCreatedAt(now)
+			if tid != 0 {
+				builder.SetTenantID(tid)
+			}
+			if src.Seo != nil {
+				builder.SetSeo(src.Seo)
+			}
+			var created *ent.TagTranslation
+			if created, err = builder.Save(ctx); err == nil {
+				savedID = created.ID
+			}
+		} else {
+			builder := client.TagTranslation.UpdateOneID(existingID).
+				SetNillableName(src.field("name")).
+				SetNillableDescription(src.field("description")).
+				SetSourceLanguage(job.SourceLanguage).
+				SetSourceRevision(src.Revision).
+				SetTranslationStatus(tagtranslation.TranslationStatusTranslationStatusCurrent).
+				SetUpdatedAt(now)
+			if src.Seo != nil {
+				builder.SetSeo(src.Seo)
+			}
+			err = builder.Exec(ctx)
+			if err == nil {
+				_, err = bumpTagTranslationRevision(ctx, client, existingID)
+			}
+		}
+	}
+	if err != nil {
+		r.log.Errorf("save machine translation draft [%s] failed: %s", lang, err.Error())
+		return nil, contentV1.ErrorInternalServerError("save machine translation draft failed")
+	}
+
+	outcome := contentV1.TranslationJobResult_OUTCOME_CREATED
+	if existingID != 0 {
+		outcome = contentV1.TranslationJobResult_OUTCOME_UPDATED
+	}
+	return &contentV1.TranslationJobResult{
+		Language:      lang,
+		Outcome:       outcome,
+		TranslationId: trans.Ptr(savedID),
+	}, nil
+}
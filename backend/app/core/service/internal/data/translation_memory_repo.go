@@ -0,0 +1,466 @@
+package data
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/tx7do/go-utils/copierutil"
+	"github.com/tx7do/go-utils/mapper"
+	"github.com/tx7do/go-utils/trans"
+
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	entCrud "github.com/tx7do/go-crud/entgo"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/predicate"
+	"go-wind-cms/app/core/service/internal/data/ent/translationmemory"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/locale"
+	"go-wind-cms/pkg/utils"
+)
+
+// MaxTmxUnits 单次导入或导出的 TMX 条目上限
+const MaxTmxUnits = 50000
+
+// TranslationMemoryPair 待写入翻译记忆的句段对
+type TranslationMemoryPair struct {
+	SourceLanguage string
+	TargetLanguage string
+	SourceText     string
+	TargetText     string
+}
+
+// TranslationMemoryRepo 翻译记忆：后台维护、TMX 导入导出，以及机器翻译时的句段查找与记录。
+type TranslationMemoryRepo struct {
+	entClient *entCrud.EntClient[*ent.Client]
+	log       *log.Helper
+
+	mapper *mapper.CopierMapper[contentV1.TranslationMemory, ent.TranslationMemory]
+
+	repository *entCrud.Repository[
+		ent.TranslationMemoryQuery, ent.TranslationMemorySelect,
+		ent.TranslationMemoryCreate, ent.TranslationMemoryCreateBulk,
+		ent.TranslationMemoryUpdate, ent.TranslationMemoryUpdateOne,
+		ent.TranslationMemoryDelete,
+		predicate.TranslationMemory,
+		contentV1.TranslationMemory, ent.TranslationMemory,
+	]
+
+	originConverter *mapper.EnumTypeConverter[contentV1.TranslationMemory_Origin, translationmemory.Origin]
+}
+
+func NewTranslationMemoryRepo(ctx *bootstrap.Context, entClient *entCrud.EntClient[*ent.Client]) *TranslationMemoryRepo {
+	repo := &TranslationMemoryRepo{
+		entClient: entClient,
+		log:       ctx.NewLoggerHelper("translation-memory/repo/core-service"),
+		mapper:    mapper.NewCopierMapper[contentV1.TranslationMemory, ent.TranslationMemory](),
+		originConverter: mapper.NewEnumTypeConverter[contentV1.TranslationMemory_Origin, translationmemory.Origin](
+			contentV1.TranslationMemory_Origin_name, contentV1.TranslationMemory_Origin_value,
+		),
+	}
+
+	repo.init()
+
+	return repo
+}
+
+func (r *TranslationMemoryRepo) init() {
+	r.repository = entCrud.NewRepository[
+		ent.TranslationMemoryQuery, ent.TranslationMemorySelect,
+		ent.TranslationMemoryCreate, ent.TranslationMemoryCreateBulk,
+		ent.TranslationMemoryUpdate, ent.TranslationMemoryUpdateOne,
+		ent.TranslationMemoryDelete,
+		predicate.TranslationMemory,
+		contentV1.TranslationMemory, ent.TranslationMemory,
+	](r.mapper)
+
+	r.mapper.AppendConverters(copierutil.NewTimeStringConverterPair())
+	r.mapper.AppendConverters(copierutil.NewTimeTimestamppbConverterPair())
+
+	r.mapper.AppendConverters(r.originConverter.NewConverterPair())
+}
+
+// segmentHash 句段的精确匹配键
+func segmentHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// translationMemoryTenant 按租户过滤；tenantID 为 0 时为平台级条目
+func translationMemoryTenant(tenantID uint32) predicate.TranslationMemory {
+	if tenantID == 0 {
+		return translationmemory.Or(translationmemory.TenantIDIsNil(), translationmemory.TenantIDEQ(0))
+	}
+	return translationmemory.TenantIDEQ(tenantID)
+}
+
+// normalizeTranslationMemory 校验语言代码，去除原文首尾空白（机器翻译按去除首尾空白后的句段查找）
+func normalizeTranslationMemory(data *contentV1.TranslationMemory) error {
+	if !locale.ValidCode(data.GetSourceLanguage()) || !locale.ValidCode(data.GetTargetLanguage()) {
+		return contentV1.ErrorBadRequest("invalid translation memory language")
+	}
+	if strings.EqualFold(data.GetSourceLanguage(), data.GetTargetLanguage()) {
+		return contentV1.ErrorBadRequest("translation memory source and target language must differ")
+	}
+
+	source := strings.TrimSpace(data.GetSourceText())
+	if source == "" || strings.TrimSpace(data.GetTargetText()) == "" {
+		return contentV1.ErrorBadRequest("translation memory source and target text are required")
+	}
+	data.SourceText = trans.Ptr(source)
+
+	return nil
+}
+
+func (r *TranslationMemoryRepo) List(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListTranslationMemoryResponse, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	builder := r.entClient.Client().TranslationMemory.Query()
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(translationmemory.TenantIDEQ(tid))
+	}
+
+	ret, err := r.repository.ListWithPaging(ctx, builder, builder.Clone(), req)
+	if err != nil {
+		return nil, err
+	}
+	if ret == nil {
+		return &contentV1.ListTranslationMemoryResponse{Total: 0, Items: nil}, nil
+	}
+
+	return &contentV1.ListTranslationMemoryResponse{
+		Total: ret.Total,
+		Items: ret.Items,
+	}, nil
+}
+
+func (r *TranslationMemoryRepo) Get(ctx context.Context, req *contentV1.GetTranslationMemoryRequest) (*contentV1.TranslationMemory, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	builder := r.entClient.Client().TranslationMemory.Query().
+		Where(translationmemory.IDEQ(req.GetId()))
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(translationmemory.TenantIDEQ(tid))
+	}
+
+	entity, err := builder.Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound("translation memory not found")
+		}
+		r.log.Errorf("query translation memory failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query translation memory failed")
+	}
+
+	return r.mapper.ToDTO(entity), nil
+}
+
+func (r *TranslationMemoryRepo) Create(ctx context.Context, req *contentV1.CreateTranslationMemoryRequest) (*contentV1.TranslationMemory, error) {
+	if req == nil || req.Data == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+	if err := normalizeTranslationMemory(req.Data); err != nil {
+		return nil, err
+	}
+
+	builder := r.entClient.Client().TranslationMemory.Create().
+		SetNillableSourceLanguage(req.Data.SourceLanguage).
+		SetNillableTargetLanguage(req.Data.TargetLanguage).
+		SetNillableSourceText(req.Data.SourceText).
+		SetNillableTargetText(req.Data.TargetText).
+		SetSourceHash(segmentHash(req.Data.GetSourceText())).
+		SetOrigin(translationmemory.OriginOriginManual).
+		SetNillableCreatedBy(req.Data.CreatedBy).
+		SetCreatedAt(time.Now())
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.SetTenantID(tid)
+	}
+
+	entity, err := builder.Save(ctx)
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return nil, contentV1.ErrorConflict("translation memory already exists")
+		}
+		r.log.Errorf("insert translation memory failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("insert translation memory failed")
+	}
+
+	return r.mapper.ToDTO(entity), nil
+}
+
+// Update 更新条目；人工编辑过的条目来源改为 ORIGIN_MANUAL，不再随术语表变更失效
+func (r *TranslationMemoryRepo) Update(ctx context.Context, req *contentV1.UpdateTranslationMemoryRequest) (*contentV1.TranslationMemory, error) {
+	if req == nil || req.Data == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	// 原文与语言对共同决定匹配键，按合并后的完整条目校验
+	current, err := r.Get(ctx, &contentV1.GetTranslationMemoryRequest{Id: req.GetId()})
+	if err != nil {
+		return nil, err
+	}
+	if req.Data.SourceLanguage == nil {
+		req.Data.SourceLanguage = current.SourceLanguage
+	}
+	if req.Data.TargetLanguage == nil {
+		req.Data.TargetLanguage = current.TargetLanguage
+	}
+	if req.Data.SourceText == nil {
+		req.Data.SourceText = current.SourceText
+	}
+	if req.Data.TargetText == nil {
+		req.Data.TargetText = current.TargetText
+	}
+	if err = normalizeTranslationMemory(req.Data); err != nil {
+		return nil, err
+	}
+
+	// 来源与复用统计由服务端维护，不随 updateMask 落库
+	if req.UpdateMask != nil {
+		req.UpdateMask.Paths = utils.FilterBlacklist(req.UpdateMask.GetPaths(), []string{
+			"origin", "hit_count", "last_used_at",
+		})
+	}
+	req.Data.Origin = nil
+	req.Data.HitCount = nil
+	req.Data.LastUsedAt = nil
+
+	tid, hasTenant := maybeTenantFromViewer(ctx)
+	callerUserID, hasUser := viewerUserIDFromContext(ctx)
+
+	builder := r.entClient.Client().TranslationMemory.UpdateOneID(req.GetId())
+	if hasTenant {
+		builder.Where(translationmemory.TenantIDEQ(tid))
+	}
+	result, err := r.repository.UpdateOne(ctx, builder, req.Data, req.GetUpdateMask(),
+		func(dto *contentV1.TranslationMemory) {
+			builder.
+				SetNillableSourceLanguage(req.Data.SourceLanguage).
+				SetNillableTargetLanguage(req.Data.TargetLanguage).
+				SetNillableSourceText(req.Data.SourceText).
+				SetNillableTargetText(req.Data.TargetText).
+				SetSourceHash(segmentHash(req.Data.GetSourceText())).
+				SetOrigin(translationmemory.OriginOriginManual).
+				SetUpdatedAt(time.Now())
+
+			// updated_by 强制由服务端 viewer context 推导，忽略客户端传入值
+			if hasUser {
+				builder.SetUpdatedBy(callerUserID)
+			}
+		},
+		func(s *sql.Selector) {
+			s.Where(sql.EQ(translationmemory.FieldID, req.GetId()))
+		},
+	)
+	if err != nil && ent.IsConstraintError(err) {
+		return nil, contentV1.ErrorConflict("translation memory already exists")
+	}
+
+	return result, err
+}
+
+func (r *TranslationMemoryRepo) Delete(ctx context.Context, req *contentV1.DeleteTranslationMemoryRequest) error {
+	if req == nil {
+		return contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	builder := r.entClient.Client().TranslationMemory.Delete().
+		Where(translationmemory.IDEQ(req.GetId()))
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(translationmemory.TenantIDEQ(tid))
+	}
+
+	affected, err := builder.Exec(ctx)
+	if err != nil {
+		r.log.Errorf("delete translation memory failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("delete translation memory failed")
+	}
+	if affected == 0 {
+		return contentV1.ErrorNotFound("translation memory not found")
+	}
+
+	return nil
+}
+
+// Lookup 按原文精确匹配查找译文，命中时累计复用次数
+func (r *TranslationMemoryRepo) Lookup(ctx context.Context, tenantID uint32, sourceLanguage, targetLanguage, text string) (string, bool) {
+	entity, err := r.entClient.Client().TranslationMemory.Query().
+		Where(
+			translationMemoryTenant(tenantID),
+			translationmemory.SourceLanguageEqualFold(sourceLanguage),
+			translationmemory.TargetLanguageEqualFold(targetLanguage),
+			translationmemory.SourceHashEQ(segmentHash(strings.TrimSpace(text))),
+		).
+		First(ctx)
+	if err != nil {
+		if !ent.IsNotFound(err) {
+			r.log.Errorf("query translation memory failed: %s", err.Error())
+		}
+		return "", false
+	}
+
+	if err = r.entClient.Client().TranslationMemory.UpdateOneID(entity.ID).
+		AddHitCount(1).
+		SetLastUsedAt(time.Now()).
+		Exec(ctx); err != nil {
+		r.log.Warnf("update translation memory [%d] hit count failed: %s", entity.ID, err.Error())
+	}
+
+	return trans.StringValue(entity.TargetText), true
+}
+
+// Remember 记录机器翻译结果；已存在相同原文的条目时保留原条目
+func (r *TranslationMemoryRepo) Remember(ctx context.Context, tenantID uint32, sourceLanguage, targetLanguage, text, translated string) {
+	if _, _, err := r.upsert(ctx, tenantID, TranslationMemoryPair{
+		SourceLanguage: sourceLanguage,
+		TargetLanguage: targetLanguage,
+		SourceText:     text,
+		TargetText:     translated,
+	}, translationmemory.OriginOriginMachine, false, 0); err != nil {
+		r.log.Warnf("remember machine translation failed: %s", err.Error())
+	}
+}
+
+// Import 批量写入 TMX 导入的句段对；overwrite 为 false 时跳过已存在的原文
+func (r *TranslationMemoryRepo) Import(ctx context.Context, pairs []TranslationMemoryPair, overwrite bool) (created, updated, skipped uint32, err error) {
+	tid, _ := maybeTenantFromViewer(ctx)
+	operatorID, _ := viewerUserIDFromContext(ctx)
+
+	for _, p := range pairs {
+		isNew, isUpdated, err := r.upsert(ctx, tid, p, translationmemory.OriginOriginImport, overwrite, operatorID)
+		if err != nil {
+			r.log.Errorf("import translation memory failed: %s", err.Error())
+			return created, updated, skipped, contentV1.ErrorInternalServerError("import translation memory failed")
+		}
+		switch {
+		case isNew:
+			created++
+		case isUpdated:
+			updated++
+		default:
+			skipped++
+		}
+	}
+
+	return created, updated, skipped, nil
+}
+
+// upsert 按租户、语言对与原文写入一条记录
+func (r *TranslationMemoryRepo) upsert(ctx context.Context, tenantID uint32, p TranslationMemoryPair, origin translationmemory.Origin, overwrite bool, operatorID uint32) (created, updated bool, err error) {
+	client := r.entClient.Client()
+	source := strings.TrimSpace(p.SourceText)
+	hash := segmentHash(source)
+	now := time.Now()
+
+	existing, err := client.TranslationMemory.Query().
+		Where(
+			translationMemoryTenant(tenantID),
+			translationmemory.SourceLanguageEqualFold(p.SourceLanguage),
+			translationmemory.TargetLanguageEqualFold(p.TargetLanguage),
+			translationmemory.SourceHashEQ(hash),
+		).
+		First(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		return false, false, err
+	}
+
+	if existing != nil {
+		if !overwrite {
+			return false, false, nil
+		}
+		builder := client.TranslationMemory.UpdateOneID(existing.ID).
+			SetTargetText(p.TargetText).
+			SetOrigin(origin).
+			SetUpdatedAt(now)
+		if operatorID != 0 {
+			builder.SetUpdatedBy(operatorID)
+		}
+		return false, true, builder.Exec(ctx)
+	}
+
+	builder := client.TranslationMemory.Create().
+		SetSourceLanguage(p.SourceLanguage).
+		SetTargetLanguage(p.TargetLanguage).
+		SetSourceText(source).
+		SetTargetText(p.TargetText).
+		SetSourceHash(hash).
+		SetOrigin(origin).
+		SetCreatedAt(now)
+	if tenantID != 0 {
+		builder.SetTenantID(tenantID)
+	}
+	if operatorID != 0 {
+		builder.SetCreatedBy(operatorID)
+	}
+	if err = builder.Exec(ctx); err != nil {
+		// 并发写入同一原文时以先写入者为准
+		if ent.IsConstraintError(err) {
+			return false, false, nil
+		}
+		return false, false, err
+	}
+
+	return true, false, nil
+}
+
+// ListForExport 按语言对列出当前租户的条目，最多 MaxTmxUnits 条
+func (r *TranslationMemoryRepo) ListForExport(ctx context.Context, sourceLanguage, targetLanguage string) ([]*ent.TranslationMemory, error) {
+	builder := r.entClient.Client().TranslationMemory.Query()
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(translationmemory.TenantIDEQ(tid))
+	}
+	if sourceLanguage != "" {
+		builder.Where(translationmemory.SourceLanguageEqualFold(sourceLanguage))
+	}
+	if targetLanguage != "" {
+		builder.Where(translationmemory.TargetLanguageEqualFold(targetLanguage))
+	}
+
+	entities, err := builder.
+		Order(
+			ent.Asc(translationmemory.FieldSourceLanguage),
+			ent.Asc(translationmemory.FieldTargetLanguage),
+			ent.Asc(translationmemory.FieldID),
+		).
+		Limit(MaxTmxUnits).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query translation memories for export failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query translation memories failed")
+	}
+
+	return entities, nil
+}
+
+// invalidateMachineTranslations 删除包含指定术语的机器翻译记忆，术语表变更后这些译文可能不再符合约束。
+// targetLanguage 为空时对所有目标语言生效。
+func invalidateMachineTranslations(ctx context.Context, client *ent.Client, tenantID uint32, sourceLanguage, targetLanguage, term string) error {
+	builder := client.TranslationMemory.Delete().
+		Where(
+			translationMemoryTenant(tenantID),
+			translationmemory.OriginEQ(translationmemory.OriginOriginMachine),
+			translationmemory.SourceLanguageEqualFold(sourceLanguage),
+		)
+	if targetLanguage != "" {
+		builder.Where(translationmemory.TargetLanguageEqualFold(targetLanguage))
+	}
+	if term != "" {
+		builder.Where(translationmemory.SourceTextContainsFold(term))
+	}
+
+	_, err := builder.Exec(ctx)
+	return err
+}
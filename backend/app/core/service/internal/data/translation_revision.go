@@ -0,0 +1,279 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tx7do/go-utils/trans"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/categorytranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/pagetranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/posttranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/tagtranslation"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/locale"
+)
+
+// 翻译修订跟踪。
+//
+// 每个语言版本维护内容修订号（revision），可翻译字段修改时递增；译文记录所依据的源语言（source_language）
+// 与当时源语言版本的修订号（source_revision）。源语言版本修订号递增后，依据旧修订号的译文标记为过期，
+// 编辑更新译文后传入 TRANSLATION_STATUS_CURRENT 确认，重新锚定到源语言的当前修订号。
+
+// translationTrackingFields 由服务端维护的修订跟踪字段，不随 updateMask 直接落库
+var translationTrackingFields = []string{"revision", "source_language", "source_revision", "translation_status"}
+
+// translationAnchor 译文的依据
+type translationAnchor struct {
+	status   contentV1.TranslationStatus
+	language *string
+	revision *uint32
+}
+
+// resolveTranslationAnchor 计算语言版本的依据：源语言为空或与自身语言相同时为原文，
+// 否则锚定到源语言版本的当前修订号；lookup 返回源语言版本的修订号及其是否存在
+func resolveTranslationAnchor(languageCode, sourceLanguage string, lookup func(lang string) (uint32, bool, error)) (*translationAnchor, error) {
+	sourceLanguage = strings.TrimSpace(sourceLanguage)
+	if sourceLanguage == "" || strings.EqualFold(sourceLanguage, languageCode) {
+		return &translationAnchor{status: contentV1.TranslationStatus_TRANSLATION_STATUS_SOURCE}, nil
+	}
+	if !locale.ValidCode(sourceLanguage) {
+		return nil, contentV1.ErrorBadRequest("invalid source language")
+	}
+
+	revision, ok, err := lookup(sourceLanguage)
+	if err != nil {
+		return nil, contentV1.ErrorInternalServerError("query source translation failed")
+	}
+	if !ok {
+		return nil, contentV1.ErrorBadRequest(fmt.Sprintf("source translation %q not found", sourceLanguage))
+	}
+
+	return &translationAnchor{
+		status:   contentV1.TranslationStatus_TRANSLATION_STATUS_CURRENT,
+		language: trans.Ptr(sourceLanguage),
+		revision: trans.Ptr(revision),
+	}, nil
+}
+
+// translationAnchorRequested 返回更新请求要求重新锚定到的源语言：修改 source_language（空串表示改为原文），
+// 或传入 TRANSLATION_STATUS_CURRENT 确认已按源语言当前版本校对；未要求时第二个返回值为 false
+func translationAnchorRequested(mask *fieldmaskpb.FieldMask, sourceLanguage *string, status *contentV1.TranslationStatus, current *string) (string, bool) {
+	requested := func(path string, set bool) bool {
+		if mask == nil {
+			return set
+		}
+		return slices.Contains(mask.GetPaths(), path)
+	}
+
+	if requested("source_language", sourceLanguage != nil) &&
+		!strings.EqualFold(trans.StringValue(sourceLanguage), trans.StringValue(current)) {
+		return trans.StringValue(sourceLanguage), true
+	}
+	if requested("translation_status", status != nil) && status != nil &&
+		*status == contentV1.TranslationStatus_TRANSLATION_STATUS_CURRENT {
+		return trans.StringValue(current), true
+	}
+
+	return "", false
+}
+
+// translationRevisionValue 修订号为空（字段引入前的数据）时视为 1
+func translationRevisionValue(revision *uint32) uint32 {
+	if revision == nil || *revision == 0 {
+		return 1
+	}
+	return *revision
+}
+
+func seoChanged(a, b *contentV1.SeoMeta) bool {
+	return !proto.Equal(a, b)
+}
+
+// postTranslationRevision 返回帖子某语言版本的当前修订号
+func postTranslationRevision(ctx context.Context, client *ent.Client, postID uint32, languageCode string) (uint32, bool, error) {
+	entity, err := client.PostTranslation.Query().
+		Where(posttranslation.PostIDEQ(postID), posttranslation.LanguageCodeEQ(languageCode)).
+		Select(posttranslation.FieldRevision).
+		First(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	return translationRevisionValue(entity.Revision), true, nil
+}
+
+// postTranslationChanged 标题、摘要、正文与 SEO 是否有修改
+func postTranslationChanged(old *ent.PostTranslation, dto *contentV1.PostTranslation) bool {
+	return trans.StringValue(old.Title) != dto.GetTitle() ||
+		trans.StringValue(old.Summary) != dto.GetSummary() ||
+		trans.StringValue(old.Content) != dto.GetContent() ||
+		trans.StringValue(old.OriginalContent) != dto.GetOriginalContent() ||
+		seoChanged(old.Seo, dto.GetSeo())
+}
+
+// bumpPostTranslationRevision 递增帖子语言版本的修订号，并把依据旧修订号的译文标记为过期
+func bumpPostTranslationRevision(ctx context.Context, client *ent.Client, id uint32) (uint32, error) {
+	old, err := client.PostTranslation.Get(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	revision := translationRevisionValue(old.Revision) + 1
+	if err = client.PostTranslation.UpdateOneID(id).SetRevision(revision).Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	_, err = client.PostTranslation.Update().
+		Where(
+			posttranslation.PostIDEQ(trans.Uint32Value(old.PostID)),
+			posttranslation.SourceLanguageEQ(trans.StringValue(old.LanguageCode)),
+			posttranslation.TranslationStatusEQ(posttranslation.TranslationStatusTranslationStatusCurrent),
+			posttranslation.Or(posttranslation.SourceRevisionIsNil(), posttranslation.SourceRevisionLT(revision)),
+		).
+		SetTranslationStatus(posttranslation.TranslationStatusTranslationStatusStale).
+		Save(ctx)
+	return revision, err
+}
+
+// pageTranslationRevision 返回页面某语言版本的当前修订号
+func pageTranslationRevision(ctx context.Context, client *ent.Client, pageID uint32, languageCode string) (uint32, bool, error) {
+	entity, err := client.PageTranslation.Query().
+		Where(pagetranslation.PageIDEQ(pageID), pagetranslation.LanguageCodeEQ(languageCode)).
+		Select(pagetranslation.FieldRevision).
+		First(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	return translationRevisionValue(entity.Revision), true, nil
+}
+
+// pageTranslationChanged 标题与 SEO 是否有修改；正文位于区块中，区块内容修改时另行递增
+func pageTranslationChanged(old *ent.PageTranslation, dto *contentV1.PageTranslation) bool {
+	return trans.StringValue(old.Title) != dto.GetTitle() ||
+		seoChanged(old.Seo, dto.GetSeo())
+}
+
+// bumpPageTranslationRevision 递增页面语言版本的修订号，并把依据旧修订号的译文标记为过期
+func bumpPageTranslationRevision(ctx context.Context, client *ent.Client, id uint32) (uint32, error) {
+	old, err := client.PageTranslation.Get(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	revision := translationRevisionValue(old.Revision) + 1
+	if err = client.PageTranslation.UpdateOneID(id).SetRevision(revision).Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	_, err = client.PageTranslation.Update().
+		Where(
+			pagetranslation.PageIDEQ(trans.Uint32Value(old.PageID)),
+			pagetranslation.SourceLanguageEQ(trans.StringValue(old.LanguageCode)),
+			pagetranslation.TranslationStatusEQ(pagetranslation.TranslationStatusTranslationStatusCurrent),
+			pagetranslation.Or(pagetranslation.SourceRevisionIsNil(), pagetranslation.SourceRevisionLT(revision)),
+		).
+		SetTranslationStatus(pagetranslation.TranslationStatusTranslationStatusStale).
+		Save(ctx)
+	return revision, err
+}
+
+// categoryTranslationRevision 返回分类某语言版本的当前修订号
+func categoryTranslationRevision(ctx context.Context, client *ent.Client, categoryID uint32, languageCode string) (uint32, bool, error) {
+	entity, err := client.CategoryTranslation.Query().
+		Where(categorytranslation.CategoryIDEQ(categoryID), categorytranslation.LanguageCodeEQ(languageCode)).
+		Select(categorytranslation.FieldRevision).
+		First(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	return translationRevisionValue(entity.Revision), true, nil
+}
+
+// categoryTranslationChanged 名称、描述与 SEO 是否有修改
+func categoryTranslationChanged(old *ent.CategoryTranslation, dto *contentV1.CategoryTranslation) bool {
+	return trans.StringValue(old.Name) != dto.GetName() ||
+		trans.StringValue(old.Description) != dto.GetDescription() ||
+		seoChanged(old.Seo, dto.GetSeo())
+}
+
+// bumpCategoryTranslationRevision 递增分类语言版本的修订号，并把依据旧修订号的译文标记为过期
+func bumpCategoryTranslationRevision(ctx context.Context, client *ent.Client, id uint32) (uint32, error) {
+	old, err := client.CategoryTranslation.Get(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	revision := translationRevisionValue(old.Revision) + 1
+	if err = client.CategoryTranslation.UpdateOneID(id).SetRevision(revision).Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	_, err = client.CategoryTranslation.Update().
+		Where(
+			categorytranslation.CategoryIDEQ(trans.Uint32Value(old.CategoryID)),
+			categorytranslation.SourceLanguageEQ(trans.StringValue(old.LanguageCode)),
+			categorytranslation.TranslationStatusEQ(categorytranslation.TranslationStatusTranslationStatusCurrent),
+			categorytranslation.Or(categorytranslation.SourceRevisionIsNil(), categorytranslation.SourceRevisionLT(revision)),
+		).
+		SetTranslationStatus(categorytranslation.TranslationStatusTranslationStatusStale).
+		Save(ctx)
+	return revision, err
+}
+
+// tagTranslationRevision 返回标签某语言版本的当前修订号
+func tagTranslationRevision(ctx context.Context, client *ent.Client, tagID uint32, languageCode string) (uint32, bool, error) {
+	entity, err := client.TagTranslation.Query().
+		Where(tagtranslation.TagIDEQ(tagID), tagtranslation.LanguageCodeEQ(languageCode)).
+		Select(tagtranslation.FieldRevision).
+		First(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	return translationRevisionValue(entity.Revision), true, nil
+}
+
+// tagTranslationChanged 名称、描述与 SEO 是否有修改
+func tagTranslationChanged(old *ent.TagTranslation, dto *contentV1.TagTranslation) bool {
+	return trans.StringValue(old.Name) != dto.GetName() ||
+		trans.StringValue(old.Description) != dto.GetDescription() ||
+		seoChanged(old.Seo, dto.GetSeo())
+}
+
+// bumpTagTranslationRevision 递增标签语言版本的修订号，并把依据旧修订号的译文标记为过期
+func bumpTagTranslationRevision(ctx context.Context, client *ent.Client, id uint32) (uint32, error) {
+	old, err := client.TagTranslation.Get(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	revision := translationRevisionValue(old.Revision) + 1
+	if err = client.TagTranslation.UpdateOneID(id).SetRevision(revision).Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	_, err = client.TagTranslation.Update().
+		Where(
+			tagtranslation.TagIDEQ(trans.Uint32Value(old.TagID)),
+			tagtranslation.SourceLanguageEQ(trans.StringValue(old.LanguageCode)),
+			tagtranslation.TranslationStatusEQ(tagtranslation.TranslationStatusTranslationStatusCurrent),
+			tagtranslation.Or(tagtranslation.SourceRevisionIsNil(), tagtranslation.SourceRevisionLT(revision)),
+		).
+		SetTranslationStatus(tagtranslation.TranslationStatusTranslationStatusStale).
+		Save(ctx)
+	return revision, err
+}
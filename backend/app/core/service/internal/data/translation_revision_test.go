@@ -0,0 +1,113 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tx7do/go-utils/trans"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+)
+
+func TestResolveTranslationAnchor(t *testing.T) {
+	lookup := func(lang string) (uint32, bool, error) {
+		if lang == "en" {
+			return 3, true, nil
+		}
+		return 0, false, nil
+	}
+
+	tests := []struct {
+		name         string
+		language     string
+		source       string
+		wantStatus   contentV1.TranslationStatus
+		wantLanguage *string
+		wantRevision *uint32
+		wantErr      bool
+	}{
+		{name: "empty source", language: "zh-CN", source: "", wantStatus: contentV1.TranslationStatus_TRANSLATION_STATUS_SOURCE},
+		{name: "same language", language: "en", source: "EN", wantStatus: contentV1.TranslationStatus_TRANSLATION_STATUS_SOURCE},
+		{
+			name: "anchored", language: "zh-CN", source: "en",
+			wantStatus:   contentV1.TranslationStatus_TRANSLATION_STATUS_CURRENT,
+			wantLanguage: trans.Ptr("en"), wantRevision: trans.Ptr(uint32(3)),
+		},
+		{name: "source missing", language: "zh-CN", source: "fr", wantErr: true},
+		{name: "invalid source", language: "zh-CN", source: "not a locale!", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveTranslationAnchor(tt.language, tt.source, lookup)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, got.status)
+			assert.Equal(t, tt.wantLanguage, got.language)
+			assert.Equal(t, tt.wantRevision, got.revision)
+		})
+	}
+}
+
+func TestTranslationAnchorRequested(t *testing.T) {
+	current := contentV1.TranslationStatus_TRANSLATION_STATUS_CURRENT
+	stale := contentV1.TranslationStatus_TRANSLATION_STATUS_STALE
+
+	tests := []struct {
+		name     string
+		mask     *fieldmaskpb.FieldMask
+		source   *string
+		status   *contentV1.TranslationStatus
+		existing *string
+		want     string
+		wantOK   bool
+	}{
+		{name: "nothing requested", existing: trans.Ptr("en")},
+		{name: "same source echoed", source: trans.Ptr("EN"), existing: trans.Ptr("en")},
+		{name: "stale status echoed", source: trans.Ptr("en"), status: &stale, existing: trans.Ptr("en")},
+		{name: "source changed", source: trans.Ptr("fr"), existing: trans.Ptr("en"), want: "fr", wantOK: true},
+		{name: "become original", mask: &fieldmaskpb.FieldMask{Paths: []string{"source_language"}}, existing: trans.Ptr("en"), wantOK: true},
+		{name: "marked reviewed", status: &current, existing: trans.Ptr("en"), want: "en", wantOK: true},
+		{name: "masked out", mask: &fieldmaskpb.FieldMask{Paths: []string{"title"}}, source: trans.Ptr("fr"), status: &current, existing: trans.Ptr("en")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := translationAnchorRequested(tt.mask, tt.source, tt.status, tt.existing)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSummarizeCoverage(t *testing.T) {
+	const (
+		source  = contentV1.TranslationStatus_TRANSLATION_STATUS_SOURCE
+		current = contentV1.TranslationStatus_TRANSLATION_STATUS_CURRENT
+		stale   = contentV1.TranslationStatus_TRANSLATION_STATUS_STALE
+	)
+
+	rows := []translationRow{
+		{entityID: 1, languageCode: "en", status: source},
+		{entityID: 1, languageCode: "zh-CN", status: current},
+		{entityID: 2, languageCode: "zh-CN", status: stale},
+		{entityID: 3, languageCode: "zh-CN", status: current, isDraft: true, machine: true},
+		{entityID: 9, languageCode: "zh-CN", status: current}, // 已删除内容
+	}
+
+	got := summarizeCoverage(contentV1.TranslationJob_ENTITY_TYPE_POST, "zh-CN", []uint32{1, 2, 3, 4}, rows)
+
+	assert.Equal(t, uint32(4), got.GetTotal())
+	assert.Equal(t, uint32(3), got.GetTranslated())
+	assert.Equal(t, uint32(1), got.GetMissing())
+	assert.Equal(t, uint32(2), got.GetPublished())
+	assert.Equal(t, uint32(1), got.GetDrafts())
+	assert.Equal(t, uint32(1), got.GetMachineDrafts())
+	assert.Equal(t, uint32(0), got.GetSources())
+	assert.Equal(t, uint32(1), got.GetStale())
+	assert.InDelta(t, 0.25, got.GetCoverage(), 1e-9)
+}
@@ -0,0 +1,1190 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/tx7do/go-utils/copierutil"
+	"github.com/tx7do/go-utils/mapper"
+	"github.com/tx7do/go-utils/timeutil"
+	"github.com/tx7do/go-utils/trans"
+
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	entCrud "github.com/tx7do/go-crud/entgo"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/category"
+	"go-wind-cms/app/core/service/internal/data/ent/categorytranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/mediaasset"
+	"go-wind-cms/app/core/service/internal/data/ent/page"
+	"go-wind-cms/app/core/service/internal/data/ent/pagetranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/post"
+	"go-wind-cms/app/core/service/internal/data/ent/posttranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/predicate"
+	"go-wind-cms/app/core/service/internal/data/ent/section"
+	"go-wind-cms/app/core/service/internal/data/ent/tag"
+	"go-wind-cms/app/core/service/internal/data/ent/tagtranslation"
+	"go-wind-cms/app/core/service/internal/data/ent/trashitem"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/trash"
+)
+
+// NewTrashOption 读取回收站配置（保留期与清理批量）
+func NewTrashOption(ctx *bootstrap.Context) *contentV1.TrashOption {
+	var cfg *contentV1.TrashOptionWrapper
+	rawCfg, ok := ctx.GetCustomConfig("Trash")
+	if ok {
+		cfg = rawCfg.(*contentV1.TrashOptionWrapper)
+	}
+	if cfg == nil {
+		return nil
+	}
+	return cfg.Trash
+}
+
+// RestoredItem 恢复结果，PostID 非零时需要重新索引搜索
+type RestoredItem struct {
+	Item   *contentV1.RestoredTrashItem
+	PostID uint32
+}
+
+// TrashRepo 内容回收站：移入、恢复与彻底清理。
+//
+// 移入回收站只标记内容的 deleted_at/deleted_by 并登记回收站条目，
+// 各内容仓库的 List/Get/Update 均过滤已删除内容；彻底清理时调用各仓库的 Purge 物理删除。
+type TrashRepo struct {
+	entClient *entCrud.EntClient[*ent.Client]
+	log       *log.Helper
+
+	mapper *mapper.CopierMapper[contentV1.TrashItem, ent.TrashItem]
+
+	repository *entCrud.Repository[
+		ent.TrashItemQuery, ent.TrashItemSelect,
+		ent.TrashItemCreate, ent.TrashItemCreateBulk,
+		ent.TrashItemUpdate, ent.TrashItemUpdateOne,
+		ent.TrashItemDelete,
+		predicate.TrashItem,
+		contentV1.TrashItem, ent.TrashItem,
+	]
+
+	entityTypeConverter *mapper.EnumTypeConverter[contentV1.TrashItem_EntityType, trashitem.EntityType]
+
+	postRepo         *PostRepo
+	pageRepo         *PageRepo
+	sectionRepo      *SectionRepo
+	categoryRepo     *CategoryRepo
+	tagRepo          *TagRepo
+	mediaAssetRepo   *MediaAssetRepo
+	postCategoryRepo *PostCategoryRepo
+	postTagRepo      *PostTagRepo
+
+	retentionDays       uint32
+	tenantRetentionDays map[uint32]uint32
+	purgeBatchSize      int
+}
+
+func NewTrashRepo(
+	ctx *bootstrap.Context,
+	entClient *entCrud.EntClient[*ent.Client],
+	cfg *contentV1.TrashOption,
+	postRepo *PostRepo,
+	pageRepo *PageRepo,
+	sectionRepo *SectionRepo,
+	categoryRepo *CategoryRepo,
+	tagRepo *TagRepo,
+	mediaAssetRepo *MediaAssetRepo,
+	postCategoryRepo *PostCategoryRepo,
+	postTagRepo *PostTagRepo,
+) *TrashRepo {
+	repo := &TrashRepo{
+		entClient: entClient,
+		log:       ctx.NewLoggerHelper("trash/repo/core-service"),
+		mapper:    mapper.NewCopierMapper[contentV1.TrashItem, ent.TrashItem](),
+		entityTypeConverter: mapper.NewEnumTypeConverter[contentV1.TrashItem_EntityType, trashitem.EntityType](
+			contentV1.TrashItem_EntityType_name, contentV1.TrashItem_EntityType_value,
+		),
+		postRepo:            postRepo,
+		pageRepo:            pageRepo,
+		sectionRepo:         sectionRepo,
+		categoryRepo:        categoryRepo,
+		tagRepo:             tagRepo,
+		mediaAssetRepo:      mediaAssetRepo,
+		postCategoryRepo:    postCategoryRepo,
+		postTagRepo:         postTagRepo,
+		retentionDays:       cfg.GetRetentionDays(),
+		tenantRetentionDays: cfg.GetTenantRetentionDays(),
+		purgeBatchSize:      int(cfg.GetPurgeBatchSize()),
+	}
+	if repo.purgeBatchSize <= 0 {
+		repo.purgeBatchSize = trash.DefaultPurgeBatchSize
+	}
+
+	repo.init()
+
+	return repo
+}
+
+func (r *TrashRepo) init() {
+	r.repository = entCrud.NewRepository[
+		ent.TrashItemQuery, ent.TrashItemSelect,
+		ent.TrashItemCreate, ent.TrashItemCreateBulk,
+		ent.TrashItemUpdate, ent.TrashItemUpdateOne,
+		ent.TrashItemDelete,
+		predicate.TrashItem,
+		contentV1.TrashItem, ent.TrashItem,
+	](r.mapper)
+
+	r.mapper.AppendConverters(copierutil.NewTimeStringConverterPair())
+	r.mapper.AppendConverters(copierutil.NewTimeTimestamppbConverterPair())
+
+	r.mapper.AppendConverters(r.entityTypeConverter.NewConverterPair())
+}
+
+func (r *TrashRepo) withTx(ctx context.Context, fn func(tx *ent.Tx) error) (err error) {
+	var tx *ent.Tx
+	tx, err = r.entClient.Client().Tx(ctx)
+	if err != nil {
+		r.log.Errorf("start transaction failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("start transaction failed")
+	}
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				r.log.Errorf("transaction rollback failed: %s", rollbackErr.Error())
+			}
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			r.log.Errorf("transaction commit failed: %s", commitErr.Error())
+			err = contentV1.ErrorInternalServerError("transaction commit failed")
+		}
+	}()
+
+	return fn(tx)
+}
+
+// trashTarget 移入回收站时登记的内容快照
+type trashTarget struct {
+	tenantID       *uint32
+	title          *string
+	previousStatus *string
+}
+
+// Trash 将内容移入回收站。页面与分类仍有未删除的子级时拒绝，避免子级悬空。
+func (r *TrashRepo) Trash(ctx context.Context, entityType contentV1.TrashItem_EntityType, id uint32) error {
+	et := r.entityTypeConverter.ToEntity(&entityType)
+	if et == nil || id == 0 {
+		return contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	operatorID, hasOperator := viewerUserIDFromContext(ctx)
+	var deletedBy *uint32
+	if hasOperator {
+		deletedBy = trans.Ptr(operatorID)
+	}
+
+	return r.withTx(ctx, func(tx *ent.Tx) error {
+		now := time.Now()
+
+		var target *trashTarget
+		var err error
+		switch entityType {
+		case contentV1.TrashItem_ENTITY_TYPE_POST:
+			target, err = r.trashPost(ctx, tx, id, now, deletedBy)
+		case contentV1.TrashItem_ENTITY_TYPE_PAGE:
+			target, err = r.trashPage(ctx, tx, id, now, deletedBy)
+		case contentV1.TrashItem_ENTITY_TYPE_SECTION:
+			target, err = r.trashSection(ctx, tx, id, now, deletedBy)
+		case contentV1.TrashItem_ENTITY_TYPE_CATEGORY:
+			target, err = r.trashCategory(ctx, tx, id, now, deletedBy)
+		case contentV1.TrashItem_ENTITY_TYPE_TAG:
+			target, err = r.trashTag(ctx, tx, id, now, deletedBy)
+		case contentV1.TrashItem_ENTITY_TYPE_MEDIA_ASSET:
+			target, err = r.trashMediaAsset(ctx, tx, id, now, deletedBy)
+		default:
+			return contentV1.ErrorBadRequest("unsupported entity type")
+		}
+		if err != nil {
+			return err
+		}
+
+		if err = tx.TrashItem.Create().
+			SetEntityType(*et).
+			SetEntityID(id).
+			SetNillableTitle(truncateTitle(target.title)).
+			SetNillablePreviousStatus(target.previousStatus).
+			SetNillableDeletedBy(deletedBy).
+			SetNillableTenantID(target.tenantID).
+			SetCreatedAt(now).
+			Exec(ctx); err != nil {
+			r.log.Errorf("insert trash item failed: %s", err.Error())
+			return contentV1.ErrorInternalServerError("insert trash item failed")
+		}
+
+		return nil
+	})
+}
+
+// truncateTitle 标题快照超出列宽时截断
+func truncateTitle(title *string) *string {
+	if title == nil {
+		return nil
+	}
+	runes := []rune(*title)
+	if len(runes) <= 255 {
+		return title
+	}
+	return trans.Ptr(string(runes[:255]))
+}
+
+func (r *TrashRepo) trashPost(ctx context.Context, tx *ent.Tx, id uint32, now time.Time, deletedBy *uint32) (*trashTarget, error) {
+	builder := tx.Post.Query().Where(post.IDEQ(id), post.DeletedAtIsNil())
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(post.TenantIDEQ(tid))
+	}
+	entity, err := builder.Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound("post not found")
+		}
+		r.log.Errorf("query post failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query post failed")
+	}
+
+	target := &trashTarget{tenantID: entity.TenantID}
+	if entity.Status != nil {
+		target.previousStatus = trans.Ptr(string(*entity.Status))
+	}
+	if t, err := tx.PostTranslation.Query().
+		Where(posttranslation.PostIDEQ(id)).
+		Order(ent.Asc(posttranslation.FieldID)).
+		First(ctx); err == nil {
+		target.title = t.Title
+	}
+
+	if err = tx.Post.UpdateOneID(id).
+		SetDeletedAt(now).
+		SetNillableDeletedBy(deletedBy).
+		SetStatus(post.StatusPostStatusTrashed).
+		Exec(ctx); err != nil {
+		r.log.Errorf("trash post failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("trash post failed")
+	}
+
+	return target, nil
+}
+
+func (r *TrashRepo) trashPage(ctx context.Context, tx *ent.Tx, id uint32, now time.Time, deletedBy *uint32) (*trashTarget, error) {
+	builder := tx.Page.Query().Where(page.IDEQ(id), page.DeletedAtIsNil())
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(page.TenantIDEQ(tid))
+	}
+	entity, err := builder.Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound("page not found")
+		}
+		r.log.Errorf("query page failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query page failed")
+	}
+
+	hasChildren, err := tx.Page.Query().
+		Where(page.ParentIDEQ(id), page.DeletedAtIsNil()).
+		Exist(ctx)
+	if err != nil {
+		r.log.Errorf("query child pages failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query child pages failed")
+	}
+	if hasChildren {
+		return nil, contentV1.ErrorConflict("page still has child pages")
+	}
+
+	target := &trashTarget{tenantID: entity.TenantID, title: entity.Slug}
+	if t, err := tx.PageTranslation.Query().
+		Where(pagetranslation.PageIDEQ(id)).
+		Order(ent.Asc(pagetranslation.FieldID)).
+		First(ctx); err == nil && t.Title != nil {
+		target.title = t.Title
+	}
+
+	if err = tx.Page.UpdateOneID(id).
+		SetDeletedAt(now).
+		SetNillableDeletedBy(deletedBy).
+		Exec(ctx); err != nil {
+		r.log.Errorf("trash page failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("trash page failed")
+	}
+
+	return target, nil
+}
+
+func (r *TrashRepo) trashSection(ctx context.Context, tx *ent.Tx, id uint32, now time.Time, deletedBy *uint32) (*trashTarget, error) {
+	builder := tx.Section.Query().Where(section.IDEQ(id), section.DeletedAtIsNil())
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(section.TenantIDEQ(tid))
+	}
+	entity, err := builder.Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound("section not found")
+		}
+		r.log.Errorf("query section failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query section failed")
+	}
+
+	if entity.Reusable != nil && *entity.Reusable {
+		count, err := r.sectionRepo.CountReferences(ctx, tx.Client(), id)
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, contentV1.ErrorConflict("reusable block is still referenced by %d sections", count)
+		}
+	}
+
+	if err = tx.Section.UpdateOneID(id).
+		SetDeletedAt(now).
+		SetNillableDeletedBy(deletedBy).
+		Exec(ctx); err != nil {
+		r.log.Errorf("trash section failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("trash section failed")
+	}
+
+	return &trashTarget{tenantID: entity.TenantID, title: entity.Name}, nil
+}
+
+func (r *TrashRepo) trashCategory(ctx context.Context, tx *ent.Tx, id uint32, now time.Time, deletedBy *uint32) (*trashTarget, error) {
+	builder := tx.Category.Query().Where(category.IDEQ(id), category.DeletedAtIsNil())
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(category.TenantIDEQ(tid))
+	}
+	entity, err := builder.Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound("category not found")
+		}
+		r.log.Errorf("query category failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query category failed")
+	}
+
+	hasChildren, err := tx.Category.Query().
+		Where(category.ParentIDEQ(id), category.DeletedAtIsNil()).
+		Exist(ctx)
+	if err != nil {
+		r.log.Errorf("query child categories failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query child categories failed")
+	}
+	if hasChildren {
+		return nil, contentV1.ErrorConflict("category still has child categories")
+	}
+
+	target := &trashTarget{tenantID: entity.TenantID}
+	if t, err := tx.CategoryTranslation.Query().
+		Where(categorytranslation.CategoryIDEQ(id)).
+		Order(ent.Asc(categorytranslation.FieldID)).
+		First(ctx); err == nil {
+		target.title = t.Name
+	}
+
+	if err = tx.Category.UpdateOneID(id).
+		SetDeletedAt(now).
+		SetNillableDeletedBy(deletedBy).
+		Exec(ctx); err != nil {
+		r.log.Errorf("trash category failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("trash category failed")
+	}
+
+	return target, nil
+}
+
+func (r *TrashRepo) trashTag(ctx context.Context, tx *ent.Tx, id uint32, now time.Time, deletedBy *uint32) (*trashTarget, error) {
+	builder := tx.Tag.Query().Where(tag.IDEQ(id), tag.DeletedAtIsNil())
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(tag.TenantIDEQ(tid))
+	}
+	entity, err := builder.Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound("tag not found")
+		}
+		r.log.Errorf("query tag failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query tag failed")
+	}
+
+	target := &trashTarget{tenantID: entity.TenantID}
+	if t, err := tx.TagTranslation.Query().
+		Where(tagtranslation.TagIDEQ(id)).
+		Order(ent.Asc(tagtranslation.FieldID)).
+		First(ctx); err == nil {
+		target.title = t.Name
+	}
+
+	if err = tx.Tag.UpdateOneID(id).
+		SetDeletedAt(now).
+		SetNillableDeletedBy(deletedBy).
+		Exec(ctx); err != nil {
+		r.log.Errorf("trash tag failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("trash tag failed")
+	}
+
+	return target, nil
+}
+
+func (r *TrashRepo) trashMediaAsset(ctx context.Context, tx *ent.Tx, id uint32, now time.Time, deletedBy *uint32) (*trashTarget, error) {
+	builder := tx.MediaAsset.Query().Where(mediaasset.IDEQ(id), mediaasset.DeletedAtIsNil())
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(mediaasset.TenantIDEQ(tid))
+	}
+	entity, err := builder.Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound("media asset not found")
+		}
+		r.log.Errorf("query media asset failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query media asset failed")
+	}
+
+	if err = tx.MediaAsset.UpdateOneID(id).
+		SetDeletedAt(now).
+		SetNillableDeletedBy(deletedBy).
+		Exec(ctx); err != nil {
+		r.log.Errorf("trash media asset failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("trash media asset failed")
+	}
+
+	target := &trashTarget{tenantID: entity.TenantID, title: entity.Title}
+	if target.title == nil || *target.title == "" {
+		target.title = entity.Filename
+	}
+	return target, nil
+}
+
+// List 回收站列表，按租户保留期计算每个条目的到期清理时间
+func (r *TrashRepo) List(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListTrashResponse, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	builder := r.entClient.Client().TrashItem.Query()
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(trashitem.TenantIDEQ(tid))
+	}
+
+	ret, err := r.repository.ListWithPaging(ctx, builder, builder.Clone(), req)
+	if err != nil {
+		return nil, err
+	}
+	if ret == nil || len(ret.Items) == 0 {
+		return &contentV1.ListTrashResponse{Total: 0, Items: nil}, nil
+	}
+
+	// 保留期按条目所属租户计算，租户ID不在响应中暴露
+	ids := make([]uint32, 0, len(ret.Items))
+	for _, item := range ret.Items {
+		ids = append(ids, item.GetId())
+	}
+	entities, err := r.entClient.Client().TrashItem.Query().
+		Where(trashitem.IDIn(ids...)).
+		Select(trashitem.FieldID, trashitem.FieldTenantID, trashitem.FieldCreatedAt).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query trash items failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query trash items failed")
+	}
+	purgeAt := make(map[uint32]*time.Time, len(entities))
+	for _, e := range entities {
+		if e.CreatedAt == nil {
+			continue
+		}
+		days := trash.RetentionDays(r.retentionDays, r.tenantRetentionDays, trans.Uint32Value(e.TenantID))
+		purgeAt[e.ID] = trash.PurgeAt(*e.CreatedAt, days)
+	}
+	for _, item := range ret.Items {
+		item.PurgeAt = timeutil.TimeToTimestamppb(purgeAt[item.GetId()])
+	}
+
+	return &contentV1.ListTrashResponse{
+		Total: ret.Total,
+		Items: ret.Items,
+	}, nil
+}
+
+// Restore 在同一事务中恢复回收站条目，任一条目失败则全部回滚；ids 需已去重。
+//
+// 父页面、父分类或所属页面仍在回收站时拒绝恢复；slug 与现有内容冲突时追加序号并返回变更。
+func (r *TrashRepo) Restore(ctx context.Context, ids []uint32) ([]RestoredItem, error) {
+	if len(ids) == 0 {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	var results []RestoredItem
+	err := r.withTx(ctx, func(tx *ent.Tx) error {
+		results = results[:0]
+
+		builder := tx.TrashItem.Query().Where(trashitem.IDIn(ids...))
+		if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+			builder.Where(trashitem.TenantIDEQ(tid))
+		}
+		items, err := builder.Order(ent.Asc(trashitem.FieldID)).All(ctx)
+		if err != nil {
+			r.log.Errorf("query trash items failed: %s", err.Error())
+			return contentV1.ErrorInternalServerError("query trash items failed")
+		}
+		if len(items) != len(ids) {
+			return contentV1.ErrorNotFound("trash item not found")
+		}
+
+		for _, item := range items {
+			restored, err := r.restoreItem(ctx, tx, item)
+			if err != nil {
+				return err
+			}
+			results = append(results, restored)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (r *TrashRepo) restoreItem(ctx context.Context, tx *ent.Tx, item *ent.TrashItem) (RestoredItem, error) {
+	var changes []*contentV1.SlugChange
+	var err error
+	switch item.EntityType {
+	case trashitem.EntityTypeEntityTypePost:
+		changes, err = r.restorePost(ctx, tx, item)
+	case trashitem.EntityTypeEntityTypePage:
+		changes, err = r.restorePage(ctx, tx, item.EntityID)
+	case trashitem.EntityTypeEntityTypeSection:
+		err = r.restoreSection(ctx, tx, item.EntityID)
+	case trashitem.EntityTypeEntityTypeCategory:
+		changes, err = r.restoreCategory(ctx, tx, item.EntityID)
+	case trashitem.EntityTypeEntityTypeTag:
+		changes, err = r.restoreTag(ctx, tx, item.EntityID)
+	case trashitem.EntityTypeEntityTypeMediaAsset:
+		err = r.restoreMediaAsset(ctx, tx, item.EntityID)
+	default:
+		err = contentV1.ErrorInternalServerError("unknown trash entity type %s", item.EntityType)
+	}
+	if err != nil {
+		return RestoredItem{}, err
+	}
+
+	if err = tx.TrashItem.DeleteOneID(item.ID).Exec(ctx); err != nil {
+		r.log.Errorf("delete trash item failed: %s", err.Error())
+		return RestoredItem{}, contentV1.ErrorInternalServerError("delete trash item failed")
+	}
+
+	restored := RestoredItem{
+		Item: &contentV1.RestoredTrashItem{
+			Id:          item.ID,
+			EntityType:  contentV1.TrashItem_EntityType(contentV1.TrashItem_EntityType_value[string(item.EntityType)]),
+			EntityId:    item.EntityID,
+			SlugChanges: changes,
+		},
+	}
+	if item.EntityType == trashitem.EntityTypeEntityTypePost {
+		restored.PostID = item.EntityID
+	}
+	return restored, nil
+}
+
+// resolveSlug 包装 trash.ResolveSlug，转换为接口错误
+func (r *TrashRepo) resolveSlug(slug string, taken func(candidate string) (bool, error)) (string, error) {
+	resolved, err := trash.ResolveSlug(slug, taken)
+	if err != nil {
+		if errors.Is(err, trash.ErrSlugExhausted) {
+			return "", contentV1.ErrorConflict("no free slug for %s", slug)
+		}
+		r.log.Errorf("resolve slug failed: %s", err.Error())
+		return "", contentV1.ErrorInternalServerError("resolve slug failed")
+	}
+	return resolved, nil
+}
+
+func (r *TrashRepo) restorePost(ctx context.Context, tx *ent.Tx, item *ent.TrashItem) ([]*contentV1.SlugChange, error) {
+	entity, err := tx.Post.Query().
+		Where(post.IDEQ(item.EntityID), post.DeletedAtNotNil()).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound("post not found")
+		}
+		r.log.Errorf("query post failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query post failed")
+	}
+
+	status := post.StatusPostStatusDraft
+	if item.PreviousStatus != nil {
+		if prev := post.Status(*item.PreviousStatus); post.StatusValidator(prev) == nil && prev != post.StatusPostStatusTrashed {
+			status = prev
+		}
+	}
+
+	translations, err := tx.PostTranslation.Query().
+		Where(posttranslation.PostIDEQ(entity.ID)).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query post translations failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query post translations failed")
+	}
+
+	var changes []*contentV1.SlugChange
+	for _, t := range translations {
+		oldSlug := trans.StringValue(t.Slug)
+		newSlug, err := r.resolveSlug(oldSlug, func(candidate string) (bool, error) {
+			others, err := tx.PostTranslation.Query().
+				Where(
+					posttranslation.PostIDNEQ(entity.ID),
+					posttranslation.LanguageCodeEQ(trans.StringValue(t.LanguageCode)),
+					posttranslation.SlugEQ(candidate),
+				).
+				All(ctx)
+			if err != nil || len(others) == 0 {
+				return false, err
+			}
+			owners := make([]uint32, 0, len(others))
+			for _, o := range others {
+				owners = append(owners, trans.Uint32Value(o.PostID))
+			}
+			q := tx.Post.Query().Where(post.IDIn(owners...), post.DeletedAtIsNil())
+			if tid := trans.Uint32Value(entity.TenantID); tid != 0 {
+				q.Where(post.TenantIDEQ(tid))
+			}
+			return q.Exist(ctx)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if newSlug == oldSlug {
+			continue
+		}
+
+		builder := tx.PostTranslation.UpdateOneID(t.ID).SetSlug(newSlug)
+		if t.FullPath != nil {
+			builder.SetFullPath(trash.ReplaceSlug(*t.FullPath, oldSlug, newSlug))
+		}
+		if err = builder.Exec(ctx); err != nil {
+			r.log.Errorf("update post translation slug failed: %s", err.Error())
+			return nil, contentV1.ErrorInternalServerError("update post translation slug failed")
+		}
+		changes = append(changes, &contentV1.SlugChange{
+			LanguageCode: t.LanguageCode,
+			OldSlug:      oldSlug,
+			NewSlug:      newSlug,
+		})
+	}
+
+	if err = tx.Post.UpdateOneID(entity.ID).
+		ClearDeletedAt().
+		ClearDeletedBy().
+		SetStatus(status).
+		Exec(ctx); err != nil {
+		r.log.Errorf("restore post failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("restore post failed")
+	}
+
+	return changes, nil
+}
+
+func (r *TrashRepo) restorePage(ctx context.Context, tx *ent.Tx, id uint32) ([]*contentV1.SlugChange, error) {
+	entity, err := tx.Page.Query().
+		Where(page.IDEQ(id), page.DeletedAtNotNil()).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound("page not found")
+		}
+		r.log.Errorf("query page failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query page failed")
+	}
+
+	if entity.ParentID != nil && *entity.ParentID != 0 {
+		parentTrashed, err := tx.Page.Query().
+			Where(page.IDEQ(*entity.ParentID), page.DeletedAtNotNil()).
+			Exist(ctx)
+		if err != nil {
+			r.log.Errorf("query parent page failed: %s", err.Error())
+			return nil, contentV1.ErrorInternalServerError("query parent page failed")
+		}
+		if parentTrashed {
+			return nil, contentV1.ErrorConflict("parent page is in the trash, restore it first")
+		}
+	}
+
+	livePages := func(ids ...uint32) *ent.PageQuery {
+		q := tx.Page.Query().Where(page.IDIn(ids...), page.DeletedAtIsNil())
+		if tid := trans.Uint32Value(entity.TenantID); tid != 0 {
+			q.Where(page.TenantIDEQ(tid))
+		}
+		return q
+	}
+
+	var changes []*contentV1.SlugChange
+	update := tx.Page.UpdateOneID(id).ClearDeletedAt().ClearDeletedBy()
+
+	// 页面自身的 slug 在同级页面中唯一
+	if oldSlug := trans.StringValue(entity.Slug); oldSlug != "" {
+		newSlug, err := r.resolveSlug(oldSlug, func(candidate string) (bool, error) {
+			q := tx.Page.Query().Where(page.IDNEQ(id), page.SlugEQ(candidate), page.DeletedAtIsNil())
+			if tid := trans.Uint32Value(entity.TenantID); tid != 0 {
+				q.Where(page.TenantIDEQ(tid))
+			}
+			if entity.ParentID == nil {
+				q.Where(page.ParentIDIsNil())
+			} else {
+				q.Where(page.ParentIDEQ(*entity.ParentID))
+			}
+			return q.Exist(ctx)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if newSlug != oldSlug {
+			update.SetSlug(newSlug)
+			changes = append(changes, &contentV1.SlugChange{OldSlug: oldSlug, NewSlug: newSlug})
+		}
+	}
+
+	translations, err := tx.PageTranslation.Query().
+		Where(pagetranslation.PageIDEQ(id)).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query page translations failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query page translations failed")
+	}
+	for _, t := range translations {
+		oldSlug := trans.StringValue(t.Slug)
+		newSlug, err := r.resolveSlug(oldSlug, func(candidate string) (bool, error) {
+			others, err := tx.PageTranslation.Query().
+				Where(
+					pagetranslation.PageIDNEQ(id),
+					pagetranslation.LanguageCodeEQ(trans.StringValue(t.LanguageCode)),
+					pagetranslation.SlugEQ(candidate),
+				).
+				All(ctx)
+			if err != nil || len(others) == 0 {
+				return false, err
+			}
+			owners := make([]uint32, 0, len(others))
+			for _, o := range others {
+				owners = append(owners, trans.Uint32Value(o.PageID))
+			}
+			return livePages(owners...).Exist(ctx)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if newSlug == oldSlug {
+			continue
+		}
+
+		builder := tx.PageTranslation.UpdateOneID(t.ID).SetSlug(newSlug)
+		if t.FullPath != nil {
+			builder.SetFullPath(trash.ReplaceSlug(*t.FullPath, oldSlug, newSlug))
+		}
+		if err = builder.Exec(ctx); err != nil {
+			r.log.Errorf("update page translation slug failed: %s", err.Error())
+			return nil, contentV1.ErrorInternalServerError("update page translation slug failed")
+		}
+		changes = append(changes, &contentV1.SlugChange{
+			LanguageCode: t.LanguageCode,
+			OldSlug:      oldSlug,
+			NewSlug:      newSlug,
+		})
+	}
+
+	if err = update.Exec(ctx); err != nil {
+		r.log.Errorf("restore page failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("restore page failed")
+	}
+
+	return changes, nil
+}
+
+func (r *TrashRepo) restoreSection(ctx context.Context, tx *ent.Tx, id uint32) error {
+	entity, err := tx.Section.Query().
+		Where(section.IDEQ(id), section.DeletedAtNotNil()).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return contentV1.ErrorNotFound("section not found")
+		}
+		r.log.Errorf("query section failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("query section failed")
+	}
+
+	if entity.PageID != nil && *entity.PageID != 0 {
+		pageTrashed, err := tx.Page.Query().
+			Where(page.IDEQ(*entity.PageID), page.DeletedAtNotNil()).
+			Exist(ctx)
+		if err != nil {
+			r.log.Errorf("query section page failed: %s", err.Error())
+			return contentV1.ErrorInternalServerError("query section page failed")
+		}
+		if pageTrashed {
+			return contentV1.ErrorConflict("page of the section is in the trash, restore it first")
+		}
+	}
+
+	if err = tx.Section.UpdateOneID(id).
+		ClearDeletedAt().
+		ClearDeletedBy().
+		Exec(ctx); err != nil {
+		r.log.Errorf("restore section failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("restore section failed")
+	}
+
+	return nil
+}
+
+func (r *TrashRepo) restoreCategory(ctx context.Context, tx *ent.Tx, id uint32) ([]*contentV1.SlugChange, error) {
+	entity, err := tx.Category.Query().
+		Where(category.IDEQ(id), category.DeletedAtNotNil()).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound("category not found")
+		}
+		r.log.Errorf("query category failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query category failed")
+	}
+
+	if entity.ParentID != nil && *entity.ParentID != 0 {
+		parentTrashed, err := tx.Category.Query().
+			Where(category.IDEQ(*entity.ParentID), category.DeletedAtNotNil()).
+			Exist(ctx)
+		if err != nil {
+			r.log.Errorf("query parent category failed: %s", err.Error())
+			return nil, contentV1.ErrorInternalServerError("query parent category failed")
+		}
+		if parentTrashed {
+			return nil, contentV1.ErrorConflict("parent category is in the trash, restore it first")
+		}
+	}
+
+	translations, err := tx.CategoryTranslation.Query().
+		Where(categorytranslation.CategoryIDEQ(id)).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query category translations failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query category translations failed")
+	}
+
+	var changes []*contentV1.SlugChange
+	for _, t := range translations {
+		oldSlug := trans.StringValue(t.Slug)
+		newSlug, err := r.resolveSlug(oldSlug, func(candidate string) (bool, error) {
+			others, err := tx.CategoryTranslation.Query().
+				Where(
+					categorytranslation.CategoryIDNEQ(id),
+					categorytranslation.LanguageCodeEQ(trans.StringValue(t.LanguageCode)),
+					categorytranslation.SlugEQ(candidate),
+				).
+				All(ctx)
+			if err != nil || len(others) == 0 {
+				return false, err
+			}
+			owners := make([]uint32, 0, len(others))
+			for _, o := range others {
+				owners = append(owners, trans.Uint32Value(o.CategoryID))
+			}
+			q := tx.Category.Query().Where(category.IDIn(owners...), category.DeletedAtIsNil())
+			if tid := trans.Uint32Value(entity.TenantID); tid != 0 {
+				q.Where(category.TenantIDEQ(tid))
+			}
+			return q.Exist(ctx)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if newSlug == oldSlug {
+			continue
+		}
+
+		builder := tx.CategoryTranslation.UpdateOneID(t.ID).SetSlug(newSlug)
+		if t.FullPath != nil {
+			builder.SetFullPath(trash.ReplaceSlug(*t.FullPath, oldSlug, newSlug))
+		}
+		if err = builder.Exec(ctx); err != nil {
+			r.log.Errorf("update category translation slug failed: %s", err.Error())
+			return nil, contentV1.ErrorInternalServerError("update category translation slug failed")
+		}
+		changes = append(changes, &contentV1.SlugChange{
+			LanguageCode: t.LanguageCode,
+			OldSlug:      oldSlug,
+			NewSlug:      newSlug,
+		})
+	}
+
+	if err = tx.Category.UpdateOneID(id).
+		ClearDeletedAt().
+		ClearDeletedBy().
+		Exec(ctx); err != nil {
+		r.log.Errorf("restore category failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("restore category failed")
+	}
+
+	return changes, nil
+}
+
+func (r *TrashRepo) restoreTag(ctx context.Context, tx *ent.Tx, id uint32) ([]*contentV1.SlugChange, error) {
+	entity, err := tx.Tag.Query().
+		Where(tag.IDEQ(id), tag.DeletedAtNotNil()).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound("tag not found")
+		}
+		r.log.Errorf("query tag failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query tag failed")
+	}
+
+	translations, err := tx.TagTranslation.Query().
+		Where(tagtranslation.TagIDEQ(id)).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query tag translations failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query tag translations failed")
+	}
+
+	var changes []*contentV1.SlugChange
+	for _, t := range translations {
+		oldSlug := trans.StringValue(t.Slug)
+		newSlug, err := r.resolveSlug(oldSlug, func(candidate string) (bool, error) {
+			others, err := tx.TagTranslation.Query().
+				Where(
+					tagtranslation.TagIDNEQ(id),
+					tagtranslation.LanguageCodeEQ(trans.StringValue(t.LanguageCode)),
+					tagtranslation.SlugEQ(candidate),
+				).
+				All(ctx)
+			if err != nil || len(others) == 0 {
+				return false, err
+			}
+			owners := make([]uint32, 0, len(others))
+			for _, o := range others {
+				owners = append(owners, trans.Uint32Value(o.TagID))
+			}
+			q := tx.Tag.Query().Where(tag.IDIn(owners...), tag.DeletedAtIsNil())
+			if tid := trans.Uint32Value(entity.TenantID); tid != 0 {
+				q.Where(tag.TenantIDEQ(tid))
+			}
+			return q.Exist(ctx)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if newSlug == oldSlug {
+			continue
+		}
+
+		builder := tx.TagTranslation.UpdateOneID(t.ID).SetSlug(newSlug)
+		if t.FullPath != nil {
+			builder.SetFullPath(trash.ReplaceSlug(*t.FullPath, oldSlug, newSlug))
+		}
+		if err = builder.Exec(ctx); err != nil {
+			r.log.Errorf("update tag translation slug failed: %s", err.Error())
+			return nil, contentV1.ErrorInternalServerError("update tag translation slug failed")
+		}
+		changes = append(changes, &contentV1.SlugChange{
+			LanguageCode: t.LanguageCode,
+			OldSlug:      oldSlug,
+			NewSlug:      newSlug,
+		})
+	}
+
+	if err = tx.Tag.UpdateOneID(id).
+		ClearDeletedAt().
+		ClearDeletedBy().
+		Exec(ctx); err != nil {
+		r.log.Errorf("restore tag failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("restore tag failed")
+	}
+
+	return changes, nil
+}
+
+func (r *TrashRepo) restoreMediaAsset(ctx context.Context, tx *ent.Tx, id uint32) error {
+	affected, err := tx.MediaAsset.Update().
+		Where(mediaasset.IDEQ(id), mediaasset.DeletedAtNotNil()).
+		ClearDeletedAt().
+		ClearDeletedBy().
+		Save(ctx)
+	if err != nil {
+		r.log.Errorf("restore media asset failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("restore media asset failed")
+	}
+	if affected == 0 {
+		return contentV1.ErrorNotFound("media asset not found")
+	}
+	return nil
+}
+
+// Purge 彻底删除回收站条目（ids 需已去重）；all 为真时忽略 ids，清空（可限定内容类型的）回收站
+func (r *TrashRepo) Purge(ctx context.Context, ids []uint32, all bool, entityType *contentV1.TrashItem_EntityType) (uint32, error) {
+	builder := r.entClient.Client().TrashItem.Query()
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(trashitem.TenantIDEQ(tid))
+	}
+
+	switch {
+	case all:
+		if entityType != nil && *entityType != contentV1.TrashItem_ENTITY_TYPE_UNSPECIFIED {
+			et := r.entityTypeConverter.ToEntity(entityType)
+			if et == nil {
+				return 0, contentV1.ErrorBadRequest("unsupported entity type")
+			}
+			builder.Where(trashitem.EntityTypeEQ(*et))
+		}
+	case len(ids) > 0:
+		builder.Where(trashitem.IDIn(ids...))
+	default:
+		return 0, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	items, err := builder.Order(ent.Asc(trashitem.FieldID)).All(ctx)
+	if err != nil {
+		r.log.Errorf("query trash items failed: %s", err.Error())
+		return 0, contentV1.ErrorInternalServerError("query trash items failed")
+	}
+	if !all && len(items) != len(ids) {
+		return 0, contentV1.ErrorNotFound("trash item not found")
+	}
+
+	var purged uint32
+	for _, item := range items {
+		if err = r.purgeItem(ctx, item); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+// PurgeExpired 彻底删除超过保留期的条目，每次最多 purge_batch_size 条，剩余的留待下次执行。
+//
+// 调用方需注入 SystemViewer 以跨租户清理。
+func (r *TrashRepo) PurgeExpired(ctx context.Context, now time.Time) (uint32, error) {
+	var preds []predicate.TrashItem
+
+	// 未单独配置的租户按默认保留期
+	if cutoff := trash.Cutoff(now, r.retentionDays); cutoff != nil {
+		pred := trashitem.CreatedAtLT(*cutoff)
+		if len(r.tenantRetentionDays) > 0 {
+			overridden := make([]uint32, 0, len(r.tenantRetentionDays))
+			for tid := range r.tenantRetentionDays {
+				overridden = append(overridden, tid)
+			}
+			pred = trashitem.And(pred, trashitem.Or(trashitem.TenantIDIsNil(), trashitem.TenantIDNotIn(overridden...)))
+		}
+		preds = append(preds, pred)
+	}
+	for tid, days := range r.tenantRetentionDays {
+		if cutoff := trash.Cutoff(now, days); cutoff != nil {
+			preds = append(preds, trashitem.And(trashitem.TenantIDEQ(tid), trashitem.CreatedAtLT(*cutoff)))
+		}
+	}
+	if len(preds) == 0 {
+		return 0, nil
+	}
+
+	items, err := r.entClient.Client().TrashItem.Query().
+		Where(trashitem.Or(preds...)).
+		Order(ent.Asc(trashitem.FieldCreatedAt)).
+		Limit(r.purgeBatchSize).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query expired trash items failed: %s", err.Error())
+		return 0, contentV1.ErrorInternalServerError("query expired trash items failed")
+	}
+
+	// 单条失败不阻断其余条目，失败的条目下次执行时重试
+	var purged uint32
+	for _, item := range items {
+		if err = r.purgeItem(ctx, item); err != nil {
+			r.log.Errorf("purge trash item %d (%s %d) failed: %s", item.ID, item.EntityType, item.EntityID, err.Error())
+			continue
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+// purgeItem 在单独的事务中物理删除条目对应的内容及条目本身
+func (r *TrashRepo) purgeItem(ctx context.Context, item *ent.TrashItem) error {
+	return r.withTx(ctx, func(tx *ent.Tx) error {
+		var err error
+		switch item.EntityType {
+		case trashitem.EntityTypeEntityTypePost:
+			err = r.postRepo.Purge(ctx, tx, item.EntityID)
+
+		case trashitem.EntityTypeEntityTypePage:
+			// 页面下已单独移入回收站的区块随页面一并清理，移除其条目
+			var sectionIDs []uint32
+			if sectionIDs, err = tx.Section.Query().Where(section.PageIDEQ(item.EntityID)).IDs(ctx); err != nil {
+				r.log.Errorf("query sections by page id failed: %s", err.Error())
+				return contentV1.ErrorInternalServerError("query sections by page id failed")
+			}
+			if len(sectionIDs) > 0 {
+				if _, err = tx.TrashItem.Delete().
+					Where(
+						trashitem.EntityTypeEQ(trashitem.EntityTypeEntityTypeSection),
+						trashitem.EntityIDIn(sectionIDs...),
+					).
+					Exec(ctx); err != nil {
+					r.log.Errorf("delete section trash items failed: %s", err.Error())
+					return contentV1.ErrorInternalServerError("delete section trash items failed")
+				}
+			}
+			err = r.pageRepo.Purge(ctx, tx, item.EntityID)
+
+		case trashitem.EntityTypeEntityTypeSection:
+			err = r.sectionRepo.Purge(ctx, tx, item.EntityID)
+
+		case trashitem.EntityTypeEntityTypeCategory:
+			if err = r.categoryRepo.Purge(ctx, tx, item.EntityID); err == nil {
+				if err = r.postCategoryRepo.CleanPosts(ctx, tx, item.EntityID); err != nil {
+					r.log.Errorf("clean category posts failed: %s", err.Error())
+					err = contentV1.ErrorInternalServerError("clean category posts failed")
+				}
+			}
+
+		case trashitem.EntityTypeEntityTypeTag:
+			if err = r.tagRepo.Purge(ctx, tx, item.EntityID); err == nil {
+				if err = r.postTagRepo.CleanPosts(ctx, tx, item.EntityID); err != nil {
+					r.log.Errorf("clean tag posts failed: %s", err.Error())
+					err = contentV1.ErrorInternalServerError("clean tag posts failed")
+				}
+			}
+
+		case trashitem.EntityTypeEntityTypeMediaAsset:
+			err = r.mediaAssetRepo.Purge(ctx, tx, item.EntityID)
+
+		default:
+			err = contentV1.ErrorInternalServerError("unknown trash entity type %s", item.EntityType)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err = tx.TrashItem.DeleteOneID(item.ID).Exec(ctx); err != nil {
+			r.log.Errorf("delete trash item failed: %s", err.Error())
+			return contentV1.ErrorInternalServerError("delete trash item failed")
+		}
+
+		return nil
+	})
+}
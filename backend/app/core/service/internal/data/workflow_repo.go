@@ -0,0 +1,430 @@
+package data
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/jinzhu/copier"
+
+	"github.com/tx7do/go-utils/copierutil"
+	"github.com/tx7do/go-utils/mapper"
+	"github.com/tx7do/go-utils/trans"
+
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	entCrud "github.com/tx7do/go-crud/entgo"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/post"
+	"go-wind-cms/app/core/service/internal/data/ent/predicate"
+	entWorkflow "go-wind-cms/app/core/service/internal/data/ent/workflow"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/workflow"
+)
+
+// WorkflowRepo 编辑工作流
+type WorkflowRepo struct {
+	entClient *entCrud.EntClient[*ent.Client]
+	log       *log.Helper
+
+	mapper *mapper.CopierMapper[contentV1.Workflow, ent.Workflow]
+
+	repository *entCrud.Repository[
+		ent.WorkflowQuery, ent.WorkflowSelect,
+		ent.WorkflowCreate, ent.WorkflowCreateBulk,
+		ent.WorkflowUpdate, ent.WorkflowUpdateOne,
+		ent.WorkflowDelete,
+		predicate.Workflow,
+		contentV1.Workflow, ent.Workflow,
+	]
+}
+
+func NewWorkflowRepo(ctx *bootstrap.Context, entClient *entCrud.EntClient[*ent.Client]) *WorkflowRepo {
+	repo := &WorkflowRepo{
+		entClient: entClient,
+		log:       ctx.NewLoggerHelper("workflow/repo/core-service"),
+		mapper:    mapper.NewCopierMapper[contentV1.Workflow, ent.Workflow](),
+	}
+
+	repo.init()
+
+	return repo
+}
+
+func (r *WorkflowRepo) init() {
+	r.repository = entCrud.NewRepository[
+		ent.WorkflowQuery, ent.WorkflowSelect,
+		ent.WorkflowCreate, ent.WorkflowCreateBulk,
+		ent.WorkflowUpdate, ent.WorkflowUpdateOne,
+		ent.WorkflowDelete,
+		predicate.Workflow,
+		contentV1.Workflow, ent.Workflow,
+	](r.mapper)
+
+	r.mapper.AppendConverters(copierutil.NewTimeStringConverterPair())
+	r.mapper.AppendConverters(copierutil.NewTimeTimestamppbConverterPair())
+
+	r.mapper.AppendConverters(newWorkflowStagesConverterPair())
+	r.mapper.AppendConverters(newWorkflowTransitionsConverterPair())
+}
+
+func newWorkflowStagesConverterPair() []copier.TypeConverter {
+	return copierutil.NewGenericTypeConverterPair(
+		[]workflow.Stage{}, []*contentV1.WorkflowStage{},
+		workflowStagesToProto,
+		workflowStagesFromProto,
+	)
+}
+
+func newWorkflowTransitionsConverterPair() []copier.TypeConverter {
+	return copierutil.NewGenericTypeConverterPair(
+		[]workflow.Transition{}, []*contentV1.WorkflowTransition{},
+		workflowTransitionsToProto,
+		workflowTransitionsFromProto,
+	)
+}
+
+func workflowStageToProto(s *workflow.Stage) *contentV1.WorkflowStage {
+	out := &contentV1.WorkflowStage{
+		Code:    s.Code,
+		Review:  s.Review,
+		Publish: s.Publish,
+	}
+	if s.Name != "" {
+		out.Name = trans.Ptr(s.Name)
+	}
+	return out
+}
+
+func workflowStagesToProto(stages []workflow.Stage) []*contentV1.WorkflowStage {
+	if stages == nil {
+		return nil
+	}
+	out := make([]*contentV1.WorkflowStage, 0, len(stages))
+	for i := range stages {
+		out = append(out, workflowStageToProto(&stages[i]))
+	}
+	return out
+}
+
+func workflowStagesFromProto(stages []*contentV1.WorkflowStage) []workflow.Stage {
+	if stages == nil {
+		return nil
+	}
+	out := make([]workflow.Stage, 0, len(stages))
+	for _, s := range stages {
+		out = append(out, workflow.Stage{
+			Code:    s.GetCode(),
+			Name:    s.GetName(),
+			Review:  s.GetReview(),
+			Publish: s.GetPublish(),
+		})
+	}
+	return out
+}
+
+func workflowTransitionsToProto(transitions []workflow.Transition) []*contentV1.WorkflowTransition {
+	if transitions == nil {
+		return nil
+	}
+	out := make([]*contentV1.WorkflowTransition, 0, len(transitions))
+	for _, t := range transitions {
+		item := &contentV1.WorkflowTransition{
+			From:           t.From,
+			To:             t.To,
+			RequireComment: t.RequireComment,
+		}
+		if t.Name != "" {
+			item.Name = trans.Ptr(t.Name)
+		}
+		if t.PermissionCode != "" {
+			item.PermissionCode = trans.Ptr(t.PermissionCode)
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
+func workflowTransitionsFromProto(transitions []*contentV1.WorkflowTransition) []workflow.Transition {
+	if transitions == nil {
+		return nil
+	}
+	out := make([]workflow.Transition, 0, len(transitions))
+	for _, t := range transitions {
+		out = append(out, workflow.Transition{
+			From:           t.GetFrom(),
+			To:             t.GetTo(),
+			Name:           t.GetName(),
+			PermissionCode: t.GetPermissionCode(),
+			RequireComment: t.GetRequireComment(),
+		})
+	}
+	return out
+}
+
+// workflowDefinition 由工作流实体得到其定义
+func workflowDefinition(w *ent.Workflow) *workflow.Definition {
+	return &workflow.Definition{
+		InitialStage: trans.StringValue(w.InitialStage),
+		Stages:       w.Stages,
+		Transitions:  w.Transitions,
+	}
+}
+
+func (r *WorkflowRepo) List(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListWorkflowResponse, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	builder := r.entClient.Client().Workflow.Query()
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(entWorkflow.TenantIDEQ(tid))
+	}
+
+	ret, err := r.repository.ListWithPaging(ctx, builder, builder.Clone(), req)
+	if err != nil {
+		return nil, err
+	}
+	if ret == nil {
+		return &contentV1.ListWorkflowResponse{Total: 0, Items: nil}, nil
+	}
+
+	return &contentV1.ListWorkflowResponse{
+		Total: ret.Total,
+		Items: ret.Items,
+	}, nil
+}
+
+func (r *WorkflowRepo) Get(ctx context.Context, req *contentV1.GetWorkflowRequest) (*contentV1.Workflow, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	entity, err := r.GetByID(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return r.mapper.ToDTO(entity), nil
+}
+
+// GetByID 按 ID 查询工作流（按 viewer 租户隔离）
+func (r *WorkflowRepo) GetByID(ctx context.Context, id uint32) (*ent.Workflow, error) {
+	builder := r.entClient.Client().Workflow.Query().
+		Where(entWorkflow.IDEQ(id))
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(entWorkflow.TenantIDEQ(tid))
+	}
+
+	entity, err := builder.Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, contentV1.ErrorNotFound("workflow not found")
+		}
+		r.log.Errorf("query workflow failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query workflow failed")
+	}
+
+	return entity, nil
+}
+
+func checkWorkflowDefinition(def *workflow.Definition) error {
+	if err := def.Validate(); err != nil {
+		return contentV1.ErrorBadRequest("invalid workflow %s", err.Error())
+	}
+	return nil
+}
+
+func (r *WorkflowRepo) Create(ctx context.Context, req *contentV1.CreateWorkflowRequest) (*contentV1.Workflow, error) {
+	if req == nil || req.Data == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	def := &workflow.Definition{
+		InitialStage: req.Data.GetInitialStage(),
+		Stages:       workflowStagesFromProto(req.Data.GetStages()),
+		Transitions:  workflowTransitionsFromProto(req.Data.GetTransitions()),
+	}
+	// 未提供阶段时使用默认的新闻编辑工作流
+	if len(def.Stages) == 0 {
+		def = workflow.Default()
+	}
+	if err := checkWorkflowDefinition(def); err != nil {
+		return nil, err
+	}
+
+	tid, hasTenant := maybeTenantFromViewer(ctx)
+
+	builder := r.entClient.Client().Workflow.Create().
+		SetNillableName(req.Data.Name).
+		SetNillableDescription(req.Data.Description).
+		SetInitialStage(def.InitialStage).
+		SetStages(def.Stages).
+		SetTransitions(def.Transitions).
+		SetPostTypes(req.Data.GetPostTypes()).
+		SetNillableEnabled(req.Data.Enabled).
+		SetNillableSortOrder(req.Data.SortOrder).
+		SetNillableCreatedBy(req.Data.CreatedBy).
+		SetCreatedAt(time.Now())
+	if hasTenant {
+		builder.SetTenantID(tid)
+	}
+
+	entity, err := builder.Save(ctx)
+	if err != nil {
+		r.log.Errorf("insert workflow failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("insert workflow failed")
+	}
+
+	return r.mapper.ToDTO(entity), nil
+}
+
+func (r *WorkflowRepo) Update(ctx context.Context, req *contentV1.UpdateWorkflowRequest) (*contentV1.Workflow, error) {
+	if req == nil || req.Data == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	// 阶段、流转与初始阶段相互约束，按合并后的定义校验
+	current, err := r.GetByID(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	paths := req.GetUpdateMask().GetPaths()
+
+	def := workflowDefinition(current)
+	if req.Data.InitialStage != nil {
+		def.InitialStage = req.Data.GetInitialStage()
+	}
+	if req.Data.Stages != nil || slices.Contains(paths, "stages") {
+		def.Stages = workflowStagesFromProto(req.Data.GetStages())
+	}
+	if req.Data.Transitions != nil || slices.Contains(paths, "transitions") {
+		def.Transitions = workflowTransitionsFromProto(req.Data.GetTransitions())
+	}
+	if err = checkWorkflowDefinition(def); err != nil {
+		return nil, err
+	}
+
+	// 仍有文章停留的阶段不可删除，否则这些文章将无法继续流转
+	codes := make([]string, 0, len(def.Stages))
+	for _, s := range def.Stages {
+		codes = append(codes, s.Code)
+	}
+	stranded, err := r.entClient.Client().Post.Query().
+		Where(
+			post.WorkflowIDEQ(current.ID),
+			post.WorkflowStageNotIn(codes...),
+		).
+		Exist(ctx)
+	if err != nil {
+		r.log.Errorf("query workflow posts failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query workflow posts failed")
+	}
+	if stranded {
+		return nil, contentV1.ErrorConflict("posts are still in a stage removed from the workflow")
+	}
+
+	callerUserID, hasUser := viewerUserIDFromContext(ctx)
+
+	builder := r.entClient.Client().Workflow.UpdateOneID(req.GetId())
+	return r.repository.UpdateOne(ctx, builder, req.Data, req.GetUpdateMask(),
+		func(dto *contentV1.Workflow) {
+			builder.
+				SetNillableName(req.Data.Name).
+				SetNillableDescription(req.Data.Description).
+				SetInitialStage(def.InitialStage).
+				SetStages(def.Stages).
+				SetTransitions(def.Transitions).
+				SetNillableEnabled(req.Data.Enabled).
+				SetNillableSortOrder(req.Data.SortOrder).
+				SetUpdatedAt(time.Now())
+			if req.Data.PostTypes != nil {
+				builder.SetPostTypes(req.Data.PostTypes)
+			}
+
+			// updated_by 强制由服务端 viewer context 推导，忽略客户端传入值
+			if hasUser {
+				builder.SetUpdatedBy(callerUserID)
+			}
+		},
+		func(s *sql.Selector) {
+			s.Where(sql.EQ(entWorkflow.FieldID, req.GetId()))
+		},
+	)
+}
+
+func (r *WorkflowRepo) Delete(ctx context.Context, req *contentV1.DeleteWorkflowRequest) error {
+	if req == nil {
+		return contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	inUse, err := r.entClient.Client().Post.Query().
+		Where(post.WorkflowIDEQ(req.GetId())).
+		Exist(ctx)
+	if err != nil {
+		r.log.Errorf("query workflow posts failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("query workflow posts failed")
+	}
+	if inUse {
+		return contentV1.ErrorConflict("workflow is still in use by posts")
+	}
+
+	builder := r.entClient.Client().Workflow.Delete().
+		Where(entWorkflow.IDEQ(req.GetId()))
+	if tid, hasTenant := maybeTenantFromViewer(ctx); hasTenant {
+		builder.Where(entWorkflow.TenantIDEQ(tid))
+	}
+
+	affected, err := builder.Exec(ctx)
+	if err != nil {
+		r.log.Errorf("delete workflow failed: %s", err.Error())
+		return contentV1.ErrorInternalServerError("delete workflow failed")
+	}
+	if affected == 0 {
+		return contentV1.ErrorNotFound("workflow not found")
+	}
+
+	return nil
+}
+
+// EnabledWorkflows 返回租户内启用的工作流，按排序与 ID 升序
+func (r *WorkflowRepo) EnabledWorkflows(ctx context.Context, tenantID uint32) ([]*ent.Workflow, error) {
+	workflows, err := r.entClient.Client().Workflow.Query().
+		Where(
+			entWorkflow.TenantIDEQ(tenantID),
+			entWorkflow.EnabledEQ(true),
+		).
+		Order(ent.Asc(entWorkflow.FieldSortOrder), ent.Asc(entWorkflow.FieldID)).
+		All(ctx)
+	if err != nil {
+		r.log.Errorf("query workflows failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("query workflows failed")
+	}
+	return workflows, nil
+}
+
+// Resolve 返回新建文章应进入的工作流：优先挂载了该帖子类型的工作流，
+// 其次未挂载任何帖子类型的租户默认工作流；均无时返回 nil，文章不受工作流约束。
+func (r *WorkflowRepo) Resolve(ctx context.Context, tenantID uint32, postType string) (*ent.Workflow, error) {
+	workflows, err := r.EnabledWorkflows(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	var fallback *ent.Workflow
+	for _, w := range workflows {
+		if slices.Contains(w.PostTypes, postType) {
+			return w, nil
+		}
+		if len(w.PostTypes) == 0 && fallback == nil {
+			fallback = w
+		}
+	}
+
+	return fallback, nil
+}
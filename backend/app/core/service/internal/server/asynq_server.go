@@ -13,7 +13,18 @@ import (
 )
 
 // NewAsynqServer creates a new asynq server.
-func NewAsynqServer(ctx *bootstrap.Context, taskService *service.TaskService, searchService *service.SearchService) *asynq.Server {
+func NewAsynqServer(
+	ctx *bootstrap.Context,
+	taskService *service.TaskService,
+	searchService *service.SearchService,
+	commentNotificationService *service.CommentNotificationService,
+	trashService *service.TrashService,
+	releaseService *service.ReleaseService,
+	formService *service.FormService,
+	translationJobService *service.TranslationJobService,
+	aiAssistService *service.AiAssistService,
+	importJobService *service.ImportJobService,
+) *asynq.Server {
 	cfg := ctx.GetConfig()
 
 	if cfg == nil || cfg.Server == nil || cfg.Server.Asynq == nil {
@@ -44,6 +55,43 @@ func NewAsynqServer(ctx *bootstrap.Context, taskService *service.TaskService, se
 		log.Error(err)
 	}
 
+	// 注册评论通知任务订阅者：评论通过审核后向被回复者、文章作者与收藏者投递站内信与邮件。
+	if err = asynq.RegisterSubscriber(srv, task.CommentNotifyTaskType, commentNotificationService.DispatchCommentNotify); err != nil {
+		log.Error(err)
+	}
+
+	// 注册回收站清理任务订阅者：彻底删除超过租户保留期的回收站条目。
+	// 周期由后台任务管理配置（type_name=trash.purge）。
+	if err = asynq.RegisterSubscriber(srv, task.TrashPurgeTaskType, trashService.PurgeExpired); err != nil {
+		log.Error(err)
+	}
+
+	// 注册定时发布任务订阅者：发布到期的定时发布集。
+	// 周期由后台任务管理配置（type_name=release.publish）。
+	if err = asynq.RegisterSubscriber(srv, task.ReleasePublishTaskType, releaseService.PublishDue); err != nil {
+		log.Error(err)
+	}
+
+	// 注册表单提交通知任务订阅者：向表单配置的接收人投递站内信并回调 Webhook。
+	if err = asynq.RegisterSubscriber(srv, task.FormNotifyTaskType, formService.DispatchFormNotify); err != nil {
+		log.Error(err)
+	}
+
+	// 注册批量机器翻译任务订阅者：把内容翻译为各目标语言的机器翻译草稿并记录进度。
+	if err = asynq.RegisterSubscriber(srv, task.TranslationJobTaskType, translationJobService.RunTranslationJob); err != nil {
+		log.Error(err)
+	}
+
+	// 注册 AI 内容辅助任务订阅者：调用大语言模型生成摘要、SEO 描述、推荐标签与备选标题并计量 Token 用量。
+	if err = asynq.RegisterSubscriber(srv, task.AiAssistTaskType, aiAssistService.RunAiAssistJob); err != nil {
+		log.Error(err)
+	}
+
+	// 注册内容导入任务订阅者：解析 WordPress 导出文件或 Markdown 归档，下载附件并写入文章、页面与评论。
+	if err = asynq.RegisterSubscriber(srv, task.ContentImportTaskType, importJobService.RunImportJob); err != nil {
+		log.Error(err)
+	}
+
 	// 启动所有的任务
 	_, _ = taskService.StartAllTask(appViewer.NewSystemViewerContext(ctx.Context()), nil)
 
@@ -23,6 +23,7 @@ import (
 	siteV1 "go-wind-cms/api/gen/go/site/service/v1"
 	storageV1 "go-wind-cms/api/gen/go/storage/service/v1"
 	taskV1 "go-wind-cms/api/gen/go/task/service/v1"
+	translatorV1 "go-wind-cms/api/gen/go/translator/service/v1"
 
 	"go-wind-cms/pkg/middleware/ent"
 )
@@ -74,6 +75,8 @@ func NewGrpcServer(
 	internalMessageRecipientService *service.InternalMessageRecipientService,
 
 	commentService *service.CommentService,
+	commentModerationService *service.CommentModerationService,
+	commentNotificationService *service.CommentNotificationService,
 
 	interactionService *service.InteractionService,
 	interactionAdminService *service.InteractionAdminService,
@@ -83,6 +86,26 @@ func NewGrpcServer(
 	tagService *service.TagService,
 	pageService *service.PageService,
 	sectionService *service.SectionService,
+	redirectService *service.RedirectService,
+	routeService *service.RouteService,
+	fieldGroupService *service.FieldGroupService,
+	contentModelService *service.ContentModelService,
+	contentEntryService *service.ContentEntryService,
+	workflowService *service.WorkflowService,
+	editorialService *service.EditorialService,
+	trashService *service.TrashService,
+	previewService *service.PreviewService,
+	releaseService *service.ReleaseService,
+	formService *service.FormService,
+	translationJobService *service.TranslationJobService,
+	translationMemoryService *service.TranslationMemoryService,
+	glossaryService *service.GlossaryService,
+	translationDashboardService *service.TranslationDashboardService,
+	translatorService *service.TranslatorService,
+	aiAssistService *service.AiAssistService,
+	seoService *service.SeoService,
+	syndicationService *service.SyndicationService,
+	importJobService *service.ImportJobService,
 
 	siteService *service.SiteService,
 	siteSettingService *service.SiteSettingService,
@@ -138,6 +161,8 @@ func NewGrpcServer(
 	internalMessageV1.RegisterInternalMessageRecipientServiceServer(srv, internalMessageRecipientService)
 
 	commentV1.RegisterCommentServiceServer(srv, commentService)
+	commentV1.RegisterCommentModerationServiceServer(srv, commentModerationService)
+	commentV1.RegisterCommentNotificationServiceServer(srv, commentNotificationService)
 
 	interactionV1.RegisterInteractionServiceServer(srv, interactionService)
 	interactionV1.RegisterInteractionAdminServiceServer(srv, interactionAdminService)
@@ -147,6 +172,26 @@ func NewGrpcServer(
 	contentV1.RegisterTagServiceServer(srv, tagService)
 	contentV1.RegisterPageServiceServer(srv, pageService)
 	contentV1.RegisterSectionServiceServer(srv, sectionService)
+	contentV1.RegisterRedirectServiceServer(srv, redirectService)
+	contentV1.RegisterRouteServiceServer(srv, routeService)
+	contentV1.RegisterFieldGroupServiceServer(srv, fieldGroupService)
+	contentV1.RegisterContentModelServiceServer(srv, contentModelService)
+	contentV1.RegisterContentEntryServiceServer(srv, contentEntryService)
+	contentV1.RegisterWorkflowServiceServer(srv, workflowService)
+	contentV1.RegisterEditorialServiceServer(srv, editorialService)
+	contentV1.RegisterTrashServiceServer(srv, trashService)
+	contentV1.RegisterPreviewServiceServer(srv, previewService)
+	contentV1.RegisterReleaseServiceServer(srv, releaseService)
+	contentV1.RegisterFormServiceServer(srv, formService)
+	contentV1.RegisterTranslationJobServiceServer(srv, translationJobService)
+	contentV1.RegisterTranslationMemoryServiceServer(srv, translationMemoryService)
+	contentV1.RegisterGlossaryServiceServer(srv, glossaryService)
+	contentV1.RegisterTranslationDashboardServiceServer(srv, translationDashboardService)
+	translatorV1.RegisterTranslatorServiceServer(srv, translatorService)
+	contentV1.RegisterAiAssistServiceServer(srv, aiAssistService)
+	contentV1.RegisterSeoServiceServer(srv, seoService)
+	contentV1.RegisterSyndicationServiceServer(srv, syndicationService)
+	contentV1.RegisterImportJobServiceServer(srv, importJobService)
 
 	siteV1.RegisterSiteSettingServiceServer(srv, siteSettingService)
 	siteV1.RegisterSiteServiceServer(srv, siteService)
@@ -0,0 +1,330 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-kratos/kratos/v2/log"
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/go-crud/viewer"
+	"github.com/tx7do/go-utils/trans"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data"
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/aiassistjob"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/llm"
+	"go-wind-cms/pkg/content/summary"
+	appViewer "go-wind-cms/pkg/entgo/viewer"
+	"go-wind-cms/pkg/task"
+)
+
+const (
+	// aiSummaryMaxLength AI 摘要的最大字符数
+	aiSummaryMaxLength = 200
+
+	// aiTagSuggestionCount 推荐标签数
+	aiTagSuggestionCount = 8
+
+	// aiTitleAlternativeCount 备选标题数
+	aiTitleAlternativeCount = 5
+)
+
+// AiAssistService AI 内容辅助：创建作业并入队 ai.assist 任务，worker 调用租户配置的大语言模型
+// 生成摘要、SEO 描述、推荐标签与备选标题，每个任务的 Token 用量计入租户台账。
+type AiAssistService struct {
+	contentV1.UnimplementedAiAssistServiceServer
+
+	log *log.Helper
+
+	aiAssistJobRepo *data.AiAssistJobRepo
+	llmUsageRepo    *data.LlmUsageRepo
+	llmProviders    *data.LlmProviders
+	taskService     *TaskService
+}
+
+func NewAiAssistService(
+	ctx *bootstrap.Context,
+	aiAssistJobRepo *data.AiAssistJobRepo,
+	llmUsageRepo *data.LlmUsageRepo,
+	llmProviders *data.LlmProviders,
+	taskService *TaskService,
+) *AiAssistService {
+	return &AiAssistService{
+		log:             ctx.NewLoggerHelper("ai-assist/service/core-service"),
+		aiAssistJobRepo: aiAssistJobRepo,
+		llmUsageRepo:    llmUsageRepo,
+		llmProviders:    llmProviders,
+		taskService:     taskService,
+	}
+}
+
+func (s *AiAssistService) ListAiAssistJobs(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListAiAssistJobResponse, error) {
+	return s.aiAssistJobRepo.List(ctx, req)
+}
+
+func (s *AiAssistService) GetAiAssistJob(ctx context.Context, req *contentV1.GetAiAssistJobRequest) (*contentV1.AiAssistJob, error) {
+	return s.aiAssistJobRepo.Get(ctx, req.GetId())
+}
+
+func (s *AiAssistService) GetLlmUsage(ctx context.Context, req *contentV1.GetLlmUsageRequest) (*contentV1.LlmUsageReport, error) {
+	return s.llmUsageRepo.Report(ctx, req)
+}
+
+func (s *AiAssistService) CreateAiAssistJob(ctx context.Context, req *contentV1.CreateAiAssistJobRequest) (*contentV1.AiAssistJob, error) {
+	tenantID := tenantIDFromContext(ctx)
+	if !s.llmProviders.Configured(tenantID) {
+		return nil, contentV1.ErrorServiceUnavailable("llm provider not configured")
+	}
+	if err := s.llmUsageRepo.CheckQuota(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	return s.createAndEnqueue(ctx, req)
+}
+
+func (s *AiAssistService) createAndEnqueue(ctx context.Context, req *contentV1.CreateAiAssistJobRequest) (*contentV1.AiAssistJob, error) {
+	job, err := s.aiAssistJobRepo.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.taskService.EnqueueAiAssistJob(&task.AiAssistPayload{JobID: job.GetId()}); err != nil {
+		// 入队失败时作业不会被执行，直接置为失败，避免一直停留在等待状态
+		_ = s.aiAssistJobRepo.Finish(ctx, job.GetId(), nil, nil, err)
+		return nil, contentV1.ErrorInternalServerError("enqueue ai assist job failed")
+	}
+
+	return job, nil
+}
+
+// EnqueueAutoSummary 开启自动摘要的文章保存正文且未填写摘要后，创建应用摘要的作业。
+//
+// best-effort：租户未配置供应商或已达用量上限时跳过，失败仅记日志，不影响文章保存。
+// 作业执行时若该语言版本已被再次修改，生成的摘要仅作为建议，不覆盖编辑的修改。
+func (s *AiAssistService) EnqueueAutoSummary(ctx context.Context, postID uint32, languageCode string) {
+	if postID == 0 || languageCode == "" {
+		return
+	}
+
+	tenantID := tenantIDFromContext(ctx)
+	if !s.llmProviders.Configured(tenantID) {
+		return
+	}
+
+	enabled, err := s.aiAssistJobRepo.AutoSummaryEnabled(ctx, postID)
+	if err != nil || !enabled {
+		return
+	}
+
+	if err = s.llmUsageRepo.CheckQuota(ctx, tenantID); err != nil {
+		s.log.Infof("auto summary skipped (post_id=%d lang=%s): %v", postID, languageCode, err)
+		return
+	}
+
+	if _, err = s.createAndEnqueue(ctx, &contentV1.CreateAiAssistJobRequest{
+		EntityType:   contentV1.AiAssistJob_ENTITY_TYPE_POST,
+		EntityId:     postID,
+		LanguageCode: languageCode,
+		Tasks:        []contentV1.AiAssistTask{contentV1.AiAssistTask_AI_ASSIST_TASK_SUMMARY},
+		Apply:        trans.Ptr(true),
+	}); err != nil {
+		s.log.Errorf("create auto summary job failed (post_id=%d lang=%s): %v", postID, languageCode, err)
+	}
+}
+
+// RunAiAssistJob 处理 ai.assist 任务。
+// 签名遵循 (taskType string, payload *T) error 模式（参考 TaskService.AsyncBackup）。
+//
+// 单个任务生成失败只记入该任务的结果，不影响其他任务；内容不存在、未配置供应商或超出用量上限使作业失败。
+func (s *AiAssistService) RunAiAssistJob(_ string, payload *task.AiAssistPayload) error {
+	if payload == nil || payload.JobID == 0 {
+		s.log.Warnf("ai assist job: invalid payload")
+		return nil
+	}
+
+	// 注入 SystemViewer：worker 需跨租户读取内容，供应商、上限与台账的租户取自作业记录
+	ctx := appViewer.NewSystemViewerContext(context.Background())
+
+	job, err := s.aiAssistJobRepo.Claim(ctx, payload.JobID)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		// 已被其他 worker 认领或已结束
+		return nil
+	}
+
+	tenantID := trans.Uint32Value(job.TenantID)
+
+	provider, err := s.llmProviders.Provider(tenantID)
+	if err != nil {
+		return s.aiAssistJobRepo.Finish(ctx, job.ID, nil, nil, err)
+	}
+	if err = s.llmUsageRepo.CheckQuota(ctx, tenantID); err != nil {
+		return s.aiAssistJobRepo.Finish(ctx, job.ID, nil, nil, err)
+	}
+
+	src, err := s.aiAssistJobRepo.LoadSource(ctx, job)
+	if err != nil {
+		return s.aiAssistJobRepo.Finish(ctx, job.ID, nil, nil, err)
+	}
+
+	usage := &data.AiAssistJobUsage{Provider: provider.Name()}
+	apply := &data.AiAssistApply{}
+	results := make([]*contentV1.AiAssistResult, 0, len(job.Tasks))
+
+	for _, t := range job.Tasks {
+		if !data.AiAssistTaskSupported(job.EntityType, t) {
+			results = append(results, &contentV1.AiAssistResult{Task: t, Outcome: contentV1.AiAssistResult_OUTCOME_SKIPPED})
+			continue
+		}
+
+		meter := llm.NewMeter(provider)
+		suggestions, genErr := generateAssist(ctx, meter, t, src, job.LanguageCode)
+		if meter.Calls() > 0 {
+			s.recordUsage(ctx, job, t, meter, usage)
+		}
+		if genErr != nil {
+			s.log.Warnf("ai assist job [%d] task [%s] failed: %s", job.ID, t, genErr.Error())
+			results = append(results, &contentV1.AiAssistResult{
+				Task:    t,
+				Outcome: contentV1.AiAssistResult_OUTCOME_FAILED,
+				Error:   trans.Ptr(genErr.Error()),
+			})
+			continue
+		}
+
+		results = append(results, &contentV1.AiAssistResult{
+			Task:        t,
+			Outcome:     contentV1.AiAssistResult_OUTCOME_SUGGESTED,
+			Suggestions: suggestions,
+		})
+
+		if job.Apply {
+			switch t {
+			case contentV1.AiAssistTask_AI_ASSIST_TASK_SUMMARY:
+				apply.Summary = trans.Ptr(suggestions[0])
+			case contentV1.AiAssistTask_AI_ASSIST_TASK_SEO_DESCRIPTION:
+				apply.MetaDescription = trans.Ptr(suggestions[0])
+			}
+		}
+	}
+
+	if job.Apply {
+		s.applyResults(ctx, job, apply, results)
+	}
+
+	s.log.Infof("ai assist job [%d] finished: %d tasks, %d tokens", job.ID, len(results), usage.PromptTokens+usage.CompletionTokens)
+
+	return s.aiAssistJobRepo.Finish(ctx, job.ID, results, usage, nil)
+}
+
+// recordUsage 把一个任务的用量计入台账并累加到作业
+func (s *AiAssistService) recordUsage(ctx context.Context, job *ent.AiAssistJob, t contentV1.AiAssistTask, meter *llm.Meter, total *data.AiAssistJobUsage) {
+	used := meter.Usage()
+	total.Model = meter.Model()
+	total.PromptTokens += used.PromptTokens
+	total.CompletionTokens += used.CompletionTokens
+
+	if err := s.llmUsageRepo.Record(ctx, trans.Uint32Value(job.TenantID), job.ID, t, total.Provider, total.Model, used); err != nil {
+		s.log.Errorf("record llm usage failed (job_id=%d task=%s): %v", job.ID, t, err)
+	}
+}
+
+// applyResults 把摘要与 SEO 描述应用到内容；内容已被修改时结果保留为建议
+func (s *AiAssistService) applyResults(ctx context.Context, job *ent.AiAssistJob, apply *data.AiAssistApply, results []*contentV1.AiAssistResult) {
+	applied, err := s.aiAssistJobRepo.Apply(ctx, job, apply)
+	if err != nil {
+		s.log.Errorf("ai assist job [%d] apply failed: %v", job.ID, err)
+		return
+	}
+	if !applied {
+		return
+	}
+
+	for _, r := range results {
+		if r.GetOutcome() != contentV1.AiAssistResult_OUTCOME_SUGGESTED {
+			continue
+		}
+		if (r.GetTask() == contentV1.AiAssistTask_AI_ASSIST_TASK_SUMMARY && apply.Summary != nil) ||
+			(r.GetTask() == contentV1.AiAssistTask_AI_ASSIST_TASK_SEO_DESCRIPTION && apply.MetaDescription != nil) {
+			r.Outcome = contentV1.AiAssistResult_OUTCOME_APPLIED
+		}
+	}
+
+	// 摘要变更影响 ES 文档，入队重索引（best-effort）
+	if job.EntityType == aiassistjob.EntityTypeEntityTypePost && apply.Summary != nil {
+		if err = s.taskService.EnqueueSearchReindex(&task.SearchReindexPayload{
+			Entity:   "post",
+			ID:       job.EntityID,
+			TenantID: trans.Uint32Value(job.TenantID),
+			Op:       "index",
+		}); err != nil {
+			s.log.Errorf("enqueue post reindex failed (post_id=%d): %v", job.EntityID, err)
+		}
+	}
+}
+
+// generateAssist 执行一个任务，返回生成的内容（至少一项）
+func generateAssist(ctx context.Context, provider llm.Provider, t contentV1.AiAssistTask, src *data.AiAssistSource, lang string) ([]string, error) {
+	// 正文为空时以摘要为依据；摘要本身须依据正文生成
+	content := src.Content
+	if llm.PlainText(content, 0) == "" && t != contentV1.AiAssistTask_AI_ASSIST_TASK_SUMMARY {
+		content = src.Summary
+	}
+	if llm.PlainText(content, 0) == "" && t != contentV1.AiAssistTask_AI_ASSIST_TASK_TITLE_ALTERNATIVES {
+		return nil, errors.New("content is empty")
+	}
+
+	var (
+		out  []string
+		resp *llm.Response
+		err  error
+	)
+	switch t {
+	case contentV1.AiAssistTask_AI_ASSIST_TASK_SUMMARY:
+		var text string
+		if text, err = summary.GenerateSummaryByAI(ctx, provider, content, lang, aiSummaryMaxLength); err == nil && text != "" {
+			out = []string{text}
+		}
+
+	case contentV1.AiAssistTask_AI_ASSIST_TASK_SEO_DESCRIPTION:
+		if resp, err = provider.Complete(ctx, llm.MetaDescriptionPrompt(src.Title, content, lang)); err == nil {
+			if text := llm.Clip(resp.Text, llm.MaxMetaDescription); text != "" {
+				out = []string{text}
+			}
+		}
+
+	case contentV1.AiAssistTask_AI_ASSIST_TASK_TAG_SUGGESTIONS:
+		if resp, err = provider.Complete(ctx, llm.TagSuggestionsPrompt(src.Title, content, lang, src.KnownTags, aiTagSuggestionCount)); err == nil {
+			out = llm.ParseList(resp.Text, aiTagSuggestionCount)
+		}
+
+	case contentV1.AiAssistTask_AI_ASSIST_TASK_TITLE_ALTERNATIVES:
+		if resp, err = provider.Complete(ctx, llm.TitleAlternativesPrompt(src.Title, content, lang, aiTitleAlternativeCount)); err == nil {
+			out = llm.ParseList(resp.Text, aiTitleAlternativeCount)
+		}
+
+	default:
+		return nil, errors.New("unsupported task")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(out) == 0 {
+		return nil, llm.ErrEmptyResponse
+	}
+	return out, nil
+}
+
+// tenantIDFromContext 从 viewer 取当前租户ID，平台或无 viewer 时为 0
+func tenantIDFromContext(ctx context.Context) uint32 {
+	if vc, exist := viewer.FromContext(ctx); exist && vc != nil {
+		return uint32(vc.TenantID())
+	}
+	return 0
+}
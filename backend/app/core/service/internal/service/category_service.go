@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-kratos/kratos/v2/log"
 	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/go-utils/trans"
 	"github.com/tx7do/kratos-bootstrap/bootstrap"
 	"google.golang.org/protobuf/types/known/emptypb"
 
@@ -16,14 +17,20 @@ import (
 type CategoryService struct {
 	contentV1.UnimplementedCategoryServiceServer
 
-	categoryRepo *data.CategoryRepo
-	log          *log.Helper
+	categoryRepo       *data.CategoryRepo
+	trashRepo          *data.TrashRepo
+	syndicationRepo    *data.SyndicationRepo
+	structuredDataRepo *data.StructuredDataRepo
+	log                *log.Helper
 }
 
-func NewCategoryService(ctx *bootstrap.Context, uc *data.CategoryRepo) *CategoryService {
+func NewCategoryService(ctx *bootstrap.Context, uc *data.CategoryRepo, trashRepo *data.TrashRepo, syndicationRepo *data.SyndicationRepo, structuredDataRepo *data.StructuredDataRepo) *CategoryService {
 	return &CategoryService{
-		log:          ctx.NewLoggerHelper("category/service/core-service"),
-		categoryRepo: uc,
+		log:                ctx.NewLoggerHelper("category/service/core-service"),
+		categoryRepo:       uc,
+		trashRepo:          trashRepo,
+		syndicationRepo:    syndicationRepo,
+		structuredDataRepo: structuredDataRepo,
 	}
 }
 
@@ -32,22 +39,51 @@ func (s *CategoryService) List(ctx context.Context, req *paginationV1.PagingRequ
 }
 
 func (s *CategoryService) Get(ctx context.Context, req *contentV1.GetCategoryRequest) (*contentV1.Category, error) {
-	return s.categoryRepo.Get(ctx, req)
+	dto, err := s.categoryRepo.Get(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 前台按需附带 JSON-LD 结构化数据；生成失败时不返回该字段，不影响详情读取
+	if req.GetIncludeJsonLd() {
+		if ld := s.structuredDataRepo.Category(ctx, req.GetHost(), dto); ld != "" {
+			dto.JsonLd = trans.Ptr(ld)
+		}
+	}
+
+	return dto, nil
 }
 
 func (s *CategoryService) Create(ctx context.Context, req *contentV1.CreateCategoryRequest) (*contentV1.Category, error) {
-	return s.categoryRepo.Create(ctx, req)
+	dto, err := s.categoryRepo.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.syndicationRepo.Invalidate(ctx)
+
+	return dto, nil
 }
 
 func (s *CategoryService) Update(ctx context.Context, req *contentV1.UpdateCategoryRequest) (*contentV1.Category, error) {
-	return s.categoryRepo.Update(ctx, req)
+	dto, err := s.categoryRepo.Update(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.syndicationRepo.Invalidate(ctx)
+
+	return dto, nil
 }
 
 func (s *CategoryService) Delete(ctx context.Context, req *contentV1.DeleteCategoryRequest) (*emptypb.Empty, error) {
-	err := s.categoryRepo.Delete(ctx, req)
-	if err != nil {
+	// 移入回收站，彻底删除经 TrashService 完成
+	if err := s.trashRepo.Trash(ctx, contentV1.TrashItem_ENTITY_TYPE_CATEGORY, req.GetId()); err != nil {
 		return nil, err
 	}
+
+	s.syndicationRepo.Invalidate(ctx)
+
 	return &emptypb.Empty{}, nil
 }
 
@@ -67,11 +103,25 @@ func (s *CategoryService) GetTranslation(ctx context.Context, req *contentV1.Get
 }
 
 func (s *CategoryService) CreateTranslation(ctx context.Context, req *contentV1.CreateCategoryTranslationRequest) (*contentV1.CategoryTranslation, error) {
-	return s.categoryRepo.CreateTranslation(ctx, req)
+	dto, err := s.categoryRepo.CreateTranslation(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.syndicationRepo.Invalidate(ctx)
+
+	return dto, nil
 }
 
 func (s *CategoryService) UpdateTranslation(ctx context.Context, req *contentV1.UpdateCategoryTranslationRequest) (*contentV1.CategoryTranslation, error) {
-	return s.categoryRepo.UpdateTranslation(ctx, req)
+	dto, err := s.categoryRepo.UpdateTranslation(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.syndicationRepo.Invalidate(ctx)
+
+	return dto, nil
 }
 
 func (s *CategoryService) DeleteTranslation(ctx context.Context, req *contentV1.DeleteCategoryTranslationRequest) (*emptypb.Empty, error) {
@@ -79,5 +129,8 @@ func (s *CategoryService) DeleteTranslation(ctx context.Context, req *contentV1.
 	if err != nil {
 		return nil, err
 	}
+
+	s.syndicationRepo.Invalidate(ctx)
+
 	return &emptypb.Empty{}, nil
 }
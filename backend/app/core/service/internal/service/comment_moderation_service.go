@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/tx7do/go-crud/viewer"
+	"github.com/tx7do/go-utils/timeutil"
+	"github.com/tx7do/go-utils/trans"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+
+	"go-wind-cms/app/core/service/internal/data"
+
+	auditV1 "go-wind-cms/api/gen/go/audit/service/v1"
+	commentV1 "go-wind-cms/api/gen/go/comment/service/v1"
+)
+
+// maxBulkModerateSize 单次批量审核的评论数上限
+const maxBulkModerateSize = 500
+
+// CommentModerationService 评论审核面：审核队列、批量处理与作者封禁/信任规则。
+//
+// 与通用 CommentService 相对，本服务面向后台审核员：
+//   - 调用方须带有操作人身份（OperatorMetadata 注入的 viewer context），否则 401。
+//   - 数据按操作人所在租户隔离；平台管理员（租户为 0）可跨租户处理。
+//   - 每次操作写 OperationAuditLog（记录操作人/动作/目标/成败），与 InteractionAdminService 一致。
+type CommentModerationService struct {
+	commentV1.UnimplementedCommentModerationServiceServer
+
+	commentRepo           *data.CommentRepo
+	authorRuleRepo        *data.CommentAuthorRuleRepo
+	operationAuditLogRepo *data.OperationAuditLogRepo
+	taskService           *TaskService
+	log                   *log.Helper
+}
+
+func NewCommentModerationService(
+	ctx *bootstrap.Context,
+	commentRepo *data.CommentRepo,
+	authorRuleRepo *data.CommentAuthorRuleRepo,
+	operationAuditLogRepo *data.OperationAuditLogRepo,
+	taskService *TaskService,
+) *CommentModerationService {
+	return &CommentModerationService{
+		commentRepo:           commentRepo,
+		authorRuleRepo:        authorRuleRepo,
+		operationAuditLogRepo: operationAuditLogRepo,
+		taskService:           taskService,
+		log:                   ctx.NewLoggerHelper("comment-moderation/service/core-service"),
+	}
+}
+
+// requireOperator 校验调用者带有操作人身份，返回操作人的 (tenantID, userID)
+func (s *CommentModerationService) requireOperator(ctx context.Context) (operatorTenantID, operatorUserID uint32, err error) {
+	vc, exist := viewer.FromContext(ctx)
+	if !exist || vc == nil || vc.UserID() == 0 {
+		return 0, 0, commentV1.ErrorUnauthorized("operator identity required")
+	}
+	return uint32(vc.TenantID()), uint32(vc.UserID()), nil
+}
+
+// writeAudit 写一条 OperationAuditLog，detail 记录审核动作与处理条数
+func (s *CommentModerationService) writeAudit(
+	ctx context.Context,
+	operatorTenantID, operatorUserID uint32,
+	action auditV1.OperationAuditLog_ActionType,
+	resourceType, resourceID string,
+	detail map[string]any,
+	err error,
+) {
+	req := &auditV1.CreateOperationAuditLogRequest{
+		Data: &auditV1.OperationAuditLog{
+			TenantId:     trans.Ptr(operatorTenantID),
+			UserId:       trans.Ptr(operatorUserID),
+			Action:       action.Enum(),
+			ResourceType: trans.Ptr(resourceType),
+			ResourceId:   trans.Ptr(resourceID),
+			Success:      trans.Ptr(err == nil),
+		},
+	}
+	if len(detail) > 0 {
+		if b, mErr := json.Marshal(detail); mErr == nil {
+			req.Data.AfterData = trans.Ptr(string(b))
+		}
+	}
+	if err != nil {
+		req.Data.FailureReason = trans.Ptr(err.Error())
+	}
+	if aErr := s.operationAuditLogRepo.Create(ctx, req); aErr != nil {
+		s.log.Errorf("write comment moderation audit failed: %s", aErr.Error())
+	}
+}
+
+func joinIDs(ids []uint32) string {
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parts = append(parts, strconv.FormatUint(uint64(id), 10))
+	}
+	return strings.Join(parts, ",")
+}
+
+func (s *CommentModerationService) ListModerationQueue(ctx context.Context, req *commentV1.ListModerationQueueRequest) (*commentV1.ListCommentResponse, error) {
+	if _, _, err := s.requireOperator(ctx); err != nil {
+		return nil, err
+	}
+	return s.commentRepo.ListModerationQueue(ctx, req)
+}
+
+func (s *CommentModerationService) BulkModerate(ctx context.Context, req *commentV1.BulkModerateRequest) (*commentV1.BulkModerateResponse, error) {
+	opTid, opUid, err := s.requireOperator(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.GetIds()) == 0 {
+		return nil, commentV1.ErrorBadRequest("comment ids are required")
+	}
+	if len(req.GetIds()) > maxBulkModerateSize {
+		return nil, commentV1.ErrorBadRequest("too many comments in one request")
+	}
+
+	var affected uint32
+	var changed []uint32
+	auditAction := auditV1.OperationAuditLog_UPDATE
+	switch req.GetAction() {
+	case commentV1.BulkModerateRequest_ACTION_APPROVE:
+		changed, err = s.commentRepo.BulkSetStatus(ctx, req.GetIds(), commentV1.Comment_STATUS_APPROVED)
+		enqueueCommentNotify(ctx, s.taskService, changed...)
+	case commentV1.BulkModerateRequest_ACTION_REJECT:
+		changed, err = s.commentRepo.BulkSetStatus(ctx, req.GetIds(), commentV1.Comment_STATUS_REJECTED)
+	case commentV1.BulkModerateRequest_ACTION_SPAM:
+		changed, err = s.commentRepo.BulkSetStatus(ctx, req.GetIds(), commentV1.Comment_STATUS_SPAM)
+	case commentV1.BulkModerateRequest_ACTION_DELETE:
+		auditAction = auditV1.OperationAuditLog_DELETE
+		affected, err = s.commentRepo.BulkDelete(ctx, req.GetIds())
+	default:
+		return nil, commentV1.ErrorBadRequest("invalid moderation action")
+	}
+	if changed != nil {
+		affected = uint32(len(changed))
+	}
+
+	s.writeAudit(ctx, opTid, opUid, auditAction, "comment", joinIDs(req.GetIds()),
+		map[string]any{"action": req.GetAction().String(), "affected": affected}, err)
+	if err != nil {
+		return nil, err
+	}
+	return &commentV1.BulkModerateResponse{AffectedRows: affected}, nil
+}
+
+func (s *CommentModerationService) BanAuthor(ctx context.Context, req *commentV1.BanAuthorRequest) (*commentV1.AuthorRuleResponse, error) {
+	opTid, opUid, err := s.requireOperator(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ruleType commentV1.CommentAuthorRule_Type
+	var value string
+	switch req.GetAuthor().(type) {
+	case *commentV1.BanAuthorRequest_UserId:
+		ruleType = commentV1.CommentAuthorRule_TYPE_BAN_USER
+		value = strconv.FormatUint(uint64(req.GetUserId()), 10)
+	case *commentV1.BanAuthorRequest_IpAddress:
+		ruleType = commentV1.CommentAuthorRule_TYPE_BAN_IP
+		value = req.GetIpAddress()
+	default:
+		return nil, commentV1.ErrorBadRequest("user_id or ip_address is required")
+	}
+
+	rule, err := s.authorRuleRepo.Save(ctx, opTid, ruleType, value, req.Reason, timeutil.TimestamppbToTime(req.ExpiredAt), opUid)
+
+	var affected uint32
+	if err == nil && req.GetMarkExistingAsSpam() {
+		var changed []uint32
+		changed, err = s.commentRepo.SetStatusByAuthor(ctx, req.GetUserId(), req.GetIpAddress(), "", nil, commentV1.Comment_STATUS_SPAM)
+		affected = uint32(len(changed))
+	}
+
+	s.writeAudit(ctx, opTid, opUid, auditV1.OperationAuditLog_CREATE, "comment_author_rule", ruleType.String()+":"+value,
+		map[string]any{"markExistingAsSpam": req.GetMarkExistingAsSpam(), "affected": affected}, err)
+	if err != nil {
+		return nil, err
+	}
+	return &commentV1.AuthorRuleResponse{Rule: rule, AffectedRows: affected}, nil
+}
+
+func (s *CommentModerationService) TrustAuthor(ctx context.Context, req *commentV1.TrustAuthorRequest) (*commentV1.AuthorRuleResponse, error) {
+	opTid, opUid, err := s.requireOperator(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ruleType commentV1.CommentAuthorRule_Type
+	var value string
+	switch req.GetAuthor().(type) {
+	case *commentV1.TrustAuthorRequest_UserId:
+		ruleType = commentV1.CommentAuthorRule_TYPE_TRUST_USER
+		value = strconv.FormatUint(uint64(req.GetUserId()), 10)
+	case *commentV1.TrustAuthorRequest_AuthorEmail:
+		ruleType = commentV1.CommentAuthorRule_TYPE_TRUST_EMAIL
+		value = req.GetAuthorEmail()
+	default:
+		return nil, commentV1.ErrorBadRequest("user_id or author_email is required")
+	}
+
+	rule, err := s.authorRuleRepo.Save(ctx, opTid, ruleType, value, req.Reason, nil, opUid)
+
+	var affected uint32
+	if err == nil && req.GetApprovePending() {
+		var changed []uint32
+		changed, err = s.commentRepo.SetStatusByAuthor(ctx, req.GetUserId(), "", req.GetAuthorEmail(),
+			trans.Ptr(commentV1.Comment_STATUS_PENDING), commentV1.Comment_STATUS_APPROVED)
+		affected = uint32(len(changed))
+		enqueueCommentNotify(ctx, s.taskService, changed...)
+	}
+
+	s.writeAudit(ctx, opTid, opUid, auditV1.OperationAuditLog_CREATE, "comment_author_rule", ruleType.String()+":"+value,
+		map[string]any{"approvePending": req.GetApprovePending(), "affected": affected}, err)
+	if err != nil {
+		return nil, err
+	}
+	return &commentV1.AuthorRuleResponse{Rule: rule, AffectedRows: affected}, nil
+}
+
+func (s *CommentModerationService) ListAuthorRules(ctx context.Context, req *paginationV1.PagingRequest) (*commentV1.ListCommentAuthorRuleResponse, error) {
+	if _, _, err := s.requireOperator(ctx); err != nil {
+		return nil, err
+	}
+	return s.authorRuleRepo.List(ctx, req)
+}
+
+func (s *CommentModerationService) DeleteAuthorRule(ctx context.Context, req *commentV1.DeleteCommentAuthorRuleRequest) (*emptypb.Empty, error) {
+	opTid, opUid, err := s.requireOperator(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := s.authorRuleRepo.Delete(ctx, req.GetId())
+
+	resourceID := strconv.FormatUint(uint64(req.GetId()), 10)
+	if rule != nil {
+		resourceID = rule.GetType().String() + ":" + rule.GetValue()
+	}
+	s.writeAudit(ctx, opTid, opUid, auditV1.OperationAuditLog_DELETE, "comment_author_rule", resourceID, nil, err)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
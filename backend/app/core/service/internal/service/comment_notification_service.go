@@ -0,0 +1,448 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/tx7do/go-crud/viewer"
+	"github.com/tx7do/go-utils/timeutil"
+	"github.com/tx7do/go-utils/trans"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"go-wind-cms/app/core/service/internal/data"
+
+	commentV1 "go-wind-cms/api/gen/go/comment/service/v1"
+	internalMessageV1 "go-wind-cms/api/gen/go/internal_message/service/v1"
+
+	appViewer "go-wind-cms/pkg/entgo/viewer"
+	"go-wind-cms/pkg/notification"
+	"go-wind-cms/pkg/task"
+)
+
+// commentExcerptLength 通知正文中评论摘录的最大字符数
+const commentExcerptLength = 200
+
+// CommentNotificationService 评论通知：用户通知偏好、一键退订，以及 comment.notify 任务的投递。
+//
+// 投递渠道：
+//   - 站内信：写入 InternalMessage + InternalMessageRecipient，并向 Redis 推送频道发布事件，
+//     由 app 服务订阅后经 SSE 推送给在线用户。
+//   - 邮件：附带 List-Unsubscribe / List-Unsubscribe-Post 头，支持邮件客户端一键退订。
+type CommentNotificationService struct {
+	commentV1.UnimplementedCommentNotificationServiceServer
+
+	log *log.Helper
+
+	cfg    *commentV1.NotificationOption
+	mailer notification.Mailer
+	signer *notification.TokenSigner
+
+	notificationRepo *data.CommentNotificationRepo
+	preferenceRepo   *data.NotificationPreferenceRepo
+
+	inApp *inAppDelivery
+}
+
+func NewCommentNotificationService(
+	ctx *bootstrap.Context,
+	cfg *commentV1.NotificationOption,
+	mailer notification.Mailer,
+	signer *notification.TokenSigner,
+	notificationRepo *data.CommentNotificationRepo,
+	preferenceRepo *data.NotificationPreferenceRepo,
+	internalMessageRepo *data.InternalMessageRepo,
+	internalMessageRecipientRepo *data.InternalMessageRecipientRepo,
+) *CommentNotificationService {
+	l := ctx.NewLoggerHelper("comment-notification/service/core-service")
+	return &CommentNotificationService{
+		log:              l,
+		cfg:              cfg,
+		mailer:           mailer,
+		signer:           signer,
+		notificationRepo: notificationRepo,
+		preferenceRepo:   preferenceRepo,
+		inApp:            newInAppDelivery(l, notificationRepo, internalMessageRepo, internalMessageRecipientRepo),
+	}
+}
+
+// viewerIdentity 从鉴权上下文提取当前用户的 (tenantID, userID)
+func viewerIdentity(ctx context.Context) (uint32, uint32, error) {
+	vc, exist := viewer.FromContext(ctx)
+	if !exist || vc == nil || vc.UserID() == 0 {
+		return 0, 0, commentV1.ErrorUnauthorized("login required")
+	}
+	return uint32(vc.TenantID()), uint32(vc.UserID()), nil
+}
+
+func (s *CommentNotificationService) GetPreference(ctx context.Context, _ *emptypb.Empty) (*commentV1.NotificationPreference, error) {
+	tenantID, userID, err := viewerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.preferenceRepo.Get(ctx, tenantID, userID)
+}
+
+func (s *CommentNotificationService) UpdatePreference(ctx context.Context, req *commentV1.UpdateNotificationPreferenceRequest) (*commentV1.NotificationPreference, error) {
+	tenantID, userID, err := viewerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetData() == nil {
+		return nil, commentV1.ErrorBadRequest("invalid parameter")
+	}
+	return s.preferenceRepo.Save(ctx, tenantID, userID, req.GetData())
+}
+
+// Unsubscribe 凭退订令牌关闭对应类型的邮件通知；令牌未指定类型时关闭全部邮件通知。
+// 令牌本身即授权凭据，因此以系统身份写入令牌中的租户与用户。
+func (s *CommentNotificationService) Unsubscribe(_ context.Context, req *commentV1.UnsubscribeRequest) (*commentV1.UnsubscribeResponse, error) {
+	if s.signer == nil {
+		return nil, commentV1.ErrorBadRequest("unsubscribe is not available")
+	}
+
+	claims, err := s.signer.Verify(req.GetToken())
+	if err != nil {
+		return nil, commentV1.ErrorBadRequest("invalid unsubscribe token")
+	}
+
+	reason := commentV1.NotificationReason(commentV1.NotificationReason_value[claims.Topic])
+
+	patch := &commentV1.NotificationPreference{}
+	switch reason {
+	case commentV1.NotificationReason_NOTIFICATION_REASON_REPLY:
+		patch.ReplyEnabled = trans.Ptr(false)
+	case commentV1.NotificationReason_NOTIFICATION_REASON_POST_COMMENT:
+		patch.PostCommentEnabled = trans.Ptr(false)
+	case commentV1.NotificationReason_NOTIFICATION_REASON_WATCHED_POST:
+		patch.WatchedPostEnabled = trans.Ptr(false)
+	default:
+		reason = commentV1.NotificationReason_NOTIFICATION_REASON_UNSPECIFIED
+		patch.EmailEnabled = trans.Ptr(false)
+	}
+
+	ctx := appViewer.NewSystemViewerContext(context.Background())
+	if _, err = s.preferenceRepo.Save(ctx, claims.TenantID, claims.UserID, patch); err != nil {
+		return nil, err
+	}
+
+	s.log.Infof("user [%d] tenant [%d] unsubscribed from comment notification [%s]", claims.UserID, claims.TenantID, reason.String())
+
+	return &commentV1.UnsubscribeResponse{Reason: reason}, nil
+}
+
+// reasonEnabled 判断用户是否接收该原因的通知
+func reasonEnabled(pref *commentV1.NotificationPreference, reason commentV1.NotificationReason) bool {
+	switch reason {
+	case commentV1.NotificationReason_NOTIFICATION_REASON_REPLY:
+		return pref.GetReplyEnabled()
+	case commentV1.NotificationReason_NOTIFICATION_REASON_POST_COMMENT:
+		return pref.GetPostCommentEnabled()
+	case commentV1.NotificationReason_NOTIFICATION_REASON_WATCHED_POST:
+		return pref.GetWatchedPostEnabled()
+	default:
+		return false
+	}
+}
+
+// DispatchCommentNotify 处理 comment.notify 任务：解析接收人并按偏好投递站内信与邮件。
+// 签名遵循 (taskType string, payload *T) error 模式（参考 TaskService.AsyncBackup）。
+func (s *CommentNotificationService) DispatchCommentNotify(_ string, payload *task.CommentNotifyPayload) error {
+	if payload == nil || payload.CommentID == 0 {
+		s.log.Warnf("comment notify: invalid payload")
+		return nil
+	}
+	if !s.cfg.GetEnabled() {
+		return nil
+	}
+
+	// 注入 SystemViewer：worker 需跨租户读取评论、文章与用户
+	ctx := appViewer.NewSystemViewerContext(context.Background())
+
+	first, err := s.notificationRepo.MarkNotified(ctx, payload.CommentID)
+	if err != nil {
+		return err
+	}
+	if !first {
+		return nil
+	}
+
+	if err = s.dispatch(ctx, payload.CommentID); err != nil {
+		// 投递前置步骤失败，清除标记让 asynq 重试
+		s.notificationRepo.UnmarkNotified(ctx, payload.CommentID)
+		return err
+	}
+	return nil
+}
+
+func (s *CommentNotificationService) dispatch(ctx context.Context, commentID uint32) error {
+	target, err := s.notificationRepo.Resolve(ctx, commentID)
+	if err != nil {
+		return err
+	}
+	if target == nil || len(target.Recipients) == 0 {
+		return nil
+	}
+
+	tenantID := trans.Uint32Value(target.Comment.TenantID)
+
+	userIDs := make([]uint32, 0, len(target.Recipients))
+	for _, r := range target.Recipients {
+		userIDs = append(userIDs, r.UserID)
+	}
+	prefs, err := s.preferenceRepo.GetMany(ctx, tenantID, userIDs)
+	if err != nil {
+		return err
+	}
+
+	var inApp, email []data.CommentNotifyRecipient
+	for _, r := range target.Recipients {
+		pref := prefs[r.UserID]
+		if !reasonEnabled(pref, r.Reason) {
+			continue
+		}
+		if pref.GetInAppEnabled() {
+			inApp = append(inApp, r)
+		}
+		if pref.GetEmailEnabled() {
+			email = append(email, r)
+		}
+	}
+
+	if err = s.deliverInApp(ctx, tenantID, target, inApp); err != nil {
+		return err
+	}
+	s.deliverEmail(ctx, tenantID, target, email)
+
+	return nil
+}
+
+// deliverInApp 每种通知原因写一条站内信，再为每个接收人写收件记录并发布推送事件
+func (s *CommentNotificationService) deliverInApp(ctx context.Context, tenantID uint32, target *data.CommentNotifyTarget, recipients []data.CommentNotifyRecipient) error {
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	senderID := trans.Uint32Value(target.Comment.AuthorID)
+
+	var reasons []commentV1.NotificationReason
+	userIDs := make(map[commentV1.NotificationReason][]uint32, 3)
+	for _, r := range recipients {
+		if _, ok := userIDs[r.Reason]; !ok {
+			reasons = append(reasons, r.Reason)
+		}
+		userIDs[r.Reason] = append(userIDs[r.Reason], r.UserID)
+	}
+
+	for _, reason := range reasons {
+		title, content := s.renderText(target, reason)
+		if err := s.inApp.Deliver(ctx, tenantID, senderID, title, content, userIDs[reason]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// inAppDelivery 站内信投递：写一条 InternalMessage，为每个接收人写收件记录，
+// 并向 Redis 推送频道发布事件。评论通知、表单通知与审阅通知共用。
+type inAppDelivery struct {
+	log *log.Helper
+
+	notificationRepo             *data.CommentNotificationRepo
+	internalMessageRepo          *data.InternalMessageRepo
+	internalMessageRecipientRepo *data.InternalMessageRecipientRepo
+}
+
+func newInAppDelivery(
+	logger *log.Helper,
+	notificationRepo *data.CommentNotificationRepo,
+	internalMessageRepo *data.InternalMessageRepo,
+	internalMessageRecipientRepo *data.InternalMessageRecipientRepo,
+) *inAppDelivery {
+	return &inAppDelivery{
+		log:                          logger,
+		notificationRepo:             notificationRepo,
+		internalMessageRepo:          internalMessageRepo,
+		internalMessageRecipientRepo: internalMessageRecipientRepo,
+	}
+}
+
+// Deliver 写入站内信并逐个投递；消息写入失败返回错误，单个接收人失败只记录日志
+func (d *inAppDelivery) Deliver(ctx context.Context, tenantID, senderID uint32, title, content string, userIDs []uint32) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	msg, err := d.internalMessageRepo.Create(ctx, &internalMessageV1.CreateInternalMessageRequest{
+		Data: &internalMessageV1.InternalMessage{
+			TenantId: trans.Ptr(tenantID),
+			Title:    trans.Ptr(title),
+			Content:  trans.Ptr(content),
+			SenderId: trans.Ptr(senderID),
+			Status:   trans.Ptr(internalMessageV1.InternalMessage_PUBLISHED),
+			Type:     trans.Ptr(internalMessageV1.InternalMessage_NOTIFICATION),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, userID := range userIDs {
+		now := time.Now()
+		recipient, err := d.internalMessageRecipientRepo.Create(ctx, &internalMessageV1.InternalMessageRecipient{
+			TenantId:        trans.Ptr(tenantID),
+			MessageId:       msg.Id,
+			RecipientUserId: trans.Ptr(userID),
+			Status:          trans.Ptr(internalMessageV1.InternalMessageRecipient_SENT),
+			ReceivedAt:      timeutil.TimeToTimestamppb(&now),
+		})
+		if err != nil {
+			d.log.Errorf("in-app notify: create recipient for user [%d] failed: %s", userID, err.Error())
+			continue
+		}
+		recipient.Title = msg.Title
+		recipient.Content = msg.Content
+
+		eventData, _ := json.Marshal(recipient)
+		if err = d.notificationRepo.Push(ctx, &notification.PushEvent{
+			TenantID: tenantID,
+			UserID:   userID,
+			Event:    "notification",
+			Data:     eventData,
+		}); err != nil {
+			d.log.Warnf("in-app notify: push to user [%d] failed: %s", userID, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// deliverEmail 逐个发送邮件，单个失败不影响其他接收人
+func (s *CommentNotificationService) deliverEmail(ctx context.Context, tenantID uint32, target *data.CommentNotifyTarget, recipients []data.CommentNotifyRecipient) {
+	if len(recipients) == 0 || s.signer == nil {
+		return
+	}
+	if _, nop := s.mailer.(notification.NopMailer); nop {
+		return
+	}
+
+	userIDs := make([]uint32, 0, len(recipients))
+	for _, r := range recipients {
+		userIDs = append(userIDs, r.UserID)
+	}
+	contacts, err := s.notificationRepo.ListUserContacts(ctx, tenantID, userIDs)
+	if err != nil {
+		s.log.Errorf("comment notify: query recipient contacts failed: %s", err.Error())
+		return
+	}
+
+	for _, r := range recipients {
+		u := contacts[r.UserID]
+		if u == nil || trans.StringValue(u.Email) == "" {
+			continue
+		}
+
+		title, content := s.renderText(target, r.Reason)
+		token := s.signer.Sign(notification.UnsubscribeClaims{
+			TenantID: tenantID,
+			UserID:   r.UserID,
+			Topic:    r.Reason.String(),
+		})
+
+		msg := &notification.Message{
+			To:      trans.StringValue(u.Email),
+			Subject: title,
+			Text:    content,
+		}
+		if link := s.unsubscribeLink(token); link != "" {
+			msg.Text += "\n\n退订此类通知：" + link
+			msg.Headers = map[string]string{
+				"List-Unsubscribe":      "<" + link + ">",
+				"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+			}
+		}
+
+		if err = s.mailer.Send(ctx, msg); err != nil {
+			s.log.Errorf("comment notify: send email to user [%d] failed: %s", r.UserID, err.Error())
+		}
+	}
+}
+
+// renderText 生成通知标题与正文
+func (s *CommentNotificationService) renderText(target *data.CommentNotifyTarget, reason commentV1.NotificationReason) (string, string) {
+	commenter := trans.StringValue(target.Comment.AuthorName)
+	if commenter == "" {
+		commenter = "有用户"
+	}
+	postTitle := target.PostTitle
+	if postTitle == "" {
+		postTitle = fmt.Sprintf("#%d", target.PostID)
+	}
+
+	var title string
+	switch reason {
+	case commentV1.NotificationReason_NOTIFICATION_REASON_REPLY:
+		title = fmt.Sprintf("%s 回复了你在《%s》下的评论", commenter, postTitle)
+	case commentV1.NotificationReason_NOTIFICATION_REASON_POST_COMMENT:
+		title = fmt.Sprintf("%s 评论了你的文章《%s》", commenter, postTitle)
+	default:
+		title = fmt.Sprintf("你收藏的文章《%s》有新评论", postTitle)
+	}
+
+	content := excerpt(trans.StringValue(target.Comment.Content), commentExcerptLength)
+	if site := strings.TrimRight(s.cfg.GetSiteUrl(), "/"); site != "" {
+		content += fmt.Sprintf("\n\n%s/post/%d", site, target.PostID)
+	}
+
+	return title, content
+}
+
+// unsubscribeLink 拼接退订链接，未配置退订页面时返回空
+func (s *CommentNotificationService) unsubscribeLink(token string) string {
+	base := s.cfg.GetUnsubscribeUrl()
+	if base == "" {
+		return ""
+	}
+	u, err := url.Parse(base)
+	if err != nil {
+		s.log.Warnf("invalid unsubscribe url [%s]: %s", base, err.Error())
+		return ""
+	}
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+func excerpt(s string, n int) string {
+	r := []rune(strings.TrimSpace(s))
+	if len(r) <= n {
+		return string(r)
+	}
+	return string(r[:n]) + "…"
+}
+
+// enqueueCommentNotify 评论变为已通过后入队通知任务，best-effort
+func enqueueCommentNotify(ctx context.Context, taskService *TaskService, commentIDs ...uint32) {
+	if taskService == nil {
+		return
+	}
+
+	var tenantID uint32
+	if vc, exist := viewer.FromContext(ctx); exist && vc != nil {
+		tenantID = uint32(vc.TenantID())
+	}
+
+	// 入队失败已由 TaskService 记录日志，不阻断主业务
+	for _, id := range commentIDs {
+		if id != 0 {
+			_ = taskService.EnqueueCommentNotify(&task.CommentNotifyPayload{CommentID: id, TenantID: tenantID})
+		}
+	}
+}
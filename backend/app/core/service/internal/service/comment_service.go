@@ -17,13 +17,15 @@ type CommentService struct {
 	commentV1.UnimplementedCommentServiceServer
 
 	commentRepo *data.CommentRepo
+	taskService *TaskService
 	log         *log.Helper
 }
 
-func NewCommentService(ctx *bootstrap.Context, uc *data.CommentRepo) *CommentService {
+func NewCommentService(ctx *bootstrap.Context, uc *data.CommentRepo, taskService *TaskService) *CommentService {
 	return &CommentService{
 		log:         ctx.NewLoggerHelper("comment/service/core-service"),
 		commentRepo: uc,
+		taskService: taskService,
 	}
 }
 
@@ -47,11 +49,29 @@ func (s *CommentService) Get(ctx context.Context, req *commentV1.GetCommentReque
 }
 
 func (s *CommentService) Create(ctx context.Context, req *commentV1.CreateCommentRequest) (*commentV1.Comment, error) {
-	return s.commentRepo.Create(ctx, req)
+	dto, err := s.commentRepo.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	s.notifyIfApproved(ctx, dto)
+	return dto, nil
 }
 
 func (s *CommentService) Update(ctx context.Context, req *commentV1.UpdateCommentRequest) (*commentV1.Comment, error) {
-	return s.commentRepo.Update(ctx, req)
+	dto, err := s.commentRepo.Update(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	s.notifyIfApproved(ctx, dto)
+	return dto, nil
+}
+
+// notifyIfApproved 评论处于已通过状态时入队通知任务；同一评论只会通知一次，由 worker 去重
+func (s *CommentService) notifyIfApproved(ctx context.Context, dto *commentV1.Comment) {
+	if dto.GetStatus() != commentV1.Comment_STATUS_APPROVED {
+		return
+	}
+	enqueueCommentNotify(ctx, s.taskService, dto.GetId())
 }
 
 func (s *CommentService) Delete(ctx context.Context, req *commentV1.DeleteCommentRequest) (*emptypb.Empty, error) {
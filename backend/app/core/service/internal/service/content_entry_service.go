@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"go-wind-cms/app/core/service/internal/data"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+)
+
+type ContentEntryService struct {
+	contentV1.UnimplementedContentEntryServiceServer
+
+	contentEntryRepo *data.ContentEntryRepo
+	log              *log.Helper
+}
+
+func NewContentEntryService(ctx *bootstrap.Context, uc *data.ContentEntryRepo) *ContentEntryService {
+	return &ContentEntryService{
+		log:              ctx.NewLoggerHelper("content-entry/service/core-service"),
+		contentEntryRepo: uc,
+	}
+}
+
+func (s *ContentEntryService) List(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListContentEntryResponse, error) {
+	return s.contentEntryRepo.List(ctx, req)
+}
+
+func (s *ContentEntryService) Get(ctx context.Context, req *contentV1.GetContentEntryRequest) (*contentV1.ContentEntry, error) {
+	return s.contentEntryRepo.Get(ctx, req)
+}
+
+func (s *ContentEntryService) Create(ctx context.Context, req *contentV1.CreateContentEntryRequest) (*contentV1.ContentEntry, error) {
+	return s.contentEntryRepo.Create(ctx, req)
+}
+
+func (s *ContentEntryService) Update(ctx context.Context, req *contentV1.UpdateContentEntryRequest) (*contentV1.ContentEntry, error) {
+	return s.contentEntryRepo.Update(ctx, req)
+}
+
+func (s *ContentEntryService) Delete(ctx context.Context, req *contentV1.DeleteContentEntryRequest) (*emptypb.Empty, error) {
+	err := s.contentEntryRepo.Delete(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ContentEntryService) Search(ctx context.Context, req *contentV1.SearchContentEntriesRequest) (*contentV1.SearchContentEntriesResponse, error) {
+	return s.contentEntryRepo.Search(ctx, req)
+}
@@ -0,0 +1,352 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/tx7do/go-crud/viewer"
+	"github.com/tx7do/go-utils/trans"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/workflow"
+)
+
+// EditorialService 文章的编辑审阅流程：阶段流转、审阅人指派、审阅意见与审阅队列。
+//
+// 流转按调用者角色的权限代码授权，平台/系统上下文不受限；
+// 文章进入审阅阶段、或审阅阶段中新增审阅人时，以站内信通知审阅人。
+type EditorialService struct {
+	contentV1.UnimplementedEditorialServiceServer
+
+	editorialRepo *data.EditorialRepo
+	workflowRepo  *data.WorkflowRepo
+
+	userRepo       data.UserRepo
+	roleRepo       *data.RoleRepo
+	permissionRepo *data.PermissionRepo
+
+	inApp *inAppDelivery
+
+	syndicationRepo *data.SyndicationRepo
+
+	log *log.Helper
+}
+
+func NewEditorialService(
+	ctx *bootstrap.Context,
+	editorialRepo *data.EditorialRepo,
+	workflowRepo *data.WorkflowRepo,
+	userRepo data.UserRepo,
+	roleRepo *data.RoleRepo,
+	permissionRepo *data.PermissionRepo,
+	notificationRepo *data.CommentNotificationRepo,
+	internalMessageRepo *data.InternalMessageRepo,
+	internalMessageRecipientRepo *data.InternalMessageRecipientRepo,
+	syndicationRepo *data.SyndicationRepo,
+) *EditorialService {
+	l := ctx.NewLoggerHelper("editorial/service/core-service")
+	return &EditorialService{
+		log:             l,
+		editorialRepo:   editorialRepo,
+		workflowRepo:    workflowRepo,
+		userRepo:        userRepo,
+		roleRepo:        roleRepo,
+		permissionRepo:  permissionRepo,
+		inApp:           newInAppDelivery(l, notificationRepo, internalMessageRepo, internalMessageRecipientRepo),
+		syndicationRepo: syndicationRepo,
+	}
+}
+
+// editorialOperator 调用者身份与其持有的权限代码
+type editorialOperator struct {
+	UserID uint32
+	Codes  []string
+	// All 平台/系统上下文，不受权限代码约束
+	All bool
+}
+
+func (o *editorialOperator) allowed(t *workflow.Transition) bool {
+	return o.All || workflow.Allowed(t, o.Codes)
+}
+
+func (o *editorialOperator) has(code string) bool {
+	return o.All || slices.Contains(o.Codes, code)
+}
+
+// resolveOperator 解析调用者：用户 → 角色 → 权限 → 权限代码
+func (s *EditorialService) resolveOperator(ctx context.Context) (*editorialOperator, error) {
+	vc, exist := viewer.FromContext(ctx)
+	if !exist || vc == nil {
+		return nil, contentV1.ErrorUnauthorized("operator identity required")
+	}
+
+	op := &editorialOperator{UserID: uint32(vc.UserID())}
+	if vc.IsPlatformContext() || vc.IsSystemContext() {
+		op.All = true
+		return op, nil
+	}
+	if op.UserID == 0 {
+		return nil, contentV1.ErrorUnauthorized("operator identity required")
+	}
+
+	roleIDs, err := s.userRepo.ListRoleIDsByUserID(ctx, op.UserID)
+	if err != nil {
+		s.log.Errorf("list role ids of user [%d] failed: %s", op.UserID, err.Error())
+		return nil, contentV1.ErrorInternalServerError("resolve operator permissions failed")
+	}
+	if len(roleIDs) == 0 {
+		return op, nil
+	}
+
+	permissionIDs, err := s.roleRepo.ListPermissionIDsByRoleIDs(ctx, roleIDs)
+	if err != nil {
+		s.log.Errorf("list permission ids by role ids failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("resolve operator permissions failed")
+	}
+	if len(permissionIDs) == 0 {
+		return op, nil
+	}
+
+	op.Codes, err = s.permissionRepo.ListPermissionCodesByIds(ctx, permissionIDs)
+	if err != nil {
+		s.log.Errorf("list permission codes by ids failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("resolve operator permissions failed")
+	}
+
+	return op, nil
+}
+
+// GetPostWorkflow 查询文章的工作流状态及调用者可执行的流转
+func (s *EditorialService) GetPostWorkflow(ctx context.Context, req *contentV1.GetPostWorkflowRequest) (*contentV1.PostWorkflowState, error) {
+	op, err := s.resolveOperator(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pw, err := s.editorialRepo.Load(ctx, req.GetPostId())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.editorialRepo.State(pw, s.available(pw, op)), nil
+}
+
+// TransitionPost 将文章流转到目标阶段
+func (s *EditorialService) TransitionPost(ctx context.Context, req *contentV1.TransitionPostRequest) (*contentV1.PostWorkflowState, error) {
+	op, err := s.resolveOperator(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pw, err := s.editorialRepo.Load(ctx, req.GetPostId())
+	if err != nil {
+		return nil, err
+	}
+
+	t, ok := pw.Definition.Find(pw.Stage.Code, req.GetToStage())
+	if !ok {
+		return nil, contentV1.ErrorBadRequest("no transition from %s to %s", pw.Stage.Code, req.GetToStage())
+	}
+	if !op.allowed(t) {
+		return nil, contentV1.ErrorForbidden("transition %s -> %s requires permission %s", t.From, t.To, t.PermissionCode)
+	}
+
+	comment := strings.TrimSpace(req.GetComment())
+	if t.RequireComment && comment == "" {
+		return nil, contentV1.ErrorBadRequest("transition %s -> %s requires a comment", t.From, t.To)
+	}
+
+	to, _ := pw.Definition.Stage(t.To)
+	if err = s.editorialRepo.Transition(ctx, pw, to, comment, op.UserID); err != nil {
+		return nil, err
+	}
+
+	// 进入或离开发布阶段会改变文章的发布状态
+	if to.Publish || pw.Stage.Publish {
+		s.syndicationRepo.Invalidate(ctx)
+	}
+
+	if to.Review {
+		s.notifyReviewers(ctx, pw, pw.Post.ReviewerIds, op.UserID)
+	}
+
+	return s.editorialRepo.State(pw, s.available(pw, op)), nil
+}
+
+// AssignReviewers 指派文章的审阅人（整体替换），需持有审阅权限
+func (s *EditorialService) AssignReviewers(ctx context.Context, req *contentV1.AssignReviewersRequest) (*contentV1.PostWorkflowState, error) {
+	op, err := s.resolveOperator(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !op.has(workflow.PermissionReview) {
+		return nil, contentV1.ErrorForbidden("assigning reviewers requires permission %s", workflow.PermissionReview)
+	}
+
+	pw, err := s.editorialRepo.Load(ctx, req.GetPostId())
+	if err != nil {
+		return nil, err
+	}
+
+	reviewerIDs := uniqueIDs(req.GetReviewerIds())
+	if err = s.checkReviewers(ctx, pw, reviewerIDs); err != nil {
+		return nil, err
+	}
+
+	added := newIDs(pw.Post.ReviewerIds, reviewerIDs)
+	if err = s.editorialRepo.AssignReviewers(ctx, pw, reviewerIDs, op.UserID); err != nil {
+		return nil, err
+	}
+
+	if pw.Stage.Review {
+		s.notifyReviewers(ctx, pw, added, op.UserID)
+	}
+
+	return s.editorialRepo.State(pw, s.available(pw, op)), nil
+}
+
+// AddReviewComment 添加审阅意见
+func (s *EditorialService) AddReviewComment(ctx context.Context, req *contentV1.AddReviewCommentRequest) (*contentV1.PostReviewLog, error) {
+	op, err := s.resolveOperator(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := strings.TrimSpace(req.GetComment())
+	if comment == "" {
+		return nil, contentV1.ErrorBadRequest("comment is required")
+	}
+
+	pw, err := s.editorialRepo.Load(ctx, req.GetPostId())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.editorialRepo.AddComment(ctx, pw, comment, op.UserID)
+}
+
+// ListReviewLogs 查询文章的审阅日志
+func (s *EditorialService) ListReviewLogs(ctx context.Context, req *contentV1.ListReviewLogsRequest) (*contentV1.ListPostReviewLogResponse, error) {
+	if _, err := s.resolveOperator(ctx); err != nil {
+		return nil, err
+	}
+
+	// 先加载文章，确保其属于调用者租户
+	if _, err := s.editorialRepo.Load(ctx, req.GetPostId()); err != nil {
+		return nil, err
+	}
+
+	return s.editorialRepo.ListLogs(ctx, req.GetPostId())
+}
+
+// ListReviewQueue 查询调用者的审阅队列：处于审阅阶段且指派给调用者的文章
+func (s *EditorialService) ListReviewQueue(ctx context.Context, req *contentV1.ListReviewQueueRequest) (*contentV1.ListReviewQueueResponse, error) {
+	vc, exist := viewer.FromContext(ctx)
+	if !exist || vc == nil || vc.UserID() == 0 {
+		return nil, contentV1.ErrorUnauthorized("login required")
+	}
+	tenantID := uint32(vc.TenantID())
+
+	workflows, err := s.workflowRepo.EnabledWorkflows(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.editorialRepo.ReviewQueue(ctx, tenantID, uint32(vc.UserID()), workflows, int(req.GetPage()), int(req.GetPageSize()))
+}
+
+func (s *EditorialService) available(pw *data.PostWorkflow, op *editorialOperator) []workflow.Transition {
+	if !op.All {
+		return pw.Definition.Available(pw.Stage.Code, op.Codes)
+	}
+
+	var out []workflow.Transition
+	for _, t := range pw.Definition.Transitions {
+		if t.From == pw.Stage.Code {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// checkReviewers 审阅人须为文章所属租户的已有用户
+func (s *EditorialService) checkReviewers(ctx context.Context, pw *data.PostWorkflow, reviewerIDs []uint32) error {
+	if len(reviewerIDs) == 0 {
+		return nil
+	}
+
+	users, err := s.userRepo.ListUsersByIds(ctx, reviewerIDs)
+	if err != nil {
+		return err
+	}
+
+	tenantID := trans.Uint32Value(pw.Post.TenantID)
+	found := make(map[uint32]bool, len(users))
+	for _, u := range users {
+		if u.GetTenantId() == tenantID {
+			found[u.GetId()] = true
+		}
+	}
+	for _, id := range reviewerIDs {
+		if !found[id] {
+			return contentV1.ErrorBadRequest("reviewer %d not found", id)
+		}
+	}
+
+	return nil
+}
+
+// notifyReviewers 为审阅人写一条站内信，通知失败只记录日志，不影响流转结果
+func (s *EditorialService) notifyReviewers(ctx context.Context, pw *data.PostWorkflow, reviewerIDs []uint32, senderID uint32) {
+	if len(reviewerIDs) == 0 {
+		return
+	}
+
+	tenantID := trans.Uint32Value(pw.Post.TenantID)
+	stageName := pw.Stage.Name
+	if stageName == "" {
+		stageName = pw.Stage.Code
+	}
+
+	userIDs := make([]uint32, 0, len(reviewerIDs))
+	for _, uid := range reviewerIDs {
+		if uid != senderID {
+			userIDs = append(userIDs, uid)
+		}
+	}
+
+	title := fmt.Sprintf("文章 #%d 待审阅", pw.Post.ID)
+	content := fmt.Sprintf("文章 #%d 已进入「%s」阶段，请前往审阅队列处理。", pw.Post.ID, stageName)
+	if err := s.inApp.Deliver(ctx, tenantID, senderID, title, content, userIDs); err != nil {
+		s.log.Errorf("editorial notify: create message for post [%d] failed: %s", pw.Post.ID, err.Error())
+	}
+}
+
+// uniqueIDs 去除 0 与重复的 ID，保持原有顺序
+func uniqueIDs(ids []uint32) []uint32 {
+	out := make([]uint32, 0, len(ids))
+	for _, id := range ids {
+		if id != 0 && !slices.Contains(out, id) {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// newIDs 返回 next 中不在 prev 里的 ID
+func newIDs(prev, next []uint32) []uint32 {
+	var out []uint32
+	for _, id := range next {
+		if !slices.Contains(prev, id) {
+			out = append(out, id)
+		}
+	}
+	return out
+}
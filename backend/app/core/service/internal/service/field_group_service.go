@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"go-wind-cms/app/core/service/internal/data"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+)
+
+type FieldGroupService struct {
+	contentV1.UnimplementedFieldGroupServiceServer
+
+	fieldGroupRepo *data.FieldGroupRepo
+	log            *log.Helper
+}
+
+func NewFieldGroupService(ctx *bootstrap.Context, uc *data.FieldGroupRepo) *FieldGroupService {
+	return &FieldGroupService{
+		log:            ctx.NewLoggerHelper("field-group/service/core-service"),
+		fieldGroupRepo: uc,
+	}
+}
+
+func (s *FieldGroupService) List(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListFieldGroupResponse, error) {
+	return s.fieldGroupRepo.List(ctx, req)
+}
+
+func (s *FieldGroupService) Get(ctx context.Context, req *contentV1.GetFieldGroupRequest) (*contentV1.FieldGroup, error) {
+	return s.fieldGroupRepo.Get(ctx, req)
+}
+
+func (s *FieldGroupService) Create(ctx context.Context, req *contentV1.CreateFieldGroupRequest) (*contentV1.FieldGroup, error) {
+	return s.fieldGroupRepo.Create(ctx, req)
+}
+
+func (s *FieldGroupService) Update(ctx context.Context, req *contentV1.UpdateFieldGroupRequest) (*contentV1.FieldGroup, error) {
+	return s.fieldGroupRepo.Update(ctx, req)
+}
+
+func (s *FieldGroupService) Delete(ctx context.Context, req *contentV1.DeleteFieldGroupRequest) (*emptypb.Empty, error) {
+	err := s.fieldGroupRepo.Delete(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -21,18 +21,18 @@ type FileService struct {
 	log *log.Helper
 
 	fileRepo *data.FileRepo
-	mc       *oss.MinIOClient
+	storage  *oss.StorageRouter
 }
 
 func NewFileService(
 	ctx *bootstrap.Context,
 	fileRepo *data.FileRepo,
-	mc *oss.MinIOClient,
+	storage *oss.StorageRouter,
 ) *FileService {
 	return &FileService{
 		log:      ctx.NewLoggerHelper("file/service/core-service"),
 		fileRepo: fileRepo,
-		mc:       mc,
+		storage:  storage,
 	}
 }
 
@@ -83,11 +83,17 @@ func (s *FileService) Delete(ctx context.Context, req *storageV1.DeleteFileReque
 		return nil, err
 	}
 
+	// 按文件记录的供应商还原驱动，避免租户切换驱动后删错存储
+	driver, err := s.storage.ForProvider(f.GetProvider())
+	if err != nil {
+		return nil, err
+	}
+
 	if err = s.fileRepo.Delete(ctx, req); err != nil {
 		return nil, err
 	}
 
-	if err = s.mc.DeleteFile(ctx,
+	if err = driver.DeleteFile(ctx,
 		f.GetBucketName(),
 		f.GetFileDirectory()+"/"+f.GetSaveFileName(),
 	); err != nil {
@@ -9,7 +9,6 @@ import (
 
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/google/uuid"
-	"github.com/minio/minio-go/v7"
 	"github.com/tx7do/go-utils/trans"
 	"github.com/tx7do/kratos-bootstrap/bootstrap"
 
@@ -33,22 +32,30 @@ type FileTransferService struct {
 
 	log *log.Helper
 
-	mc       *oss.MinIOClient
+	storage  *oss.StorageRouter
 	fileRepo *data.FileRepo
 }
 
 func NewFileTransferService(
 	ctx *bootstrap.Context,
-	mc *oss.MinIOClient,
+	storage *oss.StorageRouter,
 	fileRepo *data.FileRepo,
 ) *FileTransferService {
 	return &FileTransferService{
 		log:      ctx.NewLoggerHelper("file-transfer/service/core-service"),
-		mc:       mc,
+		storage:  storage,
 		fileRepo: fileRepo,
 	}
 }
 
+// storageForViewer 按当前 viewer 的租户选择存储驱动
+func (s *FileTransferService) storageForViewer(ctx context.Context) oss.Storage {
+	if vc, exist := viewer.FromContext(ctx); exist && vc != nil {
+		return s.storage.ForTenant(uint32(vc.TenantID()))
+	}
+	return s.storage.Default()
+}
+
 func parseKey(key string) (folder, filename, ext string) {
 	if key == "" {
 		return "", "", ""
@@ -94,7 +101,8 @@ func (s *FileTransferService) recordFile(
 	ctx context.Context,
 	tenantID, userID uint32,
 	sourceFileName string,
-	info minio.UploadInfo,
+	provider storageV1.OSSProvider,
+	info oss.ObjectInfo,
 	downloadUrl string,
 ) error {
 
@@ -102,7 +110,7 @@ func (s *FileTransferService) recordFile(
 
 	if _, err := s.fileRepo.Create(ctx, &storageV1.CreateFileRequest{
 		Data: &storageV1.File{
-			Provider:      trans.Ptr(storageV1.OSSProvider_MINIO),
+			Provider:      trans.Ptr(provider),
 			BucketName:    trans.Ptr(info.Bucket),
 			SaveFileName:  trans.Ptr(fileName + "." + ext),
 			FileDirectory: trans.Ptr(dir),
@@ -157,7 +165,9 @@ func (s *FileTransferService) directUploadFile(ctx context.Context, req *storage
 		)
 	}
 
-	info, _, downloadUrl, err := s.mc.UploadFile(
+	driver := s.storageForViewer(ctx)
+
+	info, _, downloadUrl, err := driver.UploadFile(
 		ctx,
 		req.GetStorageObject().GetBucketName(),
 		req.GetStorageObject().GetObjectName(),
@@ -185,6 +195,7 @@ func (s *FileTransferService) directUploadFile(ctx context.Context, req *storage
 		ctx,
 		tid, uid,
 		req.GetSourceFileName(),
+		driver.Provider(),
 		info, downloadUrl); err != nil {
 		return nil, err
 	}
@@ -271,6 +282,11 @@ func (s *FileTransferService) DownloadFile(ctx context.Context, req *storageV1.D
 			return nil, storageV1.ErrorDownloadFailed("file not found")
 		}
 
+		driver, err := s.storage.ForProvider(resp.GetProvider())
+		if err != nil {
+			return nil, err
+		}
+
 		req.Selector = &storageV1.DownloadFileRequest_StorageObject{
 			StorageObject: &storageV1.StorageObject{
 				BucketName: resp.BucketName,
@@ -278,10 +294,10 @@ func (s *FileTransferService) DownloadFile(ctx context.Context, req *storageV1.D
 			},
 		}
 
-		return s.mc.DownloadFile(ctx, req)
+		return driver.DownloadFile(ctx, req)
 
 	case *storageV1.DownloadFileRequest_StorageObject:
-		return s.mc.DownloadFile(ctx, req)
+		return s.storageForViewer(ctx).DownloadFile(ctx, req)
 
 	case *storageV1.DownloadFileRequest_DownloadUrl:
 		return s.downloadFileFromURL(ctx, req.GetDownloadUrl())
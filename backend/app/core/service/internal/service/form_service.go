@@ -0,0 +1,294 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/go-utils/timeutil"
+	"github.com/tx7do/go-utils/trans"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"go-wind-cms/app/core/service/internal/data"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/form"
+	appViewer "go-wind-cms/pkg/entgo/viewer"
+	"go-wind-cms/pkg/notification"
+	"go-wind-cms/pkg/task"
+)
+
+const (
+	// FormSubmittedEvent 表单提交 Webhook 的事件名
+	FormSubmittedEvent = "form.submitted"
+
+	// formFieldExcerptLength 站内信正文中单个字段值的最大字符数
+	formFieldExcerptLength = 200
+)
+
+// FormService 表单：表单定义管理、访客提交、提交记录的列表与导出，以及 form.notify 任务的投递。
+type FormService struct {
+	contentV1.UnimplementedFormServiceServer
+
+	log *log.Helper
+
+	formRepo           *data.FormRepo
+	formSubmissionRepo *data.FormSubmissionRepo
+
+	taskService *TaskService
+	webhook     *notification.WebhookSender
+
+	inApp *inAppDelivery
+}
+
+func NewFormService(
+	ctx *bootstrap.Context,
+	formRepo *data.FormRepo,
+	formSubmissionRepo *data.FormSubmissionRepo,
+	taskService *TaskService,
+	notificationRepo *data.CommentNotificationRepo,
+	internalMessageRepo *data.InternalMessageRepo,
+	internalMessageRecipientRepo *data.InternalMessageRecipientRepo,
+) *FormService {
+	l := ctx.NewLoggerHelper("form/service/core-service")
+	return &FormService{
+		log:                l,
+		formRepo:           formRepo,
+		formSubmissionRepo: formSubmissionRepo,
+		taskService:        taskService,
+		webhook:            notification.NewWebhookSender(0),
+		inApp:              newInAppDelivery(l, notificationRepo, internalMessageRepo, internalMessageRecipientRepo),
+	}
+}
+
+func (s *FormService) List(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListFormResponse, error) {
+	return s.formRepo.List(ctx, req)
+}
+
+func (s *FormService) Get(ctx context.Context, req *contentV1.GetFormRequest) (*contentV1.Form, error) {
+	return s.formRepo.Get(ctx, req)
+}
+
+func (s *FormService) Create(ctx context.Context, req *contentV1.CreateFormRequest) (*contentV1.Form, error) {
+	return s.formRepo.Create(ctx, req)
+}
+
+func (s *FormService) Update(ctx context.Context, req *contentV1.UpdateFormRequest) (*contentV1.Form, error) {
+	return s.formRepo.Update(ctx, req)
+}
+
+func (s *FormService) Delete(ctx context.Context, req *contentV1.DeleteFormRequest) (*emptypb.Empty, error) {
+	if err := s.formRepo.Delete(ctx, req); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// SubmitForm 保存一次提交并入队通知任务。
+//
+// 验证码由 app 服务在转发前校验；蜜罐字段被填写时按成功返回但不保存，避免机器人据此调整策略。
+func (s *FormService) SubmitForm(ctx context.Context, req *contentV1.SubmitFormRequest) (*contentV1.SubmitFormResponse, error) {
+	if req == nil || req.GetFormId() == 0 {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	f, err := s.formRepo.GetEntity(ctx, req.GetFormId())
+	if err != nil {
+		return nil, err
+	}
+	if f.Enabled != nil && !*f.Enabled {
+		return nil, contentV1.ErrorForbidden("form is not accepting submissions")
+	}
+
+	resp := &contentV1.SubmitFormResponse{Message: f.SuccessMessage}
+
+	if trans.StringValue(f.HoneypotField) != "" && strings.TrimSpace(req.GetHoneypot()) != "" {
+		s.log.Infof("form [%d] submission dropped by honeypot (ip=%s)", f.ID, req.GetClientIp())
+		return resp, nil
+	}
+
+	submission, err := s.formSubmissionRepo.Create(ctx, f, req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Id = submission.Id
+
+	if len(f.RecipientUserIds) > 0 || len(f.WebhookUrls) > 0 {
+		// 入队失败已由 TaskService 记录日志，不影响提交结果
+		_ = s.taskService.EnqueueFormNotify(&task.FormNotifyPayload{
+			SubmissionID: submission.GetId(),
+			TenantID:     trans.Uint32Value(f.TenantID),
+		})
+	}
+
+	return resp, nil
+}
+
+func (s *FormService) ListSubmissions(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListFormSubmissionResponse, error) {
+	return s.formSubmissionRepo.List(ctx, req)
+}
+
+// ExportSubmissions 按提交时间升序导出表单的提交记录，单次最多 form.MaxExportRows 条
+func (s *FormService) ExportSubmissions(ctx context.Context, req *contentV1.ExportFormSubmissionsRequest) (*contentV1.ExportFormSubmissionsResponse, error) {
+	if req == nil || req.GetFormId() == 0 {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	f, err := s.formRepo.GetEntity(ctx, req.GetFormId())
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.formSubmissionRepo.ListForExport(ctx, f.ID,
+		timeutil.TimestamppbToTime(req.StartTime), timeutil.TimestamppbToTime(req.EndTime))
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	resp := &contentV1.ExportFormSubmissionsResponse{Count: uint32(len(rows))}
+	baseName := fmt.Sprintf("form-%d-%s", f.ID, time.Now().Format("20060102150405"))
+
+	switch req.GetFormat() {
+	case contentV1.FormExportFormat_FORM_EXPORT_FORMAT_JSON:
+		err = form.WriteJSON(&buf, f.Fields, rows)
+		resp.FileName = baseName + ".json"
+		resp.Mime = "application/json"
+	default:
+		err = form.WriteCSV(&buf, f.Fields, rows)
+		resp.FileName = baseName + ".csv"
+		resp.Mime = "text/csv; charset=utf-8"
+	}
+	if err != nil {
+		s.log.Errorf("export form [%d] submissions failed: %s", f.ID, err.Error())
+		return nil, contentV1.ErrorInternalServerError("export form submissions failed")
+	}
+
+	resp.File = buf.Bytes()
+	return resp, nil
+}
+
+// DispatchFormNotify 处理 form.notify 任务：向表单的接收人写入站内信并回调 Webhook。
+// 签名遵循 (taskType string, payload *T) error 模式（参考 TaskService.AsyncBackup）。
+func (s *FormService) DispatchFormNotify(_ string, payload *task.FormNotifyPayload) error {
+	if payload == nil || payload.SubmissionID == 0 {
+		s.log.Warnf("form notify: invalid payload")
+		return nil
+	}
+
+	// 注入 SystemViewer：worker 需跨租户读取表单与提交
+	ctx := appViewer.NewSystemViewerContext(context.Background())
+
+	target, err := s.formSubmissionRepo.ResolveNotify(ctx, payload.SubmissionID)
+	if err != nil {
+		return err
+	}
+	if target == nil {
+		return nil
+	}
+
+	first, err := s.formSubmissionRepo.ClaimNotify(ctx, payload.SubmissionID)
+	if err != nil {
+		return err
+	}
+	if !first {
+		return nil
+	}
+
+	if err = s.deliverInApp(ctx, target); err != nil {
+		// 站内信写入失败，清除标记让 asynq 重试
+		s.formSubmissionRepo.UnclaimNotify(ctx, payload.SubmissionID)
+		return err
+	}
+	s.deliverWebhooks(ctx, target)
+
+	return nil
+}
+
+// deliverInApp 写一条站内信，再为每个接收人写收件记录并发布推送事件
+func (s *FormService) deliverInApp(ctx context.Context, target *data.FormNotifyTarget) error {
+	f, submission := target.Form, target.Submission
+	if len(f.RecipientUserIds) == 0 {
+		return nil
+	}
+
+	tenantID := trans.Uint32Value(f.TenantID)
+	title, content := renderFormSubmission(target)
+
+	return s.inApp.Deliver(ctx, tenantID, trans.Uint32Value(submission.UserID), title, content, f.RecipientUserIds)
+}
+
+// formWebhookBody Webhook 回调的请求体
+type formWebhookBody struct {
+	Event        string         `json:"event"`
+	FormID       uint32         `json:"form_id"`
+	FormName     string         `json:"form_name,omitempty"`
+	SubmissionID uint32         `json:"submission_id"`
+	SubmittedAt  time.Time      `json:"submitted_at"`
+	PageID       uint32         `json:"page_id,omitempty"`
+	Values       map[string]any `json:"values"`
+}
+
+// deliverWebhooks 逐个回调 Webhook；失败仅记日志，不重试，避免重复写入站内信
+func (s *FormService) deliverWebhooks(ctx context.Context, target *data.FormNotifyTarget) {
+	f, submission := target.Form, target.Submission
+	if len(f.WebhookUrls) == 0 {
+		return
+	}
+
+	body, err := json.Marshal(&formWebhookBody{
+		Event:        FormSubmittedEvent,
+		FormID:       f.ID,
+		FormName:     trans.StringValue(f.Name),
+		SubmissionID: submission.ID,
+		SubmittedAt:  trans.TimeValue(submission.CreatedAt).UTC(),
+		PageID:       trans.Uint32Value(submission.PageID),
+		Values:       form.PlainValues(f.Fields, submission.Data),
+	})
+	if err != nil {
+		s.log.Errorf("form notify: marshal webhook body failed: %s", err.Error())
+		return
+	}
+
+	secret := trans.StringValue(f.WebhookSecret)
+	for _, endpoint := range f.WebhookUrls {
+		if err = s.webhook.Send(ctx, endpoint, FormSubmittedEvent, secret, body); err != nil {
+			s.log.Warnf("form notify: webhook [%s] for submission [%d] failed: %s", endpoint, submission.ID, err.Error())
+		}
+	}
+}
+
+// renderFormSubmission 生成站内信标题与正文，正文按表单字段顺序逐行列出已填写的值
+func renderFormSubmission(target *data.FormNotifyTarget) (string, string) {
+	f, submission := target.Form, target.Submission
+	name := trans.StringValue(f.Name)
+	if name == "" {
+		name = fmt.Sprintf("#%d", f.ID)
+	}
+	title := fmt.Sprintf("表单《%s》收到新的提交", name)
+
+	var sb strings.Builder
+	for _, field := range f.Fields {
+		v := submission.Data[field.Key]
+		if v.Type() == "" {
+			continue
+		}
+		label := field.Label
+		if label == "" {
+			label = field.Key
+		}
+		sb.WriteString(label)
+		sb.WriteString("：")
+		sb.WriteString(excerpt(form.CellText(v), formFieldExcerptLength))
+		sb.WriteString("\n")
+	}
+
+	return title, strings.TrimRight(sb.String(), "\n")
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/log"
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/go-utils/trans"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"go-wind-cms/app/core/service/internal/data"
+	"go-wind-cms/app/core/service/internal/data/ent/glossaryterm"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/locale"
+	"go-wind-cms/pkg/content/tmx"
+)
+
+const (
+	// glossaryTypeProp TMX 翻译单元上标记术语类型的属性
+	glossaryTypeProp = "x-glossary-type"
+	// glossaryCaseSensitiveProp TMX 翻译单元上标记区分大小写的属性
+	glossaryCaseSensitiveProp = "x-case-sensitive"
+
+	glossaryTypeDoNotTranslate = "do-not-translate"
+	glossaryTypeForced         = "forced"
+
+	// maxGlossaryImportTermLength 导入术语与译法的最大长度，与术语表字段一致
+	maxGlossaryImportTermLength = 255
+)
+
+// GlossaryService 租户术语表：后台维护与 TMX 导入导出
+type GlossaryService struct {
+	contentV1.UnimplementedGlossaryServiceServer
+
+	log *log.Helper
+
+	glossaryTermRepo *data.GlossaryTermRepo
+}
+
+func NewGlossaryService(ctx *bootstrap.Context, glossaryTermRepo *data.GlossaryTermRepo) *GlossaryService {
+	return &GlossaryService{
+		log:              ctx.NewLoggerHelper("glossary/service/core-service"),
+		glossaryTermRepo: glossaryTermRepo,
+	}
+}
+
+func (s *GlossaryService) List(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListGlossaryTermResponse, error) {
+	return s.glossaryTermRepo.List(ctx, req)
+}
+
+func (s *GlossaryService) Get(ctx context.Context, req *contentV1.GetGlossaryTermRequest) (*contentV1.GlossaryTerm, error) {
+	return s.glossaryTermRepo.Get(ctx, req)
+}
+
+func (s *GlossaryService) Create(ctx context.Context, req *contentV1.CreateGlossaryTermRequest) (*contentV1.GlossaryTerm, error) {
+	return s.glossaryTermRepo.Create(ctx, req)
+}
+
+func (s *GlossaryService) Update(ctx context.Context, req *contentV1.UpdateGlossaryTermRequest) (*contentV1.GlossaryTerm, error) {
+	return s.glossaryTermRepo.Update(ctx, req)
+}
+
+func (s *GlossaryService) Delete(ctx context.Context, req *contentV1.DeleteGlossaryTermRequest) (*emptypb.Empty, error) {
+	if err := s.glossaryTermRepo.Delete(ctx, req); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ImportTmx 导入术语。
+//
+// 每个翻译单元的源语言句段为术语，各目标语言句段为强制译法；x-glossary-type 为 do-not-translate
+// 或译文与原文相同时导入为不翻译术语。不翻译术语的翻译单元只有源语言句段时，对所有目标语言生效。
+func (s *GlossaryService) ImportTmx(ctx context.Context, req *contentV1.ImportTmxRequest) (*contentV1.ImportTmxResponse, error) {
+	doc, sourceLanguage, err := parseTmxImport(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &contentV1.ImportTmxResponse{}
+	var items []data.GlossaryTermImport
+	for i, u := range doc.Units {
+		term, ok := u.Text(sourceLanguage)
+		term = strings.TrimSpace(term)
+		if !ok || term == "" || len(term) > maxGlossaryImportTermLength {
+			resp.Skipped++
+			addTmxImportError(resp, "unit %d: missing or invalid %s term", i+1, sourceLanguage)
+			continue
+		}
+		keep := strings.EqualFold(u.Props[glossaryTypeProp], glossaryTypeDoNotTranslate)
+		caseSensitive := strings.EqualFold(u.Props[glossaryCaseSensitiveProp], "true")
+
+		matched := false
+		for _, v := range u.Variants {
+			if !tmxTargetVariant(v, sourceLanguage, req.GetTargetLanguage()) {
+				continue
+			}
+			translation := strings.TrimSpace(v.Text)
+			if !locale.ValidCode(v.Lang) || len(translation) > maxGlossaryImportTermLength {
+				resp.Skipped++
+				addTmxImportError(resp, "unit %d: invalid %s segment", i+1, v.Lang)
+				continue
+			}
+			matched = true
+			items = append(items, data.GlossaryTermImport{
+				SourceLanguage: sourceLanguage,
+				TargetLanguage: v.Lang,
+				Term:           term,
+				Translation:    translation,
+				DoNotTranslate: keep || translation == "" || translation == term,
+				CaseSensitive:  caseSensitive,
+			})
+		}
+
+		if !matched {
+			if keep && req.GetTargetLanguage() == "" {
+				items = append(items, data.GlossaryTermImport{
+					SourceLanguage: sourceLanguage,
+					Term:           term,
+					DoNotTranslate: true,
+					CaseSensitive:  caseSensitive,
+				})
+				continue
+			}
+			resp.Skipped++
+			addTmxImportError(resp, "unit %d: no target segment", i+1)
+		}
+	}
+
+	created, updated, skipped, err := s.glossaryTermRepo.Import(ctx, items, req.GetOverwrite())
+	if err != nil {
+		return nil, err
+	}
+	resp.Created = created
+	resp.Updated = updated
+	resp.Skipped += skipped
+
+	return resp, nil
+}
+
+// ExportTmx 导出术语，x-glossary-type 属性记录术语类型；对所有目标语言生效的不翻译术语只输出源语言句段
+func (s *GlossaryService) ExportTmx(ctx context.Context, req *contentV1.ExportTmxRequest) (*contentV1.ExportTmxResponse, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	rows, err := s.glossaryTermRepo.ListForExport(ctx, req.GetSourceLanguage(), req.GetTargetLanguage())
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &tmx.Document{SourceLanguage: req.GetSourceLanguage(), Units: make([]tmx.Unit, 0, len(rows))}
+	for _, row := range rows {
+		term := trans.StringValue(row.Term)
+		keep := row.Type == nil || *row.Type != glossaryterm.TypeTypeForced
+
+		unit := tmx.Unit{
+			Props:    map[string]string{glossaryTypeProp: glossaryTypeForced},
+			Variants: []tmx.Variant{{Lang: trans.StringValue(row.SourceLanguage), Text: term}},
+		}
+		if keep {
+			unit.Props[glossaryTypeProp] = glossaryTypeDoNotTranslate
+		}
+		if trans.BoolValue(row.CaseSensitive) {
+			unit.Props[glossaryCaseSensitiveProp] = "true"
+		}
+
+		if target := trans.StringValue(row.TargetLanguage); target != "" {
+			translation := trans.StringValue(row.Translation)
+			if keep {
+				translation = term
+			}
+			unit.Variants = append(unit.Variants, tmx.Variant{Lang: target, Text: translation})
+		}
+
+		doc.Units = append(doc.Units, unit)
+	}
+
+	return writeTmxExport(doc, "glossary")
+}
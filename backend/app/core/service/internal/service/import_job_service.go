@@ -0,0 +1,1071 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/go-utils/trans"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data"
+	"go-wind-cms/app/core/service/internal/data/ent"
+	"go-wind-cms/app/core/service/internal/data/ent/importjob"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+	storageV1 "go-wind-cms/api/gen/go/storage/service/v1"
+
+	"go-wind-cms/pkg/content/importer"
+	"go-wind-cms/pkg/content/locale"
+	appViewer "go-wind-cms/pkg/entgo/viewer"
+	"go-wind-cms/pkg/netutil"
+	"go-wind-cms/pkg/oss"
+	"go-wind-cms/pkg/task"
+)
+
+const (
+	// maxImportFileSize 导入文件（WXR 或 Markdown 归档）的大小上限
+	maxImportFileSize = 256 << 20
+
+	// maxImportReportItems 作业报告保留的逐条结果上限
+	maxImportReportItems = 5000
+
+	// importProgressEvery 每处理多少条目更新一次进度
+	importProgressEvery = 20
+)
+
+// ImportJobService 内容导入作业：创建作业并入队 content.import 任务，
+// worker 解析导入文件，下载附件、改写站内链接后写入作业所属租户，并生成逐条报告。
+type ImportJobService struct {
+	contentV1.UnimplementedImportJobServiceServer
+
+	log *log.Helper
+
+	importJobRepo   *data.ImportJobRepo
+	fileRepo        *data.FileRepo
+	redirectRepo    *data.RedirectRepo
+	syndicationRepo *data.SyndicationRepo
+	taskService     *TaskService
+
+	storage     *oss.StorageRouter
+	uploadGuard *oss.UploadGuard
+}
+
+func NewImportJobService(
+	ctx *bootstrap.Context,
+	importJobRepo *data.ImportJobRepo,
+	fileRepo *data.FileRepo,
+	redirectRepo *data.RedirectRepo,
+	syndicationRepo *data.SyndicationRepo,
+	taskService *TaskService,
+	storage *oss.StorageRouter,
+	uploadGuard *oss.UploadGuard,
+) *ImportJobService {
+	return &ImportJobService{
+		log:             ctx.NewLoggerHelper("import-job/service/core-service"),
+		importJobRepo:   importJobRepo,
+		fileRepo:        fileRepo,
+		redirectRepo:    redirectRepo,
+		syndicationRepo: syndicationRepo,
+		taskService:     taskService,
+		storage:         storage,
+		uploadGuard:     uploadGuard,
+	}
+}
+
+func (s *ImportJobService) ListImportJobs(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListImportJobResponse, error) {
+	return s.importJobRepo.List(ctx, req)
+}
+
+func (s *ImportJobService) GetImportJob(ctx context.Context, req *contentV1.GetImportJobRequest) (*contentV1.ImportJob, error) {
+	return s.importJobRepo.Get(ctx, req.GetId())
+}
+
+func (s *ImportJobService) CreateImportJob(ctx context.Context, req *contentV1.CreateImportJobRequest) (*contentV1.ImportJob, error) {
+	job, err := s.importJobRepo.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.taskService.EnqueueContentImport(&task.ContentImportPayload{JobID: job.GetId()}); err != nil {
+		// 入队失败时作业不会被执行，直接置为失败，避免一直停留在等待状态
+		_ = s.importJobRepo.Finish(ctx, job.GetId(), nil, nil, err)
+		return nil, contentV1.ErrorInternalServerError("enqueue import job failed")
+	}
+
+	return job, nil
+}
+
+// RunImportJob 处理 content.import 任务。
+// 签名遵循 (taskType string, payload *T) error 模式（参考 TaskService.AsyncBackup）。
+//
+// 单个条目失败只记入报告，不影响其他条目；导入文件无法读取或解析等作业级错误使作业失败。
+func (s *ImportJobService) RunImportJob(_ string, payload *task.ContentImportPayload) error {
+	if payload == nil || payload.JobID == 0 {
+		s.log.Warnf("import job: invalid payload")
+		return nil
+	}
+
+	// 注入 SystemViewer：worker 需跨租户读取导入文件，写入内容的 tenant_id 取自作业记录
+	ctx := appViewer.NewSystemViewerContext(context.Background())
+
+	job, err := s.importJobRepo.Claim(ctx, payload.JobID)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		// 已被其他 worker 认领或已结束
+		return nil
+	}
+
+	bundle, cleanup, err := s.loadBundle(ctx, job)
+	if err != nil {
+		return s.importJobRepo.Finish(ctx, job.ID, nil, nil, err)
+	}
+	defer cleanup()
+
+	run := newImportRun(s, job, bundle)
+	run.execute(ctx)
+
+	s.log.Infof("import job [%d] finished: %d items, dry run %v", job.ID, run.processed, job.DryRun)
+
+	return s.importJobRepo.Finish(ctx, job.ID, run.report.stats(), run.report.items(), nil)
+}
+
+// loadBundle 读取并解析导入文件；导入文件须属于作业所在租户
+func (s *ImportJobService) loadBundle(ctx context.Context, job *ent.ImportJob) (*importer.Bundle, func(), error) {
+	noop := func() {}
+
+	f, err := s.fileRepo.GetForTenant(ctx, trans.Uint32Value(job.TenantID), job.FileID)
+	if err != nil {
+		return nil, noop, err
+	}
+	if f.GetSize() > maxImportFileSize {
+		return nil, noop, contentV1.ErrorBadRequest(fmt.Sprintf("import file exceeds the limit of %d bytes", maxImportFileSize))
+	}
+
+	driver, err := s.storage.ForProvider(f.GetProvider())
+	if err != nil {
+		return nil, noop, err
+	}
+
+	rc, _, err := driver.GetObject(ctx, f.GetBucketName(), path.Join(f.GetFileDirectory(), f.GetSaveFileName()), nil, nil)
+	if err != nil {
+		s.log.Errorf("import job [%d] read file [%d] failed: %s", job.ID, job.FileID, err.Error())
+		return nil, noop, contentV1.ErrorInternalServerError("read import file failed")
+	}
+	defer rc.Close()
+
+	switch job.Format {
+	case importjob.FormatFormatWxr:
+		b, err := importer.ParseWXR(io.LimitReader(rc, maxImportFileSize))
+		if err != nil {
+			return nil, noop, contentV1.ErrorBadRequest(err.Error())
+		}
+		return b, noop, nil
+
+	case importjob.FormatFormatMarkdown:
+		// zip 需随机读取，先落盘到临时文件，附件读取完成后删除
+		tmp, err := os.CreateTemp("", "content-import-*.zip")
+		if err != nil {
+			s.log.Errorf("create temp file failed: %s", err.Error())
+			return nil, noop, contentV1.ErrorInternalServerError("read import file failed")
+		}
+		cleanup := func() {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+
+		size, err := io.Copy(tmp, io.LimitReader(rc, maxImportFileSize))
+		if err != nil {
+			cleanup()
+			s.log.Errorf("spool import file failed: %s", err.Error())
+			return nil, noop, contentV1.ErrorInternalServerError("read import file failed")
+		}
+
+		zr, err := zip.NewReader(tmp, size)
+		if err != nil {
+			cleanup()
+			return nil, noop, contentV1.ErrorBadRequest("import file is not a valid zip archive")
+		}
+
+		b, err := importer.ParseMarkdown(archiveRoot(zr))
+		if err != nil {
+			cleanup()
+			return nil, noop, contentV1.ErrorBadRequest(err.Error())
+		}
+		return b, cleanup, nil
+
+	default:
+		return nil, noop, contentV1.ErrorBadRequest("unsupported import format")
+	}
+}
+
+// archiveRoot 归档只有一个顶层目录时（如从代码仓库下载的 zip）以该目录为根，使站点根路径的引用能够解析
+func archiveRoot(fsys fs.FS) fs.FS {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil || len(entries) != 1 || !entries[0].IsDir() {
+		return fsys
+	}
+	sub, err := fs.Sub(fsys, entries[0].Name())
+	if err != nil {
+		return fsys
+	}
+	return sub
+}
+
+// importReport 作业报告：按内容类型统计，逐条结果中未创建的条目优先保留
+type importReport struct {
+	counts   map[contentV1.ImportEntityType]*contentV1.ImportJobStat
+	problems []*contentV1.ImportJobItem
+	done     []*contentV1.ImportJobItem
+}
+
+func (r *importReport) add(item *contentV1.ImportJobItem) {
+	st, ok := r.counts[item.EntityType]
+	if !ok {
+		st = &contentV1.ImportJobStat{EntityType: item.EntityType}
+		r.counts[item.EntityType] = st
+	}
+
+	switch item.Outcome {
+	case contentV1.ImportJobItem_OUTCOME_CREATED:
+		st.Created++
+	case contentV1.ImportJobItem_OUTCOME_MATCHED:
+		st.Matched++
+	case contentV1.ImportJobItem_OUTCOME_SKIPPED:
+		st.Skipped++
+	case contentV1.ImportJobItem_OUTCOME_FAILED:
+		st.Failed++
+	}
+
+	switch item.Outcome {
+	case contentV1.ImportJobItem_OUTCOME_CREATED, contentV1.ImportJobItem_OUTCOME_MATCHED:
+		if len(r.done) < maxImportReportItems {
+			r.done = append(r.done, item)
+		}
+	default:
+		if len(r.problems) < maxImportReportItems {
+			r.problems = append(r.problems, item)
+		}
+	}
+}
+
+func (r *importReport) stats() []*contentV1.ImportJobStat {
+	out := make([]*contentV1.ImportJobStat, 0, len(r.counts))
+	for _, st := range r.counts {
+		out = append(out, st)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].EntityType < out[j].EntityType })
+	return out
+}
+
+func (r *importReport) items() []*contentV1.ImportJobItem {
+	out := r.problems
+	if room := maxImportReportItems - len(out); room > 0 {
+		if len(r.done) > room {
+			out = append(out, r.done[:room]...)
+		} else {
+			out = append(out, r.done...)
+		}
+	}
+	return out
+}
+
+// importAuthor 源站点作者对应的本站用户
+type importAuthor struct {
+	userID uint32
+	name   string
+}
+
+// importTarget 文章或页面导入后的位置
+type importTarget struct {
+	item *importer.Item
+	lang string
+	slug string
+	path string
+
+	// skip 非空时不导入，为跳过原因
+	skip string
+	id   uint32
+}
+
+// importRun 单次导入的执行状态
+type importRun struct {
+	s      *ImportJobService
+	job    *ent.ImportJob
+	bundle *importer.Bundle
+
+	tenantID uint32
+	dryRun   bool
+
+	report    *importReport
+	rewriter  *importer.Rewriter
+	total     uint32
+	processed uint32
+
+	authors     map[string]importAuthor
+	categories  map[string]uint32
+	catDepths   map[string]int32
+	tags        map[string]uint32
+	attachments map[string]string // 附件 ID 到新地址
+	targets     map[string]*importTarget
+	order       []*importTarget
+}
+
+func newImportRun(s *ImportJobService, job *ent.ImportJob, bundle *importer.Bundle) *importRun {
+	sourceURL := trans.StringValue(job.SourceURL)
+	if sourceURL == "" {
+		sourceURL = bundle.Site.BaseURL
+	}
+
+	r := &importRun{
+		s:           s,
+		job:         job,
+		bundle:      bundle,
+		tenantID:    trans.Uint32Value(job.TenantID),
+		dryRun:      job.DryRun,
+		report:      &importReport{counts: map[contentV1.ImportEntityType]*contentV1.ImportJobStat{}},
+		rewriter:    importer.NewRewriter(sourceURL, bundle.Site.BaseURL),
+		authors:     map[string]importAuthor{},
+		categories:  map[string]uint32{},
+		catDepths:   map[string]int32{},
+		tags:        map[string]uint32{},
+		attachments: map[string]string{},
+		targets:     map[string]*importTarget{},
+	}
+
+	r.total = uint32(len(bundle.Categories) + len(bundle.Tags) + len(bundle.Items))
+	if job.DownloadAttachments {
+		r.total += uint32(len(bundle.Attachments))
+	}
+	return r
+}
+
+func (r *importRun) record(kind contentV1.ImportEntityType, source, title string, outcome contentV1.ImportJobItem_Outcome, targetID uint32, targetURL, message string) {
+	item := &contentV1.ImportJobItem{
+		EntityType: kind,
+		Source:     source,
+		Outcome:    outcome,
+	}
+	if title != "" {
+		item.Title = trans.Ptr(title)
+	}
+	if targetID != 0 {
+		item.TargetId = trans.Ptr(targetID)
+	}
+	if targetURL != "" {
+		item.TargetUrl = trans.Ptr(targetURL)
+	}
+	if message != "" {
+		item.Message = trans.Ptr(message)
+	}
+	r.report.add(item)
+}
+
+// created 试运行时为预计创建，不写入数据
+func (r *importRun) created(kind contentV1.ImportEntityType, source, title string, targetID uint32, targetURL string) {
+	r.record(kind, source, title, contentV1.ImportJobItem_OUTCOME_CREATED, targetID, targetURL, "")
+}
+
+func (r *importRun) failed(kind contentV1.ImportEntityType, source, title string, err error) {
+	r.s.log.Warnf("import job [%d] %s [%s] failed: %s", r.job.ID, kind, source, err.Error())
+	r.record(kind, source, title, contentV1.ImportJobItem_OUTCOME_FAILED, 0, "", errorMessage(err))
+}
+
+func (r *importRun) step(ctx context.Context) {
+	r.processed++
+	if r.processed%importProgressEvery == 0 {
+		_ = r.s.importJobRepo.UpdateProgress(ctx, r.job.ID, r.total, r.processed)
+	}
+}
+
+// errorMessage 取错误的说明，kratos 错误取其 message
+func errorMessage(err error) string {
+	var e interface{ GetMessage() string }
+	if errors.As(err, &e) && e.GetMessage() != "" {
+		return e.GetMessage()
+	}
+	return err.Error()
+}
+
+func (r *importRun) execute(ctx context.Context) {
+	_ = r.s.importJobRepo.UpdateProgress(ctx, r.job.ID, r.total, 0)
+
+	for _, issue := range r.bundle.Issues {
+		r.record(contentV1.ImportEntityType_IMPORT_ENTITY_TYPE_SOURCE, issue.Ref, "", contentV1.ImportJobItem_OUTCOME_SKIPPED, 0, "", issue.Message)
+	}
+
+	r.importAuthors(ctx)
+	r.importCategories(ctx)
+	r.importTags(ctx)
+	r.importAttachments(ctx)
+	r.planItems(ctx)
+	r.importPages(ctx)
+	r.importPosts(ctx)
+
+	if !r.dryRun && r.processed > 0 {
+		// 导入的已发布文章会反映到站点地图与订阅源
+		r.s.syndicationRepo.Invalidate(ctx)
+	}
+
+	_ = r.s.importJobRepo.UpdateProgress(ctx, r.job.ID, r.total, r.processed)
+}
+
+// importAuthors 按邮箱把源站点作者匹配到本租户的用户；未匹配的作者名下内容归属创建作业的用户，保留原作者名
+func (r *importRun) importAuthors(ctx context.Context) {
+	for _, a := range r.bundle.Authors {
+		name := firstNonEmpty(a.DisplayName, a.Login)
+
+		userID, userName, err := r.s.importJobRepo.FindUserByEmail(ctx, r.tenantID, a.Email)
+		if err != nil {
+			r.failed(contentV1.ImportEntityType_IMPORT_ENTITY_TYPE_AUTHOR, a.Login, name, err)
+			continue
+		}
+		if userID == 0 {
+			r.authors[a.Login] = importAuthor{name: name}
+			r.record(contentV1.ImportEntityType_IMPORT_ENTITY_TYPE_AUTHOR, a.Login, name,
+				contentV1.ImportJobItem_OUTCOME_SKIPPED, 0, "", "no user with this email, content is attributed to the importer")
+			continue
+		}
+
+		r.authors[a.Login] = importAuthor{userID: userID, name: userName}
+		r.record(contentV1.ImportEntityType_IMPORT_ENTITY_TYPE_AUTHOR, a.Login, name,
+			contentV1.ImportJobItem_OUTCOME_MATCHED, userID, "", "")
+	}
+}
+
+// author 条目的作者
+func (r *importRun) author(login string) importAuthor {
+	if a, ok := r.authors[login]; ok && a.userID != 0 {
+		return a
+	}
+	a := importAuthor{userID: trans.Uint32Value(r.job.CreatedBy), name: login}
+	if known, ok := r.authors[login]; ok && known.name != "" {
+		a.name = known.name
+	}
+	return a
+}
+
+// importCategories 按父分类优先的顺序导入分类，已有相同 slug 的分类直接复用
+func (r *importRun) importCategories(ctx context.Context) {
+	lang := r.job.Language
+	pending := r.bundle.Categories
+	resolved := map[string]bool{}
+
+	for len(pending) > 0 {
+		var next []importer.Term
+		for _, term := range pending {
+			// 父分类在本次导入中且尚未处理时延后；父分类缺失或存在循环时作为顶级分类
+			if term.Parent != "" && !resolved[term.Parent] && containsTerm(pending, term.Parent) {
+				next = append(next, term)
+				continue
+			}
+			resolved[term.Slug] = true
+			r.importCategory(ctx, lang, term)
+			r.step(ctx)
+		}
+		if len(next) == len(pending) {
+			// 剩余分类互为父子（循环），不再等待父分类
+			for _, term := range next {
+				term.Parent = ""
+				resolved[term.Slug] = true
+				r.importCategory(ctx, lang, term)
+				r.step(ctx)
+			}
+			break
+		}
+		pending = next
+	}
+}
+
+func containsTerm(terms []importer.Term, slug string) bool {
+	for _, t := range terms {
+		if t.Slug == slug {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *importRun) importCategory(ctx context.Context, lang string, term importer.Term) {
+	kind := contentV1.ImportEntityType_IMPORT_ENTITY_TYPE_CATEGORY
+
+	id, err := r.s.importJobRepo.FindCategory(ctx, r.tenantID, lang, term.Slug)
+	if err != nil {
+		r.failed(kind, term.Slug, term.Name, err)
+		return
+	}
+	if id != 0 {
+		r.categories[term.Slug] = id
+		r.record(kind, term.Slug, term.Name, contentV1.ImportJobItem_OUTCOME_MATCHED, id, "", "")
+		return
+	}
+
+	var (
+		parentID uint32
+		depth    int32
+	)
+	if term.Parent != "" {
+		if pid, ok := r.categories[term.Parent]; ok {
+			parentID = pid
+			depth = r.catDepths[term.Parent] + 1
+		}
+	}
+
+	if !r.dryRun {
+		if id, err = r.s.importJobRepo.CreateCategory(ctx, r.job, lang, term, parentID, depth); err != nil {
+			r.failed(kind, term.Slug, term.Name, err)
+			return
+		}
+	}
+	r.categories[term.Slug] = id
+	r.catDepths[term.Slug] = depth
+	r.created(kind, term.Slug, term.Name, id, "/"+lang+"/category/"+term.Slug)
+}
+
+// importTags 导入标签，已有相同 slug 的标签直接复用
+func (r *importRun) importTags(ctx context.Context) {
+	lang := r.job.Language
+	kind := contentV1.ImportEntityType_IMPORT_ENTITY_TYPE_TAG
+
+	for _, term := range r.bundle.Tags {
+		r.step(ctx)
+
+		id, err := r.s.importJobRepo.FindTag(ctx, r.tenantID, lang, term.Slug)
+		if err != nil {
+			r.failed(kind, term.Slug, term.Name, err)
+			continue
+		}
+		if id != 0 {
+			r.tags[term.Slug] = id
+			r.record(kind, term.Slug, term.Name, contentV1.ImportJobItem_OUTCOME_MATCHED, id, "", "")
+			continue
+		}
+
+		if !r.dryRun {
+			if id, err = r.s.importJobRepo.CreateTag(ctx, r.job, lang, term); err != nil {
+				r.failed(kind, term.Slug, term.Name, err)
+				continue
+			}
+		}
+		r.tags[term.Slug] = id
+		r.created(kind, term.Slug, term.Name, id, "/"+lang+"/tag/"+term.Slug)
+	}
+}
+
+// importAttachments 下载附件存入租户的对象存储，并登记旧地址到新地址的改写。
+// 远程附件经 SSRF 防护的下载器取回，所有附件都经上传检查（类型白名单、大小、病毒扫描）。
+func (r *importRun) importAttachments(ctx context.Context) {
+	kind := contentV1.ImportEntityType_IMPORT_ENTITY_TYPE_ATTACHMENT
+
+	if !r.job.DownloadAttachments {
+		// 远程附件保留原地址；归档内的本地文件无法引用
+		for _, a := range r.bundle.Attachments {
+			if a.URL != "" {
+				r.attachments[a.ID] = a.URL
+				continue
+			}
+			r.record(kind, a.ID, a.Title, contentV1.ImportJobItem_OUTCOME_SKIPPED, 0, "", "attachment download is disabled")
+		}
+		return
+	}
+
+	for _, a := range r.bundle.Attachments {
+		r.step(ctx)
+
+		source := firstNonEmpty(a.URL, a.Path)
+		if r.dryRun {
+			if err := r.checkAttachment(a); err != nil {
+				r.failed(kind, source, a.Title, err)
+				continue
+			}
+			r.created(kind, source, a.Title, 0, "")
+			continue
+		}
+
+		fileID, downloadURL, err := r.storeAttachment(ctx, a)
+		if err != nil {
+			r.failed(kind, source, a.Title, err)
+			if a.URL != "" {
+				// 下载失败时保留原地址，避免正文中的图片直接失效
+				r.attachments[a.ID] = a.URL
+			}
+			continue
+		}
+
+		r.attachments[a.ID] = downloadURL
+		if a.URL != "" {
+			r.rewriter.Map(a.URL, downloadURL)
+		}
+		r.created(kind, source, a.Title, fileID, downloadURL)
+	}
+}
+
+// checkAttachment 试运行时只检查附件地址或归档内文件，不下载
+func (r *importRun) checkAttachment(a *importer.Attachment) error {
+	if a.URL != "" {
+		_, err := netutil.ValidateURL(a.URL)
+		return err
+	}
+	if r.bundle.Files == nil {
+		return errors.New("attachment has no source")
+	}
+	info, err := fs.Stat(r.bundle.Files, a.Path)
+	if err != nil {
+		return err
+	}
+	if info.Size() == 0 {
+		return errors.New("empty file")
+	}
+	return nil
+}
+
+// openAttachment 打开附件内容：远程地址经 SSRF 防护的下载器取回，本地文件从归档读取
+func (r *importRun) openAttachment(ctx context.Context, a *importer.Attachment) (io.Reader, int64, string, string, error) {
+	if a.URL == "" {
+		if r.bundle.Files == nil {
+			return nil, 0, "", "", errors.New("attachment has no source")
+		}
+		content, err := fs.ReadFile(r.bundle.Files, a.Path)
+		if err != nil {
+			return nil, 0, "", "", err
+		}
+		name := firstNonEmpty(a.FileName, path.Base(a.Path))
+		return bytes.NewReader(content), int64(len(content)), mime.TypeByExtension(path.Ext(name)), name, nil
+	}
+
+	u, err := netutil.ValidateURL(a.URL)
+	if err != nil {
+		return nil, 0, "", "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, 0, "", "", err
+	}
+	resp, err := netutil.SafeHTTPClient().Do(req)
+	if err != nil {
+		return nil, 0, "", "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, "", "", fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	content, err := io.ReadAll(netutil.LimitReader(resp.Body))
+	if err != nil {
+		return nil, 0, "", "", err
+	}
+	// 超过下载上限的内容已被截断，不能入库
+	if n, _ := resp.Body.Read(make([]byte, 1)); n > 0 {
+		return nil, 0, "", "", errors.New("attachment exceeds the download size limit")
+	}
+
+	mimeType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	name := firstNonEmpty(a.FileName, path.Base(u.Path))
+	return bytes.NewReader(content), int64(len(content)), mimeType, name, nil
+}
+
+// storeAttachment 检查并上传附件，记录文件元数据，返回文件ID与下载地址
+func (r *importRun) storeAttachment(ctx context.Context, a *importer.Attachment) (uint32, string, error) {
+	reader, size, mimeType, name, err := r.openAttachment(ctx, a)
+	if err != nil {
+		return 0, "", err
+	}
+	if size == 0 {
+		return 0, "", errors.New("empty file")
+	}
+
+	upload, err := r.s.uploadGuard.Inspect(ctx, r.tenantID, mimeType, reader, size)
+	if err != nil {
+		return 0, "", err
+	}
+	defer upload.Close()
+
+	if upload.Infected {
+		return 0, "", fmt.Errorf("file rejected: malware detected (%s)", upload.Signature)
+	}
+
+	driver := r.s.storage.ForTenant(r.tenantID)
+	objectName := oss.EnsureObjectName(
+		"import/"+strconv.FormatUint(uint64(r.job.ID), 10),
+		name, upload.MimeType, oss.GenerateFileNameTypeUUID,
+	)
+
+	info, _, downloadURL, err := driver.UploadFile(
+		ctx,
+		oss.ContentTypeToBucketName(upload.MimeType), objectName,
+		upload.MimeType,
+		upload.Reader(), upload.Size(),
+	)
+	if err != nil {
+		return 0, "", err
+	}
+
+	dir, fileName, ext := parseKey(info.Key)
+	f, err := r.s.fileRepo.Create(ctx, &storageV1.CreateFileRequest{
+		Data: &storageV1.File{
+			Provider:      trans.Ptr(driver.Provider()),
+			BucketName:    trans.Ptr(info.Bucket),
+			SaveFileName:  trans.Ptr(fileName + "." + ext),
+			FileDirectory: trans.Ptr(dir),
+			FileName:      trans.Ptr(name),
+			Extension:     trans.Ptr(ext),
+			FileGuid:      trans.Ptr(uuid.New().String()),
+			Size:          trans.Ptr(uint64(info.Size)),
+			LinkUrl:       trans.Ptr(downloadURL),
+			CreatedBy:     r.job.CreatedBy,
+			TenantId:      trans.Ptr(r.tenantID),
+		},
+	})
+	if err != nil {
+		return 0, "", err
+	}
+
+	return f.GetId(), downloadURL, nil
+}
+
+// attachmentURL 附件导入后的地址，未导入时为空
+func (r *importRun) attachmentURL(id string) string {
+	if id == "" {
+		return ""
+	}
+	return r.attachments[id]
+}
+
+// planItems 为文章与页面确定语言、slug 与新地址，并登记旧地址的改写；
+// 先于写入执行，使正文中互相引用的链接无论先后都能改写
+func (r *importRun) planItems(ctx context.Context) {
+	used := map[string]bool{}
+
+	for _, item := range r.bundle.Items {
+		lang := item.Language
+		if !locale.ValidCode(lang) {
+			lang = r.job.Language
+		}
+
+		slug := importer.Slugify(item.Slug)
+		if slug == "" {
+			slug = importer.Slugify(item.Title)
+		}
+		if slug == "" {
+			slug = string(item.Kind) + "-" + importer.Slugify(item.SourceID)
+		}
+
+		t := &importTarget{item: item, lang: lang}
+
+		// 同一导入文件中重复的 slug 追加序号
+		base := slug
+		for n := 2; used[string(item.Kind)+"/"+lang+"/"+slug]; n++ {
+			slug = base + "-" + strconv.Itoa(n)
+		}
+		used[string(item.Kind)+"/"+lang+"/"+slug] = true
+		t.slug = slug
+
+		if item.Kind == importer.KindPage {
+			t.path = "/" + lang + "/" + slug
+		} else {
+			t.path = "/" + lang + "/post/" + slug
+		}
+
+		var (
+			exist bool
+			err   error
+		)
+		if item.Kind == importer.KindPage {
+			exist, err = r.s.importJobRepo.PageSlugExists(ctx, r.tenantID, lang, slug)
+		} else {
+			exist, err = r.s.importJobRepo.PostSlugExists(ctx, r.tenantID, lang, slug)
+		}
+		switch {
+		case err != nil:
+			t.skip = errorMessage(err)
+		case exist:
+			// 已有内容的地址与导入后的地址相同，链接照常改写
+			t.skip = "content with the same slug already exists"
+		}
+
+		r.targets[item.SourceID] = t
+		r.order = append(r.order, t)
+
+		r.rewriter.Map(item.Link, t.path)
+		for _, alias := range item.Aliases {
+			r.rewriter.Map(alias, t.path)
+		}
+		if item.Slug != "" {
+			r.rewriter.MapSlug(item.Slug, t.path)
+		}
+		if r.bundle.Files == nil {
+			// WordPress 的短链接
+			if item.Kind == importer.KindPage {
+				r.rewriter.Map("/?page_id="+item.SourceID, t.path)
+			} else {
+				r.rewriter.Map("/?p="+item.SourceID, t.path)
+			}
+		}
+	}
+}
+
+// content 改写正文中的站内链接与附件地址
+func (r *importRun) content(item *importer.Item) string {
+	refs := make(map[string]string, len(item.Refs))
+	for raw, id := range item.Refs {
+		if u := r.attachmentURL(id); u != "" {
+			refs[raw] = u
+		} else if t, ok := r.targets[id]; ok {
+			refs[raw] = t.path
+		}
+	}
+	return r.rewriter.Rewrite(item.Content, item.Markdown, refs)
+}
+
+// status 条目导入后的发布状态
+func (r *importRun) status(item *importer.Item) importer.Status {
+	if r.job.AsDraft {
+		return importer.StatusDraft
+	}
+	return item.Status
+}
+
+// importPages 按父页面优先的顺序导入页面
+func (r *importRun) importPages(ctx context.Context) {
+	var pages []*importTarget
+	for _, t := range r.order {
+		if t.item.Kind == importer.KindPage {
+			pages = append(pages, t)
+		}
+	}
+
+	depths := map[string]int32{}
+	done := map[string]bool{}
+	for len(pages) > 0 {
+		var next []*importTarget
+		for _, t := range pages {
+			parent := t.item.ParentID
+			if p, ok := r.targets[parent]; ok && parent != "" && !done[parent] && p.item.Kind == importer.KindPage {
+				next = append(next, t)
+				continue
+			}
+			done[t.item.SourceID] = true
+			depths[t.item.SourceID] = r.importPage(ctx, t, depths)
+			r.step(ctx)
+		}
+		if len(next) == len(pages) {
+			for _, t := range next {
+				t.item.ParentID = ""
+				done[t.item.SourceID] = true
+				depths[t.item.SourceID] = r.importPage(ctx, t, depths)
+				r.step(ctx)
+			}
+			break
+		}
+		pages = next
+	}
+}
+
+func (r *importRun) importPage(ctx context.Context, t *importTarget, depths map[string]int32) int32 {
+	kind := contentV1.ImportEntityType_IMPORT_ENTITY_TYPE_PAGE
+	item := t.item
+
+	if t.skip != "" {
+		r.record(kind, item.SourceID, item.Title, contentV1.ImportJobItem_OUTCOME_SKIPPED, 0, t.path, t.skip)
+		return 0
+	}
+
+	var (
+		parentID uint32
+		depth    int32
+	)
+	if p, ok := r.targets[item.ParentID]; ok && p.id != 0 {
+		parentID = p.id
+		depth = depths[item.ParentID] + 1
+	}
+
+	status := r.status(item)
+	if status == importer.StatusScheduled {
+		// 页面没有定时发布，导入为草稿
+		status = importer.StatusDraft
+	}
+
+	a := r.author(item.Author)
+	if !r.dryRun {
+		id, err := r.s.importJobRepo.CreatePage(ctx, r.job, &data.ImportedPage{
+			Language:        t.lang,
+			Title:           item.Title,
+			Slug:            t.slug,
+			Content:         r.content(item),
+			Markdown:        item.Markdown,
+			Status:          status,
+			ParentID:        parentID,
+			Depth:           depth,
+			SortOrder:       uint32(max(item.MenuOrder, 0)),
+			AuthorID:        a.userID,
+			AuthorName:      a.name,
+			Thumbnail:       r.attachmentURL(item.FeaturedImage),
+			DisallowComment: item.CommentsClosed,
+			PublishTime:     item.Published,
+			UpdatedAt:       item.Modified,
+		})
+		if err != nil {
+			r.failed(kind, item.SourceID, item.Title, err)
+			return 0
+		}
+		t.id = id
+		r.recordRedirect(ctx, contentV1.ContentType_CONTENT_TYPE_PAGE, t)
+	}
+
+	r.created(kind, item.SourceID, item.Title, t.id, t.path)
+	r.importComments(ctx, t)
+	return depth
+}
+
+// importPosts 导入文章及其分类、标签与评论
+func (r *importRun) importPosts(ctx context.Context) {
+	kind := contentV1.ImportEntityType_IMPORT_ENTITY_TYPE_POST
+
+	for _, t := range r.order {
+		item := t.item
+		if item.Kind != importer.KindPost {
+			continue
+		}
+		r.step(ctx)
+
+		if t.skip != "" {
+			r.record(kind, item.SourceID, item.Title, contentV1.ImportJobItem_OUTCOME_SKIPPED, 0, t.path, t.skip)
+			continue
+		}
+
+		if r.dryRun {
+			r.created(kind, item.SourceID, item.Title, 0, t.path)
+			r.importComments(ctx, t)
+			continue
+		}
+
+		p := &data.ImportedPost{
+			Language:        t.lang,
+			Title:           item.Title,
+			Slug:            t.slug,
+			Summary:         item.Excerpt,
+			Content:         r.content(item),
+			Markdown:        item.Markdown,
+			Status:          r.status(item),
+			Password:        item.Password,
+			Thumbnail:       r.attachmentURL(item.FeaturedImage),
+			DisallowComment: item.CommentsClosed,
+			PublishTime:     item.Published,
+			UpdatedAt:       item.Modified,
+		}
+		a := r.author(item.Author)
+		p.AuthorID, p.AuthorName = a.userID, a.name
+		for _, slug := range item.Categories {
+			if id := r.categories[slug]; id != 0 {
+				p.CategoryIDs = append(p.CategoryIDs, id)
+			}
+		}
+		for _, slug := range item.Tags {
+			if id := r.tags[slug]; id != 0 {
+				p.TagIDs = append(p.TagIDs, id)
+			}
+		}
+
+		id, drafted, err := r.s.importJobRepo.CreatePost(ctx, r.job, p)
+		if err != nil {
+			r.failed(kind, item.SourceID, item.Title, err)
+			continue
+		}
+		t.id = id
+
+		var message string
+		if drafted {
+			message = "post is under an editorial workflow, imported as draft"
+		}
+		r.record(kind, item.SourceID, item.Title, contentV1.ImportJobItem_OUTCOME_CREATED, id, t.path, message)
+
+		r.recordRedirect(ctx, contentV1.ContentType_CONTENT_TYPE_POST, t)
+		if err = r.s.taskService.EnqueueSearchReindex(&task.SearchReindexPayload{
+			Entity:   "post",
+			ID:       id,
+			TenantID: r.tenantID,
+			Op:       "index",
+		}); err != nil {
+			r.s.log.Errorf("enqueue post reindex failed (post_id=%d op=index): %v", id, err)
+		}
+
+		r.importComments(ctx, t)
+	}
+}
+
+// recordRedirect 把旧地址的路径记录为到新地址的 301 重定向，站点域名迁移到本系统后旧链接仍可访问
+func (r *importRun) recordRedirect(ctx context.Context, contentType contentV1.ContentType, t *importTarget) {
+	for _, old := range append([]string{t.item.Link}, t.item.Aliases...) {
+		if old == "" {
+			continue
+		}
+		u, err := url.Parse(old)
+		if err != nil {
+			continue
+		}
+		oldPath := u.EscapedPath()
+		if oldPath == "" || oldPath == "/" {
+			continue
+		}
+		if err := r.s.redirectRepo.RecordPathChange(ctx, r.tenantID, contentType, t.id, t.lang, oldPath, t.path); err != nil {
+			r.s.log.Warnf("record redirect %s -> %s failed: %v", oldPath, t.path, err)
+		}
+	}
+}
+
+// importComments 导入条目的评论，父评论优先；回复的评论缺失时作为顶级评论
+func (r *importRun) importComments(ctx context.Context, t *importTarget) {
+	if !r.job.ImportComments || len(t.item.Comments) == 0 {
+		return
+	}
+	kind := contentV1.ImportEntityType_IMPORT_ENTITY_TYPE_COMMENT
+
+	comments := make([]importer.Comment, len(t.item.Comments))
+	copy(comments, t.item.Comments)
+	sort.SliceStable(comments, func(i, j int) bool { return comments[i].Date.Before(comments[j].Date) })
+
+	ids := make(map[string]uint32, len(comments))
+	for _, c := range comments {
+		source := t.item.SourceID + "#comment-" + c.ID
+		if r.dryRun {
+			r.created(kind, source, c.AuthorName, 0, "")
+			continue
+		}
+
+		id, err := r.s.importJobRepo.CreateComment(ctx, r.job, t.item.Kind, t.id, ids[c.ParentID], c)
+		if err != nil {
+			r.failed(kind, source, c.AuthorName, err)
+			continue
+		}
+		ids[c.ID] = id
+		r.created(kind, source, c.AuthorName, id, "")
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -10,6 +10,7 @@ import (
 
 	"go-wind-cms/app/core/service/internal/data"
 
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
 	mediaV1 "go-wind-cms/api/gen/go/media/service/v1"
 )
 
@@ -17,13 +18,15 @@ type MediaAssetService struct {
 	mediaV1.UnimplementedMediaAssetServiceServer
 
 	mediaAssetRepo *data.MediaAssetRepo
+	trashRepo      *data.TrashRepo
 	log            *log.Helper
 }
 
-func NewMediaAssetService(ctx *bootstrap.Context, uc *data.MediaAssetRepo) *MediaAssetService {
+func NewMediaAssetService(ctx *bootstrap.Context, uc *data.MediaAssetRepo, trashRepo *data.TrashRepo) *MediaAssetService {
 	return &MediaAssetService{
 		log:            ctx.NewLoggerHelper("media-asset/service/core-service"),
 		mediaAssetRepo: uc,
+		trashRepo:      trashRepo,
 	}
 }
 
@@ -44,8 +47,8 @@ func (s *MediaAssetService) Update(ctx context.Context, req *mediaV1.UpdateMedia
 }
 
 func (s *MediaAssetService) Delete(ctx context.Context, req *mediaV1.DeleteMediaAssetRequest) (*emptypb.Empty, error) {
-	_, err := s.mediaAssetRepo.Delete(ctx, req)
-	if err != nil {
+	// 移入回收站，彻底删除经 TrashService 完成
+	if err := s.trashRepo.Trash(ctx, contentV1.TrashItem_ENTITY_TYPE_MEDIA_ASSET, req.GetId()); err != nil {
 		return nil, err
 	}
 	return &emptypb.Empty{}, nil
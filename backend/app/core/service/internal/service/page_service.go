@@ -5,10 +5,12 @@ import (
 
 	"github.com/go-kratos/kratos/v2/log"
 	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/go-utils/trans"
 	"github.com/tx7do/kratos-bootstrap/bootstrap"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"go-wind-cms/app/core/service/internal/data"
+	"go-wind-cms/pkg/content/preview"
 
 	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
 )
@@ -16,14 +18,24 @@ import (
 type PageService struct {
 	contentV1.UnimplementedPageServiceServer
 
-	pageRepo *data.PageRepo
-	log      *log.Helper
+	pageRepo           *data.PageRepo
+	trashRepo          *data.TrashRepo
+	previewTokenRepo   *data.PreviewTokenRepo
+	seoRepo            *data.SeoRepo
+	syndicationRepo    *data.SyndicationRepo
+	structuredDataRepo *data.StructuredDataRepo
+	log                *log.Helper
 }
 
-func NewPageService(ctx *bootstrap.Context, uc *data.PageRepo) *PageService {
+func NewPageService(ctx *bootstrap.Context, uc *data.PageRepo, trashRepo *data.TrashRepo, previewTokenRepo *data.PreviewTokenRepo, seoRepo *data.SeoRepo, syndicationRepo *data.SyndicationRepo, structuredDataRepo *data.StructuredDataRepo) *PageService {
 	return &PageService{
-		log:      ctx.NewLoggerHelper("page/service/core-service"),
-		pageRepo: uc,
+		log:                ctx.NewLoggerHelper("page/service/core-service"),
+		pageRepo:           uc,
+		trashRepo:          trashRepo,
+		previewTokenRepo:   previewTokenRepo,
+		seoRepo:            seoRepo,
+		syndicationRepo:    syndicationRepo,
+		structuredDataRepo: structuredDataRepo,
 	}
 }
 
@@ -32,25 +44,96 @@ func (s *PageService) List(ctx context.Context, req *paginationV1.PagingRequest)
 }
 
 func (s *PageService) Get(ctx context.Context, req *contentV1.GetPageRequest) (*contentV1.Page, error) {
-	return s.pageRepo.Get(ctx, req)
+	dto, err := s.get(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 前台按需附带 JSON-LD 结构化数据；生成失败时不返回该字段，不影响详情读取
+	if req.GetIncludeJsonLd() {
+		if ld := s.structuredDataRepo.Page(ctx, req.GetHost(), dto); ld != "" {
+			dto.JsonLd = trans.Ptr(ld)
+		}
+	}
+
+	return dto, nil
+}
+
+// get 读取详情；携带预览令牌时校验令牌后放行草稿
+func (s *PageService) get(ctx context.Context, req *contentV1.GetPageRequest) (*contentV1.Page, error) {
+	if req.GetPreviewToken() == "" {
+		return s.pageRepo.Get(ctx, req)
+	}
+
+	// 草稿预览：令牌锁定语言时以令牌为准，读取后再校验吊销状态、租户与修订
+	claims, err := s.previewTokenRepo.Parse(req.GetPreviewToken(), preview.KindPage)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Locale != "" {
+		req.Locale = trans.Ptr(claims.Locale)
+	}
+
+	dto, err := s.pageRepo.Get(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.previewTokenRepo.Authorize(ctx, claims, dto.GetId()); err != nil {
+		return nil, err
+	}
+
+	dto.IsPreview = trans.Ptr(true)
+	return dto, nil
 }
 
 func (s *PageService) Create(ctx context.Context, req *contentV1.CreatePageRequest) (*contentV1.Page, error) {
-	return s.pageRepo.Create(ctx, req)
+	dto, err := s.pageRepo.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 补全各语言版本缺失的 SEO 字段并计算 SEO 得分
+	s.seoRepo.RefreshPage(ctx, dto.GetId())
+	// 发布、下线与路径变更都会反映到站点地图
+	s.syndicationRepo.Invalidate(ctx)
+
+	return dto, nil
 }
 
 func (s *PageService) Update(ctx context.Context, req *contentV1.UpdatePageRequest) (*contentV1.Page, error) {
-	return s.pageRepo.Update(ctx, req)
+	dto, err := s.pageRepo.Update(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.seoRepo.RefreshPage(ctx, dto.GetId())
+	s.syndicationRepo.Invalidate(ctx)
+
+	return dto, nil
 }
 
 func (s *PageService) Delete(ctx context.Context, req *contentV1.DeletePageRequest) (*emptypb.Empty, error) {
-	err := s.pageRepo.Delete(ctx, req)
-	if err != nil {
+	// 移入回收站，彻底删除经 TrashService 完成
+	if err := s.trashRepo.Trash(ctx, contentV1.TrashItem_ENTITY_TYPE_PAGE, req.GetId()); err != nil {
 		return nil, err
 	}
+
+	s.syndicationRepo.Invalidate(ctx)
+
 	return &emptypb.Empty{}, nil
 }
 
+func (s *PageService) Duplicate(ctx context.Context, req *contentV1.DuplicatePageRequest) (*contentV1.Page, error) {
+	dto, err := s.pageRepo.Duplicate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.seoRepo.RefreshPage(ctx, dto.GetId())
+
+	return dto, nil
+}
+
 func (s *PageService) TranslationExists(ctx context.Context, req *contentV1.PageTranslationExistsRequest) (*contentV1.PageTranslationExistsResponse, error) {
 	exists, err := s.pageRepo.TranslationExists(ctx, req.GetPageId(), req.GetLanguageCode())
 	if err != nil {
@@ -67,11 +150,27 @@ func (s *PageService) GetTranslation(ctx context.Context, req *contentV1.GetPage
 }
 
 func (s *PageService) CreateTranslation(ctx context.Context, req *contentV1.CreatePageTranslationRequest) (*contentV1.PageTranslation, error) {
-	return s.pageRepo.CreateTranslation(ctx, req)
+	dto, err := s.pageRepo.CreateTranslation(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.refreshTranslationSeo(ctx, dto)
+	s.syndicationRepo.Invalidate(ctx)
+
+	return dto, nil
 }
 
 func (s *PageService) UpdateTranslation(ctx context.Context, req *contentV1.UpdatePageTranslationRequest) (*contentV1.PageTranslation, error) {
-	return s.pageRepo.UpdateTranslation(ctx, req)
+	dto, err := s.pageRepo.UpdateTranslation(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.refreshTranslationSeo(ctx, dto)
+	s.syndicationRepo.Invalidate(ctx)
+
+	return dto, nil
 }
 
 func (s *PageService) DeleteTranslation(ctx context.Context, req *contentV1.DeletePageTranslationRequest) (*emptypb.Empty, error) {
@@ -79,5 +178,24 @@ func (s *PageService) DeleteTranslation(ctx context.Context, req *contentV1.Dele
 	if err != nil {
 		return nil, err
 	}
+
+	s.syndicationRepo.Invalidate(ctx)
+
 	return &emptypb.Empty{}, nil
 }
+
+// refreshTranslationSeo 保存语言版本后补全 SEO 字段并计算得分，结果随返回值带回；
+// best-effort：失败仅记日志，不影响保存结果。
+func (s *PageService) refreshTranslationSeo(ctx context.Context, dto *contentV1.PageTranslation) {
+	if dto == nil {
+		return
+	}
+
+	resp, err := s.seoRepo.RefreshPageTranslation(ctx, dto.GetId())
+	if err != nil {
+		s.log.Errorf("seo analysis of page translation [%d] failed: %v", dto.GetId(), err)
+		return
+	}
+	dto.Seo = resp.GetSeo()
+	dto.SeoAnalysis = resp.GetAnalysis()
+}
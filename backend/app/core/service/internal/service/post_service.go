@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"slices"
 	"strconv"
 
 	"github.com/go-kratos/kratos/v2/log"
@@ -12,6 +13,8 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"go-wind-cms/app/core/service/internal/data"
+	"go-wind-cms/pkg/content/preview"
+	"go-wind-cms/pkg/content/related"
 	"go-wind-cms/pkg/task"
 
 	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
@@ -20,18 +23,32 @@ import (
 type PostService struct {
 	contentV1.UnimplementedPostServiceServer
 
-	postRepo      *data.PostRepo
-	searchService *SearchService
-	taskService   *TaskService
-	log           *log.Helper
+	postRepo           *data.PostRepo
+	trashRepo          *data.TrashRepo
+	previewTokenRepo   *data.PreviewTokenRepo
+	relatedPostRepo    *data.RelatedPostRepo
+	searchService      *SearchService
+	taskService        *TaskService
+	aiAssistService    *AiAssistService
+	seoRepo            *data.SeoRepo
+	syndicationRepo    *data.SyndicationRepo
+	structuredDataRepo *data.StructuredDataRepo
+	log                *log.Helper
 }
 
-func NewPostService(ctx *bootstrap.Context, uc *data.PostRepo, searchService *SearchService, taskService *TaskService) *PostService {
+func NewPostService(ctx *bootstrap.Context, uc *data.PostRepo, trashRepo *data.TrashRepo, previewTokenRepo *data.PreviewTokenRepo, relatedPostRepo *data.RelatedPostRepo, searchService *SearchService, taskService *TaskService, aiAssistService *AiAssistService, seoRepo *data.SeoRepo, syndicationRepo *data.SyndicationRepo, structuredDataRepo *data.StructuredDataRepo) *PostService {
 	return &PostService{
-		log:           ctx.NewLoggerHelper("post/service/core-service"),
-		postRepo:      uc,
-		searchService: searchService,
-		taskService:   taskService,
+		log:                ctx.NewLoggerHelper("post/service/core-service"),
+		postRepo:           uc,
+		trashRepo:          trashRepo,
+		previewTokenRepo:   previewTokenRepo,
+		relatedPostRepo:    relatedPostRepo,
+		searchService:      searchService,
+		taskService:        taskService,
+		aiAssistService:    aiAssistService,
+		seoRepo:            seoRepo,
+		syndicationRepo:    syndicationRepo,
+		structuredDataRepo: structuredDataRepo,
 	}
 }
 
@@ -88,8 +105,71 @@ func (s *PostService) SearchPosts(ctx context.Context, req *contentV1.SearchPost
 	return resp, nil
 }
 
+// ListRelatedPosts 前台相关文章推荐，排序与缓存见 RelatedPostRepo。
+func (s *PostService) ListRelatedPosts(ctx context.Context, req *contentV1.ListRelatedPostsRequest) (*contentV1.ListRelatedPostsResponse, error) {
+	if req == nil || req.GetPostId() == 0 {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	ids, err := s.relatedPostRepo.Rank(ctx, req.GetPostId(), req.GetLocale())
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.postRepo.ListPublishedByIDs(ctx, ids, req.GetLocale(), related.ClampLimit(req.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &contentV1.ListRelatedPostsResponse{Items: items}, nil
+}
+
 func (s *PostService) Get(ctx context.Context, req *contentV1.GetPostRequest) (*contentV1.Post, error) {
-	return s.postRepo.Get(ctx, req)
+	dto, err := s.get(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 前台按需附带 JSON-LD 结构化数据；生成失败时不返回该字段，不影响详情读取
+	if req.GetIncludeJsonLd() {
+		if ld := s.structuredDataRepo.Post(ctx, req.GetHost(), dto); ld != "" {
+			dto.JsonLd = trans.Ptr(ld)
+		}
+	}
+
+	return dto, nil
+}
+
+// get 读取详情；携带预览令牌时校验令牌后放行草稿
+func (s *PostService) get(ctx context.Context, req *contentV1.GetPostRequest) (*contentV1.Post, error) {
+	if req.GetPreviewToken() == "" {
+		return s.postRepo.Get(ctx, req)
+	}
+
+	// 草稿预览：令牌锁定语言时以令牌为准，读取后再校验吊销状态、租户与修订
+	claims, err := s.previewTokenRepo.Parse(req.GetPreviewToken(), preview.KindPost)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Locale != "" {
+		req.Locale = trans.Ptr(claims.Locale)
+	}
+
+	dto, err := s.postRepo.Get(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.previewTokenRepo.Authorize(ctx, claims, dto.GetId()); err != nil {
+		return nil, err
+	}
+
+	dto.IsPreview = trans.Ptr(true)
+	return dto, nil
+}
+
+// UnlockPost 校验受保护帖子的访问密码并签发解锁令牌，限流与校验均在 PostRepo.Unlock 内完成。
+func (s *PostService) UnlockPost(ctx context.Context, req *contentV1.UnlockPostRequest) (*contentV1.UnlockPostResponse, error) {
+	return s.postRepo.Unlock(ctx, req)
 }
 
 func (s *PostService) Create(ctx context.Context, req *contentV1.CreatePostRequest) (*contentV1.Post, error) {
@@ -102,15 +182,30 @@ func (s *PostService) Create(ctx context.Context, req *contentV1.CreatePostReque
 		req.Data.Status = trans.Ptr(contentV1.Post_POST_STATUS_DRAFT)
 	}
 
+	// 保存前记录需要 AI 摘要的语言：保存时未填写的摘要会先按规则生成
+	autoSummaryLanguages := make([]string, 0, len(req.Data.GetTranslations()))
+	for _, t := range req.Data.GetTranslations() {
+		if autoSummaryRequested(t, nil) {
+			autoSummaryLanguages = append(autoSummaryLanguages, t.GetLanguageCode())
+		}
+	}
+
 	dto, err := s.postRepo.Create(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
+	// 补全各语言版本缺失的 SEO 字段并计算 SEO 得分
+	s.seoRepo.RefreshPost(ctx, dto.GetId())
+
 	// 双写钩子：事务提交成功后，入队 ES 重索引。
 	// best-effort：失败仅记日志，不回滚 DB；漏掉的文档由周期 ReindexAll 修复。
 	s.enqueuePostReindex(ctx, dto.GetId(), "index")
 
+	for _, lang := range autoSummaryLanguages {
+		s.aiAssistService.EnqueueAutoSummary(ctx, dto.GetId(), lang)
+	}
+
 	return dto, nil
 }
 
@@ -120,6 +215,8 @@ func (s *PostService) Update(ctx context.Context, req *contentV1.UpdatePostReque
 		return nil, err
 	}
 
+	s.seoRepo.RefreshPost(ctx, dto.GetId())
+
 	// 双写钩子：更新后入队 ES 重索引（worker 会从 DB 取最新数据 upsert ES）。
 	s.enqueuePostReindex(ctx, dto.GetId(), "index")
 
@@ -127,12 +224,12 @@ func (s *PostService) Update(ctx context.Context, req *contentV1.UpdatePostReque
 }
 
 func (s *PostService) Delete(ctx context.Context, req *contentV1.DeletePostRequest) (*emptypb.Empty, error) {
-	err := s.postRepo.Delete(ctx, req)
-	if err != nil {
+	// 移入回收站（状态置为已删除），彻底删除经 TrashService 完成
+	if err := s.trashRepo.Trash(ctx, contentV1.TrashItem_ENTITY_TYPE_POST, req.GetId()); err != nil {
 		return nil, err
 	}
 
-	// 双写钩子：删除后入队 ES 删除（worker 按 post_id 删 ES 所有语言文档）。
+	// 双写钩子：移入回收站后入队 ES 删除（worker 按 post_id 删 ES 所有语言文档），恢复时重新索引。
 	s.enqueuePostReindex(ctx, req.GetId(), "delete")
 
 	return &emptypb.Empty{}, nil
@@ -154,6 +251,8 @@ func (s *PostService) GetTranslation(ctx context.Context, req *contentV1.GetPost
 }
 
 func (s *PostService) CreateTranslation(ctx context.Context, req *contentV1.CreatePostTranslationRequest) (*contentV1.PostTranslation, error) {
+	autoSummary := autoSummaryRequested(req.GetData(), nil)
+
 	dto, err := s.postRepo.CreateTranslation(ctx, req)
 	if err != nil {
 		return nil, err
@@ -161,20 +260,30 @@ func (s *PostService) CreateTranslation(ctx context.Context, req *contentV1.Crea
 
 	// 翻译内容变更影响 ES 文档（title/summary/content），入队重索引。
 	if dto != nil {
+		s.refreshTranslationSeo(ctx, dto)
 		s.enqueuePostReindex(ctx, dto.GetPostId(), "index")
+		if autoSummary {
+			s.aiAssistService.EnqueueAutoSummary(ctx, dto.GetPostId(), dto.GetLanguageCode())
+		}
 	}
 
 	return dto, nil
 }
 
 func (s *PostService) UpdateTranslation(ctx context.Context, req *contentV1.UpdatePostTranslationRequest) (*contentV1.PostTranslation, error) {
+	autoSummary := autoSummaryRequested(req.GetData(), req.GetUpdateMask().GetPaths())
+
 	dto, err := s.postRepo.UpdateTranslation(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
 	if dto != nil {
+		s.refreshTranslationSeo(ctx, dto)
 		s.enqueuePostReindex(ctx, dto.GetPostId(), "index")
+		if autoSummary {
+			s.aiAssistService.EnqueueAutoSummary(ctx, dto.GetPostId(), dto.GetLanguageCode())
+		}
 	}
 
 	return dto, nil
@@ -200,10 +309,35 @@ func (s *PostService) DeleteTranslation(ctx context.Context, req *contentV1.Dele
 	return &emptypb.Empty{}, nil
 }
 
+// autoSummaryRequested 请求是否写入了正文且摘要留空，此时由 AI 生成摘要（文章须开启 auto_summary）。
+// 带 update_mask 的更新须同时包含 content 与 summary，只改正文的更新不替换已有摘要。
+func autoSummaryRequested(t *contentV1.PostTranslation, paths []string) bool {
+	if t == nil || t.GetContent() == "" || t.GetSummary() != "" {
+		return false
+	}
+	if len(paths) == 0 {
+		return true
+	}
+	return slices.Contains(paths, "content") && slices.Contains(paths, "summary")
+}
+
+// refreshTranslationSeo 保存语言版本后补全 SEO 字段并计算得分，结果随返回值带回；
+// best-effort：失败仅记日志，不影响保存结果。
+func (s *PostService) refreshTranslationSeo(ctx context.Context, dto *contentV1.PostTranslation) {
+	resp, err := s.seoRepo.RefreshPostTranslation(ctx, dto.GetId())
+	if err != nil {
+		s.log.Errorf("seo analysis of post translation [%d] failed: %v", dto.GetId(), err)
+		return
+	}
+	dto.Seo = resp.GetSeo()
+	dto.SeoAnalysis = resp.GetAnalysis()
+}
+
 // enqueuePostReindex 是双写钩子的统一入口。
 //
 // 从 viewer context 取 tenant_id（仅用于 payload 日志辅助），构造
 // SearchReindexPayload 并调 TaskService.EnqueueSearchReindex 入队。
+// 同时清除该文章的相关文章缓存与所在租户的站点地图、订阅源缓存。
 //
 // 安全：
 //   - payload 的 TenantID 仅日志用，ES 文档 tenant_id 由 worker 从 DB 取
@@ -215,6 +349,11 @@ func (s *PostService) enqueuePostReindex(ctx context.Context, postID uint32, op
 		return
 	}
 
+	// 文章内容、分类/标签或状态变化都会影响其相关文章，清除缓存
+	s.relatedPostRepo.Invalidate(ctx, postID)
+	// 发布、下线与内容变更都会反映到站点地图与订阅源
+	s.syndicationRepo.Invalidate(ctx)
+
 	// tenant_id 仅用于日志，取自 viewer（与 internal_message_service 同模式）
 	var tenantID uint32
 	if vc, exist := viewer.FromContext(ctx); exist && vc != nil {
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"go-wind-cms/app/core/service/internal/data"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+)
+
+// PreviewService 草稿预览令牌的签发、列表与吊销。
+//
+// 凭令牌读取草稿由 PostService.Get / PageService.Get 的 preview_token 完成。
+type PreviewService struct {
+	contentV1.UnimplementedPreviewServiceServer
+
+	previewTokenRepo *data.PreviewTokenRepo
+
+	log *log.Helper
+}
+
+func NewPreviewService(ctx *bootstrap.Context, previewTokenRepo *data.PreviewTokenRepo) *PreviewService {
+	return &PreviewService{
+		log:              ctx.NewLoggerHelper("preview/service/core-service"),
+		previewTokenRepo: previewTokenRepo,
+	}
+}
+
+func (s *PreviewService) CreatePreviewToken(ctx context.Context, req *contentV1.CreatePreviewTokenRequest) (*contentV1.CreatePreviewTokenResponse, error) {
+	return s.previewTokenRepo.Create(ctx, req)
+}
+
+func (s *PreviewService) ListPreviewTokens(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListPreviewTokenResponse, error) {
+	return s.previewTokenRepo.List(ctx, req)
+}
+
+func (s *PreviewService) RevokePreviewToken(ctx context.Context, req *contentV1.RevokePreviewTokenRequest) (*emptypb.Empty, error) {
+	if err := s.previewTokenRepo.Revoke(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -51,6 +51,26 @@ var ProviderSet = wire.NewSet(
 	service.NewTagService,
 	service.NewPageService,
 	service.NewSectionService,
+	service.NewRedirectService,
+	service.NewRouteService,
+	service.NewFieldGroupService,
+	service.NewContentModelService,
+	service.NewContentEntryService,
+	service.NewWorkflowService,
+	service.NewEditorialService,
+	service.NewTrashService,
+	service.NewPreviewService,
+	service.NewReleaseService,
+	service.NewFormService,
+	service.NewTranslationJobService,
+	service.NewTranslationMemoryService,
+	service.NewGlossaryService,
+	service.NewTranslationDashboardService,
+	service.NewTranslatorService,
+	service.NewAiAssistService,
+	service.NewSeoService,
+	service.NewSyndicationService,
+	service.NewImportJobService,
 
 	// OpenSearch 搜索与重索引服务。
 	// 消费 data.SearchRepo + data.PostRepo，使 wire 真正连通 ES 注入链。
@@ -60,6 +80,8 @@ var ProviderSet = wire.NewSet(
 
 	service.NewInteractionService,
 	service.NewInteractionAdminService,
+	service.NewCommentModerationService,
+	service.NewCommentNotificationService,
 
 	service.NewMediaAssetService,
 
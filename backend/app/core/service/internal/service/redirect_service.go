@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"go-wind-cms/app/core/service/internal/data"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+)
+
+type RedirectService struct {
+	contentV1.UnimplementedRedirectServiceServer
+
+	redirectRepo *data.RedirectRepo
+	log          *log.Helper
+}
+
+func NewRedirectService(ctx *bootstrap.Context, uc *data.RedirectRepo) *RedirectService {
+	return &RedirectService{
+		log:          ctx.NewLoggerHelper("redirect/service/core-service"),
+		redirectRepo: uc,
+	}
+}
+
+func (s *RedirectService) List(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListRedirectResponse, error) {
+	return s.redirectRepo.List(ctx, req)
+}
+
+func (s *RedirectService) Get(ctx context.Context, req *contentV1.GetRedirectRequest) (*contentV1.Redirect, error) {
+	return s.redirectRepo.Get(ctx, req)
+}
+
+func (s *RedirectService) Create(ctx context.Context, req *contentV1.CreateRedirectRequest) (*contentV1.Redirect, error) {
+	return s.redirectRepo.Create(ctx, req)
+}
+
+func (s *RedirectService) Update(ctx context.Context, req *contentV1.UpdateRedirectRequest) (*contentV1.Redirect, error) {
+	return s.redirectRepo.Update(ctx, req)
+}
+
+func (s *RedirectService) Delete(ctx context.Context, req *contentV1.DeleteRedirectRequest) (*emptypb.Empty, error) {
+	err := s.redirectRepo.Delete(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"go-wind-cms/app/core/service/internal/data"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	appViewer "go-wind-cms/pkg/entgo/viewer"
+	"go-wind-cms/pkg/task"
+)
+
+// ReleaseService 发布集：将多条内容的变更打包，预览后立即或定时整体发布，并可整体回滚。
+//
+// 发布与回滚后重新索引涉及的文章，并清除 RSS/Sitemap 缓存。
+type ReleaseService struct {
+	contentV1.UnimplementedReleaseServiceServer
+
+	releaseRepo     *data.ReleaseRepo
+	syndicationRepo *data.SyndicationRepo
+	postService     *PostService
+
+	log *log.Helper
+}
+
+func NewReleaseService(
+	ctx *bootstrap.Context,
+	releaseRepo *data.ReleaseRepo,
+	syndicationRepo *data.SyndicationRepo,
+	postService *PostService,
+) *ReleaseService {
+	return &ReleaseService{
+		log:             ctx.NewLoggerHelper("release/service/core-service"),
+		releaseRepo:     releaseRepo,
+		syndicationRepo: syndicationRepo,
+		postService:     postService,
+	}
+}
+
+func (s *ReleaseService) ListReleases(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListReleaseResponse, error) {
+	return s.releaseRepo.List(ctx, req)
+}
+
+func (s *ReleaseService) GetRelease(ctx context.Context, req *contentV1.GetReleaseRequest) (*contentV1.Release, error) {
+	return s.releaseRepo.Get(ctx, req.GetId())
+}
+
+func (s *ReleaseService) CreateRelease(ctx context.Context, req *contentV1.CreateReleaseRequest) (*contentV1.Release, error) {
+	return s.releaseRepo.Create(ctx, req)
+}
+
+func (s *ReleaseService) UpdateRelease(ctx context.Context, req *contentV1.UpdateReleaseRequest) (*contentV1.Release, error) {
+	return s.releaseRepo.Update(ctx, req)
+}
+
+func (s *ReleaseService) DeleteRelease(ctx context.Context, req *contentV1.DeleteReleaseRequest) (*emptypb.Empty, error) {
+	if err := s.releaseRepo.Delete(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ReleaseService) SetReleaseItem(ctx context.Context, req *contentV1.SetReleaseItemRequest) (*contentV1.ReleaseItem, error) {
+	return s.releaseRepo.SetItem(ctx, req)
+}
+
+func (s *ReleaseService) RemoveReleaseItem(ctx context.Context, req *contentV1.RemoveReleaseItemRequest) (*emptypb.Empty, error) {
+	if err := s.releaseRepo.RemoveItem(ctx, req.GetReleaseId(), req.GetItemId()); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ReleaseService) PreviewRelease(ctx context.Context, req *contentV1.PreviewReleaseRequest) (*contentV1.PreviewReleaseResponse, error) {
+	return s.releaseRepo.Preview(ctx, req.GetId())
+}
+
+func (s *ReleaseService) ScheduleRelease(ctx context.Context, req *contentV1.ScheduleReleaseRequest) (*contentV1.Release, error) {
+	var at *time.Time
+	if req.ScheduledAt != nil {
+		t := req.GetScheduledAt().AsTime()
+		at = &t
+	}
+	return s.releaseRepo.Schedule(ctx, req.GetId(), at)
+}
+
+func (s *ReleaseService) PublishRelease(ctx context.Context, req *contentV1.PublishReleaseRequest) (*contentV1.Release, error) {
+	resp, postIDs, err := s.releaseRepo.Publish(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	s.reindexPosts(ctx, postIDs)
+	s.syndicationRepo.Invalidate(ctx)
+
+	return resp, nil
+}
+
+func (s *ReleaseService) RollbackRelease(ctx context.Context, req *contentV1.RollbackReleaseRequest) (*contentV1.Release, error) {
+	resp, postIDs, err := s.releaseRepo.Rollback(ctx, req.GetId(), req.GetForce())
+	if err != nil {
+		return nil, err
+	}
+
+	s.reindexPosts(ctx, postIDs)
+	s.syndicationRepo.Invalidate(ctx)
+
+	return resp, nil
+}
+
+// reindexPosts 发布状态等变更后重新索引文章（非已发布状态由 worker 从索引移除）
+func (s *ReleaseService) reindexPosts(ctx context.Context, postIDs []uint32) {
+	for _, id := range uniqueIDs(postIDs) {
+		s.postService.enqueuePostReindex(ctx, id, "index")
+	}
+}
+
+// PublishDue 是 asynq "release.publish" 周期任务的 worker handler，发布到期的定时发布集。
+//
+// 单个发布集失败时记录原因并转为失败状态，不影响其余发布集。
+func (s *ReleaseService) PublishDue(taskType string, _ *task.ReleasePublishTaskData) error {
+	// 注入 SystemViewer：跨租户发布
+	ctx := appViewer.NewSystemViewerContext(context.Background())
+
+	ids, err := s.releaseRepo.ListDue(ctx, time.Now())
+	if err != nil {
+		s.log.Errorf("[%s] list due releases failed: %v", taskType, err)
+		return err
+	}
+
+	published := false
+	for _, id := range ids {
+		_, postIDs, err := s.releaseRepo.Publish(ctx, id)
+		if err != nil {
+			s.log.Errorf("[%s] publish release %d failed: %v", taskType, id, err)
+			if markErr := s.releaseRepo.MarkFailed(ctx, id, err); markErr != nil {
+				s.log.Errorf("[%s] mark release %d failed: %v", taskType, id, markErr)
+			}
+			continue
+		}
+
+		s.reindexPosts(ctx, postIDs)
+		s.log.Infof("[%s] published release %d", taskType, id)
+		published = true
+	}
+
+	// SystemViewer 无租户上下文，清除全部租户的缓存
+	if published {
+		s.syndicationRepo.Invalidate(ctx)
+	}
+
+	return nil
+}
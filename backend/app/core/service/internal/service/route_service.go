@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+)
+
+type RouteService struct {
+	contentV1.UnimplementedRouteServiceServer
+
+	routeRepo *data.RouteRepo
+	log       *log.Helper
+}
+
+func NewRouteService(ctx *bootstrap.Context, uc *data.RouteRepo) *RouteService {
+	return &RouteService{
+		log:       ctx.NewLoggerHelper("route/service/core-service"),
+		routeRepo: uc,
+	}
+}
+
+func (s *RouteService) ResolvePath(ctx context.Context, req *contentV1.ResolvePathRequest) (*contentV1.ResolvePathResponse, error) {
+	return s.routeRepo.ResolvePath(ctx, req)
+}
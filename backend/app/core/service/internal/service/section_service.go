@@ -17,13 +17,15 @@ type SectionService struct {
 	contentV1.UnimplementedSectionServiceServer
 
 	sectionRepo *data.SectionRepo
+	trashRepo   *data.TrashRepo
 	log         *log.Helper
 }
 
-func NewSectionService(ctx *bootstrap.Context, uc *data.SectionRepo) *SectionService {
+func NewSectionService(ctx *bootstrap.Context, uc *data.SectionRepo, trashRepo *data.TrashRepo) *SectionService {
 	return &SectionService{
 		log:         ctx.NewLoggerHelper("section/service/core-service"),
 		sectionRepo: uc,
+		trashRepo:   trashRepo,
 	}
 }
 
@@ -44,13 +46,17 @@ func (s *SectionService) Update(ctx context.Context, req *contentV1.UpdateSectio
 }
 
 func (s *SectionService) Delete(ctx context.Context, req *contentV1.DeleteSectionRequest) (*emptypb.Empty, error) {
-	err := s.sectionRepo.Delete(ctx, req)
-	if err != nil {
+	// 移入回收站，彻底删除经 TrashService 完成
+	if err := s.trashRepo.Trash(ctx, contentV1.TrashItem_ENTITY_TYPE_SECTION, req.GetId()); err != nil {
 		return nil, err
 	}
 	return &emptypb.Empty{}, nil
 }
 
+func (s *SectionService) ReorderSections(ctx context.Context, req *contentV1.ReorderSectionsRequest) (*contentV1.ListSectionResponse, error) {
+	return s.sectionRepo.Reorder(ctx, req.GetPageId(), req.GetIds())
+}
+
 func (s *SectionService) TranslationExists(ctx context.Context, req *contentV1.SectionTranslationExistsRequest) (*contentV1.SectionTranslationExistsResponse, error) {
 	exists, err := s.sectionRepo.TranslationExists(ctx, req.GetSectionId(), req.GetLanguageCode())
 	if err != nil {
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+)
+
+// SeoService SEO 分析：保存文章、页面时由对应服务自动触发，AnalyzeSeo 按需重新分析。
+type SeoService struct {
+	contentV1.UnimplementedSeoServiceServer
+
+	log *log.Helper
+
+	seoRepo *data.SeoRepo
+}
+
+func NewSeoService(ctx *bootstrap.Context, seoRepo *data.SeoRepo) *SeoService {
+	return &SeoService{
+		log:     ctx.NewLoggerHelper("seo/service/core-service"),
+		seoRepo: seoRepo,
+	}
+}
+
+func (s *SeoService) AnalyzeSeo(ctx context.Context, req *contentV1.AnalyzeSeoRequest) (*contentV1.AnalyzeSeoResponse, error) {
+	if req == nil || req.GetEntityId() == 0 || req.GetLanguageCode() == "" {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	return s.seoRepo.Analyze(ctx, req)
+}
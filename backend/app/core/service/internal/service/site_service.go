@@ -17,13 +17,15 @@ type SiteService struct {
 	siteV1.UnimplementedSiteServiceServer
 
 	siteSettingRepo *data.SiteRepo
+	syndicationRepo *data.SyndicationRepo
 	log             *log.Helper
 }
 
-func NewSiteService(ctx *bootstrap.Context, uc *data.SiteRepo) *SiteService {
+func NewSiteService(ctx *bootstrap.Context, uc *data.SiteRepo, syndicationRepo *data.SyndicationRepo) *SiteService {
 	return &SiteService{
 		log:             ctx.NewLoggerHelper("site/service/core-service"),
 		siteSettingRepo: uc,
+		syndicationRepo: syndicationRepo,
 	}
 }
 
@@ -36,11 +38,27 @@ func (s *SiteService) Get(ctx context.Context, req *siteV1.GetSiteRequest) (*sit
 }
 
 func (s *SiteService) Create(ctx context.Context, req *siteV1.CreateSiteRequest) (*siteV1.Site, error) {
-	return s.siteSettingRepo.Create(ctx, req)
+	dto, err := s.siteSettingRepo.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 域名或默认语言变化会影响站点地图与订阅源中的地址
+	s.syndicationRepo.Invalidate(ctx)
+
+	return dto, nil
 }
 
 func (s *SiteService) Update(ctx context.Context, req *siteV1.UpdateSiteRequest) (*siteV1.Site, error) {
-	return s.siteSettingRepo.Update(ctx, req)
+	dto, err := s.siteSettingRepo.Update(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 域名或默认语言变化会影响站点地图与订阅源中的地址
+	s.syndicationRepo.Invalidate(ctx)
+
+	return dto, nil
 }
 
 func (s *SiteService) Delete(ctx context.Context, req *siteV1.DeleteSiteRequest) (*emptypb.Empty, error) {
@@ -48,5 +66,8 @@ func (s *SiteService) Delete(ctx context.Context, req *siteV1.DeleteSiteRequest)
 	if err != nil {
 		return nil, err
 	}
+
+	s.syndicationRepo.Invalidate(ctx)
+
 	return &emptypb.Empty{}, nil
 }
@@ -17,13 +17,15 @@ type SiteSettingService struct {
 	siteV1.UnimplementedSiteSettingServiceServer
 
 	siteSettingRepo *data.SiteSettingRepo
+	syndicationRepo *data.SyndicationRepo
 	log             *log.Helper
 }
 
-func NewSiteSettingService(ctx *bootstrap.Context, uc *data.SiteSettingRepo) *SiteSettingService {
+func NewSiteSettingService(ctx *bootstrap.Context, uc *data.SiteSettingRepo, syndicationRepo *data.SyndicationRepo) *SiteSettingService {
 	return &SiteSettingService{
 		log:             ctx.NewLoggerHelper("site-setting/service/core-service"),
 		siteSettingRepo: uc,
+		syndicationRepo: syndicationRepo,
 	}
 }
 
@@ -36,11 +38,26 @@ func (s *SiteSettingService) Get(ctx context.Context, req *siteV1.GetSiteSetting
 }
 
 func (s *SiteSettingService) Create(ctx context.Context, req *siteV1.CreateSiteSettingRequest) (*siteV1.SiteSetting, error) {
-	return s.siteSettingRepo.Create(ctx, req)
+	dto, err := s.siteSettingRepo.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// robots.txt 与订阅源标题取自站点设置
+	s.syndicationRepo.Invalidate(ctx)
+
+	return dto, nil
 }
 
 func (s *SiteSettingService) Update(ctx context.Context, req *siteV1.UpdateSiteSettingRequest) (*siteV1.SiteSetting, error) {
-	return s.siteSettingRepo.Update(ctx, req)
+	dto, err := s.siteSettingRepo.Update(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.syndicationRepo.Invalidate(ctx)
+
+	return dto, nil
 }
 
 func (s *SiteSettingService) Delete(ctx context.Context, req *siteV1.DeleteSiteSettingRequest) (*emptypb.Empty, error) {
@@ -48,5 +65,8 @@ func (s *SiteSettingService) Delete(ctx context.Context, req *siteV1.DeleteSiteS
 	if err != nil {
 		return nil, err
 	}
+
+	s.syndicationRepo.Invalidate(ctx)
+
 	return &emptypb.Empty{}, nil
 }
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+)
+
+// SyndicationService 站点地图、RSS/Atom 订阅源与 robots.txt，供 app 前台按 Host 输出。
+type SyndicationService struct {
+	contentV1.UnimplementedSyndicationServiceServer
+
+	log *log.Helper
+
+	syndicationRepo *data.SyndicationRepo
+}
+
+func NewSyndicationService(ctx *bootstrap.Context, syndicationRepo *data.SyndicationRepo) *SyndicationService {
+	return &SyndicationService{
+		log:             ctx.NewLoggerHelper("syndication/service/core-service"),
+		syndicationRepo: syndicationRepo,
+	}
+}
+
+func (s *SyndicationService) GetSitemap(ctx context.Context, req *contentV1.GetSitemapRequest) (*contentV1.SyndicationDocument, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	return s.syndicationRepo.GetSitemap(ctx, req)
+}
+
+func (s *SyndicationService) GetFeed(ctx context.Context, req *contentV1.GetFeedRequest) (*contentV1.SyndicationDocument, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	return s.syndicationRepo.GetFeed(ctx, req)
+}
+
+func (s *SyndicationService) GetRobots(ctx context.Context, req *contentV1.GetRobotsRequest) (*contentV1.SyndicationDocument, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	return s.syndicationRepo.GetRobots(ctx, req)
+}
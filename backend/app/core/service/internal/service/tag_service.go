@@ -16,14 +16,18 @@ import (
 type TagService struct {
 	contentV1.UnimplementedTagServiceServer
 
-	tagRepo *data.TagRepo
-	log     *log.Helper
+	tagRepo         *data.TagRepo
+	trashRepo       *data.TrashRepo
+	syndicationRepo *data.SyndicationRepo
+	log             *log.Helper
 }
 
-func NewTagService(ctx *bootstrap.Context, uc *data.TagRepo) *TagService {
+func NewTagService(ctx *bootstrap.Context, uc *data.TagRepo, trashRepo *data.TrashRepo, syndicationRepo *data.SyndicationRepo) *TagService {
 	return &TagService{
-		log:     ctx.NewLoggerHelper("tag/service/core-service"),
-		tagRepo: uc,
+		log:             ctx.NewLoggerHelper("tag/service/core-service"),
+		tagRepo:         uc,
+		trashRepo:       trashRepo,
+		syndicationRepo: syndicationRepo,
 	}
 }
 
@@ -36,18 +40,35 @@ func (s *TagService) Get(ctx context.Context, req *contentV1.GetTagRequest) (*co
 }
 
 func (s *TagService) Create(ctx context.Context, req *contentV1.CreateTagRequest) (*contentV1.Tag, error) {
-	return s.tagRepo.Create(ctx, req)
+	dto, err := s.tagRepo.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.syndicationRepo.Invalidate(ctx)
+
+	return dto, nil
 }
 
 func (s *TagService) Update(ctx context.Context, req *contentV1.UpdateTagRequest) (*contentV1.Tag, error) {
-	return s.tagRepo.Update(ctx, req)
+	dto, err := s.tagRepo.Update(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.syndicationRepo.Invalidate(ctx)
+
+	return dto, nil
 }
 
 func (s *TagService) Delete(ctx context.Context, req *contentV1.DeleteTagRequest) (*emptypb.Empty, error) {
-	err := s.tagRepo.Delete(ctx, req)
-	if err != nil {
+	// 移入回收站，彻底删除经 TrashService 完成
+	if err := s.trashRepo.Trash(ctx, contentV1.TrashItem_ENTITY_TYPE_TAG, req.GetId()); err != nil {
 		return nil, err
 	}
+
+	s.syndicationRepo.Invalidate(ctx)
+
 	return &emptypb.Empty{}, nil
 }
 
@@ -67,11 +88,25 @@ func (s *TagService) GetTranslation(ctx context.Context, req *contentV1.GetTagRe
 }
 
 func (s *TagService) CreateTranslation(ctx context.Context, req *contentV1.CreateTagTranslationRequest) (*contentV1.TagTranslation, error) {
-	return s.tagRepo.CreateTranslation(ctx, req)
+	dto, err := s.tagRepo.CreateTranslation(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.syndicationRepo.Invalidate(ctx)
+
+	return dto, nil
 }
 
 func (s *TagService) UpdateTranslation(ctx context.Context, req *contentV1.UpdateTagTranslationRequest) (*contentV1.TagTranslation, error) {
-	return s.tagRepo.UpdateTranslation(ctx, req)
+	dto, err := s.tagRepo.UpdateTranslation(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.syndicationRepo.Invalidate(ctx)
+
+	return dto, nil
 }
 
 func (s *TagService) DeleteTranslation(ctx context.Context, req *contentV1.DeleteTagTranslationRequest) (*emptypb.Empty, error) {
@@ -79,5 +114,8 @@ func (s *TagService) DeleteTranslation(ctx context.Context, req *contentV1.Delet
 	if err != nil {
 		return nil, err
 	}
+
+	s.syndicationRepo.Invalidate(ctx)
+
 	return &emptypb.Empty{}, nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/hibiken/asynq"
@@ -463,3 +464,96 @@ func (s *TaskService) EnqueueSearchReindex(payload *task.SearchReindexPayload) e
 		payload.Entity, payload.ID, payload.Op)
 	return nil
 }
+
+// EnqueueCommentNotify 入队一个评论通知任务。
+//
+// 由 CommentService / CommentModerationService 在评论变为“已通过”后调用。
+// 入队是 best-effort：失败仅记日志，不阻断评论的创建或审核。
+func (s *TaskService) EnqueueCommentNotify(payload *task.CommentNotifyPayload) error {
+	if payload == nil {
+		return errors.New("nil comment notify payload")
+	}
+	if s.taskScheduler == nil {
+		s.log.Warnf("comment notify skipped: task scheduler not available (comment_id=%d)", payload.CommentID)
+		return nil
+	}
+	if err := s.taskScheduler.NewTask(task.CommentNotifyTaskType, payload); err != nil {
+		s.log.Errorf("enqueue comment notify failed (comment_id=%d): %v", payload.CommentID, err)
+		return err
+	}
+	return nil
+}
+
+// EnqueueFormNotify 入队一个表单提交通知任务。
+//
+// 由 FormService 在提交落库后调用。入队是 best-effort：失败仅记日志，不影响提交结果。
+func (s *TaskService) EnqueueFormNotify(payload *task.FormNotifyPayload) error {
+	if payload == nil {
+		return errors.New("nil form notify payload")
+	}
+	if s.taskScheduler == nil {
+		s.log.Warnf("form notify skipped: task scheduler not available (submission_id=%d)", payload.SubmissionID)
+		return nil
+	}
+	if err := s.taskScheduler.NewTask(task.FormNotifyTaskType, payload); err != nil {
+		s.log.Errorf("enqueue form notify failed (submission_id=%d): %v", payload.SubmissionID, err)
+		return err
+	}
+	return nil
+}
+
+// EnqueueTranslationJob 入队一个批量机器翻译任务。
+//
+// 由 TranslationJobService 在作业创建后调用。与其他通知类任务不同，调度器不可用时返回错误，
+// 否则作业将一直停留在等待状态。
+func (s *TaskService) EnqueueTranslationJob(payload *task.TranslationJobPayload) error {
+	if payload == nil {
+		return errors.New("nil translation job payload")
+	}
+	if s.taskScheduler == nil {
+		s.log.Warnf("translation job skipped: task scheduler not available (job_id=%d)", payload.JobID)
+		return errors.New("task scheduler not available")
+	}
+	if err := s.taskScheduler.NewTask(task.TranslationJobTaskType, payload); err != nil {
+		s.log.Errorf("enqueue translation job failed (job_id=%d): %v", payload.JobID, err)
+		return err
+	}
+	return nil
+}
+
+// EnqueueContentImport 入队一个内容导入任务。
+//
+// 由 ImportJobService 在作业创建后调用。调度器不可用时返回错误，由调用方把作业置为失败。
+// 大站点的导入需下载大量附件，执行超时与作业的过期认领时间一致。
+func (s *TaskService) EnqueueContentImport(payload *task.ContentImportPayload) error {
+	if payload == nil {
+		return errors.New("nil content import payload")
+	}
+	if s.taskScheduler == nil {
+		s.log.Warnf("content import skipped: task scheduler not available (job_id=%d)", payload.JobID)
+		return errors.New("task scheduler not available")
+	}
+	if err := s.taskScheduler.NewTask(task.ContentImportTaskType, payload, asynq.Timeout(2*time.Hour)); err != nil {
+		s.log.Errorf("enqueue content import failed (job_id=%d): %v", payload.JobID, err)
+		return err
+	}
+	return nil
+}
+
+// EnqueueAiAssistJob 入队一个 AI 内容辅助任务。
+//
+// 由 AiAssistService 在作业创建后调用。调度器不可用时返回错误，由调用方把作业置为失败。
+func (s *TaskService) EnqueueAiAssistJob(payload *task.AiAssistPayload) error {
+	if payload == nil {
+		return errors.New("nil ai assist payload")
+	}
+	if s.taskScheduler == nil {
+		s.log.Warnf("ai assist job skipped: task scheduler not available (job_id=%d)", payload.JobID)
+		return errors.New("task scheduler not available")
+	}
+	if err := s.taskScheduler.NewTask(task.AiAssistTaskType, payload); err != nil {
+		s.log.Errorf("enqueue ai assist job failed (job_id=%d): %v", payload.JobID, err)
+		return err
+	}
+	return nil
+}
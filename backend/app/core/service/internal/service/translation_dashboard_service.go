@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+)
+
+// TranslationDashboardService 翻译看板：覆盖率统计与待处理语言版本
+type TranslationDashboardService struct {
+	contentV1.UnimplementedTranslationDashboardServiceServer
+
+	log *log.Helper
+
+	translationDashboardRepo *data.TranslationDashboardRepo
+}
+
+func NewTranslationDashboardService(ctx *bootstrap.Context, translationDashboardRepo *data.TranslationDashboardRepo) *TranslationDashboardService {
+	return &TranslationDashboardService{
+		log:                      ctx.NewLoggerHelper("translation-dashboard/service/core-service"),
+		translationDashboardRepo: translationDashboardRepo,
+	}
+}
+
+func (s *TranslationDashboardService) GetTranslationDashboard(ctx context.Context, req *contentV1.GetTranslationDashboardRequest) (*contentV1.TranslationDashboard, error) {
+	return s.translationDashboardRepo.GetDashboard(ctx, req)
+}
+
+func (s *TranslationDashboardService) ListTranslationWorkItems(ctx context.Context, req *contentV1.ListTranslationWorkItemsRequest) (*contentV1.ListTranslationWorkItemsResponse, error) {
+	return s.translationDashboardRepo.ListWorkItems(ctx, req)
+}
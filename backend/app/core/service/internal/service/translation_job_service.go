@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/go-utils/trans"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data"
+	"go-wind-cms/app/core/service/internal/data/ent"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/mt"
+	appViewer "go-wind-cms/pkg/entgo/viewer"
+	"go-wind-cms/pkg/task"
+)
+
+// TranslationJobService 机器翻译作业：创建作业并入队 translation.job 任务，
+// worker 逐个目标语言翻译源语言版本并写入机器翻译草稿，每处理完一个语言更新一次进度。
+type TranslationJobService struct {
+	contentV1.UnimplementedTranslationJobServiceServer
+
+	log *log.Helper
+
+	translationJobRepo *data.TranslationJobRepo
+	taskService        *TaskService
+	machineTranslator  *data.MachineTranslator
+}
+
+func NewTranslationJobService(
+	ctx *bootstrap.Context,
+	translationJobRepo *data.TranslationJobRepo,
+	taskService *TaskService,
+	machineTranslator *data.MachineTranslator,
+) *TranslationJobService {
+	return &TranslationJobService{
+		log:                ctx.NewLoggerHelper("translation-job/service/core-service"),
+		translationJobRepo: translationJobRepo,
+		taskService:        taskService,
+		machineTranslator:  machineTranslator,
+	}
+}
+
+func (s *TranslationJobService) ListTranslationJobs(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListTranslationJobResponse, error) {
+	return s.translationJobRepo.List(ctx, req)
+}
+
+func (s *TranslationJobService) GetTranslationJob(ctx context.Context, req *contentV1.GetTranslationJobRequest) (*contentV1.TranslationJob, error) {
+	return s.translationJobRepo.Get(ctx, req.GetId())
+}
+
+func (s *TranslationJobService) CreateTranslationJob(ctx context.Context, req *contentV1.CreateTranslationJobRequest) (*contentV1.TranslationJob, error) {
+	job, err := s.translationJobRepo.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.taskService.EnqueueTranslationJob(&task.TranslationJobPayload{JobID: job.GetId()}); err != nil {
+		// 入队失败时作业不会被执行，直接置为失败，避免一直停留在等待状态
+		_ = s.translationJobRepo.Finish(ctx, job.GetId(), nil, err)
+		return nil, contentV1.ErrorInternalServerError("enqueue translation job failed")
+	}
+
+	return job, nil
+}
+
+// RunTranslationJob 处理 translation.job 任务。
+// 签名遵循 (taskType string, payload *T) error 模式（参考 TaskService.AsyncBackup）。
+//
+// 单个语言翻译失败只记入该语言的结果，不影响其他语言；源语言版本已不存在等作业级错误使作业失败。
+func (s *TranslationJobService) RunTranslationJob(_ string, payload *task.TranslationJobPayload) error {
+	if payload == nil || payload.JobID == 0 {
+		s.log.Warnf("translation job: invalid payload")
+		return nil
+	}
+
+	// 注入 SystemViewer：worker 需跨租户读取内容，写入的 tenant_id 取自作业记录
+	ctx := appViewer.NewSystemViewerContext(context.Background())
+
+	job, err := s.translationJobRepo.Claim(ctx, payload.JobID)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		// 已被其他 worker 认领或已结束
+		return nil
+	}
+
+	src, err := s.translationJobRepo.LoadSource(ctx, job)
+	if err != nil {
+		return s.translationJobRepo.Finish(ctx, job.ID, nil, err)
+	}
+
+	results := make([]*contentV1.TranslationJobResult, 0, len(job.TargetLanguages))
+	for _, lang := range job.TargetLanguages {
+		results = append(results, s.translateInto(ctx, job, src, lang))
+		_ = s.translationJobRepo.UpdateProgress(ctx, job.ID, results)
+	}
+
+	s.log.Infof("translation job [%d] finished: %d languages", job.ID, len(results))
+
+	return s.translationJobRepo.Finish(ctx, job.ID, results, nil)
+}
+
+// translateInto 翻译为一个目标语言并写入草稿
+func (s *TranslationJobService) translateInto(ctx context.Context, job *ent.TranslationJob, src *data.TranslationSource, lang string) *contentV1.TranslationJobResult {
+	failed := func(err error) *contentV1.TranslationJobResult {
+		s.log.Warnf("translation job [%d] language [%s] failed: %s", job.ID, lang, err.Error())
+		return &contentV1.TranslationJobResult{
+			Language: lang,
+			Outcome:  contentV1.TranslationJobResult_OUTCOME_FAILED,
+			Error:    trans.Ptr(err.Error()),
+		}
+	}
+
+	// 不可覆盖的目标翻译直接跳过，省去翻译调用
+	existingID, skip, err := s.translationJobRepo.ShouldSkip(ctx, job, lang)
+	if err != nil {
+		return failed(err)
+	}
+	if skip {
+		return &contentV1.TranslationJobResult{
+			Language:      lang,
+			Outcome:       contentV1.TranslationJobResult_OUTCOME_SKIPPED,
+			TranslationId: trans.Ptr(existingID),
+		}
+	}
+
+	// 翻译记忆与术语表按作业所在租户加载
+	fn, err := s.machineTranslator.Session(ctx, trans.Uint32Value(job.TenantID), job.SourceLanguage, lang)
+	if err != nil {
+		return failed(err)
+	}
+	if src, err = translateSource(src, fn); err != nil {
+		return failed(err)
+	}
+
+	result, err := s.translationJobRepo.SaveDraft(ctx, job, lang, src)
+	if err != nil {
+		return failed(err)
+	}
+	return result
+}
+
+// translateSource 翻译源语言版本的文本字段与 SEO 文本字段；正文等 HTML / Markdown 内容保留标签与代码块
+func translateSource(src *data.TranslationSource, fn mt.TranslateFunc) (*data.TranslationSource, error) {
+	out := *src
+	out.Fields = make(map[string]string, len(src.Fields))
+	for name, text := range src.Fields {
+		translated, err := mt.Translate(text, fn)
+		if err != nil {
+			return nil, err
+		}
+		out.Fields[name] = translated
+	}
+
+	if src.Seo != nil {
+		seo := &contentV1.SeoMeta{
+			OgImage: src.Seo.OgImage,
+			// 规范 URL 与语言相关，由编辑审阅时填写
+		}
+		for _, f := range []struct {
+			from *string
+			to   **string
+		}{
+			{src.Seo.SeoTitle, &seo.SeoTitle},
+			{src.Seo.MetaKeywords, &seo.MetaKeywords},
+			{src.Seo.MetaDescription, &seo.MetaDescription},
+			{src.Seo.OgTitle, &seo.OgTitle},
+			{src.Seo.OgDescription, &seo.OgDescription},
+		} {
+			if f.from == nil {
+				continue
+			}
+			translated, err := mt.Translate(*f.from, fn)
+			if err != nil {
+				return nil, err
+			}
+			*f.to = trans.Ptr(translated)
+		}
+		out.Seo = seo
+	}
+
+	return &out, nil
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/go-utils/trans"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"go-wind-cms/app/core/service/internal/data"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	"go-wind-cms/pkg/content/locale"
+	"go-wind-cms/pkg/content/tmx"
+)
+
+const (
+	// maxTmxFileSize 导入的 TMX 文件大小上限
+	maxTmxFileSize = 10 << 20
+
+	// maxTmxImportErrors 导入结果中返回的错误说明上限
+	maxTmxImportErrors = 100
+
+	// tmxMime TMX 文件的 MIME 类型
+	tmxMime = "application/x-tmx+xml"
+)
+
+// TranslationMemoryService 翻译记忆：后台维护与 TMX 导入导出
+type TranslationMemoryService struct {
+	contentV1.UnimplementedTranslationMemoryServiceServer
+
+	log *log.Helper
+
+	translationMemoryRepo *data.TranslationMemoryRepo
+}
+
+func NewTranslationMemoryService(ctx *bootstrap.Context, translationMemoryRepo *data.TranslationMemoryRepo) *TranslationMemoryService {
+	return &TranslationMemoryService{
+		log:                   ctx.NewLoggerHelper("translation-memory/service/core-service"),
+		translationMemoryRepo: translationMemoryRepo,
+	}
+}
+
+func (s *TranslationMemoryService) List(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListTranslationMemoryResponse, error) {
+	return s.translationMemoryRepo.List(ctx, req)
+}
+
+func (s *TranslationMemoryService) Get(ctx context.Context, req *contentV1.GetTranslationMemoryRequest) (*contentV1.TranslationMemory, error) {
+	return s.translationMemoryRepo.Get(ctx, req)
+}
+
+func (s *TranslationMemoryService) Create(ctx context.Context, req *contentV1.CreateTranslationMemoryRequest) (*contentV1.TranslationMemory, error) {
+	return s.translationMemoryRepo.Create(ctx, req)
+}
+
+func (s *TranslationMemoryService) Update(ctx context.Context, req *contentV1.UpdateTranslationMemoryRequest) (*contentV1.TranslationMemory, error) {
+	return s.translationMemoryRepo.Update(ctx, req)
+}
+
+func (s *TranslationMemoryService) Delete(ctx context.Context, req *contentV1.DeleteTranslationMemoryRequest) (*emptypb.Empty, error) {
+	if err := s.translationMemoryRepo.Delete(ctx, req); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ImportTmx 把 TMX 中源语言句段与各目标语言句段的组合导入为翻译记忆
+func (s *TranslationMemoryService) ImportTmx(ctx context.Context, req *contentV1.ImportTmxRequest) (*contentV1.ImportTmxResponse, error) {
+	doc, sourceLanguage, err := parseTmxImport(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &contentV1.ImportTmxResponse{}
+	var pairs []data.TranslationMemoryPair
+	for i, u := range doc.Units {
+		source, ok := u.Text(sourceLanguage)
+		source = strings.TrimSpace(source)
+		if !ok || source == "" {
+			resp.Skipped++
+			addTmxImportError(resp, "unit %d: missing %s segment", i+1, sourceLanguage)
+			continue
+		}
+
+		for _, v := range u.Variants {
+			if !tmxTargetVariant(v, sourceLanguage, req.GetTargetLanguage()) {
+				continue
+			}
+			if !locale.ValidCode(v.Lang) || strings.TrimSpace(v.Text) == "" {
+				resp.Skipped++
+				addTmxImportError(resp, "unit %d: invalid %s segment", i+1, v.Lang)
+				continue
+			}
+			pairs = append(pairs, data.TranslationMemoryPair{
+				SourceLanguage: sourceLanguage,
+				TargetLanguage: v.Lang,
+				SourceText:     source,
+				TargetText:     v.Text,
+			})
+		}
+	}
+	if len(pairs) > data.MaxTmxUnits {
+		return nil, contentV1.ErrorBadRequest(fmt.Sprintf("too many translation units (max %d)", data.MaxTmxUnits))
+	}
+
+	created, updated, skipped, err := s.translationMemoryRepo.Import(ctx, pairs, req.GetOverwrite())
+	if err != nil {
+		return nil, err
+	}
+	resp.Created = created
+	resp.Updated = updated
+	resp.Skipped += skipped
+
+	return resp, nil
+}
+
+// ExportTmx 导出翻译记忆，每个条目为一个翻译单元，x-origin 属性记录来源
+func (s *TranslationMemoryService) ExportTmx(ctx context.Context, req *contentV1.ExportTmxRequest) (*contentV1.ExportTmxResponse, error) {
+	if req == nil {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+
+	rows, err := s.translationMemoryRepo.ListForExport(ctx, req.GetSourceLanguage(), req.GetTargetLanguage())
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &tmx.Document{SourceLanguage: req.GetSourceLanguage(), Units: make([]tmx.Unit, 0, len(rows))}
+	for _, row := range rows {
+		unit := tmx.Unit{
+			Variants: []tmx.Variant{
+				{Lang: trans.StringValue(row.SourceLanguage), Text: trans.StringValue(row.SourceText)},
+				{Lang: trans.StringValue(row.TargetLanguage), Text: trans.StringValue(row.TargetText)},
+			},
+		}
+		if row.Origin != nil {
+			unit.Props = map[string]string{
+				"x-origin": strings.ToLower(strings.TrimPrefix(string(*row.Origin), "ORIGIN_")),
+			}
+		}
+		doc.Units = append(doc.Units, unit)
+	}
+
+	return writeTmxExport(doc, "translation-memory")
+}
+
+// parseTmxImport 校验并解析导入的 TMX 文件，返回文档与源语言（未指定时取 header 的 srclang）
+func parseTmxImport(req *contentV1.ImportTmxRequest) (*tmx.Document, string, error) {
+	if req == nil || len(req.GetFile()) == 0 {
+		return nil, "", contentV1.ErrorBadRequest("invalid parameter")
+	}
+	if len(req.GetFile()) > maxTmxFileSize {
+		return nil, "", contentV1.ErrorBadRequest(fmt.Sprintf("tmx file too large (max %d bytes)", maxTmxFileSize))
+	}
+	if req.GetTargetLanguage() != "" && !locale.ValidCode(req.GetTargetLanguage()) {
+		return nil, "", contentV1.ErrorBadRequest("invalid target language")
+	}
+
+	doc, err := tmx.Parse(bytes.NewReader(req.GetFile()))
+	if err != nil {
+		return nil, "", contentV1.ErrorBadRequest("invalid tmx file: " + err.Error())
+	}
+
+	sourceLanguage := req.GetSourceLanguage()
+	if sourceLanguage == "" {
+		sourceLanguage = doc.SourceLanguage
+	}
+	if !locale.ValidCode(sourceLanguage) {
+		return nil, "", contentV1.ErrorBadRequest("source language is required when the tmx header has no single srclang")
+	}
+	if len(doc.Units) > data.MaxTmxUnits {
+		return nil, "", contentV1.ErrorBadRequest(fmt.Sprintf("too many translation units (max %d)", data.MaxTmxUnits))
+	}
+
+	return doc, sourceLanguage, nil
+}
+
+// tmxTargetVariant 判断句段是否为要导入的目标语言
+func tmxTargetVariant(v tmx.Variant, sourceLanguage, targetLanguage string) bool {
+	if strings.EqualFold(v.Lang, sourceLanguage) {
+		return false
+	}
+	return targetLanguage == "" || strings.EqualFold(v.Lang, targetLanguage)
+}
+
+func addTmxImportError(resp *contentV1.ImportTmxResponse, format string, args ...any) {
+	if len(resp.Errors) < maxTmxImportErrors {
+		resp.Errors = append(resp.Errors, fmt.Sprintf(format, args...))
+	}
+}
+
+// writeTmxExport 输出 TMX 文件
+func writeTmxExport(doc *tmx.Document, baseName string) (*contentV1.ExportTmxResponse, error) {
+	var buf bytes.Buffer
+	if err := tmx.Write(&buf, doc); err != nil {
+		return nil, contentV1.ErrorInternalServerError("export tmx failed")
+	}
+
+	return &contentV1.ExportTmxResponse{
+		File:     buf.Bytes(),
+		FileName: fmt.Sprintf("%s-%s.tmx", baseName, time.Now().Format("20060102150405")),
+		Mime:     tmxMime,
+		Count:    uint32(len(doc.Units)),
+	}, nil
+}
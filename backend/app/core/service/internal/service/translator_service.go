@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/tx7do/go-crud/viewer"
+	"github.com/tx7do/go-utils/trans"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+	translatorV1 "go-wind-cms/api/gen/go/translator/service/v1"
+
+	"go-wind-cms/pkg/content/locale"
+	"go-wind-cms/pkg/content/mt"
+)
+
+// maxTranslateContentLength 单次翻译的内容长度上限
+const maxTranslateContentLength = 256 << 10
+
+// TranslatorService 文本翻译：保留 HTML 标签与代码，逐句段应用调用者租户的翻译记忆与术语表
+type TranslatorService struct {
+	translatorV1.UnimplementedTranslatorServiceServer
+
+	log *log.Helper
+
+	machineTranslator *data.MachineTranslator
+}
+
+func NewTranslatorService(ctx *bootstrap.Context, machineTranslator *data.MachineTranslator) *TranslatorService {
+	return &TranslatorService{
+		log:               ctx.NewLoggerHelper("translator/service/core-service"),
+		machineTranslator: machineTranslator,
+	}
+}
+
+func (s *TranslatorService) Translate(ctx context.Context, req *translatorV1.TranslateRequest) (*translatorV1.TranslateResponse, error) {
+	if req == nil || req.GetContent() == "" {
+		return nil, contentV1.ErrorBadRequest("invalid parameter")
+	}
+	if len(req.GetContent()) > maxTranslateContentLength {
+		return nil, contentV1.ErrorBadRequest("content too long")
+	}
+	if !locale.ValidCode(req.GetSourceLanguage()) || !locale.ValidCode(req.GetTargetLanguage()) {
+		return nil, contentV1.ErrorBadRequest("invalid language")
+	}
+
+	var tenantID uint32
+	if vc, exist := viewer.FromContext(ctx); exist && vc != nil {
+		tenantID = uint32(vc.TenantID())
+	}
+
+	fn, err := s.machineTranslator.Session(ctx, tenantID, req.GetSourceLanguage(), req.GetTargetLanguage())
+	if err != nil {
+		return nil, err
+	}
+
+	translated, err := mt.Translate(req.GetContent(), fn)
+	if err != nil {
+		s.log.Errorf("translate failed: %s", err.Error())
+		return nil, contentV1.ErrorInternalServerError("translate failed")
+	}
+
+	return &translatorV1.TranslateResponse{
+		TranslatedContent: trans.Ptr(translated),
+		RawContent:        req.Content,
+	}, nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"go-wind-cms/app/core/service/internal/data"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+
+	appViewer "go-wind-cms/pkg/entgo/viewer"
+	"go-wind-cms/pkg/task"
+)
+
+// TrashService 内容回收站：列表、恢复、彻底删除，以及按保留期定时清理。
+//
+// 内容移入回收站由各内容服务的 Delete 完成；恢复文章后重新索引搜索，恢复任何内容后清除站点地图与订阅源缓存。
+type TrashService struct {
+	contentV1.UnimplementedTrashServiceServer
+
+	trashRepo       *data.TrashRepo
+	syndicationRepo *data.SyndicationRepo
+	postService     *PostService
+
+	log *log.Helper
+}
+
+func NewTrashService(ctx *bootstrap.Context, trashRepo *data.TrashRepo, syndicationRepo *data.SyndicationRepo, postService *PostService) *TrashService {
+	return &TrashService{
+		log:             ctx.NewLoggerHelper("trash/service/core-service"),
+		trashRepo:       trashRepo,
+		syndicationRepo: syndicationRepo,
+		postService:     postService,
+	}
+}
+
+func (s *TrashService) ListTrash(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListTrashResponse, error) {
+	return s.trashRepo.List(ctx, req)
+}
+
+func (s *TrashService) Restore(ctx context.Context, req *contentV1.RestoreTrashRequest) (*contentV1.RestoreTrashResponse, error) {
+	ids := uniqueIDs(req.GetIds())
+	if len(ids) == 0 {
+		return nil, contentV1.ErrorBadRequest("ids is required")
+	}
+
+	restored, err := s.trashRepo.Restore(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &contentV1.RestoreTrashResponse{Items: make([]*contentV1.RestoredTrashItem, 0, len(restored))}
+	for _, r := range restored {
+		resp.Items = append(resp.Items, r.Item)
+
+		// 文章移入回收站时已从搜索索引删除，恢复后重新索引（非已发布状态由 worker 跳过）
+		if r.PostID != 0 {
+			s.postService.enqueuePostReindex(ctx, r.PostID, "index")
+		}
+	}
+	if len(restored) > 0 {
+		s.syndicationRepo.Invalidate(ctx)
+	}
+
+	return resp, nil
+}
+
+func (s *TrashService) PurgeTrash(ctx context.Context, req *contentV1.PurgeTrashRequest) (*contentV1.PurgeTrashResponse, error) {
+	ids := uniqueIDs(req.GetIds())
+	if !req.GetAll() && len(ids) == 0 {
+		return nil, contentV1.ErrorBadRequest("ids is required unless all is set")
+	}
+
+	purged, err := s.trashRepo.Purge(ctx, ids, req.GetAll(), req.EntityType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &contentV1.PurgeTrashResponse{Purged: purged}, nil
+}
+
+// PurgeExpired 是 asynq "trash.purge" 周期任务的 worker handler，彻底删除超过租户保留期的条目。
+//
+// 签名遵循 (taskType string, payload *T) error 模式（参考 TaskService.AsyncBackup）。
+func (s *TrashService) PurgeExpired(taskType string, _ *task.TrashPurgeTaskData) error {
+	// 注入 SystemViewer：跨租户清理
+	ctx := appViewer.NewSystemViewerContext(context.Background())
+
+	purged, err := s.trashRepo.PurgeExpired(ctx, time.Now())
+	if err != nil {
+		s.log.Errorf("[%s] purge expired trash failed: %v", taskType, err)
+		return err
+	}
+	if purged > 0 {
+		s.log.Infof("[%s] purged %d expired trash items", taskType, purged)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	paginationV1 "github.com/tx7do/go-crud/api/gen/go/pagination/v1"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"go-wind-cms/app/core/service/internal/data"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+)
+
+type WorkflowService struct {
+	contentV1.UnimplementedWorkflowServiceServer
+
+	workflowRepo *data.WorkflowRepo
+	log          *log.Helper
+}
+
+func NewWorkflowService(ctx *bootstrap.Context, uc *data.WorkflowRepo) *WorkflowService {
+	return &WorkflowService{
+		log:          ctx.NewLoggerHelper("workflow/service/core-service"),
+		workflowRepo: uc,
+	}
+}
+
+func (s *WorkflowService) List(ctx context.Context, req *paginationV1.PagingRequest) (*contentV1.ListWorkflowResponse, error) {
+	return s.workflowRepo.List(ctx, req)
+}
+
+func (s *WorkflowService) Get(ctx context.Context, req *contentV1.GetWorkflowRequest) (*contentV1.Workflow, error) {
+	return s.workflowRepo.Get(ctx, req)
+}
+
+func (s *WorkflowService) Create(ctx context.Context, req *contentV1.CreateWorkflowRequest) (*contentV1.Workflow, error) {
+	return s.workflowRepo.Create(ctx, req)
+}
+
+func (s *WorkflowService) Update(ctx context.Context, req *contentV1.UpdateWorkflowRequest) (*contentV1.Workflow, error) {
+	return s.workflowRepo.Update(ctx, req)
+}
+
+func (s *WorkflowService) Delete(ctx context.Context, req *contentV1.DeleteWorkflowRequest) (*emptypb.Empty, error) {
+	err := s.workflowRepo.Delete(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,313 @@
+package customfield
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"time"
+	"unicode/utf8"
+)
+
+// Type 字段类型
+type Type string
+
+const (
+	TypeText     Type = "text"
+	TypeNumber   Type = "number"
+	TypeBool     Type = "bool"
+	TypeDate     Type = "date"
+	TypeMedia    Type = "media"    // 媒体库资源ID
+	TypeContent  Type = "content"  // 站内内容引用
+	TypeSelect   Type = "select"   // 单选/多选
+	TypeRepeater Type = "repeater" // 可重复的子字段组
+)
+
+const (
+	// MaxFields 单个字段组的最大字段数
+	MaxFields = 100
+	// MaxTextLength 文本字段未设置长度上限时的默认上限
+	MaxTextLength = 10000
+	// MaxRepeaterItems 重复组未设置行数上限时的默认上限
+	MaxRepeaterItems = 100
+)
+
+var keyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// Definition 字段定义
+type Definition struct {
+	Key         string   `json:"key"`
+	Label       string   `json:"label,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Type        Type     `json:"type"`
+	Required    bool     `json:"required,omitempty"`
+	MaxLength   uint32   `json:"maxLength,omitempty"` // text
+	Min         *float64 `json:"min,omitempty"`       // number
+	Max         *float64 `json:"max,omitempty"`       // number
+
+	Options  []string `json:"options,omitempty"`  // select 可选值
+	Multiple bool     `json:"multiple,omitempty"` // select 是否多选
+
+	ContentTypes []string `json:"contentTypes,omitempty"` // content 允许引用的内容类型，空表示不限
+
+	MaxItems uint32       `json:"maxItems,omitempty"` // repeater 最大行数
+	Fields   []Definition `json:"fields,omitempty"`   // repeater 子字段
+}
+
+// ContentRef 站内内容引用
+type ContentRef struct {
+	Type string `json:"type"`
+	ID   uint32 `json:"id"`
+}
+
+// Value 字段值，按字段类型只设置其中一项。
+// 以该结构原样存入 JSON 列，过滤与排序按 "<key>.<kind>" 路径访问。
+type Value struct {
+	Text    *string             `json:"text,omitempty"`
+	Number  *float64            `json:"number,omitempty"`
+	Bool    *bool               `json:"bool,omitempty"`
+	Date    *time.Time          `json:"date,omitempty"`
+	Media   *uint32             `json:"media,omitempty"`
+	Content *ContentRef         `json:"content,omitempty"`
+	Select  []string            `json:"select,omitempty"`
+	Rows    []map[string]*Value `json:"rows,omitempty"`
+}
+
+// Type 推断值的类型，未设置任何值时返回空
+func (v *Value) Type() Type {
+	switch {
+	case v == nil:
+		return ""
+	case v.Text != nil:
+		return TypeText
+	case v.Number != nil:
+		return TypeNumber
+	case v.Bool != nil:
+		return TypeBool
+	case v.Date != nil:
+		return TypeDate
+	case v.Media != nil:
+		return TypeMedia
+	case v.Content != nil:
+		return TypeContent
+	case v.Select != nil:
+		return TypeSelect
+	case v.Rows != nil:
+		return TypeRepeater
+	default:
+		return ""
+	}
+}
+
+// Error 字段定义或字段值校验错误，Path 为出错字段的路径，如 "gallery[2].caption"
+type Error struct {
+	Path    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+func errorf(path, format string, args ...any) error {
+	return &Error{Path: path, Message: fmt.Sprintf(format, args...)}
+}
+
+// ValidateDefinitions 校验字段组定义：键名合法且唯一、类型已知、类型参数自洽。
+// 重复组只允许一层，子字段不能再是重复组。
+func ValidateDefinitions(defs []Definition) error {
+	return validateDefinitions(defs, "", false)
+}
+
+func validateDefinitions(defs []Definition, prefix string, nested bool) error {
+	if len(defs) > MaxFields {
+		return errorf(prefix, "too many fields (max %d)", MaxFields)
+	}
+
+	seen := make(map[string]struct{}, len(defs))
+	for _, def := range defs {
+		path := joinPath(prefix, def.Key)
+		if !keyPattern.MatchString(def.Key) {
+			return errorf(path, "invalid key, expect lower-case letters, digits and underscores")
+		}
+		if _, ok := seen[def.Key]; ok {
+			return errorf(path, "duplicate key")
+		}
+		seen[def.Key] = struct{}{}
+
+		switch def.Type {
+		case TypeText, TypeBool, TypeDate, TypeMedia, TypeContent:
+		case TypeNumber:
+			if def.Min != nil && def.Max != nil && *def.Min > *def.Max {
+				return errorf(path, "min is greater than max")
+			}
+		case TypeSelect:
+			if len(def.Options) == 0 {
+				return errorf(path, "select field requires options")
+			}
+		case TypeRepeater:
+			if nested {
+				return errorf(path, "nested repeater is not supported")
+			}
+			if len(def.Fields) == 0 {
+				return errorf(path, "repeater field requires sub fields")
+			}
+			if err := validateDefinitions(def.Fields, path, true); err != nil {
+				return err
+			}
+		default:
+			return errorf(path, "unknown field type %q", def.Type)
+		}
+	}
+	return nil
+}
+
+// Merge 合并多个字段组的定义，同名字段以先出现的为准
+func Merge(groups ...[]Definition) []Definition {
+	var (
+		merged []Definition
+		seen   = make(map[string]struct{})
+	)
+	for _, defs := range groups {
+		for _, def := range defs {
+			if _, ok := seen[def.Key]; ok {
+				continue
+			}
+			seen[def.Key] = struct{}{}
+			merged = append(merged, def)
+		}
+	}
+	return merged
+}
+
+// Validate 按字段定义校验字段值并规范化（日期统一为 UTC 秒精度）。
+// 未定义的字段、类型不符的值与缺失的必填字段都视为错误。
+func Validate(defs []Definition, values map[string]*Value) error {
+	return validateValues(defs, values, "")
+}
+
+func validateValues(defs []Definition, values map[string]*Value, prefix string) error {
+	byKey := make(map[string]*Definition, len(defs))
+	for i := range defs {
+		byKey[defs[i].Key] = &defs[i]
+	}
+
+	for key, value := range values {
+		def, ok := byKey[key]
+		if !ok {
+			return errorf(joinPath(prefix, key), "field is not defined")
+		}
+		if value.Type() == "" {
+			// 空值等同于未填写
+			delete(values, key)
+			continue
+		}
+		if err := validateValue(def, value, joinPath(prefix, key)); err != nil {
+			return err
+		}
+	}
+
+	for i := range defs {
+		if defs[i].Required && values[defs[i].Key] == nil {
+			return errorf(joinPath(prefix, defs[i].Key), "field is required")
+		}
+	}
+	return nil
+}
+
+func validateValue(def *Definition, v *Value, path string) error {
+	if v.Type() != def.Type {
+		return errorf(path, "expect %s value", def.Type)
+	}
+
+	switch def.Type {
+	case TypeText:
+		limit := def.MaxLength
+		if limit == 0 {
+			limit = MaxTextLength
+		}
+		if uint32(utf8.RuneCountInString(*v.Text)) > limit {
+			return errorf(path, "text is longer than %d characters", limit)
+		}
+		if def.Required && *v.Text == "" {
+			return errorf(path, "field is required")
+		}
+
+	case TypeNumber:
+		n := *v.Number
+		if math.IsNaN(n) || math.IsInf(n, 0) {
+			return errorf(path, "invalid number")
+		}
+		if def.Min != nil && n < *def.Min {
+			return errorf(path, "number is less than %v", *def.Min)
+		}
+		if def.Max != nil && n > *def.Max {
+			return errorf(path, "number is greater than %v", *def.Max)
+		}
+
+	case TypeDate:
+		t := v.Date.UTC().Truncate(time.Second)
+		v.Date = &t
+
+	case TypeMedia:
+		if *v.Media == 0 {
+			return errorf(path, "invalid media id")
+		}
+
+	case TypeContent:
+		if v.Content.ID == 0 || v.Content.Type == "" {
+			return errorf(path, "invalid content reference")
+		}
+		if len(def.ContentTypes) > 0 && !slices.Contains(def.ContentTypes, v.Content.Type) {
+			return errorf(path, "content type %s is not allowed", v.Content.Type)
+		}
+
+	case TypeSelect:
+		if !def.Multiple && len(v.Select) > 1 {
+			return errorf(path, "only one option can be selected")
+		}
+		if def.Required && len(v.Select) == 0 {
+			return errorf(path, "field is required")
+		}
+		for _, opt := range v.Select {
+			if !slices.Contains(def.Options, opt) {
+				return errorf(path, "option %q is not allowed", opt)
+			}
+		}
+
+	case TypeRepeater:
+		limit := def.MaxItems
+		if limit == 0 {
+			limit = MaxRepeaterItems
+		}
+		if uint32(len(v.Rows)) > limit {
+			return errorf(path, "too many rows (max %d)", limit)
+		}
+		if def.Required && len(v.Rows) == 0 {
+			return errorf(path, "field is required")
+		}
+		for i, row := range v.Rows {
+			if err := validateValues(def.Fields, row, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func joinPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// IsValidationError 是否为字段定义或字段值校验错误
+func IsValidationError(err error) bool {
+	var e *Error
+	return errors.As(err, &e)
+}
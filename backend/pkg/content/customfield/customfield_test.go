@@ -0,0 +1,147 @@
+package customfield
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func TestValidateDefinitions(t *testing.T) {
+	tests := []struct {
+		name    string
+		defs    []Definition
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			defs: []Definition{
+				{Key: "price", Type: TypeNumber, Min: ptr(0.0)},
+				{Key: "color", Type: TypeSelect, Options: []string{"red", "blue"}},
+				{Key: "gallery", Type: TypeRepeater, Fields: []Definition{
+					{Key: "image", Type: TypeMedia, Required: true},
+					{Key: "caption", Type: TypeText},
+				}},
+			},
+		},
+		{name: "invalid key", defs: []Definition{{Key: "Price", Type: TypeNumber}}, wantErr: true},
+		{name: "duplicate key", defs: []Definition{{Key: "a", Type: TypeText}, {Key: "a", Type: TypeBool}}, wantErr: true},
+		{name: "unknown type", defs: []Definition{{Key: "a", Type: "json"}}, wantErr: true},
+		{name: "select without options", defs: []Definition{{Key: "a", Type: TypeSelect}}, wantErr: true},
+		{name: "min greater than max", defs: []Definition{{Key: "a", Type: TypeNumber, Min: ptr(2.0), Max: ptr(1.0)}}, wantErr: true},
+		{
+			name: "nested repeater",
+			defs: []Definition{{Key: "a", Type: TypeRepeater, Fields: []Definition{
+				{Key: "b", Type: TypeRepeater, Fields: []Definition{{Key: "c", Type: TypeText}}},
+			}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDefinitions(tt.defs)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	defs := []Definition{
+		{Key: "price", Type: TypeNumber, Required: true, Min: ptr(0.0)},
+		{Key: "color", Type: TypeSelect, Options: []string{"red", "blue"}},
+		{Key: "related", Type: TypeContent, ContentTypes: []string{"CONTENT_TYPE_POST"}},
+		{Key: "gallery", Type: TypeRepeater, MaxItems: 2, Fields: []Definition{
+			{Key: "image", Type: TypeMedia, Required: true},
+		}},
+	}
+
+	tests := []struct {
+		name     string
+		values   map[string]*Value
+		wantPath string
+	}{
+		{
+			name: "valid",
+			values: map[string]*Value{
+				"price":   {Number: ptr(9.9)},
+				"color":   {Select: []string{"red"}},
+				"related": {Content: &ContentRef{Type: "CONTENT_TYPE_POST", ID: 3}},
+				"gallery": {Rows: []map[string]*Value{{"image": {Media: ptr(uint32(1))}}}},
+			},
+		},
+		{name: "missing required", values: map[string]*Value{}, wantPath: "price"},
+		{name: "type mismatch", values: map[string]*Value{"price": {Text: ptr("9.9")}}, wantPath: "price"},
+		{name: "below min", values: map[string]*Value{"price": {Number: ptr(-1.0)}}, wantPath: "price"},
+		{name: "undefined field", values: map[string]*Value{"price": {Number: ptr(1.0)}, "foo": {Text: ptr("x")}}, wantPath: "foo"},
+		{
+			name:     "multiple options on single select",
+			values:   map[string]*Value{"price": {Number: ptr(1.0)}, "color": {Select: []string{"red", "blue"}}},
+			wantPath: "color",
+		},
+		{
+			name:     "disallowed content type",
+			values:   map[string]*Value{"price": {Number: ptr(1.0)}, "related": {Content: &ContentRef{Type: "CONTENT_TYPE_PAGE", ID: 3}}},
+			wantPath: "related",
+		},
+		{
+			name: "repeater row missing required",
+			values: map[string]*Value{
+				"price":   {Number: ptr(1.0)},
+				"gallery": {Rows: []map[string]*Value{{"image": {Media: ptr(uint32(1))}}, {}}},
+			},
+			wantPath: "gallery[1].image",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(defs, tt.values)
+			if tt.wantPath == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var fieldErr *Error
+			if assert.ErrorAs(t, err, &fieldErr) {
+				assert.Equal(t, tt.wantPath, fieldErr.Path)
+			}
+		})
+	}
+}
+
+func TestValidateNormalizesDate(t *testing.T) {
+	defs := []Definition{{Key: "event_at", Type: TypeDate}}
+	local := time.Date(2026, 3, 1, 8, 30, 15, 999, time.FixedZone("CST", 8*3600))
+	values := map[string]*Value{"event_at": {Date: &local}}
+
+	assert.NoError(t, Validate(defs, values))
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 30, 15, 0, time.UTC), *values["event_at"].Date)
+}
+
+func TestParseFilterValue(t *testing.T) {
+	tests := []struct {
+		def     Definition
+		raw     string
+		want    any
+		wantErr bool
+	}{
+		{def: Definition{Key: "price", Type: TypeNumber}, raw: "12.5", want: 12.5},
+		{def: Definition{Key: "price", Type: TypeNumber}, raw: "abc", wantErr: true},
+		{def: Definition{Key: "on_sale", Type: TypeBool}, raw: "true", want: true},
+		{def: Definition{Key: "event_at", Type: TypeDate}, raw: "2026-03-01", want: "2026-03-01T00:00:00Z"},
+		{def: Definition{Key: "event_at", Type: TypeDate}, raw: "2026-03-01T08:00:00+08:00", want: "2026-03-01T00:00:00Z"},
+		{def: Definition{Key: "related", Type: TypeContent}, raw: "7", want: uint32(7)},
+		{def: Definition{Key: "gallery", Type: TypeRepeater}, raw: "x", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.def.Key+"="+tt.raw, func(t *testing.T) {
+			got, err := ParseFilterValue(tt.def, tt.raw)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package customfield
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldPrefix 列表过滤与排序中自定义字段的前缀，如 "field_values.price"
+const FieldPrefix = "field_values."
+
+// ParseFieldName 解析过滤/排序字段名，返回自定义字段键名
+func ParseFieldName(name string) (string, bool) {
+	if !strings.HasPrefix(name, FieldPrefix) {
+		return "", false
+	}
+	key := strings.TrimPrefix(name, FieldPrefix)
+	if !keyPattern.MatchString(key) {
+		return "", false
+	}
+	return key, true
+}
+
+// ValuePath 字段值在 JSON 列中的路径。内容引用按被引用内容的 ID 过滤。
+// 重复组不支持过滤与排序，返回 false。
+func ValuePath(def Definition) (string, bool) {
+	switch def.Type {
+	case TypeText:
+		return def.Key + ".text", true
+	case TypeNumber:
+		return def.Key + ".number", true
+	case TypeBool:
+		return def.Key + ".bool", true
+	case TypeDate:
+		return def.Key + ".date", true
+	case TypeMedia:
+		return def.Key + ".media", true
+	case TypeContent:
+		return def.Key + ".content.id", true
+	case TypeSelect:
+		return def.Key + ".select", true
+	default:
+		return "", false
+	}
+}
+
+// Sortable 字段是否支持排序（多值字段不支持）
+func Sortable(def Definition) bool {
+	switch def.Type {
+	case TypeText, TypeNumber, TypeBool, TypeDate, TypeMedia:
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseFilterValue 把过滤条件中的字符串值转换为与存储一致的类型，
+// 日期转换为与存储相同的 RFC3339 UTC 格式，以便按字符串比较。
+func ParseFilterValue(def Definition, raw string) (any, error) {
+	raw = strings.TrimSpace(raw)
+	switch def.Type {
+	case TypeText, TypeSelect:
+		return raw, nil
+
+	case TypeNumber:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, errorf(def.Key, "invalid number %q", raw)
+		}
+		return n, nil
+
+	case TypeBool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, errorf(def.Key, "invalid bool %q", raw)
+		}
+		return b, nil
+
+	case TypeDate:
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			if t, err = time.Parse(time.DateOnly, raw); err != nil {
+				return nil, errorf(def.Key, "invalid date %q", raw)
+			}
+		}
+		return t.UTC().Truncate(time.Second).Format(time.RFC3339), nil
+
+	case TypeMedia, TypeContent:
+		id, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			return nil, errorf(def.Key, "invalid id %q", raw)
+		}
+		return uint32(id), nil
+
+	default:
+		return nil, errorf(def.Key, "%s field is not filterable", def.Type)
+	}
+}
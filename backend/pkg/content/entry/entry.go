@@ -0,0 +1,135 @@
+package entry
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"go-wind-cms/pkg/content/customfield"
+)
+
+const (
+	// TaxonomyCategory 内容类型可绑定分类
+	TaxonomyCategory = "category"
+	// TaxonomyTag 内容类型可绑定标签
+	TaxonomyTag = "tag"
+
+	// MaxSearchTextLength 搜索文本的最大长度（字符数），超出部分截断
+	MaxSearchTextLength = 20000
+)
+
+var codePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,63}$`)
+
+// Translation 内容条目的单语言版本，以语言代码为键存入 JSON 列
+type Translation struct {
+	Title       string                        `json:"title"`
+	Slug        string                        `json:"slug,omitempty"`
+	Summary     string                        `json:"summary,omitempty"`
+	FieldValues map[string]*customfield.Value `json:"fieldValues,omitempty"`
+}
+
+// ValidCode 内容类型编码是否合法：小写字母开头，仅含小写字母、数字、下划线与连字符
+func ValidCode(code string) bool {
+	return codePattern.MatchString(code)
+}
+
+// ValidTaxonomies 校验内容类型绑定的分类法
+func ValidTaxonomies(taxonomies []string) error {
+	for _, t := range taxonomies {
+		if t != TaxonomyCategory && t != TaxonomyTag {
+			return errors.New("unknown taxonomy " + t)
+		}
+	}
+	return nil
+}
+
+// SplitDefinitions 按多语言字段列表拆分字段定义：shared 存于条目本身，localized 存于各语言版本
+func SplitDefinitions(defs []customfield.Definition, localizedFields []string) (shared, localized []customfield.Definition) {
+	for _, def := range defs {
+		if slices.Contains(localizedFields, def.Key) {
+			localized = append(localized, def)
+		} else {
+			shared = append(shared, def)
+		}
+	}
+	return
+}
+
+// ValidateLocalizedFields 多语言字段列表必须引用已定义的字段
+func ValidateLocalizedFields(defs []customfield.Definition, localizedFields []string) error {
+	for _, key := range localizedFields {
+		if !slices.ContainsFunc(defs, func(def customfield.Definition) bool { return def.Key == key }) {
+			return errors.New("localized field " + key + " is not defined")
+		}
+	}
+	return nil
+}
+
+// Languages 返回条目已有的语言代码（有序）
+func Languages(translations map[string]*Translation) []string {
+	languages := make([]string, 0, len(translations))
+	for lang, tr := range translations {
+		if tr != nil {
+			languages = append(languages, lang)
+		}
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// SearchText 汇总标题、摘要与文本类字段值，生成小写的检索文本，用于条目的关键词搜索
+func SearchText(values map[string]*customfield.Value, translations map[string]*Translation) string {
+	var sb strings.Builder
+	write := func(s string) {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			return
+		}
+		if sb.Len() > 0 {
+			sb.WriteByte('\n')
+		}
+		sb.WriteString(strings.ToLower(s))
+	}
+
+	writeValues(write, values)
+	for _, lang := range Languages(translations) {
+		tr := translations[lang]
+		write(tr.Title)
+		write(tr.Summary)
+		writeValues(write, tr.FieldValues)
+	}
+
+	return truncate(sb.String(), MaxSearchTextLength)
+}
+
+func writeValues(write func(string), values map[string]*customfield.Value) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		v := values[key]
+		switch v.Type() {
+		case customfield.TypeText:
+			write(*v.Text)
+		case customfield.TypeSelect:
+			write(strings.Join(v.Select, " "))
+		case customfield.TypeRepeater:
+			for _, row := range v.Rows {
+				writeValues(write, row)
+			}
+		}
+	}
+}
+
+func truncate(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+	return string([]rune(s)[:limit])
+}
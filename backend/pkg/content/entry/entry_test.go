@@ -0,0 +1,62 @@
+package entry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-wind-cms/pkg/content/customfield"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func TestValidCode(t *testing.T) {
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{code: "product", want: true},
+		{code: "case-study", want: true},
+		{code: "event_2026", want: true},
+		{code: "Product", want: false},
+		{code: "1product", want: false},
+		{code: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			assert.Equal(t, tt.want, ValidCode(tt.code))
+		})
+	}
+}
+
+func TestSplitDefinitions(t *testing.T) {
+	defs := []customfield.Definition{
+		{Key: "price", Type: customfield.TypeNumber},
+		{Key: "tagline", Type: customfield.TypeText},
+	}
+
+	shared, localized := SplitDefinitions(defs, []string{"tagline"})
+	assert.Equal(t, []customfield.Definition{defs[0]}, shared)
+	assert.Equal(t, []customfield.Definition{defs[1]}, localized)
+
+	assert.NoError(t, ValidateLocalizedFields(defs, []string{"tagline"}))
+	assert.Error(t, ValidateLocalizedFields(defs, []string{"color"}))
+}
+
+func TestSearchText(t *testing.T) {
+	values := map[string]*customfield.Value{
+		"sku":   {Text: ptr("ABC-1")},
+		"price": {Number: ptr(9.9)},
+		"color": {Select: []string{"Red", "Blue"}},
+	}
+	translations := map[string]*Translation{
+		"zh-CN": {Title: "无线耳机", FieldValues: map[string]*customfield.Value{"tagline": {Text: ptr("降噪")}}},
+		"en":    {Title: "Wireless Headphones", Summary: "Noise cancelling"},
+	}
+
+	assert.Equal(t,
+		"red blue\nabc-1\nwireless headphones\nnoise cancelling\n无线耳机\n降噪",
+		SearchText(values, translations),
+	)
+	assert.Equal(t, []string{"en", "zh-CN"}, Languages(translations))
+}
@@ -0,0 +1,139 @@
+package form
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-wind-cms/pkg/content/customfield"
+)
+
+// MaxExportRows 单次导出的最大提交数
+const MaxExportRows = 10000
+
+// Submission 待导出的一次提交
+type Submission struct {
+	ID          uint32
+	SubmittedAt time.Time
+	PageID      uint32
+	Values      map[string]*customfield.Value
+}
+
+// WriteCSV 以 CSV 导出提交：固定列 id、submitted_at、page_id，其后按表单字段顺序每个字段一列，
+// 表头使用字段键名。单元格以 = + - @ 等开头时加前缀单引号，防止在表格软件中被当作公式执行。
+func WriteCSV(w io.Writer, fields []Field, rows []Submission) error {
+	cw := csv.NewWriter(w)
+
+	header := []string{"id", "submitted_at", "page_id"}
+	for i := range fields {
+		header = append(header, fields[i].Key)
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	record := make([]string, len(header))
+	for _, row := range rows {
+		record[0] = strconv.FormatUint(uint64(row.ID), 10)
+		record[1] = row.SubmittedAt.UTC().Format(time.RFC3339)
+		record[2] = ""
+		if row.PageID != 0 {
+			record[2] = strconv.FormatUint(uint64(row.PageID), 10)
+		}
+		for i := range fields {
+			record[3+i] = escapeCell(CellText(row.Values[fields[i].Key]))
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// jsonSubmission JSON 导出的单条记录，字段值按类型输出为 JSON 原生值
+type jsonSubmission struct {
+	ID          uint32         `json:"id"`
+	SubmittedAt time.Time      `json:"submitted_at"`
+	PageID      uint32         `json:"page_id,omitempty"`
+	Values      map[string]any `json:"values"`
+}
+
+// WriteJSON 以 JSON 数组导出提交，仅包含表单当前定义的字段
+func WriteJSON(w io.Writer, fields []Field, rows []Submission) error {
+	out := make([]jsonSubmission, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, jsonSubmission{
+			ID:          row.ID,
+			SubmittedAt: row.SubmittedAt.UTC(),
+			PageID:      row.PageID,
+			Values:      PlainValues(fields, row.Values),
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// PlainValues 将字段值转换为 JSON 原生值（字符串、数值、布尔、时间、字符串数组），
+// 仅包含表单当前定义且已填写的字段
+func PlainValues(fields []Field, values map[string]*customfield.Value) map[string]any {
+	out := make(map[string]any, len(fields))
+	for i := range fields {
+		if v := plainValue(values[fields[i].Key]); v != nil {
+			out[fields[i].Key] = v
+		}
+	}
+	return out
+}
+
+// CellText 字段值的文本形式，多选值以 "; " 连接
+func CellText(v *customfield.Value) string {
+	switch v.Type() {
+	case customfield.TypeText:
+		return *v.Text
+	case customfield.TypeNumber:
+		return strconv.FormatFloat(*v.Number, 'f', -1, 64)
+	case customfield.TypeBool:
+		return strconv.FormatBool(*v.Bool)
+	case customfield.TypeDate:
+		return v.Date.UTC().Format(time.RFC3339)
+	case customfield.TypeSelect:
+		return strings.Join(v.Select, "; ")
+	default:
+		return ""
+	}
+}
+
+func plainValue(v *customfield.Value) any {
+	switch v.Type() {
+	case customfield.TypeText:
+		return *v.Text
+	case customfield.TypeNumber:
+		return *v.Number
+	case customfield.TypeBool:
+		return *v.Bool
+	case customfield.TypeDate:
+		return v.Date.UTC()
+	case customfield.TypeSelect:
+		return v.Select
+	default:
+		return nil
+	}
+}
+
+func escapeCell(s string) string {
+	if s == "" {
+		return s
+	}
+	switch s[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + s
+	}
+	return s
+}
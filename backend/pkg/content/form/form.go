@@ -0,0 +1,151 @@
+package form
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"go-wind-cms/pkg/content/customfield"
+)
+
+const (
+	// MaxFields 单个表单的最大字段数
+	MaxFields = 50
+	// MaxPatternLength 正则约束的最大长度
+	MaxPatternLength = 256
+)
+
+// Format 文本字段的格式约束
+type Format string
+
+const (
+	FormatEmail Format = "email"
+	FormatURL   Format = "url"
+	FormatPhone Format = "phone"
+)
+
+// 表单字段允许的类型：媒体、内容引用与重复组需要登录态或后台上下文，不对匿名访客开放
+var allowedTypes = []customfield.Type{
+	customfield.TypeText,
+	customfield.TypeNumber,
+	customfield.TypeBool,
+	customfield.TypeDate,
+	customfield.TypeSelect,
+}
+
+var phonePattern = regexp.MustCompile(`^\+?[0-9][0-9 ()\-]{4,30}$`)
+
+// Field 表单字段：在自定义字段定义的基础上增加文本格式与正则约束
+type Field struct {
+	customfield.Definition
+
+	Format      Format `json:"format,omitempty"`      // text 格式约束
+	Pattern     string `json:"pattern,omitempty"`     // text 正则约束，须整体匹配
+	Placeholder string `json:"placeholder,omitempty"` // 输入提示
+}
+
+// Definitions 提取字段的自定义字段定义
+func Definitions(fields []Field) []customfield.Definition {
+	defs := make([]customfield.Definition, 0, len(fields))
+	for i := range fields {
+		defs = append(defs, fields[i].Definition)
+	}
+	return defs
+}
+
+// ValidateFields 校验表单字段定义：类型限于 text/number/bool/date/select，
+// 格式与正则仅用于文本字段，其余约束沿用自定义字段的定义校验。
+func ValidateFields(fields []Field) error {
+	if len(fields) == 0 {
+		return &customfield.Error{Message: "at least one field is required"}
+	}
+	if len(fields) > MaxFields {
+		return &customfield.Error{Message: fmt.Sprintf("too many fields (max %d)", MaxFields)}
+	}
+
+	for i := range fields {
+		f := &fields[i]
+		if !slices.Contains(allowedTypes, f.Type) {
+			return &customfield.Error{Path: f.Key, Message: fmt.Sprintf("type %s is not allowed in forms", f.Type)}
+		}
+		if f.Format != "" || f.Pattern != "" {
+			if f.Type != customfield.TypeText {
+				return &customfield.Error{Path: f.Key, Message: "format and pattern only apply to text fields"}
+			}
+		}
+		switch f.Format {
+		case "", FormatEmail, FormatURL, FormatPhone:
+		default:
+			return &customfield.Error{Path: f.Key, Message: fmt.Sprintf("unknown format %s", f.Format)}
+		}
+		if f.Pattern != "" {
+			if len(f.Pattern) > MaxPatternLength {
+				return &customfield.Error{Path: f.Key, Message: "pattern is too long"}
+			}
+			if _, err := compilePattern(f.Pattern); err != nil {
+				return &customfield.Error{Path: f.Key, Message: "invalid pattern: " + err.Error()}
+			}
+		}
+	}
+
+	return customfield.ValidateDefinitions(Definitions(fields))
+}
+
+// Validate 校验一次提交的字段值：先按自定义字段规则校验类型、必填与范围（空值会被移除），
+// 再检查文本字段的格式与正则约束。
+func Validate(fields []Field, values map[string]*customfield.Value) error {
+	if err := customfield.Validate(Definitions(fields), values); err != nil {
+		return err
+	}
+
+	for i := range fields {
+		f := &fields[i]
+		v := values[f.Key]
+		if v == nil || v.Text == nil || *v.Text == "" {
+			continue
+		}
+		text := *v.Text
+
+		if err := checkFormat(f.Format, text); err != nil {
+			return &customfield.Error{Path: f.Key, Message: err.Error()}
+		}
+		if f.Pattern != "" {
+			re, err := compilePattern(f.Pattern)
+			if err != nil {
+				return &customfield.Error{Path: f.Key, Message: "invalid pattern"}
+			}
+			if !re.MatchString(text) {
+				return &customfield.Error{Path: f.Key, Message: "value does not match the required pattern"}
+			}
+		}
+	}
+	return nil
+}
+
+// compilePattern 编译正则并锚定为整体匹配
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile(`^(?:` + pattern + `)$`)
+}
+
+func checkFormat(format Format, text string) error {
+	switch format {
+	case FormatEmail:
+		addr, err := mail.ParseAddress(text)
+		if err != nil || addr.Address != text {
+			return fmt.Errorf("invalid email address")
+		}
+	case FormatURL:
+		u, err := url.Parse(text)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid url")
+		}
+	case FormatPhone:
+		if !phonePattern.MatchString(strings.TrimSpace(text)) {
+			return fmt.Errorf("invalid phone number")
+		}
+	}
+	return nil
+}
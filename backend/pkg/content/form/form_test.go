@@ -0,0 +1,117 @@
+package form
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-wind-cms/pkg/content/customfield"
+)
+
+func text(s string) *customfield.Value { return &customfield.Value{Text: &s} }
+
+func testFields() []Field {
+	return []Field{
+		{Definition: customfield.Definition{Key: "email", Type: customfield.TypeText, Required: true}, Format: FormatEmail},
+		{Definition: customfield.Definition{Key: "code", Type: customfield.TypeText}, Pattern: `[A-Z]{3}-\d+`},
+		{Definition: customfield.Definition{Key: "topic", Type: customfield.TypeSelect, Options: []string{"sales", "support"}, Multiple: true}},
+	}
+}
+
+func TestValidateFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  []Field
+		wantErr string
+	}{
+		{name: "valid", fields: testFields()},
+		{name: "empty", fields: nil, wantErr: "at least one field"},
+		{
+			name:    "media not allowed",
+			fields:  []Field{{Definition: customfield.Definition{Key: "avatar", Type: customfield.TypeMedia}}},
+			wantErr: "avatar: type media is not allowed",
+		},
+		{
+			name:    "format on number",
+			fields:  []Field{{Definition: customfield.Definition{Key: "age", Type: customfield.TypeNumber}, Format: FormatEmail}},
+			wantErr: "age: format and pattern only apply to text fields",
+		},
+		{
+			name:    "bad pattern",
+			fields:  []Field{{Definition: customfield.Definition{Key: "code", Type: customfield.TypeText}, Pattern: `[`}},
+			wantErr: "code: invalid pattern",
+		},
+		{
+			name: "duplicate key from definitions",
+			fields: []Field{
+				{Definition: customfield.Definition{Key: "name", Type: customfield.TypeText}},
+				{Definition: customfield.Definition{Key: "name", Type: customfield.TypeText}},
+			},
+			wantErr: "name",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateFields(tt.fields)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.True(t, customfield.IsValidationError(err))
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  map[string]*customfield.Value
+		wantErr string
+	}{
+		{name: "valid", values: map[string]*customfield.Value{"email": text("a@example.com"), "code": text("ABC-12")}},
+		{name: "missing required", values: map[string]*customfield.Value{}, wantErr: "email: field is required"},
+		{name: "bad email", values: map[string]*customfield.Value{"email": text("Bob <a@example.com>")}, wantErr: "email: invalid email"},
+		{name: "pattern is anchored", values: map[string]*customfield.Value{"email": text("a@example.com"), "code": text("xABC-12")}, wantErr: "code: value does not match"},
+		{name: "unknown field", values: map[string]*customfield.Value{"email": text("a@example.com"), "extra": text("x")}, wantErr: "extra: field is not defined"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(testFields(), tt.values)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExport(t *testing.T) {
+	at := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	rows := []Submission{
+		{ID: 1, SubmittedAt: at, PageID: 3, Values: map[string]*customfield.Value{
+			"email": text("=HYPERLINK(\"http://x\")"),
+			"topic": {Select: []string{"sales", "support"}},
+		}},
+		{ID: 2, SubmittedAt: at, Values: map[string]*customfield.Value{"email": text("b@example.com")}},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteCSV(&buf, testFields(), rows))
+	assert.Equal(t,
+		"id,submitted_at,page_id,email,code,topic\n"+
+			"1,2024-05-01T08:00:00Z,3,\"'=HYPERLINK(\"\"http://x\"\")\",,sales; support\n"+
+			"2,2024-05-01T08:00:00Z,,b@example.com,,\n",
+		buf.String())
+
+	buf.Reset()
+	assert.NoError(t, WriteJSON(&buf, testFields(), rows[1:]))
+	assert.JSONEq(t, `[{"id":2,"submitted_at":"2024-05-01T08:00:00Z","values":{"email":"b@example.com"}}]`, buf.String())
+}
@@ -0,0 +1,188 @@
+// Package importer 解析 WordPress WXR 导出文件与带 YAML front matter 的 Markdown 目录，
+// 转换为与来源无关的 Bundle，由 core 写入文章、页面、分类、标签与评论。
+//
+// 解析只读取输入，不访问网络；附件的下载与链接改写由调用方按 Bundle 中的地址完成。
+package importer
+
+import (
+	"fmt"
+	"io/fs"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/tx7do/go-utils/slug"
+)
+
+// Kind 导入条目的类型
+type Kind string
+
+const (
+	KindPost Kind = "post"
+	KindPage Kind = "page"
+)
+
+// Status 源内容的发布状态
+type Status string
+
+const (
+	StatusDraft     Status = "draft"
+	StatusPublished Status = "published"
+	StatusScheduled Status = "scheduled" // 定时发布，发布时间在将来
+)
+
+// Site 源站点信息
+type Site struct {
+	Title    string
+	BaseURL  string // 旧站点地址，用于识别站内链接
+	Language string
+}
+
+// Author 源站点的作者
+type Author struct {
+	Login       string
+	Email       string
+	DisplayName string
+}
+
+// Term 分类或标签；Parent 为父分类的 slug
+type Term struct {
+	Slug        string
+	Name        string
+	Description string
+	Parent      string
+}
+
+// Comment 评论；ParentID 为回复的评论在源站点的 ID
+type Comment struct {
+	ID          string
+	ParentID    string
+	AuthorName  string
+	AuthorEmail string
+	AuthorURL   string
+	AuthorIP    string
+	Content     string
+	Date        time.Time
+	Approved    bool
+}
+
+// Attachment 附件，远程地址 URL 与归档内路径 Path 二选一
+type Attachment struct {
+	ID       string // 源站点的附件 ID，Markdown 中为归档内路径或远程地址
+	URL      string
+	Path     string
+	FileName string
+	Title    string
+}
+
+// Item 文章或页面
+type Item struct {
+	Kind     Kind
+	SourceID string // 源站点的 ID，Markdown 中为文件路径
+	Title    string
+	Slug     string
+	Link     string   // 旧地址，用于改写站内链接
+	Aliases  []string // 其他旧地址
+	Content  string
+	Excerpt  string
+	Markdown bool // 正文为 Markdown，否则为 HTML
+	Status   Status
+	Password string
+	Language string // 源内容声明的语言，为空时使用导入作业的语言
+
+	Published time.Time
+	Modified  time.Time
+
+	Author     string // 作者的 Login，Markdown 中为作者名称
+	Categories []string
+	Tags       []string
+
+	ParentID  string // 父页面在源站点的 ID
+	MenuOrder int32
+
+	FeaturedImage  string // 特色图片的附件 ID 或地址
+	Comments       []Comment
+	CommentsClosed bool
+
+	// Refs 正文中引用的本地文件：原始写法到附件 ID 或其他条目的 SourceID，改写链接时优先匹配
+	Refs map[string]string
+}
+
+// Issue 解析时跳过或无法识别的内容
+type Issue struct {
+	Ref     string // 文件路径或源站点 ID
+	Message string
+}
+
+// Bundle 解析结果
+type Bundle struct {
+	Site        Site
+	Authors     []Author
+	Categories  []Term
+	Tags        []Term
+	Items       []*Item
+	Attachments []*Attachment
+
+	// Files Markdown 归档的文件系统，附件 Path 相对于它；WXR 为 nil
+	Files fs.FS
+
+	Issues []Issue
+}
+
+func (b *Bundle) skip(ref, format string, args ...any) {
+	b.Issues = append(b.Issues, Issue{Ref: ref, Message: fmt.Sprintf(format, args...)})
+}
+
+// Attachment 按 ID 查找附件
+func (b *Bundle) Attachment(id string) *Attachment {
+	for _, a := range b.Attachments {
+		if a.ID == id {
+			return a
+		}
+	}
+	return nil
+}
+
+// Count 按类型统计条目数
+func (b *Bundle) Count(kind Kind) int {
+	n := 0
+	for _, it := range b.Items {
+		if it.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+var reSlugDash = regexp.MustCompile(`-{2,}`)
+
+// Slugify 把源站点的 slug（WordPress 中可能经过 URL 编码）或标题规范为 slug
+func Slugify(s string) string {
+	if u, err := url.PathUnescape(s); err == nil {
+		s = u
+	}
+	s = slug.Generate(strings.TrimSpace(s))
+	return strings.Trim(reSlugDash.ReplaceAllString(s, "-"), "-")
+}
+
+// addTerm 按 slug 去重追加分类或标签，返回其 slug
+func addTerm(terms []Term, name, termSlug string) ([]Term, string) {
+	name = strings.TrimSpace(name)
+	termSlug = Slugify(termSlug)
+	if termSlug == "" {
+		termSlug = Slugify(name)
+	}
+	if termSlug == "" {
+		return terms, ""
+	}
+	for _, t := range terms {
+		if t.Slug == termSlug {
+			return terms, termSlug
+		}
+	}
+	if name == "" {
+		name = termSlug
+	}
+	return append(terms, Term{Slug: termSlug, Name: name}), termSlug
+}
@@ -0,0 +1,212 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testWXR = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0"
+	xmlns:excerpt="http://wordpress.org/export/1.2/excerpt/"
+	xmlns:content="http://purl.org/rss/1.0/modules/content/"
+	xmlns:dc="http://purl.org/dc/elements/1.1/"
+	xmlns:wp="http://wordpress.org/export/1.2/">
+<channel>
+	<title>Old Blog</title>
+	<link>https://old.example.com</link>
+	<language>en-US</language>
+	<wp:base_blog_url>https://old.example.com</wp:base_blog_url>
+	<wp:author><wp:author_login><![CDATA[alice]]></wp:author_login><wp:author_email><![CDATA[alice@example.com]]></wp:author_email><wp:author_display_name><![CDATA[Alice]]></wp:author_display_name></wp:author>
+	<wp:category><wp:category_nicename><![CDATA[go]]></wp:category_nicename><wp:category_parent><![CDATA[tech]]></wp:category_parent><wp:cat_name><![CDATA[Go]]></wp:cat_name></wp:category>
+	<wp:category><wp:category_nicename><![CDATA[tech]]></wp:category_nicename><wp:category_parent><![CDATA[]]></wp:category_parent><wp:cat_name><![CDATA[Tech]]></wp:cat_name></wp:category>
+	<wp:tag><wp:tag_slug><![CDATA[cms]]></wp:tag_slug><wp:tag_name><![CDATA[CMS]]></wp:tag_name></wp:tag>
+	<item>
+		<title>Hello World</title>
+		<link>https://old.example.com/2024/01/hello-world/</link>
+		<dc:creator><![CDATA[alice]]></dc:creator>
+		<content:encoded><![CDATA[<p>See <a href="https://old.example.com/about/">about</a>.</p>]]></content:encoded>
+		<excerpt:encoded><![CDATA[Intro]]></excerpt:encoded>
+		<wp:post_id>10</wp:post_id>
+		<wp:post_date><![CDATA[2024-01-02 08:00:00]]></wp:post_date>
+		<wp:post_date_gmt><![CDATA[2024-01-02 00:00:00]]></wp:post_date_gmt>
+		<wp:comment_status><![CDATA[open]]></wp:comment_status>
+		<wp:post_name><![CDATA[hello-world]]></wp:post_name>
+		<wp:status><![CDATA[publish]]></wp:status>
+		<wp:post_parent>0</wp:post_parent>
+		<wp:post_type><![CDATA[post]]></wp:post_type>
+		<category domain="category" nicename="go"><![CDATA[Go]]></category>
+		<category domain="post_tag" nicename="cms"><![CDATA[CMS]]></category>
+		<wp:postmeta><wp:meta_key><![CDATA[_thumbnail_id]]></wp:meta_key><wp:meta_value><![CDATA[30]]></wp:meta_value></wp:postmeta>
+		<wp:comment>
+			<wp:comment_id>1</wp:comment_id>
+			<wp:comment_author><![CDATA[Bob]]></wp:comment_author>
+			<wp:comment_date_gmt><![CDATA[2024-01-03 00:00:00]]></wp:comment_date_gmt>
+			<wp:comment_content><![CDATA[Nice]]></wp:comment_content>
+			<wp:comment_approved><![CDATA[1]]></wp:comment_approved>
+			<wp:comment_type><![CDATA[comment]]></wp:comment_type>
+			<wp:comment_parent>0</wp:comment_parent>
+		</wp:comment>
+		<wp:comment>
+			<wp:comment_id>2</wp:comment_id>
+			<wp:comment_content><![CDATA[buy now]]></wp:comment_content>
+			<wp:comment_approved><![CDATA[spam]]></wp:comment_approved>
+		</wp:comment>
+	</item>
+	<item>
+		<title>About</title>
+		<link>https://old.example.com/about/</link>
+		<content:encoded><![CDATA[About us]]></content:encoded>
+		<wp:post_id>20</wp:post_id>
+		<wp:post_date_gmt><![CDATA[0000-00-00 00:00:00]]></wp:post_date_gmt>
+		<wp:post_date><![CDATA[2023-05-01 10:00:00]]></wp:post_date>
+		<wp:post_name><![CDATA[about]]></wp:post_name>
+		<wp:status><![CDATA[draft]]></wp:status>
+		<wp:menu_order>3</wp:menu_order>
+		<wp:post_type><![CDATA[page]]></wp:post_type>
+	</item>
+	<item>
+		<title>photo</title>
+		<wp:post_id>30</wp:post_id>
+		<wp:status><![CDATA[inherit]]></wp:status>
+		<wp:post_type><![CDATA[attachment]]></wp:post_type>
+		<wp:attachment_url><![CDATA[https://old.example.com/wp-content/uploads/2024/01/photo.jpg]]></wp:attachment_url>
+	</item>
+	<item>
+		<title>Menu</title>
+		<wp:post_id>40</wp:post_id>
+		<wp:post_type><![CDATA[nav_menu_item]]></wp:post_type>
+	</item>
+</channel>
+</rss>`
+
+func TestParseWXR(t *testing.T) {
+	b, err := ParseWXR(strings.NewReader(testWXR))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "https://old.example.com", b.Site.BaseURL)
+	assert.Equal(t, []Author{{Login: "alice", Email: "alice@example.com", DisplayName: "Alice"}}, b.Authors)
+	assert.Equal(t, []Term{{Slug: "go", Name: "Go", Parent: "tech"}, {Slug: "tech", Name: "Tech"}}, b.Categories)
+	assert.Equal(t, []Term{{Slug: "cms", Name: "CMS"}}, b.Tags)
+	assert.Len(t, b.Issues, 1)
+
+	assert.Len(t, b.Items, 2)
+	post := b.Items[0]
+	assert.Equal(t, KindPost, post.Kind)
+	assert.Equal(t, "hello-world", post.Slug)
+	assert.Equal(t, StatusPublished, post.Status)
+	assert.Equal(t, "Intro", post.Excerpt)
+	assert.Contains(t, post.Content, `href="https://old.example.com/about/"`)
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), post.Published)
+	assert.Equal(t, []string{"go"}, post.Categories)
+	assert.Equal(t, []string{"cms"}, post.Tags)
+	assert.Equal(t, "30", post.FeaturedImage)
+	assert.Len(t, post.Comments, 1)
+	assert.True(t, post.Comments[0].Approved)
+	assert.Empty(t, post.Comments[0].ParentID)
+
+	page := b.Items[1]
+	assert.Equal(t, KindPage, page.Kind)
+	assert.Equal(t, StatusDraft, page.Status)
+	assert.Equal(t, int32(3), page.MenuOrder)
+	assert.Equal(t, time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC), page.Published)
+
+	assert.Equal(t, "https://old.example.com/wp-content/uploads/2024/01/photo.jpg", b.Attachment("30").URL)
+
+	_, err = ParseWXR(strings.NewReader("<rss><channel>"))
+	assert.Error(t, err)
+}
+
+func TestParseMarkdown(t *testing.T) {
+	fsys := fstest.MapFS{
+		"content/posts/2024-03-01-first-post.md": {Data: []byte("---\r\n" +
+			"title: First Post\r\n" +
+			"tags: [Go, CMS]\r\n" +
+			"categories: Notes\r\n" +
+			"image: /images/cover.png\r\n" +
+			"aliases:\r\n  - /old/first/\r\n" +
+			"---\r\n" +
+			"![diagram](./diagram.png) and [second](../posts/second.md) and ![remote](https://cdn.example.com/a.png)\r\n")},
+		"content/posts/diagram.png":     {Data: []byte("png")},
+		"content/posts/second/index.md": {Data: []byte("---\ndraft: true\ndate: 2024-03-02 10:00:00 +0800\n---\n# Second Title\nbody")},
+		"content/pages/about.md":        {Data: []byte("---\ntitle: About\n---\nabout")},
+		"content/posts/_index.md":       {Data: []byte("---\ntitle: Posts\n---\n")},
+		"content/posts/toml.md":         {Data: []byte("+++\ntitle = \"x\"\n+++\n")},
+		"static/images/cover.png":       {Data: []byte("png")},
+		"public/index.md":               {Data: []byte("build output")},
+	}
+
+	b, err := ParseMarkdown(fsys)
+	assert.NoError(t, err)
+	assert.Len(t, b.Issues, 1)
+	assert.Len(t, b.Items, 3)
+
+	byID := map[string]*Item{}
+	for _, it := range b.Items {
+		byID[it.SourceID] = it
+	}
+
+	first := byID["content/posts/2024-03-01-first-post.md"]
+	assert.Equal(t, "first-post", first.Slug)
+	assert.True(t, first.Markdown)
+	assert.Equal(t, StatusPublished, first.Status)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), first.Published)
+	assert.Equal(t, []string{"go", "cms"}, first.Tags)
+	assert.Equal(t, []string{"notes"}, first.Categories)
+	assert.Equal(t, []string{"/old/first/"}, first.Aliases)
+	assert.Equal(t, "static/images/cover.png", first.FeaturedImage)
+	assert.Equal(t, "content/posts/diagram.png", first.Refs["./diagram.png"])
+	_, linked := first.Refs["../posts/second.md"]
+	assert.False(t, linked, "missing files are not resolved")
+
+	second := byID["content/posts/second/index.md"]
+	assert.Equal(t, "second", second.Slug)
+	assert.Equal(t, "Second Title", second.Title)
+	assert.Equal(t, StatusDraft, second.Status)
+	assert.Equal(t, time.Date(2024, 3, 2, 2, 0, 0, 0, time.UTC), second.Published.UTC())
+
+	assert.Equal(t, KindPage, byID["content/pages/about.md"].Kind)
+
+	assert.NotNil(t, b.Attachment("content/posts/diagram.png"))
+	assert.NotNil(t, b.Attachment("static/images/cover.png"))
+	assert.Equal(t, "https://cdn.example.com/a.png", b.Attachment("https://cdn.example.com/a.png").URL)
+}
+
+func TestRewriter(t *testing.T) {
+	r := NewRewriter("https://www.old.example.com")
+	r.Map("https://old.example.com/2024/01/hello-world/", "/en/post/hello-world")
+	r.Map("https://old.example.com/?p=10", "/en/post/hello-world")
+	r.Map("https://old.example.com/wp-content/uploads/2024/01/photo.jpg", "https://cdn.new/photo.jpg")
+	r.MapSlug("about", "/en/about")
+
+	for _, tc := range []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"http://OLD.example.com/2024/01/hello-world#top", "/en/post/hello-world#top", true},
+		{"/?p=10", "/en/post/hello-world", true},
+		{"https://old.example.com/wp-content/uploads/2024/01/photo-300x200.jpg", "https://cdn.new/photo.jpg", true},
+		{"https://old.example.com/company/about/", "/en/about", true},
+		{"https://other.example.com/about/", "", false},
+		{"#top", "", false},
+		{"mailto:a@b.c", "", false},
+	} {
+		got, ok := r.Resolve(tc.in)
+		assert.Equal(t, tc.ok, ok, tc.in)
+		assert.Equal(t, tc.want, got, tc.in)
+	}
+
+	html := `<a href="https://old.example.com/about/">About</a><img src='https://old.example.com/wp-content/uploads/2024/01/photo.jpg' srcset="https://old.example.com/wp-content/uploads/2024/01/photo-300x200.jpg 300w, https://x.com/y.jpg 600w">`
+	assert.Equal(t,
+		`<a href="/en/about">About</a><img src='https://cdn.new/photo.jpg' srcset="https://cdn.new/photo.jpg 300w, https://x.com/y.jpg 600w">`,
+		r.Rewrite(html, false, nil))
+
+	md := "![d](./diagram.png \"t\") [home](/?p=10)\n[ref]: https://old.example.com/about/\n"
+	assert.Equal(t,
+		"![d](/files/diagram.png \"t\") [home](/en/post/hello-world)\n[ref]: /en/about\n",
+		r.Rewrite(md, true, map[string]string{"./diagram.png": "/files/diagram.png"}))
+}
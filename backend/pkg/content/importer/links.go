@@ -0,0 +1,201 @@
+package importer
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var (
+	reHTMLAttr   = regexp.MustCompile(`(?i)\s(?:href|src)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+	reSrcset     = regexp.MustCompile(`(?i)\ssrcset\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+	reRefDef     = regexp.MustCompile(`(?m)^[ ]{0,3}\[[^\]]+\]:[ \t]*<?([^\s>]+)>?`)
+	reSizeSuffix = regexp.MustCompile(`-\d+x\d+(\.[A-Za-z0-9]+)$`)
+)
+
+// Rewriter 把正文中指向旧站点的链接改写为导入后的地址，附件地址改写为新的存储地址。
+//
+// 旧地址按主机名（忽略大小写、www. 前缀与协议）、路径（忽略末尾斜杠）与查询串匹配，锚点保留；
+// 找不到时依次尝试 WordPress 的缩略图尺寸后缀（-300x200）与路径最后一段的 slug。
+type Rewriter struct {
+	hosts map[string]bool
+	urls  map[string]string
+	slugs map[string]string
+}
+
+// NewRewriter 以旧站点地址创建，相对地址与这些主机名下的地址视为站内链接
+func NewRewriter(baseURLs ...string) *Rewriter {
+	r := &Rewriter{
+		hosts: map[string]bool{},
+		urls:  map[string]string{},
+		slugs: map[string]string{},
+	}
+	for _, b := range baseURLs {
+		if u, err := url.Parse(strings.TrimSpace(b)); err == nil && u.Host != "" {
+			r.hosts[normalizeLinkHost(u.Host)] = true
+		}
+	}
+	return r
+}
+
+func normalizeLinkHost(host string) string {
+	host = strings.ToLower(host)
+	if h, _, ok := strings.Cut(host, ":"); ok {
+		host = h
+	}
+	return strings.TrimPrefix(host, "www.")
+}
+
+// key 规范化地址；站内地址不含主机名。无法解析时返回空
+func (r *Rewriter) key(raw string) (key, fragment string, internal bool) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", "", false
+	}
+	if u.Scheme != "" && u.Scheme != "http" && u.Scheme != "https" {
+		return "", "", false
+	}
+	if u.Host == "" && u.Path == "" && u.RawQuery == "" {
+		// 空地址或页内锚点
+		return "", "", false
+	}
+
+	host := normalizeLinkHost(u.Host)
+	internal = host == "" || r.hosts[host]
+	if internal {
+		host = ""
+	}
+
+	p := strings.TrimRight(u.EscapedPath(), "/")
+	if p == "" {
+		p = "/"
+	}
+	if !strings.HasPrefix(p, "/") {
+		// 相对路径无法确定基准目录，只能按 slug 兜底
+		p = "/" + p
+	}
+
+	key = host + p
+	if u.RawQuery != "" {
+		key += "?" + u.RawQuery
+	}
+	return key, u.Fragment, internal
+}
+
+// Map 登记旧地址对应的新地址
+func (r *Rewriter) Map(oldURL, newURL string) {
+	if oldURL == "" || newURL == "" {
+		return
+	}
+	if k, _, _ := r.key(oldURL); k != "" {
+		r.urls[k] = newURL
+	}
+}
+
+// MapSlug 登记 slug 对应的新地址，用于站内链接的兜底匹配
+func (r *Rewriter) MapSlug(slug, newURL string) {
+	if slug != "" && newURL != "" {
+		r.slugs[slug] = newURL
+	}
+}
+
+// Resolve 查找旧地址对应的新地址
+func (r *Rewriter) Resolve(raw string) (string, bool) {
+	k, fragment, internal := r.key(raw)
+	if k == "" {
+		return "", false
+	}
+
+	withFragment := func(s string) string {
+		if fragment != "" {
+			return s + "#" + fragment
+		}
+		return s
+	}
+
+	if v, ok := r.urls[k]; ok {
+		return withFragment(v), true
+	}
+	if stripped := reSizeSuffix.ReplaceAllString(k, "$1"); stripped != k {
+		if v, ok := r.urls[stripped]; ok {
+			return withFragment(v), true
+		}
+	}
+	if internal {
+		p, _, _ := strings.Cut(k, "?")
+		last := strings.TrimSuffix(path.Base(p), path.Ext(p))
+		if v, ok := r.slugs[last]; ok {
+			return withFragment(v), true
+		}
+	}
+	return "", false
+}
+
+// Rewrite 改写正文中的链接；refs 为该条目正文中本地引用的原始写法到新地址，优先于按地址匹配
+func (r *Rewriter) Rewrite(content string, markdown bool, refs map[string]string) string {
+	replace := func(raw string) string {
+		if v, ok := refs[strings.TrimSpace(raw)]; ok {
+			return v
+		}
+		if v, ok := r.Resolve(raw); ok {
+			return v
+		}
+		return raw
+	}
+
+	content = replaceGroups(content, reHTMLAttr, replace)
+	content = replaceGroups(content, reSrcset, func(set string) string {
+		parts := strings.Split(set, ",")
+		for i, part := range parts {
+			fields := strings.Fields(part)
+			if len(fields) == 0 {
+				continue
+			}
+			fields[0] = replace(fields[0])
+			parts[i] = strings.Join(fields, " ")
+		}
+		return strings.Join(parts, ", ")
+	})
+	if markdown {
+		content = replaceGroups(content, reMarkdownLink, replace, 2)
+		content = replaceGroups(content, reRefDef, replace)
+	}
+	return content
+}
+
+// replaceGroups 替换正则每个匹配中已匹配的捕获组；指定 groups 时只替换这些组
+func replaceGroups(s string, re *regexp.Regexp, fn func(string) string, groups ...int) string {
+	matches := re.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return s
+	}
+
+	var sb strings.Builder
+	last := 0
+	for _, m := range matches {
+		for g := 1; g*2 < len(m); g++ {
+			if len(groups) > 0 && !containsInt(groups, g) {
+				continue
+			}
+			start, end := m[g*2], m[g*2+1]
+			if start < 0 || start < last {
+				continue
+			}
+			sb.WriteString(s[last:start])
+			sb.WriteString(fn(s[start:end]))
+			last = end
+		}
+	}
+	sb.WriteString(s[last:])
+	return sb.String()
+}
+
+func containsInt(values []int, v int) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
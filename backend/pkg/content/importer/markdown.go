@@ -0,0 +1,455 @@
+package importer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// maxMarkdownFileSize 单个 Markdown 文件的大小上限
+	maxMarkdownFileSize = 8 << 20
+
+	// maxMarkdownFiles 单次导入的 Markdown 文件数上限
+	maxMarkdownFiles = 10000
+)
+
+// skippedDirs 静态站点生成器的构建输出与依赖目录，不含源内容
+var skippedDirs = map[string]bool{
+	"node_modules": true,
+	"public":       true,
+	"_site":        true,
+	"resources":    true,
+	"layouts":      true,
+	"_layouts":     true,
+	"_includes":    true,
+	"themes":       true,
+}
+
+// staticRoots 以 / 开头的本地引用依次在这些目录下查找（Hugo 的 static、Jekyll/Hexo 的站点根目录等）
+var staticRoots = []string{"", "static", "assets", "source", "public"}
+
+var (
+	reJekyllDate   = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})-(.+)$`)
+	reMarkdownLink = regexp.MustCompile(`(!?)\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+	reHTMLRef      = regexp.MustCompile(`(?i)<(img|a)\b[^>]*?\s(?:src|href)\s*=\s*["']([^"']+)["']`)
+	reHeading      = regexp.MustCompile(`(?m)^#\s+(.+?)\s*#*\s*$`)
+)
+
+// fmTime front matter 中的时间，兼容 Hugo、Jekyll、Hexo 的常见写法；未带时区时按 UTC 处理
+type fmTime struct{ time.Time }
+
+var fmTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05 -07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+func (t *fmTime) UnmarshalYAML(node *yaml.Node) error {
+	v := strings.TrimSpace(node.Value)
+	if v == "" {
+		return nil
+	}
+	for _, layout := range fmTimeLayouts {
+		if parsed, err := time.Parse(layout, v); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid date %q", v)
+}
+
+// fmList 字符串或字符串列表；字符串按逗号拆分
+type fmList []string
+
+func (l *fmList) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		for _, v := range strings.Split(node.Value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				*l = append(*l, v)
+			}
+		}
+		return nil
+	case yaml.SequenceNode:
+		var values []string
+		if err := node.Decode(&values); err != nil {
+			return err
+		}
+		for _, v := range values {
+			if v = strings.TrimSpace(v); v != "" {
+				*l = append(*l, v)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("expected a string or a list of strings")
+	}
+}
+
+// frontMatter 常见静态站点生成器的 front matter 字段
+type frontMatter struct {
+	Title         string `yaml:"title"`
+	Slug          string `yaml:"slug"`
+	Date          fmTime `yaml:"date"`
+	PublishDate   fmTime `yaml:"publishDate"`
+	Lastmod       fmTime `yaml:"lastmod"`
+	Updated       fmTime `yaml:"updated"`
+	Draft         bool   `yaml:"draft"`
+	Published     *bool  `yaml:"published"`
+	Categories    fmList `yaml:"categories"`
+	Category      fmList `yaml:"category"`
+	Tags          fmList `yaml:"tags"`
+	Summary       string `yaml:"summary"`
+	Description   string `yaml:"description"`
+	Excerpt       string `yaml:"excerpt"`
+	Author        fmList `yaml:"author"`
+	Authors       fmList `yaml:"authors"`
+	Type          string `yaml:"type"`
+	Layout        string `yaml:"layout"`
+	Image         string `yaml:"image"`
+	Thumbnail     string `yaml:"thumbnail"`
+	FeaturedImage string `yaml:"featured_image"`
+	Weight        int32  `yaml:"weight"`
+	Comments      *bool  `yaml:"comments"`
+	Aliases       fmList `yaml:"aliases"`
+	Permalink     string `yaml:"permalink"`
+	URL           string `yaml:"url"`
+	Lang          string `yaml:"lang"`
+}
+
+// splitFrontMatter 拆分 YAML front matter 与正文；没有 front matter 时返回空
+func splitFrontMatter(data []byte) (front, body []byte, err error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+
+	if bytes.HasPrefix(data, []byte("+++\n")) {
+		return nil, nil, errors.New("toml front matter is not supported")
+	}
+	if !bytes.HasPrefix(data, []byte("---\n")) {
+		return nil, data, nil
+	}
+
+	rest := data[4:]
+	for _, delim := range []string{"---", "..."} {
+		if bytes.HasPrefix(rest, []byte(delim+"\n")) || bytes.Equal(rest, []byte(delim)) {
+			return nil, bytes.TrimPrefix(rest[len(delim):], []byte("\n")), nil
+		}
+		if i := bytes.Index(rest, []byte("\n"+delim+"\n")); i >= 0 {
+			return rest[:i], rest[i+len(delim)+2:], nil
+		}
+		if bytes.HasSuffix(rest, []byte("\n"+delim)) {
+			return rest[:len(rest)-len(delim)-1], nil, nil
+		}
+	}
+	return nil, nil, errors.New("unterminated front matter")
+}
+
+// isMarkdown 按扩展名判断是否为 Markdown 文件
+func isMarkdown(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".md", ".markdown":
+		return true
+	}
+	return false
+}
+
+// isExternal 判断引用是否指向站外或非文件（锚点、邮件等）
+func isExternal(ref string) bool {
+	if strings.HasPrefix(ref, "#") || strings.HasPrefix(ref, "//") {
+		return true
+	}
+	if i := strings.Index(ref, ":"); i > 0 && !strings.ContainsAny(ref[:i], "/?#") {
+		return true
+	}
+	return false
+}
+
+// ParseMarkdown 解析 Markdown 目录（Hugo、Jekyll、Hexo 等静态站点生成器的源文件）。
+//
+// 每个 .md/.markdown 文件为一篇文章，front matter 的 type/layout 为 page 或位于 pages、_pages
+// 目录下时为页面；正文中引用的本地图片等文件作为附件，引用其他 Markdown 文件的链接记入 Refs。
+// 构建输出目录、主题与 Hugo 的 _index.md 列表页跳过。
+func ParseMarkdown(fsys fs.FS) (*Bundle, error) {
+	b := &Bundle{Files: fsys}
+	attachments := map[string]*Attachment{}
+
+	var files []string
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if p != "." && (strings.HasPrefix(name, ".") || skippedDirs[name]) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !isMarkdown(name) {
+			return nil
+		}
+		if len(files) >= maxMarkdownFiles {
+			return fmt.Errorf("too many markdown files (max %d)", maxMarkdownFiles)
+		}
+		files = append(files, p)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walk markdown files: %w", err)
+	}
+
+	for _, p := range files {
+		item, err := parseMarkdownFile(fsys, p)
+		if err != nil {
+			b.skip(p, "%s", err.Error())
+			continue
+		}
+		if item == nil {
+			continue
+		}
+
+		for _, name := range item.Categories {
+			b.Categories, _ = addTerm(b.Categories, name, "")
+		}
+		for _, name := range item.Tags {
+			b.Tags, _ = addTerm(b.Tags, name, "")
+		}
+		item.Categories = termSlugs(item.Categories)
+		item.Tags = termSlugs(item.Tags)
+
+		b.collectRefs(item, attachments)
+		b.Items = append(b.Items, item)
+	}
+
+	return b, nil
+}
+
+// parseMarkdownFile 解析单个文件；Hugo 的 _index.md 列表页返回 nil
+func parseMarkdownFile(fsys fs.FS, p string) (*Item, error) {
+	f, err := fsys.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxMarkdownFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxMarkdownFileSize {
+		return nil, fmt.Errorf("file exceeds %d bytes", maxMarkdownFileSize)
+	}
+
+	front, body, err := splitFrontMatter(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var fm frontMatter
+	if len(front) > 0 {
+		if err = yaml.Unmarshal(front, &fm); err != nil {
+			// 类型不符的字段被忽略，其余字段照常解析
+			var typeErr *yaml.TypeError
+			if !errors.As(err, &typeErr) {
+				return nil, fmt.Errorf("parse front matter: %w", err)
+			}
+		}
+	}
+
+	dir, file := path.Split(p)
+	dir = strings.TrimSuffix(dir, "/")
+	base := strings.TrimSuffix(file, path.Ext(file))
+	if base == "_index" {
+		return nil, nil
+	}
+	if strings.EqualFold(base, "readme") && len(front) == 0 {
+		return nil, nil
+	}
+	if base == "index" && dir != "" {
+		// Hugo 页面包：content/posts/hello/index.md
+		base = path.Base(dir)
+	}
+
+	item := &Item{
+		SourceID:   p,
+		Kind:       KindPost,
+		Title:      strings.TrimSpace(fm.Title),
+		Content:    strings.TrimSpace(string(body)),
+		Markdown:   true,
+		Status:     StatusPublished,
+		Excerpt:    strings.TrimSpace(firstNonBlank(fm.Summary, fm.Description, fm.Excerpt)),
+		Link:       strings.TrimSpace(firstNonBlank(fm.Permalink, fm.URL)),
+		Aliases:    fm.Aliases,
+		Language:   strings.TrimSpace(fm.Lang),
+		MenuOrder:  fm.Weight,
+		Published:  firstTime(fm.Date.Time, fm.PublishDate.Time),
+		Modified:   firstTime(fm.Lastmod.Time, fm.Updated.Time),
+		Categories: append(fm.Categories, fm.Category...),
+		Tags:       fm.Tags,
+	}
+
+	if m := reJekyllDate.FindStringSubmatch(base); m != nil {
+		base = m[2]
+		if item.Published.IsZero() {
+			item.Published, _ = time.Parse("2006-01-02", m[1])
+		}
+	}
+
+	item.Slug = Slugify(firstNonBlank(fm.Slug, base))
+	if item.Title == "" {
+		if m := reHeading.FindStringSubmatch(item.Content); m != nil {
+			item.Title = m[1]
+		} else {
+			item.Title = base
+		}
+	}
+
+	if len(fm.Author) > 0 {
+		item.Author = fm.Author[0]
+	} else if len(fm.Authors) > 0 {
+		item.Author = fm.Authors[0]
+	}
+
+	segments := strings.Split(dir, "/")
+	for _, s := range segments {
+		if s == "pages" || s == "_pages" {
+			item.Kind = KindPage
+		}
+	}
+	if fm.Type == "page" || fm.Layout == "page" {
+		item.Kind = KindPage
+	}
+
+	switch {
+	case fm.Draft, fm.Published != nil && !*fm.Published:
+		item.Status = StatusDraft
+	case len(segments) > 0 && segments[0] == "_drafts":
+		item.Status = StatusDraft
+	case item.Published.After(time.Now()):
+		item.Status = StatusScheduled
+	}
+
+	if fm.Comments != nil {
+		item.CommentsClosed = !*fm.Comments
+	}
+	item.FeaturedImage = strings.TrimSpace(firstNonBlank(fm.FeaturedImage, fm.Image, fm.Thumbnail))
+
+	return item, nil
+}
+
+// collectRefs 识别正文与特色图片中引用的本地文件与远程图片，登记为附件；
+// 指向其他 Markdown 文件的链接以其路径作为条目 SourceID 记入 Refs
+func (b *Bundle) collectRefs(item *Item, attachments map[string]*Attachment) {
+	dir := path.Dir(item.SourceID)
+
+	addRef := func(ref string, image bool) string {
+		ref = strings.TrimSpace(ref)
+		if ref == "" {
+			return ""
+		}
+
+		if isExternal(ref) {
+			lower := strings.ToLower(ref)
+			if !image || !(strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")) {
+				return ""
+			}
+			if _, ok := attachments[ref]; !ok {
+				a := &Attachment{ID: ref, URL: ref, FileName: path.Base(strings.SplitN(ref, "?", 2)[0])}
+				attachments[ref] = a
+				b.Attachments = append(b.Attachments, a)
+			}
+			return ref
+		}
+
+		target := ref
+		if i := strings.IndexAny(target, "?#"); i >= 0 {
+			target = target[:i]
+		}
+		if target == "" {
+			return ""
+		}
+
+		resolved := b.resolveLocal(dir, target)
+		if resolved == "" {
+			return ""
+		}
+		if item.Refs == nil {
+			item.Refs = map[string]string{}
+		}
+		item.Refs[ref] = resolved
+		if isMarkdown(resolved) {
+			return resolved
+		}
+
+		if _, ok := attachments[resolved]; !ok {
+			a := &Attachment{ID: resolved, Path: resolved, FileName: path.Base(resolved)}
+			attachments[resolved] = a
+			b.Attachments = append(b.Attachments, a)
+		}
+		return resolved
+	}
+
+	for _, m := range reMarkdownLink.FindAllStringSubmatch(item.Content, -1) {
+		addRef(m[2], m[1] == "!")
+	}
+	for _, m := range reHTMLRef.FindAllStringSubmatch(item.Content, -1) {
+		addRef(m[2], strings.EqualFold(m[1], "img"))
+	}
+	if item.FeaturedImage != "" {
+		item.FeaturedImage = addRef(item.FeaturedImage, true)
+	}
+}
+
+// resolveLocal 把相对引用解析为归档内的文件路径，文件不存在时返回空
+func (b *Bundle) resolveLocal(dir, ref string) string {
+	var candidates []string
+	if strings.HasPrefix(ref, "/") {
+		for _, root := range staticRoots {
+			candidates = append(candidates, path.Join(root, strings.TrimPrefix(ref, "/")))
+		}
+	} else {
+		candidates = append(candidates, path.Join(dir, ref))
+	}
+
+	for _, c := range candidates {
+		if c == "." || strings.HasPrefix(c, "../") || c == ".." {
+			continue
+		}
+		if info, err := fs.Stat(b.Files, c); err == nil && !info.IsDir() {
+			return c
+		}
+	}
+	return ""
+}
+
+func termSlugs(names []string) []string {
+	out := make([]string, 0, len(names))
+	for _, n := range names {
+		if s := Slugify(n); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func firstTime(times ...time.Time) time.Time {
+	for _, t := range times {
+		if !t.IsZero() {
+			return t
+		}
+	}
+	return time.Time{}
+}
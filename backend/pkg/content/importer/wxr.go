@@ -0,0 +1,340 @@
+package importer
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WXR（WordPress eXtended RSS）导出文件的结构。
+// 1.0/1.1/1.2 版本的 wp 命名空间地址不同，字段标签只写本地名以兼容各版本；
+// content:encoded 与 excerpt:encoded 本地名相同，按命名空间区分。
+
+type wxrDocument struct {
+	Channel wxrChannel `xml:"channel"`
+}
+
+type wxrChannel struct {
+	Title      string        `xml:"title"`
+	Link       string        `xml:"link"`
+	Language   string        `xml:"language"`
+	BaseSite   string        `xml:"base_site_url"`
+	BaseBlog   string        `xml:"base_blog_url"`
+	Authors    []wxrAuthor   `xml:"author"`
+	Categories []wxrCategory `xml:"category"`
+	Tags       []wxrTag      `xml:"tag"`
+	Terms      []wxrTerm     `xml:"term"`
+	Items      []wxrItem     `xml:"item"`
+}
+
+type wxrAuthor struct {
+	Login       string `xml:"author_login"`
+	Email       string `xml:"author_email"`
+	DisplayName string `xml:"author_display_name"`
+}
+
+type wxrCategory struct {
+	Nicename    string `xml:"category_nicename"`
+	Name        string `xml:"cat_name"`
+	Parent      string `xml:"category_parent"`
+	Description string `xml:"category_description"`
+}
+
+type wxrTag struct {
+	Slug        string `xml:"tag_slug"`
+	Name        string `xml:"tag_name"`
+	Description string `xml:"tag_description"`
+}
+
+type wxrTerm struct {
+	Taxonomy    string `xml:"term_taxonomy"`
+	Slug        string `xml:"term_slug"`
+	Name        string `xml:"term_name"`
+	Parent      string `xml:"term_parent"`
+	Description string `xml:"term_description"`
+}
+
+type wxrEncoded struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+type wxrItemTerm struct {
+	Domain   string `xml:"domain,attr"`
+	Nicename string `xml:"nicename,attr"`
+	Name     string `xml:",chardata"`
+}
+
+type wxrMeta struct {
+	Key   string `xml:"meta_key"`
+	Value string `xml:"meta_value"`
+}
+
+type wxrComment struct {
+	ID          string `xml:"comment_id"`
+	Author      string `xml:"comment_author"`
+	AuthorEmail string `xml:"comment_author_email"`
+	AuthorURL   string `xml:"comment_author_url"`
+	AuthorIP    string `xml:"comment_author_IP"`
+	Date        string `xml:"comment_date"`
+	DateGMT     string `xml:"comment_date_gmt"`
+	Content     string `xml:"comment_content"`
+	Approved    string `xml:"comment_approved"`
+	Type        string `xml:"comment_type"`
+	Parent      string `xml:"comment_parent"`
+}
+
+type wxrItem struct {
+	Title         string        `xml:"title"`
+	Link          string        `xml:"link"`
+	Creator       string        `xml:"creator"`
+	Encoded       []wxrEncoded  `xml:"encoded"`
+	PostID        string        `xml:"post_id"`
+	PostDate      string        `xml:"post_date"`
+	PostDateGMT   string        `xml:"post_date_gmt"`
+	ModifiedGMT   string        `xml:"post_modified_gmt"`
+	CommentStatus string        `xml:"comment_status"`
+	PostName      string        `xml:"post_name"`
+	Status        string        `xml:"status"`
+	PostParent    string        `xml:"post_parent"`
+	MenuOrder     string        `xml:"menu_order"`
+	PostType      string        `xml:"post_type"`
+	PostPassword  string        `xml:"post_password"`
+	AttachmentURL string        `xml:"attachment_url"`
+	Terms         []wxrItemTerm `xml:"category"`
+	Meta          []wxrMeta     `xml:"postmeta"`
+	Comments      []wxrComment  `xml:"comment"`
+}
+
+// encoded 取指定命名空间（content 或 excerpt）的 encoded 内容
+func (it *wxrItem) encoded(ns string) string {
+	for _, e := range it.Encoded {
+		if strings.Contains(e.XMLName.Space, "/"+ns) {
+			return e.Value
+		}
+	}
+	return ""
+}
+
+func (it *wxrItem) meta(key string) string {
+	for _, m := range it.Meta {
+		if m.Key == key {
+			return m.Value
+		}
+	}
+	return ""
+}
+
+// wxrTimeLayout WXR 中的时间格式；GMT 时间未设置时为 0000-00-00 00:00:00
+const wxrTimeLayout = "2006-01-02 15:04:05"
+
+// wxrTime 优先取 GMT 时间，缺失时把站点本地时间按 UTC 处理
+func wxrTime(values ...string) time.Time {
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" || strings.HasPrefix(v, "0000") {
+			continue
+		}
+		if t, err := time.Parse(wxrTimeLayout, v); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// ParseWXR 解析 WordPress 导出文件（工具 → 导出）。
+//
+// 导入 post 与 page（含评论）、attachment，以及分类、标签与作者；修订版本、菜单项、
+// 自定义文章类型与已删除的内容记入 Issues 后跳过。pingback/trackback 与垃圾评论不导入。
+func ParseWXR(r io.Reader) (*Bundle, error) {
+	var doc wxrDocument
+	dec := xml.NewDecoder(r)
+	dec.Strict = false
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("parse wxr: %w", err)
+	}
+
+	ch := &doc.Channel
+	b := &Bundle{
+		Site: Site{
+			Title:    strings.TrimSpace(ch.Title),
+			BaseURL:  strings.TrimRight(firstNonBlank(ch.BaseBlog, ch.Link, ch.BaseSite), "/"),
+			Language: strings.TrimSpace(ch.Language),
+		},
+	}
+
+	for _, a := range ch.Authors {
+		if a.Login == "" {
+			continue
+		}
+		b.Authors = append(b.Authors, Author{
+			Login:       strings.TrimSpace(a.Login),
+			Email:       strings.TrimSpace(a.Email),
+			DisplayName: strings.TrimSpace(a.DisplayName),
+		})
+	}
+
+	// 频道级分类与标签带有父级与描述，文章上只有名称与 nicename
+	for _, c := range ch.Categories {
+		b.addCategory(c.Name, c.Nicename, c.Parent, c.Description)
+	}
+	for _, t := range ch.Tags {
+		b.addTag(t.Name, t.Slug, t.Description)
+	}
+	for _, t := range ch.Terms {
+		switch t.Taxonomy {
+		case "category":
+			b.addCategory(t.Name, t.Slug, t.Parent, t.Description)
+		case "post_tag":
+			b.addTag(t.Name, t.Slug, t.Description)
+		}
+	}
+
+	for i := range ch.Items {
+		it := &ch.Items[i]
+		ref := it.PostID
+		if ref == "" {
+			ref = it.Link
+		}
+
+		switch it.PostType {
+		case "attachment":
+			if it.AttachmentURL == "" {
+				b.skip(ref, "attachment without url")
+				continue
+			}
+			b.Attachments = append(b.Attachments, &Attachment{
+				ID:       it.PostID,
+				URL:      strings.TrimSpace(it.AttachmentURL),
+				FileName: path.Base(strings.TrimSpace(it.AttachmentURL)),
+				Title:    strings.TrimSpace(it.Title),
+			})
+			continue
+		case "post", "page":
+		default:
+			b.skip(ref, "unsupported post type %q", it.PostType)
+			continue
+		}
+
+		var status Status
+		switch it.Status {
+		case "publish":
+			status = StatusPublished
+		case "future":
+			status = StatusScheduled
+		case "draft", "pending", "private":
+			status = StatusDraft
+		default:
+			// trash、auto-draft、inherit 等
+			b.skip(ref, "skipped %s with status %q", it.PostType, it.Status)
+			continue
+		}
+
+		item := &Item{
+			Kind:           Kind(it.PostType),
+			SourceID:       it.PostID,
+			Title:          strings.TrimSpace(it.Title),
+			Slug:           Slugify(it.PostName),
+			Link:           strings.TrimSpace(it.Link),
+			Content:        it.encoded("content"),
+			Excerpt:        strings.TrimSpace(it.encoded("excerpt")),
+			Status:         status,
+			Password:       it.PostPassword,
+			Published:      wxrTime(it.PostDateGMT, it.PostDate),
+			Modified:       wxrTime(it.ModifiedGMT),
+			Author:         strings.TrimSpace(it.Creator),
+			CommentsClosed: it.CommentStatus == "closed",
+			FeaturedImage:  it.meta("_thumbnail_id"),
+		}
+		if item.Slug == "" {
+			item.Slug = Slugify(item.Title)
+		}
+		if it.PostParent != "" && it.PostParent != "0" {
+			item.ParentID = it.PostParent
+		}
+		if n, err := strconv.ParseInt(it.MenuOrder, 10, 32); err == nil {
+			item.MenuOrder = int32(n)
+		}
+
+		for _, t := range it.Terms {
+			switch t.Domain {
+			case "category":
+				var s string
+				b.Categories, s = addTerm(b.Categories, t.Name, t.Nicename)
+				if s != "" {
+					item.Categories = append(item.Categories, s)
+				}
+			case "post_tag":
+				var s string
+				b.Tags, s = addTerm(b.Tags, t.Name, t.Nicename)
+				if s != "" {
+					item.Tags = append(item.Tags, s)
+				}
+			}
+		}
+
+		for _, c := range it.Comments {
+			if c.Type != "" && c.Type != "comment" {
+				continue
+			}
+			if c.Approved != "1" && c.Approved != "0" {
+				// spam、trash
+				continue
+			}
+			parent := strings.TrimSpace(c.Parent)
+			if parent == "0" {
+				parent = ""
+			}
+			item.Comments = append(item.Comments, Comment{
+				ID:          c.ID,
+				ParentID:    parent,
+				AuthorName:  strings.TrimSpace(c.Author),
+				AuthorEmail: strings.TrimSpace(c.AuthorEmail),
+				AuthorURL:   strings.TrimSpace(c.AuthorURL),
+				AuthorIP:    strings.TrimSpace(c.AuthorIP),
+				Content:     c.Content,
+				Date:        wxrTime(c.DateGMT, c.Date),
+				Approved:    c.Approved == "1",
+			})
+		}
+
+		b.Items = append(b.Items, item)
+	}
+
+	return b, nil
+}
+
+// addCategory 追加频道级分类，父级以 nicename 引用
+func (b *Bundle) addCategory(name, nicename, parent, description string) {
+	var s string
+	b.Categories, s = addTerm(b.Categories, name, nicename)
+	for i := range b.Categories {
+		if b.Categories[i].Slug == s {
+			b.Categories[i].Parent = Slugify(parent)
+			b.Categories[i].Description = strings.TrimSpace(description)
+		}
+	}
+}
+
+func (b *Bundle) addTag(name, tagSlug, description string) {
+	var s string
+	b.Tags, s = addTerm(b.Tags, name, tagSlug)
+	for i := range b.Tags {
+		if b.Tags[i].Slug == s {
+			b.Tags[i].Description = strings.TrimSpace(description)
+		}
+	}
+}
+
+func firstNonBlank(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,271 @@
+// Package jsonld 生成 Schema.org JSON-LD 结构化数据（Organization、WebSite、Article、BreadcrumbList 等），
+// 输出为单个 @graph 文档，供前台直接嵌入 <script type="application/ld+json">。
+package jsonld
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SchemaContext JSON-LD 的 @context
+const SchemaContext = "https://schema.org"
+
+// 节点类型
+const (
+	TypeOrganization   = "Organization"
+	TypeWebSite        = "WebSite"
+	TypeWebPage        = "WebPage"
+	TypeCollectionPage = "CollectionPage"
+	TypeArticle        = "Article"
+	TypeBreadcrumbList = "BreadcrumbList"
+	TypePerson         = "Person"
+	TypeImageObject    = "ImageObject"
+	TypeListItem       = "ListItem"
+)
+
+// Node 一个 JSON-LD 节点
+type Node map[string]any
+
+// set 仅在值非空时写入，避免输出空属性
+func (n Node) set(key string, value any) {
+	switch v := value.(type) {
+	case nil:
+		return
+	case string:
+		if v == "" {
+			return
+		}
+	case []string:
+		if len(v) == 0 {
+			return
+		}
+	case time.Time:
+		if v.IsZero() {
+			return
+		}
+		value = v.UTC().Format(time.RFC3339)
+	case Node:
+		if v == nil {
+			return
+		}
+	}
+	n[key] = value
+}
+
+// Ref 以 @id 引用图中的其他节点
+func Ref(id string) Node {
+	if id == "" {
+		return nil
+	}
+	return Node{"@id": id}
+}
+
+// OrganizationID 站点所属组织节点的 @id
+func OrganizationID(baseURL string) string {
+	return strings.TrimRight(baseURL, "/") + "/#organization"
+}
+
+// WebSiteID 站点节点的 @id
+func WebSiteID(baseURL string) string {
+	return strings.TrimRight(baseURL, "/") + "/#website"
+}
+
+// Organization 站点所属的组织
+type Organization struct {
+	BaseURL string
+	Name    string
+	Logo    string
+	SameAs  []string // 社交账号等其他主页
+}
+
+// Node 生成 Organization 节点
+func (o *Organization) Node() Node {
+	n := Node{"@type": TypeOrganization, "@id": OrganizationID(o.BaseURL)}
+	n.set("name", o.Name)
+	n.set("url", o.BaseURL)
+	if o.Logo != "" {
+		n["logo"] = Node{"@type": TypeImageObject, "url": o.Logo}
+	}
+	n.set("sameAs", o.SameAs)
+	return n
+}
+
+// WebSite 站点
+type WebSite struct {
+	BaseURL     string
+	Name        string
+	Description string
+	Language    string
+}
+
+// Node 生成 WebSite 节点，publisher 指向同一站点的 Organization
+func (w *WebSite) Node() Node {
+	n := Node{"@type": TypeWebSite, "@id": WebSiteID(w.BaseURL)}
+	n.set("url", w.BaseURL)
+	n.set("name", w.Name)
+	n.set("description", w.Description)
+	n.set("inLanguage", w.Language)
+	n["publisher"] = Ref(OrganizationID(w.BaseURL))
+	return n
+}
+
+// Article 文章
+type Article struct {
+	BaseURL     string
+	URL         string
+	Headline    string
+	Description string
+	Image       string
+	Keywords    []string
+	Language    string
+	AuthorName  string
+	Section     string // 所属分类名称
+	Published   time.Time
+	Modified    time.Time
+}
+
+// Node 生成 Article 节点，mainEntityOfPage 指向文章地址，publisher 指向站点的 Organization
+func (a *Article) Node() Node {
+	n := Node{"@type": TypeArticle, "@id": a.URL + "#article"}
+	n.set("headline", truncate(a.Headline, maxHeadline))
+	n.set("description", a.Description)
+	n.set("url", a.URL)
+	n.set("mainEntityOfPage", a.URL)
+	n.set("image", a.Image)
+	if len(a.Keywords) > 0 {
+		n["keywords"] = strings.Join(a.Keywords, ", ")
+	}
+	n.set("inLanguage", a.Language)
+	n.set("articleSection", a.Section)
+	if a.AuthorName != "" {
+		n["author"] = Node{"@type": TypePerson, "name": a.AuthorName}
+	}
+	n.set("datePublished", a.Published)
+	if a.Modified.IsZero() {
+		n.set("dateModified", a.Published)
+	} else {
+		n.set("dateModified", a.Modified)
+	}
+	n["publisher"] = Ref(OrganizationID(a.BaseURL))
+	n["isPartOf"] = Ref(WebSiteID(a.BaseURL))
+	return n
+}
+
+// WebPage 页面或分类页，Type 为 WebPage 或 CollectionPage
+type WebPage struct {
+	Type        string
+	BaseURL     string
+	URL         string
+	Name        string
+	Description string
+	Image       string
+	Language    string
+	Published   time.Time
+	Modified    time.Time
+}
+
+// Node 生成 WebPage/CollectionPage 节点
+func (p *WebPage) Node() Node {
+	typ := p.Type
+	if typ == "" {
+		typ = TypeWebPage
+	}
+	n := Node{"@type": typ, "@id": p.URL + "#webpage"}
+	n.set("url", p.URL)
+	n.set("name", p.Name)
+	n.set("description", p.Description)
+	n.set("primaryImageOfPage", p.Image)
+	n.set("inLanguage", p.Language)
+	n.set("datePublished", p.Published)
+	n.set("dateModified", p.Modified)
+	n["isPartOf"] = Ref(WebSiteID(p.BaseURL))
+	return n
+}
+
+// Crumb 面包屑中的一级
+type Crumb struct {
+	Name string
+	URL  string
+}
+
+// Breadcrumbs 生成 BreadcrumbList 节点，按顺序从 1 编号；少于两级时没有导航意义，返回 nil
+func Breadcrumbs(pageURL string, crumbs []Crumb) Node {
+	if len(crumbs) < 2 {
+		return nil
+	}
+
+	items := make([]Node, 0, len(crumbs))
+	for i, c := range crumbs {
+		item := Node{"@type": TypeListItem, "position": i + 1}
+		item.set("name", c.Name)
+		item.set("item", c.URL)
+		items = append(items, item)
+	}
+	return Node{"@type": TypeBreadcrumbList, "@id": pageURL + "#breadcrumb", "itemListElement": items}
+}
+
+// Overrides 按节点类型覆盖生成的属性，如 {"Organization": {"legalName": "..."}}；值为 null 时删除该属性
+type Overrides map[string]map[string]any
+
+// ParseOverrides 解析租户配置的覆盖项，空字符串返回 nil
+func ParseOverrides(raw string) (Overrides, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	var o Overrides
+	if err := json.Unmarshal([]byte(raw), &o); err != nil {
+		return nil, fmt.Errorf("parse json-ld overrides: %w", err)
+	}
+	return o, nil
+}
+
+// Apply 把覆盖项合并到同类型节点上；@id 与 @type 不可覆盖，以保持节点间引用有效
+func (o Overrides) Apply(n Node) {
+	if n == nil || len(o) == 0 {
+		return
+	}
+	typ, _ := n["@type"].(string)
+	for k, v := range o[typ] {
+		if k == "@id" || k == "@type" {
+			continue
+		}
+		if v == nil {
+			delete(n, k)
+			continue
+		}
+		n[k] = v
+	}
+}
+
+// Graph 把节点输出为带 @context 的 @graph 文档，忽略 nil 节点。
+// 保留 encoding/json 对 <、>、& 的转义，嵌入 <script> 时不会提前闭合标签
+func Graph(overrides Overrides, nodes ...Node) (string, error) {
+	graph := make([]Node, 0, len(nodes))
+	for _, n := range nodes {
+		if n == nil {
+			continue
+		}
+		overrides.Apply(n)
+		graph = append(graph, n)
+	}
+
+	body, err := json.Marshal(map[string]any{"@context": SchemaContext, "@graph": graph})
+	if err != nil {
+		return "", fmt.Errorf("marshal json-ld: %w", err)
+	}
+	return string(body), nil
+}
+
+// maxHeadline Google 建议 headline 不超过 110 个字符
+const maxHeadline = 110
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
@@ -0,0 +1,67 @@
+package jsonld
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraph(t *testing.T) {
+	base := "https://a.com"
+	published := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	org := (&Organization{BaseURL: base, Name: "ACME", Logo: "https://a.com/logo.png"}).Node()
+	site := (&WebSite{BaseURL: base, Name: "ACME Blog", Language: "en"}).Node()
+	article := (&Article{
+		BaseURL:    base,
+		URL:        "https://a.com/en/post/hello",
+		Headline:   "Hello </script>",
+		Keywords:   []string{"go", "cms"},
+		AuthorName: "alice",
+		Published:  published,
+	}).Node()
+	crumbs := Breadcrumbs("https://a.com/en/post/hello", []Crumb{
+		{Name: "Home", URL: "https://a.com/"},
+		{Name: "Go", URL: "https://a.com/en/category/go"},
+		{Name: "Hello", URL: "https://a.com/en/post/hello"},
+	})
+
+	overrides, err := ParseOverrides(`{"Organization": {"legalName": "ACME Ltd.", "logo": null, "@id": "x"}}`)
+	assert.NoError(t, err)
+
+	body, err := Graph(overrides, org, site, article, Breadcrumbs("https://a.com/", []Crumb{{Name: "Home"}}), crumbs)
+	assert.NoError(t, err)
+	assert.NotContains(t, body, "</script>")
+
+	var doc struct {
+		Context string           `json:"@context"`
+		Graph   []map[string]any `json:"@graph"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(body), &doc))
+	assert.Equal(t, SchemaContext, doc.Context)
+	assert.Len(t, doc.Graph, 4)
+
+	assert.Equal(t, "https://a.com/#organization", doc.Graph[0]["@id"])
+	assert.Equal(t, "ACME Ltd.", doc.Graph[0]["legalName"])
+	assert.NotContains(t, doc.Graph[0], "logo")
+
+	a := doc.Graph[2]
+	assert.Equal(t, TypeArticle, a["@type"])
+	assert.Equal(t, "Hello </script>", a["headline"])
+	assert.Equal(t, "go, cms", a["keywords"])
+	assert.Equal(t, "2026-03-01T08:00:00Z", a["datePublished"])
+	assert.Equal(t, "2026-03-01T08:00:00Z", a["dateModified"])
+	assert.Equal(t, map[string]any{"@id": "https://a.com/#organization"}, a["publisher"])
+	assert.Equal(t, map[string]any{"@type": TypePerson, "name": "alice"}, a["author"])
+	assert.NotContains(t, a, "image")
+
+	items := doc.Graph[3]["itemListElement"].([]any)
+	assert.Len(t, items, 3)
+	assert.Equal(t, float64(2), items[1].(map[string]any)["position"])
+	assert.Equal(t, "https://a.com/en/category/go", items[1].(map[string]any)["item"])
+
+	_, err = ParseOverrides("{")
+	assert.Error(t, err)
+}
@@ -0,0 +1,146 @@
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// maxPromptContent 送入提示词的正文最多字符数，超出部分截断以控制 Token 消耗
+	maxPromptContent = 6000
+
+	// MaxMetaDescription SEO 描述的建议长度上限
+	MaxMetaDescription = 160
+)
+
+var (
+	htmlTagPattern   = regexp.MustCompile(`(?s)<script.*?</script>|<style.*?</style>|<[^>]*>`)
+	spacePattern     = regexp.MustCompile(`\s+`)
+	listItemPrefix   = regexp.MustCompile(`^\s*(?:[-*•]|\d+[.)、])\s*`)
+	codeFencePattern = regexp.MustCompile("(?s)^```[a-zA-Z]*\\s*(.*?)\\s*```$")
+)
+
+const assistSystem = "你是内容管理系统中的编辑助手，只输出要求的结果，不添加解释、前缀或 Markdown 格式。"
+
+// PlainText 去除 HTML 标签与多余空白，并截断到 maxRunes 个字符（0 表示不截断）
+func PlainText(content string, maxRunes int) string {
+	text := htmlTagPattern.ReplaceAllString(content, " ")
+	text = html.UnescapeString(text)
+	text = strings.TrimSpace(spacePattern.ReplaceAllString(text, " "))
+	return Clip(text, maxRunes)
+}
+
+// Clip 截断到 maxRunes 个字符（0 表示不截断），并去掉模型常加的首尾引号
+func Clip(text string, maxRunes int) string {
+	text = strings.TrimSpace(text)
+	text = strings.Trim(text, "\"'“”「」")
+	if maxRunes > 0 && utf8.RuneCountInString(text) > maxRunes {
+		text = strings.TrimSpace(string([]rune(text)[:maxRunes]))
+	}
+	return text
+}
+
+// SummaryPrompt 生成摘要
+func SummaryPrompt(content, lang string, maxLength int) *Request {
+	return &Request{
+		System: assistSystem,
+		Prompt: fmt.Sprintf(`请为以下文章生成简洁的摘要，要求：
+1. 语言：%s
+2. 长度：不超过%d个字符
+3. 内容：提炼核心观点，不要简单截断
+4. 格式：纯文本，无markdown，无多余标点
+
+文章内容：%s`, lang, maxLength, PlainText(content, maxPromptContent)),
+	}
+}
+
+// MetaDescriptionPrompt 生成 SEO 描述（meta description）
+func MetaDescriptionPrompt(title, content, lang string) *Request {
+	return &Request{
+		System: assistSystem,
+		Prompt: fmt.Sprintf(`请为以下网页撰写 SEO 描述（meta description），要求：
+1. 语言：%s
+2. 长度：不超过%d个字符
+3. 概括页面内容并吸引点击，自然包含主要关键词，不堆砌关键词
+4. 格式：一段纯文本
+
+标题：%s
+内容：%s`, lang, MaxMetaDescription, title, PlainText(content, maxPromptContent)),
+	}
+}
+
+// TagSuggestionsPrompt 推荐标签；existing 为站点已有的标签，优先从中选择
+func TagSuggestionsPrompt(title, content, lang string, existing []string, n int) *Request {
+	var known string
+	if len(existing) > 0 {
+		known = "\n站点已有标签（优先从中选择）：" + strings.Join(existing, "、")
+	}
+	return &Request{
+		System: assistSystem,
+		Prompt: fmt.Sprintf(`请为以下文章推荐最多%d个标签，要求：
+1. 语言：%s
+2. 每个标签为简短的名词或短语，不含 # 号
+3. 以 JSON 字符串数组输出，如 ["标签一","标签二"]%s
+
+标题：%s
+内容：%s`, n, lang, known, title, PlainText(content, maxPromptContent)),
+	}
+}
+
+// TitleAlternativesPrompt 生成备选标题
+func TitleAlternativesPrompt(title, content, lang string, n int) *Request {
+	return &Request{
+		System: assistSystem,
+		Prompt: fmt.Sprintf(`请为以下文章提供%d个备选标题，要求：
+1. 语言：%s
+2. 准确概括内容，风格各异，每个不超过60个字符
+3. 以 JSON 字符串数组输出，如 ["标题一","标题二"]
+
+当前标题：%s
+内容：%s`, n, lang, title, PlainText(content, maxPromptContent)),
+	}
+}
+
+// ParseList 解析模型返回的列表：优先按 JSON 字符串数组解析（允许包在代码块中），
+// 否则按行拆分并去掉列表符号与序号。结果去重（忽略大小写）并最多保留 n 项（0 表示不限制）
+func ParseList(text string, n int) []string {
+	text = strings.TrimSpace(text)
+	if m := codeFencePattern.FindStringSubmatch(text); m != nil {
+		text = m[1]
+	}
+
+	var items []string
+	if start, end := strings.Index(text, "["), strings.LastIndex(text, "]"); start >= 0 && end > start {
+		if err := json.Unmarshal([]byte(text[start:end+1]), &items); err != nil {
+			items = nil
+		}
+	}
+	if items == nil {
+		for _, line := range strings.Split(text, "\n") {
+			items = append(items, listItemPrefix.ReplaceAllString(line, ""))
+		}
+	}
+
+	seen := make(map[string]struct{}, len(items))
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		item = Clip(strings.TrimSuffix(strings.TrimSpace(item), ","), 0)
+		key := strings.ToLower(item)
+		if item == "" {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		out = append(out, item)
+		if n > 0 && len(out) == n {
+			break
+		}
+	}
+	return out
+}
@@ -0,0 +1,149 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// 供应商类型
+const (
+	TypeOpenAI = "openai" // OpenAI 及兼容接口（/chat/completions），如 DeepSeek、通义千问、vLLM
+	TypeOllama = "ollama" // Ollama 本地模型（/api/chat）
+	TypeMock   = "mock"   // 测试用，不发起网络请求
+)
+
+const (
+	defaultTimeout     = 60 * time.Second
+	defaultMaxTokens   = 512
+	defaultTemperature = 0.3
+)
+
+var (
+	// ErrNotConfigured 未配置供应商
+	ErrNotConfigured = errors.New("llm provider not configured")
+	// ErrEmptyResponse 模型未返回内容
+	ErrEmptyResponse = errors.New("llm returned no content")
+	// ErrInvalidResponse 响应无法解析
+	ErrInvalidResponse = errors.New("invalid llm response")
+)
+
+// StatusError 供应商返回非 2xx 状态码
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("llm request failed with status %d: %s", e.StatusCode, e.Body)
+}
+
+// Request 一次补全请求
+type Request struct {
+	System      string  // 系统提示词，可为空
+	Prompt      string  // 用户提示词
+	Model       string  // 为空时使用供应商配置的模型
+	Temperature float64 // 为 0 时使用供应商配置
+	MaxTokens   int     // 为 0 时使用供应商配置
+}
+
+// Usage Token 用量
+type Usage struct {
+	PromptTokens     int
+	CompletionTokens int
+}
+
+// Total 总 Token 数
+func (u Usage) Total() int {
+	return u.PromptTokens + u.CompletionTokens
+}
+
+// Add 累加用量
+func (u *Usage) Add(o Usage) {
+	u.PromptTokens += o.PromptTokens
+	u.CompletionTokens += o.CompletionTokens
+}
+
+// Response 补全结果
+type Response struct {
+	Text  string
+	Model string
+	Usage Usage
+}
+
+// Provider 大语言模型供应商
+type Provider interface {
+	// Name 供应商类型，见 TypeOpenAI 等
+	Name() string
+
+	// Complete 生成补全；未返回内容时返回 ErrEmptyResponse
+	Complete(ctx context.Context, req *Request) (*Response, error)
+}
+
+// Config 供应商配置
+type Config struct {
+	Type        string        // 供应商类型，为空时为 openai
+	BaseURL     string        // 接口地址，为空时使用各类型的默认地址
+	APIKey      string        // API Key，本地模型可为空
+	Model       string        // 默认模型
+	Timeout     time.Duration // 单次请求超时，默认 60 秒
+	MaxTokens   int           // 默认最大生成 Token 数，默认 512
+	Temperature float64       // 默认温度，默认 0.3
+
+	HTTPClient *http.Client // 为空时按 Timeout 创建
+}
+
+func (c Config) withDefaults(baseURL, model string) Config {
+	if c.BaseURL == "" {
+		c.BaseURL = baseURL
+	}
+	c.BaseURL = strings.TrimRight(c.BaseURL, "/")
+	if c.Model == "" {
+		c.Model = model
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = defaultTimeout
+	}
+	if c.MaxTokens <= 0 {
+		c.MaxTokens = defaultMaxTokens
+	}
+	if c.Temperature <= 0 {
+		c.Temperature = defaultTemperature
+	}
+	if c.HTTPClient == nil {
+		c.HTTPClient = &http.Client{Timeout: c.Timeout}
+	}
+	return c
+}
+
+// options 合并请求参数与供应商默认值
+func (c Config) options(req *Request) (model string, temperature float64, maxTokens int) {
+	model, temperature, maxTokens = req.Model, req.Temperature, req.MaxTokens
+	if model == "" {
+		model = c.Model
+	}
+	if temperature <= 0 {
+		temperature = c.Temperature
+	}
+	if maxTokens <= 0 {
+		maxTokens = c.MaxTokens
+	}
+	return
+}
+
+// New 按配置创建供应商
+func New(cfg Config) (Provider, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.Type)) {
+	case "", TypeOpenAI:
+		return NewOpenAI(cfg), nil
+	case TypeOllama:
+		return NewOllama(cfg), nil
+	case TypeMock:
+		return NewMock(), nil
+	default:
+		return nil, fmt.Errorf("unknown llm provider type %q", cfg.Type)
+	}
+}
@@ -0,0 +1,161 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAIComplete(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		wantText  string
+		wantUsage Usage
+		wantErr   error
+	}{
+		{
+			name:      "with usage",
+			status:    http.StatusOK,
+			body:      `{"model":"gpt-test","choices":[{"message":{"role":"assistant","content":" 摘要 "}}],"usage":{"prompt_tokens":12,"completion_tokens":3,"total_tokens":15}}`,
+			wantText:  "摘要",
+			wantUsage: Usage{PromptTokens: 12, CompletionTokens: 3},
+		},
+		{name: "empty choices", status: http.StatusOK, body: `{"choices":[]}`, wantErr: ErrEmptyResponse},
+		{name: "invalid json", status: http.StatusOK, body: `invalid`, wantErr: ErrInvalidResponse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+				assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+
+				var req ChatRequest
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				assert.Equal(t, "gpt-test", req.Model)
+				assert.Len(t, req.Messages, 2)
+
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			p := NewOpenAI(Config{BaseURL: server.URL + "/v1/", APIKey: "key", Model: "gpt-test"})
+			resp, err := p.Complete(context.Background(), &Request{System: "s", Prompt: "p"})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantText, resp.Text)
+			assert.Equal(t, tt.wantUsage, resp.Usage)
+		})
+	}
+}
+
+func TestOpenAICompleteStatusError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":"unauthorized"}`))
+	}))
+	defer server.Close()
+
+	_, err := NewOpenAI(Config{BaseURL: server.URL}).Complete(context.Background(), &Request{Prompt: "p"})
+
+	var statusErr *StatusError
+	require.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusUnauthorized, statusErr.StatusCode)
+}
+
+func TestOllamaComplete(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/chat", r.URL.Path)
+
+		var req ollamaRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.False(t, req.Stream)
+		assert.Equal(t, 100, req.Options.NumPredict)
+
+		_, _ = w.Write([]byte(`{"model":"llama","message":{"role":"assistant","content":"ok"},"prompt_eval_count":20,"eval_count":2}`))
+	}))
+	defer server.Close()
+
+	resp, err := NewOllama(Config{BaseURL: server.URL}).Complete(context.Background(), &Request{Prompt: "p", MaxTokens: 100})
+	require.NoError(t, err)
+	assert.Equal(t, "ok", resp.Text)
+	assert.Equal(t, 22, resp.Usage.Total())
+}
+
+func TestMock(t *testing.T) {
+	m := NewMock("first", "second")
+	ctx := context.Background()
+
+	for _, want := range []string{"first", "second", "second"} {
+		resp, err := m.Complete(ctx, &Request{Prompt: "hello world"})
+		require.NoError(t, err)
+		assert.Equal(t, want, resp.Text)
+		assert.Positive(t, resp.Usage.Total())
+	}
+	assert.Len(t, m.Requests(), 3)
+
+	_, err := m.WithError(ErrNotConfigured).Complete(ctx, &Request{})
+	assert.ErrorIs(t, err, ErrNotConfigured)
+}
+
+func TestNew(t *testing.T) {
+	for typ, want := range map[string]string{"": TypeOpenAI, "OpenAI": TypeOpenAI, "ollama": TypeOllama, "mock": TypeMock} {
+		p, err := New(Config{Type: typ})
+		require.NoError(t, err)
+		assert.Equal(t, want, p.Name())
+	}
+
+	_, err := New(Config{Type: "unknown"})
+	assert.Error(t, err)
+}
+
+func TestParseList(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		n    int
+		want []string
+	}{
+		{name: "json", text: `["Go", "Kratos", "go"]`, want: []string{"Go", "Kratos"}},
+		{name: "fenced json", text: "```json\n[\"a\",\"b\",\"c\"]\n```", n: 2, want: []string{"a", "b"}},
+		{name: "numbered lines", text: "1. 第一个标题\n2) “第二个标题”\n- 第三个标题", want: []string{"第一个标题", "第二个标题", "第三个标题"}},
+		{name: "empty", text: " ", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseList(tt.text, tt.n))
+		})
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	assert.Equal(t, "Hello World & more", PlainText("<p>Hello <b>World</b></p>\n<script>x()</script> &amp; more", 0))
+	assert.Equal(t, "你好", PlainText("<p>你好世界</p>", 2))
+}
+
+func TestMeter(t *testing.T) {
+	m := NewMeter(NewMock("a", "b"))
+	ctx := context.Background()
+
+	for range 2 {
+		_, err := m.Complete(ctx, &Request{Prompt: "prompt text"})
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, m.Calls())
+	assert.Equal(t, TypeMock, m.Model())
+	assert.Equal(t, Usage{PromptTokens: 4, CompletionTokens: 2}, m.Usage())
+}
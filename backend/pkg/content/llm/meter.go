@@ -0,0 +1,56 @@
+package llm
+
+import (
+	"context"
+	"sync"
+)
+
+// Meter 包装供应商并累计每次调用的 Token 用量，便于按任务计量
+type Meter struct {
+	Provider
+
+	mu    sync.Mutex
+	usage Usage
+	model string
+	calls int
+}
+
+func NewMeter(p Provider) *Meter {
+	return &Meter{Provider: p}
+}
+
+func (m *Meter) Complete(ctx context.Context, req *Request) (*Response, error) {
+	resp, err := m.Provider.Complete(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	m.usage.Add(resp.Usage)
+	m.model = resp.Model
+	m.calls++
+	m.mu.Unlock()
+
+	return resp, nil
+}
+
+// Usage 累计用量
+func (m *Meter) Usage() Usage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.usage
+}
+
+// Model 最近一次调用实际使用的模型
+func (m *Meter) Model() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.model
+}
+
+// Calls 成功调用次数
+func (m *Meter) Calls() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls
+}
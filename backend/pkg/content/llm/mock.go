@@ -0,0 +1,71 @@
+package llm
+
+import (
+	"context"
+	"sync"
+	"unicode/utf8"
+)
+
+// Mock 测试用供应商：按顺序返回预置的回复，回复用完后重复最后一条；记录收到的请求
+type Mock struct {
+	mu        sync.Mutex
+	responses []string
+	err       error
+	requests  []Request
+}
+
+func NewMock(responses ...string) *Mock {
+	return &Mock{responses: responses}
+}
+
+// WithError 之后的请求均返回 err
+func (m *Mock) WithError(err error) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.err = err
+	return m
+}
+
+// Requests 已收到的请求
+func (m *Mock) Requests() []Request {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Request(nil), m.requests...)
+}
+
+func (m *Mock) Name() string { return TypeMock }
+
+func (m *Mock) Complete(_ context.Context, req *Request) (*Response, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, *req)
+	if m.err != nil {
+		return nil, m.err
+	}
+	if len(m.responses) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	text := m.responses[0]
+	if len(m.responses) > 1 {
+		m.responses = m.responses[1:]
+	}
+
+	return &Response{Text: text, Model: TypeMock, Usage: EstimateUsage(req, text)}, nil
+}
+
+// EstimateUsage 供应商未返回用量时按字符数估算：约 4 个字符计 1 个 Token，不足 1 个按 1 个计
+func EstimateUsage(req *Request, completion string) Usage {
+	estimate := func(s string) int {
+		n := utf8.RuneCountInString(s)
+		if n == 0 {
+			return 0
+		}
+		return max(n/4, 1)
+	}
+	return Usage{
+		PromptTokens:     estimate(req.System) + estimate(req.Prompt),
+		CompletionTokens: estimate(completion),
+	}
+}
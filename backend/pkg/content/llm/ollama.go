@@ -0,0 +1,80 @@
+package llm
+
+import (
+	"context"
+	"strings"
+)
+
+const (
+	defaultOllamaBaseURL = "http://localhost:11434"
+	defaultOllamaModel   = "qwen2.5:7b"
+)
+
+type ollamaRequest struct {
+	Model    string        `json:"model"`
+	Messages []Message     `json:"messages"`
+	Stream   bool          `json:"stream"`
+	Options  ollamaOptions `json:"options"`
+}
+
+type ollamaOptions struct {
+	Temperature float64 `json:"temperature"`
+	NumPredict  int     `json:"num_predict"`
+}
+
+type ollamaResponse struct {
+	Model           string  `json:"model"`
+	Message         Message `json:"message"`
+	PromptEvalCount int     `json:"prompt_eval_count"`
+	EvalCount       int     `json:"eval_count"`
+}
+
+// Ollama 本地模型供应商，调用 Ollama 的 /api/chat 接口（非流式）
+type Ollama struct {
+	cfg Config
+}
+
+func NewOllama(cfg Config) *Ollama {
+	return &Ollama{cfg: cfg.withDefaults(defaultOllamaBaseURL, defaultOllamaModel)}
+}
+
+func (p *Ollama) Name() string { return TypeOllama }
+
+func (p *Ollama) Complete(ctx context.Context, req *Request) (*Response, error) {
+	model, temperature, maxTokens := p.cfg.options(req)
+
+	body := ollamaRequest{
+		Model:    model,
+		Messages: messages(req),
+		Options:  ollamaOptions{Temperature: temperature, NumPredict: maxTokens},
+	}
+
+	headers := map[string]string{}
+	if p.cfg.APIKey != "" {
+		// 经反向代理鉴权的部署
+		headers["Authorization"] = "Bearer " + p.cfg.APIKey
+	}
+
+	var resp ollamaResponse
+	if err := postJSON(ctx, p.cfg.HTTPClient, p.cfg.BaseURL+"/api/chat", headers, body, &resp); err != nil {
+		return nil, err
+	}
+
+	text := strings.TrimSpace(resp.Message.Content)
+	if text == "" {
+		return nil, ErrEmptyResponse
+	}
+
+	out := &Response{
+		Text:  text,
+		Model: resp.Model,
+		Usage: Usage{PromptTokens: resp.PromptEvalCount, CompletionTokens: resp.EvalCount},
+	}
+	if out.Model == "" {
+		out.Model = model
+	}
+	if out.Usage.Total() == 0 {
+		out.Usage = EstimateUsage(req, text)
+	}
+	return out, nil
+}
@@ -0,0 +1,143 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	defaultOpenAIBaseURL = "https://api.openai.com/v1"
+	defaultOpenAIModel   = "gpt-4o-mini"
+
+	// maxErrorBody 错误响应体最多保留的字节数
+	maxErrorBody = 512
+)
+
+// Message 对话消息
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// ChatRequest OpenAI 兼容接口的请求体
+type ChatRequest struct {
+	Model       string    `json:"model"`
+	Messages    []Message `json:"messages"`
+	Temperature float64   `json:"temperature"`
+	MaxTokens   int       `json:"max_tokens"`
+}
+
+// ChatResponse OpenAI 兼容接口的响应体
+type ChatResponse struct {
+	Model   string     `json:"model,omitempty"`
+	Choices []Choice   `json:"choices"`
+	Usage   *ChatUsage `json:"usage,omitempty"`
+}
+
+type Choice struct {
+	Message Message `json:"message"`
+}
+
+type ChatUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// OpenAI OpenAI 及兼容接口的供应商
+type OpenAI struct {
+	cfg Config
+}
+
+func NewOpenAI(cfg Config) *OpenAI {
+	return &OpenAI{cfg: cfg.withDefaults(defaultOpenAIBaseURL, defaultOpenAIModel)}
+}
+
+func (p *OpenAI) Name() string { return TypeOpenAI }
+
+func (p *OpenAI) Complete(ctx context.Context, req *Request) (*Response, error) {
+	model, temperature, maxTokens := p.cfg.options(req)
+
+	body := ChatRequest{
+		Model:       model,
+		Messages:    messages(req),
+		Temperature: temperature,
+		MaxTokens:   maxTokens,
+	}
+
+	headers := map[string]string{}
+	if p.cfg.APIKey != "" {
+		headers["Authorization"] = "Bearer " + p.cfg.APIKey
+	}
+
+	var resp ChatResponse
+	if err := postJSON(ctx, p.cfg.HTTPClient, p.cfg.BaseURL+"/chat/completions", headers, body, &resp); err != nil {
+		return nil, err
+	}
+
+	if len(resp.Choices) == 0 {
+		return nil, ErrEmptyResponse
+	}
+	text := strings.TrimSpace(resp.Choices[0].Message.Content)
+	if text == "" {
+		return nil, ErrEmptyResponse
+	}
+
+	out := &Response{Text: text, Model: resp.Model}
+	if out.Model == "" {
+		out.Model = model
+	}
+	if resp.Usage != nil {
+		out.Usage = Usage{PromptTokens: resp.Usage.PromptTokens, CompletionTokens: resp.Usage.CompletionTokens}
+	} else {
+		// 部分兼容接口不返回用量，按字符数估算，保证计量不漏记
+		out.Usage = EstimateUsage(req, text)
+	}
+	return out, nil
+}
+
+func messages(req *Request) []Message {
+	var out []Message
+	if req.System != "" {
+		out = append(out, Message{Role: "system", Content: req.System})
+	}
+	return append(out, Message{Role: "user", Content: req.Prompt})
+}
+
+// postJSON 以 JSON 发送请求并解析响应；非 2xx 返回 *StatusError，响应无法解析时返回 ErrInvalidResponse
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, body, out any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("marshal llm request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("create llm request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call llm: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return &StatusError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(raw))}
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+	return nil
+}
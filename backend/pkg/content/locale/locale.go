@@ -0,0 +1,144 @@
+package locale
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// MaxChains 单个站点最多配置的回退链数
+	MaxChains = 50
+	// MaxChainLength 单条回退链最多包含的回退语言数
+	MaxChainLength = 8
+)
+
+// codePattern 语言代码格式（BCP 47 的常用子集，如 zh、zh-CN、zh-Hant-TW）
+var codePattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+// Fallback 一条回退链：请求 Locale 缺少翻译时，依次尝试 Fallbacks 中的语言
+type Fallback struct {
+	Locale    string   `json:"locale"`
+	Fallbacks []string `json:"fallbacks"`
+}
+
+// ValidCode 语言代码是否合法
+func ValidCode(code string) bool {
+	return codePattern.MatchString(code)
+}
+
+// ValidateFallbacks 校验回退链配置：语言代码合法、同一语言只配置一条链、链内不重复且不指向自身
+func ValidateFallbacks(fallbacks []Fallback) error {
+	if len(fallbacks) > MaxChains {
+		return fmt.Errorf("too many fallback chains (max %d)", MaxChains)
+	}
+
+	seen := make(map[string]struct{}, len(fallbacks))
+	for _, fb := range fallbacks {
+		if !ValidCode(fb.Locale) {
+			return fmt.Errorf("invalid locale %q", fb.Locale)
+		}
+		key := strings.ToLower(fb.Locale)
+		if _, ok := seen[key]; ok {
+			return fmt.Errorf("duplicate fallback chain for %q", fb.Locale)
+		}
+		seen[key] = struct{}{}
+
+		if len(fb.Fallbacks) == 0 {
+			return fmt.Errorf("fallback chain for %q is empty", fb.Locale)
+		}
+		if len(fb.Fallbacks) > MaxChainLength {
+			return fmt.Errorf("fallback chain for %q is too long (max %d)", fb.Locale, MaxChainLength)
+		}
+
+		inChain := map[string]struct{}{key: {}}
+		for _, code := range fb.Fallbacks {
+			if !ValidCode(code) {
+				return fmt.Errorf("invalid fallback locale %q for %q", code, fb.Locale)
+			}
+			k := strings.ToLower(code)
+			if _, ok := inChain[k]; ok {
+				return fmt.Errorf("fallback chain for %q repeats %q", fb.Locale, code)
+			}
+			inChain[k] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+// Chain 计算请求语言的完整回退顺序：
+//  1. 请求语言本身；
+//  2. 为其配置的回退链，链中语言若也配置了回退链则依次展开（已出现的语言跳过，不会成环）；
+//  3. 请求语言的主语言（如 zh-TW 的 zh）；
+//  4. 站点默认语言 defaultLocales（依次，通常为站点 default_locale 与系统默认语言）。
+//
+// requested 为空时返回 nil，表示不指定语言（返回全部翻译）。
+func Chain(requested string, fallbacks []Fallback, defaultLocales ...string) []string {
+	requested = strings.TrimSpace(requested)
+	if requested == "" {
+		return nil
+	}
+
+	byLocale := make(map[string][]string, len(fallbacks))
+	for _, fb := range fallbacks {
+		byLocale[strings.ToLower(fb.Locale)] = fb.Fallbacks
+	}
+
+	var chain []string
+	seen := make(map[string]struct{})
+	add := func(code string) bool {
+		code = strings.TrimSpace(code)
+		if code == "" {
+			return false
+		}
+		key := strings.ToLower(code)
+		if _, ok := seen[key]; ok {
+			return false
+		}
+		seen[key] = struct{}{}
+		chain = append(chain, code)
+		return true
+	}
+
+	var expand func(code string)
+	expand = func(code string) {
+		if !add(code) {
+			return
+		}
+		for _, next := range byLocale[strings.ToLower(code)] {
+			expand(next)
+		}
+	}
+	expand(requested)
+
+	if i := strings.IndexByte(requested, '-'); i > 0 {
+		expand(requested[:i])
+	}
+	for _, code := range defaultLocales {
+		expand(code)
+	}
+
+	return chain
+}
+
+// Pick 按回退顺序返回第一个可用的语言（返回 available 中的原始写法），均不可用时返回空串
+func Pick(chain []string, available []string) string {
+	if len(chain) == 0 || len(available) == 0 {
+		return ""
+	}
+
+	byKey := make(map[string]string, len(available))
+	for _, code := range available {
+		key := strings.ToLower(code)
+		if _, ok := byKey[key]; !ok {
+			byKey[key] = code
+		}
+	}
+	for _, code := range chain {
+		if found, ok := byKey[strings.ToLower(code)]; ok {
+			return found
+		}
+	}
+	return ""
+}
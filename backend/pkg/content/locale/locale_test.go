@@ -0,0 +1,63 @@
+package locale
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateFallbacks(t *testing.T) {
+	tests := []struct {
+		name      string
+		fallbacks []Fallback
+		wantErr   bool
+	}{
+		{name: "empty", fallbacks: nil},
+		{name: "valid", fallbacks: []Fallback{{Locale: "zh-TW", Fallbacks: []string{"zh-HK", "zh-CN"}}}},
+		{name: "invalid locale", fallbacks: []Fallback{{Locale: "zh_TW", Fallbacks: []string{"zh-CN"}}}, wantErr: true},
+		{name: "invalid fallback", fallbacks: []Fallback{{Locale: "zh-TW", Fallbacks: []string{""}}}, wantErr: true},
+		{name: "empty chain", fallbacks: []Fallback{{Locale: "zh-TW"}}, wantErr: true},
+		{name: "self reference", fallbacks: []Fallback{{Locale: "zh-TW", Fallbacks: []string{"zh-tw"}}}, wantErr: true},
+		{
+			name: "duplicate chain",
+			fallbacks: []Fallback{
+				{Locale: "zh-TW", Fallbacks: []string{"zh-CN"}},
+				{Locale: "zh-tw", Fallbacks: []string{"en"}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateFallbacks(tt.fallbacks)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestChain(t *testing.T) {
+	fallbacks := []Fallback{
+		{Locale: "zh-HK", Fallbacks: []string{"zh-TW"}},
+		{Locale: "zh-TW", Fallbacks: []string{"zh-CN"}},
+		{Locale: "zh-CN", Fallbacks: []string{"zh-HK"}}, // 成环，展开时跳过
+	}
+
+	assert.Nil(t, Chain("", fallbacks, "en-US"))
+	assert.Equal(t, []string{"zh-HK", "zh-TW", "zh-CN", "zh", "en-US"}, Chain("zh-HK", fallbacks, "en-US"))
+	assert.Equal(t, []string{"fr-CA", "fr", "en-US"}, Chain("fr-CA", fallbacks, "en-US", "EN-us"))
+	assert.Equal(t, []string{"en"}, Chain("en", nil, "", "en"))
+}
+
+func TestPick(t *testing.T) {
+	chain := []string{"zh-TW", "zh-CN", "en"}
+
+	assert.Equal(t, "zh-CN", Pick(chain, []string{"en", "zh-CN"}))
+	assert.Equal(t, "zh-tw", Pick(chain, []string{"zh-tw", "zh-CN"}))
+	assert.Equal(t, "", Pick(chain, []string{"ja"}))
+	assert.Equal(t, "", Pick(nil, []string{"ja"}))
+}
@@ -0,0 +1,202 @@
+package mt
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Term 术语表条目
+type Term struct {
+	// Source 源语言术语
+	Source string
+	// Target 强制译法，Keep 为 true 时忽略
+	Target string
+	// Keep 不翻译：译文中保留原文中出现的写法
+	Keep bool
+	// CaseSensitive 是否区分大小写匹配
+	CaseSensitive bool
+}
+
+// placeholderRe 匹配译文中的术语占位符，容忍翻译引擎在括号内插入空白
+var placeholderRe = regexp.MustCompile(`⟦\s*(\d+)\s*⟧`)
+
+// Glossary 术语表：送翻前把命中的术语替换为占位符，译后再把占位符还原为原文（不翻译）或强制译法。
+//
+// 翻译引擎丢失或重复占位符时，退化为逐段翻译术语之间的文本，保证术语不被翻译引擎改写。
+type Glossary struct {
+	terms []Term
+}
+
+// NewGlossary 创建术语表；较长的术语优先匹配，使“Go Cloud”先于“Go”命中
+func NewGlossary(terms []Term) *Glossary {
+	g := &Glossary{terms: make([]Term, 0, len(terms))}
+	for _, t := range terms {
+		if strings.TrimSpace(t.Source) == "" || (!t.Keep && t.Target == "") {
+			continue
+		}
+		g.terms = append(g.terms, t)
+	}
+	sort.SliceStable(g.terms, func(i, j int) bool {
+		return len(g.terms[i].Source) > len(g.terms[j].Source)
+	})
+	return g
+}
+
+// Empty 术语表是否为空
+func (g *Glossary) Empty() bool {
+	return g == nil || len(g.terms) == 0
+}
+
+// Apply 以术语表约束翻译一段纯文本
+func (g *Glossary) Apply(text string, fn TranslateFunc) (string, error) {
+	spans := g.match(text)
+	if len(spans) == 0 {
+		return fn(text)
+	}
+
+	// 原文本身含占位符字符时无法区分，直接逐段翻译
+	if !strings.Contains(text, "⟦") {
+		var sb strings.Builder
+		last := 0
+		for i, sp := range spans {
+			sb.WriteString(text[last:sp.start])
+			sb.WriteString("⟦" + strconv.Itoa(i) + "⟧")
+			last = sp.end
+		}
+		sb.WriteString(text[last:])
+
+		translated, err := fn(sb.String())
+		if err != nil {
+			return "", err
+		}
+		if restored, ok := restorePlaceholders(translated, spans); ok {
+			return restored, nil
+		}
+	}
+
+	return g.translatePieces(text, spans, fn)
+}
+
+// span 术语在原文中的位置与替换文本
+type span struct {
+	start, end  int
+	replacement string
+}
+
+// match 自左向右查找不重叠的术语
+func (g *Glossary) match(text string) []span {
+	if g.Empty() {
+		return nil
+	}
+
+	var spans []span
+	for i := 0; i < len(text); {
+		matched := false
+		for _, t := range g.terms {
+			end := i + len(t.Source)
+			if end > len(text) {
+				continue
+			}
+			candidate := text[i:end]
+			if t.CaseSensitive && candidate != t.Source {
+				continue
+			}
+			if !t.CaseSensitive && !strings.EqualFold(candidate, t.Source) {
+				continue
+			}
+			if !wordBoundary(text, i, end) {
+				continue
+			}
+
+			replacement := t.Target
+			if t.Keep {
+				replacement = candidate
+			}
+			spans = append(spans, span{start: i, end: end, replacement: replacement})
+			i = end
+			matched = true
+			break
+		}
+		if !matched {
+			_, size := utf8.DecodeRuneInString(text[i:])
+			i += size
+		}
+	}
+
+	return spans
+}
+
+// restorePlaceholders 还原占位符；每个占位符须恰好出现一次
+func restorePlaceholders(translated string, spans []span) (string, bool) {
+	seen := make([]bool, len(spans))
+	ok := true
+	restored := placeholderRe.ReplaceAllStringFunc(translated, func(m string) string {
+		idx, err := strconv.Atoi(placeholderRe.FindStringSubmatch(m)[1])
+		if err != nil || idx >= len(spans) || seen[idx] {
+			ok = false
+			return m
+		}
+		seen[idx] = true
+		return spans[idx].replacement
+	})
+	for _, s := range seen {
+		ok = ok && s
+	}
+	return restored, ok
+}
+
+// translatePieces 逐段翻译术语之间的文本，术语直接替换
+func (g *Glossary) translatePieces(text string, spans []span, fn TranslateFunc) (string, error) {
+	var segments []Segment
+	last := 0
+	for _, sp := range spans {
+		addText(&segments, text[last:sp.start])
+		segments = append(segments, Segment{Text: sp.replacement})
+		last = sp.end
+	}
+	addText(&segments, text[last:])
+
+	var sb strings.Builder
+	for _, seg := range segments {
+		if !seg.Translate {
+			sb.WriteString(seg.Text)
+			continue
+		}
+		translated, err := fn(seg.Text)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(translated)
+	}
+	return sb.String(), nil
+}
+
+// wordBoundary 术语两端不能紧邻字母或数字，避免“Go”命中“Google”；中日韩文字不以空格分词，不做限制
+func wordBoundary(text string, start, end int) bool {
+	if start > 0 {
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		first, _ := utf8.DecodeRuneInString(text[start:])
+		if isWordRune(before) && isWordRune(first) {
+			return false
+		}
+	}
+	if end < len(text) {
+		last, _ := utf8.DecodeLastRuneInString(text[:end])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if isWordRune(last) && isWordRune(after) {
+			return false
+		}
+	}
+	return true
+}
+
+func isWordRune(r rune) bool {
+	if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+		return false
+	}
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
@@ -0,0 +1,45 @@
+package mt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlossaryApply(t *testing.T) {
+	glossary := NewGlossary([]Term{
+		{Source: "WindCMS", Keep: true},
+		{Source: "Go", Keep: true, CaseSensitive: true},
+		{Source: "content model", Target: "MODÈLE DE CONTENU"},
+	})
+
+	upper := func(text string) (string, error) { return strings.ToUpper(text), nil }
+	// dropping 模拟丢弃占位符的翻译引擎
+	dropping := func(text string) (string, error) {
+		return strings.ToUpper(placeholderRe.ReplaceAllString(text, "")), nil
+	}
+
+	tests := []struct {
+		name string
+		text string
+		fn   TranslateFunc
+		want string
+	}{
+		{name: "no term", text: "hello world", fn: upper, want: "HELLO WORLD"},
+		{name: "keep original casing", text: "try windcms today", fn: upper, want: "TRY windcms TODAY"},
+		{name: "forced", text: "Each Content Model has fields", fn: upper, want: "EACH MODÈLE DE CONTENU HAS FIELDS"},
+		{name: "word boundary", text: "Google and Go", fn: upper, want: "GOOGLE AND Go"},
+		{name: "case sensitive", text: "go home", fn: upper, want: "GO HOME"},
+		{name: "placeholder lost", text: "Use WindCMS with Go daily", fn: dropping, want: "USE WindCMS WITH Go DAILY"},
+		{name: "term only", text: "WindCMS", fn: dropping, want: "WindCMS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := glossary.Apply(tt.text, tt.fn)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
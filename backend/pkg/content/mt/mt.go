@@ -0,0 +1,269 @@
+package mt
+
+import (
+	"strings"
+	"unicode"
+)
+
+// TranslateFunc 翻译一段纯文本
+type TranslateFunc func(text string) (string, error)
+
+// Segment 内容片段：Translate 为 true 的片段送翻，其余（HTML 标签、注释、代码、链接地址、空白）原样保留
+type Segment struct {
+	Text      string
+	Translate bool
+}
+
+// rawElements 内容整体保留、不送翻的 HTML 元素
+var rawElements = map[string]struct{}{
+	"pre":      {},
+	"code":     {},
+	"kbd":      {},
+	"samp":     {},
+	"script":   {},
+	"style":    {},
+	"textarea": {},
+}
+
+// Split 将 HTML / Markdown 内容切分为片段，各片段按顺序拼接即为原文。
+//
+// 以下内容原样保留：HTML 标签（含属性）与注释，pre/code/script/style 等元素的全部内容，
+// Markdown 围栏代码块与行内代码，以及 Markdown 链接/图片的地址部分。
+func Split(content string) []Segment {
+	var segments []Segment
+	textStart := 0
+
+	keep := func(start, end int) {
+		addText(&segments, content[textStart:start])
+		segments = append(segments, Segment{Text: content[start:end]})
+		textStart = end
+	}
+
+	for i := 0; i < len(content); {
+		if end := fencedBlockEnd(content, i); end > i {
+			keep(i, end)
+			i = end
+			continue
+		}
+
+		switch content[i] {
+		case '`':
+			if end := inlineCodeEnd(content, i); end > i {
+				keep(i, end)
+				i = end
+				continue
+			}
+		case '<':
+			if end := markupEnd(content, i); end > i {
+				keep(i, end)
+				i = end
+				continue
+			}
+		case ']':
+			if strings.HasPrefix(content[i:], "](") {
+				if j := strings.IndexByte(content[i:], ')'); j > 0 && !strings.Contains(content[i:i+j], "\n") {
+					keep(i, i+j+1)
+					i += j + 1
+					continue
+				}
+			}
+		}
+		i++
+	}
+	addText(&segments, content[textStart:])
+
+	return segments
+}
+
+// Translate 按 Split 的切分逐段翻译，保留标签与代码，并保留每段首尾空白
+func Translate(content string, fn TranslateFunc) (string, error) {
+	var sb strings.Builder
+	sb.Grow(len(content))
+
+	for _, seg := range Split(content) {
+		if !seg.Translate {
+			sb.WriteString(seg.Text)
+			continue
+		}
+		translated, err := fn(seg.Text)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(translated)
+	}
+
+	return sb.String(), nil
+}
+
+// addText 追加文本片段：首尾空白单独保留，只有含字母的部分才送翻
+func addText(segments *[]Segment, text string) {
+	if text == "" {
+		return
+	}
+
+	core := strings.TrimSpace(text)
+	if core == "" || !strings.ContainsFunc(core, unicode.IsLetter) {
+		*segments = append(*segments, Segment{Text: text})
+		return
+	}
+
+	lead := strings.Index(text, core)
+	if lead > 0 {
+		*segments = append(*segments, Segment{Text: text[:lead]})
+	}
+	*segments = append(*segments, Segment{Text: core, Translate: true})
+	if tail := text[lead+len(core):]; tail != "" {
+		*segments = append(*segments, Segment{Text: tail})
+	}
+}
+
+// fencedBlockEnd 位于行首的 Markdown 围栏代码块（``` 或 ~~~）的结束位置，非代码块返回 i
+func fencedBlockEnd(s string, i int) int {
+	if i > 0 && s[i-1] != '\n' {
+		return i
+	}
+
+	j := i
+	for j < len(s) && j-i < 3 && s[j] == ' ' {
+		j++
+	}
+	if j >= len(s) || (s[j] != '`' && s[j] != '~') {
+		return i
+	}
+	fence := s[j]
+	n := 0
+	for j+n < len(s) && s[j+n] == fence {
+		n++
+	}
+	if n < 3 {
+		return i
+	}
+
+	// 从下一行起查找不短于开头的闭合围栏，找不到时保留到末尾
+	pos := strings.IndexByte(s[j:], '\n')
+	if pos < 0 {
+		return len(s)
+	}
+	pos += j + 1
+	for pos < len(s) {
+		lineEnd := strings.IndexByte(s[pos:], '\n')
+		line := s[pos:]
+		if lineEnd >= 0 {
+			line = s[pos : pos+lineEnd]
+		}
+		trimmed := strings.TrimLeft(line, " ")
+		if len(line)-len(trimmed) <= 3 && strings.HasPrefix(trimmed, strings.Repeat(string(fence), n)) &&
+			strings.TrimSpace(strings.TrimLeft(trimmed, string(fence))) == "" {
+			if lineEnd < 0 {
+				return len(s)
+			}
+			return pos + lineEnd + 1
+		}
+		if lineEnd < 0 {
+			break
+		}
+		pos += lineEnd + 1
+	}
+	return len(s)
+}
+
+// inlineCodeEnd 行内代码（成对的等长反引号）的结束位置，无闭合时返回 i
+func inlineCodeEnd(s string, i int) int {
+	n := 0
+	for i+n < len(s) && s[i+n] == '`' {
+		n++
+	}
+	ticks := strings.Repeat("`", n)
+
+	for j := i + n; j < len(s); {
+		k := strings.Index(s[j:], ticks)
+		if k < 0 {
+			return i
+		}
+		k += j
+		m := 0
+		for k+m < len(s) && s[k+m] == '`' {
+			m++
+		}
+		if m == n {
+			return k + n
+		}
+		j = k + m
+	}
+	return i
+}
+
+// markupEnd HTML 注释或标签的结束位置；pre/code 等元素返回其闭合标签之后的位置，非标签返回 i
+func markupEnd(s string, i int) int {
+	if strings.HasPrefix(s[i:], "<!--") {
+		if j := strings.Index(s[i+4:], "-->"); j >= 0 {
+			return i + 4 + j + 3
+		}
+		return len(s)
+	}
+
+	if i+1 >= len(s) {
+		return i
+	}
+	c := s[i+1]
+	closing := c == '/'
+	if !closing && c != '!' && c != '?' && !isASCIILetter(c) {
+		return i
+	}
+
+	end := tagEnd(s, i+1)
+	if end < 0 {
+		return i
+	}
+	if closing {
+		return end
+	}
+
+	name := tagName(s[i+1 : end])
+	if _, ok := rawElements[name]; !ok || strings.HasSuffix(s[i:end], "/>") {
+		return end
+	}
+
+	// 原样保留元素内容直至对应的闭合标签
+	j := strings.Index(strings.ToLower(s[end:]), "</"+name)
+	if j < 0 {
+		return len(s)
+	}
+	if k := tagEnd(s, end+j+1); k >= 0 {
+		return k
+	}
+	return len(s)
+}
+
+// tagEnd 从 i 起查找标签结束的 '>'（跳过引号内的内容），返回其后一位；未找到返回 -1
+func tagEnd(s string, i int) int {
+	var quote byte
+	for ; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			return i + 1
+		case c == '<':
+			return -1
+		}
+	}
+	return -1
+}
+
+// tagName 标签名（小写）
+func tagName(tag string) string {
+	end := 0
+	for end < len(tag) && (isASCIILetter(tag[end]) || (end > 0 && tag[end] >= '0' && tag[end] <= '9')) {
+		end++
+	}
+	return strings.ToLower(tag[:end])
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
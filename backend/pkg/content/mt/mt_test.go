@@ -0,0 +1,55 @@
+package mt
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string // 送翻的片段
+	}{
+		{name: "plain", content: "  Hello world  ", want: []string{"Hello world"}},
+		{name: "html", content: `<p class="a>b">Hello <b>world</b></p>`, want: []string{"Hello", "world"}},
+		{name: "pre and code", content: "<p>Run</p><pre><code>go test ./...</code></pre><CODE>x := 1</CODE>", want: []string{"Run"}},
+		{name: "comment", content: "<!-- note -->Text", want: []string{"Text"}},
+		{name: "fenced", content: "Intro\n```go\nfmt.Println(\"hi\")\n```\nOutro", want: []string{"Intro", "Outro"}},
+		{name: "inline code", content: "Use ``a ` b`` and `c` here", want: []string{"Use", "and", "here"}},
+		{name: "link", content: "See [the docs](https://example.com/docs) now", want: []string{"See [the docs", "now"}},
+		{name: "not a tag", content: "a < b and c<d", want: []string{"a < b and c<d"}},
+		{name: "numbers only", content: "<td>42</td>", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			segments := Split(tt.content)
+
+			var sb strings.Builder
+			var got []string
+			for _, seg := range segments {
+				sb.WriteString(seg.Text)
+				if seg.Translate {
+					got = append(got, seg.Text)
+				}
+			}
+			assert.Equal(t, tt.content, sb.String())
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	upper := func(s string) (string, error) { return strings.ToUpper(s), nil }
+
+	out, err := Translate("<p>Hello <code>fmt</code> world</p>\n", upper)
+	assert.NoError(t, err)
+	assert.Equal(t, "<p>HELLO <code>fmt</code> WORLD</p>\n", out)
+
+	_, err = Translate("<p>Hello</p>", func(string) (string, error) { return "", errors.New("quota exceeded") })
+	assert.Error(t, err)
+}
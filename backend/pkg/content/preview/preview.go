@@ -0,0 +1,131 @@
+package preview
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid preview token")
+	ErrExpiredToken = errors.New("preview token expired")
+)
+
+const (
+	KindPost = "post"
+	KindPage = "page"
+)
+
+const (
+	fallbackTTL    = 24 * time.Hour
+	fallbackMaxTTL = 30 * 24 * time.Hour
+)
+
+// Claims 预览令牌内容
+type Claims struct {
+	TokenID   string `json:"j"`           // 令牌ID，用于吊销
+	TenantID  uint32 `json:"t"`           // 租户ID
+	Kind      string `json:"k"`           // 内容类型：post / page
+	ContentID uint32 `json:"c"`           // 内容ID
+	Revision  int64  `json:"r,omitempty"` // 锁定的修订（内容更新时间，Unix 毫秒），0 表示始终预览最新草稿
+	Locale    string `json:"l,omitempty"` // 锁定的语言代码
+	ExpiresAt int64  `json:"exp"`         // 过期时间（Unix 秒）
+}
+
+// Signer 草稿预览令牌签发与解析。
+// 令牌为 HMAC-SHA256 签名，格式为 base64url(payload).base64url(signature)；
+// 签名只保证令牌未被篡改且未过期，吊销状态由调用方按 TokenID 另行校验。
+type Signer struct {
+	secret     []byte
+	defaultTTL time.Duration
+	maxTTL     time.Duration
+}
+
+func NewSigner(secret string, defaultTTL, maxTTL time.Duration) (*Signer, error) {
+	if len(secret) < 16 {
+		return nil, errors.New("preview token secret must be at least 16 bytes")
+	}
+	if maxTTL <= 0 {
+		maxTTL = fallbackMaxTTL
+	}
+	if defaultTTL <= 0 {
+		defaultTTL = fallbackTTL
+	}
+	if defaultTTL > maxTTL {
+		defaultTTL = maxTTL
+	}
+	return &Signer{secret: []byte(secret), defaultTTL: defaultTTL, maxTTL: maxTTL}, nil
+}
+
+// TTL 返回实际使用的有效期：未指定时取默认值，超过上限时截断
+func (s *Signer) TTL(requested time.Duration) time.Duration {
+	switch {
+	case requested <= 0:
+		return s.defaultTTL
+	case requested > s.maxTTL:
+		return s.maxTTL
+	default:
+		return requested
+	}
+}
+
+// NewTokenID 生成随机令牌ID
+func NewTokenID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Sign 签发令牌，ExpiresAt 按 ttl（经 TTL 截断）由 now 计算，TokenID 为空时自动生成。
+// 返回令牌与最终写入的 Claims。
+func (s *Signer) Sign(claims Claims, ttl time.Duration, now time.Time) (string, Claims) {
+	if claims.TokenID == "" {
+		claims.TokenID = NewTokenID()
+	}
+	claims.ExpiresAt = now.Add(s.TTL(ttl)).Unix()
+
+	payload, _ := json.Marshal(claims)
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.mac(encoded)), claims
+}
+
+// Parse 校验令牌签名与有效期，返回令牌内容
+func (s *Signer) Parse(token string, now time.Time) (*Claims, error) {
+	encoded, sig, ok := strings.Cut(token, ".")
+	if !ok || encoded == "" || sig == "" {
+		return nil, ErrInvalidToken
+	}
+
+	got, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(got, s.mac(encoded)) {
+		return nil, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	var claims Claims
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if claims.TokenID == "" || claims.ContentID == 0 || (claims.Kind != KindPost && claims.Kind != KindPage) {
+		return nil, ErrInvalidToken
+	}
+
+	if now.Unix() >= claims.ExpiresAt {
+		return nil, ErrExpiredToken
+	}
+	return &claims, nil
+}
+
+func (s *Signer) mac(data string) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
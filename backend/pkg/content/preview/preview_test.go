@@ -0,0 +1,55 @@
+package preview
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSigner(t *testing.T) {
+	_, err := NewSigner("short", time.Hour, time.Hour)
+	assert.Error(t, err)
+
+	s, err := NewSigner("0123456789abcdef0123456789abcdef", time.Hour, 48*time.Hour)
+	assert.NoError(t, err)
+
+	assert.Equal(t, time.Hour, s.TTL(0))
+	assert.Equal(t, 2*time.Hour, s.TTL(2*time.Hour))
+	assert.Equal(t, 48*time.Hour, s.TTL(72*time.Hour))
+
+	now := time.Unix(1700000000, 0)
+	token, claims := s.Sign(Claims{TenantID: 2, Kind: KindPost, ContentID: 7, Locale: "en"}, 0, now)
+	assert.NotEmpty(t, claims.TokenID)
+	assert.Equal(t, now.Add(time.Hour).Unix(), claims.ExpiresAt)
+
+	got, err := s.Parse(token, now.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, claims, *got)
+
+	other, _ := NewSigner("fedcba9876543210fedcba9876543210", time.Hour, 0)
+	forged, _ := other.Sign(claims, 0, now)
+	noKind, _ := s.Sign(Claims{TenantID: 2, ContentID: 7}, 0, now)
+	payload, sig, _ := strings.Cut(token, ".")
+
+	tests := []struct {
+		name    string
+		token   string
+		now     time.Time
+		wantErr error
+	}{
+		{"expired", token, now.Add(time.Hour), ErrExpiredToken},
+		{"signed by other secret", forged, now, ErrInvalidToken},
+		{"tampered payload", payload + "x." + sig, now, ErrInvalidToken},
+		{"no signature", payload, now, ErrInvalidToken},
+		{"unknown kind", noKind, now, ErrInvalidToken},
+		{"empty", "", now, ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Parse(tt.token, tt.now)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package related
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultLimit 未指定数量时返回的相关文章数
+	DefaultLimit = 5
+	// MaxLimit 单次最多返回的相关文章数
+	MaxLimit = 20
+
+	// PoolSize 排序后缓存的候选数，多于 MaxLimit 以便读取时剔除已下线文章后仍能填满
+	PoolSize = MaxLimit * 2
+)
+
+// Weights 各信号的权重，0 表示不使用该信号
+type Weights struct {
+	Category     float64 // 共享分类占源文章分类的比例
+	Tag          float64 // 共享标签占源文章标签的比例
+	MoreLikeThis float64 // OpenSearch more_like_this 相似度（按本批最大值归一）
+	Popularity   float64 // 点赞与收藏数（对数归一），仅用于相关候选之间的排序
+}
+
+// DefaultWeights 未配置权重时的默认值
+var DefaultWeights = Weights{
+	Category:     2,
+	Tag:          1,
+	MoreLikeThis: 3,
+	Popularity:   0.5,
+}
+
+// ResolveWeights 租户的权重：有租户配置时以其为准，否则取默认配置；均未配置时取 DefaultWeights
+func ResolveWeights(defaults *Weights, tenantWeights map[uint32]Weights, tenantID uint32) Weights {
+	if w, ok := tenantWeights[tenantID]; ok {
+		return w
+	}
+	if defaults != nil {
+		return *defaults
+	}
+	return DefaultWeights
+}
+
+// ClampLimit 规范请求数量
+func ClampLimit(limit uint32) int {
+	switch {
+	case limit == 0:
+		return DefaultLimit
+	case limit > MaxLimit:
+		return MaxLimit
+	default:
+		return int(limit)
+	}
+}
+
+// Source 源文章的分类与标签数
+type Source struct {
+	Categories int
+	Tags       int
+}
+
+// Candidate 候选文章的各项信号
+type Candidate struct {
+	PostID           uint32
+	SharedCategories int
+	SharedTags       int
+	MoreLikeThis     float64
+	Engagement       int64
+}
+
+// Scored 排序结果
+type Scored struct {
+	PostID uint32
+	Score  float64
+}
+
+// Rank 按加权得分排序候选文章，返回前 limit 篇。
+//
+// 只有分类、标签或文本相似度至少一项有得分的候选才算相关；
+// 热度只在相关候选之间调整次序，不会单独把文章带入结果。
+func Rank(src Source, candidates []Candidate, w Weights, limit int) []Scored {
+	if limit <= 0 || len(candidates) == 0 {
+		return nil
+	}
+
+	var maxMLT float64
+	var maxEngagement int64
+	for _, c := range candidates {
+		maxMLT = math.Max(maxMLT, c.MoreLikeThis)
+		if c.Engagement > maxEngagement {
+			maxEngagement = c.Engagement
+		}
+	}
+
+	scored := make([]Scored, 0, len(candidates))
+	for _, c := range candidates {
+		var relevance float64
+		if src.Categories > 0 {
+			relevance += w.Category * math.Min(float64(c.SharedCategories)/float64(src.Categories), 1)
+		}
+		if src.Tags > 0 {
+			relevance += w.Tag * math.Min(float64(c.SharedTags)/float64(src.Tags), 1)
+		}
+		if maxMLT > 0 {
+			relevance += w.MoreLikeThis * c.MoreLikeThis / maxMLT
+		}
+		if relevance <= 0 {
+			continue
+		}
+
+		score := relevance
+		if maxEngagement > 0 && c.Engagement > 0 {
+			score += w.Popularity * math.Log1p(float64(c.Engagement)) / math.Log1p(float64(maxEngagement))
+		}
+		scored = append(scored, Scored{PostID: c.PostID, Score: score})
+	}
+
+	// 同分时新文章（ID 较大）优先
+	sort.Slice(scored, func(i, j int) bool {
+		if scored[i].Score != scored[j].Score {
+			return scored[i].Score > scored[j].Score
+		}
+		return scored[i].PostID > scored[j].PostID
+	})
+
+	if len(scored) > limit {
+		scored = scored[:limit]
+	}
+	return scored
+}
+
+// EncodeIDs 将排序结果编码为缓存值
+func EncodeIDs(scored []Scored) string {
+	parts := make([]string, 0, len(scored))
+	for _, s := range scored {
+		parts = append(parts, strconv.FormatUint(uint64(s.PostID), 10))
+	}
+	return strings.Join(parts, ",")
+}
+
+// DecodeIDs 解析缓存值，格式错误的项被忽略
+func DecodeIDs(raw string) []uint32 {
+	if raw == "" {
+		return []uint32{}
+	}
+
+	parts := strings.Split(raw, ",")
+	ids := make([]uint32, 0, len(parts))
+	for _, p := range parts {
+		id, err := strconv.ParseUint(p, 10, 32)
+		if err != nil || id == 0 {
+			continue
+		}
+		ids = append(ids, uint32(id))
+	}
+	return ids
+}
@@ -0,0 +1,86 @@
+package related
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveWeights(t *testing.T) {
+	custom := Weights{Category: 1}
+	tenantWeights := map[uint32]Weights{2: {Tag: 5}}
+
+	assert.Equal(t, DefaultWeights, ResolveWeights(nil, nil, 1))
+	assert.Equal(t, custom, ResolveWeights(&custom, tenantWeights, 1))
+	assert.Equal(t, Weights{Tag: 5}, ResolveWeights(&custom, tenantWeights, 2))
+}
+
+func TestClampLimit(t *testing.T) {
+	assert.Equal(t, DefaultLimit, ClampLimit(0))
+	assert.Equal(t, 3, ClampLimit(3))
+	assert.Equal(t, MaxLimit, ClampLimit(MaxLimit+1))
+}
+
+func TestRank(t *testing.T) {
+	src := Source{Categories: 2, Tags: 4}
+	candidates := []Candidate{
+		{PostID: 1, SharedCategories: 2},
+		{PostID: 2, SharedTags: 4, Engagement: 100},
+		{PostID: 3, MoreLikeThis: 12},
+		{PostID: 4, MoreLikeThis: 6, SharedTags: 1},
+		{PostID: 5, Engagement: 1000}, // 仅有热度，不算相关
+		{PostID: 6, SharedCategories: 2},
+	}
+
+	tests := []struct {
+		name  string
+		w     Weights
+		limit int
+		want  []uint32
+	}{
+		{
+			name:  "default weights",
+			w:     DefaultWeights,
+			limit: 10,
+			want:  []uint32{3, 6, 1, 4, 2},
+		},
+		{
+			name:  "popularity breaks category ties",
+			w:     Weights{Category: 1, Tag: 1, Popularity: 2},
+			limit: 10,
+			want:  []uint32{2, 6, 1, 4},
+		},
+		{
+			name:  "zero weight drops the signal",
+			w:     Weights{Tag: 1},
+			limit: 10,
+			want:  []uint32{2, 4},
+		},
+		{
+			name:  "limit",
+			w:     DefaultWeights,
+			limit: 2,
+			want:  []uint32{3, 6},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []uint32
+			for _, s := range Rank(src, candidates, tt.w, tt.limit) {
+				got = append(got, s.PostID)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	assert.Empty(t, Rank(src, nil, DefaultWeights, 5))
+	assert.Empty(t, Rank(Source{}, []Candidate{{PostID: 1, Engagement: 5}}, DefaultWeights, 5))
+}
+
+func TestEncodeDecodeIDs(t *testing.T) {
+	raw := EncodeIDs([]Scored{{PostID: 3}, {PostID: 10}, {PostID: 7}})
+	assert.Equal(t, "3,10,7", raw)
+	assert.Equal(t, []uint32{3, 10, 7}, DecodeIDs(raw))
+	assert.Equal(t, []uint32{}, DecodeIDs(""))
+	assert.Equal(t, []uint32{1, 2}, DecodeIDs("1,x,0,2"))
+}
@@ -0,0 +1,201 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// 发布集可变更的内容类型
+const (
+	EntityPost           = "post"
+	EntityPage           = "page"
+	EntitySection        = "section"
+	EntityNavigationItem = "navigation_item"
+	EntitySiteSetting    = "site_setting"
+)
+
+var (
+	ErrUnknownEntity = errors.New("unsupported release entity type")
+	ErrUnknownField  = errors.New("field cannot be changed by a release")
+	ErrInvalidValue  = errors.New("invalid field value")
+	ErrNoChanges     = errors.New("release item has no changes")
+)
+
+// Kind 字段值类型
+type Kind int
+
+const (
+	KindString Kind = iota
+	KindBool
+	KindUint32
+	KindTime // RFC 3339
+	KindEnum
+)
+
+// Field 发布集可变更的字段
+type Field struct {
+	Name     string
+	Kind     Kind
+	Values   []string // KindEnum 的可选值
+	Nillable bool     // 空字符串表示清空（仅 KindTime）
+}
+
+// fields 各内容类型允许经发布集变更的字段。
+// 文章不允许经发布集移入回收站，也不变更 slug/code 等影响路由的字段。
+var fields = map[string][]Field{
+	EntityPost: {
+		{Name: "status", Kind: KindEnum, Values: []string{"POST_STATUS_DRAFT", "POST_STATUS_PUBLISHED", "POST_STATUS_SCHEDULED"}},
+		{Name: "publish_time", Kind: KindTime, Nillable: true},
+		{Name: "is_featured", Kind: KindBool},
+		{Name: "disallow_comment", Kind: KindBool},
+		{Name: "sort_order", Kind: KindUint32},
+	},
+	EntityPage: {
+		{Name: "status", Kind: KindEnum, Values: []string{"PAGE_STATUS_DRAFT", "PAGE_STATUS_PUBLISHED", "PAGE_STATUS_ARCHIVED"}},
+		{Name: "show_in_navigation", Kind: KindBool},
+		{Name: "template", Kind: KindString},
+		{Name: "redirect_url", Kind: KindString},
+		{Name: "disallow_comment", Kind: KindBool},
+		{Name: "sort_order", Kind: KindUint32},
+	},
+	EntitySection: {
+		{Name: "name", Kind: KindString},
+		{Name: "sort_order", Kind: KindUint32},
+	},
+	EntityNavigationItem: {
+		{Name: "title", Kind: KindString},
+		{Name: "url", Kind: KindString},
+		{Name: "icon", Kind: KindString},
+		{Name: "description", Kind: KindString},
+		{Name: "is_open_new_tab", Kind: KindBool},
+		{Name: "is_invalid", Kind: KindBool},
+		{Name: "sort_order", Kind: KindUint32},
+	},
+	EntitySiteSetting: {
+		{Name: "value", Kind: KindString},
+	},
+}
+
+// Lookup 查找内容类型的可变更字段
+func Lookup(entityType, name string) (Field, error) {
+	list, ok := fields[entityType]
+	if !ok {
+		return Field{}, ErrUnknownEntity
+	}
+	for _, f := range list {
+		if f.Name == name {
+			return f, nil
+		}
+	}
+	return Field{}, fmt.Errorf("%w: %s.%s", ErrUnknownField, entityType, name)
+}
+
+// Validate 校验变更集中的字段与取值
+func Validate(entityType string, changes map[string]string) error {
+	if _, ok := fields[entityType]; !ok {
+		return ErrUnknownEntity
+	}
+	if len(changes) == 0 {
+		return ErrNoChanges
+	}
+	for name, raw := range changes {
+		f, err := Lookup(entityType, name)
+		if err != nil {
+			return err
+		}
+		if _, err = f.Parse(raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SortedNames 按字段名排序，保证发布与预览的顺序稳定
+func SortedNames(changes map[string]string) []string {
+	names := make([]string, 0, len(changes))
+	for name := range changes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Parse 将字符串取值解析为字段类型；返回 nil 表示清空。
+// KindEnum 返回字符串，由调用方转换为具体的枚举类型。
+func (f Field) Parse(raw string) (any, error) {
+	switch f.Kind {
+	case KindString:
+		return raw, nil
+
+	case KindBool:
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s=%q", ErrInvalidValue, f.Name, raw)
+		}
+		return v, nil
+
+	case KindUint32:
+		v, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s=%q", ErrInvalidValue, f.Name, raw)
+		}
+		return uint32(v), nil
+
+	case KindTime:
+		if raw == "" && f.Nillable {
+			return nil, nil
+		}
+		v, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s=%q", ErrInvalidValue, f.Name, raw)
+		}
+		return v, nil
+
+	case KindEnum:
+		if !slices.Contains(f.Values, raw) {
+			return nil, fmt.Errorf("%w: %s=%q", ErrInvalidValue, f.Name, raw)
+		}
+		return raw, nil
+
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidValue, f.Name)
+	}
+}
+
+// Deref 解引用指针取值，nil 指针返回 nil
+func Deref(v any) any {
+	rv := reflect.ValueOf(v)
+	for rv.IsValid() && rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return nil
+	}
+	return rv.Interface()
+}
+
+// Format 将字段取值格式化为字符串（与 Parse 互逆），nil 返回 nil。
+// 用于记录发布前的快照与预览对比。
+func Format(v any) *string {
+	var s string
+	switch x := Deref(v).(type) {
+	case nil:
+		return nil
+	case time.Time:
+		s = x.UTC().Format(time.RFC3339)
+	case string:
+		s = x
+	case fmt.Stringer:
+		s = x.String()
+	default:
+		s = fmt.Sprint(x)
+	}
+	return &s
+}
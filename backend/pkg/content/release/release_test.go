@@ -0,0 +1,78 @@
+package release
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		entityType string
+		changes    map[string]string
+		wantErr    error
+	}{
+		{"publish post", EntityPost, map[string]string{"status": "POST_STATUS_PUBLISHED", "publish_time": "2026-10-20T08:00:00Z"}, nil},
+		{"clear publish time", EntityPost, map[string]string{"publish_time": ""}, nil},
+		{"trash via release", EntityPost, map[string]string{"status": "POST_STATUS_TRASHED"}, ErrInvalidValue},
+		{"field not allowed", EntityPost, map[string]string{"code": "new-code"}, ErrUnknownField},
+		{"bad bool", EntityNavigationItem, map[string]string{"is_invalid": "maybe"}, ErrInvalidValue},
+		{"bad sort order", EntitySection, map[string]string{"sort_order": "-1"}, ErrInvalidValue},
+		{"setting value", EntitySiteSetting, map[string]string{"value": ""}, nil},
+		{"no changes", EntityPage, nil, ErrNoChanges},
+		{"unknown entity", "menu", map[string]string{"name": "x"}, ErrUnknownEntity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.entityType, tt.changes)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+type status string
+
+func (s status) String() string { return string(s) }
+
+func TestFormatParseRoundTrip(t *testing.T) {
+	at := time.Date(2026, 10, 20, 8, 0, 0, 0, time.UTC)
+	st := status("PAGE_STATUS_ARCHIVED")
+	var nilTime *time.Time
+
+	tests := []struct {
+		entityType string
+		field      string
+		value      any
+		want       *string
+	}{
+		{EntityPost, "publish_time", &at, strPtr("2026-10-20T08:00:00Z")},
+		{EntityPost, "publish_time", nilTime, nil},
+		{EntityPage, "status", &st, strPtr("PAGE_STATUS_ARCHIVED")},
+		{EntityPage, "show_in_navigation", true, strPtr("true")},
+		{EntitySection, "sort_order", uint32(3), strPtr("3")},
+		{EntityNavigationItem, "title", strPtr("Docs"), strPtr("Docs")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.entityType+"."+tt.field, func(t *testing.T) {
+			got := Format(tt.value)
+			assert.Equal(t, tt.want, got)
+			if got == nil {
+				return
+			}
+
+			f, err := Lookup(tt.entityType, tt.field)
+			assert.NoError(t, err)
+			parsed, err := f.Parse(*got)
+			assert.NoError(t, err)
+			assert.Equal(t, *got, *Format(parsed))
+		})
+	}
+}
+
+func strPtr(s string) *string { return &s }
@@ -0,0 +1,211 @@
+package route
+
+import (
+	"errors"
+	"net/url"
+	"path"
+	"strings"
+)
+
+var ErrInvalidPath = errors.New("invalid path")
+
+const (
+	// Wildcard 重定向规则通配符，只能出现在源路径末尾，匹配任意剩余路径
+	Wildcard = "*"
+
+	// MaxPathLength 路径最大长度
+	MaxPathLength = 1024
+)
+
+// NormalizePath 规范化前台路径：去除查询串与片段，补全前导斜杠，折叠重复斜杠并去除末尾斜杠。
+// 含 ".." 等上跳片段的路径视为非法，避免与存储路径产生歧义。
+func NormalizePath(p string) (string, error) {
+	p = strings.TrimSpace(p)
+	if i := strings.IndexAny(p, "?#"); i >= 0 {
+		p = p[:i]
+	}
+	if p == "" {
+		return "", ErrInvalidPath
+	}
+	if len(p) > MaxPathLength {
+		return "", ErrInvalidPath
+	}
+
+	if unescaped, err := url.PathUnescape(p); err == nil {
+		p = unescaped
+	}
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+	for _, seg := range strings.Split(p, "/") {
+		if seg == "." || seg == ".." {
+			return "", ErrInvalidPath
+		}
+	}
+
+	return path.Clean(p), nil
+}
+
+// Segments 拆分规范化后的路径，根路径返回空
+func Segments(p string) []string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
+
+// IsWildcard 源路径是否为通配规则
+func IsWildcard(source string) bool {
+	return strings.HasSuffix(source, Wildcard)
+}
+
+// NormalizeSource 规范化重定向源路径，通配规则保留末尾的 *
+func NormalizeSource(source string) (string, error) {
+	source = strings.TrimSpace(source)
+	if !IsWildcard(source) {
+		return NormalizePath(source)
+	}
+
+	prefix := strings.TrimSuffix(source, Wildcard)
+	if strings.Contains(prefix, Wildcard) {
+		return "", ErrInvalidPath
+	}
+	trailingSlash := strings.HasSuffix(prefix, "/")
+	if prefix == "" || prefix == "/" {
+		return "/" + Wildcard, nil
+	}
+
+	normalized, err := NormalizePath(prefix)
+	if err != nil {
+		return "", err
+	}
+	if trailingSlash {
+		normalized += "/"
+	}
+	return normalized + Wildcard, nil
+}
+
+// NormalizeTarget 校验并规范化重定向目标：站内路径或 http(s) 绝对地址。
+// 只有通配规则的目标可以包含 *。
+func NormalizeTarget(target string, wildcard bool) (string, error) {
+	target = strings.TrimSpace(target)
+	if target == "" || len(target) > 2*MaxPathLength {
+		return "", ErrInvalidPath
+	}
+	if strings.Contains(target, Wildcard) && !wildcard {
+		return "", ErrInvalidPath
+	}
+
+	// 反斜杠会被部分浏览器当作 /，"/\host" 等价于协议相对地址，一律拒绝
+	if strings.Contains(target, "\\") {
+		return "", ErrInvalidPath
+	}
+
+	if strings.HasPrefix(target, "/") && !strings.HasPrefix(target, "//") {
+		for _, seg := range strings.Split(target, "/") {
+			if seg == "." || seg == ".." {
+				return "", ErrInvalidPath
+			}
+		}
+		return target, nil
+	}
+
+	u, err := url.Parse(target)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", ErrInvalidPath
+	}
+	return target, nil
+}
+
+// ValidStatusCode 是否为允许的重定向状态码
+func ValidStatusCode(code uint32) bool {
+	switch code {
+	case 301, 302, 307, 308:
+		return true
+	default:
+		return false
+	}
+}
+
+// Rule 重定向规则
+type Rule struct {
+	Source     string // 源路径，末尾为 * 时为通配规则
+	Target     string // 目标路径或绝对 URL，通配规则中的 * 替换为匹配到的剩余路径
+	StatusCode uint32
+}
+
+// Match 按规则匹配路径：精确规则优先，其次为前缀最长的通配规则。
+// 返回替换通配后的目标地址。
+func Match(rules []Rule, p string) (*Rule, string, bool) {
+	var (
+		best      *Rule
+		bestLen   = -1
+		remainder string
+	)
+	for i := range rules {
+		rule := &rules[i]
+		if !IsWildcard(rule.Source) {
+			if rule.Source == p {
+				return rule, rule.Target, true
+			}
+			continue
+		}
+
+		prefix := strings.TrimSuffix(rule.Source, Wildcard)
+		var rest string
+		switch {
+		case strings.HasPrefix(p, prefix):
+			rest = strings.TrimPrefix(p, prefix)
+		case p+"/" == prefix:
+			// "/blog/*" 同样匹配 "/blog"
+		default:
+			continue
+		}
+		if len(prefix) > bestLen {
+			best, bestLen, remainder = rule, len(prefix), rest
+		}
+	}
+	if best == nil {
+		return nil, "", false
+	}
+
+	target, ok := substitute(best.Target, remainder)
+	if !ok {
+		return nil, "", false
+	}
+	return best, target, true
+}
+
+// substitute 将通配剩余路径代入目标地址，并对结果重新校验，防止剩余路径把目标改写为站外地址：
+// 如 "/*" 代入 "\evil.com" 或 "/evil.com"，"https://a.com*" 代入 "@evil.com"。
+func substitute(target, remainder string) (string, bool) {
+	i := strings.Index(target, Wildcard)
+	if i < 0 {
+		return target, true
+	}
+	if strings.Contains(remainder, "\\") {
+		return "", false
+	}
+	if before := target[:i]; strings.HasPrefix(remainder, "/") && (before == "" || strings.HasSuffix(before, "/")) {
+		return "", false
+	}
+
+	result, err := NormalizeTarget(target[:i]+remainder+target[i+len(Wildcard):], true)
+	if err != nil {
+		return "", false
+	}
+
+	// 绝对地址代入后主机不得改变
+	if !strings.HasPrefix(result, "/") {
+		want, err := url.Parse(strings.Replace(target, Wildcard, "", 1))
+		if err != nil {
+			return "", false
+		}
+		got, err := url.Parse(result)
+		if err != nil || got.Host != want.Host {
+			return "", false
+		}
+	}
+	return result, true
+}
@@ -0,0 +1,151 @@
+package route
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizePath(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "/zh-CN/blog/hello", want: "/zh-CN/blog/hello"},
+		{in: "zh-CN//blog/hello/", want: "/zh-CN/blog/hello"},
+		{in: "/about?from=nav#team", want: "/about"},
+		{in: "/%E5%85%B3%E4%BA%8E", want: "/关于"},
+		{in: "/", want: "/"},
+		{in: "", wantErr: true},
+		{in: "?q=1", wantErr: true},
+		{in: "/blog/../admin", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := NormalizePath(tt.in)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidPath)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNormalizeSource(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "/old-blog/", want: "/old-blog"},
+		{in: "/old-blog/*", want: "/old-blog/*"},
+		{in: "old-blog*", want: "/old-blog*"},
+		{in: "*", want: "/*"},
+		{in: "/a/*/b/*", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := NormalizeSource(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNormalizeTarget(t *testing.T) {
+	tests := []struct {
+		in       string
+		wildcard bool
+		wantErr  bool
+	}{
+		{in: "/blog/hello"},
+		{in: "https://example.com/a?b=1"},
+		{in: "/blog/*", wildcard: true},
+		{in: "/blog/*", wantErr: true},
+		{in: "//evil.example.com", wantErr: true},
+		{in: "/\\evil.example.com", wantErr: true},
+		{in: "javascript:alert(1)", wantErr: true},
+		{in: "/a/../b", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			_, err := NormalizeTarget(tt.in, tt.wildcard)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	rules := []Rule{
+		{Source: "/old-blog/*", Target: "/blog/*", StatusCode: 301},
+		{Source: "/old-blog/archive/*", Target: "/archive", StatusCode: 302},
+		{Source: "/old-blog/hello", Target: "/blog/hello-world", StatusCode: 301},
+		{Source: "/docs*", Target: "https://docs.example.com*", StatusCode: 308},
+	}
+
+	tests := []struct {
+		path       string
+		wantTarget string
+		wantCode   uint32
+		wantOK     bool
+	}{
+		{path: "/old-blog/hello", wantTarget: "/blog/hello-world", wantCode: 301, wantOK: true},
+		{path: "/old-blog/a/b", wantTarget: "/blog/a/b", wantCode: 301, wantOK: true},
+		{path: "/old-blog", wantTarget: "/blog/", wantCode: 301, wantOK: true},
+		{path: "/old-blog/archive/2020", wantTarget: "/archive", wantCode: 302, wantOK: true},
+		{path: "/docs/v1/intro", wantTarget: "https://docs.example.com/v1/intro", wantCode: 308, wantOK: true},
+		{path: "/blog/hello", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rule, target, ok := Match(rules, tt.path)
+			assert.Equal(t, tt.wantOK, ok)
+			if !ok {
+				return
+			}
+			assert.Equal(t, tt.wantTarget, target)
+			assert.Equal(t, tt.wantCode, rule.StatusCode)
+		})
+	}
+}
+
+func TestMatch_RejectsOffsiteTarget(t *testing.T) {
+	rules := []Rule{
+		{Source: "/old/*", Target: "/*", StatusCode: 301},
+		{Source: "/legacy*", Target: "/*", StatusCode: 301},
+		{Source: "/docs*", Target: "https://docs.example.com*", StatusCode: 308},
+	}
+
+	tests := []struct {
+		path       string
+		wantTarget string
+		wantOK     bool
+	}{
+		{path: "/old/hello", wantTarget: "/hello", wantOK: true},
+		{path: "/old/%5Cevil.com", wantOK: false},
+		{path: "/legacy/evil.com", wantOK: false},
+		{path: "/legacy-hello", wantTarget: "/-hello", wantOK: true},
+		{path: "/docs@evil.com", wantOK: false},
+		{path: "/docs/v1", wantTarget: "https://docs.example.com/v1", wantOK: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			p, err := NormalizePath(tt.path)
+			assert.NoError(t, err)
+
+			_, target, ok := Match(rules, p)
+			assert.Equal(t, tt.wantOK, ok)
+			if ok {
+				assert.Equal(t, tt.wantTarget, target)
+			}
+		})
+	}
+}
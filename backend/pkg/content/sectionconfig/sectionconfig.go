@@ -0,0 +1,143 @@
+package sectionconfig
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+//go:embed schemas/*.json
+var schemaFS embed.FS
+
+// 内嵌 schema 的资源地址前缀，仅用于解析 schema 之间的 $ref
+const schemaBaseURL = "https://go-wind-cms.local/schemas/section/"
+
+// typeSchemas 区块类型到 schema 文件的映射；类型名与 SectionType 枚举名一致
+var typeSchemas = map[string]string{
+	"SECTION_TYPE_RICH_TEXT": "rich_text.json",
+	"SECTION_TYPE_MARKDOWN":  "markdown.json",
+	"SECTION_TYPE_TITLE":     "title.json",
+	"SECTION_TYPE_IMAGE":     "image.json",
+	"SECTION_TYPE_GALLERY":   "gallery.json",
+	"SECTION_TYPE_VIDEO":     "video.json",
+	"SECTION_TYPE_BUTTON":    "button.json",
+	"SECTION_TYPE_DIVIDER":   "divider.json",
+	"SECTION_TYPE_SPACER":    "spacer.json",
+	"SECTION_TYPE_CODE":      "code.json",
+	"SECTION_TYPE_HTML":      "html.json",
+	"SECTION_TYPE_FORM":      "form.json",
+	"SECTION_TYPE_CAROUSEL":  "carousel.json",
+	"SECTION_TYPE_CUSTOM":    "custom.json",
+}
+
+// ErrUnknownType 区块类型没有对应的配置 schema
+var ErrUnknownType = errors.New("sectionconfig: unknown section type")
+
+var (
+	compileOnce sync.Once
+	compiled    map[string]*jsonschema.Schema
+	compileErr  error
+)
+
+// compile 编译全部内嵌 schema，仅执行一次
+func compile() (map[string]*jsonschema.Schema, error) {
+	compileOnce.Do(func() {
+		c := jsonschema.NewCompiler()
+
+		entries, err := fs.ReadDir(schemaFS, "schemas")
+		if err != nil {
+			compileErr = err
+			return
+		}
+		for _, e := range entries {
+			f, err := schemaFS.Open(path.Join("schemas", e.Name()))
+			if err != nil {
+				compileErr = err
+				return
+			}
+			doc, err := jsonschema.UnmarshalJSON(f)
+			_ = f.Close()
+			if err != nil {
+				compileErr = fmt.Errorf("sectionconfig: parse %s: %w", e.Name(), err)
+				return
+			}
+			if err = c.AddResource(schemaBaseURL+e.Name(), doc); err != nil {
+				compileErr = err
+				return
+			}
+		}
+
+		schemas := make(map[string]*jsonschema.Schema, len(typeSchemas))
+		for typ, file := range typeSchemas {
+			sch, err := c.Compile(schemaBaseURL + file)
+			if err != nil {
+				compileErr = fmt.Errorf("sectionconfig: compile %s: %w", file, err)
+				return
+			}
+			schemas[typ] = sch
+		}
+		compiled = schemas
+	})
+	return compiled, compileErr
+}
+
+// Validate 按区块类型的 JSON Schema 校验配置。
+//
+// 配置以字符串键值存储，数值、布尔等由 schema 中的 pattern/enum 约束；
+// 未在 schema 中声明的键允许存在（主题自定义样式），但值须为字符串。
+func Validate(sectionType string, config map[string]string) error {
+	schemas, err := compile()
+	if err != nil {
+		return err
+	}
+
+	sch, ok := schemas[sectionType]
+	if !ok {
+		return ErrUnknownType
+	}
+
+	inst := make(map[string]any, len(config))
+	for k, v := range config {
+		inst[k] = v
+	}
+
+	if err = sch.Validate(inst); err != nil {
+		var ve *jsonschema.ValidationError
+		if errors.As(err, &ve) {
+			return errors.New(describe(ve))
+		}
+		return err
+	}
+	return nil
+}
+
+// describe 将校验错误压缩为一行：仅保留叶子错误，形如 "/src: missing property"
+func describe(ve *jsonschema.ValidationError) string {
+	var leaves []string
+	var walk func(e *jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) == 0 {
+			msg := e.Error()
+			// 叶子错误的文本形如 `at '/src': ...`，去掉位置前缀后重新拼接
+			if i := strings.Index(msg, ": "); i >= 0 {
+				msg = msg[i+2:]
+			}
+			leaves = append(leaves, "/"+strings.Join(e.InstanceLocation, "/")+": "+msg)
+			return
+		}
+		for _, c := range e.Causes {
+			walk(c)
+		}
+	}
+	walk(ve)
+
+	sort.Strings(leaves)
+	return strings.Join(leaves, "; ")
+}
@@ -0,0 +1,73 @@
+package sectionconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		typ     string
+		config  map[string]string
+		wantErr string
+	}{
+		{
+			name:   "image",
+			typ:    "SECTION_TYPE_IMAGE",
+			config: map[string]string{"src": "https://cdn.example.com/a.png", "width": "320px", "align": "center"},
+		},
+		{
+			name:    "image without src",
+			typ:     "SECTION_TYPE_IMAGE",
+			config:  map[string]string{"width": "320px"},
+			wantErr: "/: missing property 'src'",
+		},
+		{
+			name:    "common property from shared schema",
+			typ:     "SECTION_TYPE_RICH_TEXT",
+			config:  map[string]string{"align": "middle"},
+			wantErr: "/align: ",
+		},
+		{
+			name:   "theme specific keys are allowed",
+			typ:    "SECTION_TYPE_DIVIDER",
+			config: map[string]string{"theme_color": "#fff"},
+		},
+		{
+			name:    "boolean as string",
+			typ:     "SECTION_TYPE_CAROUSEL",
+			config:  map[string]string{"media_ids": "1,2,3", "autoplay": "yes"},
+			wantErr: "/autoplay: ",
+		},
+		{
+			name:    "form id must be numeric",
+			typ:     "SECTION_TYPE_FORM",
+			config:  map[string]string{"form_id": "abc"},
+			wantErr: "/form_id: ",
+		},
+		{
+			name:   "empty config for type without required keys",
+			typ:    "SECTION_TYPE_HTML",
+			config: nil,
+		},
+		{
+			name:    "unknown type",
+			typ:     "SECTION_TYPE_UNSPECIFIED",
+			wantErr: ErrUnknownType.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.typ, tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package seo
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Status 单项检查结果
+type Status int
+
+const (
+	StatusPass Status = iota + 1
+	StatusWarn
+	StatusFail
+)
+
+// 检查项标识
+const (
+	CheckTitleLength       = "title_length"
+	CheckDescriptionLength = "description_length"
+	CheckKeyword           = "keyword"
+	CheckHeadings          = "headings"
+	CheckImageAlt          = "image_alt"
+	CheckDuplicateTitle    = "duplicate_title"
+)
+
+// 各检查项的满分，合计 100
+const (
+	weightTitleLength       = 20
+	weightDescriptionLength = 20
+	weightKeyword           = 20
+	weightHeadings          = 15
+	weightImageAlt          = 15
+	weightDuplicateTitle    = 10
+)
+
+var (
+	htmlHeadingRegex     = regexp.MustCompile(`(?i)<h([1-6])[\s>]`)
+	markdownHeadingRegex = regexp.MustCompile(`(?m)^\s{0,3}(#{1,6})\s`)
+	htmlImageRegex       = regexp.MustCompile(`(?i)<img\b[^>]*>`)
+	htmlAltRegex         = regexp.MustCompile(`(?i)\balt\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))`)
+	markdownImageRegex   = regexp.MustCompile(`!\[([^\]]*)\]\(`)
+)
+
+// Check 单项检查
+type Check struct {
+	ID       string
+	Status   Status
+	Score    int
+	MaxScore int
+	Message  string
+}
+
+// Report 分析报告
+type Report struct {
+	Score   int // 0-100
+	Keyword string
+	Checks  []Check
+}
+
+// Input 分析输入
+type Input struct {
+	Document Document
+	Meta     Meta   // 补全后的 SEO 元数据
+	Keyword  string // 焦点关键词，为空时不检查关键词
+
+	// DuplicateTitles 租户内同语言下 SEO 标题相同的其他内容数
+	DuplicateTitles int
+}
+
+// Analyze 计算 SEO 得分：标题与描述长度、焦点关键词、标题层级、图片替代文本以及租户内标题重复
+func Analyze(in *Input) *Report {
+	title := in.Meta.SeoTitle
+	if title == "" {
+		title = PlainText(in.Document.Title)
+	}
+	description := in.Meta.MetaDescription
+	content := in.Document.Content
+
+	report := &Report{Keyword: strings.TrimSpace(in.Keyword)}
+	report.Checks = []Check{
+		lengthCheck(CheckTitleLength, weightTitleLength, "标题", title, MinTitleWidth, MaxTitleWidth),
+		lengthCheck(CheckDescriptionLength, weightDescriptionLength, "描述", description, MinDescriptionWidth, MaxDescriptionWidth),
+		keywordCheck(report.Keyword, title, description, PlainText(content)),
+		headingCheck(content),
+		imageAltCheck(content),
+		duplicateTitleCheck(in.DuplicateTitles),
+	}
+
+	for _, c := range report.Checks {
+		report.Score += c.Score
+	}
+
+	return report
+}
+
+func newCheck(id string, maxScore int, status Status, message string) Check {
+	c := Check{ID: id, Status: status, MaxScore: maxScore, Message: message}
+	switch status {
+	case StatusPass:
+		c.Score = maxScore
+	case StatusWarn:
+		c.Score = maxScore / 2
+	}
+	return c
+}
+
+func lengthCheck(id string, maxScore int, label, text string, minWidth, maxWidth int) Check {
+	w := Width(text)
+	switch {
+	case w == 0:
+		return newCheck(id, maxScore, StatusFail, fmt.Sprintf("缺少%s", label))
+	case w < minWidth:
+		return newCheck(id, maxScore, StatusWarn, fmt.Sprintf("%s过短（%d，建议 %d-%d）", label, w, minWidth, maxWidth))
+	case w > maxWidth:
+		return newCheck(id, maxScore, StatusWarn, fmt.Sprintf("%s过长（%d，建议 %d-%d），搜索结果中会被截断", label, w, minWidth, maxWidth))
+	default:
+		return newCheck(id, maxScore, StatusPass, fmt.Sprintf("%s长度合适（%d）", label, w))
+	}
+}
+
+func keywordCheck(keyword, title, description, content string) Check {
+	if keyword == "" {
+		return newCheck(CheckKeyword, weightKeyword, StatusWarn, "未设置焦点关键词（取 SEO 关键词的第一个）")
+	}
+
+	k := strings.ToLower(keyword)
+	var missing []string
+	for _, field := range []struct{ label, text string }{
+		{"标题", title},
+		{"描述", description},
+		{"正文", content},
+	} {
+		if !strings.Contains(strings.ToLower(field.text), k) {
+			missing = append(missing, field.label)
+		}
+	}
+
+	c := Check{ID: CheckKeyword, MaxScore: weightKeyword, Score: weightKeyword * (3 - len(missing)) / 3}
+	switch len(missing) {
+	case 0:
+		c.Status, c.Message = StatusPass, fmt.Sprintf("焦点关键词 %q 出现在标题、描述与正文中", keyword)
+	case 3:
+		c.Status, c.Message = StatusFail, fmt.Sprintf("焦点关键词 %q 未出现在标题、描述与正文中", keyword)
+	default:
+		c.Status, c.Message = StatusWarn, fmt.Sprintf("焦点关键词 %q 未出现在%s中", keyword, strings.Join(missing, "、"))
+	}
+	return c
+}
+
+// headingLevels 正文中各级标题的层级（按出现顺序）；HTML 正文优先
+func headingLevels(content string) []int {
+	var levels []int
+	for _, m := range htmlHeadingRegex.FindAllStringSubmatch(content, -1) {
+		l, _ := strconv.Atoi(m[1])
+		levels = append(levels, l)
+	}
+	if len(levels) > 0 {
+		return levels
+	}
+	for _, m := range markdownHeadingRegex.FindAllStringSubmatch(content, -1) {
+		levels = append(levels, len(m[1]))
+	}
+	return levels
+}
+
+func headingCheck(content string) Check {
+	if strings.TrimSpace(PlainText(content)) == "" {
+		return newCheck(CheckHeadings, weightHeadings, StatusFail, "正文为空")
+	}
+
+	levels := headingLevels(content)
+	if len(levels) == 0 {
+		if Width(PlainText(content)) > longContentWidth {
+			return newCheck(CheckHeadings, weightHeadings, StatusWarn, "长文建议使用小标题分段")
+		}
+		return newCheck(CheckHeadings, weightHeadings, StatusPass, "正文较短，无需小标题")
+	}
+
+	var problems []string
+	for _, l := range levels {
+		if l == 1 {
+			problems = append(problems, "正文不应包含一级标题（页面标题已作为一级标题）")
+			break
+		}
+	}
+	prev := 1
+	for _, l := range levels {
+		if l > prev+1 {
+			problems = append(problems, fmt.Sprintf("标题层级跳跃（h%d 之后出现 h%d）", prev, l))
+			break
+		}
+		prev = l
+	}
+
+	switch len(problems) {
+	case 0:
+		return newCheck(CheckHeadings, weightHeadings, StatusPass, fmt.Sprintf("标题层级正确（%d 个小标题）", len(levels)))
+	case 1:
+		return newCheck(CheckHeadings, weightHeadings, StatusWarn, problems[0])
+	default:
+		return newCheck(CheckHeadings, weightHeadings, StatusFail, strings.Join(problems, "；"))
+	}
+}
+
+func imageAltCheck(content string) Check {
+	total, withAlt := 0, 0
+	for _, tag := range htmlImageRegex.FindAllString(content, -1) {
+		total++
+		if m := htmlAltRegex.FindStringSubmatch(tag); m != nil && strings.TrimSpace(m[1]+m[2]+m[3]) != "" {
+			withAlt++
+		}
+	}
+	for _, m := range markdownImageRegex.FindAllStringSubmatch(content, -1) {
+		total++
+		if strings.TrimSpace(m[1]) != "" {
+			withAlt++
+		}
+	}
+
+	if total == 0 {
+		return newCheck(CheckImageAlt, weightImageAlt, StatusPass, "正文不含图片")
+	}
+
+	c := Check{ID: CheckImageAlt, MaxScore: weightImageAlt, Score: weightImageAlt * withAlt / total}
+	switch {
+	case withAlt == total:
+		c.Status, c.Message = StatusPass, fmt.Sprintf("%d 张图片均设置了替代文本", total)
+	case withAlt*2 >= total:
+		c.Status, c.Message = StatusWarn, fmt.Sprintf("%d/%d 张图片缺少替代文本", total-withAlt, total)
+	default:
+		c.Status, c.Message = StatusFail, fmt.Sprintf("%d/%d 张图片缺少替代文本", total-withAlt, total)
+	}
+	return c
+}
+
+func duplicateTitleCheck(duplicates int) Check {
+	if duplicates > 0 {
+		return newCheck(CheckDuplicateTitle, weightDuplicateTitle, StatusFail, fmt.Sprintf("另有 %d 篇内容使用相同的标题", duplicates))
+	}
+	return newCheck(CheckDuplicateTitle, weightDuplicateTitle, StatusPass, "标题在站内唯一")
+}
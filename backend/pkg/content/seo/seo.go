@@ -0,0 +1,134 @@
+package seo
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const (
+	// MinTitleWidth / MaxTitleWidth SEO 标题建议的显示宽度范围（全角字符计 2）
+	MinTitleWidth = 20
+	MaxTitleWidth = 60
+
+	// MinDescriptionWidth / MaxDescriptionWidth SEO 描述建议的显示宽度范围（全角字符计 2）
+	MinDescriptionWidth = 70
+	MaxDescriptionWidth = 160
+
+	// longContentWidth 超过该宽度的正文建议使用小标题分段
+	longContentWidth = 600
+)
+
+var (
+	htmlTagRegex      = regexp.MustCompile(`(?s)<script.*?</script>|<style.*?</style>|<[^>]*>`)
+	spaceRegex        = regexp.MustCompile(`\s+`)
+	keywordSplitRegex = regexp.MustCompile(`[,，;；、]`)
+)
+
+// Meta SEO 元数据，与翻译上保存的 SeoMeta 一一对应
+type Meta struct {
+	SeoTitle        string
+	MetaKeywords    string
+	MetaDescription string
+	OgTitle         string
+	OgDescription   string
+	OgImage         string
+	CanonicalURL    string
+}
+
+// Document 待分析的内容（某一语言版本）
+type Document struct {
+	Title        string
+	Summary      string
+	Content      string // HTML 或 Markdown 正文
+	Thumbnail    string
+	CanonicalURL string // 默认规范 URL
+}
+
+// Fill 用标题、摘要、正文与缩略图补全缺失的 SEO 字段，已填写的字段保持不变。返回被补全的字段名
+func Fill(doc *Document, meta *Meta) []string {
+	var filled []string
+	set := func(name string, field *string, value string) {
+		if strings.TrimSpace(*field) != "" || value == "" {
+			return
+		}
+		*field = value
+		filled = append(filled, name)
+	}
+
+	set("seo_title", &meta.SeoTitle, ClipWidth(PlainText(doc.Title), MaxTitleWidth))
+
+	description := PlainText(doc.Summary)
+	if description == "" {
+		description = PlainText(doc.Content)
+	}
+	set("meta_description", &meta.MetaDescription, ClipWidth(description, MaxDescriptionWidth))
+
+	set("og_title", &meta.OgTitle, meta.SeoTitle)
+	set("og_description", &meta.OgDescription, meta.MetaDescription)
+	set("og_image", &meta.OgImage, strings.TrimSpace(doc.Thumbnail))
+	set("canonical_url", &meta.CanonicalURL, strings.TrimSpace(doc.CanonicalURL))
+
+	return filled
+}
+
+// FocusKeyword 取 SEO 关键词中的第一个作为焦点关键词
+func FocusKeyword(metaKeywords string) string {
+	for _, k := range keywordSplitRegex.Split(metaKeywords, -1) {
+		if k = strings.TrimSpace(k); k != "" {
+			return k
+		}
+	}
+	return ""
+}
+
+// PlainText 去除 HTML 标签与多余空白
+func PlainText(content string) string {
+	text := htmlTagRegex.ReplaceAllString(content, " ")
+	text = html.UnescapeString(text)
+	return strings.TrimSpace(spaceRegex.ReplaceAllString(text, " "))
+}
+
+// Width 文本显示宽度：全角字符（中日韩文字、全角符号）计 2，其余计 1
+func Width(text string) int {
+	w := 0
+	for _, r := range text {
+		w += runeWidth(r)
+	}
+	return w
+}
+
+// ClipWidth 按显示宽度截断，截断时优先在空白处断开
+func ClipWidth(text string, maxWidth int) string {
+	text = strings.TrimSpace(text)
+	if Width(text) <= maxWidth {
+		return text
+	}
+
+	w, cut, lastSpace := 0, 0, -1
+	for i, r := range text {
+		if w+runeWidth(r) > maxWidth {
+			break
+		}
+		w += runeWidth(r)
+		cut = i + len(string(r))
+		if unicode.IsSpace(r) {
+			lastSpace = i
+		}
+	}
+	if lastSpace > cut/2 {
+		cut = lastSpace
+	}
+	return strings.TrimSpace(text[:cut])
+}
+
+func runeWidth(r rune) int {
+	switch {
+	case unicode.Is(unicode.Han, r), unicode.Is(unicode.Hiragana, r), unicode.Is(unicode.Katakana, r),
+		unicode.Is(unicode.Hangul, r), r >= 0x3000 && r <= 0x303f, r >= 0xff00 && r <= 0xffef:
+		return 2
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,120 @@
+package seo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFill(t *testing.T) {
+	doc := &Document{
+		Title:        "Go 并发编程实战",
+		Summary:      "<p>介绍 goroutine 与 channel 的常见用法。</p>",
+		Thumbnail:    "https://cdn.example.com/go.png",
+		CanonicalURL: "/zh-CN/post/go-concurrency",
+	}
+	meta := &Meta{OgTitle: "自定义分享标题"}
+
+	filled := Fill(doc, meta)
+
+	assert.Equal(t, []string{"seo_title", "meta_description", "og_description", "og_image", "canonical_url"}, filled)
+	assert.Equal(t, "Go 并发编程实战", meta.SeoTitle)
+	assert.Equal(t, "介绍 goroutine 与 channel 的常见用法。", meta.MetaDescription)
+	assert.Equal(t, "自定义分享标题", meta.OgTitle)
+	assert.Equal(t, meta.MetaDescription, meta.OgDescription)
+	assert.Equal(t, "https://cdn.example.com/go.png", meta.OgImage)
+	assert.Equal(t, "/zh-CN/post/go-concurrency", meta.CanonicalURL)
+
+	// 无摘要时从正文生成描述，并按宽度截断
+	meta = &Meta{}
+	Fill(&Document{Title: "T", Content: "<p>" + strings.Repeat("word ", 60) + "</p>"}, meta)
+	assert.LessOrEqual(t, Width(meta.MetaDescription), MaxDescriptionWidth)
+	assert.True(t, strings.HasPrefix(meta.MetaDescription, "word word"))
+	assert.False(t, strings.HasSuffix(meta.MetaDescription, " "))
+}
+
+func TestWidthAndClip(t *testing.T) {
+	assert.Equal(t, 5, Width("hello"))
+	assert.Equal(t, 8, Width("你好世界"))
+	assert.Equal(t, 6, Width("Go语言"))
+
+	assert.Equal(t, "你好", ClipWidth("你好世界", 5))
+	assert.Equal(t, "hello", ClipWidth("hello world", 8))
+	assert.Equal(t, "short", ClipWidth("short", 10))
+}
+
+func TestFocusKeyword(t *testing.T) {
+	assert.Equal(t, "golang", FocusKeyword(" golang, 并发"))
+	assert.Equal(t, "并发", FocusKeyword("，并发；goroutine"))
+	assert.Equal(t, "", FocusKeyword(" , "))
+}
+
+func findCheck(r *Report, id string) Check {
+	for _, c := range r.Checks {
+		if c.ID == id {
+			return c
+		}
+	}
+	return Check{}
+}
+
+func TestAnalyze(t *testing.T) {
+	good := &Input{
+		Document: Document{
+			Content: `<h2>Goroutine 入门</h2><p>Golang 的 goroutine 很轻量。</p>` +
+				`<h3>调度</h3><p>示例</p><img src="a.png" alt="调度示意图">`,
+		},
+		Meta: Meta{
+			SeoTitle:        "Golang 并发编程实战：goroutine 与 channel",
+			MetaDescription: "本文介绍 Golang 中 goroutine 与 channel 的常见用法，包括调度模型、同步原语以及常见的并发模式。",
+		},
+		Keyword: "golang",
+	}
+	report := Analyze(good)
+	assert.Equal(t, 100, report.Score)
+	for _, c := range report.Checks {
+		assert.Equal(t, StatusPass, c.Status, c.ID)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(in *Input)
+		check  string
+		status Status
+	}{
+		{name: "missing title", modify: func(in *Input) { in.Meta.SeoTitle = "" }, check: CheckTitleLength, status: StatusFail},
+		{name: "short description", modify: func(in *Input) { in.Meta.MetaDescription = "Golang 并发" }, check: CheckDescriptionLength, status: StatusWarn},
+		{name: "no keyword", modify: func(in *Input) { in.Keyword = "" }, check: CheckKeyword, status: StatusWarn},
+		{name: "keyword absent", modify: func(in *Input) { in.Keyword = "rust" }, check: CheckKeyword, status: StatusFail},
+		{
+			name:   "h1 and skipped level",
+			modify: func(in *Input) { in.Document.Content = "# 标题\n\n#### 细节\n\n正文" },
+			check:  CheckHeadings, status: StatusFail,
+		},
+		{
+			name:   "long content without headings",
+			modify: func(in *Input) { in.Document.Content = strings.Repeat("golang 内容。", 100) },
+			check:  CheckHeadings, status: StatusWarn,
+		},
+		{
+			name: "images without alt",
+			modify: func(in *Input) {
+				in.Document.Content = `<img src="a.png"><img src="b.png" alt=""> ![](c.png) ![图](d.png)`
+			},
+			check: CheckImageAlt, status: StatusFail,
+		},
+		{name: "duplicate title", modify: func(in *Input) { in.DuplicateTitles = 2 }, check: CheckDuplicateTitle, status: StatusFail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := *good
+			tt.modify(&in)
+			r := Analyze(&in)
+			c := findCheck(r, tt.check)
+			assert.Equal(t, tt.status, c.Status, c.Message)
+			assert.Less(t, r.Score, 100)
+		})
+	}
+}
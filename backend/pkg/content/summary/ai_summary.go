@@ -1,102 +1,49 @@
 package summary
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
-	"net/http"
-	"strings"
-	"time"
 	"unicode/utf8"
+
+	"go-wind-cms/pkg/content/llm"
 )
 
-// AISummaryRequest AI摘要请求参数
-type AISummaryRequest struct {
-	Model       string    `json:"model"`
-	Messages    []Message `json:"messages"`
-	Temperature float64   `json:"temperature"`
-	MaxTokens   int       `json:"max_tokens"`
-}
+// AISummaryRequest AI摘要请求参数（OpenAI 兼容接口的请求体）
+type AISummaryRequest = llm.ChatRequest
 
-type Message struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
-}
+type Message = llm.Message
 
 // AISummaryResponse AI摘要响应
-type AISummaryResponse struct {
-	Choices []Choice `json:"choices"`
-}
+type AISummaryResponse = llm.ChatResponse
 
-type Choice struct {
-	Message Message `json:"message"`
-}
+type Choice = llm.Choice
 
 // GenerateSummaryByAI AI生成摘要
-// apiKey: AI平台API Key
+// provider: 大语言模型供应商，见 llm.New
 // content: 原始HTML内容（或剥离标签后的纯文本）
 // lang: 语言（zh-CN/en-US）
 // maxLength: 摘要最大字符数
-func GenerateSummaryByAI(apiKey, content, lang string, maxLength int) (string, error) {
-	// 步骤1：先剥离HTML标签，减少Token消耗
-	plainText := htmlTagRegex.ReplaceAllString(content, "")
-	plainText = spaceRegex.ReplaceAllString(plainText, " ")
-	plainText = strings.TrimSpace(plainText)
-
-	// 步骤2：构建AI提示词（Prompt）
-	prompt := fmt.Sprintf(`请为以下文章生成简洁的摘要，要求：
-1. 语言：%s
-2. 长度：不超过%d个字符
-3. 内容：提炼核心观点，不要简单截断
-4. 格式：纯文本，无markdown，无多余标点
-
-文章内容：%s`, lang, maxLength, plainText)
-
-	// 步骤3：调用OpenAI API
-	requestBody := AISummaryRequest{
-		Model:       "gpt-3.5-turbo",
-		Temperature: 0.3, // 低随机性，保证摘要精准
-		MaxTokens:   200, // 预留足够Token生成摘要
-		Messages: []Message{
-			{Role: "user", Content: prompt},
-		},
+func GenerateSummaryByAI(ctx context.Context, provider llm.Provider, content, lang string, maxLength int) (string, error) {
+	if provider == nil {
+		return "", llm.ErrNotConfigured
 	}
 
-	// 序列化请求体
-	jsonBody, err := json.Marshal(requestBody)
-	if err != nil {
-		return "", fmt.Errorf("序列化请求体失败：%v", err)
-	}
+	// 提示词中已剥离HTML标签，减少Token消耗
+	req := llm.SummaryPrompt(content, lang, maxLength)
+	req.MaxTokens = 200 // 预留足够Token生成摘要
 
-	// 发送请求
-	req, err := http.NewRequest("POST", "https://api.openai.com/v1/chat/completions", bytes.NewBuffer(jsonBody))
-	if err != nil {
-		return "", fmt.Errorf("创建请求失败：%v", err)
-	}
-
-	// 设置请求头
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+apiKey)
-
-	// 发送请求（设置超时）
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("调用AI API失败：%v", err)
-	}
-	defer resp.Body.Close()
-
-	// 解析响应
-	var aiResp AISummaryResponse
-	if err := json.NewDecoder(resp.Body).Decode(&aiResp); err != nil {
-		return "", fmt.Errorf("解析AI响应失败：%v", err)
-	}
-
-	// 提取摘要内容
-	if len(aiResp.Choices) == 0 {
+	resp, err := provider.Complete(ctx, req)
+	switch {
+	case errors.Is(err, llm.ErrEmptyResponse):
 		return "", fmt.Errorf("AI未返回摘要")
+	case errors.Is(err, llm.ErrInvalidResponse):
+		return "", fmt.Errorf("解析AI响应失败：%w", err)
+	case err != nil:
+		return "", fmt.Errorf("调用AI API失败：%w", err)
 	}
-	summary := strings.TrimSpace(aiResp.Choices[0].Message.Content)
+
+	summary := llm.Clip(resp.Text, 0)
 
 	// 兜底：如果AI生成的摘要过长，截断
 	if utf8.RuneCountInString(summary) > maxLength {
@@ -108,9 +55,9 @@ func GenerateSummaryByAI(apiKey, content, lang string, maxLength int) (string, e
 
 // 使用示例
 // func main() {
-// 	apiKey := "your-openai-api-key"
+// 	provider := llm.NewOpenAI(llm.Config{APIKey: "your-openai-api-key"})
 // 	htmlContent := `<p>Vue3 暗黑模式教程是前端开发的重要知识点。通过CSS变量和Tiptap编辑器，可快速适配暗黑模式，提升用户体验。该方案已适配Vben Admin，支持多语言切换，性能比UEditor提升80%。</p>`
-// 	summary, err := GenerateSummaryByAI(apiKey, htmlContent, "zh-CN", 100)
+// 	summary, err := GenerateSummaryByAI(context.Background(), provider, htmlContent, "zh-CN", 100)
 // 	if err != nil {
 // 		fmt.Println("生成失败：", err)
 // 		return
@@ -1,14 +1,27 @@
 package summary
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
+
+	"go-wind-cms/pkg/content/llm"
 )
 
+// newTestProvider 指向 mock server 的 OpenAI 兼容供应商
+func newTestProvider(baseURL, apiKey string, timeout time.Duration) llm.Provider {
+	return llm.NewOpenAI(llm.Config{
+		BaseURL: baseURL,
+		APIKey:  apiKey,
+		Model:   "gpt-3.5-turbo",
+		Timeout: timeout,
+	})
+}
+
 // TestGenerateSummaryByAI_Success 测试成功生成摘要
 func TestGenerateSummaryByAI_Success(t *testing.T) {
 	tests := []struct {
@@ -93,8 +106,8 @@ func TestGenerateSummaryByAI_Success(t *testing.T) {
 				if req.Model != "gpt-3.5-turbo" {
 					t.Errorf("Expected model gpt-3.5-turbo, got %s", req.Model)
 				}
-				if len(req.Messages) != 1 {
-					t.Errorf("Expected 1 message, got %d", len(req.Messages))
+				if n := len(req.Messages); n == 0 || req.Messages[n-1].Role != "user" {
+					t.Errorf("Expected the prompt as the last user message, got %v", req.Messages)
 				}
 
 				// 返回 mock 响应
@@ -104,13 +117,14 @@ func TestGenerateSummaryByAI_Success(t *testing.T) {
 			}))
 			defer server.Close()
 
-			// 临时替换 API URL（通过修改函数或使用环境变量）
-			// 这里我们需要修改函数以支持自定义 URL，或者使用接口注入
-			// 暂时跳过实际调用，使用 mock 验证逻辑
-
-			// 由于原函数硬编码了 URL，我们需要重构或使用集成测试
-			// 这里先验证其他逻辑
-			t.Skip("Skipping actual API call test - requires refactoring for dependency injection")
+			provider := newTestProvider(server.URL, "test-key", 10*time.Second)
+			summary, err := GenerateSummaryByAI(context.Background(), provider, tt.content, tt.lang, tt.maxLength)
+			if err != nil {
+				t.Fatalf("GenerateSummaryByAI failed: %v", err)
+			}
+			if !strings.Contains(summary, tt.wantSubstr) {
+				t.Errorf("expected summary to contain %q, got %q", tt.wantSubstr, summary)
+			}
 		})
 	}
 }
@@ -216,8 +230,14 @@ func TestGenerateSummaryByAI_ErrorHandling(t *testing.T) {
 			}))
 			defer server.Close()
 
-			// 由于原函数硬编码了 URL，这里需要重构
-			t.Skip("Skipping error handling test - requires refactoring for dependency injection")
+			provider := newTestProvider(server.URL, tt.apiKey, 10*time.Second)
+			_, err := GenerateSummaryByAI(context.Background(), provider, tt.content, tt.lang, tt.maxLength)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("error = %v, expectError %v", err, tt.expectError)
+			}
+			if err != nil && !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("expected error to contain %q, got %q", tt.errorContains, err.Error())
+			}
 		})
 	}
 }
@@ -247,13 +267,19 @@ func TestGenerateSummaryByAI_TruncateLongResponse(t *testing.T) {
 func TestGenerateSummaryByAI_RequestTimeout(t *testing.T) {
 	// 创建一个慢响应的 mock server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		time.Sleep(15 * time.Second) // 超过 10 秒超时设置
+		select {
+		case <-time.After(2 * time.Second): // 超过客户端超时设置
+		case <-r.Context().Done():
+		}
 		w.WriteHeader(http.StatusOK)
 	}))
 	defer server.Close()
 
-	// 由于原函数硬编码了 URL，这里需要重构
-	t.Skip("Skipping timeout test - requires refactoring for dependency injection")
+	provider := newTestProvider(server.URL, "test-key", 200*time.Millisecond)
+	_, err := GenerateSummaryByAI(context.Background(), provider, "test content", "zh-CN", 100)
+	if err == nil || !strings.Contains(err.Error(), "调用AI API失败") {
+		t.Errorf("expected timeout error, got %v", err)
+	}
 }
 
 // TestAISummaryRequest_Marshaling 测试请求结构体序列化
@@ -430,7 +456,8 @@ func TestGenerateSummaryByAI_Integration(t *testing.T) {
 	// }
 
 	// content := "<p>Vue3 暗黑模式教程</p>"
-	// summary, err := GenerateSummaryByAI(apiKey, content, "zh-CN", 100)
+	// provider := llm.NewOpenAI(llm.Config{APIKey: apiKey})
+	// summary, err := GenerateSummaryByAI(context.Background(), provider, content, "zh-CN", 100)
 	// if err != nil {
 	// 	t.Fatalf("GenerateSummaryByAI failed: %v", err)
 	// }
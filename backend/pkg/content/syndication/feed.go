@@ -0,0 +1,265 @@
+package syndication
+
+import (
+	"encoding/xml"
+	"net/url"
+	"time"
+)
+
+const (
+	// DefaultFeedSize 订阅源默认包含的条目数
+	DefaultFeedSize = 20
+	// MaxFeedSize 订阅源最多包含的条目数
+	MaxFeedSize = 100
+
+	atomNamespace = "http://www.w3.org/2005/Atom"
+)
+
+// 订阅范围，同时作为分类与标签订阅源访问路径的前缀
+const (
+	FeedScopeSite     = ""
+	FeedScopeCategory = "category"
+	FeedScopeTag      = "tag"
+)
+
+// FeedPath 订阅源的访问路径：站点为 /feed.xml、/atom.xml，分类与标签为 /{scope}/{slug}/feed.xml 等
+func FeedPath(scope, slug string, atom bool) string {
+	file := "feed.xml"
+	if atom {
+		file = "atom.xml"
+	}
+	if scope == FeedScopeSite {
+		return "/" + file
+	}
+	return "/" + scope + "/" + url.PathEscape(slug) + "/" + file
+}
+
+// Channel 订阅源（站点、分类或标签）
+type Channel struct {
+	Title       string
+	Link        string // 订阅源对应的页面地址
+	FeedURL     string // 订阅源自身地址
+	Description string
+	Language    string
+	Updated     time.Time
+}
+
+// Item 订阅源中的一篇文章
+type Item struct {
+	ID         string // 全局唯一标识，为空时取 Link
+	Title      string
+	Link       string
+	Summary    string
+	Author     string
+	Categories []string
+	Published  time.Time
+	Updated    time.Time
+}
+
+func (i *Item) guid() string {
+	if i.ID != "" {
+		return i.ID
+	}
+	return i.Link
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        rssGUID  `xml:"guid"`
+	Description string   `xml:"description,omitempty"`
+	Author      string   `xml:"dc:creator,omitempty"`
+	Categories  []string `xml:"category"`
+	PubDate     string   `xml:"pubDate,omitempty"`
+}
+
+type rssAtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssChannel struct {
+	Title         string       `xml:"title"`
+	Link          string       `xml:"link"`
+	Description   string       `xml:"description"`
+	Language      string       `xml:"language,omitempty"`
+	LastBuildDate string       `xml:"lastBuildDate,omitempty"`
+	AtomLink      *rssAtomLink `xml:"atom:link,omitempty"`
+	Items         []rssItem    `xml:"item"`
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Atom    string     `xml:"xmlns:atom,attr"`
+	DC      string     `xml:"xmlns:dc,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomText struct {
+	Type  string `xml:"type,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Links      []atomLink     `xml:"link"`
+	Published  string         `xml:"published,omitempty"`
+	Updated    string         `xml:"updated"`
+	Summary    *atomText      `xml:"summary,omitempty"`
+	Author     *atomPerson    `xml:"author,omitempty"`
+	Categories []atomCategory `xml:"category"`
+}
+
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"feed"`
+	XMLNS    string      `xml:"xmlns,attr"`
+	Lang     string      `xml:"xml:lang,attr,omitempty"`
+	ID       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Links    []atomLink  `xml:"link"`
+	Updated  string      `xml:"updated"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+// BuildRSS 生成 RSS 2.0 订阅源
+func BuildRSS(ch *Channel, items []Item) ([]byte, error) {
+	feed := rssFeed{
+		Version: "2.0",
+		Atom:    atomNamespace,
+		DC:      "http://purl.org/dc/elements/1.1/",
+		Channel: rssChannel{
+			Title:         ch.Title,
+			Link:          ch.Link,
+			Description:   ch.Description,
+			Language:      ch.Language,
+			LastBuildDate: formatRFC1123(feedUpdated(ch, items)),
+			Items:         make([]rssItem, 0, len(items)),
+		},
+	}
+	if feed.Channel.Description == "" {
+		// description 为 RSS 2.0 必填元素
+		feed.Channel.Description = ch.Title
+	}
+	if ch.FeedURL != "" {
+		feed.Channel.AtomLink = &rssAtomLink{Href: ch.FeedURL, Rel: "self", Type: "application/rss+xml"}
+	}
+
+	for i := range items {
+		item := &items[i]
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			GUID:        rssGUID{IsPermaLink: item.ID == "", Value: item.guid()},
+			Description: item.Summary,
+			Author:      item.Author,
+			Categories:  item.Categories,
+			PubDate:     formatRFC1123(item.Published),
+		})
+	}
+
+	return marshal(feed)
+}
+
+// BuildAtom 生成 Atom 1.0 订阅源
+func BuildAtom(ch *Channel, items []Item) ([]byte, error) {
+	id := ch.FeedURL
+	if id == "" {
+		id = ch.Link
+	}
+
+	feed := atomFeed{
+		XMLNS:    atomNamespace,
+		Lang:     ch.Language,
+		ID:       id,
+		Title:    ch.Title,
+		Subtitle: ch.Description,
+		Links:    []atomLink{{Href: ch.Link, Rel: "alternate", Type: "text/html"}},
+		Updated:  formatRFC3339(feedUpdated(ch, items)),
+		Entries:  make([]atomEntry, 0, len(items)),
+	}
+	if ch.FeedURL != "" {
+		feed.Links = append(feed.Links, atomLink{Href: ch.FeedURL, Rel: "self", Type: "application/atom+xml"})
+	}
+
+	for i := range items {
+		item := &items[i]
+		entry := atomEntry{
+			ID:        item.guid(),
+			Title:     item.Title,
+			Links:     []atomLink{{Href: item.Link, Rel: "alternate", Type: "text/html"}},
+			Published: formatRFC3339(item.Published),
+			Updated:   formatRFC3339(entryUpdated(item)),
+		}
+		if item.Summary != "" {
+			entry.Summary = &atomText{Type: "html", Value: item.Summary}
+		}
+		if item.Author != "" {
+			entry.Author = &atomPerson{Name: item.Author}
+		}
+		for _, c := range item.Categories {
+			entry.Categories = append(entry.Categories, atomCategory{Term: c})
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	return marshal(feed)
+}
+
+// feedUpdated 订阅源的更新时间：未指定时取条目中最新的更新时间
+func feedUpdated(ch *Channel, items []Item) time.Time {
+	if !ch.Updated.IsZero() {
+		return ch.Updated
+	}
+	var latest time.Time
+	for i := range items {
+		if t := entryUpdated(&items[i]); t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
+
+func entryUpdated(item *Item) time.Time {
+	if item.Updated.IsZero() {
+		return item.Published
+	}
+	return item.Updated
+}
+
+func formatRFC1123(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC1123Z)
+}
+
+func formatRFC3339(t time.Time) string {
+	if t.IsZero() {
+		// Atom 要求 updated 必填
+		t = time.Unix(0, 0)
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,49 @@
+package syndication
+
+import (
+	"strings"
+)
+
+// RobotsPath robots.txt 的访问路径
+const RobotsPath = "/robots.txt"
+
+// DefaultRobots 未配置 robots.txt 时的默认规则：允许抓取全部内容
+const DefaultRobots = "User-agent: *\nAllow: /\n"
+
+// NoIndexRobots 站点设置为禁止索引时的规则
+const NoIndexRobots = "User-agent: *\nDisallow: /\n"
+
+// BuildRobots 生成 robots.txt：noindex 时禁止全部抓取；否则取 custom，为空时取 DefaultRobots。
+// 未声明 Sitemap 时追加站点地图索引地址（禁止索引时不追加）
+func BuildRobots(custom, sitemapURL string, noindex bool) string {
+	if noindex {
+		return NoIndexRobots
+	}
+
+	body := strings.TrimSpace(strings.ReplaceAll(custom, "\r\n", "\n"))
+	if body == "" {
+		body = strings.TrimSpace(DefaultRobots)
+	}
+
+	if sitemapURL != "" && !hasSitemapDirective(body) {
+		body += "\n\nSitemap: " + sitemapURL
+	}
+	return body + "\n"
+}
+
+func hasSitemapDirective(body string) bool {
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(line)), "sitemap:") {
+			return true
+		}
+	}
+	return false
+}
+
+// JoinURL 把站内路径拼接到站点根地址；path 已是绝对地址时原样返回
+func JoinURL(base, path string) string {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path
+	}
+	return strings.TrimRight(base, "/") + "/" + strings.TrimLeft(path, "/")
+}
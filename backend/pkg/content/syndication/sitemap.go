@@ -0,0 +1,169 @@
+package syndication
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// URLsPerSitemap 单个分类型站点地图的 URL 数上限（协议上限为 50000，取较小值以控制单次渲染开销）
+	URLsPerSitemap = 5000
+
+	// XDefault hreflang 的默认语言标记
+	XDefault = "x-default"
+
+	sitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+	xhtmlNamespace   = "http://www.w3.org/1999/xhtml"
+)
+
+// 分类型站点地图的内容类型
+const (
+	SitemapPosts      = "posts"
+	SitemapPages      = "pages"
+	SitemapCategories = "categories"
+	SitemapTags       = "tags"
+)
+
+// SitemapTypes 站点地图索引中按顺序列出的内容类型
+var SitemapTypes = []string{SitemapPosts, SitemapPages, SitemapCategories, SitemapTags}
+
+// Alternate 同一内容的其他语言版本
+type Alternate struct {
+	Lang string
+	Href string
+}
+
+// URL 站点地图中的一条地址
+type URL struct {
+	Loc        string
+	LastMod    time.Time
+	Alternates []Alternate // 含自身在内的全部语言版本，为空时不输出 hreflang
+}
+
+// SitemapRef 站点地图索引中的一项
+type SitemapRef struct {
+	Loc     string
+	LastMod time.Time
+}
+
+type xmlLink struct {
+	Rel      string `xml:"rel,attr"`
+	Hreflang string `xml:"hreflang,attr"`
+	Href     string `xml:"href,attr"`
+}
+
+type xmlURL struct {
+	Loc     string    `xml:"loc"`
+	LastMod string    `xml:"lastmod,omitempty"`
+	Links   []xmlLink `xml:"xhtml:link"`
+}
+
+type xmlURLSet struct {
+	XMLName xml.Name `xml:"urlset"`
+	XMLNS   string   `xml:"xmlns,attr"`
+	XHTML   string   `xml:"xmlns:xhtml,attr"`
+	URLs    []xmlURL `xml:"url"`
+}
+
+type xmlSitemap struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+type xmlSitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	XMLNS    string       `xml:"xmlns,attr"`
+	Sitemaps []xmlSitemap `xml:"sitemap"`
+}
+
+// SitemapIndexPath 站点地图索引的访问路径
+const SitemapIndexPath = "/sitemap.xml"
+
+// SitemapPath 分类型站点地图的访问路径，如 /sitemap-posts-1.xml
+func SitemapPath(name string) string {
+	return "/sitemap-" + name + ".xml"
+}
+
+// SitemapName 分类型站点地图的名称，如 posts-1（页码从 1 开始）
+func SitemapName(kind string, page int) string {
+	return kind + "-" + strconv.Itoa(page)
+}
+
+// ParseSitemapName 解析 SitemapName 生成的名称
+func ParseSitemapName(name string) (kind string, page int, ok bool) {
+	i := strings.LastIndexByte(name, '-')
+	if i <= 0 {
+		return "", 0, false
+	}
+	kind = name[:i]
+	page, err := strconv.Atoi(name[i+1:])
+	if err != nil || page < 1 {
+		return "", 0, false
+	}
+	for _, t := range SitemapTypes {
+		if t == kind {
+			return kind, page, true
+		}
+	}
+	return "", 0, false
+}
+
+// Alternates 按语言生成 hreflang 列表（按语言排序），defaultLang 存在时追加 x-default
+func Alternates(hrefByLang map[string]string, defaultLang string) []Alternate {
+	if len(hrefByLang) < 2 {
+		// 单一语言无需 hreflang
+		return nil
+	}
+
+	alternates := make([]Alternate, 0, len(hrefByLang)+1)
+	for lang, href := range hrefByLang {
+		alternates = append(alternates, Alternate{Lang: lang, Href: href})
+	}
+	sort.Slice(alternates, func(i, j int) bool { return alternates[i].Lang < alternates[j].Lang })
+
+	if href, ok := hrefByLang[defaultLang]; ok {
+		alternates = append(alternates, Alternate{Lang: XDefault, Href: href})
+	}
+	return alternates
+}
+
+// BuildURLSet 生成分类型站点地图（urlset），带 xhtml:link hreflang 互链
+func BuildURLSet(urls []URL) ([]byte, error) {
+	set := xmlURLSet{XMLNS: sitemapNamespace, XHTML: xhtmlNamespace, URLs: make([]xmlURL, 0, len(urls))}
+	for _, u := range urls {
+		item := xmlURL{Loc: u.Loc, LastMod: formatLastMod(u.LastMod)}
+		for _, a := range u.Alternates {
+			item.Links = append(item.Links, xmlLink{Rel: "alternate", Hreflang: a.Lang, Href: a.Href})
+		}
+		set.URLs = append(set.URLs, item)
+	}
+	return marshal(set)
+}
+
+// BuildSitemapIndex 生成站点地图索引（sitemapindex）
+func BuildSitemapIndex(refs []SitemapRef) ([]byte, error) {
+	index := xmlSitemapIndex{XMLNS: sitemapNamespace, Sitemaps: make([]xmlSitemap, 0, len(refs))}
+	for _, r := range refs {
+		index.Sitemaps = append(index.Sitemaps, xmlSitemap{Loc: r.Loc, LastMod: formatLastMod(r.LastMod)})
+	}
+	return marshal(index)
+}
+
+func formatLastMod(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func marshal(v any) ([]byte, error) {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal xml: %w", err)
+	}
+	return append([]byte(xml.Header), body...), nil
+}
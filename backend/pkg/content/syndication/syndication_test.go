@@ -0,0 +1,120 @@
+package syndication
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSitemapName(t *testing.T) {
+	assert.Equal(t, "posts-2", SitemapName(SitemapPosts, 2))
+	assert.Equal(t, "/sitemap-posts-2.xml", SitemapPath(SitemapName(SitemapPosts, 2)))
+
+	tests := []struct {
+		name string
+		kind string
+		page int
+		ok   bool
+	}{
+		{name: "posts-1", kind: SitemapPosts, page: 1, ok: true},
+		{name: "categories-12", kind: SitemapCategories, page: 12, ok: true},
+		{name: "posts-0"},
+		{name: "posts"},
+		{name: "users-1"},
+		{name: "-1"},
+	}
+	for _, tt := range tests {
+		kind, page, ok := ParseSitemapName(tt.name)
+		assert.Equal(t, tt.ok, ok, tt.name)
+		assert.Equal(t, tt.kind, kind, tt.name)
+		assert.Equal(t, tt.page, page, tt.name)
+	}
+}
+
+func TestBuildURLSet(t *testing.T) {
+	assert.Nil(t, Alternates(map[string]string{"en": "https://a.com/en/post/x"}, "en"))
+
+	alternates := Alternates(map[string]string{
+		"zh-CN": "https://a.com/zh-CN/post/x",
+		"en":    "https://a.com/en/post/x",
+	}, "en")
+	assert.Equal(t, []Alternate{
+		{Lang: "en", Href: "https://a.com/en/post/x"},
+		{Lang: "zh-CN", Href: "https://a.com/zh-CN/post/x"},
+		{Lang: XDefault, Href: "https://a.com/en/post/x"},
+	}, alternates)
+
+	body, err := BuildURLSet([]URL{
+		{Loc: "https://a.com/en/post/x?a=1&b=2", LastMod: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), Alternates: alternates},
+		{Loc: "https://a.com/en/about"},
+	})
+	assert.NoError(t, err)
+
+	xml := string(body)
+	assert.True(t, strings.HasPrefix(xml, `<?xml version="1.0" encoding="UTF-8"?>`))
+	assert.Contains(t, xml, `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9" xmlns:xhtml="http://www.w3.org/1999/xhtml">`)
+	assert.Contains(t, xml, `<loc>https://a.com/en/post/x?a=1&amp;b=2</loc>`)
+	assert.Contains(t, xml, `<lastmod>2026-01-02T03:04:05Z</lastmod>`)
+	assert.Contains(t, xml, `<xhtml:link rel="alternate" hreflang="x-default" href="https://a.com/en/post/x"></xhtml:link>`)
+	assert.Equal(t, 3, strings.Count(xml, "<xhtml:link"))
+
+	body, err = BuildSitemapIndex([]SitemapRef{{Loc: "https://a.com/sitemap-posts-1.xml"}})
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `<sitemap>`)
+	assert.Contains(t, string(body), `<loc>https://a.com/sitemap-posts-1.xml</loc>`)
+	assert.NotContains(t, string(body), `<lastmod>`)
+}
+
+func TestBuildFeeds(t *testing.T) {
+	published := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	ch := &Channel{Title: "Blog", Link: "https://a.com/", FeedURL: "https://a.com/feed.xml", Language: "en"}
+	items := []Item{{
+		Title:      "Hello & welcome",
+		Link:       "https://a.com/en/post/hello",
+		Summary:    "<p>Hi</p>",
+		Author:     "alice",
+		Categories: []string{"Go"},
+		Published:  published,
+		Updated:    published.Add(time.Hour),
+	}}
+
+	assert.Equal(t, "/feed.xml", FeedPath(FeedScopeSite, "", false))
+	assert.Equal(t, "/tag/c%2Fc++/atom.xml", FeedPath(FeedScopeTag, "c/c++", true))
+
+	body, err := BuildRSS(ch, items)
+	assert.NoError(t, err)
+	rss := string(body)
+	assert.Contains(t, rss, `<rss version="2.0"`)
+	assert.Contains(t, rss, `<description>Blog</description>`)
+	assert.Contains(t, rss, `<atom:link href="https://a.com/feed.xml" rel="self" type="application/rss+xml"></atom:link>`)
+	assert.Contains(t, rss, `<title>Hello &amp; welcome</title>`)
+	assert.Contains(t, rss, `<guid isPermaLink="true">https://a.com/en/post/hello</guid>`)
+	assert.Contains(t, rss, `<description>&lt;p&gt;Hi&lt;/p&gt;</description>`)
+	assert.Contains(t, rss, `<pubDate>Sun, 01 Mar 2026 08:00:00 +0000</pubDate>`)
+	assert.Contains(t, rss, `<lastBuildDate>Sun, 01 Mar 2026 09:00:00 +0000</lastBuildDate>`)
+
+	body, err = BuildAtom(ch, items)
+	assert.NoError(t, err)
+	atom := string(body)
+	assert.Contains(t, atom, `<feed xmlns="http://www.w3.org/2005/Atom" xml:lang="en">`)
+	assert.Contains(t, atom, `<id>https://a.com/feed.xml</id>`)
+	assert.Contains(t, atom, `<updated>2026-03-01T09:00:00Z</updated>`)
+	assert.Contains(t, atom, `<published>2026-03-01T08:00:00Z</published>`)
+	assert.Contains(t, atom, `<category term="Go"></category>`)
+	assert.Contains(t, atom, `<name>alice</name>`)
+}
+
+func TestBuildRobots(t *testing.T) {
+	assert.Equal(t, "User-agent: *\nAllow: /\n\nSitemap: https://a.com/sitemap.xml\n",
+		BuildRobots("", "https://a.com/sitemap.xml", false))
+	assert.Equal(t, "User-agent: *\nDisallow: /admin\n\nSitemap: https://a.com/sitemap.xml\n",
+		BuildRobots("User-agent: *\r\nDisallow: /admin\r\n", "https://a.com/sitemap.xml", false))
+	assert.Equal(t, "User-agent: *\nsitemap: https://cdn.a.com/s.xml\n",
+		BuildRobots("User-agent: *\nsitemap: https://cdn.a.com/s.xml", "https://a.com/sitemap.xml", false))
+	assert.Equal(t, NoIndexRobots, BuildRobots("User-agent: *\nAllow: /", "https://a.com/sitemap.xml", true))
+
+	assert.Equal(t, "https://a.com/en/post/x", JoinURL("https://a.com/", "/en/post/x"))
+	assert.Equal(t, "https://cdn.a.com/x", JoinURL("https://a.com", "https://cdn.a.com/x"))
+}
@@ -0,0 +1,208 @@
+package tmx
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ============================================================================
+// TMX（Translation Memory eXchange）1.4 读写
+//
+// 只处理交换所需的最小子集：header 的 srclang、每个 tu 的 prop 与各语言 tuv/seg。
+// seg 中的内联标记（bpt/ept/ph/it/hi 等）按 TMX 约定还原为原生文本：
+// 所有字符数据按出现顺序拼接，标记本身丢弃。导出时文本整体转义，不生成内联标记。
+// ============================================================================
+
+// ErrNotTMX 输入不是 TMX 文档（缺少 tmx 根元素）
+var ErrNotTMX = errors.New("tmx: not a tmx document")
+
+// xmlNamespace xml:lang 属性的命名空间
+const xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+
+// Variant 翻译单元中某一语言的文本
+type Variant struct {
+	Lang string
+	Text string
+}
+
+// Unit 翻译单元（tu），同一句子的多个语言版本
+type Unit struct {
+	Props    map[string]string
+	Variants []Variant
+}
+
+// Text 取指定语言的文本，语言代码不区分大小写
+func (u *Unit) Text(lang string) (string, bool) {
+	for _, v := range u.Variants {
+		if strings.EqualFold(v.Lang, lang) {
+			return v.Text, true
+		}
+	}
+	return "", false
+}
+
+// Document TMX 文档
+type Document struct {
+	// SourceLanguage header 中的 srclang，可能为 "*all*"
+	SourceLanguage string
+	Units          []Unit
+}
+
+// Parse 解析 TMX 文档。兼容 TMX 1.1 的 lang 属性与 1.4 的 xml:lang 属性。
+func Parse(r io.Reader) (*Document, error) {
+	dec := xml.NewDecoder(r)
+
+	doc := &Document{}
+	var (
+		sawRoot  bool
+		unit     *Unit
+		lang     string
+		segDepth int
+		seg      strings.Builder
+		propType string
+		inProp   bool
+		prop     strings.Builder
+	)
+
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("tmx: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if segDepth > 0 {
+				// seg 内的内联标记
+				segDepth++
+				continue
+			}
+			switch t.Name.Local {
+			case "tmx":
+				sawRoot = true
+			case "header":
+				doc.SourceLanguage = attr(t, "", "srclang")
+			case "tu":
+				unit = &Unit{}
+			case "tuv":
+				lang = attr(t, xmlNamespace, "lang")
+				if lang == "" {
+					lang = attr(t, "", "lang")
+				}
+			case "seg":
+				if unit != nil {
+					segDepth = 1
+					seg.Reset()
+				}
+			case "prop":
+				if unit != nil {
+					propType = attr(t, "", "type")
+					inProp = true
+					prop.Reset()
+				}
+			}
+
+		case xml.EndElement:
+			if segDepth > 0 {
+				segDepth--
+				if segDepth == 0 && lang != "" {
+					unit.Variants = append(unit.Variants, Variant{Lang: lang, Text: seg.String()})
+				}
+				continue
+			}
+			switch t.Name.Local {
+			case "tu":
+				if unit != nil {
+					doc.Units = append(doc.Units, *unit)
+					unit = nil
+				}
+			case "tuv":
+				lang = ""
+			case "prop":
+				if inProp && propType != "" {
+					if unit.Props == nil {
+						unit.Props = make(map[string]string)
+					}
+					unit.Props[propType] = prop.String()
+				}
+				inProp = false
+			}
+
+		case xml.CharData:
+			if segDepth > 0 {
+				seg.Write(t)
+			} else if inProp {
+				prop.Write(t)
+			}
+		}
+	}
+
+	if !sawRoot {
+		return nil, ErrNotTMX
+	}
+
+	return doc, nil
+}
+
+// Write 以 TMX 1.4 格式输出文档，prop 按类型排序以保证输出稳定
+func Write(w io.Writer, doc *Document) error {
+	bw := bufio.NewWriter(w)
+
+	srcLang := doc.SourceLanguage
+	if srcLang == "" {
+		srcLang = "*all*"
+	}
+
+	_, _ = bw.WriteString(xml.Header)
+	_, _ = bw.WriteString("<tmx version=\"1.4\">\n")
+	_, _ = bw.WriteString("  <header creationtool=\"go-wind-cms\" creationtoolversion=\"1.0\" segtype=\"sentence\"" +
+		" o-tmf=\"go-wind-cms\" adminlang=\"en\" srclang=\"" + escape(srcLang) + "\" datatype=\"plaintext\"/>\n")
+	_, _ = bw.WriteString("  <body>\n")
+
+	for _, u := range doc.Units {
+		_, _ = bw.WriteString("    <tu>\n")
+
+		keys := make([]string, 0, len(u.Props))
+		for k := range u.Props {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			_, _ = bw.WriteString("      <prop type=\"" + escape(k) + "\">" + escape(u.Props[k]) + "</prop>\n")
+		}
+
+		for _, v := range u.Variants {
+			_, _ = bw.WriteString("      <tuv xml:lang=\"" + escape(v.Lang) + "\"><seg>" + escape(v.Text) + "</seg></tuv>\n")
+		}
+
+		_, _ = bw.WriteString("    </tu>\n")
+	}
+
+	_, _ = bw.WriteString("  </body>\n")
+	_, _ = bw.WriteString("</tmx>\n")
+
+	return bw.Flush()
+}
+
+func attr(el xml.StartElement, space, local string) string {
+	for _, a := range el.Attr {
+		if a.Name.Space == space && a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+func escape(s string) string {
+	var sb strings.Builder
+	_ = xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
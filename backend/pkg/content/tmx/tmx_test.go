@@ -0,0 +1,75 @@
+package tmx
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	const src = `<?xml version="1.0" encoding="UTF-8"?>
+<tmx version="1.4">
+  <header srclang="en" datatype="html"><prop type="x-note">ignored</prop></header>
+  <body>
+    <tu>
+      <prop type="x-glossary-type">forced</prop>
+      <tuv xml:lang="en"><seg>Click <bpt i="1">&lt;b&gt;</bpt>Save<ept i="1">&lt;/b&gt;</ept></seg></tuv>
+      <tuv xml:lang="zh-CN"><seg>点击<bpt i="1">&lt;b&gt;</bpt>保存<ept i="1">&lt;/b&gt;</ept></seg></tuv>
+    </tu>
+    <tu>
+      <tuv lang="EN"><seg>Hello</seg></tuv>
+      <tuv lang="fr"><seg>Bonjour</seg></tuv>
+    </tu>
+  </body>
+</tmx>`
+
+	doc, err := Parse(strings.NewReader(src))
+	assert.NoError(t, err)
+	assert.Equal(t, "en", doc.SourceLanguage)
+	assert.Len(t, doc.Units, 2)
+
+	assert.Equal(t, map[string]string{"x-glossary-type": "forced"}, doc.Units[0].Props)
+	text, ok := doc.Units[0].Text("en")
+	assert.True(t, ok)
+	assert.Equal(t, "Click <b>Save</b>", text)
+	text, ok = doc.Units[0].Text("zh-cn")
+	assert.True(t, ok)
+	assert.Equal(t, "点击<b>保存</b>", text)
+
+	text, ok = doc.Units[1].Text("en")
+	assert.True(t, ok)
+	assert.Equal(t, "Hello", text)
+	_, ok = doc.Units[1].Text("de")
+	assert.False(t, ok)
+
+	_, err = Parse(strings.NewReader(`<xliff version="1.2"></xliff>`))
+	assert.ErrorIs(t, err, ErrNotTMX)
+
+	_, err = Parse(strings.NewReader(`<tmx><body><tu>`))
+	assert.Error(t, err)
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	doc := &Document{
+		SourceLanguage: "en",
+		Units: []Unit{
+			{
+				Props: map[string]string{"x-origin": "manual", "x-glossary-type": "keep"},
+				Variants: []Variant{
+					{Lang: "en", Text: "Use <code>go test</code> & \"quotes\""},
+					{Lang: "de", Text: "Verwende <code>go test</code>\nzweite Zeile"},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, Write(&buf, doc))
+	assert.Contains(t, buf.String(), `<tuv xml:lang="en">`)
+
+	got, err := Parse(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, doc, got)
+}
@@ -0,0 +1,88 @@
+package trash
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultPurgeBatchSize 每次清理的默认最大条目数
+	DefaultPurgeBatchSize = 500
+
+	// MaxSlugAttempts 恢复时为冲突 slug 追加序号的最大尝试次数
+	MaxSlugAttempts = 100
+)
+
+// RetentionDays 租户的回收站保留天数：有租户配置时以其为准，否则取默认值；0 表示不自动清理
+func RetentionDays(defaultDays uint32, tenantDays map[uint32]uint32, tenantID uint32) uint32 {
+	if days, ok := tenantDays[tenantID]; ok {
+		return days
+	}
+	return defaultDays
+}
+
+// PurgeAt 条目的到期清理时间，不自动清理时返回 nil
+func PurgeAt(trashedAt time.Time, days uint32) *time.Time {
+	if days == 0 || trashedAt.IsZero() {
+		return nil
+	}
+	t := trashedAt.AddDate(0, 0, int(days))
+	return &t
+}
+
+// Cutoff 保留期的截止时间：早于该时间移入回收站的条目已到期，不自动清理时返回 nil
+func Cutoff(now time.Time, days uint32) *time.Time {
+	if days == 0 {
+		return nil
+	}
+	t := now.AddDate(0, 0, -int(days))
+	return &t
+}
+
+// ErrSlugExhausted 追加序号达到上限后仍与现有内容冲突
+var ErrSlugExhausted = errors.New("trash: no free slug within attempt limit")
+
+// ResolveSlug 返回不与现有内容冲突的 slug：原 slug 可用时原样返回，
+// 否则依次尝试 slug-2、slug-3……直至 MaxSlugAttempts
+func ResolveSlug(slug string, taken func(candidate string) (bool, error)) (string, error) {
+	if slug == "" {
+		return slug, nil
+	}
+
+	candidate := slug
+	for n := 2; ; n++ {
+		exist, err := taken(candidate)
+		if err != nil {
+			return "", err
+		}
+		if !exist {
+			return candidate, nil
+		}
+		if n > MaxSlugAttempts {
+			return "", ErrSlugExhausted
+		}
+		candidate = SuffixSlug(slug, n)
+	}
+}
+
+// SuffixSlug 为 slug 追加序号，n 从 2 开始：about → about-2
+func SuffixSlug(slug string, n int) string {
+	return slug + "-" + strconv.Itoa(n)
+}
+
+// ReplaceSlug 将完整路径末段的 oldSlug 替换为 newSlug；末段不是 oldSlug 时原样返回
+func ReplaceSlug(fullPath, oldSlug, newSlug string) string {
+	if fullPath == "" || oldSlug == "" {
+		return fullPath
+	}
+
+	trimmed := strings.TrimSuffix(fullPath, "/")
+	idx := strings.LastIndex(trimmed, "/")
+	if trimmed[idx+1:] != oldSlug {
+		return fullPath
+	}
+
+	return trimmed[:idx+1] + newSlug + fullPath[len(trimmed):]
+}
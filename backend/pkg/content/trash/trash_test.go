@@ -0,0 +1,70 @@
+package trash
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetentionDays(t *testing.T) {
+	tenantDays := map[uint32]uint32{2: 90, 3: 0}
+
+	assert.Equal(t, uint32(30), RetentionDays(30, tenantDays, 1))
+	assert.Equal(t, uint32(90), RetentionDays(30, tenantDays, 2))
+	assert.Equal(t, uint32(0), RetentionDays(30, tenantDays, 3))
+	assert.Equal(t, uint32(30), RetentionDays(30, nil, 2))
+}
+
+func TestPurgeAt(t *testing.T) {
+	trashedAt := time.Date(2026, 1, 31, 8, 0, 0, 0, time.UTC)
+
+	assert.Nil(t, PurgeAt(trashedAt, 0))
+	assert.Nil(t, PurgeAt(time.Time{}, 30))
+	assert.Equal(t, time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC), *PurgeAt(trashedAt, 30))
+
+	now := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	assert.Nil(t, Cutoff(now, 0))
+	assert.Equal(t, trashedAt, *Cutoff(now, 30))
+}
+
+func TestReplaceSlug(t *testing.T) {
+	tests := []struct {
+		name     string
+		fullPath string
+		oldSlug  string
+		want     string
+	}{
+		{name: "nested", fullPath: "/news/hello", oldSlug: "hello", want: "/news/hello-2"},
+		{name: "root", fullPath: "/hello", oldSlug: "hello", want: "/hello-2"},
+		{name: "trailing slash", fullPath: "/news/hello/", oldSlug: "hello", want: "/news/hello-2/"},
+		{name: "relative", fullPath: "hello", oldSlug: "hello", want: "hello-2"},
+		{name: "last segment differs", fullPath: "/hello/world", oldSlug: "hello", want: "/hello/world"},
+		{name: "empty path", fullPath: "", oldSlug: "hello", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ReplaceSlug(tt.fullPath, tt.oldSlug, SuffixSlug(tt.oldSlug, 2)))
+		})
+	}
+}
+
+func TestResolveSlug(t *testing.T) {
+	used := map[string]bool{"about": true, "about-2": true}
+	taken := func(candidate string) (bool, error) { return used[candidate], nil }
+
+	got, err := ResolveSlug("contact", taken)
+	assert.NoError(t, err)
+	assert.Equal(t, "contact", got)
+
+	got, err = ResolveSlug("about", taken)
+	assert.NoError(t, err)
+	assert.Equal(t, "about-3", got)
+
+	got, err = ResolveSlug("", taken)
+	assert.NoError(t, err)
+	assert.Equal(t, "", got)
+
+	_, err = ResolveSlug("x", func(string) (bool, error) { return true, nil })
+	assert.ErrorIs(t, err, ErrSlugExhausted)
+}
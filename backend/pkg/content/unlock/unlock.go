@@ -0,0 +1,99 @@
+package unlock
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid unlock token")
+	ErrExpiredToken = errors.New("unlock token expired")
+)
+
+// Claims 解锁令牌内容
+type Claims struct {
+	TenantID    uint32 `json:"t"`
+	PostID      uint32 `json:"p"`
+	Fingerprint string `json:"f"`   // 密码哈希指纹，修改密码后旧令牌随之失效
+	ExpiresAt   int64  `json:"exp"` // 过期时间（Unix 秒）
+}
+
+// Signer 受密码保护内容的解锁令牌签发与校验。
+// 令牌为无状态的 HMAC-SHA256 签名，格式为 base64url(payload).base64url(signature)，
+// 绑定租户、文章与当前密码哈希，在有效期内凭令牌即可读取正文。
+type Signer struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewSigner(secret string, ttl time.Duration) (*Signer, error) {
+	if len(secret) < 16 {
+		return nil, errors.New("unlock token secret must be at least 16 bytes")
+	}
+	if ttl <= 0 {
+		ttl = 30 * time.Minute
+	}
+	return &Signer{secret: []byte(secret), ttl: ttl}, nil
+}
+
+// Fingerprint 计算密码哈希的指纹，令牌中不直接携带哈希本身
+func (s *Signer) Fingerprint(passwordHash string) string {
+	sum := s.mac("fp:" + passwordHash)
+	return base64.RawURLEncoding.EncodeToString(sum[:12])
+}
+
+// Sign 签发令牌，返回令牌与过期时间
+func (s *Signer) Sign(tenantID, postID uint32, passwordHash string, now time.Time) (string, time.Time) {
+	expiresAt := now.Add(s.ttl)
+	payload, _ := json.Marshal(Claims{
+		TenantID:    tenantID,
+		PostID:      postID,
+		Fingerprint: s.Fingerprint(passwordHash),
+		ExpiresAt:   expiresAt.Unix(),
+	})
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.mac(encoded)), expiresAt
+}
+
+// Verify 校验令牌签名、有效期及其是否属于指定租户下的指定文章与密码
+func (s *Signer) Verify(token string, tenantID, postID uint32, passwordHash string, now time.Time) error {
+	encoded, sig, ok := strings.Cut(token, ".")
+	if !ok || encoded == "" || sig == "" {
+		return ErrInvalidToken
+	}
+
+	got, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(got, s.mac(encoded)) {
+		return ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return ErrInvalidToken
+	}
+	var claims Claims
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		return ErrInvalidToken
+	}
+
+	if claims.TenantID != tenantID || claims.PostID != postID ||
+		!hmac.Equal([]byte(claims.Fingerprint), []byte(s.Fingerprint(passwordHash))) {
+		return ErrInvalidToken
+	}
+	if now.Unix() >= claims.ExpiresAt {
+		return ErrExpiredToken
+	}
+	return nil
+}
+
+func (s *Signer) mac(data string) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
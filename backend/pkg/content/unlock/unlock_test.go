@@ -0,0 +1,56 @@
+package unlock
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSigner(t *testing.T) {
+	_, err := NewSigner("short", time.Minute)
+	assert.Error(t, err)
+
+	s, err := NewSigner("0123456789abcdef0123456789abcdef", 10*time.Minute)
+	assert.NoError(t, err)
+
+	now := time.Unix(1700000000, 0)
+	token, expiresAt := s.Sign(2, 7, "$2a$10$hash", now)
+	assert.Equal(t, now.Add(10*time.Minute), expiresAt)
+	assert.NotContains(t, token, "hash")
+
+	other, _ := NewSigner("fedcba9876543210fedcba9876543210", 10*time.Minute)
+	forged, _ := other.Sign(2, 7, "$2a$10$hash", now)
+	payload, sig, _ := strings.Cut(token, ".")
+
+	tests := []struct {
+		name     string
+		token    string
+		tenantID uint32
+		postID   uint32
+		hash     string
+		now      time.Time
+		wantErr  error
+	}{
+		{"valid", token, 2, 7, "$2a$10$hash", now.Add(time.Minute), nil},
+		{"expired", token, 2, 7, "$2a$10$hash", expiresAt, ErrExpiredToken},
+		{"other post", token, 2, 8, "$2a$10$hash", now, ErrInvalidToken},
+		{"other tenant", token, 3, 7, "$2a$10$hash", now, ErrInvalidToken},
+		{"password changed", token, 2, 7, "$2a$10$other", now, ErrInvalidToken},
+		{"signed by other secret", forged, 2, 7, "$2a$10$hash", now, ErrInvalidToken},
+		{"tampered payload", payload + "x." + sig, 2, 7, "$2a$10$hash", now, ErrInvalidToken},
+		{"no signature", payload, 2, 7, "$2a$10$hash", now, ErrInvalidToken},
+		{"empty", "", 2, 7, "$2a$10$hash", now, ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.Verify(tt.token, tt.tenantID, tt.postID, tt.hash, tt.now)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
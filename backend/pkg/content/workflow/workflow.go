@@ -0,0 +1,158 @@
+package workflow
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+)
+
+const (
+	// StageDraft 默认工作流：草稿
+	StageDraft = "draft"
+	// StageInReview 默认工作流：审阅中
+	StageInReview = "in_review"
+	// StageApproved 默认工作流：已批准
+	StageApproved = "approved"
+	// StagePublished 默认工作流：已发布
+	StagePublished = "published"
+
+	// PermissionSubmit 提交审阅的权限代码
+	PermissionSubmit = "cms:workflow:submit"
+	// PermissionReview 审阅（批准/退回）的权限代码
+	PermissionReview = "cms:workflow:review"
+	// PermissionPublish 发布的权限代码
+	PermissionPublish = "cms:workflow:publish"
+)
+
+var stageCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,31}$`)
+
+// Stage 工作流阶段
+type Stage struct {
+	Code string `json:"code"`
+	Name string `json:"name,omitempty"`
+
+	// Review 审阅阶段：文章进入时通知已指派的审阅人，并出现在其审阅队列中
+	Review bool `json:"review,omitempty"`
+
+	// Publish 发布阶段：文章进入时状态置为已发布，离开时退回草稿
+	Publish bool `json:"publish,omitempty"`
+}
+
+// Transition 阶段间的流转
+type Transition struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Name string `json:"name,omitempty"`
+
+	// PermissionCode 执行该流转所需的角色权限代码，为空则不限
+	PermissionCode string `json:"permissionCode,omitempty"`
+
+	// RequireComment 执行该流转须填写审阅意见（如退回修改）
+	RequireComment bool `json:"requireComment,omitempty"`
+}
+
+// Definition 工作流定义
+type Definition struct {
+	InitialStage string       `json:"initialStage"`
+	Stages       []Stage      `json:"stages"`
+	Transitions  []Transition `json:"transitions"`
+}
+
+// Default 默认的新闻编辑工作流：草稿 → 审阅中 → 已批准 → 已发布
+func Default() *Definition {
+	return &Definition{
+		InitialStage: StageDraft,
+		Stages: []Stage{
+			{Code: StageDraft, Name: "草稿"},
+			{Code: StageInReview, Name: "审阅中", Review: true},
+			{Code: StageApproved, Name: "已批准"},
+			{Code: StagePublished, Name: "已发布", Publish: true},
+		},
+		Transitions: []Transition{
+			{From: StageDraft, To: StageInReview, Name: "提交审阅", PermissionCode: PermissionSubmit},
+			{From: StageInReview, To: StageApproved, Name: "批准", PermissionCode: PermissionReview},
+			{From: StageInReview, To: StageDraft, Name: "退回修改", PermissionCode: PermissionReview, RequireComment: true},
+			{From: StageApproved, To: StagePublished, Name: "发布", PermissionCode: PermissionPublish},
+			{From: StageApproved, To: StageDraft, Name: "撤回", PermissionCode: PermissionReview},
+			{From: StagePublished, To: StageDraft, Name: "撤下", PermissionCode: PermissionPublish},
+		},
+	}
+}
+
+// Validate 校验工作流定义：阶段编码合法且唯一，初始阶段与流转两端均为已定义阶段，流转不重复
+func (d *Definition) Validate() error {
+	if d == nil || len(d.Stages) == 0 {
+		return errors.New("workflow requires at least one stage")
+	}
+
+	seen := make(map[string]bool, len(d.Stages))
+	for _, s := range d.Stages {
+		if !stageCodePattern.MatchString(s.Code) {
+			return errors.New("invalid stage code " + s.Code)
+		}
+		if seen[s.Code] {
+			return errors.New("duplicate stage " + s.Code)
+		}
+		seen[s.Code] = true
+	}
+
+	if !seen[d.InitialStage] {
+		return errors.New("unknown initial stage " + d.InitialStage)
+	}
+	if initial, _ := d.Stage(d.InitialStage); initial.Publish {
+		return errors.New("initial stage cannot be a publish stage")
+	}
+
+	pairs := make(map[[2]string]bool, len(d.Transitions))
+	for _, t := range d.Transitions {
+		if !seen[t.From] || !seen[t.To] {
+			return errors.New("transition " + t.From + " -> " + t.To + " references an unknown stage")
+		}
+		if t.From == t.To {
+			return errors.New("transition " + t.From + " -> " + t.To + " does not change the stage")
+		}
+		key := [2]string{t.From, t.To}
+		if pairs[key] {
+			return errors.New("duplicate transition " + t.From + " -> " + t.To)
+		}
+		pairs[key] = true
+	}
+
+	return nil
+}
+
+// Stage 按编码查找阶段
+func (d *Definition) Stage(code string) (*Stage, bool) {
+	for i := range d.Stages {
+		if d.Stages[i].Code == code {
+			return &d.Stages[i], true
+		}
+	}
+	return nil, false
+}
+
+// Find 查找 from → to 的流转
+func (d *Definition) Find(from, to string) (*Transition, bool) {
+	for i := range d.Transitions {
+		if d.Transitions[i].From == from && d.Transitions[i].To == to {
+			return &d.Transitions[i], true
+		}
+	}
+	return nil, false
+}
+
+// Available 返回从 from 出发、持有 codes 权限即可执行的流转
+func (d *Definition) Available(from string, codes []string) []Transition {
+	var out []Transition
+	for _, t := range d.Transitions {
+		if t.From == from && Allowed(&t, codes) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// Allowed 持有 codes 权限是否可执行流转 t
+func Allowed(t *Transition, codes []string) bool {
+	return t.PermissionCode == "" || slices.Contains(codes, t.PermissionCode)
+}
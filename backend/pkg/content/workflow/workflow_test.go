@@ -0,0 +1,78 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefinitionValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		def     *Definition
+		wantErr bool
+	}{
+		{name: "default", def: Default()},
+		{name: "empty", def: &Definition{}, wantErr: true},
+		{
+			name:    "unknown initial",
+			def:     &Definition{InitialStage: "x", Stages: []Stage{{Code: "draft"}}},
+			wantErr: true,
+		},
+		{
+			name:    "publish initial",
+			def:     &Definition{InitialStage: "draft", Stages: []Stage{{Code: "draft", Publish: true}}},
+			wantErr: true,
+		},
+		{
+			name: "unknown transition stage",
+			def: &Definition{
+				InitialStage: "draft",
+				Stages:       []Stage{{Code: "draft"}},
+				Transitions:  []Transition{{From: "draft", To: "legal"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate transition",
+			def: &Definition{
+				InitialStage: "draft",
+				Stages:       []Stage{{Code: "draft"}, {Code: "legal"}},
+				Transitions:  []Transition{{From: "draft", To: "legal"}, {From: "draft", To: "legal"}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "invalid stage code",
+			def:     &Definition{InitialStage: "Draft", Stages: []Stage{{Code: "Draft"}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.def.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDefinitionAvailable(t *testing.T) {
+	def := Default()
+
+	assert.Empty(t, def.Available(StageInReview, []string{PermissionSubmit}))
+
+	available := def.Available(StageInReview, []string{PermissionReview})
+	assert.Len(t, available, 2)
+
+	tr, ok := def.Find(StageInReview, StageDraft)
+	assert.True(t, ok)
+	assert.True(t, tr.RequireComment)
+	assert.False(t, Allowed(tr, []string{PermissionPublish}))
+
+	_, ok = def.Find(StageDraft, StagePublished)
+	assert.False(t, ok)
+}
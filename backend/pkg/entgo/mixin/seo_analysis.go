@@ -0,0 +1,21 @@
+package mixin
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/mixin"
+
+	contentV1 "go-wind-cms/api/gen/go/content/service/v1"
+)
+
+var _ ent.Mixin = (*SeoAnalysis)(nil)
+
+type SeoAnalysis struct{ mixin.Schema }
+
+func (SeoAnalysis) Fields() []ent.Field {
+	return []ent.Field{
+		field.JSON("seo_analysis", &contentV1.SeoAnalysis{}).
+			Comment("SEO 分析结果（保存时自动计算）").
+			Optional(),
+	}
+}
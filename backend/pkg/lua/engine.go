@@ -33,7 +33,7 @@ type Engine struct {
 	registry        *hook.Registry
 	rdb             *redis.Client              // Redis client for cache operations
 	eventbusManager *eventbus.Manager          // EventBus manager
-	ossClient       *oss.MinIOClient           // OSS/MinIO client
+	ossClient       oss.Storage                // OSS storage driver
 	callbacks       map[string][]*CallbackInfo // Hook callbacks (hook name -> multiple callbacks)
 	dedicatedVMs    map[*lua.LState]bool       // VMs that should not be pooled
 	mu              sync.RWMutex
@@ -597,7 +597,7 @@ func (e *Engine) SetEventBus(manager *eventbus.Manager) {
 }
 
 // SetOSS sets the OSS client for object storage operations
-func (e *Engine) SetOSS(client *oss.MinIOClient) {
+func (e *Engine) SetOSS(client oss.Storage) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 	e.ossClient = client
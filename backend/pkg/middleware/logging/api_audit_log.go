@@ -18,6 +18,7 @@ import (
 	auditV1 "go-wind-cms/api/gen/go/audit/service/v1"
 
 	appViewer "go-wind-cms/pkg/entgo/viewer"
+	"go-wind-cms/pkg/netutil"
 )
 
 type ApiAuditLogMiddleware struct {
@@ -41,7 +42,7 @@ func (a *ApiAuditLogMiddleware) Handle(ctx context.Context, htr *http.Transport,
 
 	apiAuditLog := &auditV1.ApiAuditLog{}
 
-	clientIp := getClientRealIP(htr.Request())
+	clientIp := netutil.ClientIP(htr.Request())
 	referer, _ := url.QueryUnescape(htr.RequestHeader().Get(HeaderKeyReferer))
 	requestUri, _ := url.QueryUnescape(htr.Request().RequestURI)
 	bodyBytes, _ := io.ReadAll(htr.Request().Body)
@@ -20,6 +20,7 @@ import (
 	auditV1 "go-wind-cms/api/gen/go/audit/service/v1"
 
 	appViewer "go-wind-cms/pkg/entgo/viewer"
+	"go-wind-cms/pkg/netutil"
 )
 
 type LoginAuditLogMiddleware struct {
@@ -60,7 +61,7 @@ func (l *LoginAuditLogMiddleware) Handle(ctx context.Context, htr *http.Transpor
 		loginAuditLog.ActionType = trans.Ptr(auditV1.LoginAuditLog_LOGOUT)
 	}
 
-	clientIp := getClientRealIP(htr.Request())
+	clientIp := netutil.ClientIP(htr.Request())
 
 	loginAuditLog.IpAddress = trans.Ptr(clientIp)
 	loginAuditLog.CreatedAt = timeutil.TimeToTimestamppb(trans.Ptr(time.Now()))
@@ -0,0 +1,137 @@
+package moderation
+
+import (
+	"context"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Classifier 垃圾评论分类器
+type Classifier interface {
+	// Name 分类器名称
+	Name() string
+
+	// SpamProbability 返回文本为垃圾内容的概率，样本不足无法判断时 ok 为 false
+	SpamProbability(ctx context.Context, tenantID uint32, text string) (p float64, ok bool, err error)
+
+	// Train 以审核结果训练模型
+	Train(ctx context.Context, tenantID uint32, text string, spam bool) error
+}
+
+// TokenStats 分类所需的统计量
+type TokenStats struct {
+	SpamDocs, HamDocs     int64            // 两个类别的样本数
+	SpamTokens, HamTokens int64            // 两个类别的总词数
+	Vocabulary            int64            // 词表大小
+	Spam, Ham             map[string]int64 // 待分类文本中各词在两个类别中的词频
+}
+
+// TokenStore 朴素贝叶斯模型的词频存储，按租户隔离
+type TokenStore interface {
+	// Add 将一篇样本的词计入对应类别
+	Add(ctx context.Context, tenantID uint32, spam bool, tokens []string) error
+
+	// Stats 返回指定词的词频及类别汇总
+	Stats(ctx context.Context, tenantID uint32, tokens []string) (*TokenStats, error)
+}
+
+// NaiveBayes 基于多项式朴素贝叶斯的本地分类器
+type NaiveBayes struct {
+	store      TokenStore
+	minSamples int64
+}
+
+// NewNaiveBayes minSamples 为每个类别至少需要的样本数，不足时不做判断
+func NewNaiveBayes(store TokenStore, minSamples int64) *NaiveBayes {
+	if minSamples <= 0 {
+		minSamples = 10
+	}
+	return &NaiveBayes{store: store, minSamples: minSamples}
+}
+
+func (c *NaiveBayes) Name() string { return "naive_bayes" }
+
+func (c *NaiveBayes) Train(ctx context.Context, tenantID uint32, text string, spam bool) error {
+	tokens := Tokenize(text)
+	if len(tokens) == 0 {
+		return nil
+	}
+	return c.store.Add(ctx, tenantID, spam, tokens)
+}
+
+func (c *NaiveBayes) SpamProbability(ctx context.Context, tenantID uint32, text string) (float64, bool, error) {
+	tokens := Tokenize(text)
+	if len(tokens) == 0 {
+		return 0, false, nil
+	}
+
+	stats, err := c.store.Stats(ctx, tenantID, tokens)
+	if err != nil {
+		return 0, false, err
+	}
+	if stats.SpamDocs < c.minSamples || stats.HamDocs < c.minSamples {
+		return 0, false, nil
+	}
+
+	total := float64(stats.SpamDocs + stats.HamDocs)
+	vocab := float64(stats.Vocabulary)
+	if vocab < 1 {
+		vocab = 1
+	}
+
+	// 对数空间计算，拉普拉斯平滑
+	logSpam := math.Log(float64(stats.SpamDocs) / total)
+	logHam := math.Log(float64(stats.HamDocs) / total)
+	for _, t := range tokens {
+		logSpam += math.Log((float64(stats.Spam[t]) + 1) / (float64(stats.SpamTokens) + vocab))
+		logHam += math.Log((float64(stats.Ham[t]) + 1) / (float64(stats.HamTokens) + vocab))
+	}
+
+	return 1 / (1 + math.Exp(logHam-logSpam)), true, nil
+}
+
+const maxTokenLength = 32
+
+// Tokenize 分词：拉丁字母与数字按词切分，中日韩文字按相邻二字切分
+func Tokenize(text string) []string {
+	var tokens []string
+	var word []rune
+	var han []rune
+
+	flushWord := func() {
+		if len(word) >= 2 && len(word) <= maxTokenLength {
+			tokens = append(tokens, string(word))
+		}
+		word = word[:0]
+	}
+	flushHan := func() {
+		switch {
+		case len(han) == 1:
+			tokens = append(tokens, string(han))
+		case len(han) > 1:
+			for i := 0; i+1 < len(han); i++ {
+				tokens = append(tokens, string(han[i:i+2]))
+			}
+		}
+		han = han[:0]
+	}
+
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+			flushWord()
+			han = append(han, r)
+		case unicode.IsLetter(r) || unicode.IsNumber(r):
+			flushHan()
+			word = append(word, r)
+		default:
+			flushWord()
+			flushHan()
+		}
+	}
+	flushWord()
+	flushHan()
+
+	return tokens
+}
@@ -0,0 +1,102 @@
+package moderation
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryTokenStore 内存词频存储，仅适用于单实例或测试
+type MemoryTokenStore struct {
+	mu     sync.RWMutex
+	models map[uint32]*memoryModel
+}
+
+type memoryModel struct {
+	spamDocs, hamDocs     int64
+	spamTokens, hamTokens int64
+	spam, ham             map[string]int64
+}
+
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{models: make(map[uint32]*memoryModel)}
+}
+
+func (s *MemoryTokenStore) Add(_ context.Context, tenantID uint32, spam bool, tokens []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, ok := s.models[tenantID]
+	if !ok {
+		m = &memoryModel{spam: make(map[string]int64), ham: make(map[string]int64)}
+		s.models[tenantID] = m
+	}
+
+	counts, docs, total := m.ham, &m.hamDocs, &m.hamTokens
+	if spam {
+		counts, docs, total = m.spam, &m.spamDocs, &m.spamTokens
+	}
+	*docs++
+	*total += int64(len(tokens))
+	for _, t := range tokens {
+		counts[t]++
+	}
+	return nil
+}
+
+func (s *MemoryTokenStore) Stats(_ context.Context, tenantID uint32, tokens []string) (*TokenStats, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stats := &TokenStats{Spam: make(map[string]int64), Ham: make(map[string]int64)}
+	m, ok := s.models[tenantID]
+	if !ok {
+		return stats, nil
+	}
+
+	stats.SpamDocs, stats.HamDocs = m.spamDocs, m.hamDocs
+	stats.SpamTokens, stats.HamTokens = m.spamTokens, m.hamTokens
+
+	vocab := int64(len(m.spam))
+	for t := range m.ham {
+		if _, dup := m.spam[t]; !dup {
+			vocab++
+		}
+	}
+	stats.Vocabulary = vocab
+
+	for _, t := range tokens {
+		stats.Spam[t] = m.spam[t]
+		stats.Ham[t] = m.ham[t]
+	}
+	return stats, nil
+}
+
+// MemoryCounterStore 内存计数存储，仅适用于单实例或测试
+type MemoryCounterStore struct {
+	mu      sync.Mutex
+	entries map[string]*memoryCounter
+}
+
+type memoryCounter struct {
+	count    int64
+	expireAt time.Time
+}
+
+func NewMemoryCounterStore() *MemoryCounterStore {
+	return &MemoryCounterStore{entries: make(map[string]*memoryCounter)}
+}
+
+func (s *MemoryCounterStore) Incr(_ context.Context, key string, window time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	e, ok := s.entries[key]
+	if !ok || now.After(e.expireAt) {
+		e = &memoryCounter{expireAt: now.Add(window)}
+		s.entries[key] = e
+	}
+	e.count++
+	return e.count, nil
+}
@@ -0,0 +1,354 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// Verdict 审核结论
+type Verdict int
+
+const (
+	VerdictApprove Verdict = iota // 直接通过
+	VerdictPending                // 进入人工审核
+	VerdictReject                 // 拒绝（保存为已拒绝）
+	VerdictSpam                   // 垃圾评论（保存为垃圾）
+	VerdictBlock                  // 拒绝保存，例如触发限流
+)
+
+func (v Verdict) String() string {
+	switch v {
+	case VerdictApprove:
+		return "approve"
+	case VerdictPending:
+		return "pending"
+	case VerdictReject:
+		return "reject"
+	case VerdictSpam:
+		return "spam"
+	case VerdictBlock:
+		return "block"
+	default:
+		return fmt.Sprintf("verdict(%d)", int(v))
+	}
+}
+
+// severity 结论的严重程度，多个规则给出结论时取最严重者
+func (v Verdict) severity() int {
+	switch v {
+	case VerdictPending:
+		return 1
+	case VerdictReject:
+		return 2
+	case VerdictSpam:
+		return 3
+	case VerdictBlock:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// Decision 审核决定
+type Decision struct {
+	Verdict Verdict
+	Rule    string  // 给出结论的规则名
+	Reason  string  // 原因说明
+	Score   float64 // 分类器给出的垃圾概率，未启用分类器时为 -1
+}
+
+// Input 待审核的评论
+type Input struct {
+	TenantID    uint32
+	UserID      uint32 // 0 表示游客
+	IP          string
+	UserAgent   string
+	ObjectID    uint32
+	Content     string
+	AuthorName  string
+	AuthorEmail string
+	AuthorURL   string
+}
+
+// Text 参与关键词匹配与分类的全部文本
+func (in *Input) Text() string {
+	return in.AuthorName + "\n" + in.AuthorURL + "\n" + in.Content
+}
+
+// authorKey 作者标识：登录用户取用户ID，游客取IP
+func (in *Input) authorKey() string {
+	if in.UserID != 0 {
+		return fmt.Sprintf("u:%d", in.UserID)
+	}
+	return "ip:" + in.IP
+}
+
+// Rule 审核规则，不给出结论时返回 nil
+type Rule interface {
+	Name() string
+	Check(ctx context.Context, in *Input) (*Decision, error)
+}
+
+// RateLimit 限流配置
+type RateLimit struct {
+	Limit  int           // 窗口内允许的次数，0 表示不限制
+	Window time.Duration // 窗口长度
+}
+
+// Policy 租户审核策略
+type Policy struct {
+	// Enabled 是否启用自动审核，未启用时评论按调用方传入的状态保存
+	Enabled bool
+
+	// RequireApproval 未命中任何规则时仍需人工审核
+	RequireApproval bool
+
+	// Keywords 关键词黑名单，忽略大小写
+	Keywords []string
+
+	// Patterns 正则黑名单
+	Patterns []string
+
+	// BlocklistVerdict 命中黑名单后的结论，默认 VerdictSpam
+	BlocklistVerdict Verdict
+
+	// MaxLinks 链接数上限，超过则进入人工审核，0 表示不限制
+	MaxLinks int
+
+	// DuplicateWindow 同一作者重复内容检测窗口，0 表示不检测
+	DuplicateWindow time.Duration
+
+	// IPRateLimit 按 IP 限流。
+	// IP 由 app 服务经 netutil.ClientIP 取自 X-Forwarded-For，最外层反向代理必须覆写该头，否则客户端可伪造 IP 绕过限流
+	IPRateLimit RateLimit
+
+	// UserRateLimit 按用户限流
+	UserRateLimit RateLimit
+
+	// UseClassifier 是否启用垃圾分类器
+	UseClassifier bool
+
+	// SpamThreshold 垃圾概率不低于该值时判定为垃圾，默认 0.9
+	SpamThreshold float64
+
+	// PendingThreshold 垃圾概率不低于该值时进入人工审核，默认 0.6
+	PendingThreshold float64
+}
+
+// Options Moderator 配置
+type Options struct {
+	// DefaultPolicy 默认策略
+	DefaultPolicy *Policy
+
+	// TenantPolicies 按租户指定策略，整体替换默认策略
+	TenantPolicies map[uint32]*Policy
+
+	// Store 计数存储，用于限流与重复检测，为空时不启用这两类规则
+	Store CounterStore
+
+	// Classifier 垃圾分类器，为空时不启用
+	Classifier Classifier
+}
+
+// pipeline 某个策略对应的规则链
+type pipeline struct {
+	policy *Policy
+	rules  []Rule
+}
+
+// Moderator 评论审核链
+type Moderator struct {
+	log *log.Helper
+
+	defaultPipeline *pipeline
+	tenantPipelines map[uint32]*pipeline
+	classifier      Classifier
+}
+
+func NewModerator(opts *Options, logger log.Logger) (*Moderator, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	m := &Moderator{
+		log:             log.NewHelper(log.With(logger, "module", "moderator/moderation")),
+		tenantPipelines: make(map[uint32]*pipeline, len(opts.TenantPolicies)),
+		classifier:      opts.Classifier,
+	}
+
+	var err error
+	if m.defaultPipeline, err = newPipeline(opts.DefaultPolicy, opts.Store); err != nil {
+		return nil, err
+	}
+	for tenantID, p := range opts.TenantPolicies {
+		if m.tenantPipelines[tenantID], err = newPipeline(p, opts.Store); err != nil {
+			return nil, fmt.Errorf("tenant [%d]: %w", tenantID, err)
+		}
+	}
+
+	return m, nil
+}
+
+// newPipeline 按策略构建规则链，顺序为：限流、黑名单、重复检测、链接数
+func newPipeline(policy *Policy, store CounterStore) (*pipeline, error) {
+	if policy == nil {
+		policy = &Policy{}
+	}
+
+	p := &pipeline{policy: policy}
+	if !policy.Enabled {
+		return p, nil
+	}
+
+	if store != nil {
+		if policy.IPRateLimit.Limit > 0 {
+			p.rules = append(p.rules, &RateLimitRule{store: store, limit: policy.IPRateLimit, byUser: false})
+		}
+		if policy.UserRateLimit.Limit > 0 {
+			p.rules = append(p.rules, &RateLimitRule{store: store, limit: policy.UserRateLimit, byUser: true})
+		}
+	}
+
+	if len(policy.Keywords) > 0 || len(policy.Patterns) > 0 {
+		rule, err := NewBlocklistRule(policy.Keywords, policy.Patterns, policy.BlocklistVerdict)
+		if err != nil {
+			return nil, err
+		}
+		p.rules = append(p.rules, rule)
+	}
+
+	if store != nil && policy.DuplicateWindow > 0 {
+		p.rules = append(p.rules, &DuplicateRule{store: store, window: policy.DuplicateWindow})
+	}
+
+	if policy.MaxLinks > 0 {
+		p.rules = append(p.rules, &LinkCountRule{max: policy.MaxLinks})
+	}
+
+	return p, nil
+}
+
+func (m *Moderator) pipelineFor(tenantID uint32) *pipeline {
+	if p, ok := m.tenantPipelines[tenantID]; ok {
+		return p
+	}
+	return m.defaultPipeline
+}
+
+// Enabled 租户是否启用了自动审核
+func (m *Moderator) Enabled(tenantID uint32) bool {
+	return m.pipelineFor(tenantID).policy.Enabled
+}
+
+// Moderate 依次执行规则链与分类器，给出评论的初始状态。
+// 规则出错时记录日志并跳过该规则，不阻塞评论提交。
+func (m *Moderator) Moderate(ctx context.Context, in *Input) *Decision {
+	p := m.pipelineFor(in.TenantID)
+
+	decision := &Decision{Verdict: VerdictApprove, Score: -1}
+	if p.policy.RequireApproval {
+		decision.Verdict = VerdictPending
+		decision.Rule = "require_approval"
+	}
+	if !p.policy.Enabled {
+		return decision
+	}
+
+	for _, rule := range p.rules {
+		d, err := rule.Check(ctx, in)
+		if err != nil {
+			m.log.Errorf("moderation rule [%s] failed: %v", rule.Name(), err)
+			continue
+		}
+		if d == nil || d.Verdict.severity() <= decision.Verdict.severity() {
+			continue
+		}
+		d.Score = decision.Score
+		decision = d
+		if decision.Verdict.severity() >= VerdictReject.severity() {
+			return decision
+		}
+	}
+
+	if p.policy.UseClassifier && m.classifier != nil {
+		score, ok, err := m.classifier.SpamProbability(ctx, in.TenantID, in.Text())
+		if err != nil {
+			m.log.Errorf("spam classifier [%s] failed: %v", m.classifier.Name(), err)
+			return decision
+		}
+		if !ok {
+			return decision
+		}
+
+		decision.Score = score
+		switch {
+		case score >= thresholdOr(p.policy.SpamThreshold, 0.9):
+			decision.Verdict = VerdictSpam
+			decision.Rule = m.classifier.Name()
+			decision.Reason = fmt.Sprintf("spam probability %.2f", score)
+		case score >= thresholdOr(p.policy.PendingThreshold, 0.6) && decision.Verdict == VerdictApprove:
+			decision.Verdict = VerdictPending
+			decision.Rule = m.classifier.Name()
+			decision.Reason = fmt.Sprintf("spam probability %.2f", score)
+		}
+	}
+
+	return decision
+}
+
+// RateLimit 只执行限流规则，供跳过自动审核的信任作者使用，未超限时返回 nil
+func (m *Moderator) RateLimit(ctx context.Context, in *Input) *Decision {
+	p := m.pipelineFor(in.TenantID)
+	if !p.policy.Enabled {
+		return nil
+	}
+
+	for _, rule := range p.rules {
+		if _, ok := rule.(*RateLimitRule); !ok {
+			continue
+		}
+		d, err := rule.Check(ctx, in)
+		if err != nil {
+			m.log.Errorf("moderation rule [%s] failed: %v", rule.Name(), err)
+			continue
+		}
+		if d != nil {
+			d.Score = -1
+			return d
+		}
+	}
+
+	return nil
+}
+
+// Learn 以审核员的处理结果训练分类器
+func (m *Moderator) Learn(ctx context.Context, tenantID uint32, in *Input, spam bool) error {
+	if m.classifier == nil || !m.pipelineFor(tenantID).policy.UseClassifier {
+		return nil
+	}
+	return m.classifier.Train(ctx, tenantID, in.Text(), spam)
+}
+
+func thresholdOr(v, def float64) float64 {
+	if v <= 0 || v > 1 {
+		return def
+	}
+	return v
+}
+
+// compilePatterns 编译正则黑名单
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	res := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid blocklist pattern [%s]: %w", p, err)
+		}
+		res = append(res, re)
+	}
+	return res, nil
+}
@@ -0,0 +1,180 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModerator_Rules(t *testing.T) {
+	m, err := NewModerator(&Options{
+		DefaultPolicy: &Policy{
+			Enabled:         true,
+			Keywords:        []string{"Casino"},
+			Patterns:        []string{`(?i)v[i1]agra`},
+			MaxLinks:        2,
+			DuplicateWindow: time.Minute,
+		},
+		TenantPolicies: map[uint32]*Policy{
+			2: {Enabled: true, RequireApproval: true, Keywords: []string{"casino"}, BlocklistVerdict: VerdictReject},
+			3: {Enabled: false},
+		},
+		Store: NewMemoryCounterStore(),
+	}, log.DefaultLogger)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name string
+		in   *Input
+		want Verdict
+		rule string
+	}{
+		{"clean", &Input{TenantID: 1, UserID: 1, Content: "nice article"}, VerdictApprove, ""},
+		{"keyword", &Input{TenantID: 1, UserID: 1, Content: "best CASINO online"}, VerdictSpam, "blocklist"},
+		{"keyword in author name", &Input{TenantID: 1, IP: "1.1.1.1", AuthorName: "casino king", Content: "hi"}, VerdictSpam, "blocklist"},
+		{"pattern", &Input{TenantID: 1, UserID: 1, Content: "cheap V1AGRA"}, VerdictSpam, "blocklist"},
+		{"links", &Input{TenantID: 1, UserID: 1, Content: "see http://a.com https://b.com www.c.com"}, VerdictPending, "link_count"},
+		{"tenant requires approval", &Input{TenantID: 2, UserID: 1, Content: "nice article"}, VerdictPending, "require_approval"},
+		{"tenant blocklist verdict", &Input{TenantID: 2, UserID: 1, Content: "casino"}, VerdictReject, "blocklist"},
+		{"tenant disabled", &Input{TenantID: 3, UserID: 1, Content: "casino"}, VerdictApprove, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := m.Moderate(context.Background(), tt.in)
+			assert.Equal(t, tt.want, d.Verdict)
+			assert.Equal(t, tt.rule, d.Rule)
+		})
+	}
+
+	assert.False(t, m.Enabled(3))
+	assert.True(t, m.Enabled(99))
+
+	_, err = NewModerator(&Options{DefaultPolicy: &Policy{Enabled: true, Patterns: []string{"("}}}, log.DefaultLogger)
+	assert.Error(t, err)
+}
+
+func TestModerator_DuplicateAndRateLimit(t *testing.T) {
+	ctx := context.Background()
+	m, err := NewModerator(&Options{
+		DefaultPolicy: &Policy{
+			Enabled:         true,
+			DuplicateWindow: time.Minute,
+			IPRateLimit:     RateLimit{Limit: 3, Window: time.Hour},
+			UserRateLimit:   RateLimit{Limit: 4, Window: time.Hour},
+		},
+		Store: NewMemoryCounterStore(),
+	}, log.DefaultLogger)
+	assert.NoError(t, err)
+
+	in := &Input{TenantID: 1, UserID: 7, IP: "10.0.0.1", Content: "Great post!"}
+	assert.Equal(t, VerdictApprove, m.Moderate(ctx, in).Verdict)
+
+	// 改写大小写与标点不影响重复检测
+	dup := m.Moderate(ctx, &Input{TenantID: 1, UserID: 7, IP: "10.0.0.1", Content: "great post"})
+	assert.Equal(t, VerdictSpam, dup.Verdict)
+	assert.Equal(t, "duplicate", dup.Rule)
+
+	// 其他用户发表相同内容不算重复
+	assert.Equal(t, VerdictApprove, m.Moderate(ctx, &Input{TenantID: 1, UserID: 8, IP: "10.0.0.2", Content: "Great post!"}).Verdict)
+
+	assert.Equal(t, VerdictApprove, m.Moderate(ctx, &Input{TenantID: 1, UserID: 7, IP: "10.0.0.1", Content: "third"}).Verdict)
+	blocked := m.Moderate(ctx, &Input{TenantID: 1, UserID: 7, IP: "10.0.0.1", Content: "fourth"})
+	assert.Equal(t, VerdictBlock, blocked.Verdict)
+	assert.Equal(t, "ip_rate_limit", blocked.Rule)
+
+	// 换 IP 后仍按用户计数；被 IP 限流拦截的那条未计入用户计数
+	assert.Equal(t, VerdictApprove, m.Moderate(ctx, &Input{TenantID: 1, UserID: 7, IP: "10.0.0.3", Content: "fifth"}).Verdict)
+	blocked = m.Moderate(ctx, &Input{TenantID: 1, UserID: 7, IP: "10.0.0.4", Content: "sixth"})
+	assert.Equal(t, VerdictBlock, blocked.Verdict)
+	assert.Equal(t, "user_rate_limit", blocked.Rule)
+}
+
+func TestModerator_RateLimitOnly(t *testing.T) {
+	ctx := context.Background()
+	m, err := NewModerator(&Options{
+		DefaultPolicy: &Policy{
+			Enabled:     true,
+			Keywords:    []string{"casino"},
+			IPRateLimit: RateLimit{Limit: 2, Window: time.Hour},
+		},
+		TenantPolicies: map[uint32]*Policy{
+			2: {Enabled: false, IPRateLimit: RateLimit{Limit: 1, Window: time.Hour}},
+		},
+		Store: NewMemoryCounterStore(),
+	}, log.DefaultLogger)
+	assert.NoError(t, err)
+
+	// 只计限流，不执行黑名单等内容规则
+	in := &Input{TenantID: 1, IP: "10.0.0.1", Content: "casino"}
+	assert.Nil(t, m.RateLimit(ctx, in))
+	assert.Nil(t, m.RateLimit(ctx, in))
+	blocked := m.RateLimit(ctx, in)
+	if assert.NotNil(t, blocked) {
+		assert.Equal(t, VerdictBlock, blocked.Verdict)
+		assert.Equal(t, "ip_rate_limit", blocked.Rule)
+	}
+
+	// 与 Moderate 共用计数
+	assert.Equal(t, VerdictBlock, m.Moderate(ctx, &Input{TenantID: 1, IP: "10.0.0.1", Content: "hi"}).Verdict)
+
+	in = &Input{TenantID: 2, IP: "10.0.0.1"}
+	assert.Nil(t, m.RateLimit(ctx, in))
+	assert.Nil(t, m.RateLimit(ctx, in))
+}
+
+func TestModerator_Classifier(t *testing.T) {
+	ctx := context.Background()
+	m, err := NewModerator(&Options{
+		DefaultPolicy: &Policy{Enabled: true, UseClassifier: true},
+		Classifier:    NewNaiveBayes(NewMemoryTokenStore(), 5),
+	}, log.DefaultLogger)
+	assert.NoError(t, err)
+
+	spam := &Input{TenantID: 1, Content: "buy cheap pills discount pharmacy"}
+
+	// 样本不足时不做判断
+	d := m.Moderate(ctx, spam)
+	assert.Equal(t, VerdictApprove, d.Verdict)
+	assert.Equal(t, float64(-1), d.Score)
+
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, m.Learn(ctx, 1, &Input{Content: fmt.Sprintf("cheap pills discount %d pharmacy offer", i)}, true))
+		assert.NoError(t, m.Learn(ctx, 1, &Input{Content: fmt.Sprintf("thanks for the article %d, very helpful explanation", i)}, false))
+	}
+
+	d = m.Moderate(ctx, spam)
+	assert.Equal(t, VerdictSpam, d.Verdict)
+	assert.Equal(t, "naive_bayes", d.Rule)
+	assert.Greater(t, d.Score, 0.9)
+
+	d = m.Moderate(ctx, &Input{TenantID: 1, Content: "very helpful article, thanks"})
+	assert.Equal(t, VerdictApprove, d.Verdict)
+	assert.Less(t, d.Score, 0.5)
+
+	// 模型按租户隔离
+	d = m.Moderate(ctx, &Input{TenantID: 2, Content: "cheap pills"})
+	assert.Equal(t, float64(-1), d.Score)
+}
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"Hello, World! a", []string{"hello", "world"}},
+		{"垃圾评论", []string{"垃圾", "圾评", "评论"}},
+		{"买 iPhone15 便宜", []string{"买", "iphone15", "便宜"}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			assert.Equal(t, tt.want, Tokenize(tt.text))
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package moderation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// CounterStore 带过期时间的计数存储
+type CounterStore interface {
+	// Incr 计数加一并返回当前计数，首次计数时设置过期时间
+	Incr(ctx context.Context, key string, window time.Duration) (int64, error)
+}
+
+// BlocklistRule 关键词与正则黑名单
+type BlocklistRule struct {
+	keywords []string
+	patterns []*regexp.Regexp
+	verdict  Verdict
+}
+
+func NewBlocklistRule(keywords, patterns []string, verdict Verdict) (*BlocklistRule, error) {
+	compiled, err := compilePatterns(patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &BlocklistRule{patterns: compiled, verdict: verdict}
+	if r.verdict == VerdictApprove {
+		r.verdict = VerdictSpam
+	}
+	for _, k := range keywords {
+		if k = strings.ToLower(strings.TrimSpace(k)); k != "" {
+			r.keywords = append(r.keywords, k)
+		}
+	}
+	return r, nil
+}
+
+func (r *BlocklistRule) Name() string { return "blocklist" }
+
+func (r *BlocklistRule) Check(_ context.Context, in *Input) (*Decision, error) {
+	text := in.Text()
+	lower := strings.ToLower(text)
+
+	for _, k := range r.keywords {
+		if strings.Contains(lower, k) {
+			return &Decision{Verdict: r.verdict, Rule: r.Name(), Reason: "keyword: " + k}, nil
+		}
+	}
+	for _, re := range r.patterns {
+		if re.MatchString(text) {
+			return &Decision{Verdict: r.verdict, Rule: r.Name(), Reason: "pattern: " + re.String()}, nil
+		}
+	}
+	return nil, nil
+}
+
+var linkPattern = regexp.MustCompile(`(?i)(https?://|www\.)[^\s<>"']+`)
+
+// CountLinks 统计文本中的链接数
+func CountLinks(text string) int {
+	return len(linkPattern.FindAllStringIndex(text, -1))
+}
+
+// LinkCountRule 链接数超过上限时进入人工审核
+type LinkCountRule struct {
+	max int
+}
+
+func (r *LinkCountRule) Name() string { return "link_count" }
+
+func (r *LinkCountRule) Check(_ context.Context, in *Input) (*Decision, error) {
+	// 作者网址单独填写，不计入正文链接数
+	if n := CountLinks(in.Content); n > r.max {
+		return &Decision{Verdict: VerdictPending, Rule: r.Name(), Reason: fmt.Sprintf("%d links exceeds limit %d", n, r.max)}, nil
+	}
+	return nil, nil
+}
+
+// DuplicateRule 同一作者在窗口期内重复提交相同内容时判定为垃圾
+type DuplicateRule struct {
+	store  CounterStore
+	window time.Duration
+}
+
+func (r *DuplicateRule) Name() string { return "duplicate" }
+
+func (r *DuplicateRule) Check(ctx context.Context, in *Input) (*Decision, error) {
+	fp := Fingerprint(in.Content)
+	if fp == "" {
+		return nil, nil
+	}
+
+	key := fmt.Sprintf("dup:%d:%s:%s", in.TenantID, in.authorKey(), fp)
+	n, err := r.store.Incr(ctx, key, r.window)
+	if err != nil {
+		return nil, err
+	}
+	if n > 1 {
+		return &Decision{Verdict: VerdictSpam, Rule: r.Name(), Reason: "duplicate content"}, nil
+	}
+	return nil, nil
+}
+
+// Fingerprint 内容指纹：忽略大小写、空白与标点，避免简单改写绕过重复检测
+func Fingerprint(content string) string {
+	var sb strings.Builder
+	for _, c := range strings.ToLower(content) {
+		if unicode.IsLetter(c) || unicode.IsNumber(c) {
+			sb.WriteRune(c)
+		}
+	}
+	if sb.Len() == 0 {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(sb.String()))
+	return hex.EncodeToString(sum[:16])
+}
+
+// RateLimitRule 按 IP 或用户限流，超限后拒绝保存
+type RateLimitRule struct {
+	store  CounterStore
+	limit  RateLimit
+	byUser bool
+}
+
+func (r *RateLimitRule) Name() string {
+	if r.byUser {
+		return "user_rate_limit"
+	}
+	return "ip_rate_limit"
+}
+
+func (r *RateLimitRule) Check(ctx context.Context, in *Input) (*Decision, error) {
+	var subject string
+	switch {
+	case r.byUser && in.UserID != 0:
+		subject = fmt.Sprintf("u:%d", in.UserID)
+	case !r.byUser && in.IP != "":
+		subject = "ip:" + in.IP
+	default:
+		return nil, nil
+	}
+
+	window := r.limit.Window
+	if window <= 0 {
+		window = time.Minute
+	}
+
+	// 按固定窗口计数，窗口起点对齐，保证同一窗口内使用同一个 key
+	bucket := time.Now().UnixNano() / int64(window)
+	key := fmt.Sprintf("rate:%d:%s:%d", in.TenantID, subject, bucket)
+
+	n, err := r.store.Incr(ctx, key, window)
+	if err != nil {
+		return nil, err
+	}
+	if n > int64(r.limit.Limit) {
+		return &Decision{Verdict: VerdictBlock, Rule: r.Name(), Reason: fmt.Sprintf("more than %d comments in %s", r.limit.Limit, window)}, nil
+	}
+	return nil, nil
+}
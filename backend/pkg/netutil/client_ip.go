@@ -2,7 +2,9 @@ package netutil
 
 import (
 	"context"
+	"net"
 	"net/http"
+	"strings"
 
 	"github.com/go-kratos/kratos/v2/transport"
 	khttp "github.com/go-kratos/kratos/v2/transport/http"
@@ -12,6 +14,14 @@ import (
 // 用于 service 层读取通过 header 传递的参数（如验证码 id/value）。
 // 若上下文非 HTTP 传输或取不到 request，返回 nil。
 func HeaderFromContext(ctx context.Context) http.Header {
+	req := requestFromContext(ctx)
+	if req == nil {
+		return nil
+	}
+	return req.Header
+}
+
+func requestFromContext(ctx context.Context) *http.Request {
 	tr, ok := transport.FromServerContext(ctx)
 	if !ok {
 		return nil
@@ -20,9 +30,68 @@ func HeaderFromContext(ctx context.Context) http.Header {
 	if !ok {
 		return nil
 	}
-	req := htr.Request()
+	return htr.Request()
+}
+
+// ClientIPFromContext 从 kratos context 中提取客户端真实 IP，规则见 ClientIP
+func ClientIPFromContext(ctx context.Context) string {
+	return ClientIP(requestFromContext(ctx))
+}
+
+// ClientIP 获取客户端真实 IP。
+// 依次取 X-Forwarded-For 第一个合法 IP、X-Real-IP、RemoteAddr。
+//
+// X-Forwarded-For 的第一个 IP 由客户端决定，可被伪造。部署时最外层反向代理必须覆写该头，
+// 在此基础上才能用于审计、限流等场景：
+// 最外层 Nginx 配置为：proxy_set_header X-Forwarded-For $remote_addr;
+// 里层 Nginx 配置为：proxy_set_header X-Forwarded-For $proxy_add_x_forwarded_for;
+func ClientIP(req *http.Request) string {
 	if req == nil {
-		return nil
+		return ""
 	}
-	return req.Header
+
+	// X-Forwarded-For 是逗号分隔的代理链，第一个合法 IP 为原始客户端
+	if xff := req.Header.Get("X-Forwarded-For"); xff != "" {
+		for _, ip := range strings.Split(xff, ",") {
+			if ip = strings.TrimSpace(ip); net.ParseIP(ip) != nil {
+				return ip
+			}
+		}
+	}
+
+	// X-Real-IP 只记录上一跳代理看到的客户端地址，不适用于多级代理
+	if xri := strings.TrimSpace(req.Header.Get("X-Real-IP")); net.ParseIP(xri) != nil {
+		return xri
+	}
+
+	return IPFromRemoteAddr(req.RemoteAddr)
+}
+
+// IPFromRemoteAddr 从 host:port 或纯 IP 形式的地址中提取 IP，非法地址返回空串
+func IPFromRemoteAddr(hostAddress string) string {
+	if host, _, err := net.SplitHostPort(strings.TrimSpace(hostAddress)); err == nil && net.ParseIP(host) != nil {
+		return host
+	}
+	if net.ParseIP(hostAddress) != nil {
+		return hostAddress
+	}
+	return ""
+}
+
+// UserAgentFromContext 从 kratos context 中提取 User-Agent
+func UserAgentFromContext(ctx context.Context) string {
+	req := requestFromContext(ctx)
+	if req == nil {
+		return ""
+	}
+	return req.UserAgent()
+}
+
+// HostFromContext 从 kratos context 中提取请求的 Host（可能带端口）
+func HostFromContext(ctx context.Context) string {
+	req := requestFromContext(ctx)
+	if req == nil {
+		return ""
+	}
+	return req.Host
 }
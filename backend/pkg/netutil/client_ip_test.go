@@ -0,0 +1,19 @@
+package netutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPFromRemoteAddr(t *testing.T) {
+	assert.Equal(t, "::1", IPFromRemoteAddr("[::1]:7788"))
+	assert.Equal(t, "127.0.0.1", IPFromRemoteAddr("127.0.0.1:7788"))
+	assert.Equal(t, "127.0.0.1", IPFromRemoteAddr("127.0.0.1"))
+	assert.Equal(t, "::1", IPFromRemoteAddr("::1"))
+	assert.Equal(t, "127.0.0.1", IPFromRemoteAddr("127.0.0.1:12456"))
+	assert.Equal(t, "192.0.2.1", IPFromRemoteAddr("192.0.2.1:5566"))
+	assert.Equal(t, "2001:db8::68", IPFromRemoteAddr("2001:db8::68"))
+
+	assert.Equal(t, "", IPFromRemoteAddr("192.0.2"))
+}
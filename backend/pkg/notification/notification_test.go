@@ -0,0 +1,115 @@
+package notification
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenSigner(t *testing.T) {
+	_, err := NewTokenSigner("short")
+	assert.Error(t, err)
+
+	s, err := NewTokenSigner("0123456789abcdef0123456789abcdef")
+	assert.NoError(t, err)
+
+	token := s.Sign(UnsubscribeClaims{TenantID: 2, UserID: 7, Topic: "reply"})
+	claims, err := s.Verify(token)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2), claims.TenantID)
+	assert.Equal(t, uint32(7), claims.UserID)
+	assert.Equal(t, "reply", claims.Topic)
+	assert.NotZero(t, claims.IssuedAt)
+
+	other, _ := NewTokenSigner("fedcba9876543210fedcba9876543210")
+	payload, _, _ := strings.Cut(token, ".")
+	forged := other.Sign(UnsubscribeClaims{TenantID: 2, UserID: 8})
+	_, forgedSig, _ := strings.Cut(forged, ".")
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"no signature", payload},
+		{"tampered payload", payload + "x." + strings.SplitN(token, ".", 2)[1]},
+		{"signed by other secret", forged},
+		{"swapped signature", payload + "." + forgedSig},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Verify(tt.token)
+			assert.ErrorIs(t, err, ErrInvalidToken)
+		})
+	}
+}
+
+func TestBuildMIME(t *testing.T) {
+	from := &mail.Address{Name: "Wind CMS", Address: "noreply@example.com"}
+	to := &mail.Address{Address: "user@example.com"}
+
+	body, err := BuildMIME(from, to, &Message{
+		Subject: "新回复",
+		Text:    "hello",
+		Headers: map[string]string{"List-Unsubscribe": "<https://example.com/u?token=abc>"},
+	})
+	assert.NoError(t, err)
+	s := string(body)
+	assert.Contains(t, s, "Subject: =?utf-8?q?")
+	assert.Contains(t, s, "List-Unsubscribe: <https://example.com/u?token=abc>\r\n")
+	assert.Contains(t, s, "Content-Type: text/plain; charset=utf-8\r\n")
+	assert.True(t, strings.HasSuffix(s, "\r\n\r\nhello"))
+
+	body, err = BuildMIME(from, to, &Message{Subject: "s", Text: "plain", HTML: "<p>html</p>"})
+	assert.NoError(t, err)
+	s = string(body)
+	assert.Contains(t, s, "multipart/alternative")
+	assert.Contains(t, s, "text/html; charset=utf-8")
+	assert.Contains(t, s, "<p>html</p>")
+
+	_, err = BuildMIME(from, to, &Message{Subject: "s", Headers: map[string]string{"X-Bad": "a\r\nBcc: x@example.com"}})
+	assert.Error(t, err)
+}
+
+func TestWebhookSender(t *testing.T) {
+	var got *http.Request
+	var gotBody []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		gotBody, _ = io.ReadAll(r.Body)
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+
+	sender := NewWebhookSender(0)
+	body := []byte(`{"form_id":1}`)
+
+	// 测试服务器监听在回环地址，默认客户端拒绝连接
+	assert.Error(t, sender.Send(context.Background(), srv.URL+"/hook", "form.submitted", "", body))
+	assert.Nil(t, got)
+
+	sender.client.Transport = srv.Client().Transport
+
+	assert.NoError(t, sender.Send(context.Background(), srv.URL+"/hook", "form.submitted", "s3cret", body))
+	assert.Equal(t, "form.submitted", got.Header.Get(WebhookEventHeader))
+	assert.Equal(t, body, gotBody)
+	ts, err := strconv.ParseInt(got.Header.Get(WebhookTimestampHeader), 10, 64)
+	assert.NoError(t, err)
+	assert.Equal(t, SignWebhook("s3cret", ts, body), got.Header.Get(WebhookSignatureHeader))
+
+	assert.NoError(t, sender.Send(context.Background(), srv.URL+"/hook", "form.submitted", "", body))
+	assert.Empty(t, got.Header.Get(WebhookSignatureHeader))
+
+	assert.Error(t, sender.Send(context.Background(), srv.URL+"/fail", "form.submitted", "", body))
+	assert.Error(t, sender.Send(context.Background(), "file:///etc/passwd", "form.submitted", "", body))
+	assert.Error(t, ValidateWebhookURL("/relative"))
+}
@@ -0,0 +1,31 @@
+package notification
+
+import (
+	"encoding/json"
+)
+
+// DefaultPushChannel 站内通知推送的 Redis 频道。
+// core 服务写入站内信后向该频道发布，app/admin 服务订阅后经 SSE 推送给在线用户。
+const DefaultPushChannel = "gwc:notify:push"
+
+// PushEvent 推送给在线用户的通知
+type PushEvent struct {
+	TenantID uint32          `json:"tenant_id"`
+	UserID   uint32          `json:"user_id"`
+	Event    string          `json:"event"` // SSE 事件名，如 notification
+	Data     json.RawMessage `json:"data"`  // SSE 事件数据
+}
+
+// Marshal 序列化推送事件
+func (e *PushEvent) Marshal() ([]byte, error) {
+	return json.Marshal(e)
+}
+
+// UnmarshalPushEvent 反序列化推送事件
+func UnmarshalPushEvent(b []byte) (*PushEvent, error) {
+	var e PushEvent
+	if err := json.Unmarshal(b, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
@@ -0,0 +1,124 @@
+package notification
+
+import (
+	"context"
+	"sync"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+	"github.com/tx7do/go-utils/id"
+	"github.com/tx7do/kratos-transport/transport/sse"
+)
+
+// Publisher SSE 事件发布者，*sse.Server 满足该接口
+type Publisher interface {
+	Publish(ctx context.Context, streamID sse.StreamID, event *sse.Event)
+}
+
+// StreamLookup 查询用户当前在线的 SSE 流（即该用户的 access token 列表）
+type StreamLookup func(ctx context.Context, userID uint32) ([]string, error)
+
+// Relay 订阅 Redis 推送频道，把 core 服务发布的 PushEvent 经本服务的 SSE 推送给在线用户。
+// 实现 transport.Server，随应用启动与停止。
+type Relay struct {
+	rdb       *redis.Client
+	channel   string
+	publisher Publisher
+	lookup    StreamLookup
+	log       *log.Helper
+
+	mu     sync.Mutex
+	pubsub *redis.PubSub
+	done   chan struct{}
+}
+
+func NewRelay(rdb *redis.Client, channel string, publisher Publisher, lookup StreamLookup, logger log.Logger) *Relay {
+	if channel == "" {
+		channel = DefaultPushChannel
+	}
+	return &Relay{
+		rdb:       rdb,
+		channel:   channel,
+		publisher: publisher,
+		lookup:    lookup,
+		log:       log.NewHelper(log.With(logger, "module", "notification-relay")),
+	}
+}
+
+// Start 订阅推送频道；未配置 Redis 或 SSE 时不做任何事
+func (r *Relay) Start(ctx context.Context) error {
+	if r.rdb == nil || r.publisher == nil || r.lookup == nil {
+		r.log.Warn("notification relay disabled: redis or sse server not available")
+		return nil
+	}
+
+	pubsub := r.rdb.Subscribe(ctx, r.channel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return err
+	}
+
+	r.mu.Lock()
+	r.pubsub = pubsub
+	r.done = make(chan struct{})
+	r.mu.Unlock()
+
+	go r.loop(pubsub.Channel(), r.done)
+
+	r.log.Infof("notification relay subscribed to [%s]", r.channel)
+	return nil
+}
+
+// Stop 取消订阅并等待转发协程退出
+func (r *Relay) Stop(ctx context.Context) error {
+	r.mu.Lock()
+	pubsub, done := r.pubsub, r.done
+	r.pubsub, r.done = nil, nil
+	r.mu.Unlock()
+
+	if pubsub == nil {
+		return nil
+	}
+
+	err := pubsub.Close()
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+	return err
+}
+
+func (r *Relay) loop(ch <-chan *redis.Message, done chan struct{}) {
+	defer close(done)
+	for msg := range ch {
+		r.forward(msg.Payload)
+	}
+}
+
+func (r *Relay) forward(payload string) {
+	event, err := UnmarshalPushEvent([]byte(payload))
+	if err != nil || event.UserID == 0 {
+		r.log.Warnf("drop invalid push event: %v", err)
+		return
+	}
+
+	ctx := context.Background()
+
+	streams, err := r.lookup(ctx, event.UserID)
+	if err != nil {
+		r.log.Errorf("lookup streams of user [%d] failed: %s", event.UserID, err.Error())
+		return
+	}
+
+	name := event.Event
+	if name == "" {
+		name = "notification"
+	}
+	for _, streamID := range streams {
+		r.publisher.Publish(ctx, sse.StreamID(streamID), &sse.Event{
+			ID:    []byte(id.NewGUIDv7(false)),
+			Data:  event.Data,
+			Event: []byte(name),
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package notification
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var ErrInvalidToken = errors.New("invalid unsubscribe token")
+
+// UnsubscribeClaims 退订令牌内容
+type UnsubscribeClaims struct {
+	TenantID uint32 `json:"t"`
+	UserID   uint32 `json:"u"`
+	Topic    string `json:"s"`   // 退订的通知类型，空表示全部
+	IssuedAt int64  `json:"iat"` // 签发时间（Unix 秒）
+}
+
+// TokenSigner 退订令牌签发与校验。
+// 令牌为无状态的 HMAC-SHA256 签名，不设过期时间，保证旧邮件中的退订链接始终可用；
+// 更换密钥即可使全部旧令牌失效。
+type TokenSigner struct {
+	secret []byte
+}
+
+func NewTokenSigner(secret string) (*TokenSigner, error) {
+	if len(secret) < 16 {
+		return nil, errors.New("unsubscribe token secret must be at least 16 bytes")
+	}
+	return &TokenSigner{secret: []byte(secret)}, nil
+}
+
+// Sign 签发令牌，格式为 base64url(payload).base64url(signature)
+func (s *TokenSigner) Sign(claims UnsubscribeClaims) string {
+	if claims.IssuedAt == 0 {
+		claims.IssuedAt = time.Now().Unix()
+	}
+	payload, _ := json.Marshal(claims)
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.mac(encoded))
+}
+
+// Verify 校验令牌签名并返回其内容
+func (s *TokenSigner) Verify(token string) (*UnsubscribeClaims, error) {
+	encoded, sig, ok := strings.Cut(token, ".")
+	if !ok || encoded == "" || sig == "" {
+		return nil, ErrInvalidToken
+	}
+
+	got, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(got, s.mac(encoded)) {
+		return nil, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	var claims UnsubscribeClaims
+	if err = json.Unmarshal(payload, &claims); err != nil || claims.UserID == 0 {
+		return nil, ErrInvalidToken
+	}
+	return &claims, nil
+}
+
+func (s *TokenSigner) mac(data string) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
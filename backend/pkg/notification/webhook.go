@@ -0,0 +1,104 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"go-wind-cms/pkg/netutil"
+)
+
+const (
+	// WebhookEventHeader 事件名请求头
+	WebhookEventHeader = "X-Wind-Event"
+	// WebhookTimestampHeader 签名时间戳请求头（Unix 秒）
+	WebhookTimestampHeader = "X-Wind-Timestamp"
+	// WebhookSignatureHeader 签名请求头，值为 "sha256=" + hex(HMAC-SHA256(secret, timestamp + "." + body))
+	WebhookSignatureHeader = "X-Wind-Signature"
+
+	defaultWebhookTimeout = 10 * time.Second
+)
+
+// ValidateWebhookURL 校验 Webhook 地址：仅允许带主机名的 http/https 绝对地址
+func ValidateWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid webhook url [%s]: %w", raw, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid webhook url [%s]: only absolute http(s) urls are allowed", raw)
+	}
+	return nil
+}
+
+// SignWebhook 计算 Webhook 签名，接收方以同一密钥按相同方式计算后比对，并校验时间戳防止重放
+func SignWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookSender 以 JSON POST 投递 Webhook
+type WebhookSender struct {
+	client *http.Client
+}
+
+// NewWebhookSender 创建 Webhook 发送器，timeout 为 0 时使用默认的 10 秒。
+// Webhook 地址由租户配置，使用 SSRF 防护的客户端，拒绝连接内网与保留地址；
+// 不跟随重定向，避免被重定向到非预期地址。
+func NewWebhookSender(timeout time.Duration) *WebhookSender {
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+
+	client := netutil.SafeHTTPClient()
+	client.Timeout = timeout
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	return &WebhookSender{client: client}
+}
+
+// Send 投递一次 Webhook；secret 为空时不签名。非 2xx 响应视为失败。
+func (s *WebhookSender) Send(ctx context.Context, endpoint, event, secret string, body []byte) error {
+	if err := ValidateWebhookURL(endpoint); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	ts := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, event)
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(ts, 10))
+	if secret != "" {
+		req.Header.Set(WebhookSignatureHeader, SignWebhook(secret, ts, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.New("webhook responded with " + resp.Status)
+	}
+	return nil
+}
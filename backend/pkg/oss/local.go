@@ -0,0 +1,742 @@
+package oss
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/tx7do/go-utils/timeutil"
+	"github.com/tx7do/go-utils/trans"
+
+	storageV1 "go-wind-cms/api/gen/go/storage/service/v1"
+)
+
+const (
+	// LocalRoutePrefix 本地驱动签名地址的路由前缀，由 REST 服务器挂载 LocalClient.Handler
+	LocalRoutePrefix = "/oss/v1/"
+
+	localMetaDir = ".meta" // 元数据目录，存放对象的 Content-Type 与校验和
+
+	localQueryExpires   = "X-Oss-Expires"
+	localQuerySignature = "X-Oss-Signature"
+)
+
+// localPublicBuckets 允许不带签名读取的存储桶。
+// 与 MinIO 的公共读存储桶一致，UploadFile 返回的永久下载地址不带签名，文件与媒体记录中保存的地址须能直接访问；
+// 隔离桶等其他存储桶仍只能通过签名地址读取。
+var localPublicBuckets = map[string]bool{
+	BucketImages: true,
+	BucketVideos: true,
+	BucketAudios: true,
+	BucketDocs:   true,
+	BucketFiles:  true,
+}
+
+// LocalOptions 本地文件系统驱动配置
+type LocalOptions struct {
+	Root    string // 存储根目录，对象保存在 <Root>/<bucket>/<object>
+	BaseURL string // 对外访问地址（REST 服务器地址），例如 http://127.0.0.1:7788
+	SignKey string // 签名地址的 HMAC 密钥，必填，core/admin/app 须配置相同的值
+}
+
+// localObjectMeta 对象元数据
+type localObjectMeta struct {
+	ContentType string `json:"content_type"`
+	Checksum    string `json:"checksum"`
+}
+
+// LocalClient 本地文件系统存储驱动。
+// 在没有对象存储的部署环境中使用，预签名地址通过 HMAC 签名模拟，由 Handler 校验并提供读写。
+type LocalClient struct {
+	root    string
+	baseURL string
+	signKey []byte
+	log     *log.Helper
+}
+
+func NewLocalClient(opts *LocalOptions, logger log.Logger) (*LocalClient, error) {
+	if opts == nil || strings.TrimSpace(opts.Root) == "" {
+		return nil, errors.New("local storage root is required")
+	}
+	// 不回退到内置密钥：内置密钥随源码公开，任何人都能伪造签名地址
+	if opts.SignKey == "" {
+		return nil, errors.New("local storage sign key is required")
+	}
+
+	root, err := filepath.Abs(opts.Root)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &LocalClient{
+		root:    root,
+		baseURL: strings.TrimRight(opts.BaseURL, "/"),
+		signKey: []byte(opts.SignKey),
+		log:     log.NewHelper(log.With(logger, "module", "local/oss")),
+	}, nil
+}
+
+// Provider 本地文件系统
+func (c *LocalClient) Provider() storageV1.OSSProvider {
+	return storageV1.OSSProvider_LOCAL
+}
+
+// Root 存储根目录
+func (c *LocalClient) Root() string {
+	return c.root
+}
+
+// validBucketName 校验存储桶名称，禁止路径分隔符与隐藏目录（与元数据目录冲突）
+func validBucketName(bucketName string) bool {
+	if bucketName == "" || strings.HasPrefix(bucketName, ".") {
+		return false
+	}
+	return !strings.ContainsAny(bucketName, `/\`+"\x00")
+}
+
+// cleanObjectName 规范化对象键，拒绝越出存储桶目录的路径
+func cleanObjectName(objectName string) (string, bool) {
+	if objectName == "" || strings.ContainsAny(objectName, `\`+"\x00") {
+		return "", false
+	}
+	cleaned := path.Clean("/" + objectName)
+	cleaned = strings.TrimPrefix(cleaned, "/")
+	if cleaned == "" || cleaned == "." {
+		return "", false
+	}
+	return cleaned, true
+}
+
+// objectPath 返回对象的文件路径与元数据路径
+func (c *LocalClient) objectPath(bucketName, objectName string) (string, string, error) {
+	if !validBucketName(bucketName) {
+		return "", "", storageV1.ErrorBadRequest("invalid bucket name")
+	}
+	key, ok := cleanObjectName(objectName)
+	if !ok {
+		return "", "", storageV1.ErrorBadRequest("invalid object name")
+	}
+
+	filePath := filepath.Join(c.root, bucketName, filepath.FromSlash(key))
+	metaPath := filepath.Join(c.root, localMetaDir, bucketName, filepath.FromSlash(key)+".json")
+	return filePath, metaPath, nil
+}
+
+func (c *LocalClient) readMeta(metaPath string) localObjectMeta {
+	var meta localObjectMeta
+	data, err := os.ReadFile(metaPath)
+	if err != nil {
+		return meta
+	}
+	_ = json.Unmarshal(data, &meta)
+	return meta
+}
+
+func (c *LocalClient) objectInfo(bucketName, key, metaPath string, fi os.FileInfo) ObjectInfo {
+	meta := c.readMeta(metaPath)
+	contentType := meta.ContentType
+	if contentType == "" {
+		contentType = DefaultContentType
+	}
+	return ObjectInfo{
+		Bucket:       bucketName,
+		Key:          key,
+		Size:         fi.Size(),
+		ETag:         meta.Checksum,
+		ContentType:  contentType,
+		Checksum:     meta.Checksum,
+		LastModified: fi.ModTime(),
+	}
+}
+
+// EnsureBucketExists 确保存储桶目录存在
+func (c *LocalClient) EnsureBucketExists(_ context.Context, bucketName string) error {
+	if !validBucketName(bucketName) {
+		return storageV1.ErrorBadRequest("invalid bucket name")
+	}
+	if err := os.MkdirAll(filepath.Join(c.root, bucketName), 0o755); err != nil {
+		c.log.Errorf("Failed to create bucket: %v", err)
+		return storageV1.ErrorInternalServerError("failed to create bucket: %s", bucketName)
+	}
+	return nil
+}
+
+// UploadFile 上传文件，先写临时文件再原子重命名，避免读到写了一半的对象
+func (c *LocalClient) UploadFile(
+	ctx context.Context,
+	bucketName string, objectName string,
+	mimeType string,
+	reader io.Reader, objectSize int64,
+) (ObjectInfo, string, string, error) {
+	if objectSize <= 0 {
+		c.log.Errorf("empty fileContent data")
+		return ObjectInfo{}, "", "", storageV1.ErrorUploadFailed("empty fileContent data")
+	}
+	if objectSize > MaxUploadObjectSize {
+		c.log.Errorf("upload object too large: %d bytes (max %d)", objectSize, MaxUploadObjectSize)
+		return ObjectInfo{}, "", "", storageV1.ErrorUploadFailed("upload object exceeds max size limit")
+	}
+
+	if bucketName == "" {
+		bucketName = BucketFiles
+	}
+	if objectName == "" {
+		bucketName = ContentTypeToBucketName(mimeType)
+		objectName = GenerateObjectName("", ContentTypeToFileExtension(mimeType), GenerateFileNameTypeUUID)
+	}
+	if mimeType == "" {
+		mimeType = DefaultContentType
+	}
+
+	filePath, metaPath, err := c.objectPath(bucketName, objectName)
+	if err != nil {
+		return ObjectInfo{}, "", "", err
+	}
+	key, _ := cleanObjectName(objectName)
+
+	if err = os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		c.log.Errorf("failed to create object directory: %v", err)
+		return ObjectInfo{}, "", "", storageV1.ErrorUploadFailed("failed to upload fileContent")
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), ".upload-*")
+	if err != nil {
+		c.log.Errorf("failed to create temp file: %v", err)
+		return ObjectInfo{}, "", "", storageV1.ErrorUploadFailed("failed to upload fileContent")
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	// 与 MinIO 行为一致：只读取声明大小的字节，实际内容不足时视为上传失败
+	hash := sha256.New()
+	written, err := io.Copy(io.MultiWriter(tmp, hash), io.LimitReader(reader, objectSize))
+	if err != nil {
+		c.log.Errorf("failed to upload fileContent: %v", err)
+		return ObjectInfo{}, "", "", storageV1.ErrorUploadFailed("failed to upload fileContent")
+	}
+	if written != objectSize {
+		c.log.Errorf("upload size mismatch: declared %d, got %d", objectSize, written)
+		return ObjectInfo{}, "", "", storageV1.ErrorUploadFailed("upload size mismatch")
+	}
+	if err = tmp.Close(); err != nil {
+		c.log.Errorf("failed to upload fileContent: %v", err)
+		return ObjectInfo{}, "", "", storageV1.ErrorUploadFailed("failed to upload fileContent")
+	}
+	if err = os.Rename(tmp.Name(), filePath); err != nil {
+		c.log.Errorf("failed to upload fileContent: %v", err)
+		return ObjectInfo{}, "", "", storageV1.ErrorUploadFailed("failed to upload fileContent")
+	}
+
+	meta := localObjectMeta{
+		ContentType: mimeType,
+		Checksum:    hex.EncodeToString(hash.Sum(nil)),
+	}
+	if err = os.MkdirAll(filepath.Dir(metaPath), 0o755); err == nil {
+		data, _ := json.Marshal(meta)
+		err = os.WriteFile(metaPath, data, 0o644)
+	}
+	if err != nil {
+		c.log.Warnf("failed to write object meta: %v", err)
+	}
+
+	info := ObjectInfo{
+		Bucket:       bucketName,
+		Key:          key,
+		Size:         written,
+		ETag:         meta.Checksum,
+		ContentType:  mimeType,
+		Checksum:     meta.Checksum,
+		LastModified: time.Now(),
+	}
+
+	downloadUrl := JoinObjectUrl(c.baseURL+strings.TrimSuffix(LocalRoutePrefix, "/"), bucketName, key)
+	storagePath := JoinObjectUrl("", bucketName, key)
+
+	return info, storagePath, downloadUrl, nil
+}
+
+// localObjectReader 限定读取区间的对象读取器
+type localObjectReader struct {
+	io.Reader
+	f *os.File
+}
+
+func (r *localObjectReader) Close() error {
+	return r.f.Close()
+}
+
+// GetObject 读取对象
+func (c *LocalClient) GetObject(_ context.Context, bucketName, objectName string, rangeStart, rangeEnd *int64) (io.ReadCloser, ObjectInfo, error) {
+	filePath, metaPath, err := c.objectPath(bucketName, objectName)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	key, _ := cleanObjectName(objectName)
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		c.log.Errorf("failed to get object: %v", err)
+		return nil, ObjectInfo{}, storageV1.ErrorDownloadFailed("failed to get object")
+	}
+	fi, err := f.Stat()
+	if err != nil || fi.IsDir() {
+		_ = f.Close()
+		return nil, ObjectInfo{}, storageV1.ErrorDownloadFailed("failed to stat object")
+	}
+
+	info := c.objectInfo(bucketName, key, metaPath, fi)
+
+	start, end := int64(0), fi.Size()-1
+	switch {
+	case rangeStart != nil && rangeEnd != nil:
+		start, end = *rangeStart, *rangeEnd
+	case rangeStart != nil:
+		start = *rangeStart
+	case rangeEnd != nil:
+		end = *rangeEnd
+	}
+	if end > fi.Size()-1 {
+		end = fi.Size() - 1
+	}
+	if start < 0 || (fi.Size() > 0 && start > end) {
+		_ = f.Close()
+		return nil, ObjectInfo{}, storageV1.ErrorBadRequest("invalid download range")
+	}
+
+	return &localObjectReader{
+		Reader: io.NewSectionReader(f, start, end-start+1),
+		f:      f,
+	}, info, nil
+}
+
+// DeleteFile 删除一个文件
+func (c *LocalClient) DeleteFile(_ context.Context, bucketName, objectName string) error {
+	if bucketName == "" {
+		return storageV1.ErrorBadRequest("bucket name is required")
+	}
+	if objectName == "" {
+		return storageV1.ErrorBadRequest("object name is required")
+	}
+
+	filePath, metaPath, err := c.objectPath(bucketName, objectName)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(filePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		c.log.Errorf("Failed to delete file: %v", err)
+		return storageV1.ErrorDeleteFailed("failed to delete file")
+	}
+	_ = os.Remove(metaPath)
+
+	return nil
+}
+
+// ListObjects 列出指定前缀下的对象，非递归时目录以 "/" 结尾返回
+func (c *LocalClient) ListObjects(_ context.Context, bucketName, prefix string, recursive bool) ([]ObjectInfo, error) {
+	if !validBucketName(bucketName) {
+		return nil, storageV1.ErrorBadRequest("invalid bucket name")
+	}
+
+	bucketDir := filepath.Join(c.root, bucketName)
+	objects := make([]ObjectInfo, 0)
+	seenDirs := make(map[string]struct{})
+
+	err := filepath.WalkDir(bucketDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".upload-") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(bucketDir, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		if !recursive {
+			if idx := strings.Index(key[len(prefix):], "/"); idx >= 0 {
+				dir := key[:len(prefix)+idx+1]
+				if _, ok := seenDirs[dir]; !ok {
+					seenDirs[dir] = struct{}{}
+					objects = append(objects, ObjectInfo{Bucket: bucketName, Key: dir})
+				}
+				return nil
+			}
+		}
+
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		_, metaPath, _ := c.objectPath(bucketName, key)
+		objects = append(objects, c.objectInfo(bucketName, key, metaPath, fi))
+		return nil
+	})
+	if err != nil {
+		c.log.Errorf("failed to list objects: %v", err)
+		return nil, storageV1.ErrorInternalServerError("failed to list objects")
+	}
+
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+
+	return objects, nil
+}
+
+// sign 计算签名：HMAC-SHA256(method \n bucket \n object \n expires)
+func (c *LocalClient) sign(method, bucketName, objectName string, expires int64) string {
+	mac := hmac.New(sha256.New, c.signKey)
+	mac.Write([]byte(method + "\n" + bucketName + "\n" + objectName + "\n" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// presign 生成签名地址
+func (c *LocalClient) presign(method, bucketName, objectName string, expiry time.Duration) (string, error) {
+	if !validBucketName(bucketName) {
+		return "", storageV1.ErrorBadRequest("invalid bucket name")
+	}
+	key, ok := cleanObjectName(objectName)
+	if !ok {
+		return "", storageV1.ErrorBadRequest("invalid object name")
+	}
+	if expiry <= 0 {
+		expiry = defaultExpiryTime
+	}
+
+	expires := time.Now().Add(expiry).Unix()
+
+	q := url.Values{}
+	q.Set(localQueryExpires, strconv.FormatInt(expires, 10))
+	q.Set(localQuerySignature, c.sign(method, bucketName, key, expires))
+
+	u := c.baseURL + LocalRoutePrefix + url.PathEscape(bucketName) + "/" + (&url.URL{Path: key}).EscapedPath()
+	return u + "?" + q.Encode(), nil
+}
+
+// PresignedPutObject 生成预签名上传地址
+func (c *LocalClient) PresignedPutObject(_ context.Context, bucketName, objectName string, expiry time.Duration) (string, error) {
+	return c.presign(http.MethodPut, bucketName, objectName, expiry)
+}
+
+// PresignedGetObject 生成预签名下载地址
+func (c *LocalClient) PresignedGetObject(_ context.Context, bucketName, objectName string, expiry time.Duration) (string, error) {
+	return c.presign(http.MethodGet, bucketName, objectName, expiry)
+}
+
+// GetUploadPresignedUrl 获取上传地址，POST 方式使用 multipart 表单的 file 字段上传
+func (c *LocalClient) GetUploadPresignedUrl(ctx context.Context, req *storageV1.GetUploadPresignedUrlRequest) (*storageV1.GetUploadPresignedUrlResponse, error) {
+	var bucketName string
+	if req.BucketName != nil {
+		bucketName = req.GetBucketName()
+	} else {
+		bucketName = ContentTypeToBucketName(req.GetContentType())
+	}
+	if bucketName == "" {
+		bucketName = BucketFiles
+	}
+
+	objectName, _ := JoinObjectName(req.GetContentType(), req.FileDirectory, req.FileName)
+
+	expiry := defaultExpiryTime
+	if req.ExpireSeconds != nil {
+		expiry = time.Second * time.Duration(req.GetExpireSeconds())
+	}
+
+	if err := c.EnsureBucketExists(ctx, bucketName); err != nil {
+		return nil, err
+	}
+
+	method := http.MethodPut
+	if req.GetMethod() == storageV1.GetUploadPresignedUrlRequest_Post {
+		method = http.MethodPost
+	}
+
+	uploadUrl, err := c.presign(method, bucketName, objectName, expiry)
+	if err != nil {
+		c.log.Errorf("Failed to generate presigned %s url: %v", method, err)
+		return nil, storageV1.ErrorUploadFailed("failed to generate presigned %s policy", method)
+	}
+
+	key, _ := cleanObjectName(objectName)
+
+	return &storageV1.GetUploadPresignedUrlResponse{
+		UploadUrl:   uploadUrl,
+		DownloadUrl: JoinObjectUrl(c.baseURL+strings.TrimSuffix(LocalRoutePrefix, "/"), bucketName, key),
+		ObjectName:  key,
+		BucketName:  trans.Ptr(bucketName),
+		FormData:    map[string]string{},
+	}, nil
+}
+
+// ListFile 获取文件夹下面的文件列表
+func (c *LocalClient) ListFile(ctx context.Context, req *storageV1.ListOssFileRequest) (*storageV1.ListOssFileResponse, error) {
+	objects, err := c.ListObjects(ctx, req.GetBucketName(), req.GetFolder(), req.GetRecursive())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &storageV1.ListOssFileResponse{
+		Files: make([]string, 0, len(objects)),
+	}
+	for _, object := range objects {
+		resp.Files = append(resp.Files, object.Key)
+	}
+	return resp, nil
+}
+
+// readObject 读取对象全部内容
+func (c *LocalClient) readObject(ctx context.Context, obj *storageV1.StorageObject, rangeStart, rangeEnd *int64) ([]byte, ObjectInfo, error) {
+	reader, info, err := c.GetObject(ctx, obj.GetBucketName(), obj.GetObjectName(), rangeStart, rangeEnd)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	defer reader.Close()
+
+	buf := new(bytes.Buffer)
+	if _, err = buf.ReadFrom(reader); err != nil {
+		c.log.Errorf("failed to read object: %v", err)
+		return nil, ObjectInfo{}, storageV1.ErrorDownloadFailed("failed to read object")
+	}
+	return buf.Bytes(), info, nil
+}
+
+// GetDownloadUrl 获取下载地址
+func (c *LocalClient) GetDownloadUrl(ctx context.Context, req *storageV1.GetDownloadInfoRequest) (*storageV1.GetDownloadInfoResponse, error) {
+	switch req.Selector.(type) {
+	case *storageV1.GetDownloadInfoRequest_StorageObject:
+		obj := req.GetStorageObject()
+		if req.GetPreferPresignedUrl() {
+			downloadUrl, err := c.PresignedGetObject(ctx, obj.GetBucketName(), obj.GetObjectName(), presignExpiry(req.PresignExpireSeconds))
+			if err != nil {
+				return nil, err
+			}
+			return &storageV1.GetDownloadInfoResponse{
+				Content: &storageV1.GetDownloadInfoResponse_DownloadUrl{DownloadUrl: downloadUrl},
+			}, nil
+		}
+
+		data, info, err := c.readObject(ctx, obj, req.RangeStart, req.RangeEnd)
+		if err != nil {
+			return nil, err
+		}
+		resp := &storageV1.GetDownloadInfoResponse{
+			Content:        &storageV1.GetDownloadInfoResponse_File{File: data},
+			Mime:           info.ContentType,
+			Checksum:       info.Checksum,
+			SourceFileName: info.Key,
+			Size:           info.Size,
+			UpdatedAt:      timeutil.TimeToTimestamppb(&info.LastModified),
+		}
+		if req.GetAcceptMime() != "" {
+			resp.Mime = req.GetAcceptMime()
+		}
+		return resp, nil
+
+	case *storageV1.GetDownloadInfoRequest_FileId:
+		return nil, storageV1.ErrorNotImplemented("not implemented yet")
+
+	default:
+		return nil, storageV1.ErrorBadRequest("invalid selector")
+	}
+}
+
+// DownloadFile 下载文件
+func (c *LocalClient) DownloadFile(ctx context.Context, req *storageV1.DownloadFileRequest) (*storageV1.DownloadFileResponse, error) {
+	switch req.Selector.(type) {
+	case *storageV1.DownloadFileRequest_StorageObject:
+		obj := req.GetStorageObject()
+		if req.GetPreferPresignedUrl() {
+			downloadUrl, err := c.PresignedGetObject(ctx, obj.GetBucketName(), obj.GetObjectName(), presignExpiry(req.PresignExpireSeconds))
+			if err != nil {
+				return nil, err
+			}
+			return &storageV1.DownloadFileResponse{
+				Content: &storageV1.DownloadFileResponse_DownloadUrl{DownloadUrl: downloadUrl},
+			}, nil
+		}
+
+		data, info, err := c.readObject(ctx, obj, req.RangeStart, req.RangeEnd)
+		if err != nil {
+			return nil, err
+		}
+		resp := &storageV1.DownloadFileResponse{
+			Content:        &storageV1.DownloadFileResponse_File{File: data},
+			Mime:           info.ContentType,
+			Checksum:       info.Checksum,
+			SourceFileName: info.Key,
+			Size:           info.Size,
+			UpdatedAt:      timeutil.TimeToTimestamppb(&info.LastModified),
+		}
+		if req.GetAcceptMime() != "" {
+			resp.Mime = req.GetAcceptMime()
+		}
+		return resp, nil
+
+	case *storageV1.DownloadFileRequest_FileId:
+		return nil, storageV1.ErrorNotImplemented("not implemented yet")
+
+	default:
+		return nil, storageV1.ErrorBadRequest("invalid selector")
+	}
+}
+
+func presignExpiry(seconds *int32) time.Duration {
+	if seconds == nil {
+		return defaultExpiryTime
+	}
+	return time.Second * time.Duration(*seconds)
+}
+
+// Handler 返回签名地址的 HTTP 处理器，挂载在 LocalRoutePrefix 下：
+// GET/HEAD 下载（支持 Range），PUT 以请求体上传，POST 以 multipart 表单 file 字段上传。
+// 公共存储桶的 GET/HEAD 不要求签名，其余请求须带有效签名。
+func (c *LocalClient) Handler() http.Handler {
+	return http.HandlerFunc(c.serveHTTP)
+}
+
+func (c *LocalClient) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimPrefix(r.URL.Path, strings.TrimSuffix(LocalRoutePrefix, "/"))
+	rest = strings.TrimPrefix(rest, "/")
+	bucketName, objectName, found := strings.Cut(rest, "/")
+	if !found {
+		http.Error(w, "invalid object path", http.StatusBadRequest)
+		return
+	}
+	key, ok := cleanObjectName(objectName)
+	if !ok || !validBucketName(bucketName) {
+		http.Error(w, "invalid object path", http.StatusBadRequest)
+		return
+	}
+
+	signMethod := r.Method
+	if signMethod == http.MethodHead {
+		signMethod = http.MethodGet
+	}
+	if !c.publicRead(signMethod, bucketName, r.URL.Query()) && !c.verify(signMethod, bucketName, key, r.URL.Query()) {
+		http.Error(w, "signature mismatch or expired", http.StatusForbidden)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		c.serveObject(w, r, bucketName, key)
+	case http.MethodPut:
+		if r.ContentLength <= 0 {
+			http.Error(w, "content length required", http.StatusLengthRequired)
+			return
+		}
+		c.storeObject(w, r, bucketName, key, r.Header.Get("Content-Type"), r.Body, r.ContentLength)
+	case http.MethodPost:
+		r.Body = http.MaxBytesReader(w, r.Body, MaxUploadObjectSize+1<<20)
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, "file is required", http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		c.storeObject(w, r, bucketName, key, header.Header.Get("Content-Type"), file, header.Size)
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// publicRead 是否为公共存储桶的不带签名读取；带签名的请求仍按签名校验
+func (c *LocalClient) publicRead(method, bucketName string, query url.Values) bool {
+	return method == http.MethodGet && localPublicBuckets[bucketName] && !query.Has(localQuerySignature)
+}
+
+// verify 校验签名与有效期
+func (c *LocalClient) verify(method, bucketName, objectName string, query url.Values) bool {
+	expires, err := strconv.ParseInt(query.Get(localQueryExpires), 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		return false
+	}
+	signature, err := hex.DecodeString(query.Get(localQuerySignature))
+	if err != nil {
+		return false
+	}
+	expected, _ := hex.DecodeString(c.sign(method, bucketName, objectName, expires))
+	return hmac.Equal(signature, expected)
+}
+
+func (c *LocalClient) serveObject(w http.ResponseWriter, r *http.Request, bucketName, objectName string) {
+	filePath, metaPath, err := c.objectPath(bucketName, objectName)
+	if err != nil {
+		http.Error(w, "invalid object path", http.StatusBadRequest)
+		return
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil || fi.IsDir() {
+		http.NotFound(w, r)
+		return
+	}
+
+	info := c.objectInfo(bucketName, objectName, metaPath, fi)
+	w.Header().Set("Content-Type", info.ContentType)
+	if info.ETag != "" {
+		w.Header().Set("ETag", `"`+info.ETag+`"`)
+	}
+
+	// ServeContent 负责 Range、If-Modified-Since 等协商
+	http.ServeContent(w, r, path.Base(objectName), fi.ModTime(), f)
+}
+
+func (c *LocalClient) storeObject(w http.ResponseWriter, r *http.Request, bucketName, objectName, contentType string, reader io.Reader, size int64) {
+	if size > MaxUploadObjectSize {
+		http.Error(w, "upload object exceeds max size limit", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err := c.EnsureBucketExists(r.Context(), bucketName); err != nil {
+		http.Error(w, "failed to create bucket", http.StatusInternalServerError)
+		return
+	}
+
+	info, _, _, err := c.UploadFile(r.Context(), bucketName, objectName, contentType, reader, size)
+	if err != nil {
+		http.Error(w, "failed to upload object", http.StatusInternalServerError)
+		return
+	}
+
+	if info.ETag != "" {
+		w.Header().Set("ETag", `"`+info.ETag+`"`)
+	}
+	w.WriteHeader(http.StatusOK)
+}
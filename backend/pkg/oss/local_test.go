@@ -0,0 +1,285 @@
+package oss
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/tx7do/go-utils/trans"
+
+	storageV1 "go-wind-cms/api/gen/go/storage/service/v1"
+)
+
+func createTestLocalClient(t *testing.T, baseURL string) *LocalClient {
+	t.Helper()
+	c, err := NewLocalClient(&LocalOptions{
+		Root:    t.TempDir(),
+		BaseURL: baseURL,
+		SignKey: "test-sign-key",
+	}, log.DefaultLogger)
+	assert.NoError(t, err)
+	return c
+}
+
+func TestLocalClient_UploadGetDelete(t *testing.T) {
+	ctx := context.Background()
+	c := createTestLocalClient(t, "http://127.0.0.1:7788")
+
+	content := []byte("hello local storage")
+	info, storagePath, downloadUrl, err := c.UploadFile(ctx, "docs", "a/b/hello.txt", "text/plain", bytes.NewReader(content), int64(len(content)))
+	assert.NoError(t, err)
+	assert.Equal(t, "docs", info.Bucket)
+	assert.Equal(t, "a/b/hello.txt", info.Key)
+	assert.Equal(t, int64(len(content)), info.Size)
+	assert.NotEmpty(t, info.Checksum)
+	assert.Equal(t, "/docs/a/b/hello.txt", storagePath)
+	assert.True(t, strings.HasPrefix(downloadUrl, "http://127.0.0.1:7788/oss/v1/docs/a/b/hello.txt"))
+
+	reader, got, err := c.GetObject(ctx, "docs", "a/b/hello.txt", nil, nil)
+	assert.NoError(t, err)
+	data, _ := io.ReadAll(reader)
+	_ = reader.Close()
+	assert.Equal(t, content, data)
+	assert.Equal(t, "text/plain", got.ContentType)
+
+	reader, _, err = c.GetObject(ctx, "docs", "a/b/hello.txt", trans.Ptr(int64(6)), trans.Ptr(int64(10)))
+	assert.NoError(t, err)
+	data, _ = io.ReadAll(reader)
+	_ = reader.Close()
+	assert.Equal(t, "local", string(data))
+
+	resp, err := c.DownloadFile(ctx, &storageV1.DownloadFileRequest{
+		Selector: &storageV1.DownloadFileRequest_StorageObject{
+			StorageObject: &storageV1.StorageObject{
+				BucketName: trans.Ptr("docs"),
+				ObjectName: trans.Ptr("a/b/hello.txt"),
+			},
+		},
+		RangeStart: trans.Ptr(int64(6)),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "local storage", string(resp.GetFile()))
+
+	assert.NoError(t, c.DeleteFile(ctx, "docs", "a/b/hello.txt"))
+	_, _, err = c.GetObject(ctx, "docs", "a/b/hello.txt", nil, nil)
+	assert.Error(t, err)
+}
+
+func TestLocalClient_UploadSizeMismatch(t *testing.T) {
+	c := createTestLocalClient(t, "")
+
+	_, _, _, err := c.UploadFile(context.Background(), "files", "short.bin", "", strings.NewReader("abc"), 10)
+	assert.Error(t, err)
+
+	objects, err := c.ListObjects(context.Background(), "files", "", true)
+	assert.NoError(t, err)
+	assert.Empty(t, objects)
+}
+
+func TestLocalClient_RejectsTraversal(t *testing.T) {
+	ctx := context.Background()
+	c := createTestLocalClient(t, "")
+
+	tests := []struct {
+		name   string
+		bucket string
+		object string
+		wantOK bool
+	}{
+		{"normal", "files", "x/y.txt", true},
+		{"dot dot is confined", "files", "../../etc/passwd", true},
+		{"bucket traversal", "..", "passwd", false},
+		{"hidden bucket", ".meta", "x.json", false},
+		{"bucket with slash", "a/b", "x", false},
+		{"empty object", "files", "", false},
+		{"backslash", "files", `..\x`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath, _, err := c.objectPath(tt.bucket, tt.object)
+			if !tt.wantOK {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, strings.HasPrefix(filePath, c.Root()))
+		})
+	}
+
+	_, _, _, err := c.UploadFile(ctx, "..", "x", "", strings.NewReader("x"), 1)
+	assert.Error(t, err)
+}
+
+func TestLocalClient_ListObjects(t *testing.T) {
+	ctx := context.Background()
+	c := createTestLocalClient(t, "")
+
+	for _, key := range []string{"a/1.txt", "a/2.txt", "a/sub/3.txt", "b.txt"} {
+		_, _, _, err := c.UploadFile(ctx, "docs", key, "text/plain", strings.NewReader("x"), 1)
+		assert.NoError(t, err)
+	}
+
+	objects, err := c.ListObjects(ctx, "docs", "a/", true)
+	assert.NoError(t, err)
+	var keys []string
+	for _, o := range objects {
+		keys = append(keys, o.Key)
+	}
+	assert.Equal(t, []string{"a/1.txt", "a/2.txt", "a/sub/3.txt"}, keys)
+
+	resp, err := c.ListFile(ctx, &storageV1.ListOssFileRequest{
+		BucketName: trans.Ptr("docs"),
+		Folder:     trans.Ptr("a/"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a/1.txt", "a/2.txt", "a/sub/"}, resp.GetFiles())
+}
+
+func TestLocalClient_SignedURLHandler(t *testing.T) {
+	ctx := context.Background()
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := createTestLocalClient(t, srv.URL)
+	mux.Handle(LocalRoutePrefix, c.Handler())
+
+	putUrl, err := c.PresignedPutObject(ctx, "images", "u/p.png", time.Minute)
+	assert.NoError(t, err)
+
+	req, _ := http.NewRequest(http.MethodPut, putUrl, strings.NewReader("0123456789"))
+	req.Header.Set("Content-Type", "image/png")
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	getUrl, err := c.PresignedGetObject(ctx, "images", "u/p.png", time.Minute)
+	assert.NoError(t, err)
+
+	req, _ = http.NewRequest(http.MethodGet, getUrl, nil)
+	req.Header.Set("Range", "bytes=2-4")
+	resp, err = http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusPartialContent, resp.StatusCode)
+	assert.Equal(t, "234", string(body))
+	assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+
+	// 签名用于 GET 的地址不能用于上传
+	req, _ = http.NewRequest(http.MethodPut, getUrl, strings.NewReader("evil"))
+	resp, err = http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	// 篡改对象键后签名失效
+	u, _ := url.Parse(getUrl)
+	u.Path = strings.Replace(u.Path, "p.png", "q.png", 1)
+	resp, err = http.Get(u.String())
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	// 过期地址被拒绝
+	q := u.Query()
+	expires := time.Now().Add(-time.Minute).Unix()
+	q.Set(localQueryExpires, strconv.FormatInt(expires, 10))
+	q.Set(localQuerySignature, c.sign(http.MethodGet, "images", "u/p.png", expires))
+	u.Path = strings.Replace(u.Path, "q.png", "p.png", 1)
+	u.RawQuery = q.Encode()
+	resp, err = http.Get(u.String())
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestLocalClient_RequiresSignKey(t *testing.T) {
+	_, err := NewLocalClient(&LocalOptions{Root: t.TempDir()}, log.DefaultLogger)
+	assert.Error(t, err)
+}
+
+func TestLocalClient_DownloadURLHandler(t *testing.T) {
+	ctx := context.Background()
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := createTestLocalClient(t, srv.URL)
+	mux.Handle(LocalRoutePrefix, c.Handler())
+
+	// UploadFile 返回的永久下载地址可直接访问
+	content := []byte("public content")
+	_, _, downloadUrl, err := c.UploadFile(ctx, "images", "u/a.png", "image/png", bytes.NewReader(content), int64(len(content)))
+	assert.NoError(t, err)
+
+	resp, err := http.Get(downloadUrl)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, content, body)
+
+	// 公共存储桶也不能不带签名上传
+	req, _ := http.NewRequest(http.MethodPut, downloadUrl, strings.NewReader("evil"))
+	resp, err = http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	// 带错误签名的请求不因存储桶公开而放行
+	resp, err = http.Get(downloadUrl + "?" + localQueryExpires + "=1&" + localQuerySignature + "=00")
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	// 隔离桶只能通过签名地址读取
+	_, _, downloadUrl, err = c.UploadFile(ctx, DefaultQuarantineBucket, "u/b.bin", "application/octet-stream", bytes.NewReader(content), int64(len(content)))
+	assert.NoError(t, err)
+
+	resp, err = http.Get(downloadUrl)
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestStorageRouter(t *testing.T) {
+	local := createTestLocalClient(t, "")
+
+	_, err := NewStorageRouterWithDrivers(storageV1.OSSProvider_MINIO, nil, log.DefaultLogger, local)
+	assert.Error(t, err)
+
+	r, err := NewStorageRouterWithDrivers(
+		storageV1.OSSProvider_LOCAL,
+		map[uint32]storageV1.OSSProvider{2: storageV1.OSSProvider_LOCAL},
+		log.DefaultLogger,
+		local,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, storageV1.OSSProvider_LOCAL, r.Default().Provider())
+	assert.Equal(t, storageV1.OSSProvider_LOCAL, r.ForTenant(1).Provider())
+	assert.Equal(t, storageV1.OSSProvider_LOCAL, r.ForTenant(2).Provider())
+	assert.NotNil(t, r.Local())
+
+	_, err = r.ForProvider(storageV1.OSSProvider_AWS)
+	assert.Error(t, err)
+
+	_, err = NewStorageRouterWithDrivers(
+		storageV1.OSSProvider_LOCAL,
+		map[uint32]storageV1.OSSProvider{3: storageV1.OSSProvider_MINIO},
+		log.DefaultLogger,
+		local,
+	)
+	assert.Error(t, err)
+}
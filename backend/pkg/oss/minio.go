@@ -48,6 +48,11 @@ func NewMinIoClient(cfg *conf.Bootstrap, logger log.Logger) *MinIOClient {
 	}
 }
 
+// Provider MinIO
+func (c *MinIOClient) Provider() storageV1.OSSProvider {
+	return storageV1.OSSProvider_MINIO
+}
+
 // GetClient returns the underlying MinIO client
 func (c *MinIOClient) GetClient() *minio.Client {
 	return c.mc
@@ -214,17 +219,17 @@ func (c *MinIOClient) UploadFile(
 	bucketName string, objectName string,
 	mimeType string,
 	reader io.Reader, objectSize int64,
-) (minio.UploadInfo, string, string, error) {
+) (ObjectInfo, string, string, error) {
 	if objectSize <= 0 {
 		c.log.Errorf("empty fileContent data")
-		return minio.UploadInfo{}, "", "", storageV1.ErrorUploadFailed("empty fileContent data")
+		return ObjectInfo{}, "", "", storageV1.ErrorUploadFailed("empty fileContent data")
 	}
 
 	// 大小硬限制：阻止超大文件占用存储。minio-go 按 objectSize 读取，
 	// 客户端无法绕过该声明值上传更多字节。
 	if objectSize > MaxUploadObjectSize {
 		c.log.Errorf("upload object too large: %d bytes (max %d)", objectSize, MaxUploadObjectSize)
-		return minio.UploadInfo{}, "", "", storageV1.ErrorUploadFailed("upload object exceeds max size limit")
+		return ObjectInfo{}, "", "", storageV1.ErrorUploadFailed("upload object exceeds max size limit")
 	}
 
 	if bucketName == "" {
@@ -244,7 +249,7 @@ func (c *MinIOClient) UploadFile(
 	}
 
 	if err := c.EnsureBucketExists(ctx, bucketName); err != nil {
-		return minio.UploadInfo{}, "", "", err
+		return ObjectInfo{}, "", "", err
 	}
 
 	// 以流式 reader 喂给 PutObject，minio-go 内部按 size 自动分片（multipart），
@@ -259,13 +264,102 @@ func (c *MinIOClient) UploadFile(
 	)
 	if err != nil {
 		c.log.Errorf("failed to upload fileContent: %v", err)
-		return info, "", "", storageV1.ErrorUploadFailed("failed to upload fileContent")
+		return ObjectInfo{}, "", "", storageV1.ErrorUploadFailed("failed to upload fileContent")
 	}
 
 	downloadUrl := JoinObjectUrl(c.conf.Minio.DownloadHost, bucketName, objectName)
 	storagePath := JoinObjectUrl("", bucketName, objectName)
 
-	return info, storagePath, downloadUrl, nil
+	return ObjectInfo{
+		Bucket:       info.Bucket,
+		Key:          info.Key,
+		Size:         info.Size,
+		ETag:         info.ETag,
+		ContentType:  mimeType,
+		Checksum:     info.ChecksumSHA256,
+		LastModified: info.LastModified,
+	}, storagePath, downloadUrl, nil
+}
+
+// GetObject 读取对象
+func (c *MinIOClient) GetObject(ctx context.Context, bucketName, objectName string, rangeStart, rangeEnd *int64) (io.ReadCloser, ObjectInfo, error) {
+	opts := minio.GetObjectOptions{}
+
+	SetDownloadRange(&opts, rangeStart, rangeEnd)
+
+	object, err := c.mc.GetObject(ctx, bucketName, objectName, opts)
+	if err != nil {
+		c.log.Errorf("failed to get object: %v", err)
+		return nil, ObjectInfo{}, storageV1.ErrorDownloadFailed("failed to get object")
+	}
+
+	st, err := object.Stat()
+	if err != nil {
+		_ = object.Close()
+		c.log.Errorf("failed to stat object: %v", err)
+		return nil, ObjectInfo{}, storageV1.ErrorDownloadFailed("failed to stat object")
+	}
+
+	return object, ObjectInfo{
+		Bucket:       bucketName,
+		Key:          st.Key,
+		Size:         st.Size,
+		ETag:         st.ETag,
+		ContentType:  st.ContentType,
+		Checksum:     st.ChecksumSHA256,
+		LastModified: st.LastModified,
+	}, nil
+}
+
+// ListObjects 列出指定前缀下的对象
+func (c *MinIOClient) ListObjects(ctx context.Context, bucketName, prefix string, recursive bool) ([]ObjectInfo, error) {
+	objects := make([]ObjectInfo, 0)
+	for object := range c.mc.ListObjects(ctx,
+		bucketName,
+		minio.ListObjectsOptions{
+			Prefix:    prefix,
+			Recursive: recursive,
+		},
+	) {
+		if object.Err != nil {
+			c.log.Errorf("failed to list objects: %v", object.Err)
+			return nil, storageV1.ErrorInternalServerError("failed to list objects")
+		}
+		objects = append(objects, ObjectInfo{
+			Bucket:       bucketName,
+			Key:          object.Key,
+			Size:         object.Size,
+			ETag:         object.ETag,
+			ContentType:  object.ContentType,
+			Checksum:     object.ChecksumSHA256,
+			LastModified: object.LastModified,
+		})
+	}
+	return objects, nil
+}
+
+// PresignedPutObject 生成预签名上传地址
+func (c *MinIOClient) PresignedPutObject(ctx context.Context, bucketName, objectName string, expiry time.Duration) (string, error) {
+	presignedURL, err := c.mc.PresignedPutObject(ctx, bucketName, objectName, expiry)
+	if err != nil {
+		c.log.Errorf("Failed to generate presigned PUT url: %v", err)
+		return "", storageV1.ErrorUploadFailed("failed to generate presigned PUT url")
+	}
+	return ReplaceEndpointHost(presignedURL.String(), c.conf.Minio.UploadHost, c.conf.Minio.Endpoint), nil
+}
+
+// PresignedGetObject 生成预签名下载地址
+func (c *MinIOClient) PresignedGetObject(ctx context.Context, bucketName, objectName string, expiry time.Duration) (string, error) {
+	presignedURL, err := c.mc.PresignedGetObject(ctx, bucketName, objectName, expiry, nil)
+	if err != nil {
+		c.log.Errorf("Failed to generate presigned GET url: %v", err)
+		return "", storageV1.ErrorDownloadFailed("failed to generate presigned GET url")
+	}
+	downloadUrl := ReplaceEndpointHost(presignedURL.String(), c.conf.Minio.DownloadHost, c.conf.Minio.Endpoint)
+	if !strings.HasPrefix(downloadUrl, presignedURL.Scheme) {
+		downloadUrl = presignedURL.Scheme + "://" + downloadUrl
+	}
+	return downloadUrl, nil
 }
 
 // getDownloadUrlWithStorageObjectDirect 直接获取文件内容
@@ -0,0 +1,179 @@
+package oss
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	conf "github.com/tx7do/kratos-bootstrap/api/gen/go/conf/v1"
+
+	storageV1 "go-wind-cms/api/gen/go/storage/service/v1"
+)
+
+// ObjectInfo 对象元信息，与具体存储驱动无关
+type ObjectInfo struct {
+	Bucket       string    // 存储桶
+	Key          string    // 对象键
+	Size         int64     // 对象大小（字节）
+	ETag         string    // 对象 ETag
+	ContentType  string    // MIME 类型
+	Checksum     string    // SHA256 校验和
+	LastModified time.Time // 最后修改时间
+}
+
+// Storage 对象存储驱动接口。
+// 业务层（FileTransferService、Lua oss 模块等）只依赖该接口，不再直接依赖 MinIO 客户端。
+type Storage interface {
+	// Provider 驱动对应的 OSS 供应商，写入文件记录的 provider 字段
+	Provider() storageV1.OSSProvider
+
+	// EnsureBucketExists 确保存储桶存在
+	EnsureBucketExists(ctx context.Context, bucketName string) error
+
+	// UploadFile 流式上传对象，返回对象信息、存储路径与下载地址
+	UploadFile(ctx context.Context, bucketName, objectName, mimeType string, reader io.Reader, objectSize int64) (ObjectInfo, string, string, error)
+
+	// GetObject 读取对象，rangeStart/rangeEnd 为可选的闭区间字节范围，语义同 SetDownloadRange
+	GetObject(ctx context.Context, bucketName, objectName string, rangeStart, rangeEnd *int64) (io.ReadCloser, ObjectInfo, error)
+
+	// DeleteFile 删除对象
+	DeleteFile(ctx context.Context, bucketName, objectName string) error
+
+	// ListObjects 列出指定前缀下的对象
+	ListObjects(ctx context.Context, bucketName, prefix string, recursive bool) ([]ObjectInfo, error)
+
+	// PresignedPutObject 生成预签名上传地址
+	PresignedPutObject(ctx context.Context, bucketName, objectName string, expiry time.Duration) (string, error)
+
+	// PresignedGetObject 生成预签名下载地址
+	PresignedGetObject(ctx context.Context, bucketName, objectName string, expiry time.Duration) (string, error)
+
+	// GetUploadPresignedUrl 获取上传地址
+	GetUploadPresignedUrl(ctx context.Context, req *storageV1.GetUploadPresignedUrlRequest) (*storageV1.GetUploadPresignedUrlResponse, error)
+
+	// ListFile 获取文件夹下面的文件列表
+	ListFile(ctx context.Context, req *storageV1.ListOssFileRequest) (*storageV1.ListOssFileResponse, error)
+
+	// GetDownloadUrl 获取下载地址
+	GetDownloadUrl(ctx context.Context, req *storageV1.GetDownloadInfoRequest) (*storageV1.GetDownloadInfoResponse, error)
+
+	// DownloadFile 下载文件
+	DownloadFile(ctx context.Context, req *storageV1.DownloadFileRequest) (*storageV1.DownloadFileResponse, error)
+}
+
+var (
+	_ Storage = (*MinIOClient)(nil)
+	_ Storage = (*LocalClient)(nil)
+)
+
+// RouterOptions 存储路由配置
+type RouterOptions struct {
+	// DefaultProvider 默认驱动，零值为 MinIO
+	DefaultProvider storageV1.OSSProvider
+
+	// TenantProviders 按租户指定驱动，未配置的租户使用默认驱动
+	TenantProviders map[uint32]storageV1.OSSProvider
+
+	// Local 本地文件系统驱动配置，为空时不启用
+	Local *LocalOptions
+}
+
+// StorageRouter 存储驱动路由，按租户选择驱动，按文件记录的 provider 还原驱动。
+type StorageRouter struct {
+	log *log.Helper
+
+	drivers         map[storageV1.OSSProvider]Storage
+	defaultProvider storageV1.OSSProvider
+	tenantProviders map[uint32]storageV1.OSSProvider
+}
+
+// NewStorageRouter 根据引导配置与路由配置创建存储路由。
+// oss.minio 配置存在时注册 MinIO 驱动，opts.Local 配置存在时注册本地驱动。
+func NewStorageRouter(cfg *conf.Bootstrap, opts *RouterOptions, logger log.Logger) (*StorageRouter, error) {
+	if opts == nil {
+		opts = &RouterOptions{}
+	}
+
+	var drivers []Storage
+	if cfg != nil && cfg.Oss != nil && cfg.Oss.Minio != nil {
+		drivers = append(drivers, NewMinIoClient(cfg, logger))
+	}
+	if opts.Local != nil {
+		lc, err := NewLocalClient(opts.Local, logger)
+		if err != nil {
+			return nil, err
+		}
+		drivers = append(drivers, lc)
+	}
+
+	return NewStorageRouterWithDrivers(opts.DefaultProvider, opts.TenantProviders, logger, drivers...)
+}
+
+// NewStorageRouterWithDrivers 使用已创建好的驱动创建存储路由
+func NewStorageRouterWithDrivers(
+	defaultProvider storageV1.OSSProvider,
+	tenantProviders map[uint32]storageV1.OSSProvider,
+	logger log.Logger,
+	drivers ...Storage,
+) (*StorageRouter, error) {
+	r := &StorageRouter{
+		log:             log.NewHelper(log.With(logger, "module", "storage-router/oss")),
+		drivers:         make(map[storageV1.OSSProvider]Storage, len(drivers)),
+		defaultProvider: defaultProvider,
+		tenantProviders: make(map[uint32]storageV1.OSSProvider, len(tenantProviders)),
+	}
+
+	for _, d := range drivers {
+		if d == nil {
+			continue
+		}
+		r.drivers[d.Provider()] = d
+	}
+
+	if _, ok := r.drivers[defaultProvider]; !ok {
+		return nil, fmt.Errorf("default storage provider [%s] is not configured", defaultProvider.String())
+	}
+
+	for tenantID, provider := range tenantProviders {
+		if _, ok := r.drivers[provider]; !ok {
+			return nil, fmt.Errorf("storage provider [%s] of tenant [%d] is not configured", provider.String(), tenantID)
+		}
+		r.tenantProviders[tenantID] = provider
+	}
+
+	return r, nil
+}
+
+// Default 返回默认驱动
+func (r *StorageRouter) Default() Storage {
+	return r.drivers[r.defaultProvider]
+}
+
+// ForTenant 返回租户使用的驱动
+func (r *StorageRouter) ForTenant(tenantID uint32) Storage {
+	if provider, ok := r.tenantProviders[tenantID]; ok {
+		return r.drivers[provider]
+	}
+	return r.Default()
+}
+
+// ForProvider 按供应商返回驱动，用于操作已落库的文件
+func (r *StorageRouter) ForProvider(provider storageV1.OSSProvider) (Storage, error) {
+	if d, ok := r.drivers[provider]; ok {
+		return d, nil
+	}
+	r.log.Errorf("storage provider [%s] is not configured", provider.String())
+	return nil, storageV1.ErrorNotImplemented("storage provider [%s] is not configured", provider.String())
+}
+
+// Local 返回本地文件系统驱动，未启用时返回 nil
+func (r *StorageRouter) Local() *LocalClient {
+	if d, ok := r.drivers[storageV1.OSSProvider_LOCAL]; ok {
+		lc, _ := d.(*LocalClient)
+		return lc
+	}
+	return nil
+}
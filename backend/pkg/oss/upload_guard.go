@@ -0,0 +1,265 @@
+package oss
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	storageV1 "go-wind-cms/api/gen/go/storage/service/v1"
+
+	"go-wind-cms/pkg/scanner"
+)
+
+// DefaultQuarantineBucket 默认隔离桶
+const DefaultQuarantineBucket = "quarantine"
+
+// UploadPolicy 上传策略
+type UploadPolicy struct {
+	// AllowedMimeTypes MIME 白名单，支持 image/* 通配，为空时不限制
+	AllowedMimeTypes []string
+
+	// MaxSize 单文件大小上限（字节），为 0 时使用 MaxUploadObjectSize
+	MaxSize int64
+}
+
+// Limit 返回生效的大小上限，不超过 MaxUploadObjectSize
+func (p *UploadPolicy) Limit() int64 {
+	if p == nil || p.MaxSize <= 0 || p.MaxSize > MaxUploadObjectSize {
+		return MaxUploadObjectSize
+	}
+	return p.MaxSize
+}
+
+// AllowsMime 判断 MIME 是否在白名单内
+func (p *UploadPolicy) AllowsMime(mimeType string) bool {
+	if p == nil || len(p.AllowedMimeTypes) == 0 {
+		return true
+	}
+
+	mt := normalizeMimeType(mimeType)
+	if mt == "" {
+		return false
+	}
+
+	for _, allowed := range p.AllowedMimeTypes {
+		allowed = normalizeMimeType(allowed)
+		switch {
+		case allowed == "*/*" || allowed == mt:
+			return true
+		case strings.HasSuffix(allowed, "/*") && strings.HasPrefix(mt, strings.TrimSuffix(allowed, "*")):
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeMimeType 去除参数（如 charset）并转为小写
+func normalizeMimeType(mimeType string) string {
+	mt, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		mt = strings.TrimSpace(mimeType)
+	}
+	return strings.ToLower(mt)
+}
+
+// isGenericMimeType 嗅探结果过于笼统，无法用来判断真实类型
+func isGenericMimeType(mimeType string) bool {
+	switch mimeType {
+	case "", DefaultContentType, "text/plain", "text/xml":
+		return true
+	default:
+		return false
+	}
+}
+
+// majorMimeType 返回 MIME 的主类型，例如 image/png 返回 image
+func majorMimeType(mimeType string) string {
+	major, _, _ := strings.Cut(mimeType, "/")
+	return major
+}
+
+// UploadGuardOptions 上传检查配置
+type UploadGuardOptions struct {
+	// DefaultPolicy 默认上传策略
+	DefaultPolicy *UploadPolicy
+
+	// TenantPolicies 按租户指定上传策略，未配置的租户使用默认策略
+	TenantPolicies map[uint32]*UploadPolicy
+
+	// Scanner 扫描器，为空时不扫描
+	Scanner scanner.Scanner
+
+	// FailOpen 扫描引擎不可用时是否放行
+	FailOpen bool
+
+	// QuarantineBucket 隔离桶，为空时使用 DefaultQuarantineBucket
+	QuarantineBucket string
+}
+
+// UploadGuard 上传前置检查：按租户策略校验 MIME 与大小，并扫描恶意内容
+type UploadGuard struct {
+	log *log.Helper
+
+	defaultPolicy    *UploadPolicy
+	tenantPolicies   map[uint32]*UploadPolicy
+	scanner          scanner.Scanner
+	failOpen         bool
+	quarantineBucket string
+}
+
+func NewUploadGuard(opts *UploadGuardOptions, logger log.Logger) *UploadGuard {
+	if opts == nil {
+		opts = &UploadGuardOptions{}
+	}
+
+	g := &UploadGuard{
+		log:              log.NewHelper(log.With(logger, "module", "upload-guard/oss")),
+		defaultPolicy:    opts.DefaultPolicy,
+		tenantPolicies:   opts.TenantPolicies,
+		scanner:          opts.Scanner,
+		failOpen:         opts.FailOpen,
+		quarantineBucket: opts.QuarantineBucket,
+	}
+	if g.quarantineBucket == "" {
+		g.quarantineBucket = DefaultQuarantineBucket
+	}
+	return g
+}
+
+// PolicyFor 返回租户生效的上传策略
+func (g *UploadGuard) PolicyFor(tenantID uint32) *UploadPolicy {
+	if p, ok := g.tenantPolicies[tenantID]; ok && p != nil {
+		return p
+	}
+	return g.defaultPolicy
+}
+
+// QuarantineBucket 返回隔离桶名称
+func (g *UploadGuard) QuarantineBucket() string {
+	return g.quarantineBucket
+}
+
+// InspectedUpload 已通过检查的上传内容，内容已落盘到临时文件，使用完毕须调用 Close
+type InspectedUpload struct {
+	file *scanner.SpooledFile
+
+	// MimeType 生效的 MIME，声明为空时取嗅探结果
+	MimeType string
+
+	// Infected 是否检出恶意内容，为 true 时调用方须转存隔离桶而不是正常入库
+	Infected bool
+
+	// Signature 命中的特征名
+	Signature string
+}
+
+// Reader 返回上传内容
+func (u *InspectedUpload) Reader() io.Reader {
+	return u.file
+}
+
+// Size 返回上传内容的字节数
+func (u *InspectedUpload) Size() int64 {
+	return u.file.Size()
+}
+
+// Close 删除临时文件
+func (u *InspectedUpload) Close() error {
+	return u.file.Close()
+}
+
+// Inspect 检查上传内容：
+// 1. 按租户策略校验声明的大小与 MIME；
+// 2. 扫描内容并落盘，扫描引擎不可用时按 FailOpen 决定放行或拒绝；
+// 3. 嗅探真实类型，防止以允许的 MIME 伪装上传其他类型的文件。
+func (g *UploadGuard) Inspect(ctx context.Context, tenantID uint32, mimeType string, reader io.Reader, size int64) (*InspectedUpload, error) {
+	policy := g.PolicyFor(tenantID)
+
+	if size > policy.Limit() {
+		return nil, storageV1.ErrorFileTooLarge("file size %d exceeds the limit of %d bytes", size, policy.Limit())
+	}
+
+	declared := normalizeMimeType(mimeType)
+	if declared != "" && !policy.AllowsMime(declared) {
+		return nil, storageV1.ErrorUnsupportedMediaType("mime type [%s] is not allowed", declared)
+	}
+
+	result, spooled, err := scanner.ScanToTempFile(ctx, g.scanner, reader, size)
+	if spooled == nil {
+		g.log.Errorf("failed to spool upload: %v", err)
+		return nil, storageV1.ErrorUploadFailed("failed to read upload content")
+	}
+	if err != nil {
+		if !g.failOpen {
+			_ = spooled.Close()
+			g.log.Errorf("upload scan failed: %v", err)
+			if errors.Is(err, scanner.ErrScannerUnavailable) {
+				return nil, storageV1.ErrorServiceUnavailable("upload scanner is unavailable")
+			}
+			return nil, storageV1.ErrorUploadFailed("upload scan failed")
+		}
+		g.log.Warnf("upload scan failed, fail open: %v", err)
+	}
+
+	if spooled.Size() != size {
+		_ = spooled.Close()
+		return nil, storageV1.ErrorUploadFailed("upload size mismatch: declared %d, got %d", size, spooled.Size())
+	}
+
+	upload := &InspectedUpload{file: spooled, MimeType: declared}
+
+	if result != nil && result.Infected {
+		upload.Infected = true
+		upload.Signature = result.Signature
+		g.log.Warnf("infected upload detected: tenant [%d] engine [%s] signature [%s]", tenantID, result.Engine, result.Signature)
+		return upload, nil
+	}
+
+	head := make([]byte, 512)
+	n, err := spooled.ReadAt(head, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		_ = upload.Close()
+		return nil, storageV1.ErrorUploadFailed("failed to read upload content")
+	}
+	sniffed, _ := DetectFileType(head[:n])
+	sniffed = normalizeMimeType(sniffed)
+
+	if upload.MimeType == "" {
+		upload.MimeType = sniffed
+	}
+
+	// 嗅探结果与声明的主类型一致时以声明为准（如 docx 嗅探为 application/zip），
+	// 不一致且嗅探结果明确时，真实类型也必须在白名单内
+	if !isGenericMimeType(sniffed) &&
+		majorMimeType(sniffed) != majorMimeType(declared) &&
+		!policy.AllowsMime(sniffed) {
+		_ = upload.Close()
+		return nil, storageV1.ErrorUnsupportedMediaType("content type [%s] does not match declared mime type [%s]", sniffed, declared)
+	}
+
+	return upload, nil
+}
+
+// Quarantine 将检出恶意内容的文件转存至隔离桶，隔离文件不对外提供下载地址。
+// objectName 为空时按源文件名生成：驱动在对象名为空时会改用按类型划分的公共存储桶，不能交给驱动处理。
+func (g *UploadGuard) Quarantine(ctx context.Context, driver Storage, objectName, sourceFileName string, upload *InspectedUpload) (ObjectInfo, string, error) {
+	if objectName == "" {
+		objectName = EnsureObjectName("", sourceFileName, upload.MimeType, GenerateFileNameTypeUUID)
+	}
+
+	info, storagePath, _, err := driver.UploadFile(
+		ctx,
+		g.quarantineBucket, objectName,
+		DefaultContentType,
+		upload.Reader(), upload.Size(),
+	)
+	if err != nil {
+		g.log.Errorf("failed to quarantine upload [%s]: %v", objectName, err)
+		return ObjectInfo{}, "", err
+	}
+	return info, storagePath, nil
+}
@@ -0,0 +1,159 @@
+package oss
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+
+	"go-wind-cms/pkg/scanner"
+)
+
+type stubScanner struct {
+	signature string
+	err       error
+}
+
+func (s *stubScanner) Name() string { return "stub" }
+
+func (s *stubScanner) Scan(_ context.Context, reader io.Reader) (*scanner.Result, error) {
+	data, _ := io.ReadAll(reader)
+	if s.err != nil {
+		return nil, s.err
+	}
+	if s.signature != "" && strings.Contains(string(data), "EICAR") {
+		return &scanner.Result{Infected: true, Signature: s.signature, Engine: "stub"}, nil
+	}
+	return &scanner.Result{Engine: "stub"}, nil
+}
+
+func TestUploadPolicy_AllowsMime(t *testing.T) {
+	p := &UploadPolicy{AllowedMimeTypes: []string{"image/*", "application/pdf"}}
+
+	tests := []struct {
+		mime string
+		want bool
+	}{
+		{"image/png", true},
+		{"IMAGE/JPEG", true},
+		{"application/pdf", true},
+		{"text/plain; charset=utf-8", false},
+		{"application/pdfx", false},
+		{"imagex/png", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mime, func(t *testing.T) {
+			assert.Equal(t, tt.want, p.AllowsMime(tt.mime))
+		})
+	}
+
+	var empty *UploadPolicy
+	assert.True(t, empty.AllowsMime("application/x-anything"))
+	assert.Equal(t, MaxUploadObjectSize, empty.Limit())
+}
+
+func TestUploadGuard_Inspect(t *testing.T) {
+	png := "\x89PNG\r\n\x1a\n" + strings.Repeat("x", 32)
+	pdf := "%PDF-1.7 " + strings.Repeat("x", 32)
+
+	guard := NewUploadGuard(&UploadGuardOptions{
+		DefaultPolicy: &UploadPolicy{AllowedMimeTypes: []string{"image/*", "text/plain"}, MaxSize: 1024},
+		TenantPolicies: map[uint32]*UploadPolicy{
+			2: {AllowedMimeTypes: []string{"application/pdf"}, MaxSize: 16},
+		},
+		Scanner: &stubScanner{signature: "Eicar-Test-Signature"},
+	}, log.DefaultLogger)
+
+	tests := []struct {
+		name         string
+		tenantID     uint32
+		mime         string
+		content      string
+		wantErr      bool
+		wantInfected bool
+	}{
+		{"allowed image", 1, "image/png", png, false, false},
+		{"mime not allowed", 1, "application/pdf", pdf, true, false},
+		{"disguised pdf", 1, "image/png", pdf, true, false},
+		{"plain text", 1, "text/plain", "hello", false, false},
+		{"too large for tenant", 2, "application/pdf", pdf, true, false},
+		{"tenant policy", 2, "application/pdf", "%PDF-1.7", false, false},
+		{"infected", 1, "text/plain", "xx EICAR xx", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upload, err := guard.Inspect(context.Background(), tt.tenantID, tt.mime, strings.NewReader(tt.content), int64(len(tt.content)))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			defer upload.Close()
+
+			assert.Equal(t, tt.wantInfected, upload.Infected)
+			assert.Equal(t, int64(len(tt.content)), upload.Size())
+			data, _ := io.ReadAll(upload.Reader())
+			assert.Equal(t, tt.content, string(data))
+		})
+	}
+}
+
+func TestUploadGuard_ScannerUnavailable(t *testing.T) {
+	down := &stubScanner{err: scanner.ErrScannerUnavailable}
+
+	closed := NewUploadGuard(&UploadGuardOptions{Scanner: down}, log.DefaultLogger)
+	_, err := closed.Inspect(context.Background(), 1, "text/plain", strings.NewReader("abc"), 3)
+	assert.Error(t, err)
+
+	open := NewUploadGuard(&UploadGuardOptions{Scanner: down, FailOpen: true}, log.DefaultLogger)
+	upload, err := open.Inspect(context.Background(), 1, "text/plain", strings.NewReader("abc"), 3)
+	assert.NoError(t, err)
+	assert.False(t, upload.Infected)
+	_ = upload.Close()
+
+	_, err = open.Inspect(context.Background(), 1, "text/plain", strings.NewReader("ab"), 3)
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, scanner.ErrScannerUnavailable))
+}
+
+func TestUploadGuard_Quarantine(t *testing.T) {
+	ctx := context.Background()
+	local := createTestLocalClient(t, "")
+	guard := NewUploadGuard(&UploadGuardOptions{Scanner: &stubScanner{signature: "Eicar-Test-Signature"}}, log.DefaultLogger)
+
+	content := "EICAR payload"
+	upload, err := guard.Inspect(ctx, 1, "image/png", strings.NewReader(content), int64(len(content)))
+	assert.NoError(t, err)
+	defer upload.Close()
+	assert.True(t, upload.Infected)
+	assert.Equal(t, "Eicar-Test-Signature", upload.Signature)
+
+	info, storagePath, err := guard.Quarantine(ctx, local, "u/evil.png", "evil.png", upload)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultQuarantineBucket, info.Bucket)
+	assert.Equal(t, "/quarantine/u/evil.png", storagePath)
+
+	reader, got, err := local.GetObject(ctx, DefaultQuarantineBucket, "u/evil.png", nil, nil)
+	assert.NoError(t, err)
+	data, _ := io.ReadAll(reader)
+	_ = reader.Close()
+	assert.Equal(t, content, string(data))
+	assert.Equal(t, DefaultContentType, got.ContentType)
+
+	// 未指定对象名时仍存入隔离桶
+	upload, err = guard.Inspect(ctx, 1, "image/png", strings.NewReader(content), int64(len(content)))
+	assert.NoError(t, err)
+	defer upload.Close()
+
+	info, _, err = guard.Quarantine(ctx, local, "", "evil.png", upload)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultQuarantineBucket, info.Bucket)
+	assert.True(t, strings.HasSuffix(info.Key, ".png"))
+}
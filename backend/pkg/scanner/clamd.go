@@ -0,0 +1,200 @@
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	defaultClamdTimeout   = 30 * time.Second
+	defaultClamdChunkSize = 64 * 1024
+
+	clamdEngineName = "clamav"
+)
+
+// ClamdOption ClamdScanner 选项
+type ClamdOption func(*ClamdScanner)
+
+// WithClamdTimeout 设置单次扫描（连接 + 传输 + 等待结果）的超时时间
+func WithClamdTimeout(timeout time.Duration) ClamdOption {
+	return func(c *ClamdScanner) {
+		if timeout > 0 {
+			c.timeout = timeout
+		}
+	}
+}
+
+// WithClamdChunkSize 设置 INSTREAM 分片大小，不能超过 clamd 的 StreamMaxLength
+func WithClamdChunkSize(size int) ClamdOption {
+	return func(c *ClamdScanner) {
+		if size > 0 {
+			c.chunkSize = size
+		}
+	}
+}
+
+// ClamdScanner 基于 clamd INSTREAM 协议的扫描器。
+// 协议：发送 "zINSTREAM\0"，随后若干 [4 字节大端长度][数据] 分片，以长度为 0 的分片结束，
+// clamd 返回 "stream: OK" 或 "stream: <特征名> FOUND" 或 "<原因> ERROR"。
+type ClamdScanner struct {
+	network   string // tcp 或 unix
+	address   string // 127.0.0.1:3310 或 /var/run/clamav/clamd.ctl
+	timeout   time.Duration
+	chunkSize int
+}
+
+func NewClamdScanner(network, address string, opts ...ClamdOption) *ClamdScanner {
+	if network == "" {
+		network = "tcp"
+	}
+	c := &ClamdScanner{
+		network:   network,
+		address:   address,
+		timeout:   defaultClamdTimeout,
+		chunkSize: defaultClamdChunkSize,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *ClamdScanner) Name() string { return clamdEngineName }
+
+func (c *ClamdScanner) dial(ctx context.Context) (net.Conn, error) {
+	deadline := time.Now().Add(c.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+
+	dialer := net.Dialer{Deadline: deadline}
+	conn, err := dialer.DialContext(ctx, c.network, c.address)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrScannerUnavailable, err)
+	}
+	_ = conn.SetDeadline(deadline)
+	return conn, nil
+}
+
+// Ping 检查 clamd 是否可用
+func (c *ClamdScanner) Ping(ctx context.Context) error {
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err = conn.Write([]byte("zPING\x00")); err != nil {
+		return fmt.Errorf("%w: %v", ErrScannerUnavailable, err)
+	}
+
+	reply, err := readClamdReply(conn)
+	if err != nil {
+		return err
+	}
+	if reply != "PONG" {
+		return fmt.Errorf("unexpected clamd ping reply: %q", reply)
+	}
+	return nil
+}
+
+// Scan 以 INSTREAM 方式扫描内容
+func (c *ClamdScanner) Scan(ctx context.Context, reader io.Reader) (*Result, error) {
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if _, err = conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrScannerUnavailable, err)
+	}
+
+	if writeErr := c.writeStream(conn, reader); writeErr != nil {
+		// clamd 在超出 StreamMaxLength 时会先回复错误再断开，优先返回其回复
+		if reply, err := readClamdReply(conn); err == nil && reply != "" {
+			return parseClamdReply(reply)
+		}
+		return nil, writeErr
+	}
+
+	reply, err := readClamdReply(conn)
+	if err != nil {
+		return nil, err
+	}
+	return parseClamdReply(reply)
+}
+
+// writeStream 按分片写入内容并以零长度分片结束
+func (c *ClamdScanner) writeStream(conn net.Conn, reader io.Reader) error {
+	buf := make([]byte, c.chunkSize)
+	var header [4]byte
+
+	for {
+		n, readErr := reader.Read(buf)
+		if n > 0 {
+			binary.BigEndian.PutUint32(header[:], uint32(n))
+			if _, err := conn.Write(header[:]); err != nil {
+				return fmt.Errorf("%w: %v", ErrScannerUnavailable, err)
+			}
+			if _, err := conn.Write(buf[:n]); err != nil {
+				return fmt.Errorf("%w: %v", ErrScannerUnavailable, err)
+			}
+		}
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			return readErr
+		}
+	}
+
+	binary.BigEndian.PutUint32(header[:], 0)
+	if _, err := conn.Write(header[:]); err != nil {
+		return fmt.Errorf("%w: %v", ErrScannerUnavailable, err)
+	}
+	return nil
+}
+
+// readClamdReply 读取以 \0 结尾的回复
+func readClamdReply(conn net.Conn) (string, error) {
+	reply, err := bufio.NewReader(conn).ReadString('\x00')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("%w: %v", ErrScannerUnavailable, err)
+	}
+	reply = strings.TrimSpace(strings.TrimSuffix(reply, "\x00"))
+	if reply == "" && err != nil {
+		return "", fmt.Errorf("%w: empty reply", ErrScannerUnavailable)
+	}
+	return reply, nil
+}
+
+// parseClamdReply 解析扫描结果
+func parseClamdReply(reply string) (*Result, error) {
+	reply = strings.TrimPrefix(reply, "stream: ")
+
+	switch {
+	case reply == "OK":
+		return &Result{Engine: clamdEngineName}, nil
+
+	case strings.HasSuffix(reply, " FOUND"):
+		return &Result{
+			Infected:  true,
+			Signature: strings.TrimSuffix(reply, " FOUND"),
+			Engine:    clamdEngineName,
+		}, nil
+
+	case strings.HasSuffix(reply, " ERROR"):
+		return nil, fmt.Errorf("clamd error: %s", strings.TrimSuffix(reply, " ERROR"))
+
+	default:
+		return nil, fmt.Errorf("unexpected clamd reply: %q", reply)
+	}
+}
@@ -0,0 +1,196 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const eicar = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// fakeClamd 在 unix socket 上模拟 clamd 的 PING 与 INSTREAM 命令
+type fakeClamd struct {
+	ln        net.Listener
+	maxStream int
+	received  chan []byte
+}
+
+func newFakeClamd(t *testing.T, maxStream int) *fakeClamd {
+	t.Helper()
+	ln, err := net.Listen("unix", filepath.Join(t.TempDir(), "clamd.sock"))
+	assert.NoError(t, err)
+
+	f := &fakeClamd{ln: ln, maxStream: maxStream, received: make(chan []byte, 16)}
+	go f.serve()
+	t.Cleanup(func() { _ = ln.Close() })
+	return f
+}
+
+func (f *fakeClamd) serve() {
+	for {
+		conn, err := f.ln.Accept()
+		if err != nil {
+			return
+		}
+		go f.handle(conn)
+	}
+}
+
+func (f *fakeClamd) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+
+	cmd, err := r.ReadString('\x00')
+	if err != nil {
+		return
+	}
+
+	switch strings.TrimSuffix(cmd, "\x00") {
+	case "zPING":
+		_, _ = conn.Write([]byte("PONG\x00"))
+
+	case "zINSTREAM":
+		var data bytes.Buffer
+		for {
+			var size uint32
+			if err = binary.Read(r, binary.BigEndian, &size); err != nil {
+				return
+			}
+			if size == 0 {
+				break
+			}
+			if f.maxStream > 0 && data.Len()+int(size) > f.maxStream {
+				_, _ = conn.Write([]byte("INSTREAM size limit exceeded. ERROR\x00"))
+				return
+			}
+			if _, err = io.CopyN(&data, r, int64(size)); err != nil {
+				return
+			}
+		}
+		f.received <- data.Bytes()
+
+		if bytes.Contains(data.Bytes(), []byte(eicar)) {
+			_, _ = conn.Write([]byte("stream: Eicar-Test-Signature FOUND\x00"))
+		} else {
+			_, _ = conn.Write([]byte("stream: OK\x00"))
+		}
+
+	default:
+		_, _ = conn.Write([]byte("UNKNOWN COMMAND\x00"))
+	}
+}
+
+func TestClamdScanner_Scan(t *testing.T) {
+	fake := newFakeClamd(t, 0)
+	s := NewClamdScanner("unix", fake.ln.Addr().String(), WithClamdChunkSize(7))
+
+	tests := []struct {
+		name         string
+		content      string
+		wantInfected bool
+		wantSig      string
+	}{
+		{"clean", "hello world, this is a clean file", false, ""},
+		{"eicar", eicar, true, "Eicar-Test-Signature"},
+		{"eicar embedded", "prefix " + eicar + " suffix", true, "Eicar-Test-Signature"},
+		{"empty", "", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := s.Scan(context.Background(), strings.NewReader(tt.content))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantInfected, result.Infected)
+			assert.Equal(t, tt.wantSig, result.Signature)
+			assert.Equal(t, "clamav", result.Engine)
+			// 分片后在服务端重新拼接的内容应与原文一致
+			assert.Equal(t, tt.content, string(<-fake.received))
+		})
+	}
+}
+
+func TestClamdScanner_Ping(t *testing.T) {
+	fake := newFakeClamd(t, 0)
+	assert.NoError(t, NewClamdScanner("unix", fake.ln.Addr().String()).Ping(context.Background()))
+}
+
+func TestClamdScanner_SizeLimitError(t *testing.T) {
+	fake := newFakeClamd(t, 16)
+	s := NewClamdScanner("unix", fake.ln.Addr().String(), WithClamdChunkSize(8))
+
+	_, err := s.Scan(context.Background(), strings.NewReader(strings.Repeat("a", 64)))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "size limit exceeded")
+}
+
+func TestClamdScanner_Unavailable(t *testing.T) {
+	s := NewClamdScanner("unix", filepath.Join(t.TempDir(), "missing.sock"), WithClamdTimeout(time.Second))
+
+	_, err := s.Scan(context.Background(), strings.NewReader("x"))
+	assert.True(t, errors.Is(err, ErrScannerUnavailable))
+}
+
+func TestParseClamdReply(t *testing.T) {
+	tests := []struct {
+		reply        string
+		wantErr      bool
+		wantInfected bool
+		wantSig      string
+	}{
+		{"stream: OK", false, false, ""},
+		{"stream: Win.Test.EICAR_HDB-1 FOUND", false, true, "Win.Test.EICAR_HDB-1"},
+		{"INSTREAM size limit exceeded. ERROR", true, false, ""},
+		{"garbage", true, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reply, func(t *testing.T) {
+			result, err := parseClamdReply(tt.reply)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantInfected, result.Infected)
+			assert.Equal(t, tt.wantSig, result.Signature)
+		})
+	}
+}
+
+func TestScanToTempFile(t *testing.T) {
+	fake := newFakeClamd(t, 0)
+	s := NewClamdScanner("unix", fake.ln.Addr().String())
+
+	content := "prefix " + eicar
+	result, spooled, err := ScanToTempFile(context.Background(), s, strings.NewReader(content), int64(len(content)))
+	assert.NoError(t, err)
+	assert.True(t, result.Infected)
+	<-fake.received
+
+	data, _ := io.ReadAll(spooled)
+	assert.Equal(t, content, string(data))
+	assert.Equal(t, int64(len(content)), spooled.Size())
+
+	name := spooled.Name()
+	assert.NoError(t, spooled.Close())
+	_, statErr := filepath.Glob(name)
+	assert.NoError(t, statErr)
+
+	// 扫描引擎不可用时仍完整落盘，由调用方决定是否放行
+	down := NewClamdScanner("unix", filepath.Join(t.TempDir(), "missing.sock"))
+	_, spooled, err = ScanToTempFile(context.Background(), down, strings.NewReader("abc"), 3)
+	assert.True(t, errors.Is(err, ErrScannerUnavailable))
+	data, _ = io.ReadAll(spooled)
+	assert.Equal(t, "abc", string(data))
+	_ = spooled.Close()
+}
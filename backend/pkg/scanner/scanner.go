@@ -0,0 +1,94 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+)
+
+// ErrScannerUnavailable 扫描引擎不可用（连接失败、超时等），由调用方决定放行还是拒绝
+var ErrScannerUnavailable = errors.New("scanner unavailable")
+
+// Result 扫描结果
+type Result struct {
+	Infected  bool   // 是否检出恶意内容
+	Signature string // 命中的特征名，例如 Eicar-Test-Signature
+	Engine    string // 扫描引擎名称
+}
+
+// Scanner 上传内容扫描器
+type Scanner interface {
+	// Name 扫描引擎名称
+	Name() string
+
+	// Scan 扫描 reader 中的全部内容
+	Scan(ctx context.Context, reader io.Reader) (*Result, error)
+}
+
+// NopScanner 不做任何扫描，始终返回未感染
+type NopScanner struct{}
+
+func (NopScanner) Name() string { return "nop" }
+
+func (NopScanner) Scan(_ context.Context, reader io.Reader) (*Result, error) {
+	if _, err := io.Copy(io.Discard, reader); err != nil {
+		return nil, err
+	}
+	return &Result{Engine: "nop"}, nil
+}
+
+// SpooledFile 扫描时落盘的临时文件，Close 时一并删除
+type SpooledFile struct {
+	*os.File
+	size int64
+}
+
+// Size 已写入的字节数
+func (f *SpooledFile) Size() int64 {
+	return f.size
+}
+
+// Close 关闭并删除临时文件
+func (f *SpooledFile) Close() error {
+	err := f.File.Close()
+	_ = os.Remove(f.Name())
+	return err
+}
+
+// ScanToTempFile 边扫描边将内容写入临时文件，扫描结束后文件指针回到起始位置，
+// 供后续上传使用，避免上传流只能读取一次导致需要整文件载入内存。
+// 扫描失败时仍返回已完整落盘的文件与错误，由调用方决定是否放行。
+func ScanToTempFile(ctx context.Context, s Scanner, reader io.Reader, size int64) (*Result, *SpooledFile, error) {
+	tmp, err := os.CreateTemp("", "upload-scan-*")
+	if err != nil {
+		return nil, nil, err
+	}
+	spooled := &SpooledFile{File: tmp}
+
+	src := io.LimitReader(reader, size)
+	tee := io.TeeReader(src, tmp)
+
+	var result *Result
+	var scanErr error
+	if s != nil {
+		result, scanErr = s.Scan(ctx, tee)
+	}
+
+	// 扫描引擎可能提前结束读取（如超出 clamd StreamMaxLength），补齐剩余内容
+	if _, err = io.Copy(io.Discard, tee); err != nil {
+		_ = spooled.Close()
+		return nil, nil, err
+	}
+
+	if spooled.size, err = tmp.Seek(0, io.SeekCurrent); err != nil {
+		_ = spooled.Close()
+		return nil, nil, err
+	}
+	if _, err = tmp.Seek(0, io.SeekStart); err != nil {
+		_ = spooled.Close()
+		return nil, nil, err
+	}
+
+	return result, spooled, scanErr
+}
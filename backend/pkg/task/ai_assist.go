@@ -0,0 +1,24 @@
+package task
+
+// ============================================================================
+// AI 内容辅助任务类型定义
+//
+// 编辑在后台创建 AI 辅助作业，或开启自动摘要的文章保存正文后，入队一个 ai.assist 任务，
+// asynq worker 收到后调用租户配置的大语言模型生成摘要、SEO 描述、推荐标签与备选标题，
+// 按作业配置应用到内容或作为建议记录在作业上，并把 Token 用量计入租户台账。
+//
+// 安全：
+//   - payload 只含作业 id，内容由 worker 从 DB 取（带 SystemViewer 跨租户读），
+//     供应商、用量上限与台账的租户均取自作业记录，非 payload
+//   - 作业以条件更新从 pending 认领，重复入队不会重复调用模型
+// ============================================================================
+
+const (
+	// AiAssistTaskType AI 内容辅助任务的 asynq 任务类型。
+	AiAssistTaskType = "ai.assist"
+)
+
+// AiAssistPayload AI 内容辅助任务的 payload。
+type AiAssistPayload struct {
+	JobID uint32 `json:"job_id"`
+}
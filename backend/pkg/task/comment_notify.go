@@ -0,0 +1,26 @@
+package task
+
+// ============================================================================
+// 评论通知任务类型定义
+//
+// 评论通过审核（创建即通过、后台改判或批量通过）后入队一个 comment.notify 任务，
+// asynq worker 收到后解析接收人（被回复者、文章作者、收藏者），按各自的通知偏好
+// 写入站内信、发布 SSE 推送并发送邮件。
+//
+// 安全：
+//   - payload 只含评论 id，接收人与内容由 worker 从 DB 取（带 SystemViewer 跨租户读）
+//   - 同一评论只通知一次（Redis 标记），重复入队或重复改判不会重复打扰用户
+// ============================================================================
+
+const (
+	// CommentNotifyTaskType 评论通知任务的 asynq 任务类型。
+	CommentNotifyTaskType = "comment.notify"
+)
+
+// CommentNotifyPayload 评论通知任务的 payload。
+//
+// TenantID 仅供日志展示，worker 以 DB 记录的 tenant_id 为准。
+type CommentNotifyPayload struct {
+	CommentID uint32 `json:"comment_id"`
+	TenantID  uint32 `json:"tenant_id"`
+}
@@ -0,0 +1,25 @@
+package task
+
+// ============================================================================
+// 内容导入任务类型定义
+//
+// 管理员上传 WordPress 导出文件（WXR）或 Markdown 归档并创建导入作业后入队一个 content.import 任务，
+// asynq worker 收到后解析导入文件，下载附件存入对象存储，改写站内链接，
+// 写入分类、标签、文章、页面与评论，并在作业上记录进度与逐条报告；试运行时只生成报告。
+//
+// 安全：
+//   - payload 只含作业 id，导入文件与选项由 worker 从 DB 取（带 SystemViewer 跨租户读），
+//     导入文件与写入内容的 tenant_id 均取自作业记录，非 payload
+//   - 附件地址来自不可信的导入文件，经 SSRF 防护的下载器取回，并经上传检查（类型白名单、病毒扫描）
+//   - 作业以条件更新从 pending 认领，重复入队不会重复导入
+// ============================================================================
+
+const (
+	// ContentImportTaskType 内容导入任务的 asynq 任务类型。
+	ContentImportTaskType = "content.import"
+)
+
+// ContentImportPayload 内容导入任务的 payload。
+type ContentImportPayload struct {
+	JobID uint32 `json:"job_id"`
+}
@@ -0,0 +1,25 @@
+package task
+
+// ============================================================================
+// 表单提交通知任务类型定义
+//
+// 访客提交表单后入队一个 form.notify 任务，asynq worker 收到后向表单配置的
+// 接收人写入站内信，并向配置的 Webhook 地址投递签名的 JSON 回调。
+//
+// 安全：
+//   - payload 只含提交 id，表单配置与提交内容由 worker 从 DB 取（带 SystemViewer 跨租户读）
+//   - 同一提交只通知一次（条件更新 notified_at），重复入队不会重复通知
+// ============================================================================
+
+const (
+	// FormNotifyTaskType 表单提交通知任务的 asynq 任务类型。
+	FormNotifyTaskType = "form.notify"
+)
+
+// FormNotifyPayload 表单提交通知任务的 payload。
+//
+// TenantID 仅供日志展示，worker 以 DB 记录的 tenant_id 为准。
+type FormNotifyPayload struct {
+	SubmissionID uint32 `json:"submission_id"`
+	TenantID     uint32 `json:"tenant_id"`
+}
@@ -0,0 +1,17 @@
+package task
+
+// ============================================================================
+// 发布集定时发布任务类型定义
+//
+// 发布集经 ScheduleRelease 设置定时后，由 release.publish 任务在到期时整体发布。
+// 该任务为周期任务，由后台任务管理以 type_name=release.publish 配置 cron 表达式启用
+// （建议每分钟一次）；定时精度取决于该周期。
+// ============================================================================
+
+const (
+	// ReleasePublishTaskType 发布集定时发布任务的 asynq 任务类型。
+	ReleasePublishTaskType = "release.publish"
+)
+
+// ReleasePublishTaskData 发布集定时发布任务的 payload，目前无参数。
+type ReleasePublishTaskData struct{}
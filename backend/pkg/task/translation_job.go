@@ -0,0 +1,24 @@
+package task
+
+// ============================================================================
+// 批量机器翻译任务类型定义
+//
+// 编辑在后台为文章、页面、分类或标签创建翻译作业后入队一个 translation.job 任务，
+// asynq worker 收到后逐个目标语言翻译源语言版本的标题、摘要、正文与 SEO 字段，
+// 写入标记为机器翻译的草稿翻译，并在作业上记录进度，供编辑审阅后发布。
+//
+// 安全：
+//   - payload 只含作业 id，内容与目标语言由 worker 从 DB 取（带 SystemViewer 跨租户读），
+//     写入翻译的 tenant_id 取自作业记录，非 payload
+//   - 作业以条件更新从 pending 认领，重复入队不会重复执行
+// ============================================================================
+
+const (
+	// TranslationJobTaskType 批量机器翻译任务的 asynq 任务类型。
+	TranslationJobTaskType = "translation.job"
+)
+
+// TranslationJobPayload 批量机器翻译任务的 payload。
+type TranslationJobPayload struct {
+	JobID uint32 `json:"job_id"`
+}
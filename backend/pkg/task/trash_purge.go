@@ -0,0 +1,17 @@
+package task
+
+// ============================================================================
+// 回收站清理任务类型定义
+//
+// 内容删除后先进入回收站，超过租户保留期（trash.retention_days /
+// trash.tenant_retention_days）的条目由 trash.purge 任务彻底删除。
+// 该任务为周期任务，由后台任务管理以 type_name=trash.purge 配置 cron 表达式启用。
+// ============================================================================
+
+const (
+	// TrashPurgeTaskType 回收站到期清理任务的 asynq 任务类型。
+	TrashPurgeTaskType = "trash.purge"
+)
+
+// TrashPurgeTaskData 回收站清理任务的 payload，目前无参数。
+type TrashPurgeTaskData struct{}